@@ -15,7 +15,9 @@
 package a2a
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 // TestInterfaceGuards calls the private methods that are used to enforce interface implementations.
@@ -228,3 +230,48 @@ func TestNewArtifactUpdateEvent_EmptyTask(t *testing.T) {
 	}()
 	_ = NewArtifactUpdateEvent(Task{}, "artifact-1", TextPart{Text: "update part"})
 }
+
+func TestEffectivePageSize(t *testing.T) {
+	tests := []struct {
+		requested int
+		want      int
+	}{
+		{requested: 0, want: DefaultPageSize},
+		{requested: 7, want: 7},
+		{requested: MaxPageSize + 1, want: MaxPageSize},
+	}
+	for _, tt := range tests {
+		if got, err := EffectivePageSize(tt.requested); err != nil || got != tt.want {
+			t.Errorf("EffectivePageSize(%d) = %d, %v, want %d", tt.requested, got, err, tt.want)
+		}
+	}
+	if _, err := EffectivePageSize(-1); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("EffectivePageSize(-1) error = %v, want %v", err, ErrInvalidRequest)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	tests := []struct {
+		timeout time.Duration
+		want    string
+	}{
+		{timeout: 1500 * time.Millisecond, want: "1500"},
+		{timeout: time.Microsecond, want: "1"},
+		{timeout: -time.Second, want: "0"},
+	}
+	for _, tt := range tests {
+		got := FormatRequestTimeout(tt.timeout)
+		if got != tt.want {
+			t.Errorf("FormatRequestTimeout(%v) = %q, want %q", tt.timeout, got, tt.want)
+		}
+		if _, err := ParseRequestTimeout(got); err != nil {
+			t.Errorf("ParseRequestTimeout(%q) error = %v", got, err)
+		}
+	}
+
+	for _, value := range []string{"", "1s", "-5", "99999999999999999999"} {
+		if _, err := ParseRequestTimeout(value); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("ParseRequestTimeout(%q) error = %v, want %v", value, err, ErrInvalidRequest)
+		}
+	}
+}
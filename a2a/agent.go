@@ -14,6 +14,8 @@
 
 package a2a
 
+import "encoding/json"
+
 // AgentCapabilities define optional capabilities supported by an agent.
 type AgentCapabilities struct {
 	// Extensions is a list of protocol extensions supported by the agent.
@@ -132,6 +134,27 @@ type AgentCard struct {
 
 	// Version is the agent's own version number. The format is defined by the provider.
 	Version string `json:"version" yaml:"version" mapstructure:"version"`
+
+	// Extra holds the JSON fields which are not modeled by the type. They are written back when
+	// the value is marshaled. See DecodeMode.
+	Extra map[string]json.RawMessage `json:"-" yaml:"-" mapstructure:"-"`
+}
+
+func (c AgentCard) MarshalJSON() ([]byte, error) {
+	type plain AgentCard
+	return marshalWithExtra(plain(c), c.Extra)
+}
+
+func (c *AgentCard) UnmarshalJSON(b []byte) error {
+	type plain AgentCard
+	var decoded plain
+	extra, err := unmarshalWithExtra(b, &decoded)
+	if err != nil {
+		return err
+	}
+	*c = AgentCard(decoded)
+	c.Extra = extra
+	return nil
 }
 
 // AgentCardSignature represents a JWS signature of an AgentCard.
@@ -202,6 +225,10 @@ type AgentSkill struct {
 	// defaults.
 	InputModes []string `json:"inputModes,omitempty" yaml:"inputModes,omitempty" mapstructure:"inputModes,omitempty"`
 
+	// InputSchema is an optional JSON Schema the data of DataParts sent to the skill must conform to.
+	// See AgentSkill.ValidateInput for the supported keywords.
+	InputSchema map[string]any `json:"inputSchema,omitempty" yaml:"inputSchema,omitempty" mapstructure:"inputSchema,omitempty"`
+
 	// Name is a human-readable name for the skill.
 	Name string `json:"name" yaml:"name" mapstructure:"name"`
 
@@ -0,0 +1,70 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+// AuthRequirementsMetaKey is the status Message metadata key under which an agent lists the security
+// requirements a client needs to satisfy for a Task in TaskStateAuthRequired to continue.
+const AuthRequirementsMetaKey = "authRequirements"
+
+// AuthRequirement describes a credential an agent needs for continuing a Task.
+type AuthRequirement struct {
+	// Scheme is the name of a security scheme declared in AgentCard.SecuritySchemes.
+	Scheme SecuritySchemeName
+	// Scopes the credential must be covering.
+	Scopes []string
+}
+
+// AuthRequirementsToMeta converts the requirements to a metadata value which can be stored under AuthRequirementsMetaKey.
+func AuthRequirementsToMeta(reqs ...AuthRequirement) []any {
+	result := make([]any, len(reqs))
+	for i, r := range reqs {
+		scopes := make([]any, len(r.Scopes))
+		for j, s := range r.Scopes {
+			scopes[j] = s
+		}
+		result[i] = map[string]any{"scheme": string(r.Scheme), "scopes": scopes}
+	}
+	return result
+}
+
+// AuthRequirementsFromMeta extracts requirements stored under AuthRequirementsMetaKey.
+// Malformed entries are skipped.
+func AuthRequirementsFromMeta(meta map[string]any) []AuthRequirement {
+	entries, ok := meta[AuthRequirementsMetaKey].([]any)
+	if !ok {
+		return nil
+	}
+
+	var result []AuthRequirement
+	for _, e := range entries {
+		entry, ok := e.(map[string]any)
+		if !ok {
+			continue
+		}
+		scheme, ok := entry["scheme"].(string)
+		if !ok || scheme == "" {
+			continue
+		}
+		req := AuthRequirement{Scheme: SecuritySchemeName(scheme)}
+		scopes, _ := entry["scopes"].([]any)
+		for _, s := range scopes {
+			if scope, ok := s.(string); ok {
+				req.Scopes = append(req.Scopes, scope)
+			}
+		}
+		result = append(result, req)
+	}
+	return result
+}
@@ -83,6 +83,27 @@ type Message struct {
 	// first message of a new task.
 	// An empty string means the message doesn't reference any Task.
 	TaskID TaskID `json:"taskId,omitempty" yaml:"taskId,omitempty" mapstructure:"taskId,omitempty"`
+
+	// Extra holds the JSON fields which are not modeled by the type. They are written back when
+	// the value is marshaled. See DecodeMode.
+	Extra map[string]json.RawMessage `json:"-" yaml:"-" mapstructure:"-"`
+}
+
+func (m Message) MarshalJSON() ([]byte, error) {
+	type plain Message
+	return marshalWithExtra(plain(m), m.Extra)
+}
+
+func (m *Message) UnmarshalJSON(b []byte) error {
+	type plain Message
+	var decoded plain
+	extra, err := unmarshalWithExtra(b, &decoded)
+	if err != nil {
+		return err
+	}
+	*m = Message(decoded)
+	m.Extra = extra
+	return nil
 }
 
 // NewMessage creates a new message with a random identifier.
@@ -162,6 +183,27 @@ type Task struct {
 
 	// Status is the current status of the task, including its state and a descriptive message.
 	Status TaskStatus `json:"status" yaml:"status" mapstructure:"status"`
+
+	// Extra holds the JSON fields which are not modeled by the type. They are written back when
+	// the value is marshaled. See DecodeMode.
+	Extra map[string]json.RawMessage `json:"-" yaml:"-" mapstructure:"-"`
+}
+
+func (t Task) MarshalJSON() ([]byte, error) {
+	type plain Task
+	return marshalWithExtra(plain(t), t.Extra)
+}
+
+func (t *Task) UnmarshalJSON(b []byte) error {
+	type plain Task
+	var decoded plain
+	extra, err := unmarshalWithExtra(b, &decoded)
+	if err != nil {
+		return err
+	}
+	*t = Task(decoded)
+	t.Extra = extra
+	return nil
 }
 
 // TaskStatus represents the status of a task at a specific point in time.
@@ -226,6 +268,27 @@ type TaskArtifactUpdateEvent struct {
 
 	// Metadata is an optional metadata for extensions.
 	Metadata map[string]any `json:"metadata,omitempty" yaml:"metadata,omitempty" mapstructure:"metadata,omitempty"`
+
+	// Extra holds the JSON fields which are not modeled by the type. They are written back when
+	// the value is marshaled. See DecodeMode.
+	Extra map[string]json.RawMessage `json:"-" yaml:"-" mapstructure:"-"`
+}
+
+func (e TaskArtifactUpdateEvent) MarshalJSON() ([]byte, error) {
+	type plain TaskArtifactUpdateEvent
+	return marshalWithExtra(plain(e), e.Extra)
+}
+
+func (e *TaskArtifactUpdateEvent) UnmarshalJSON(b []byte) error {
+	type plain TaskArtifactUpdateEvent
+	var decoded plain
+	extra, err := unmarshalWithExtra(b, &decoded)
+	if err != nil {
+		return err
+	}
+	*e = TaskArtifactUpdateEvent(decoded)
+	e.Extra = extra
+	return nil
 }
 
 // NewArtifactEvent create a TaskArtifactUpdateEvent for an Artifact with a random ID.
@@ -270,6 +333,27 @@ type TaskStatusUpdateEvent struct {
 
 	// Metadata is an optional metadata for extensions.
 	Metadata map[string]any `json:"metadata,omitempty" yaml:"metadata,omitempty" mapstructure:"metadata,omitempty"`
+
+	// Extra holds the JSON fields which are not modeled by the type. They are written back when
+	// the value is marshaled. See DecodeMode.
+	Extra map[string]json.RawMessage `json:"-" yaml:"-" mapstructure:"-"`
+}
+
+func (e TaskStatusUpdateEvent) MarshalJSON() ([]byte, error) {
+	type plain TaskStatusUpdateEvent
+	return marshalWithExtra(plain(e), e.Extra)
+}
+
+func (e *TaskStatusUpdateEvent) UnmarshalJSON(b []byte) error {
+	type plain TaskStatusUpdateEvent
+	var decoded plain
+	extra, err := unmarshalWithExtra(b, &decoded)
+	if err != nil {
+		return err
+	}
+	*e = TaskStatusUpdateEvent(decoded)
+	e.Extra = extra
+	return nil
 }
 
 // NewStatusUpdateEvent creates a TaskStatusUpdateEvent that references the provided Task.
@@ -289,6 +373,25 @@ func NewStatusUpdateEvent(task *Task, state TaskState, msg *Message) *TaskStatus
 // ContentParts is an array of content parts that form the message body or an artifact.
 type ContentParts []Part
 
+func (j ContentParts) MarshalJSON() ([]byte, error) {
+	if j == nil {
+		return []byte("null"), nil
+	}
+	result := make([]json.RawMessage, len(j))
+	for i, part := range j {
+		var err error
+		if custom, ok := part.(CustomPart); ok {
+			result[i], err = marshalCustomPart(custom)
+		} else {
+			result[i], err = json.Marshal(part)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(result)
+}
+
 func (j *ContentParts) UnmarshalJSON(b []byte) error {
 	type typedPart struct {
 		Kind string `json:"kind"`
@@ -306,26 +409,30 @@ func (j *ContentParts) UnmarshalJSON(b []byte) error {
 			return err
 		}
 		switch tp.Kind {
-		case "text":
+		case PartKindText:
 			var part TextPart
 			if err := json.Unmarshal(rawMsg, &part); err != nil {
 				return err
 			}
 			result[i] = part
-		case "data":
+		case PartKindData:
 			var part DataPart
 			if err := json.Unmarshal(rawMsg, &part); err != nil {
 				return err
 			}
 			result[i] = part
-		case "file":
+		case PartKindFile:
 			var part FilePart
 			if err := json.Unmarshal(rawMsg, &part); err != nil {
 				return err
 			}
 			result[i] = part
 		default:
-			return fmt.Errorf("unknown part kind %s", tp.Kind)
+			part, err := unmarshalCustomPart(tp.Kind, rawMsg)
+			if err != nil {
+				return err
+			}
+			result[i] = part
 		}
 	}
 
@@ -370,7 +477,7 @@ func (p TextPart) MarshalJSON() ([]byte, error) {
 		Kind string `json:"kind"`
 		wrapped
 	}
-	return json.Marshal(withKind{Kind: "text", wrapped: wrapped(p)})
+	return json.Marshal(withKind{Kind: PartKindText, wrapped: wrapped(p)})
 }
 
 // DataPart represents a structured data segment (e.g., JSON) within a message or artifact.
@@ -392,7 +499,7 @@ func (p DataPart) MarshalJSON() ([]byte, error) {
 		Kind string `json:"kind"`
 		wrapped
 	}
-	return json.Marshal(withKind{Kind: "data", wrapped: wrapped(p)})
+	return json.Marshal(withKind{Kind: PartKindData, wrapped: wrapped(p)})
 }
 
 // FilePart represents a file segment within a message or artifact. The file content can be
@@ -415,7 +522,7 @@ func (p FilePart) MarshalJSON() ([]byte, error) {
 		Kind string `json:"kind"`
 		wrapped
 	}
-	return json.Marshal(withKind{Kind: "file", wrapped: wrapped(p)})
+	return json.Marshal(withKind{Kind: PartKindFile, wrapped: wrapped(p)})
 }
 
 func (p *FilePart) UnmarshalJSON(b []byte) error {
@@ -0,0 +1,45 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import "maps"
+
+// DryRunMetaKey is the MessageSendParams metadata key clients set to true for requesting a dry run
+// of 'message/send'. The agent validates the request as usual but doesn't process the message and returns
+// the Task which would be created. The returned Task has the key set to true in its metadata.
+const DryRunMetaKey = "dryRun"
+
+// WithDryRun returns a copy of params which requests a dry run. The metadata map is copied, so params
+// is not modified.
+func WithDryRun(params MessageSendParams) MessageSendParams {
+	params.Metadata = maps.Clone(params.Metadata)
+	if params.Metadata == nil {
+		params.Metadata = map[string]any{}
+	}
+	params.Metadata[DryRunMetaKey] = true
+	return params
+}
+
+// IsDryRun reports whether params request a dry run.
+func IsDryRun(params *MessageSendParams) bool {
+	dryRun, _ := params.Metadata[DryRunMetaKey].(bool)
+	return dryRun
+}
+
+// IsDryRunResult reports whether the Task was returned for a dry run request.
+func IsDryRunResult(task *Task) bool {
+	dryRun, _ := task.Metadata[DryRunMetaKey].(bool)
+	return dryRun
+}
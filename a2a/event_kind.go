@@ -0,0 +1,151 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Kinds of the Event types defined by the protocol. They are used as the "kind" discriminator in JSON.
+const (
+	EventKindMessage        = "message"
+	EventKindTask           = "task"
+	EventKindStatusUpdate   = "status-update"
+	EventKindArtifactUpdate = "artifact-update"
+)
+
+// ErrUnknownEventKind is returned by UnmarshalEvent for events of a kind which is neither
+// defined by the protocol nor registered with RegisterEventKind.
+var ErrUnknownEventKind = errors.New("unknown event kind")
+
+// CustomEvent is an Event type defined by an extension. Types implement Event by embedding CustomEventBase.
+// The JSON encoding of a CustomEvent must be an object and must not contain a "kind" field,
+// which is added by MarshalEvent.
+type CustomEvent interface {
+	Event
+	// EventKind returns the discriminator the event is registered with using RegisterEventKind.
+	EventKind() string
+}
+
+// CustomEventBase can be embedded in a struct to make it implement Event.
+type CustomEventBase struct{}
+
+func (CustomEventBase) isEvent() { _ = 0 }
+
+// EventDecoder decodes the JSON of a custom event. The payload includes the "kind" field.
+type EventDecoder func(data []byte) (CustomEvent, error)
+
+var eventKinds = struct {
+	sync.RWMutex
+	decoders map[string]EventDecoder
+}{decoders: make(map[string]EventDecoder)}
+
+// RegisterEventKind makes UnmarshalEvent decode events of the kind using decode, so that extension-defined
+// events survive JSON round trips through clients and servers. Returns an error if the kind is defined
+// by the protocol or already registered.
+func RegisterEventKind(kind string, decode EventDecoder) error {
+	if kind == "" || decode == nil {
+		return fmt.Errorf("event kind and decoder must be provided")
+	}
+	if isProtocolEventKind(kind) {
+		return fmt.Errorf("event kind %q is defined by the protocol", kind)
+	}
+	eventKinds.Lock()
+	defer eventKinds.Unlock()
+	if _, ok := eventKinds.decoders[kind]; ok {
+		return fmt.Errorf("event kind %q is already registered", kind)
+	}
+	eventKinds.decoders[kind] = decode
+	return nil
+}
+
+// EventKindOf returns the "kind" discriminator of the event or an empty string for unknown event types.
+func EventKindOf(event Event) string {
+	switch v := event.(type) {
+	case *Message:
+		return EventKindMessage
+	case *Task:
+		return EventKindTask
+	case *TaskStatusUpdateEvent:
+		return EventKindStatusUpdate
+	case *TaskArtifactUpdateEvent:
+		return EventKindArtifactUpdate
+	case CustomEvent:
+		return v.EventKind()
+	default:
+		return ""
+	}
+}
+
+// MarshalEvent encodes the event as a JSON object with the "kind" discriminator.
+func MarshalEvent(event Event) ([]byte, error) {
+	kind := EventKindOf(event)
+	if kind == "" {
+		return nil, fmt.Errorf("%w: %T", ErrUnknownEventKind, event)
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return withKindField(kind, data)
+}
+
+// UnmarshalEvent decodes an event encoded with MarshalEvent. Custom events are decoded using
+// the decoder registered for their kind. Returns an error wrapping ErrUnknownEventKind if the kind
+// is not known.
+func UnmarshalEvent(data []byte) (Event, error) {
+	var typed struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+
+	var event Event
+	switch typed.Kind {
+	case EventKindMessage:
+		event = &Message{}
+	case EventKindTask:
+		event = &Task{}
+	case EventKindStatusUpdate:
+		event = &TaskStatusUpdateEvent{}
+	case EventKindArtifactUpdate:
+		event = &TaskArtifactUpdateEvent{}
+	default:
+		eventKinds.RLock()
+		decode, ok := eventKinds.decoders[typed.Kind]
+		eventKinds.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownEventKind, typed.Kind)
+		}
+		return decode(data)
+	}
+	if err := json.Unmarshal(data, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func isProtocolEventKind(kind string) bool {
+	switch kind {
+	case EventKindMessage, EventKindTask, EventKindStatusUpdate, EventKindArtifactUpdate:
+		return true
+	default:
+		return false
+	}
+}
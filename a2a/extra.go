@@ -0,0 +1,196 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DecodeMode controls how Unmarshal treats JSON fields which are not modeled by the a2a types.
+type DecodeMode int
+
+const (
+	// DecodeLenient captures unknown fields of Message, Task, AgentCard and events into their Extra field,
+	// which is written back when the value is marshaled. Useful for proxies which must not lose data
+	// sent by newer peers. This is also how json.Unmarshal decodes these types.
+	DecodeLenient DecodeMode = iota
+	// DecodeStrict rejects payloads containing unknown fields. Useful for conformance testing.
+	DecodeStrict
+)
+
+// ErrUnknownFields is returned by Unmarshal in DecodeStrict mode when the payload contains unknown fields.
+var ErrUnknownFields = errors.New("unknown fields")
+
+// Unmarshal decodes the JSON payload into v using the mode.
+func Unmarshal(data []byte, v any, mode DecodeMode) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	if mode != DecodeStrict {
+		return nil
+	}
+	if unknown := UnknownFields(v); len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownFields, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// UnknownFields returns the paths of the JSON fields captured in the Extra fields of v and the values it references,
+// eg. "$.history[0].newField".
+func UnknownFields(v any) []string {
+	var unknown []string
+	collectExtra(reflect.ValueOf(v), "$", &unknown)
+	return unknown
+}
+
+var extraType = reflect.TypeFor[map[string]json.RawMessage]()
+
+// collectExtra finds unknown fields captured in Extra fields of v and its descendants.
+func collectExtra(v reflect.Value, path string, unknown *[]string) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			collectExtra(v.Elem(), path, unknown)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			collectExtra(v.Index(i), fmt.Sprintf("%s[%d]", path, i), unknown)
+		}
+	case reflect.Map:
+		if v.Type() == extraType {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			collectExtra(iter.Value(), fmt.Sprintf("%s.%v", path, iter.Key()), unknown)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Name == "Extra" && field.Type == extraType {
+				keys := make([]string, 0, v.Field(i).Len())
+				for _, key := range v.Field(i).MapKeys() {
+					keys = append(keys, path+"."+key.String())
+				}
+				slices.Sort(keys)
+				*unknown = append(*unknown, keys...)
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			collectExtra(v.Field(i), path+"."+name, unknown)
+		}
+	}
+}
+
+// knownFieldsCache maps struct types to the set of JSON field names they model.
+var knownFieldsCache sync.Map
+
+func knownFields(t reflect.Type) map[string]bool {
+	if cached, ok := knownFieldsCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+	// the discriminator is sent by peers for all the polymorphic types
+	known := map[string]bool{"kind": true}
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[name] = true
+	}
+	knownFieldsCache.Store(t, known)
+	return known
+}
+
+// unmarshalWithExtra decodes data into plain, which is a pointer to a struct type without JSON methods,
+// and returns the fields it doesn't model.
+func unmarshalWithExtra(data []byte, plain any) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, plain); err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	known := knownFields(reflect.TypeOf(plain).Elem())
+	var extra map[string]json.RawMessage
+	for name, value := range fields {
+		if known[name] {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[name] = value
+	}
+	return extra, nil
+}
+
+// marshalWithExtra encodes plain, which is a struct type without JSON methods, and appends
+// the extra fields it doesn't model.
+func marshalWithExtra(plain any, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(plain)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	known := knownFields(reflect.TypeOf(plain))
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		if !known[name] {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	empty := len(data) == 2
+	for _, name := range names {
+		if !empty {
+			buf.WriteByte(',')
+		}
+		empty = false
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(extra[name])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// InterfaceProblem is a violation of the rules for declaring AgentCard interfaces found by CheckInterfaces.
+type InterfaceProblem struct {
+	// Field is a JSON path to the problematic AgentCard field, eg. "additionalInterfaces[1]".
+	Field string
+	// Message describes the problem.
+	Message string
+	// Invalid is true if the problem prevents clients from connecting to the agent, and false if
+	// the card doesn't follow a best practice.
+	Invalid bool
+	// Fixable is true if NormalizeInterfaces resolves the problem.
+	Fixable bool
+}
+
+func (p InterfaceProblem) String() string {
+	return fmt.Sprintf("%s: %s", p.Field, p.Message)
+}
+
+var knownTransports = []TransportProtocol{TransportProtocolJSONRPC, TransportProtocolGRPC, TransportProtocolHTTPJSON}
+
+// canonicalTransport returns the protocol-defined spelling of a known transport or the transport
+// as is if it's unknown.
+func canonicalTransport(transport TransportProtocol) TransportProtocol {
+	for _, known := range knownTransports {
+		if strings.EqualFold(string(known), string(transport)) {
+			return known
+		}
+	}
+	return transport
+}
+
+// preferredTransport returns the transport available at the main card URL.
+func (c *AgentCard) preferredTransport() TransportProtocol {
+	if c.PreferredTransport == "" {
+		return TransportProtocolJSONRPC
+	}
+	return c.PreferredTransport
+}
+
+// CheckInterfaces checks URL, PreferredTransport and AdditionalInterfaces of the card for consistency:
+//   - known transports are spelled the way the protocol defines them, as clients compare them exactly;
+//   - JSONRPC and HTTP+JSON interfaces are available at absolute http(s) URLs;
+//   - every transport and URL combination is declared once;
+//   - AdditionalInterfaces include an entry matching URL and PreferredTransport.
+//
+// Empty fields are not reported. Returns nil if no problems were found.
+func CheckInterfaces(card *AgentCard) []InterfaceProblem {
+	var problems []InterfaceProblem
+	report := func(field string, invalid, fixable bool, format string, args ...any) {
+		problems = append(problems, InterfaceProblem{Field: field, Message: fmt.Sprintf(format, args...), Invalid: invalid, Fixable: fixable})
+	}
+	checkTransport := func(transportField, urlField string, transport TransportProtocol, rawURL string) {
+		canonical := canonicalTransport(transport)
+		if canonical != transport {
+			report(transportField, true, true, "transport %q should be spelled %q", transport, canonical)
+		}
+		if rawURL == "" || (canonical != TransportProtocolJSONRPC && canonical != TransportProtocolHTTPJSON) {
+			return
+		}
+		if u, err := url.Parse(rawURL); err != nil || !u.IsAbs() || (u.Scheme != "http" && u.Scheme != "https") {
+			report(urlField, true, false, "%s transport requires an absolute http(s) URL, got %q", canonical, rawURL)
+		}
+	}
+
+	checkTransport("preferredTransport", "url", card.preferredTransport(), card.URL)
+
+	seen := make(map[AgentInterface]int)
+	for i, iface := range card.AdditionalInterfaces {
+		field := fmt.Sprintf("additionalInterfaces[%d]", i)
+		if iface.Transport != "" {
+			checkTransport(field+".transport", field+".url", TransportProtocol(iface.Transport), iface.URL)
+		}
+		key := AgentInterface{Transport: string(canonicalTransport(TransportProtocol(iface.Transport))), URL: iface.URL}
+		if prev, ok := seen[key]; ok {
+			report(field, false, true, "duplicates additionalInterfaces[%d]", prev)
+			continue
+		}
+		seen[key] = i
+	}
+
+	if len(card.AdditionalInterfaces) > 0 && card.URL != "" && !declaresMainInterface(card) {
+		report("additionalInterfaces", false, true, "should include an entry matching url and preferredTransport")
+	}
+	return problems
+}
+
+func declaresMainInterface(card *AgentCard) bool {
+	preferred := canonicalTransport(card.preferredTransport())
+	return slices.ContainsFunc(card.AdditionalInterfaces, func(iface AgentInterface) bool {
+		return canonicalTransport(TransportProtocol(iface.Transport)) == preferred && iface.URL == card.URL
+	})
+}
+
+// NormalizeInterfaces returns a copy of the card with the fixable problems reported by CheckInterfaces resolved:
+// known transports are spelled the way the protocol defines them, duplicate AdditionalInterfaces entries are
+// removed and an entry matching URL and PreferredTransport is added as the first one if it's missing.
+// The card is not modified.
+func NormalizeInterfaces(card *AgentCard) *AgentCard {
+	normalized := *card
+	if card.PreferredTransport != "" {
+		normalized.PreferredTransport = canonicalTransport(card.PreferredTransport)
+	}
+	if len(card.AdditionalInterfaces) == 0 {
+		return &normalized
+	}
+
+	normalized.AdditionalInterfaces = make([]AgentInterface, 0, len(card.AdditionalInterfaces)+1)
+	if card.URL != "" && !declaresMainInterface(card) {
+		main := AgentInterface{Transport: string(normalized.preferredTransport()), URL: card.URL}
+		normalized.AdditionalInterfaces = append(normalized.AdditionalInterfaces, main)
+	}
+	for _, iface := range card.AdditionalInterfaces {
+		iface.Transport = string(canonicalTransport(TransportProtocol(iface.Transport)))
+		if !slices.Contains(normalized.AdditionalInterfaces, iface) {
+			normalized.AdditionalInterfaces = append(normalized.AdditionalInterfaces, iface)
+		}
+	}
+	return &normalized
+}
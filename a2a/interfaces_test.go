@@ -0,0 +1,106 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckInterfaces(t *testing.T) {
+	testCases := []struct {
+		name string
+		card AgentCard
+		want []InterfaceProblem
+	}{
+		{
+			name: "consistent",
+			card: AgentCard{URL: "https://agent.com/rpc", PreferredTransport: TransportProtocolJSONRPC, AdditionalInterfaces: []AgentInterface{
+				{Transport: "JSONRPC", URL: "https://agent.com/rpc"},
+				{Transport: "GRPC", URL: "agent.com:443"},
+			}},
+		},
+		{
+			name: "default preferred transport without additional interfaces",
+			card: AgentCard{URL: "https://agent.com/rpc"},
+		},
+		{
+			name: "misspelled transport",
+			card: AgentCard{URL: "agent.com:443", PreferredTransport: "grpc"},
+			want: []InterfaceProblem{
+				{Field: "preferredTransport", Message: `transport "grpc" should be spelled "GRPC"`, Invalid: true, Fixable: true},
+			},
+		},
+		{
+			name: "transport not available at URL",
+			card: AgentCard{URL: "agent.com:443", AdditionalInterfaces: []AgentInterface{
+				{Transport: "HTTP+JSON", URL: "/v1"},
+			}},
+			want: []InterfaceProblem{
+				{Field: "url", Message: `JSONRPC transport requires an absolute http(s) URL, got "agent.com:443"`, Invalid: true},
+				{Field: "additionalInterfaces[0].url", Message: `HTTP+JSON transport requires an absolute http(s) URL, got "/v1"`, Invalid: true},
+				{Field: "additionalInterfaces", Message: "should include an entry matching url and preferredTransport", Fixable: true},
+			},
+		},
+		{
+			name: "duplicates",
+			card: AgentCard{URL: "https://agent.com", AdditionalInterfaces: []AgentInterface{
+				{Transport: "JSONRPC", URL: "https://agent.com"},
+				{Transport: "jsonrpc", URL: "https://agent.com"},
+			}},
+			want: []InterfaceProblem{
+				{Field: "additionalInterfaces[1].transport", Message: `transport "jsonrpc" should be spelled "JSONRPC"`, Invalid: true, Fixable: true},
+				{Field: "additionalInterfaces[1]", Message: "duplicates additionalInterfaces[0]", Fixable: true},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CheckInterfaces(&tc.card); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("CheckInterfaces() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeInterfaces(t *testing.T) {
+	card := &AgentCard{
+		URL:                "https://agent.com",
+		PreferredTransport: "http+json",
+		AdditionalInterfaces: []AgentInterface{
+			{Transport: "grpc", URL: "agent.com:443"},
+			{Transport: "GRPC", URL: "agent.com:443"},
+			{Transport: "custom", URL: "wss://agent.com"},
+		},
+	}
+	original := *card
+	original.AdditionalInterfaces = append([]AgentInterface(nil), card.AdditionalInterfaces...)
+
+	got := NormalizeInterfaces(card)
+	want := []AgentInterface{
+		{Transport: "HTTP+JSON", URL: "https://agent.com"},
+		{Transport: "GRPC", URL: "agent.com:443"},
+		{Transport: "custom", URL: "wss://agent.com"},
+	}
+	if got.PreferredTransport != TransportProtocolHTTPJSON || !reflect.DeepEqual(got.AdditionalInterfaces, want) {
+		t.Fatalf("NormalizeInterfaces() = %s %v, want HTTP+JSON %v", got.PreferredTransport, got.AdditionalInterfaces, want)
+	}
+	if problems := CheckInterfaces(got); len(problems) != 0 {
+		t.Fatalf("CheckInterfaces() of normalized card = %v, want none", problems)
+	}
+	if !reflect.DeepEqual(*card, original) {
+		t.Fatalf("NormalizeInterfaces() modified the card: %+v", card)
+	}
+}
@@ -16,10 +16,12 @@ package a2a
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func mustMarshal(t *testing.T, data any) string {
@@ -217,3 +219,263 @@ func TestSecuritySchemeJSONCodec(t *testing.T) {
 		t.Fatalf("Decoding back failed:\nwant %v\ngot: %s", decodedJSON, decodedBack)
 	}
 }
+
+type quotaEvent struct {
+	CustomEventBase
+	TaskID    TaskID `json:"taskId"`
+	Remaining int    `json:"remaining"`
+}
+
+func (quotaEvent) EventKind() string { return "x-quota" }
+
+func TestMarshalEvent_RoundTrip(t *testing.T) {
+	if err := RegisterEventKind("x-quota", func(data []byte) (CustomEvent, error) {
+		var event quotaEvent
+		err := json.Unmarshal(data, &event)
+		return &event, err
+	}); err != nil {
+		t.Fatalf("RegisterEventKind() error = %v", err)
+	}
+
+	events := []Event{
+		&Message{ID: "msg", Role: MessageRoleUser, Parts: ContentParts{TextPart{Text: "hi"}}},
+		&Task{ID: "task", ContextID: "ctx"},
+		&TaskStatusUpdateEvent{TaskID: "task", Status: TaskStatus{State: TaskStateWorking}},
+		&TaskArtifactUpdateEvent{TaskID: "task", Artifact: &Artifact{ID: "artifact", Parts: ContentParts{DataPart{Data: map[string]any{"n": 1.0}}}}},
+		&quotaEvent{TaskID: "task", Remaining: 3},
+	}
+	for _, event := range events {
+		data, err := MarshalEvent(event)
+		if err != nil {
+			t.Fatalf("MarshalEvent(%T) error = %v", event, err)
+		}
+		got, err := UnmarshalEvent(data)
+		if err != nil {
+			t.Fatalf("UnmarshalEvent(%s) error = %v", data, err)
+		}
+		if !reflect.DeepEqual(got, event) {
+			t.Fatalf("UnmarshalEvent(%s) = %+v, want %+v", data, got, event)
+		}
+	}
+}
+
+func TestMarshalEvent_Kind(t *testing.T) {
+	data, err := MarshalEvent(&Task{ID: "task"})
+	if err != nil {
+		t.Fatalf("MarshalEvent() error = %v", err)
+	}
+	var typed struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil || typed.Kind != EventKindTask {
+		t.Fatalf("MarshalEvent() = %s, want kind %q", data, EventKindTask)
+	}
+}
+
+func TestUnmarshalEvent_UnknownKind(t *testing.T) {
+	if _, err := UnmarshalEvent([]byte(`{"kind":"x-unregistered"}`)); !errors.Is(err, ErrUnknownEventKind) {
+		t.Fatalf("UnmarshalEvent() error = %v, want %v", err, ErrUnknownEventKind)
+	}
+}
+
+func TestRegisterEventKind_Conflicts(t *testing.T) {
+	decode := func(data []byte) (CustomEvent, error) { return &quotaEvent{}, nil }
+	if err := RegisterEventKind(EventKindTask, decode); err == nil {
+		t.Error("RegisterEventKind() with a protocol kind succeeded, want an error")
+	}
+	if err := RegisterEventKind("x-conflict", decode); err != nil {
+		t.Fatalf("RegisterEventKind() error = %v", err)
+	}
+	if err := RegisterEventKind("x-conflict", decode); err == nil {
+		t.Error("RegisterEventKind() with a registered kind succeeded, want an error")
+	}
+}
+
+type geoPart struct {
+	CustomPartBase
+	Lat      float64        `json:"lat"`
+	Lng      float64        `json:"lng"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+func (geoPart) PartKind() string { return "x-geo" }
+
+func (p geoPart) Meta() map[string]any { return p.Metadata }
+
+func registerGeoPart(t *testing.T) {
+	t.Helper()
+	err := RegisterPartKind(geoPart{}, PartCodec{
+		Decode: func(data []byte) (CustomPart, error) {
+			var part geoPart
+			err := json.Unmarshal(data, &part)
+			return part, err
+		},
+		Validate: func(part CustomPart) error {
+			if p := part.(geoPart); p.Lat < -90 || p.Lat > 90 {
+				return fmt.Errorf("invalid latitude %v", p.Lat)
+			}
+			return nil
+		},
+	})
+	if err != nil && !strings.Contains(err.Error(), "already registered") {
+		t.Fatalf("RegisterPartKind() error = %v", err)
+	}
+}
+
+func TestContentParts_CustomPart(t *testing.T) {
+	registerGeoPart(t)
+
+	parts := ContentParts{TextPart{Text: "here"}, geoPart{Lat: 52.1, Lng: 4.3}}
+	data, err := json.Marshal(parts)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `[{"kind":"text","text":"here"},{"kind":"x-geo","lat":52.1,"lng":4.3}]`
+	if string(data) != want {
+		t.Fatalf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	var decoded ContentParts
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, parts) {
+		t.Fatalf("json.Unmarshal() = %v, want %v", decoded, parts)
+	}
+}
+
+type unregisteredPart struct{ CustomPartBase }
+
+func (unregisteredPart) PartKind() string     { return "x-unregistered" }
+func (unregisteredPart) Meta() map[string]any { return nil }
+
+func TestValidatePart(t *testing.T) {
+	registerGeoPart(t)
+
+	tests := []struct {
+		part    Part
+		wantErr bool
+	}{
+		{part: TextPart{Text: "text"}},
+		{part: geoPart{Lat: 10}},
+		{part: geoPart{Lat: 100}, wantErr: true},
+		{part: unregisteredPart{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := ValidatePart(tt.part); (err != nil) != tt.wantErr {
+			t.Errorf("ValidatePart(%v) error = %v, want error %v", tt.part, err, tt.wantErr)
+		}
+	}
+	if _, err := json.Marshal(ContentParts{unregisteredPart{}}); !errors.Is(err, ErrUnknownPartKind) {
+		t.Errorf("json.Marshal() error = %v, want %v", err, ErrUnknownPartKind)
+	}
+}
+
+func TestRegisterPartKind_Conflicts(t *testing.T) {
+	registerGeoPart(t)
+	decode := func(data []byte) (CustomPart, error) { return geoPart{}, nil }
+	if err := RegisterPartKind(geoPart{}, PartCodec{Decode: decode}); err == nil {
+		t.Error("RegisterPartKind() with a registered kind succeeded, want an error")
+	}
+	if err := RegisterPartKind(geoPart{}, PartCodec{}); err == nil {
+		t.Error("RegisterPartKind() without a decoder succeeded, want an error")
+	}
+}
+
+func TestUnmarshal_Lenient(t *testing.T) {
+	payload := `{"id":"task","contextId":"ctx","status":{"state":"working"},"kind":"task","priority":3,` +
+		`"history":[{"messageId":"m","role":"user","parts":[],"kind":"message","trace":{"id":"abc"}}]}`
+
+	var task Task
+	if err := Unmarshal([]byte(payload), &task, DecodeLenient); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := string(task.Extra["priority"]); got != "3" {
+		t.Fatalf("Task.Extra[priority] = %q, want 3", got)
+	}
+	if got := string(task.History[0].Extra["trace"]); got != `{"id":"abc"}` {
+		t.Fatalf("Message.Extra[trace] = %q, want the raw object", got)
+	}
+	if _, ok := task.Extra["kind"]; ok {
+		t.Fatal("Task.Extra captured the kind discriminator")
+	}
+
+	data, err := json.Marshal(task)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var roundTripped Task
+	if err := json.Unmarshal(data, &roundTripped); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(roundTripped, task) {
+		t.Fatalf("round trip = %+v, want %+v", roundTripped, task)
+	}
+}
+
+func TestUnmarshal_Strict(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		v       any
+		wantErr string
+	}{
+		{name: "known fields", payload: `{"messageId":"m","role":"user","parts":[],"kind":"message"}`, v: &Message{}},
+		{name: "unknown message field", payload: `{"messageId":"m","role":"user","parts":[],"x":1}`, v: &Message{}, wantErr: "$.x"},
+		{name: "unknown nested field", payload: `{"taskId":"t","contextId":"c","final":true,"status":{"state":"completed","message":{"messageId":"m","role":"agent","parts":[],"y":true}}}`, v: &TaskStatusUpdateEvent{}, wantErr: "$.status.message.y"},
+		{name: "unknown card field", payload: `{"name":"agent","supportsExtendedCard":true}`, v: &AgentCard{}, wantErr: "$.supportsExtendedCard"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Unmarshal([]byte(tt.payload), tt.v, DecodeStrict)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Unmarshal() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrUnknownFields) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Unmarshal() error = %v, want %v mentioning %s", err, ErrUnknownFields, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMarshal_Extra(t *testing.T) {
+	msg := Message{ID: "m", Role: MessageRoleUser, Parts: ContentParts{}, Extra: map[string]json.RawMessage{
+		"b":         json.RawMessage(`2`),
+		"a":         json.RawMessage(`"1"`),
+		"messageId": json.RawMessage(`"ignored"`),
+	}}
+	got, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"messageId":"m","parts":[],"role":"user","a":"1","b":2}`
+	if string(got) != want {
+		t.Fatalf("json.Marshal() = %s, want %s", got, want)
+	}
+}
+
+func TestRedirectHint_JSON(t *testing.T) {
+	hint := RedirectHint{RetryAfter: 1500 * time.Millisecond, URL: "https://replica-2.example.com", Transport: TransportProtocolJSONRPC}
+	event := NewRedirectEvent("task-1", "ctx-1", TaskStatus{State: TaskStateWorking}, hint)
+	if got, ok := RedirectHintFrom(event); !ok || got != hint {
+		t.Fatalf("RedirectHintFrom() = %+v, %v, want %+v", got, ok, hint)
+	}
+
+	data, err := MarshalEvent(event)
+	if err != nil {
+		t.Fatalf("MarshalEvent() error = %v", err)
+	}
+	decoded, err := UnmarshalEvent(data)
+	if err != nil {
+		t.Fatalf("UnmarshalEvent() error = %v", err)
+	}
+	if got, ok := RedirectHintFrom(decoded); !ok || got != hint {
+		t.Fatalf("RedirectHintFrom() after JSON round-trip = %+v, %v, want %+v", got, ok, hint)
+	}
+	if _, ok := RedirectHintFrom(NewStatusUpdateEvent(&Task{ID: "task-1"}, TaskStateWorking, nil)); ok {
+		t.Fatal("RedirectHintFrom() for a regular update = true, want false")
+	}
+}
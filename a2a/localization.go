@@ -0,0 +1,185 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LocalizationExtensionURI identifies the AgentExtension which carries AgentCard translations
+// in its Params under the "translations" key, mapping BCP 47 language tags to CardTranslation.
+const LocalizationExtensionURI = "https://github.com/a2aproject/a2a-go/extensions/localization/v1"
+
+// CardTranslation contains localized AgentCard texts. Empty fields fall back to the card values.
+type CardTranslation struct {
+	// Name is the localized AgentCard.Name.
+	Name string `json:"name,omitempty"`
+	// Description is the localized AgentCard.Description.
+	Description string `json:"description,omitempty"`
+	// Skills are localized skill texts keyed by AgentSkill.ID.
+	Skills map[string]SkillTranslation `json:"skills,omitempty"`
+}
+
+// SkillTranslation contains localized AgentSkill texts. Empty fields fall back to the skill values.
+type SkillTranslation struct {
+	// Name is the localized AgentSkill.Name.
+	Name string `json:"name,omitempty"`
+	// Description is the localized AgentSkill.Description.
+	Description string `json:"description,omitempty"`
+	// Examples are the localized AgentSkill.Examples.
+	Examples []string `json:"examples,omitempty"`
+}
+
+// SetCardTranslations publishes the translations in the card as LocalizationExtensionURI extension,
+// replacing previously published ones.
+func SetCardTranslations(card *AgentCard, translations map[string]CardTranslation) error {
+	raw, err := json.Marshal(translations)
+	if err != nil {
+		return err
+	}
+	var params any
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return err
+	}
+
+	ext := AgentExtension{
+		URI:         LocalizationExtensionURI,
+		Description: "Localized agent card texts",
+		Params:      map[string]any{"translations": params},
+	}
+	card.Capabilities.Extensions = slices.DeleteFunc(card.Capabilities.Extensions, func(e AgentExtension) bool {
+		return e.URI == LocalizationExtensionURI
+	})
+	card.Capabilities.Extensions = append(card.Capabilities.Extensions, ext)
+	return nil
+}
+
+// CardTranslations returns the translations published in the card or nil if there are none.
+func CardTranslations(card *AgentCard) (map[string]CardTranslation, error) {
+	for _, ext := range card.Capabilities.Extensions {
+		if ext.URI != LocalizationExtensionURI {
+			continue
+		}
+		raw, err := json.Marshal(ext.Params["translations"])
+		if err != nil {
+			return nil, err
+		}
+		var result map[string]CardTranslation
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return nil, fmt.Errorf("malformed translations: %w", err)
+		}
+		return result, nil
+	}
+	return nil, nil
+}
+
+// LocalizeCard returns a copy of the card with texts replaced by the translation which best matches
+// the Accept-Language header value, together with the selected language tag. The card is returned
+// unchanged with an empty tag if no translation matches.
+func LocalizeCard(card *AgentCard, acceptLanguage string) (*AgentCard, string, error) {
+	translations, err := CardTranslations(card)
+	if err != nil {
+		return nil, "", err
+	}
+	available := make([]string, 0, len(translations))
+	for tag := range translations {
+		available = append(available, tag)
+	}
+	tag := MatchLanguage(acceptLanguage, available)
+	if tag == "" {
+		return card, "", nil
+	}
+
+	translation := translations[tag]
+	localized := *card
+	if translation.Name != "" {
+		localized.Name = translation.Name
+	}
+	if translation.Description != "" {
+		localized.Description = translation.Description
+	}
+	localized.Skills = make([]AgentSkill, len(card.Skills))
+	for i, skill := range card.Skills {
+		if t, ok := translation.Skills[skill.ID]; ok {
+			if t.Name != "" {
+				skill.Name = t.Name
+			}
+			if t.Description != "" {
+				skill.Description = t.Description
+			}
+			if len(t.Examples) > 0 {
+				skill.Examples = t.Examples
+			}
+		}
+		localized.Skills[i] = skill
+	}
+	return &localized, tag, nil
+}
+
+// MatchLanguage returns the available language tag which best matches the Accept-Language header value
+// or an empty string if there's no match. Tags are compared case-insensitively, a requested tag matches
+// an available tag with the same primary language if there's no exact match, and "*" matches any tag.
+func MatchLanguage(acceptLanguage string, available []string) string {
+	type preference struct {
+		tag string
+		q   float64
+	}
+	var prefs []preference
+	for _, entry := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(entry), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			prefs = append(prefs, preference{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].q > prefs[j].q })
+
+	sorted := slices.Clone(available)
+	slices.Sort(sorted)
+	for _, pref := range prefs {
+		if pref.tag == "*" && len(sorted) > 0 {
+			return sorted[0]
+		}
+		for _, tag := range sorted {
+			if strings.EqualFold(tag, pref.tag) {
+				return tag
+			}
+		}
+		primary, _, _ := strings.Cut(pref.tag, "-")
+		for _, tag := range sorted {
+			candidate, _, _ := strings.Cut(tag, "-")
+			if strings.EqualFold(candidate, primary) {
+				return tag
+			}
+		}
+	}
+	return ""
+}
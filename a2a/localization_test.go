@@ -0,0 +1,108 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMatchLanguage(t *testing.T) {
+	available := []string{"en", "de-DE", "pt-BR", "pt-PT"}
+	testCases := []struct {
+		accept string
+		want   string
+	}{
+		{accept: "de-DE", want: "de-DE"},
+		{accept: "DE-de", want: "de-DE"},
+		{accept: "de", want: "de-DE"},
+		{accept: "en-US,en;q=0.8", want: "en"},
+		{accept: "fr;q=0.9, pt-PT;q=0.5, en;q=0.1", want: "pt-PT"},
+		{accept: "fr, *;q=0.1", want: "de-DE"},
+		{accept: "fr", want: ""},
+		{accept: "en;q=0", want: ""},
+		{accept: "", want: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.accept, func(t *testing.T) {
+			if got := MatchLanguage(tc.accept, available); got != tc.want {
+				t.Fatalf("MatchLanguage(%q) = %q, want %q", tc.accept, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLocalizeCard(t *testing.T) {
+	card := &AgentCard{
+		Name:        "Travel agent",
+		Description: "Books trips",
+		Skills: []AgentSkill{
+			{ID: "flights", Name: "Flights", Description: "Books flights", Examples: []string{"Fly to Paris"}},
+			{ID: "hotels", Name: "Hotels", Description: "Books hotels"},
+		},
+	}
+	translations := map[string]CardTranslation{
+		"de": {
+			Name: "Reiseagent",
+			Skills: map[string]SkillTranslation{
+				"flights": {Name: "Flüge", Examples: []string{"Flieg nach Paris"}},
+			},
+		},
+	}
+	if err := SetCardTranslations(card, translations); err != nil {
+		t.Fatalf("SetCardTranslations() error = %v", err)
+	}
+	if err := SetCardTranslations(card, translations); err != nil {
+		t.Fatalf("SetCardTranslations() error = %v", err)
+	}
+	if len(card.Capabilities.Extensions) != 1 {
+		t.Fatalf("got %d extensions, want 1", len(card.Capabilities.Extensions))
+	}
+
+	// translations must survive a wire round-trip
+	raw, err := json.Marshal(card)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var decoded AgentCard
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	localized, lang, err := LocalizeCard(&decoded, "de-AT, en;q=0.5")
+	if err != nil {
+		t.Fatalf("LocalizeCard() error = %v", err)
+	}
+	if lang != "de" {
+		t.Fatalf("LocalizeCard() language = %q, want de", lang)
+	}
+	if localized.Name != "Reiseagent" || localized.Description != "Books trips" {
+		t.Errorf("localized card = %q / %q, want translated name and default description", localized.Name, localized.Description)
+	}
+	if localized.Skills[0].Name != "Flüge" || localized.Skills[0].Examples[0] != "Flieg nach Paris" || localized.Skills[0].Description != "Books flights" {
+		t.Errorf("localized skill = %+v, want translated name and examples", localized.Skills[0])
+	}
+	if localized.Skills[1].Name != "Hotels" {
+		t.Errorf("untranslated skill = %+v, want defaults", localized.Skills[1])
+	}
+	if decoded.Name != "Travel agent" || decoded.Skills[0].Name != "Flights" {
+		t.Errorf("LocalizeCard() modified the original card")
+	}
+
+	unchanged, lang, err := LocalizeCard(&decoded, "fr")
+	if err != nil || lang != "" || unchanged != &decoded {
+		t.Errorf("LocalizeCard(fr) = %v, %q, %v, want the original card", unchanged, lang, err)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import "fmt"
+
+const (
+	// DefaultPageSize is the number of items returned by list methods when the page size is not set.
+	DefaultPageSize = 50
+	// MaxPageSize is the maximum number of items list methods return in a single page.
+	MaxPageSize = 100
+)
+
+// EffectivePageSize returns the page size a list method should use for the requested size:
+// DefaultPageSize if it's not set and MaxPageSize if it's too large.
+// Returns an error wrapping ErrInvalidRequest if the size is negative.
+func EffectivePageSize(requested int) (int, error) {
+	switch {
+	case requested < 0:
+		return 0, fmt.Errorf("%w: negative page size %d", ErrInvalidRequest, requested)
+	case requested == 0:
+		return DefaultPageSize, nil
+	case requested > MaxPageSize:
+		return MaxPageSize, nil
+	default:
+		return requested, nil
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Kinds of the Part types defined by the protocol. They are used as the "kind" discriminator in JSON.
+const (
+	PartKindText = "text"
+	PartKindData = "data"
+	PartKindFile = "file"
+)
+
+// ErrUnknownPartKind is returned when decoding or validating a Part of a kind which is neither
+// defined by the protocol nor registered with RegisterPartKind.
+var ErrUnknownPartKind = errors.New("unknown part kind")
+
+// CustomPart is a Part type defined by a protocol extension. Types implement Part by embedding CustomPartBase.
+// Unless PartCodec.Encode is provided, the JSON encoding of a CustomPart must be an object without
+// a "kind" field, which is added when ContentParts are marshaled.
+type CustomPart interface {
+	Part
+	// PartKind returns the discriminator the part is registered with using RegisterPartKind.
+	PartKind() string
+}
+
+// CustomPartBase can be embedded in a struct to make it implement Part.
+type CustomPartBase struct{}
+
+func (CustomPartBase) isPart() { _ = 0 }
+
+// PartCodec describes how a CustomPart is encoded, decoded and validated.
+type PartCodec struct {
+	// Decode decodes the JSON of the part, which includes the "kind" field. Required.
+	Decode func(data []byte) (CustomPart, error)
+	// Encode encodes the part as a JSON object. The "kind" field is added if missing. Defaults to json.Marshal.
+	Encode func(part CustomPart) ([]byte, error)
+	// Validate is called by ValidatePart, eg. before the part is stored as a part of a Task. Optional.
+	Validate func(part CustomPart) error
+}
+
+var partKinds = struct {
+	sync.RWMutex
+	codecs map[string]PartCodec
+}{codecs: make(map[string]PartCodec)}
+
+// RegisterPartKind makes ContentParts decode, encode and validate parts of the prototype kind using the codec,
+// so that extension-defined parts survive JSON round trips and task storage. The prototype type is also
+// registered with encoding/gob. Returns an error if the kind is defined by the protocol or already registered.
+func RegisterPartKind(prototype CustomPart, codec PartCodec) error {
+	if prototype == nil || codec.Decode == nil {
+		return fmt.Errorf("part prototype and decoder must be provided")
+	}
+	kind := prototype.PartKind()
+	switch kind {
+	case "":
+		return fmt.Errorf("part kind must not be empty")
+	case PartKindText, PartKindData, PartKindFile:
+		return fmt.Errorf("part kind %q is defined by the protocol", kind)
+	}
+	partKinds.Lock()
+	defer partKinds.Unlock()
+	if _, ok := partKinds.codecs[kind]; ok {
+		return fmt.Errorf("part kind %q is already registered", kind)
+	}
+	partKinds.codecs[kind] = codec
+	gob.Register(prototype)
+	return nil
+}
+
+// ValidatePart checks that a custom part is of a registered kind and passes the codec validation.
+// Parts defined by the protocol are always valid.
+func ValidatePart(part Part) error {
+	custom, ok := part.(CustomPart)
+	if !ok {
+		return nil
+	}
+	codec, err := partCodec(custom.PartKind())
+	if err != nil {
+		return err
+	}
+	if codec.Validate == nil {
+		return nil
+	}
+	return codec.Validate(custom)
+}
+
+func partCodec(kind string) (PartCodec, error) {
+	partKinds.RLock()
+	defer partKinds.RUnlock()
+	codec, ok := partKinds.codecs[kind]
+	if !ok {
+		return PartCodec{}, fmt.Errorf("%w: %q", ErrUnknownPartKind, kind)
+	}
+	return codec, nil
+}
+
+func marshalCustomPart(part CustomPart) ([]byte, error) {
+	codec, err := partCodec(part.PartKind())
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if codec.Encode != nil {
+		data, err = codec.Encode(part)
+	} else {
+		data, err = json.Marshal(part)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var typed struct {
+		Kind *string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, fmt.Errorf("part %q must be encoded as a JSON object: %w", part.PartKind(), err)
+	}
+	if typed.Kind != nil {
+		return data, nil
+	}
+	return withKindField(part.PartKind(), data)
+}
+
+func unmarshalCustomPart(kind string, data []byte) (Part, error) {
+	codec, err := partCodec(kind)
+	if err != nil {
+		return nil, err
+	}
+	return codec.Decode(data)
+}
+
+// withKindField adds the "kind" discriminator to the JSON object.
+func withKindField(kind string, data []byte) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) < 2 || data[0] != '{' {
+		return nil, fmt.Errorf("%q must be encoded as a JSON object", kind)
+	}
+	kindJSON, err := json.Marshal(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`{"kind":`)
+	buf.Write(kindJSON)
+	if rest := bytes.TrimSpace(data[1:]); rest[0] != '}' {
+		buf.WriteByte(',')
+	}
+	buf.Write(data[1:])
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PreferencesMetaKey is the Message metadata key clients use for sending the Preferences of the user
+// the agent output is produced for.
+const PreferencesMetaKey = "preferences"
+
+// UnitSystem is a system of measurement units.
+type UnitSystem string
+
+const (
+	UnitSystemMetric   UnitSystem = "metric"
+	UnitSystemImperial UnitSystem = "imperial"
+)
+
+// Preferences describe how agent output should be localized for the user. Empty fields mean
+// there's no preference and the agent defaults should be used.
+type Preferences struct {
+	// Locale is a BCP 47 language tag, eg. "de-CH", which selects the output language and the formatting
+	// of numbers, dates and currencies.
+	Locale string `json:"locale,omitempty"`
+	// TimeZone is an IANA time zone name, eg. "Europe/Zurich", for presenting times.
+	TimeZone string `json:"timeZone,omitempty"`
+	// Units is the preferred system of measurement.
+	Units UnitSystem `json:"units,omitempty"`
+}
+
+// Validate checks that Locale is a well-formed language tag and Units is a known UnitSystem.
+// TimeZone is not validated, as the set of known zones depends on the time zone database available
+// to the agent. Use Location to resolve it.
+func (p Preferences) Validate() error {
+	if p.Locale != "" && !isLanguageTag(p.Locale) {
+		return fmt.Errorf("malformed locale %q", p.Locale)
+	}
+	switch p.Units {
+	case "", UnitSystemMetric, UnitSystemImperial:
+	default:
+		return fmt.Errorf("unknown unit system %q", p.Units)
+	}
+	return nil
+}
+
+// Location returns the time.Location of the preferred TimeZone or time.UTC if there's no preference.
+// An error is returned if the time zone is unknown.
+func (p Preferences) Location() (*time.Location, error) {
+	if p.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(p.TimeZone)
+}
+
+// Language returns the available language tag which best matches the preferred Locale
+// or an empty string if there's no match. See MatchLanguage for the matching rules.
+func (p Preferences) Language(available []string) string {
+	if p.Locale == "" {
+		return ""
+	}
+	return MatchLanguage(p.Locale, available)
+}
+
+// SetPreferences stores the preferences in the message metadata under PreferencesMetaKey.
+// Preferences are removed from the metadata if prefs is empty.
+func SetPreferences(msg *Message, prefs Preferences) {
+	if prefs == (Preferences{}) {
+		delete(msg.Metadata, PreferencesMetaKey)
+		return
+	}
+	value := map[string]any{}
+	if prefs.Locale != "" {
+		value["locale"] = prefs.Locale
+	}
+	if prefs.TimeZone != "" {
+		value["timeZone"] = prefs.TimeZone
+	}
+	if prefs.Units != "" {
+		value["units"] = string(prefs.Units)
+	}
+	if msg.Metadata == nil {
+		msg.Metadata = map[string]any{}
+	}
+	msg.Metadata[PreferencesMetaKey] = value
+}
+
+// PreferencesOf returns the preferences stored in the message metadata. Empty Preferences are returned
+// if there are none. An error wrapping ErrInvalidRequest is returned if the preferences are malformed.
+func PreferencesOf(msg *Message) (Preferences, error) {
+	value, ok := msg.Metadata[PreferencesMetaKey]
+	if !ok || value == nil {
+		return Preferences{}, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return Preferences{}, fmt.Errorf("%w: malformed preferences: %v", ErrInvalidRequest, err)
+	}
+	var prefs Preferences
+	if err := json.Unmarshal(raw, &prefs); err != nil {
+		return Preferences{}, fmt.Errorf("%w: malformed preferences: %v", ErrInvalidRequest, err)
+	}
+	if err := prefs.Validate(); err != nil {
+		return Preferences{}, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+	return prefs, nil
+}
+
+// isLanguageTag checks the BCP 47 syntax loosely: a primary language subtag of 2-8 letters
+// followed by subtags of 1-8 letters or digits.
+func isLanguageTag(tag string) bool {
+	for i, subtag := range strings.Split(tag, "-") {
+		if len(subtag) == 0 || len(subtag) > 8 || (i == 0 && len(subtag) < 2) {
+			return false
+		}
+		for _, c := range subtag {
+			isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+			if !isLetter && (i == 0 || c < '0' || c > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestPreferences_RoundTrip(t *testing.T) {
+	want := Preferences{Locale: "de-CH", TimeZone: "Europe/Zurich", Units: UnitSystemMetric}
+	msg := NewMessage(MessageRoleUser, TextPart{Text: "Wie warm ist es?"})
+	SetPreferences(msg, want)
+
+	// preferences must survive a wire round-trip
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var decoded Message
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	got, err := PreferencesOf(&decoded)
+	if err != nil || got != want {
+		t.Fatalf("PreferencesOf() = %+v, %v, want %+v", got, err, want)
+	}
+
+	SetPreferences(msg, Preferences{})
+	if _, ok := msg.Metadata[PreferencesMetaKey]; ok {
+		t.Fatalf("SetPreferences() with empty preferences kept metadata %v", msg.Metadata)
+	}
+	if got, err := PreferencesOf(msg); err != nil || got != (Preferences{}) {
+		t.Fatalf("PreferencesOf() = %+v, %v, want empty preferences", got, err)
+	}
+}
+
+func TestPreferencesOf_Malformed(t *testing.T) {
+	testCases := map[string]any{
+		"not an object":    "de-CH",
+		"wrong field type": map[string]any{"locale": 42},
+		"malformed locale": map[string]any{"locale": "de_CH"},
+		"numeric language": map[string]any{"locale": "12-CH"},
+		"unknown units":    map[string]any{"units": "furlongs"},
+	}
+	for name, value := range testCases {
+		t.Run(name, func(t *testing.T) {
+			msg := &Message{Metadata: map[string]any{PreferencesMetaKey: value}}
+			if _, err := PreferencesOf(msg); !errors.Is(err, ErrInvalidRequest) {
+				t.Fatalf("PreferencesOf() error = %v, want %v", err, ErrInvalidRequest)
+			}
+		})
+	}
+}
+
+func TestPreferences_Location(t *testing.T) {
+	if loc, err := (Preferences{}).Location(); err != nil || loc.String() != "UTC" {
+		t.Fatalf("Location() = %v, %v, want UTC", loc, err)
+	}
+	if _, err := (Preferences{TimeZone: "Mars/Olympus_Mons"}).Location(); err == nil {
+		t.Fatal("Location() of unknown zone succeeded, want error")
+	}
+}
+
+func TestPreferences_Language(t *testing.T) {
+	available := []string{"en", "de"}
+	if got := (Preferences{Locale: "de-CH"}).Language(available); got != "de" {
+		t.Fatalf("Language() = %q, want de", got)
+	}
+	if got := (Preferences{}).Language(available); got != "" {
+		t.Fatalf("Language() without locale = %q, want empty", got)
+	}
+}
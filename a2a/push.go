@@ -31,10 +31,27 @@ type ListTaskPushConfigParams struct {
 	// TaskID is the unique identifier of the task.
 	TaskID TaskID `json:"id" yaml:"id" mapstructure:"id"`
 
+	// PageSize is the maximum number of configurations to return. DefaultPageSize is used if not set.
+	PageSize int `json:"pageSize,omitempty" yaml:"pageSize,omitempty" mapstructure:"pageSize,omitempty"`
+
+	// PageToken is the NextPageToken of the previous page. The first page is returned if empty.
+	PageToken string `json:"pageToken,omitempty" yaml:"pageToken,omitempty" mapstructure:"pageToken,omitempty"`
+
 	// Metadata is an optional metadata for extensions.
 	Metadata map[string]any `json:"metadata,omitempty" yaml:"metadata,omitempty" mapstructure:"metadata,omitempty"`
 }
 
+// ListTaskPushConfigResult is a page of push notification configurations associated with a task.
+// The JSON-RPC binding returns it only for requests setting PageSize or PageToken, other requests get
+// an array of all configurations.
+type ListTaskPushConfigResult struct {
+	// Configs are the push notification configurations on the page.
+	Configs []TaskPushConfig `json:"configs" yaml:"configs" mapstructure:"configs"`
+
+	// NextPageToken can be used for requesting the next page. Empty if this is the last page.
+	NextPageToken string `json:"nextPageToken,omitempty" yaml:"nextPageToken,omitempty" mapstructure:"nextPageToken,omitempty"`
+}
+
 // DeleteTaskPushConfigParams defines parameters for deleting a specific push notification configuration for a task.
 type DeleteTaskPushConfigParams struct {
 	// TaskID is the unique identifier of the task.
@@ -0,0 +1,76 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import "time"
+
+// RedirectMetaKey is the metadata key of a TaskStatusUpdateEvent a server sends before closing a stream
+// because it's shutting down. The event repeats the latest Task status and isn't final, so clients
+// should resubscribe to the Task using the interface described by the RedirectHint.
+const RedirectMetaKey = "a2a-redirect"
+
+// RedirectHint tells a client where and when to resubscribe to a Task after the server closed the stream.
+type RedirectHint struct {
+	// RetryAfter is the delay after which the client should resubscribe.
+	RetryAfter time.Duration
+	// URL is the alternate interface to resubscribe with. The interface the stream was opened with
+	// should be used if it's empty.
+	URL string
+	// Transport is the transport protocol of the alternate interface.
+	Transport TransportProtocol
+}
+
+// NewRedirectEvent creates a non-final TaskStatusUpdateEvent which carries the hint in its metadata.
+func NewRedirectEvent(taskID TaskID, contextID string, status TaskStatus, hint RedirectHint) *TaskStatusUpdateEvent {
+	value := map[string]any{"retryAfterMs": hint.RetryAfter.Milliseconds()}
+	if hint.URL != "" {
+		value["url"] = hint.URL
+	}
+	if hint.Transport != "" {
+		value["transport"] = string(hint.Transport)
+	}
+	now := time.Now()
+	status.Timestamp = &now
+	return &TaskStatusUpdateEvent{
+		TaskID:    taskID,
+		ContextID: contextID,
+		Status:    status,
+		Metadata:  map[string]any{RedirectMetaKey: value},
+	}
+}
+
+// RedirectHintFrom returns the hint carried by an event created with NewRedirectEvent.
+func RedirectHintFrom(event Event) (RedirectHint, bool) {
+	update, ok := event.(*TaskStatusUpdateEvent)
+	if !ok {
+		return RedirectHint{}, false
+	}
+	value, ok := update.Metadata[RedirectMetaKey].(map[string]any)
+	if !ok {
+		return RedirectHint{}, false
+	}
+	var hint RedirectHint
+	switch ms := value["retryAfterMs"].(type) {
+	case int64:
+		hint.RetryAfter = time.Duration(ms) * time.Millisecond
+	case float64:
+		hint.RetryAfter = time.Duration(ms) * time.Millisecond
+	}
+	hint.URL, _ = value["url"].(string)
+	if transport, ok := value["transport"].(string); ok {
+		hint.Transport = TransportProtocol(transport)
+	}
+	return hint, true
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"mime"
+	"slices"
+	"strings"
+)
+
+// RejectionMetaKey is the status Message metadata key under which an agent describes in a machine-readable
+// form why a Task was moved to TaskStateRejected.
+const RejectionMetaKey = "rejection"
+
+// RejectionReason identifies the reason of a Task rejection.
+type RejectionReason string
+
+const (
+	// RejectionReasonUnsupportedContentType means that parts of the message have media types which
+	// are not accepted by the agent or the requested skill.
+	RejectionReasonUnsupportedContentType RejectionReason = "unsupported-content-type"
+)
+
+// Rejection describes why a Task was rejected, so that clients can adjust the message and try again.
+type Rejection struct {
+	// Reason identifies the reason of the rejection.
+	Reason RejectionReason
+	// UnsupportedTypes are the media types of the message parts which were not accepted.
+	UnsupportedTypes []string
+	// AcceptedInputModes are the media types the agent would accept instead.
+	AcceptedInputModes []string
+}
+
+// RejectionToMeta converts the rejection to a metadata value which can be stored under RejectionMetaKey.
+func RejectionToMeta(r Rejection) map[string]any {
+	toAny := func(values []string) []any {
+		result := make([]any, len(values))
+		for i, v := range values {
+			result[i] = v
+		}
+		return result
+	}
+	return map[string]any{
+		"reason":             string(r.Reason),
+		"unsupportedTypes":   toAny(r.UnsupportedTypes),
+		"acceptedInputModes": toAny(r.AcceptedInputModes),
+	}
+}
+
+// RejectionFromMeta extracts the rejection stored under RejectionMetaKey. The second return value
+// is false if there's no rejection or it doesn't have a reason. Malformed media types are skipped.
+func RejectionFromMeta(meta map[string]any) (Rejection, bool) {
+	entry, ok := meta[RejectionMetaKey].(map[string]any)
+	if !ok {
+		return Rejection{}, false
+	}
+	reason, _ := entry["reason"].(string)
+	if reason == "" {
+		return Rejection{}, false
+	}
+	fromAny := func(value any) []string {
+		values, _ := value.([]any)
+		var result []string
+		for _, v := range values {
+			if s, ok := v.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return Rejection{
+		Reason:             RejectionReason(reason),
+		UnsupportedTypes:   fromAny(entry["unsupportedTypes"]),
+		AcceptedInputModes: fromAny(entry["acceptedInputModes"]),
+	}, true
+}
+
+// PartMediaType returns the media type of the part: "text/plain" for a TextPart, "application/json"
+// for a DataPart and the declared MIME type of a FilePart or "application/octet-stream" if it has none.
+func PartMediaType(part Part) string {
+	switch v := part.(type) {
+	case TextPart:
+		return "text/plain"
+	case DataPart:
+		return "application/json"
+	case FilePart:
+		var meta FileMeta
+		switch f := v.File.(type) {
+		case FileBytes:
+			meta = f.FileMeta
+		case FileURI:
+			meta = f.FileMeta
+		}
+		if meta.MimeType != "" {
+			return meta.MimeType
+		}
+	}
+	return "application/octet-stream"
+}
+
+// UnsupportedMediaTypes returns media types of the message parts which don't match any of the modes,
+// without duplicates. Modes can use wildcards like "image/*" or "*/*" and media type parameters are
+// ignored. Everything is accepted if modes are empty.
+func UnsupportedMediaTypes(msg *Message, modes []string) []string {
+	if len(modes) == 0 {
+		return nil
+	}
+	var result []string
+	for _, part := range msg.Parts {
+		mediaType := PartMediaType(part)
+		if !mediaTypeAccepted(mediaType, modes) && !slices.Contains(result, mediaType) {
+			result = append(result, mediaType)
+		}
+	}
+	return result
+}
+
+func mediaTypeAccepted(mediaType string, modes []string) bool {
+	mediaType = baseMediaType(mediaType)
+	for _, mode := range modes {
+		mode = baseMediaType(mode)
+		if mode == "*/*" || mode == mediaType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(mode, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func baseMediaType(value string) string {
+	if mediaType, _, err := mime.ParseMediaType(value); err == nil {
+		return mediaType
+	}
+	return strings.ToLower(strings.TrimSpace(value))
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestUnsupportedMediaTypes(t *testing.T) {
+	msg := &Message{Parts: ContentParts{
+		TextPart{Text: "hi"},
+		DataPart{Data: map[string]any{}},
+		FilePart{File: FileURI{FileMeta: FileMeta{MimeType: "image/png; q=1"}, URI: "https://files.com/a.png"}},
+		FilePart{File: FileBytes{Bytes: "AA=="}},
+	}}
+
+	testCases := []struct {
+		name  string
+		modes []string
+		want  []string
+	}{
+		{name: "no modes"},
+		{name: "any", modes: []string{"*/*"}},
+		{name: "wildcard", modes: []string{"text/plain", "application/*", "IMAGE/*"}},
+		{name: "exact", modes: []string{"text/plain"}, want: []string{"application/json", "image/png; q=1", "application/octet-stream"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := UnsupportedMediaTypes(msg, tc.modes); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("UnsupportedMediaTypes() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRejectionMeta(t *testing.T) {
+	rejection := Rejection{
+		Reason:             RejectionReasonUnsupportedContentType,
+		UnsupportedTypes:   []string{"audio/wav"},
+		AcceptedInputModes: []string{"text/plain"},
+	}
+	// simulate a round trip over the wire
+	data, err := json.Marshal(map[string]any{RejectionMetaKey: RejectionToMeta(rejection)})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var meta map[string]any
+	if err := json.Unmarshal(data, &meta); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got, ok := RejectionFromMeta(meta); !ok || !reflect.DeepEqual(got, rejection) {
+		t.Fatalf("RejectionFromMeta() = %+v, %v, want %+v", got, ok, rejection)
+	}
+	if _, ok := RejectionFromMeta(map[string]any{RejectionMetaKey: map[string]any{}}); ok {
+		t.Fatal("RejectionFromMeta() without a reason ok = true, want false")
+	}
+}
@@ -0,0 +1,368 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidSkillInput indicates that the data of a DataPart doesn't conform to the InputSchema of a skill.
+var ErrInvalidSkillInput = errors.New("input doesn't match skill input schema")
+
+// SkillInputError describes a DataPart which doesn't conform to the InputSchema of a skill.
+// It matches both ErrInvalidSkillInput and ErrInvalidRequest with errors.Is.
+type SkillInputError struct {
+	// SkillID is the ID of the skill the message was sent to.
+	SkillID string
+	// Part is the index of the invalid part in Message.Parts.
+	Part int
+	// Path is a JSON Pointer (RFC 6901) to the invalid value within the part data.
+	Path string
+	// Reason describes the violated constraint.
+	Reason string
+}
+
+func (e *SkillInputError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%v: skill %q, part %d, %s: %s", ErrInvalidSkillInput, e.SkillID, e.Part, path, e.Reason)
+}
+
+func (e *SkillInputError) Unwrap() []error {
+	return []error{ErrInvalidSkillInput, ErrInvalidRequest}
+}
+
+// FindSkill returns the skill with the provided ID. If id is empty, the only skill of the card is returned.
+func (c *AgentCard) FindSkill(id string) (*AgentSkill, bool) {
+	if id == "" {
+		if len(c.Skills) == 1 {
+			return &c.Skills[0], true
+		}
+		return nil, false
+	}
+	for i := range c.Skills {
+		if c.Skills[i].ID == id {
+			return &c.Skills[i], true
+		}
+	}
+	return nil, false
+}
+
+// ValidateInput validates the data of all DataParts of the message against InputSchema and returns
+// a *SkillInputError describing the first violation. Messages without DataParts and skills without
+// InputSchema are always valid.
+//
+// A subset of JSON Schema sufficient for describing structured inputs is supported: "type", "enum", "const",
+// "properties", "required", "additionalProperties", "items", "minItems", "maxItems", "minLength",
+// "maxLength", "pattern", "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "allOf", "anyOf",
+// "oneOf" and "not". Other keywords, including "$ref", are ignored, so schemas should be self-contained.
+func (s *AgentSkill) ValidateInput(msg *Message) error {
+	if len(s.InputSchema) == 0 {
+		return nil
+	}
+	var schema any
+	for i, part := range msg.Parts {
+		data, ok := part.(DataPart)
+		if !ok {
+			continue
+		}
+		if schema == nil {
+			normalized, err := normalizeJSON(s.InputSchema)
+			if err != nil {
+				return fmt.Errorf("invalid input schema of skill %q: %w", s.ID, err)
+			}
+			schema = normalized
+		}
+		value, err := normalizeJSON(data.Data)
+		if err != nil {
+			return &SkillInputError{SkillID: s.ID, Part: i, Reason: err.Error()}
+		}
+		if v := validateSchema(schema, value, ""); v != nil {
+			return &SkillInputError{SkillID: s.ID, Part: i, Path: v.path, Reason: v.reason}
+		}
+	}
+	return nil
+}
+
+// normalizeJSON converts values constructed in Go (eg. ints or typed slices) to the types produced
+// by decoding JSON, so that they can be compared with each other.
+func normalizeJSON(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+type schemaViolation struct {
+	path   string
+	reason string
+}
+
+func violation(path, format string, args ...any) *schemaViolation {
+	return &schemaViolation{path: path, reason: fmt.Sprintf(format, args...)}
+}
+
+func validateSchema(schema any, value any, path string) *schemaViolation {
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			return violation(path, "no value is allowed")
+		}
+		return nil
+	case map[string]any:
+		return validateSchemaObject(s, value, path)
+	default:
+		return nil
+	}
+}
+
+func validateSchemaObject(schema map[string]any, value any, path string) *schemaViolation {
+	if t, ok := schema["type"]; ok {
+		if v := validateType(t, value, path); v != nil {
+			return v
+		}
+	}
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, allowed := range enum {
+			if reflect.DeepEqual(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return violation(path, "value is not one of %v", enum)
+		}
+	}
+	if c, ok := schema["const"]; ok && !reflect.DeepEqual(c, value) {
+		return violation(path, "value must be %v", c)
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		if violation := validateObject(schema, v, path); violation != nil {
+			return violation
+		}
+	case []any:
+		if violation := validateArray(schema, v, path); violation != nil {
+			return violation
+		}
+	case string:
+		if violation := validateString(schema, v, path); violation != nil {
+			return violation
+		}
+	case float64:
+		if violation := validateNumber(schema, v, path); violation != nil {
+			return violation
+		}
+	}
+
+	if all, ok := schema["allOf"].([]any); ok {
+		for _, sub := range all {
+			if v := validateSchema(sub, value, path); v != nil {
+				return v
+			}
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok {
+		matched := false
+		for _, sub := range anyOf {
+			if validateSchema(sub, value, path) == nil {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return violation(path, "value doesn't match any of the anyOf schemas")
+		}
+	}
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		matched := 0
+		for _, sub := range oneOf {
+			if validateSchema(sub, value, path) == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return violation(path, "value matches %d of the oneOf schemas, want exactly 1", matched)
+		}
+	}
+	if not, ok := schema["not"]; ok && validateSchema(not, value, path) == nil {
+		return violation(path, "value must not match the \"not\" schema")
+	}
+	return nil
+}
+
+func validateType(t any, value any, path string) *schemaViolation {
+	var types []string
+	switch t := t.(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				types = append(types, s)
+			}
+		}
+	default:
+		return nil
+	}
+	for _, typ := range types {
+		if hasJSONType(value, typ) {
+			return nil
+		}
+	}
+	return violation(path, "value must be of type %s, got %s", strings.Join(types, " or "), jsonTypeOf(value))
+}
+
+func hasJSONType(value any, typ string) bool {
+	switch typ {
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonTypeOf(value) == typ
+	}
+}
+
+func jsonTypeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func validateObject(schema map[string]any, value map[string]any, path string) *schemaViolation {
+	if required, ok := schema["required"].([]any); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, present := value[name]; !present {
+					return violation(path, "missing required property %q", name)
+				}
+			}
+		}
+	}
+	properties, _ := schema["properties"].(map[string]any)
+	additional, hasAdditional := schema["additionalProperties"]
+	// iterate in a stable order to always report the same violation
+	for _, name := range slices.Sorted(maps.Keys(value)) {
+		v := value[name]
+		propPath := path + "/" + escapeJSONPointer(name)
+		if sub, ok := properties[name]; ok {
+			if violation := validateSchema(sub, v, propPath); violation != nil {
+				return violation
+			}
+			continue
+		}
+		if !hasAdditional {
+			continue
+		}
+		if allowed, ok := additional.(bool); ok && !allowed {
+			return violation(path, "unexpected property %q", name)
+		}
+		if violation := validateSchema(additional, v, propPath); violation != nil {
+			return violation
+		}
+	}
+	return nil
+}
+
+func validateArray(schema map[string]any, value []any, path string) *schemaViolation {
+	if n, ok := schema["minItems"].(float64); ok && float64(len(value)) < n {
+		return violation(path, "array must have at least %v items, got %d", n, len(value))
+	}
+	if n, ok := schema["maxItems"].(float64); ok && float64(len(value)) > n {
+		return violation(path, "array must have at most %v items, got %d", n, len(value))
+	}
+	if items, ok := schema["items"]; ok {
+		for i, item := range value {
+			if v := validateSchema(items, item, fmt.Sprintf("%s/%d", path, i)); v != nil {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+func validateString(schema map[string]any, value string, path string) *schemaViolation {
+	length := float64(utf8.RuneCountInString(value))
+	if n, ok := schema["minLength"].(float64); ok && length < n {
+		return violation(path, "string must be at least %v characters long", n)
+	}
+	if n, ok := schema["maxLength"].(float64); ok && length > n {
+		return violation(path, "string must be at most %v characters long", n)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return violation(path, "invalid pattern %q in schema: %v", pattern, err)
+		}
+		if !re.MatchString(value) {
+			return violation(path, "string doesn't match pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+func validateNumber(schema map[string]any, value float64, path string) *schemaViolation {
+	if n, ok := schema["minimum"].(float64); ok && value < n {
+		return violation(path, "value must be >= %v", n)
+	}
+	if n, ok := schema["maximum"].(float64); ok && value > n {
+		return violation(path, "value must be <= %v", n)
+	}
+	if n, ok := schema["exclusiveMinimum"].(float64); ok && value <= n {
+		return violation(path, "value must be > %v", n)
+	}
+	if n, ok := schema["exclusiveMaximum"].(float64); ok && value >= n {
+		return violation(path, "value must be < %v", n)
+	}
+	return nil
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
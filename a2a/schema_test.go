@@ -0,0 +1,100 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAgentSkill_ValidateInput(t *testing.T) {
+	skill := AgentSkill{ID: "flights", InputSchema: map[string]any{
+		"type":                 "object",
+		"required":             []string{"from", "to"},
+		"additionalProperties": false,
+		"properties": map[string]any{
+			"from":       map[string]any{"type": "string", "pattern": "^[A-Z]{3}$"},
+			"to":         map[string]any{"type": "string", "pattern": "^[A-Z]{3}$"},
+			"passengers": map[string]any{"type": "integer", "minimum": 1, "maximum": 9},
+			"class":      map[string]any{"enum": []string{"economy", "business"}},
+			"stops": map[string]any{
+				"type":     "array",
+				"maxItems": 2,
+				"items":    map[string]any{"type": "string"},
+			},
+			"date": map[string]any{"anyOf": []any{
+				map[string]any{"type": "string", "minLength": 10},
+				map[string]any{"type": "null"},
+			}},
+		},
+	}}
+
+	testCases := []struct {
+		name     string
+		data     map[string]any
+		wantPath string
+	}{
+		{name: "valid", data: map[string]any{"from": "JFK", "to": "SFO", "passengers": 2, "class": "economy", "stops": []string{"ORD"}, "date": nil}},
+		{name: "missing required", data: map[string]any{"from": "JFK"}, wantPath: ""},
+		{name: "wrong type", data: map[string]any{"from": "JFK", "to": 1}, wantPath: "/to"},
+		{name: "pattern", data: map[string]any{"from": "jfk", "to": "SFO"}, wantPath: "/from"},
+		{name: "not integer", data: map[string]any{"from": "JFK", "to": "SFO", "passengers": 1.5}, wantPath: "/passengers"},
+		{name: "maximum", data: map[string]any{"from": "JFK", "to": "SFO", "passengers": 10}, wantPath: "/passengers"},
+		{name: "enum", data: map[string]any{"from": "JFK", "to": "SFO", "class": "first"}, wantPath: "/class"},
+		{name: "array item", data: map[string]any{"from": "JFK", "to": "SFO", "stops": []any{"ORD", 1}}, wantPath: "/stops/1"},
+		{name: "max items", data: map[string]any{"from": "JFK", "to": "SFO", "stops": []string{"A", "B", "C"}}, wantPath: "/stops"},
+		{name: "anyOf", data: map[string]any{"from": "JFK", "to": "SFO", "date": "tomorrow"}, wantPath: "/date"},
+		{name: "additional property", data: map[string]any{"from": "JFK", "to": "SFO", "pets": true}, wantPath: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := &Message{Parts: ContentParts{TextPart{Text: "book"}, DataPart{Data: tc.data}}}
+			err := skill.ValidateInput(msg)
+			if tc.name == "valid" {
+				if err != nil {
+					t.Fatalf("ValidateInput() error = %v, want nil", err)
+				}
+				return
+			}
+			var inputErr *SkillInputError
+			if !errors.As(err, &inputErr) {
+				t.Fatalf("ValidateInput() error = %v, want SkillInputError", err)
+			}
+			if inputErr.Part != 1 || inputErr.Path != tc.wantPath {
+				t.Fatalf("ValidateInput() error = %+v, want part 1 and path %q", inputErr, tc.wantPath)
+			}
+			if !errors.Is(err, ErrInvalidSkillInput) || !errors.Is(err, ErrInvalidRequest) {
+				t.Fatalf("ValidateInput() error = %v, want it to match ErrInvalidSkillInput and ErrInvalidRequest", err)
+			}
+		})
+	}
+}
+
+func TestAgentSkill_ValidateInputNoSchema(t *testing.T) {
+	skill := AgentSkill{ID: "chat"}
+	if err := skill.ValidateInput(&Message{Parts: ContentParts{DataPart{Data: map[string]any{"x": 1}}}}); err != nil {
+		t.Fatalf("ValidateInput() error = %v, want nil", err)
+	}
+}
+
+func TestAgentCard_FindSkill(t *testing.T) {
+	card := &AgentCard{Skills: []AgentSkill{{ID: "flights"}}}
+	if skill, ok := card.FindSkill(""); !ok || skill.ID != "flights" {
+		t.Fatalf("FindSkill(\"\") = %v, %v, want the only skill", skill, ok)
+	}
+	if _, ok := card.FindSkill("hotels"); ok {
+		t.Fatal("FindSkill(hotels) found a skill, want none")
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SkillIDMetaKey is the Message metadata key clients use for selecting the skill which should handle the message.
+const SkillIDMetaKey = "skillId"
+
+// ErrNoSkillInput is returned by DecodeSkillInput if a message doesn't contain a DataPart.
+var ErrNoSkillInput = errors.New("message doesn't contain skill input")
+
+// NewSkillMessage creates a user message invoking the skill with the provided input. The skill ID is
+// stored in the message metadata under SkillIDMetaKey. Input is converted to a part as follows:
+//   - a string becomes a TextPart;
+//   - a Part is used as is;
+//   - anything else is encoded to JSON and becomes a DataPart, so it must encode to a JSON object.
+//
+// A nil input creates a message without parts.
+func NewSkillMessage(skillID string, input any) (*Message, error) {
+	if skillID == "" {
+		return nil, fmt.Errorf("skill ID must be provided")
+	}
+	msg := NewMessage(MessageRoleUser)
+	msg.Metadata = map[string]any{SkillIDMetaKey: skillID}
+	if input == nil {
+		return msg, nil
+	}
+
+	switch v := input.(type) {
+	case string:
+		msg.Parts = ContentParts{TextPart{Text: v}}
+	case Part:
+		msg.Parts = ContentParts{v}
+	case map[string]any:
+		msg.Parts = ContentParts{DataPart{Data: v}}
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode skill input: %w", err)
+		}
+		var object map[string]any
+		if err := json.Unmarshal(data, &object); err != nil || object == nil {
+			return nil, fmt.Errorf("skill input of type %T must encode to a JSON object", input)
+		}
+		msg.Parts = ContentParts{DataPart{Data: object}}
+	}
+	return msg, nil
+}
+
+// SkillIDOf returns the ID of the skill referenced by the message metadata or an empty string.
+func SkillIDOf(msg *Message) string {
+	id, _ := msg.Metadata[SkillIDMetaKey].(string)
+	return id
+}
+
+// DecodeSkillInput decodes the data of the first DataPart of the message into v, which should be a pointer
+// to the type the client passed to NewSkillMessage. Returns ErrNoSkillInput if the message doesn't
+// contain a DataPart.
+func DecodeSkillInput(msg *Message, v any) error {
+	for _, part := range msg.Parts {
+		if data, ok := part.(DataPart); ok {
+			encoded, err := json.Marshal(data.Data)
+			if err != nil {
+				return err
+			}
+			if err := json.Unmarshal(encoded, v); err != nil {
+				return fmt.Errorf("failed to decode skill input: %w", err)
+			}
+			return nil
+		}
+	}
+	return ErrNoSkillInput
+}
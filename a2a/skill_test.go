@@ -0,0 +1,65 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewSkillMessage(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input any
+		want  Part
+	}{
+		{name: "text", input: "hello", want: TextPart{Text: "hello"}},
+		{name: "part", input: FilePart{File: FileURI{URI: "https://example.com/a.png"}}, want: FilePart{File: FileURI{URI: "https://example.com/a.png"}}},
+		{name: "struct", input: struct {
+			N int `json:"n"`
+		}{N: 1}, want: DataPart{Data: map[string]any{"n": float64(1)}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg, err := NewSkillMessage("skill", tc.input)
+			if err != nil {
+				t.Fatalf("NewSkillMessage() error = %v", err)
+			}
+			if SkillIDOf(msg) != "skill" || msg.Role != MessageRoleUser {
+				t.Fatalf("NewSkillMessage() = %+v, want a user message referencing the skill", msg)
+			}
+			if len(msg.Parts) != 1 {
+				t.Fatalf("NewSkillMessage() parts = %v, want 1 part", msg.Parts)
+			}
+			if !reflect.DeepEqual(msg.Parts[0], tc.want) {
+				t.Fatalf("NewSkillMessage() part = %#v, want %#v", msg.Parts[0], tc.want)
+			}
+		})
+	}
+}
+
+func TestDecodeSkillInput(t *testing.T) {
+	var input struct {
+		N int `json:"n"`
+	}
+	msg := &Message{Parts: ContentParts{TextPart{Text: "hi"}, DataPart{Data: map[string]any{"n": 2}}}}
+	if err := DecodeSkillInput(msg, &input); err != nil || input.N != 2 {
+		t.Fatalf("DecodeSkillInput() = %+v, %v, want n = 2", input, err)
+	}
+	if err := DecodeSkillInput(&Message{}, &input); !errors.Is(err, ErrNoSkillInput) {
+		t.Fatalf("DecodeSkillInput() error = %v, want %v", err, ErrNoSkillInput)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// RequestTimeoutMetaKey is the request header (or gRPC metadata key) clients use for telling servers
+// how much time is left until the request deadline, so that servers can stop working on requests
+// the client is no longer waiting for. The value is formatted with FormatRequestTimeout.
+// gRPC transports additionally propagate deadlines natively.
+const RequestTimeoutMetaKey = "a2a-request-timeout"
+
+// FormatRequestTimeout formats the remaining time as a RequestTimeoutMetaKey value:
+// a whole number of milliseconds, rounded up so that a short timeout doesn't become zero.
+func FormatRequestTimeout(d time.Duration) string {
+	if d <= 0 {
+		return "0"
+	}
+	return strconv.FormatInt(int64((d+time.Millisecond-1)/time.Millisecond), 10)
+}
+
+// ParseRequestTimeout parses a RequestTimeoutMetaKey value.
+func ParseRequestTimeout(value string) (time.Duration, error) {
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || ms < 0 || ms > int64(time.Duration(1<<63-1)/time.Millisecond) {
+		return 0, fmt.Errorf("%w: invalid request timeout %q", ErrInvalidRequest, value)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
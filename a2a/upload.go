@@ -0,0 +1,60 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import "strings"
+
+// UploadURIScheme is the URI scheme of FileURI parts referencing a file which was uploaded
+// to an agent in chunks before sending the Message, eg. "a2a-upload:<id>".
+const UploadURIScheme = "a2a-upload"
+
+// UploadOffsetHeader is the HTTP header carrying the number of bytes of an upload received by an agent.
+// Clients pass it with every chunk and agents return the new value in responses.
+const UploadOffsetHeader = "Upload-Offset"
+
+// MultipartMessageField is the name of the multipart/form-data field carrying JSON-encoded MessageSendParams
+// when a Message is submitted together with raw file parts.
+const MultipartMessageField = "message"
+
+// multipartFileScheme is the URI scheme of FileURI parts referencing multipart/form-data fields (RFC 2392).
+const multipartFileScheme = "cid"
+
+// MultipartFileURI returns a FileURI.URI referencing a raw file sent in the multipart/form-data field.
+func MultipartFileURI(field string) string {
+	return multipartFileScheme + ":" + field
+}
+
+// MultipartFieldFromURI extracts a field name from a URI created with MultipartFileURI.
+func MultipartFieldFromURI(uri string) (string, bool) {
+	field, ok := strings.CutPrefix(uri, multipartFileScheme+":")
+	if !ok || field == "" {
+		return "", false
+	}
+	return field, true
+}
+
+// UploadURI returns a FileURI.URI referencing an upload with the provided ID.
+func UploadURI(id string) string {
+	return UploadURIScheme + ":" + id
+}
+
+// UploadIDFromURI extracts an upload ID from a URI created with UploadURI.
+func UploadIDFromURI(uri string) (string, bool) {
+	id, ok := strings.CutPrefix(uri, UploadURIScheme+":")
+	if !ok || id == "" {
+		return "", false
+	}
+	return id, true
+}
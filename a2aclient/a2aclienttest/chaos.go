@@ -0,0 +1,227 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclienttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+)
+
+var (
+	// ErrInjected is the transient error returned by a chaos Transport instead of making a call.
+	ErrInjected = errors.New("injected transient failure")
+	// ErrStreamDropped is yielded by a chaos Transport stream to simulate a broken connection.
+	ErrStreamDropped = errors.New("injected stream drop")
+)
+
+// Faults configures the failures injected by NewChaosTransport. Rates are probabilities in the range [0, 1].
+type Faults struct {
+	// Rand is the source of randomness. Set it to a seeded source for reproducible runs.
+	Rand *rand.Rand
+	// Latency is the maximum delay added to a call or a stream event. The actual delay is uniformly distributed.
+	Latency time.Duration
+	// LatencyRate is the probability of delaying a call or a stream event by up to Latency.
+	LatencyRate float64
+	// ErrorRate is the probability of a call failing with ErrInjected without reaching the wrapped Transport.
+	ErrorRate float64
+	// DropRate is the probability of a stream ending with ErrStreamDropped before an event is delivered.
+	DropRate float64
+	// MalformedRate is the probability of a stream event being replaced with an event which
+	// doesn't reference any Task and has an unknown state.
+	MalformedRate float64
+}
+
+// NewChaosTransport wraps a Transport to inject latency, transient errors, dropped streams and
+// malformed events according to the provided Faults. Destroy and GetAgentCard calls are not affected.
+func NewChaosTransport(transport a2aclient.Transport, faults Faults) a2aclient.Transport {
+	r := faults.Rand
+	if r == nil {
+		r = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	return &chaosTransport{Transport: transport, faults: faults, rand: r}
+}
+
+type chaosTransport struct {
+	a2aclient.Transport
+	faults Faults
+	mu     sync.Mutex
+	rand   *rand.Rand
+}
+
+func (t *chaosTransport) happens(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rand.Float64() < rate
+}
+
+func (t *chaosTransport) delay(ctx context.Context) error {
+	if t.faults.Latency <= 0 || !t.happens(t.faults.LatencyRate) {
+		return nil
+	}
+	t.mu.Lock()
+	d := time.Duration(t.rand.Int64N(int64(t.faults.Latency)))
+	t.mu.Unlock()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+// inject applies latency and transient error faults before a call.
+func (t *chaosTransport) inject(ctx context.Context) error {
+	if err := t.delay(ctx); err != nil {
+		return err
+	}
+	if t.happens(t.faults.ErrorRate) {
+		return ErrInjected
+	}
+	return nil
+}
+
+func chaosCall[Req, Resp any](t *chaosTransport, call func(context.Context, Req) (Resp, error)) func(context.Context, Req) (Resp, error) {
+	return func(ctx context.Context, req Req) (Resp, error) {
+		if err := t.inject(ctx); err != nil {
+			var zero Resp
+			return zero, err
+		}
+		return call(ctx, req)
+	}
+}
+
+func (t *chaosTransport) GetTask(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+	return chaosCall(t, t.Transport.GetTask)(ctx, query)
+}
+
+func (t *chaosTransport) CancelTask(ctx context.Context, id a2a.TaskIDParams) (*a2a.Task, error) {
+	return chaosCall(t, t.Transport.CancelTask)(ctx, id)
+}
+
+func (t *chaosTransport) SendMessage(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	return chaosCall(t, t.Transport.SendMessage)(ctx, message)
+}
+
+func (t *chaosTransport) ResubscribeToTask(ctx context.Context, id a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+	return t.stream(ctx, func() iter.Seq2[a2a.Event, error] {
+		return t.Transport.ResubscribeToTask(ctx, id)
+	})
+}
+
+func (t *chaosTransport) SendStreamingMessage(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return t.stream(ctx, func() iter.Seq2[a2a.Event, error] {
+		return t.Transport.SendStreamingMessage(ctx, message)
+	})
+}
+
+func (t *chaosTransport) GetTaskPushConfig(ctx context.Context, params a2a.GetTaskPushConfigParams) (a2a.TaskPushConfig, error) {
+	return chaosCall(t, t.Transport.GetTaskPushConfig)(ctx, params)
+}
+
+func (t *chaosTransport) ListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) (*a2a.ListTaskPushConfigResult, error) {
+	return chaosCall(t, t.Transport.ListTaskPushConfig)(ctx, params)
+}
+
+func (t *chaosTransport) SetTaskPushConfig(ctx context.Context, params a2a.TaskPushConfig) (a2a.TaskPushConfig, error) {
+	return chaosCall(t, t.Transport.SetTaskPushConfig)(ctx, params)
+}
+
+func (t *chaosTransport) DeleteTaskPushConfig(ctx context.Context, params a2a.DeleteTaskPushConfigParams) error {
+	if err := t.inject(ctx); err != nil {
+		return err
+	}
+	return t.Transport.DeleteTaskPushConfig(ctx, params)
+}
+
+func (t *chaosTransport) Call(ctx context.Context, method string, params any, result any) error {
+	raw, ok := t.Transport.(a2aclient.RawTransport)
+	if !ok {
+		return fmt.Errorf("%w: %s", a2aclient.ErrRawCallUnsupported, method)
+	}
+	if err := t.inject(ctx); err != nil {
+		return err
+	}
+	return raw.Call(ctx, method, params, result)
+}
+
+func (t *chaosTransport) CallStreaming(ctx context.Context, method string, params any) iter.Seq2[json.RawMessage, error] {
+	return func(yield func(json.RawMessage, error) bool) {
+		raw, ok := t.Transport.(a2aclient.RawTransport)
+		if !ok {
+			yield(nil, fmt.Errorf("%w: %s", a2aclient.ErrRawCallUnsupported, method))
+			return
+		}
+		if err := t.inject(ctx); err != nil {
+			yield(nil, err)
+			return
+		}
+		for payload, err := range raw.CallStreaming(ctx, method, params) {
+			if err == nil && t.happens(t.faults.DropRate) {
+				yield(nil, ErrStreamDropped)
+				return
+			}
+			if !yield(payload, err) {
+				return
+			}
+		}
+	}
+}
+
+func (t *chaosTransport) stream(ctx context.Context, open func() iter.Seq2[a2a.Event, error]) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		if err := t.inject(ctx); err != nil {
+			yield(nil, err)
+			return
+		}
+		for event, err := range open() {
+			if err == nil {
+				if err := t.delay(ctx); err != nil {
+					yield(nil, err)
+					return
+				}
+				if t.happens(t.faults.DropRate) {
+					yield(nil, ErrStreamDropped)
+					return
+				}
+				if t.happens(t.faults.MalformedRate) {
+					event = MalformedEvent()
+				}
+			}
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+// MalformedEvent returns an event which doesn't reference any Task and has an unknown state.
+func MalformedEvent() a2a.Event {
+	return &a2a.TaskStatusUpdateEvent{Status: a2a.TaskStatus{State: "malformed"}}
+}
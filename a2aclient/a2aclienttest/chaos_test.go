@@ -0,0 +1,105 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclienttest
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+)
+
+type stubTransport struct {
+	a2aclient.Transport
+	calls  int
+	events []a2a.Event
+}
+
+func (t *stubTransport) GetTask(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+	t.calls++
+	return &a2a.Task{ID: query.ID}, nil
+}
+
+func (t *stubTransport) SendStreamingMessage(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		for _, event := range t.events {
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+func collect(seq iter.Seq2[a2a.Event, error]) ([]a2a.Event, error) {
+	var events []a2a.Event
+	for event, err := range seq {
+		if err != nil {
+			return events, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func TestChaosTransport_TransientErrors(t *testing.T) {
+	stub := &stubTransport{}
+	transport := NewChaosTransport(stub, Faults{Rand: rand.New(rand.NewPCG(1, 2)), ErrorRate: 0.5})
+
+	failures := 0
+	for range 20 {
+		if _, err := transport.GetTask(t.Context(), a2a.TaskQueryParams{ID: "task"}); err != nil {
+			if !errors.Is(err, ErrInjected) {
+				t.Fatalf("GetTask() error = %v, want %v", err, ErrInjected)
+			}
+			failures++
+		}
+	}
+	if failures == 0 || failures == 20 {
+		t.Fatalf("got %d failures out of 20 calls, want some of them to fail", failures)
+	}
+	if stub.calls != 20-failures {
+		t.Fatalf("wrapped transport called %d times, want %d", stub.calls, 20-failures)
+	}
+}
+
+func TestChaosTransport_DroppedStream(t *testing.T) {
+	stub := &stubTransport{events: []a2a.Event{&a2a.Task{ID: "task"}, &a2a.Task{ID: "task"}}}
+	transport := NewChaosTransport(stub, Faults{DropRate: 1})
+
+	events, err := collect(transport.SendStreamingMessage(t.Context(), a2a.MessageSendParams{}))
+	if len(events) != 0 || !errors.Is(err, ErrStreamDropped) {
+		t.Fatalf("SendStreamingMessage() = %v, %v, want the stream dropped", events, err)
+	}
+}
+
+func TestChaosTransport_MalformedEvents(t *testing.T) {
+	stub := &stubTransport{events: []a2a.Event{&a2a.Task{ID: "task"}}}
+	transport := NewChaosTransport(stub, Faults{MalformedRate: 1})
+
+	events, err := collect(transport.SendStreamingMessage(t.Context(), a2a.MessageSendParams{}))
+	if err != nil {
+		t.Fatalf("SendStreamingMessage() error = %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("SendStreamingMessage() = %v, want one event", events)
+	}
+	if update, ok := events[0].(*a2a.TaskStatusUpdateEvent); !ok || update.Status.State != "malformed" {
+		t.Fatalf("SendStreamingMessage() event = %v, want a malformed event", events[0])
+	}
+}
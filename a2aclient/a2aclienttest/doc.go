@@ -0,0 +1,29 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package a2aclienttest provides utilities for testing code built on top of a2aclient.
+//
+// NewChaosTransport wraps a Transport to inject latency, transient errors, dropped streams and
+// malformed events, so that retry and resubscribe logic can be verified under failure:
+//
+//	factory := a2aclient.NewFactory(a2aclient.WithTransport(protocol, a2aclient.TransportFactoryFn(
+//		func(ctx context.Context, url string, card *a2a.AgentCard) (a2aclient.Transport, error) {
+//			transport, err := inner.Create(ctx, url, card)
+//			if err != nil {
+//				return nil, err
+//			}
+//			return a2aclienttest.NewChaosTransport(transport, a2aclienttest.Faults{ErrorRate: 0.1}), nil
+//		},
+//	)))
+package a2aclienttest
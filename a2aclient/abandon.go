@@ -0,0 +1,114 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// AbandonPolicy defines what happens to a Task when a caller stops consuming a streaming call
+// before the Task reached a terminal state.
+type AbandonPolicy string
+
+const (
+	// AbandonDetach stops reading the stream and leaves the Task running on the agent. The default.
+	AbandonDetach AbandonPolicy = "detach"
+	// AbandonCancel requests the agent to cancel the Task.
+	AbandonCancel AbandonPolicy = "cancel"
+	// AbandonRegisterPush leaves the Task running and registers Config.PushConfigs for it,
+	// so that the rest of the updates are delivered as push notifications.
+	AbandonRegisterPush AbandonPolicy = "push"
+)
+
+// abandonTimeout limits the time spent on applying AbandonPolicy after the caller stopped iteration.
+const abandonTimeout = 10 * time.Second
+
+// taskTracker follows the Task referenced by streamed events.
+type taskTracker struct {
+	taskID   a2a.TaskID
+	terminal bool
+}
+
+func (t *taskTracker) observe(event a2a.Event) {
+	switch v := event.(type) {
+	case *a2a.Task:
+		t.taskID = v.ID
+		t.terminal = v.Status.State.Terminal()
+	case *a2a.TaskStatusUpdateEvent:
+		t.taskID = v.TaskID
+		t.terminal = v.Status.State.Terminal()
+	case *a2a.TaskArtifactUpdateEvent:
+		t.taskID = v.TaskID
+	case *a2a.Message:
+		if v.TaskID != "" {
+			t.taskID = v.TaskID
+		}
+	}
+}
+
+// withAbandonPolicy applies Config.AbandonPolicy if the caller breaks out of the event iteration.
+// The policy is applied even if the caller's context was canceled. Its errors are ignored,
+// because there's nobody left to report them to.
+func (c *Client) withAbandonPolicy(ctx context.Context, taskID a2a.TaskID, events iter.Seq2[a2a.Event, error]) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		tracker := &taskTracker{taskID: taskID}
+		for event, err := range events {
+			if err == nil {
+				tracker.observe(event)
+			}
+			if !yield(event, err) {
+				if tracker.taskID != "" && !tracker.terminal {
+					_ = c.abandon(ctx, tracker.taskID)
+				}
+				return
+			}
+		}
+	}
+}
+
+func (c *Client) abandon(ctx context.Context, taskID a2a.TaskID) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), abandonTimeout)
+	defer cancel()
+
+	switch c.Config.AbandonPolicy {
+	case AbandonCancel:
+		_, err := c.CancelTask(ctx, a2a.TaskIDParams{ID: taskID})
+		if errors.Is(err, a2a.ErrTaskNotCancelable) {
+			return nil
+		}
+		return err
+
+	case AbandonRegisterPush:
+		var errs []error
+		for _, config := range c.Config.PushConfigs {
+			if _, err := c.SetTaskPushConfig(ctx, a2a.TaskPushConfig{TaskID: taskID, Config: config}); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+
+	case AbandonDetach, "":
+		return nil
+
+	default:
+		return fmt.Errorf("unknown abandon policy %q", c.Config.AbandonPolicy)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"iter"
+	"reflect"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestClient_AbandonPolicy(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+	pushConfig := a2a.PushConfig{ID: "config-1", URL: "https://example.com/push"}
+
+	testCases := []struct {
+		name       string
+		policy     AbandonPolicy
+		events     []a2a.Event
+		readAll    bool
+		wantCancel []a2a.TaskID
+		wantPush   []a2a.TaskPushConfig
+	}{
+		{name: "default detaches", events: []a2a.Event{task, task}},
+		{name: "detach", policy: AbandonDetach, events: []a2a.Event{task, task}},
+		{
+			name:       "cancel",
+			policy:     AbandonCancel,
+			events:     []a2a.Event{task, task},
+			wantCancel: []a2a.TaskID{task.ID},
+		},
+		{
+			name:     "register push",
+			policy:   AbandonRegisterPush,
+			events:   []a2a.Event{task, task},
+			wantPush: []a2a.TaskPushConfig{{TaskID: task.ID, Config: pushConfig}},
+		},
+		{
+			name:    "not applied when consumed fully",
+			policy:  AbandonCancel,
+			events:  []a2a.Event{task},
+			readAll: true,
+		},
+		{
+			name:   "not applied to terminal task",
+			policy: AbandonCancel,
+			events: []a2a.Event{a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil), task},
+		},
+		{
+			name:   "not applied without task",
+			policy: AbandonCancel,
+			events: []a2a.Event{a2a.NewMessage(a2a.MessageRoleAgent), task},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotCancel []a2a.TaskID
+			var gotPush []a2a.TaskPushConfig
+			transport := &mockTransport{
+				StreamFunc: func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+					return eventSeq(tc.events, nil)
+				},
+				CancelTaskFunc: func(ctx context.Context, id a2a.TaskIDParams) (*a2a.Task, error) {
+					gotCancel = append(gotCancel, id.ID)
+					return nil, nil
+				},
+				SetPushFunc: func(ctx context.Context, params a2a.TaskPushConfig) (a2a.TaskPushConfig, error) {
+					gotPush = append(gotPush, params)
+					return params, nil
+				},
+			}
+			client := &Client{
+				Config:    Config{AbandonPolicy: tc.policy, PushConfigs: []a2a.PushConfig{pushConfig}},
+				transport: transport,
+			}
+
+			for _, err := range client.SendStreamingMessage(t.Context(), a2a.MessageSendParams{}) {
+				if err != nil {
+					t.Fatalf("SendStreamingMessage() error = %v", err)
+				}
+				if !tc.readAll {
+					break
+				}
+			}
+
+			if !reflect.DeepEqual(gotCancel, tc.wantCancel) {
+				t.Fatalf("CancelTask() calls = %v, want %v", gotCancel, tc.wantCancel)
+			}
+			if !reflect.DeepEqual(gotPush, tc.wantPush) {
+				t.Fatalf("SetTaskPushConfig() calls = %v, want %v", gotPush, tc.wantPush)
+			}
+		})
+	}
+}
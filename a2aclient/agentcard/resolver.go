@@ -16,7 +16,16 @@ package agentcard
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/a2aproject/a2a-go/a2a"
 )
@@ -24,8 +33,28 @@ import (
 const defaultAgentCardPath = "/.well-known/agent-card.json"
 
 // Resolver is used to fetch an AgentCard from the provided URL.
+//
+// Fetched cards are cached in memory according to the Cache-Control and ETag response headers:
+// a card is reused without contacting the server until its max-age passes, and a stale card with
+// an ETag is revalidated using a conditional request. Responses marked no-store, and responses
+// which have neither a max-age nor an ETag, are not cached. Cards fetched with different paths or
+// request headers are cached separately.
 type Resolver struct {
 	BaseURL string
+	// Client is used for fetching the card. http.DefaultClient is used if nil.
+	// a2ahttp.ClientConfig can be used for creating a Client with custom TLS configuration.
+	Client *http.Client
+
+	mu    sync.Mutex
+	cache map[string]*cachedCard
+	// now is used instead of time.Now if set.
+	now func() time.Time
+}
+
+type cachedCard struct {
+	body    []byte
+	etag    string
+	expires time.Time
 }
 
 // ResolveOption is used to customize Resolve() behavior.
@@ -34,6 +63,7 @@ type ResolveOption func(r *resolveRequest)
 type resolveRequest struct {
 	path    string
 	headers map[string]string
+	refresh bool
 }
 
 // Resolve fetches an AgentCard from the provided URL.
@@ -47,7 +77,126 @@ func (r *Resolver) Resolve(ctx context.Context, opts ...ResolveOption) (*a2a.Age
 		o(req)
 	}
 
-	return &a2a.AgentCard{}, fmt.Errorf("not implemented")
+	url := strings.TrimSuffix(r.BaseURL, "/") + "/" + strings.TrimPrefix(req.path, "/")
+	key := cacheKey(url, req.headers)
+	cached := r.cached(key)
+	if cached != nil && !req.refresh && r.clock().Before(cached.expires) {
+		return decodeCard(cached.body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create agent card request: %w", err)
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	for k, v := range req.headers {
+		httpReq.Header.Set(k, v)
+	}
+	if cached != nil && cached.etag != "" && !req.refresh {
+		httpReq.Header.Set("If-None-Match", cached.etag)
+	}
+
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch agent card: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil && !req.refresh {
+		r.store(key, cached.body, resp.Header, cached.etag)
+		return decodeCard(cached.body)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch agent card from %s: unexpected status %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent card: %w", err)
+	}
+	card, err := decodeCard(body)
+	if err != nil {
+		return nil, err
+	}
+	r.store(key, body, resp.Header, resp.Header.Get("ETag"))
+	return card, nil
+}
+
+func (r *Resolver) clock() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+func (r *Resolver) cached(key string) *cachedCard {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cache[key]
+}
+
+// store caches the card body according to the response Cache-Control header or removes
+// the cache entry if the response can't be cached.
+func (r *Resolver) store(key string, body []byte, header http.Header, etag string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	maxAge, noStore := parseCacheControl(header.Get("Cache-Control"))
+	if noStore || (maxAge <= 0 && etag == "") {
+		delete(r.cache, key)
+		return
+	}
+	if r.cache == nil {
+		r.cache = make(map[string]*cachedCard)
+	}
+	if age, err := strconv.Atoi(header.Get("Age")); err == nil && age > 0 {
+		maxAge -= time.Duration(age) * time.Second
+	}
+	r.cache[key] = &cachedCard{body: body, etag: etag, expires: r.clock().Add(maxAge)}
+}
+
+// parseCacheControl returns the max-age of a response and whether it must not be stored.
+// no-cache responses are stored, but have zero max-age so that they are always revalidated.
+func parseCacheControl(value string) (maxAge time.Duration, noStore bool) {
+	noCache := false
+	for _, directive := range strings.Split(value, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store":
+			noStore = true
+		case "no-cache":
+			noCache = true
+		case "max-age":
+			if seconds, err := strconv.Atoi(strings.Trim(arg, `"`)); err == nil && seconds > 0 {
+				maxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	if noCache {
+		maxAge = 0
+	}
+	return maxAge, noStore
+}
+
+func cacheKey(url string, headers map[string]string) string {
+	var b strings.Builder
+	b.WriteString(url)
+	for _, k := range slices.Sorted(maps.Keys(headers)) {
+		b.WriteString("\n" + http.CanonicalHeaderKey(k) + ": " + headers[k])
+	}
+	return b.String()
+}
+
+func decodeCard(body []byte) (*a2a.AgentCard, error) {
+	var card a2a.AgentCard
+	if err := json.Unmarshal(body, &card); err != nil {
+		return nil, fmt.Errorf("failed to decode agent card: %w", err)
+	}
+	return &card, nil
 }
 
 // WithPath makes Resolve fetch from the provided path relative to BaseURL.
@@ -65,3 +214,11 @@ func WithRequestHeaders(headers map[string]string) ResolveOption {
 		}
 	}
 }
+
+// WithRefresh makes Resolve bypass the cache and fetch the card from the server unconditionally.
+// The cache is updated with the fetched card.
+func WithRefresh() ResolveOption {
+	return func(r *resolveRequest) {
+		r.refresh = true
+	}
+}
@@ -15,31 +15,221 @@
 package agentcard
 
 import (
-	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
 )
 
+func newCardServer(t *testing.T, path string, card *a2a.AgentCard) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("X-Test"); got != "" {
+			card := *card
+			card.Name = got
+			_ = json.NewEncoder(w).Encode(card)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(card)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
 func TestResolver_Resolve(t *testing.T) {
-	resolver := &Resolver{BaseURL: "http://localhost"}
-	ctx := context.Background()
+	ctx := t.Context()
+	card := &a2a.AgentCard{Name: "agent", URL: "http://localhost/a2a"}
+	server := newCardServer(t, defaultAgentCardPath, card)
+	resolver := &Resolver{BaseURL: server.URL + "/"}
 
 	// Test with no options
-	_, err := resolver.Resolve(ctx)
-	if err == nil || err.Error() != "not implemented" {
-		t.Errorf("expected 'not implemented' error, got %v", err)
+	got, err := resolver.Resolve(ctx)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
 	}
-
-	// Test with WithPath option
-	_, err = resolver.Resolve(ctx, WithPath("/new-path"))
-	if err == nil || err.Error() != "not implemented" {
-		t.Errorf("expected 'not implemented' error, got %v", err)
+	if got.Name != "agent" || got.URL != card.URL {
+		t.Errorf("Resolve() = %+v, want %+v", got, card)
 	}
 
 	// Test with WithRequestHeaders option
 	headers := map[string]string{"X-Test": "true"}
-	_, err = resolver.Resolve(ctx, WithRequestHeaders(headers))
-	if err == nil || err.Error() != "not implemented" {
-		t.Errorf("expected 'not implemented' error, got %v", err)
+	got, err = resolver.Resolve(ctx, WithRequestHeaders(headers))
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got.Name != "true" {
+		t.Errorf("Resolve() name = %q, want the request header to be sent", got.Name)
+	}
+
+	// Test with WithPath option
+	if _, err = resolver.Resolve(ctx, WithPath("/new-path")); err == nil {
+		t.Error("Resolve() from a missing path error = nil, want an error")
+	}
+}
+
+func TestResolver_ResolveCustomPath(t *testing.T) {
+	server := newCardServer(t, "/cards/agent.json", &a2a.AgentCard{Name: "agent"})
+	resolver := &Resolver{BaseURL: server.URL, Client: server.Client()}
+
+	got, err := resolver.Resolve(t.Context(), WithPath("cards/agent.json"))
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got.Name != "agent" {
+		t.Errorf("Resolve() = %+v, want agent", got)
+	}
+}
+
+func TestResolver_ResolveMalformed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{"))
+	}))
+	defer server.Close()
+
+	if _, err := (&Resolver{BaseURL: server.URL}).Resolve(t.Context()); err == nil {
+		t.Error("Resolve() error = nil, want a decoding error")
+	}
+}
+
+type cachingServer struct {
+	cacheControl string
+	etag         bool
+	requests     []*http.Request
+	version      int
+}
+
+func (s *cachingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests = append(s.requests, r)
+	etag := fmt.Sprintf(`"v%d"`, s.version)
+	w.Header().Set("Cache-Control", s.cacheControl)
+	if s.etag {
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	_ = json.NewEncoder(w).Encode(a2a.AgentCard{Name: "agent", Version: etag})
+}
+
+func newCachingResolver(t *testing.T, cacheControl string, etag bool) (*Resolver, *cachingServer, *time.Time) {
+	t.Helper()
+	cs := &cachingServer{cacheControl: cacheControl, etag: etag}
+	server := httptest.NewServer(cs)
+	t.Cleanup(server.Close)
+	now := time.Now()
+	return &Resolver{BaseURL: server.URL, now: func() time.Time { return now }}, cs, &now
+}
+
+func TestResolver_CacheMaxAge(t *testing.T) {
+	ctx := t.Context()
+	resolver, server, now := newCachingResolver(t, "public, max-age=60", false)
+
+	for range 2 {
+		if _, err := resolver.Resolve(ctx); err != nil {
+			t.Fatalf("Resolve() error = %v", err)
+		}
+	}
+	if len(server.requests) != 1 {
+		t.Fatalf("server got %d requests, want a fresh card to be served from the cache", len(server.requests))
+	}
+
+	if _, err := resolver.Resolve(ctx, WithRequestHeaders(map[string]string{"X-Tenant": "a"})); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(server.requests) != 2 {
+		t.Fatalf("server got %d requests, want cards fetched with different headers cached separately", len(server.requests))
+	}
+
+	server.version++
+	*now = now.Add(61 * time.Second)
+	got, err := resolver.Resolve(ctx)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(server.requests) != 3 || got.Version != `"v1"` {
+		t.Errorf("Resolve() = %q after %d requests, want an expired card to be fetched again", got.Version, len(server.requests))
+	}
+}
+
+func TestResolver_CacheETagRevalidation(t *testing.T) {
+	ctx := t.Context()
+	resolver, server, _ := newCachingResolver(t, "no-cache", true)
+
+	first, err := resolver.Resolve(ctx)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	second, err := resolver.Resolve(ctx)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(server.requests) != 2 {
+		t.Fatalf("server got %d requests, want a no-cache card to be revalidated", len(server.requests))
+	}
+	if got := server.requests[1].Header.Get("If-None-Match"); got != `"v0"` {
+		t.Errorf("If-None-Match = %q, want the cached ETag", got)
+	}
+	if second.Version != first.Version || second == first {
+		t.Errorf("Resolve() = %p %+v, want a copy of the cached card %p", second, second, first)
+	}
+
+	server.version++
+	got, err := resolver.Resolve(ctx)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got.Version != `"v1"` {
+		t.Errorf("Resolve() version = %q, want the modified card", got.Version)
+	}
+}
+
+func TestResolver_CacheNoStore(t *testing.T) {
+	ctx := t.Context()
+	resolver, server, _ := newCachingResolver(t, "no-store, max-age=60", false)
+
+	for range 2 {
+		if _, err := resolver.Resolve(ctx); err != nil {
+			t.Fatalf("Resolve() error = %v", err)
+		}
+	}
+	if len(server.requests) != 2 {
+		t.Errorf("server got %d requests, want no-store responses not to be cached", len(server.requests))
+	}
+}
+
+func TestResolver_WithRefresh(t *testing.T) {
+	ctx := t.Context()
+	resolver, server, _ := newCachingResolver(t, "max-age=60", true)
+
+	if _, err := resolver.Resolve(ctx); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	server.version++
+	got, err := resolver.Resolve(ctx, WithRefresh())
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(server.requests) != 2 || server.requests[1].Header.Get("If-None-Match") != "" {
+		t.Fatalf("server got %d requests, want an unconditional fetch", len(server.requests))
+	}
+	if got.Version != `"v1"` {
+		t.Errorf("Resolve() version = %q, want the refreshed card", got.Version)
+	}
+
+	if got, err = resolver.Resolve(ctx); err != nil || got.Version != `"v1"` {
+		t.Errorf("Resolve() = %+v, %v, want the refreshed card to be cached", got, err)
+	}
+	if len(server.requests) != 2 {
+		t.Errorf("server got %d requests, want the refreshed card served from the cache", len(server.requests))
 	}
 }
 
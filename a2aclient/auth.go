@@ -17,6 +17,8 @@ package a2aclient
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/a2aproject/a2a-go/a2a"
@@ -29,15 +31,19 @@ var ErrCredentialNotFound = errors.New("credential not found")
 // SessionID is a client-generated identifier used for scoping auth credentials.
 type SessionID string
 
+// AgentID identifies an agent credentials can be scoped to, typically AgentCard URL.
+// Allows using different credentials for the same security scheme name when talking to multiple agents.
+type AgentID string
+
 // AuthCredential represents a security-scheme specific credential (eg. a JWT token).
 type AuthCredential string
 
 // AuthInterceptor implements CallInterceptor.
-// It uses SessionID provided using a2aclient.WithSessionID to lookup credentials according
-// and attach them to the according to the security scheme described in a2a.AgentCard.
+// It uses SessionID provided using a2aclient.WithSessionID to lookup credentials for the security
+// requirements of the AgentCard known to the Client and attaches them to the request according to
+// the security scheme described in a2a.AgentCard.
 // Credentials fetching is delegated to CredentialsService.
 type AuthInterceptor struct {
-	PassthroughInterceptor
 	Service CredentialsService
 }
 
@@ -46,31 +52,174 @@ type CredentialsService interface {
 	Get(ctx context.Context, sid SessionID, scheme string) (AuthCredential, error)
 }
 
+// CredentialsRefresher can be implemented by a CredentialsService to obtain new credentials
+// when an agent rejects a call with an auth challenge, for example because a token expired.
+// AuthInterceptor makes the Client retry the call once after a successful refresh.
+type CredentialsRefresher interface {
+	Refresh(ctx context.Context, sid SessionID, challenge *AuthChallengeError) error
+}
+
+// AuthChallengeError is returned by Transport implementations when an agent rejected a call
+// because of missing or invalid credentials (eg. HTTP 401 or gRPC UNAUTHENTICATED).
+type AuthChallengeError struct {
+	// Challenge is the raw challenge received from the agent (eg. a WWW-Authenticate header value).
+	// Can be empty if the agent did not provide any details.
+	Challenge string
+	// Err is the underlying transport error.
+	Err error
+}
+
+func (e *AuthChallengeError) Error() string {
+	if e.Challenge == "" {
+		return fmt.Sprintf("authentication required: %v", e.Err)
+	}
+	return fmt.Sprintf("authentication required (%s): %v", e.Challenge, e.Err)
+}
+
+func (e *AuthChallengeError) Unwrap() error {
+	return e.Err
+}
+
+// Before attaches credentials for the first security requirement of CallContext.Card all the credentials
+// of which are known to Service. The request is sent as is if there's no session, no card or no credentials.
+func (ai AuthInterceptor) Before(ctx context.Context, req *Request) (context.Context, error) {
+	callCtx, ok := CallContextFrom(ctx)
+	if !ok || callCtx.SessionID == "" || callCtx.Card == nil {
+		return ctx, nil
+	}
+
+	for _, requirement := range callCtx.Card.Security {
+		meta, err := ai.resolve(ctx, callCtx, requirement)
+		if errors.Is(err, ErrCredentialNotFound) {
+			continue
+		}
+		if err != nil {
+			return ctx, err
+		}
+		if req.Meta == nil {
+			req.Meta = CallMeta{}
+		}
+		for k, v := range meta {
+			req.Meta[k] = v
+		}
+		return ctx, nil
+	}
+	return ctx, nil
+}
+
+// resolve returns CallMeta satisfying all the schemes of the requirement or ErrCredentialNotFound
+// if a credential for one of them is missing or the scheme can't be attached to a request.
+func (ai AuthInterceptor) resolve(ctx context.Context, callCtx CallContext, requirement a2a.SecurityRequirements) (CallMeta, error) {
+	meta := CallMeta{}
+	for name := range requirement {
+		scheme, ok := callCtx.Card.SecuritySchemes[name]
+		if !ok {
+			return nil, ErrCredentialNotFound
+		}
+		credential, err := ai.Service.Get(ctx, callCtx.SessionID, string(name))
+		if err != nil {
+			return nil, err
+		}
+		if !attachCredential(meta, scheme, credential) {
+			return nil, ErrCredentialNotFound
+		}
+	}
+	return meta, nil
+}
+
+// attachCredential adds the credential to meta as described by the scheme. Returns false if the scheme
+// is not supported, eg. mutual TLS which is configured on the transport or an API key passed in a query.
+func attachCredential(meta CallMeta, scheme a2a.SecurityScheme, credential AuthCredential) bool {
+	switch s := scheme.(type) {
+	case a2a.HTTPAuthSecurityScheme:
+		authScheme := s.Scheme
+		if authScheme == "" || strings.EqualFold(authScheme, "bearer") {
+			authScheme = "Bearer"
+		} else if strings.EqualFold(authScheme, "basic") {
+			authScheme = "Basic"
+		}
+		meta["Authorization"] = authScheme + " " + string(credential)
+		return true
+	case a2a.OAuth2SecurityScheme, a2a.OpenIDConnectSecurityScheme:
+		meta["Authorization"] = "Bearer " + string(credential)
+		return true
+	case a2a.APIKeySecurityScheme:
+		switch s.In {
+		case a2a.APIKeySecuritySchemeInHeader:
+			meta[s.Name] = string(credential)
+			return true
+		case a2a.APIKeySecuritySchemeInCookie:
+			cookie := s.Name + "=" + string(credential)
+			if prev, ok := meta["Cookie"]; ok {
+				cookie = prev + "; " + cookie
+			}
+			meta["Cookie"] = cookie
+			return true
+		}
+	}
+	return false
+}
+
+// After makes the Client retry a call rejected with AuthChallengeError if Service implements
+// CredentialsRefresher and successfully refreshed credentials for the session.
+func (ai AuthInterceptor) After(ctx context.Context, resp *Response) error {
+	var challenge *AuthChallengeError
+	if !errors.As(resp.Err, &challenge) {
+		return nil
+	}
+
+	refresher, ok := ai.Service.(CredentialsRefresher)
+	if !ok {
+		return nil
+	}
+
+	callCtx, ok := CallContextFrom(ctx)
+	if !ok || callCtx.SessionID == "" {
+		return nil
+	}
+
+	if err := refresher.Refresh(ctx, callCtx.SessionID, challenge); err != nil {
+		return fmt.Errorf("failed to refresh credentials: %w", err)
+	}
+	resp.Retry = true
+	return nil
+}
+
 type SessionCredentials map[a2a.SecuritySchemeName]AuthCredential
 
+type credentialsKey struct {
+	agent AgentID
+	sid   SessionID
+}
+
 // InMemoryCredentialsStore implements CredentialsService.
+// Credentials set for an agent take precedence over credentials set for all agents.
+// The agent is determined using CallContext.Agent.
 type InMemoryCredentialsStore struct {
 	mu          sync.RWMutex
-	credentials map[SessionID]SessionCredentials
+	credentials map[credentialsKey]SessionCredentials
 }
 
 // NewInMemoryCredentialsStore initializes an InMemoryCredentialsStore.
 func NewInMemoryCredentialsStore() InMemoryCredentialsStore {
 	return InMemoryCredentialsStore{
-		credentials: make(map[SessionID]SessionCredentials),
+		credentials: make(map[credentialsKey]SessionCredentials),
 	}
 }
 
-func (s *InMemoryCredentialsStore) Get(ctx context.Context, sid SessionID, scheme a2a.SecuritySchemeName) (AuthCredential, error) {
+var _ CredentialsService = (*InMemoryCredentialsStore)(nil)
+
+func (s *InMemoryCredentialsStore) Get(ctx context.Context, sid SessionID, scheme string) (AuthCredential, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	forSession, ok := s.credentials[sid]
-	if !ok {
-		return AuthCredential(""), ErrCredentialNotFound
+	if callCtx, ok := CallContextFrom(ctx); ok && callCtx.Agent != "" {
+		if credential, ok := s.credentials[credentialsKey{agent: callCtx.Agent, sid: sid}][a2a.SecuritySchemeName(scheme)]; ok {
+			return credential, nil
+		}
 	}
 
-	credential, ok := forSession[scheme]
+	credential, ok := s.credentials[credentialsKey{sid: sid}][a2a.SecuritySchemeName(scheme)]
 	if !ok {
 		return AuthCredential(""), ErrCredentialNotFound
 	}
@@ -78,12 +227,19 @@ func (s *InMemoryCredentialsStore) Get(ctx context.Context, sid SessionID, schem
 	return credential, nil
 }
 
+// Set stores a credential used for all agents.
 func (s *InMemoryCredentialsStore) Set(sid SessionID, scheme a2a.SecuritySchemeName, credential AuthCredential) {
+	s.SetForAgent("", sid, scheme, credential)
+}
+
+// SetForAgent stores a credential used only for the provided agent.
+func (s *InMemoryCredentialsStore) SetForAgent(agent AgentID, sid SessionID, scheme a2a.SecuritySchemeName, credential AuthCredential) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, ok := s.credentials[sid]; !ok {
-		s.credentials[sid] = make(map[a2a.SecuritySchemeName]AuthCredential)
+	key := credentialsKey{agent: agent, sid: sid}
+	if _, ok := s.credentials[key]; !ok {
+		s.credentials[key] = make(map[a2a.SecuritySchemeName]AuthCredential)
 	}
-	s.credentials[sid][scheme] = credential
+	s.credentials[key][scheme] = credential
 }
@@ -0,0 +1,72 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// AuthRequiredError is returned when credentials requested for a Task couldn't be acquired.
+type AuthRequiredError struct {
+	// Task is the Task in TaskStateAuthRequired.
+	Task *a2a.Task
+	// Requirements are the credentials requested by the agent. Can be empty if the agent
+	// didn't specify them.
+	Requirements []a2a.AuthRequirement
+	// Err is the error returned by AuthAcquirer.
+	Err error
+}
+
+func (e *AuthRequiredError) Error() string {
+	return fmt.Sprintf("task %s requires authentication %v: %v", e.Task.ID, e.Requirements, e.Err)
+}
+
+func (e *AuthRequiredError) Unwrap() error {
+	return e.Err
+}
+
+// AuthRequirementsOf returns the credentials an agent requested for continuing the Task.
+// The second return value is false if the Task is not in TaskStateAuthRequired.
+func AuthRequirementsOf(task *a2a.Task) ([]a2a.AuthRequirement, bool) {
+	if task.Status.State != a2a.TaskStateAuthRequired {
+		return nil, false
+	}
+	if task.Status.Message == nil {
+		return nil, true
+	}
+	return a2a.AuthRequirementsFromMeta(task.Status.Message.Metadata), true
+}
+
+// AuthAcquirer is invoked for obtaining the credentials requested by an agent, eg. by running an
+// interactive OAuth flow and storing the result in the CredentialsService used by AuthInterceptor.
+type AuthAcquirer func(ctx context.Context, reqs []a2a.AuthRequirement) error
+
+// ResumeAfterAuth acquires the credentials requested for a Task in TaskStateAuthRequired and sends a
+// message with the provided parts to continue the Task. Returns AuthRequiredError if the credentials
+// couldn't be acquired.
+func (c *Client) ResumeAfterAuth(ctx context.Context, task *a2a.Task, acquire AuthAcquirer, parts ...a2a.Part) (a2a.SendMessageResult, error) {
+	reqs, ok := AuthRequirementsOf(task)
+	if !ok {
+		return nil, fmt.Errorf("task %s is in %s state, not %s", task.ID, task.Status.State, a2a.TaskStateAuthRequired)
+	}
+	if err := acquire(ctx, reqs); err != nil {
+		return nil, &AuthRequiredError{Task: task, Requirements: reqs, Err: err}
+	}
+	msg := a2a.NewMessageForTask(a2a.MessageRoleUser, *task, parts...)
+	return c.SendMessage(ctx, a2a.MessageSendParams{Message: *msg})
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func newAuthRequiredTask(reqs ...a2a.AuthRequirement) *a2a.Task {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	msg := a2a.NewMessageForTask(a2a.MessageRoleAgent, *task, a2a.TextPart{Text: "please log in"})
+	msg.Metadata = map[string]any{a2a.AuthRequirementsMetaKey: a2a.AuthRequirementsToMeta(reqs...)}
+	task.Status = a2a.TaskStatus{State: a2a.TaskStateAuthRequired, Message: msg}
+	return task
+}
+
+func TestAuthRequirementsOf(t *testing.T) {
+	want := a2a.AuthRequirement{Scheme: "oauth2", Scopes: []string{"read"}}
+	reqs, ok := AuthRequirementsOf(newAuthRequiredTask(want))
+	if !ok {
+		t.Fatal("AuthRequirementsOf() = false, want true")
+	}
+	if len(reqs) != 1 || reqs[0].Scheme != want.Scheme || reqs[0].Scopes[0] != "read" {
+		t.Fatalf("AuthRequirementsOf() = %v, want [%v]", reqs, want)
+	}
+
+	if _, ok := AuthRequirementsOf(&a2a.Task{Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}); ok {
+		t.Fatal("AuthRequirementsOf() for working task = true, want false")
+	}
+}
+
+func TestClient_ResumeAfterAuth(t *testing.T) {
+	task := newAuthRequiredTask(a2a.AuthRequirement{Scheme: "oauth2"})
+	var sent a2a.MessageSendParams
+	transport := &mockTransport{
+		SendMessageFunc: func(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+			sent = message
+			return &a2a.Task{ID: message.Message.TaskID}, nil
+		},
+	}
+	client := &Client{transport: transport}
+
+	var acquired []a2a.AuthRequirement
+	acquire := func(ctx context.Context, reqs []a2a.AuthRequirement) error {
+		acquired = reqs
+		return nil
+	}
+	if _, err := client.ResumeAfterAuth(t.Context(), task, acquire, a2a.TextPart{Text: "done"}); err != nil {
+		t.Fatalf("ResumeAfterAuth() error = %v", err)
+	}
+	if len(acquired) != 1 || acquired[0].Scheme != "oauth2" {
+		t.Fatalf("acquired = %v, want oauth2 requirement", acquired)
+	}
+	if sent.Message.TaskID != task.ID || sent.Message.ContextID != task.ContextID {
+		t.Fatalf("sent message = %+v, want message for task %s", sent.Message, task.ID)
+	}
+}
+
+func TestClient_ResumeAfterAuthFailures(t *testing.T) {
+	client := &Client{transport: &mockTransport{}}
+	acquireErr := errors.New("user declined")
+
+	_, err := client.ResumeAfterAuth(t.Context(), newAuthRequiredTask(), func(ctx context.Context, reqs []a2a.AuthRequirement) error {
+		return acquireErr
+	})
+	var authErr *AuthRequiredError
+	if !errors.As(err, &authErr) || !errors.Is(err, acquireErr) {
+		t.Fatalf("ResumeAfterAuth() error = %v, want AuthRequiredError wrapping %v", err, acquireErr)
+	}
+
+	working := &a2a.Task{ID: "task-1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+	if _, err := client.ResumeAfterAuth(t.Context(), working, nil); err == nil {
+		t.Fatal("ResumeAfterAuth() for working task succeeded")
+	}
+}
@@ -16,9 +16,23 @@ package a2aclient
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
 	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2apb"
 )
 
 func TestInMemoryCredentialsStore(t *testing.T) {
@@ -29,14 +43,14 @@ func TestInMemoryCredentialsStore(t *testing.T) {
 	cred := AuthCredential("test-credential")
 
 	// 1. Test getting a credential that doesn't exist
-	_, err := store.Get(ctx, sid, scheme)
+	_, err := store.Get(ctx, sid, string(scheme))
 	if err != ErrCredentialNotFound {
 		t.Errorf("expected ErrCredentialNotFound, got %v", err)
 	}
 
 	// 2. Test setting and getting a credential
 	store.Set(sid, scheme, cred)
-	retrievedCred, err := store.Get(ctx, sid, scheme)
+	retrievedCred, err := store.Get(ctx, sid, string(scheme))
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -47,7 +61,7 @@ func TestInMemoryCredentialsStore(t *testing.T) {
 	// 3. Test overwriting a credential
 	newCred := AuthCredential("new-credential")
 	store.Set(sid, scheme, newCred)
-	retrievedCred, err = store.Get(ctx, sid, scheme)
+	retrievedCred, err = store.Get(ctx, sid, string(scheme))
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -57,15 +71,389 @@ func TestInMemoryCredentialsStore(t *testing.T) {
 
 	// 4. Test getting a credential for a different scheme
 	otherScheme := a2a.SecuritySchemeName("other-scheme")
-	_, err = store.Get(ctx, sid, otherScheme)
+	_, err = store.Get(ctx, sid, string(otherScheme))
 	if err != ErrCredentialNotFound {
 		t.Errorf("expected ErrCredentialNotFound, got %v", err)
 	}
 
 	// 5. Test getting a credential for a different session
 	otherSid := SessionID("other-session")
-	_, err = store.Get(ctx, otherSid, scheme)
+	_, err = store.Get(ctx, otherSid, string(scheme))
 	if err != ErrCredentialNotFound {
 		t.Errorf("expected ErrCredentialNotFound, got %v", err)
 	}
 }
+
+type staticCredentialsService struct{}
+
+func (staticCredentialsService) Get(ctx context.Context, sid SessionID, scheme string) (AuthCredential, error) {
+	return AuthCredential("token"), nil
+}
+
+type refreshingCredentialsService struct {
+	refreshed  int
+	refreshErr error
+}
+
+// Get returns a credential which changes after every refresh.
+func (s *refreshingCredentialsService) Get(ctx context.Context, sid SessionID, scheme string) (AuthCredential, error) {
+	return AuthCredential(fmt.Sprintf("token-%d", s.refreshed)), nil
+}
+
+// bearerAuthCard requires credentials of an HTTP bearer scheme.
+var bearerAuthCard = &a2a.AgentCard{
+	URL:             "https://agent.example.com",
+	Security:        []a2a.SecurityRequirements{{"bearer": {}}},
+	SecuritySchemes: a2a.NamedSecuritySchemes{"bearer": a2a.HTTPAuthSecurityScheme{Scheme: "bearer"}},
+}
+
+func (s *refreshingCredentialsService) Refresh(ctx context.Context, sid SessionID, challenge *AuthChallengeError) error {
+	s.refreshed++
+	return s.refreshErr
+}
+
+func TestAuthInterceptor_RefreshOnChallenge(t *testing.T) {
+	challenge := &AuthChallengeError{Challenge: `Bearer error="invalid_token"`, Err: errors.New("401")}
+	service := &refreshingCredentialsService{}
+	var sent []string
+	transport := &mockTransport{
+		GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			meta, _ := CallMetaFrom(ctx)
+			sent = append(sent, meta["Authorization"])
+			if meta["Authorization"] != "Bearer token-1" {
+				return nil, challenge
+			}
+			return &a2a.Task{ID: query.ID}, nil
+		},
+	}
+	client := &Client{transport: transport, card: bearerAuthCard}
+	client.AddCallInterceptor(&AuthInterceptor{Service: service})
+
+	ctx := WithSessionID(t.Context(), "session")
+	task, err := client.GetTask(ctx, a2a.TaskQueryParams{ID: "task-1"})
+	if err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if task.ID != "task-1" {
+		t.Fatalf("GetTask() = %v, want task-1", task)
+	}
+	if service.refreshed != 1 {
+		t.Fatalf("refreshed %d times, want 1", service.refreshed)
+	}
+	if want := []string{"Bearer token-0", "Bearer token-1"}; !slices.Equal(sent, want) {
+		t.Fatalf("sent Authorization %q, want %q", sent, want)
+	}
+}
+
+func TestAuthInterceptor_AttachesCredentials(t *testing.T) {
+	schemes := a2a.NamedSecuritySchemes{
+		"bearer": a2a.HTTPAuthSecurityScheme{Scheme: "bearer"},
+		"basic":  a2a.HTTPAuthSecurityScheme{Scheme: "Basic"},
+		"oauth":  a2a.OAuth2SecurityScheme{},
+		"key":    a2a.APIKeySecurityScheme{In: a2a.APIKeySecuritySchemeInHeader, Name: "X-API-Key"},
+		"cookie": a2a.APIKeySecurityScheme{In: a2a.APIKeySecuritySchemeInCookie, Name: "session"},
+		"query":  a2a.APIKeySecurityScheme{In: a2a.APIKeySecuritySchemeInQuery, Name: "key"},
+		"mtls":   a2a.MutualTLSSecurityScheme{},
+	}
+	store := NewInMemoryCredentialsStore()
+	for name := range schemes {
+		store.Set("session", name, AuthCredential("cred-"+name))
+	}
+	store.Set("session", "unknown", "cred-unknown")
+
+	testCases := []struct {
+		name     string
+		security []a2a.SecurityRequirements
+		sid      SessionID
+		want     CallMeta
+	}{
+		{name: "bearer", security: []a2a.SecurityRequirements{{"bearer": {}}}, sid: "session", want: CallMeta{"Authorization": "Bearer cred-bearer"}},
+		{name: "basic", security: []a2a.SecurityRequirements{{"basic": {}}}, sid: "session", want: CallMeta{"Authorization": "Basic cred-basic"}},
+		{name: "oauth2", security: []a2a.SecurityRequirements{{"oauth": {"read"}}}, sid: "session", want: CallMeta{"Authorization": "Bearer cred-oauth"}},
+		{name: "api key header", security: []a2a.SecurityRequirements{{"key": {}}}, sid: "session", want: CallMeta{"X-API-Key": "cred-key"}},
+		{name: "api key cookie", security: []a2a.SecurityRequirements{{"cookie": {}}}, sid: "session", want: CallMeta{"Cookie": "session=cred-cookie"}},
+		{
+			name:     "all schemes of a requirement",
+			security: []a2a.SecurityRequirements{{"bearer": {}, "key": {}}},
+			sid:      "session",
+			want:     CallMeta{"Authorization": "Bearer cred-bearer", "X-API-Key": "cred-key"},
+		},
+		{
+			name:     "falls back to a satisfiable requirement",
+			security: []a2a.SecurityRequirements{{"query": {}}, {"mtls": {}}, {"unknown": {}}, {"key": {}}},
+			sid:      "session",
+			want:     CallMeta{"X-API-Key": "cred-key"},
+		},
+		{name: "no credentials", security: []a2a.SecurityRequirements{{"bearer": {}}}, sid: "other", want: CallMeta{}},
+		{name: "no session", security: []a2a.SecurityRequirements{{"bearer": {}}}, want: CallMeta{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got CallMeta
+			transport := &mockTransport{
+				GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+					got, _ = CallMetaFrom(ctx)
+					return &a2a.Task{ID: query.ID}, nil
+				},
+			}
+			card := &a2a.AgentCard{Security: tc.security, SecuritySchemes: schemes}
+			client := &Client{transport: transport, card: card}
+			client.AddCallInterceptor(AuthInterceptor{Service: &store})
+
+			ctx := t.Context()
+			if tc.sid != "" {
+				ctx = WithSessionID(ctx, tc.sid)
+			}
+			if _, err := client.GetTask(ctx, a2a.TaskQueryParams{ID: "task-1"}); err != nil {
+				t.Fatalf("GetTask() error = %v", err)
+			}
+			if !maps.Equal(got, tc.want) {
+				t.Fatalf("sent meta %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAuthInterceptor_CredentialsError(t *testing.T) {
+	storeErr := errors.New("keyring locked")
+	called := false
+	transport := &mockTransport{
+		GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			called = true
+			return &a2a.Task{}, nil
+		},
+	}
+	client := &Client{transport: transport, card: bearerAuthCard}
+	client.AddCallInterceptor(AuthInterceptor{Service: failingCredentialsService{err: storeErr}})
+
+	if _, err := client.GetTask(WithSessionID(t.Context(), "session"), a2a.TaskQueryParams{}); !errors.Is(err, storeErr) {
+		t.Fatalf("GetTask() error = %v, want %v", err, storeErr)
+	}
+	if called {
+		t.Fatal("transport called after credentials lookup failed")
+	}
+}
+
+type failingCredentialsService struct{ err error }
+
+func (s failingCredentialsService) Get(ctx context.Context, sid SessionID, scheme string) (AuthCredential, error) {
+	return AuthCredential(""), s.err
+}
+
+func TestAuthInterceptor_NoRetry(t *testing.T) {
+	challenge := &AuthChallengeError{Err: errors.New("401")}
+	refreshErr := errors.New("refresh token revoked")
+
+	testCases := []struct {
+		name    string
+		service CredentialsService
+		sid     SessionID
+		wantErr error
+	}{
+		{name: "refresh not supported", service: &staticCredentialsService{}, sid: "session", wantErr: challenge},
+		{name: "no session", service: &refreshingCredentialsService{}, wantErr: challenge},
+		{name: "refresh failed", service: &refreshingCredentialsService{refreshErr: refreshErr}, sid: "session", wantErr: refreshErr},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			transport := &mockTransport{
+				GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+					calls++
+					return nil, challenge
+				},
+			}
+			client := &Client{transport: transport}
+			client.AddCallInterceptor(&AuthInterceptor{Service: tc.service})
+
+			ctx := t.Context()
+			if tc.sid != "" {
+				ctx = WithSessionID(ctx, tc.sid)
+			}
+			if _, err := client.GetTask(ctx, a2a.TaskQueryParams{}); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("GetTask() error = %v, want %v", err, tc.wantErr)
+			}
+			if calls != 1 {
+				t.Fatalf("transport called %d times, want 1", calls)
+			}
+		})
+	}
+}
+
+func TestInMemoryCredentialsStore_AgentScoped(t *testing.T) {
+	store := NewInMemoryCredentialsStore()
+	sid := SessionID("test-session")
+	scheme := a2a.SecuritySchemeName("oauth2")
+
+	store.Set(sid, scheme, "shared")
+	store.SetForAgent("https://agent-a.com", sid, scheme, "agent-a")
+
+	testCases := []struct {
+		agent AgentID
+		want  AuthCredential
+	}{
+		{agent: "", want: "shared"},
+		{agent: "https://agent-a.com", want: "agent-a"},
+		{agent: "https://agent-b.com", want: "shared"},
+	}
+	for _, tc := range testCases {
+		ctx := t.Context()
+		if tc.agent != "" {
+			ctx = WithAgentID(ctx, tc.agent)
+		}
+		got, err := store.Get(ctx, sid, string(scheme))
+		if err != nil {
+			t.Fatalf("Get() for agent %q error = %v", tc.agent, err)
+		}
+		if got != tc.want {
+			t.Errorf("Get() for agent %q = %q, want %q", tc.agent, got, tc.want)
+		}
+	}
+
+	onlyAgent := NewInMemoryCredentialsStore()
+	onlyAgent.SetForAgent("https://agent-a.com", sid, scheme, "agent-a")
+	if _, err := onlyAgent.Get(WithAgentID(t.Context(), "https://agent-b.com"), sid, string(scheme)); err != ErrCredentialNotFound {
+		t.Errorf("Get() for other agent error = %v, want %v", err, ErrCredentialNotFound)
+	}
+}
+
+// challengingA2AServer rejects GetTask calls which are not authorized with the refreshed token.
+type challengingA2AServer struct {
+	a2apb.UnimplementedA2AServiceServer
+	mu         sync.Mutex
+	authorized []string
+}
+
+func (s *challengingA2AServer) GetTask(ctx context.Context, req *a2apb.GetTaskRequest) (*a2apb.Task, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	auth := strings.Join(md.Get("authorization"), ",")
+	s.mu.Lock()
+	s.authorized = append(s.authorized, auth)
+	s.mu.Unlock()
+	if auth != "Bearer token-1" {
+		return nil, status.Error(codes.Unauthenticated, "token expired")
+	}
+	return &a2apb.Task{Id: "task-1", ContextId: "ctx-1", Status: &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_WORKING}}, nil
+}
+
+func (s *challengingA2AServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.authorized)
+}
+
+func TestAuthInterceptor_TransportChallenge(t *testing.T) {
+	testCases := []struct {
+		name          string
+		transport     func(t *testing.T) (Transport, func() []string)
+		wantChallenge string
+	}{
+		{
+			name: "HTTP+JSON 401",
+			transport: func(t *testing.T) (Transport, func() []string) {
+				var mu sync.Mutex
+				var authorized []string
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					auth := r.Header.Get("Authorization")
+					mu.Lock()
+					authorized = append(authorized, auth)
+					mu.Unlock()
+					if auth != "Bearer token-1" {
+						w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+						w.WriteHeader(http.StatusUnauthorized)
+						return
+					}
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(a2a.Task{ID: "task-1", ContextID: "ctx-1"})
+				}))
+				t.Cleanup(server.Close)
+				return NewHTTPJSONTransport(server.URL, server.Client()), func() []string {
+					mu.Lock()
+					defer mu.Unlock()
+					return slices.Clone(authorized)
+				}
+			},
+			wantChallenge: `Bearer error="invalid_token"`,
+		},
+		{
+			name: "gRPC UNAUTHENTICATED",
+			transport: func(t *testing.T) (Transport, func() []string) {
+				srv := &challengingA2AServer{}
+				return newFakeGRPCTransport(t, srv), srv.received
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transport, received := tc.transport(t)
+			service := &challengeRecordingService{}
+			client := &Client{transport: transport, card: bearerAuthCard}
+			client.AddCallInterceptor(&AuthInterceptor{Service: service})
+
+			task, err := client.GetTask(WithSessionID(t.Context(), "session"), a2a.TaskQueryParams{ID: "task-1"})
+			if err != nil {
+				t.Fatalf("GetTask() error = %v", err)
+			}
+			if task.ID != "task-1" {
+				t.Fatalf("GetTask() = %v, want task-1", task)
+			}
+			if len(service.challenges) != 1 {
+				t.Fatalf("refreshed %d times, want 1", len(service.challenges))
+			}
+			if got := service.challenges[0].Challenge; got != tc.wantChallenge {
+				t.Fatalf("refreshed with challenge %q, want %q", got, tc.wantChallenge)
+			}
+			if got, want := received(), []string{"Bearer token-0", "Bearer token-1"}; !slices.Equal(got, want) {
+				t.Fatalf("server received Authorization %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// challengeRecordingService records the challenges it was asked to refresh credentials for.
+// The credential it returns changes after every refresh.
+type challengeRecordingService struct {
+	challenges []*AuthChallengeError
+}
+
+func (s *challengeRecordingService) Get(ctx context.Context, sid SessionID, scheme string) (AuthCredential, error) {
+	return AuthCredential(fmt.Sprintf("token-%d", len(s.challenges))), nil
+}
+
+func (s *challengeRecordingService) Refresh(ctx context.Context, sid SessionID, challenge *AuthChallengeError) error {
+	s.challenges = append(s.challenges, challenge)
+	return nil
+}
+
+func TestAuthInterceptor_AgentScopedCredentials(t *testing.T) {
+	store := NewInMemoryCredentialsStore()
+	store.Set("session", "bearer", "shared")
+
+	newAgent := func() (*Client, *string) {
+		var got string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header.Get("Authorization")
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(a2a.Task{ID: "task-1", ContextID: "ctx-1"})
+		}))
+		t.Cleanup(server.Close)
+		card := *bearerAuthCard
+		card.URL = server.URL
+		client := &Client{transport: NewHTTPJSONTransport(server.URL, server.Client()), card: &card}
+		client.AddCallInterceptor(AuthInterceptor{Service: &store})
+		return client, &got
+	}
+	clientA, gotA := newAgent()
+	clientB, gotB := newAgent()
+	store.SetForAgent(AgentID(clientA.AgentCard().URL), "session", "bearer", "agent-a")
+
+	ctx := WithSessionID(t.Context(), "session")
+	for _, client := range []*Client{clientA, clientB} {
+		if _, err := client.GetTask(ctx, a2a.TaskQueryParams{ID: "task-1"}); err != nil {
+			t.Fatalf("GetTask() error = %v", err)
+		}
+	}
+	if *gotA != "Bearer agent-a" || *gotB != "Bearer shared" {
+		t.Fatalf("agents received Authorization (%q, %q), want (Bearer agent-a, Bearer shared)", *gotA, *gotB)
+	}
+}
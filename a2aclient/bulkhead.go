@@ -0,0 +1,139 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBulkheadFull is returned by calls rejected by Bulkhead because too many calls to the agent are in progress.
+var ErrBulkheadFull = errors.New("too many concurrent calls to agent")
+
+const defaultBulkheadMaxConcurrent = 10
+
+// BulkheadConfig configures a Bulkhead.
+type BulkheadConfig struct {
+	// MaxConcurrent is the maximum number of calls to a single agent in progress. Defaults to 10.
+	MaxConcurrent int
+	// MaxQueued is the maximum number of calls to a single agent waiting for a slot. Calls beyond the limit are
+	// rejected with ErrBulkheadFull immediately. Zero means the number of waiting calls is not limited.
+	MaxQueued int
+	// QueueTimeout is the maximum time a call waits for a slot before it's rejected with ErrBulkheadFull.
+	// Zero means calls wait until their context is done.
+	QueueTimeout time.Duration
+}
+
+// Bulkhead is a CallInterceptor which limits the number of concurrent calls to every agent, identified
+// by CallContext.Agent, so that one slow agent can't consume all goroutines and connections of a process
+// which fans out to many agents. A streaming call holds its slot until the stream ends.
+// A single Bulkhead should be shared by all Clients and added as the last interceptor, so that a slot
+// is always released by After.
+type Bulkhead struct {
+	config BulkheadConfig
+
+	mu           sync.Mutex
+	compartments map[AgentID]*compartment
+}
+
+var _ CallInterceptor = (*Bulkhead)(nil)
+
+type compartment struct {
+	slots   chan struct{}
+	waiting int
+}
+
+type bulkheadReleaseKey struct{}
+
+// NewBulkhead creates a Bulkhead.
+func NewBulkhead(config BulkheadConfig) *Bulkhead {
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = defaultBulkheadMaxConcurrent
+	}
+	return &Bulkhead{config: config, compartments: make(map[AgentID]*compartment)}
+}
+
+// InFlight returns the number of calls to the agent in progress.
+func (b *Bulkhead) InFlight(agent AgentID) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if c, ok := b.compartments[agent]; ok {
+		return len(c.slots)
+	}
+	return 0
+}
+
+// Before waits for a free slot of the agent.
+func (b *Bulkhead) Before(ctx context.Context, req *Request) (context.Context, error) {
+	callCtx, _ := CallContextFrom(ctx)
+
+	b.mu.Lock()
+	c, ok := b.compartments[callCtx.Agent]
+	if !ok {
+		c = &compartment{slots: make(chan struct{}, b.config.MaxConcurrent)}
+		b.compartments[callCtx.Agent] = c
+	}
+	select {
+	case c.slots <- struct{}{}:
+		b.mu.Unlock()
+		return b.acquired(ctx, c), nil
+	default:
+	}
+	if b.config.MaxQueued > 0 && c.waiting >= b.config.MaxQueued {
+		b.mu.Unlock()
+		return ctx, fmt.Errorf("%w: %s", ErrBulkheadFull, callCtx.Agent)
+	}
+	c.waiting++
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		c.waiting--
+		b.mu.Unlock()
+	}()
+
+	var timeout <-chan time.Time
+	if b.config.QueueTimeout > 0 {
+		timer := time.NewTimer(b.config.QueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case c.slots <- struct{}{}:
+		return b.acquired(ctx, c), nil
+	case <-timeout:
+		return ctx, fmt.Errorf("%w: %s: no slot within %v", ErrBulkheadFull, callCtx.Agent, b.config.QueueTimeout)
+	case <-ctx.Done():
+		return ctx, context.Cause(ctx)
+	}
+}
+
+func (b *Bulkhead) acquired(ctx context.Context, c *compartment) context.Context {
+	var once sync.Once
+	release := func() {
+		once.Do(func() { <-c.slots })
+	}
+	return context.WithValue(ctx, bulkheadReleaseKey{}, release)
+}
+
+// After releases the slot acquired by Before.
+func (b *Bulkhead) After(ctx context.Context, resp *Response) error {
+	if release, ok := ctx.Value(bulkheadReleaseKey{}).(func()); ok {
+		release()
+	}
+	return nil
+}
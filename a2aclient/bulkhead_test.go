@@ -0,0 +1,109 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestBulkhead(t *testing.T) {
+	ctx := WithAgentID(t.Context(), "slow-agent")
+	bulkhead := NewBulkhead(BulkheadConfig{MaxConcurrent: 2, MaxQueued: 1, QueueTimeout: 50 * time.Millisecond})
+
+	var releases []context.Context
+	for range 2 {
+		callCtx, err := bulkhead.Before(ctx, &Request{})
+		if err != nil {
+			t.Fatalf("Before() error = %v", err)
+		}
+		releases = append(releases, callCtx)
+	}
+	if got := bulkhead.InFlight("slow-agent"); got != 2 {
+		t.Fatalf("InFlight() = %d, want 2", got)
+	}
+	if _, err := bulkhead.Before(WithAgentID(t.Context(), "fast-agent"), &Request{}); err != nil {
+		t.Fatalf("Before() for another agent error = %v", err)
+	}
+
+	if _, err := bulkhead.Before(ctx, &Request{}); !errors.Is(err, ErrBulkheadFull) {
+		t.Fatalf("Before() after queue timeout error = %v, want %v", err, ErrBulkheadFull)
+	}
+
+	queued := make(chan error, 1)
+	go func() {
+		_, err := bulkhead.Before(ctx, &Request{})
+		queued <- err
+	}()
+	for !bulkheadWaiting(bulkhead, "slow-agent") {
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := bulkhead.Before(ctx, &Request{}); !errors.Is(err, ErrBulkheadFull) {
+		t.Fatalf("Before() with full queue error = %v, want %v", err, ErrBulkheadFull)
+	}
+
+	_ = bulkhead.After(releases[0], &Response{})
+	_ = bulkhead.After(releases[0], &Response{})
+	if err := <-queued; err != nil {
+		t.Fatalf("queued Before() error = %v", err)
+	}
+	if got := bulkhead.InFlight("slow-agent"); got != 2 {
+		t.Fatalf("InFlight() after repeated release = %d, want 2", got)
+	}
+}
+
+func bulkheadWaiting(b *Bulkhead, agent AgentID) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.compartments[agent].waiting > 0
+}
+
+func TestBulkhead_Client(t *testing.T) {
+	ctx := WithAgentID(t.Context(), "agent")
+	bulkhead := NewBulkhead(BulkheadConfig{MaxConcurrent: 1})
+	transport := &mockTransport{
+		GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			return &a2a.Task{ID: query.ID}, nil
+		},
+		StreamFunc: func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+			return func(yield func(a2a.Event, error) bool) {
+				yield(&a2a.Task{ID: "task-1"}, nil)
+			}
+		},
+	}
+	client := &Client{transport: transport, interceptors: []CallInterceptor{bulkhead}}
+
+	for range 3 {
+		if _, err := client.GetTask(ctx, a2a.TaskQueryParams{ID: "task-1"}); err != nil {
+			t.Fatalf("GetTask() error = %v", err)
+		}
+	}
+	for _, err := range client.SendStreamingMessage(ctx, a2a.MessageSendParams{}) {
+		if err != nil {
+			t.Fatalf("SendStreamingMessage() error = %v", err)
+		}
+		if got := bulkhead.InFlight("agent"); got != 1 {
+			t.Fatalf("InFlight() during stream = %d, want 1", got)
+		}
+	}
+	if got := bulkhead.InFlight("agent"); got != 0 {
+		t.Fatalf("InFlight() after calls = %d, want 0", got)
+	}
+}
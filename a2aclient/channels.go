@@ -0,0 +1,89 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// ToChannels runs the event iterator in a separate goroutine and delivers its events over a channel.
+// If the iteration fails, the error is delivered over the error channel before the event channel is closed.
+// Both channels are closed when the iteration ends. Calling cancel stops the iteration after the event
+// which is being delivered. It is safe to call cancel more than once and after the iteration ended.
+func ToChannels(seq iter.Seq2[a2a.Event, error]) (<-chan a2a.Event, <-chan error, func()) {
+	events := make(chan a2a.Event)
+	errs := make(chan error, 1)
+	done := make(chan struct{})
+	var once sync.Once
+	cancel := func() { once.Do(func() { close(done) }) }
+
+	go func() {
+		defer close(errs)
+		defer close(events)
+		for event, err := range seq {
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case events <- event:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return events, errs, cancel
+}
+
+// FromChannels creates an event iterator from channel-based producers. It can be used for implementing
+// Transport streaming methods. The producer must close events when it's done and can report a failure
+// by sending an error to errs before closing events. errs can be nil if the producer doesn't fail.
+// Iteration stops after the first error.
+func FromChannels(events <-chan a2a.Event, errs <-chan error) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					// the error is sent before events are closed, so it's available without blocking
+					select {
+					case err, ok := <-errs:
+						if ok && err != nil {
+							yield(nil, err)
+						}
+					default:
+					}
+					return
+				}
+				if !yield(event, nil) {
+					return
+				}
+			case err, ok := <-errs:
+				if !ok {
+					// the error channel is done, only wait for events
+					errs = nil
+					continue
+				}
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+		}
+	}
+}
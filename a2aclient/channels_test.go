@@ -0,0 +1,116 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestToChannels(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	want := []a2a.Event{task, a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil)}
+	streamErr := errors.New("connection reset")
+
+	events, errs, cancel := ToChannels(eventSeq(want, streamErr))
+	defer cancel()
+
+	var got []a2a.Event
+	for event := range events {
+		got = append(got, event)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ToChannels() events = %v, want %v", got, want)
+	}
+	if err := <-errs; !errors.Is(err, streamErr) {
+		t.Fatalf("ToChannels() error = %v, want %v", err, streamErr)
+	}
+	if err, ok := <-errs; ok {
+		t.Fatalf("ToChannels() second error = %v, want closed channel", err)
+	}
+}
+
+func TestToChannels_Cancel(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	stopped := make(chan struct{})
+	infinite := func(yield func(a2a.Event, error) bool) {
+		defer close(stopped)
+		for yield(task, nil) {
+		}
+	}
+
+	events, errs, cancel := ToChannels(infinite)
+	if event := <-events; event != task {
+		t.Fatalf("ToChannels() event = %v, want %v", event, task)
+	}
+	cancel()
+	cancel()
+	<-stopped
+	for range events {
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("ToChannels() error = %v after cancel, want nil", err)
+	}
+}
+
+func TestFromChannels(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	want := []a2a.Event{task, a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil)}
+	streamErr := errors.New("connection reset")
+
+	tests := []struct {
+		name    string
+		err     error
+		nilErrs bool
+	}{
+		{name: "success"},
+		{name: "failure", err: streamErr},
+		{name: "nil error channel", nilErrs: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			events := make(chan a2a.Event)
+			var errs chan error
+			if !tc.nilErrs {
+				errs = make(chan error, 1)
+			}
+			go func() {
+				for _, event := range want {
+					events <- event
+				}
+				if tc.err != nil {
+					errs <- tc.err
+				}
+				close(events)
+			}()
+
+			var got []a2a.Event
+			var gotErr error
+			for event, err := range FromChannels(events, errs) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				got = append(got, event)
+			}
+			if !reflect.DeepEqual(got, want) || !errors.Is(gotErr, tc.err) {
+				t.Fatalf("FromChannels() = %v, %v, want %v, %v", got, gotErr, want, tc.err)
+			}
+		})
+	}
+}
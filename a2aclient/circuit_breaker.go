@@ -0,0 +1,282 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// ErrCircuitOpen is returned by calls rejected by CircuitBreaker without reaching the agent.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a CircuitBreaker circuit.
+type CircuitState string
+
+const (
+	// CircuitClosed lets all calls through while tracking their failure rate.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects all calls with ErrCircuitOpen.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a single probe call through to check whether the agent recovered.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+const (
+	defaultCircuitWindow      = 30 * time.Second
+	defaultCircuitMinRequests = 10
+	defaultCircuitFailureRate = 0.5
+	defaultCircuitOpenTimeout = 30 * time.Second
+	circuitWindowBuckets      = 10
+)
+
+// CircuitBreakerConfig configures a CircuitBreaker. Zero values are replaced with defaults.
+type CircuitBreakerConfig struct {
+	// Window is the period over which the failure rate is computed. Defaults to 30 seconds.
+	Window time.Duration
+	// MinRequests is the number of calls within Window required before the circuit can open. Defaults to 10.
+	MinRequests int
+	// FailureRate is the ratio of failed calls within Window which opens the circuit. Defaults to 0.5.
+	FailureRate float64
+	// OpenTimeout is the time the circuit stays open before a probe call is let through. Defaults to 30 seconds.
+	OpenTimeout time.Duration
+	// HalfOpenSuccesses is the number of consecutive successful probes which close the circuit. Defaults to 1.
+	HalfOpenSuccesses int
+	// IsFailure reports whether a call error counts as an agent failure. By default all errors count, except
+	// cancellations by the caller and errors the agent reports for invalid or unsupported requests.
+	IsFailure func(err error) bool
+	// OnStateChange is called every time a circuit changes its state.
+	OnStateChange func(agent AgentID, from, to CircuitState)
+}
+
+// CircuitBreaker is a CallInterceptor which tracks calls to every agent, identified by CallContext.Agent,
+// separately. Once the failure rate of an agent exceeds the configured threshold, calls to it fail fast with
+// ErrCircuitOpen until a probe call after CircuitBreakerConfig.OpenTimeout succeeds.
+// A single CircuitBreaker should be shared by all Clients, so that it's not reset when Clients are recreated.
+type CircuitBreaker struct {
+	config CircuitBreakerConfig
+	now    func() time.Time
+
+	mu       sync.Mutex
+	circuits map[AgentID]*circuit
+	changes  []circuitStateChange
+}
+
+var _ CallInterceptor = (*CircuitBreaker)(nil)
+
+type circuit struct {
+	state    CircuitState
+	openedAt time.Time
+
+	// buckets count calls in consecutive slices of the window, bucketStart is the start of the latest one.
+	buckets     [circuitWindowBuckets]circuitBucket
+	bucketStart time.Time
+
+	probing      bool
+	probeStarted time.Time
+	successes    int
+}
+
+type circuitBucket struct {
+	total, failed int
+}
+
+type circuitStateChange struct {
+	agent    AgentID
+	from, to CircuitState
+}
+
+type circuitProbeKey struct{}
+
+// NewCircuitBreaker creates a CircuitBreaker.
+func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
+	if config.Window <= 0 {
+		config.Window = defaultCircuitWindow
+	}
+	if config.MinRequests <= 0 {
+		config.MinRequests = defaultCircuitMinRequests
+	}
+	if config.FailureRate <= 0 {
+		config.FailureRate = defaultCircuitFailureRate
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = defaultCircuitOpenTimeout
+	}
+	if config.HalfOpenSuccesses <= 0 {
+		config.HalfOpenSuccesses = 1
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = isAgentFailure
+	}
+	return &CircuitBreaker{config: config, now: time.Now, circuits: make(map[AgentID]*circuit)}
+}
+
+// isAgentFailure is the default CircuitBreakerConfig.IsFailure.
+func isAgentFailure(err error) bool {
+	switch {
+	case err == nil,
+		errors.Is(err, context.Canceled),
+		errors.Is(err, a2a.ErrTaskNotFound),
+		errors.Is(err, a2a.ErrTaskNotCancelable),
+		errors.Is(err, a2a.ErrPushNotificationNotSupported),
+		errors.Is(err, a2a.ErrUnsupportedOperation),
+		errors.Is(err, a2a.ErrUnsupportedContentType),
+		errors.Is(err, a2a.ErrInvalidRequest),
+		errors.Is(err, a2a.ErrAuthenticatedExtendedCardNotConfigured):
+		return false
+	default:
+		return true
+	}
+}
+
+// State returns the state of the circuit of the agent.
+func (b *CircuitBreaker) State(agent AgentID) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.circuits[agent]
+	if !ok {
+		return CircuitClosed
+	}
+	return c.state
+}
+
+// Before rejects the call with ErrCircuitOpen if the circuit of the agent is open.
+func (b *CircuitBreaker) Before(ctx context.Context, req *Request) (context.Context, error) {
+	callCtx, _ := CallContextFrom(ctx)
+
+	b.mu.Lock()
+	defer b.unlock()
+	c := b.circuit(callCtx.Agent)
+	now := b.now()
+	if c.state == CircuitOpen && now.Sub(c.openedAt) >= b.config.OpenTimeout {
+		b.transition(callCtx.Agent, c, CircuitHalfOpen)
+	}
+	switch c.state {
+	case CircuitOpen:
+		return ctx, fmt.Errorf("%w: %s", ErrCircuitOpen, callCtx.Agent)
+	case CircuitHalfOpen:
+		// a probe which didn't report back, for example because a later interceptor rejected it, expires
+		if c.probing && now.Sub(c.probeStarted) < b.config.OpenTimeout {
+			return ctx, fmt.Errorf("%w: %s", ErrCircuitOpen, callCtx.Agent)
+		}
+		c.probing, c.probeStarted = true, now
+		return context.WithValue(ctx, circuitProbeKey{}, true), nil
+	default:
+		return ctx, nil
+	}
+}
+
+// After records the outcome of the call.
+func (b *CircuitBreaker) After(ctx context.Context, resp *Response) error {
+	callCtx, _ := CallContextFrom(ctx)
+	failed := b.config.IsFailure(resp.Err)
+
+	b.mu.Lock()
+	defer b.unlock()
+	c := b.circuit(callCtx.Agent)
+	if probe, _ := ctx.Value(circuitProbeKey{}).(bool); probe {
+		c.probing = false
+		if c.state != CircuitHalfOpen {
+			return nil
+		}
+		if failed {
+			b.open(callCtx.Agent, c)
+			return nil
+		}
+		if c.successes++; c.successes >= b.config.HalfOpenSuccesses {
+			c.buckets = [circuitWindowBuckets]circuitBucket{}
+			b.transition(callCtx.Agent, c, CircuitClosed)
+		}
+		return nil
+	}
+	if c.state != CircuitClosed {
+		return nil
+	}
+
+	bucket := b.advance(c)
+	bucket.total++
+	if failed {
+		bucket.failed++
+	}
+	total, failures := 0, 0
+	for _, bucket := range c.buckets {
+		total += bucket.total
+		failures += bucket.failed
+	}
+	if total >= b.config.MinRequests && float64(failures) >= b.config.FailureRate*float64(total) {
+		b.open(callCtx.Agent, c)
+	}
+	return nil
+}
+
+// unlock releases the mutex and reports the state changes made while it was held.
+func (b *CircuitBreaker) unlock() {
+	changes := b.changes
+	b.changes = nil
+	b.mu.Unlock()
+	if b.config.OnStateChange == nil {
+		return
+	}
+	for _, change := range changes {
+		b.config.OnStateChange(change.agent, change.from, change.to)
+	}
+}
+
+func (b *CircuitBreaker) circuit(agent AgentID) *circuit {
+	c, ok := b.circuits[agent]
+	if !ok {
+		c = &circuit{state: CircuitClosed}
+		b.circuits[agent] = c
+	}
+	return c
+}
+
+// advance drops the buckets which fell out of the window and returns the current bucket.
+func (b *CircuitBreaker) advance(c *circuit) *circuitBucket {
+	width := b.config.Window / circuitWindowBuckets
+	now := b.now()
+	if c.bucketStart.IsZero() {
+		c.bucketStart = now
+	}
+	for steps := 0; now.Sub(c.bucketStart) >= width; steps++ {
+		if steps == circuitWindowBuckets {
+			c.buckets = [circuitWindowBuckets]circuitBucket{}
+			c.bucketStart = now
+			break
+		}
+		copy(c.buckets[:], c.buckets[1:])
+		c.buckets[circuitWindowBuckets-1] = circuitBucket{}
+		c.bucketStart = c.bucketStart.Add(width)
+	}
+	return &c.buckets[circuitWindowBuckets-1]
+}
+
+func (b *CircuitBreaker) open(agent AgentID, c *circuit) {
+	c.openedAt = b.now()
+	b.transition(agent, c, CircuitOpen)
+}
+
+func (b *CircuitBreaker) transition(agent AgentID, c *circuit, to CircuitState) {
+	from := c.state
+	c.state, c.successes = to, 0
+	if from != to {
+		b.changes = append(b.changes, circuitStateChange{agent: agent, from: from, to: to})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := WithAgentID(t.Context(), "https://agent.example.com")
+	now := time.Now()
+	var changes []string
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{
+		MinRequests: 4,
+		OpenTimeout: time.Minute,
+		OnStateChange: func(agent AgentID, from, to CircuitState) {
+			changes = append(changes, fmt.Sprintf("%s:%s->%s", agent, from, to))
+		},
+	})
+	breaker.now = func() time.Time { return now }
+
+	var agentErr error
+	calls := 0
+	transport := &mockTransport{GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+		calls++
+		return &a2a.Task{ID: query.ID}, agentErr
+	}}
+	client := &Client{transport: transport, interceptors: []CallInterceptor{breaker}}
+	call := func() error {
+		_, err := client.GetTask(ctx, a2a.TaskQueryParams{ID: "task-1"})
+		return err
+	}
+
+	agentErr = errors.New("connection refused")
+	for range 2 {
+		_ = call()
+	}
+	agentErr = nil
+	_ = call()
+	if state := breaker.State("https://agent.example.com"); state != CircuitClosed {
+		t.Fatalf("State() after 2 failures of 3 calls = %s, want %s", state, CircuitClosed)
+	}
+	_ = call()
+	agentErr = errors.New("connection refused")
+	_ = call()
+	if state := breaker.State("https://agent.example.com"); state != CircuitOpen {
+		t.Fatalf("State() after 3 failures of 5 calls = %s, want %s", state, CircuitOpen)
+	}
+
+	calls = 0
+	if err := call(); !errors.Is(err, ErrCircuitOpen) || calls != 0 {
+		t.Fatalf("call with open circuit error = %v and %d transport calls, want %v and none", err, calls, ErrCircuitOpen)
+	}
+	if state := breaker.State("https://other.example.com"); state != CircuitClosed {
+		t.Fatalf("State() of another agent = %s, want %s", state, CircuitClosed)
+	}
+
+	now = now.Add(time.Minute)
+	if err := call(); err == nil || errors.Is(err, ErrCircuitOpen) || calls != 1 {
+		t.Fatalf("failing probe error = %v and %d transport calls, want the agent error", err, calls)
+	}
+	if err := call(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("call after failed probe error = %v, want %v", err, ErrCircuitOpen)
+	}
+
+	now = now.Add(time.Minute)
+	agentErr = nil
+	if err := call(); err != nil {
+		t.Fatalf("successful probe error = %v", err)
+	}
+	if state := breaker.State("https://agent.example.com"); state != CircuitClosed {
+		t.Fatalf("State() after successful probe = %s, want %s", state, CircuitClosed)
+	}
+
+	want := []string{
+		"https://agent.example.com:closed->open",
+		"https://agent.example.com:open->half-open",
+		"https://agent.example.com:half-open->open",
+		"https://agent.example.com:open->half-open",
+		"https://agent.example.com:half-open->closed",
+	}
+	if !slices.Equal(changes, want) {
+		t.Fatalf("state changes = %v, want %v", changes, want)
+	}
+}
+
+func TestCircuitBreaker_Window(t *testing.T) {
+	ctx := WithAgentID(t.Context(), "agent")
+	now := time.Now()
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 2, Window: 10 * time.Second})
+	breaker.now = func() time.Time { return now }
+
+	record := func(err error) {
+		ctx, beforeErr := breaker.Before(ctx, &Request{})
+		if beforeErr != nil {
+			t.Fatalf("Before() error = %v", beforeErr)
+		}
+		_ = breaker.After(ctx, &Response{Err: err})
+	}
+
+	record(errors.New("timeout"))
+	now = now.Add(11 * time.Second)
+	record(errors.New("timeout"))
+	if state := breaker.State("agent"); state != CircuitClosed {
+		t.Fatalf("State() with one failure in window = %s, want %s", state, CircuitClosed)
+	}
+	now = now.Add(5 * time.Second)
+	record(errors.New("timeout"))
+	if state := breaker.State("agent"); state != CircuitOpen {
+		t.Fatalf("State() with two failures in window = %s, want %s", state, CircuitOpen)
+	}
+}
+
+func TestCircuitBreaker_IgnoredErrors(t *testing.T) {
+	ctx := WithAgentID(t.Context(), "agent")
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 1})
+	for _, err := range []error{nil, context.Canceled, a2a.ErrTaskNotFound, fmt.Errorf("wrapped: %w", a2a.ErrInvalidRequest)} {
+		ctx, _ := breaker.Before(ctx, &Request{})
+		_ = breaker.After(ctx, &Response{Err: err})
+	}
+	if state := breaker.State("agent"); state != CircuitClosed {
+		t.Fatalf("State() after caller errors = %s, want %s", state, CircuitClosed)
+	}
+}
@@ -16,7 +16,11 @@ package a2aclient
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"iter"
+	"net/http"
+	"sync"
 
 	"github.com/a2aproject/a2a-go/a2a"
 )
@@ -37,68 +41,305 @@ type Config struct {
 	// If there's no overlap in supported Transport Factory will return an error on Client
 	// creation attempt.
 	PreferredTransports []a2a.TransportProtocol
+	// AbandonPolicy defines what happens to a Task when a caller stops consuming a streaming call
+	// before the Task reached a terminal state. AbandonDetach is used if not set.
+	AbandonPolicy AbandonPolicy
+	// HTTPClient is used for requests made outside of the Transport, such as artifact downloads.
+	// http.DefaultClient is used if nil.
+	HTTPClient *http.Client
 }
 
 // Client represents a transport-agnostic implementation of A2A client.
 // The actual call is delegated to a specific Transport implementation.
 // CallInterceptors are applied before and after every protocol call.
+//
+// A Client is safe for concurrent use by multiple goroutines. Config is set when the Client is created
+// by a Factory and must not be modified afterwards. Interceptors can be added while calls are in progress,
+// in which case they apply to calls started after AddCallInterceptor returned.
 type Client struct {
-	Config       Config
-	transport    Transport
+	Config    Config
+	transport Transport
+
+	mu sync.RWMutex
+	// interceptors is replaced rather than modified, so that in-progress calls can keep using a snapshot.
 	interceptors []CallInterceptor
+	// card is the AgentCard the Client was created from until GetAgentCard fetches one from the agent.
+	card *a2a.AgentCard
+	// cardFetched is set when card was fetched by GetAgentCard and is reset when it might be outdated.
+	cardFetched bool
 }
 
 // AddCallInterceptor allows to attach a CallInterceptor to the client after creation.
 func (c *Client) AddCallInterceptor(ci CallInterceptor) {
-	c.interceptors = append(c.interceptors, ci)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	interceptors := make([]CallInterceptor, len(c.interceptors), len(c.interceptors)+1)
+	copy(interceptors, c.interceptors)
+	c.interceptors = append(interceptors, ci)
+}
+
+// callInterceptors returns a snapshot of the interceptors which is never modified.
+func (c *Client) callInterceptors() []CallInterceptor {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.interceptors
 }
 
 // A2A protocol methods
 
 func (c *Client) GetTask(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
-	return &a2a.Task{}, ErrNotImplemented
+	return doCall(ctx, c, "GetTask", query, c.transport.GetTask)
 }
 
 func (c *Client) CancelTask(ctx context.Context, id a2a.TaskIDParams) (*a2a.Task, error) {
-	return &a2a.Task{}, ErrNotImplemented
+	return doCall(ctx, c, "CancelTask", id, c.transport.CancelTask)
 }
 
+// SendMessage calls the 'message/send' protocol method. If the agent rejected the message and described
+// the reason, the Task is returned together with RejectedError.
 func (c *Client) SendMessage(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
-	return &a2a.Task{}, ErrNotImplemented
+	message = withContextPreferences(ctx, message)
+	result, err := doCall(ctx, c, "SendMessage", message, c.transport.SendMessage)
+	if err != nil {
+		return result, err
+	}
+	return result, rejectedErrorOf(result)
 }
 
 func (c *Client) ResubscribeToTask(ctx context.Context, id a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
-	return func(yield func(a2a.Event, error) bool) {
-		yield(&a2a.Message{}, ErrNotImplemented)
-	}
+	return c.withAbandonPolicy(ctx, id.ID, doStreamingCall(ctx, c, "ResubscribeToTask", id, c.transport.ResubscribeToTask))
 }
 
 func (c *Client) SendStreamingMessage(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
-	return func(yield func(a2a.Event, error) bool) {
-		yield(&a2a.Message{}, ErrNotImplemented)
-	}
+	message = withContextPreferences(ctx, message)
+	return c.withAbandonPolicy(ctx, message.Message.TaskID, doStreamingCall(ctx, c, "SendStreamingMessage", message, c.transport.SendStreamingMessage))
 }
 
 func (c *Client) GetTaskPushConfig(ctx context.Context, params a2a.GetTaskPushConfigParams) (a2a.TaskPushConfig, error) {
-	return a2a.TaskPushConfig{}, ErrNotImplemented
+	return doCall(ctx, c, "GetTaskPushConfig", params, c.transport.GetTaskPushConfig)
 }
 
-func (c *Client) ListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) ([]a2a.TaskPushConfig, error) {
-	return []a2a.TaskPushConfig{}, ErrNotImplemented
+func (c *Client) ListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) (*a2a.ListTaskPushConfigResult, error) {
+	return doCall(ctx, c, "ListTaskPushConfig", params, c.transport.ListTaskPushConfig)
+}
+
+// AllTaskPushConfigs iterates over push notification configurations of the Task, fetching the pages
+// of the list using ListTaskPushConfig. params.PageSize controls the number of configurations fetched per call.
+func (c *Client) AllTaskPushConfigs(ctx context.Context, params a2a.ListTaskPushConfigParams) iter.Seq2[a2a.TaskPushConfig, error] {
+	return func(yield func(a2a.TaskPushConfig, error) bool) {
+		for {
+			result, err := c.ListTaskPushConfig(ctx, params)
+			if err != nil {
+				yield(a2a.TaskPushConfig{}, err)
+				return
+			}
+			for _, config := range result.Configs {
+				if !yield(config, nil) {
+					return
+				}
+			}
+			if result.NextPageToken == "" {
+				return
+			}
+			if result.NextPageToken == params.PageToken {
+				yield(a2a.TaskPushConfig{}, fmt.Errorf("%w: page token didn't advance", a2a.ErrInvalidAgentResponse))
+				return
+			}
+			params.PageToken = result.NextPageToken
+		}
+	}
 }
 
 func (c *Client) SetTaskPushConfig(ctx context.Context, params a2a.TaskPushConfig) (a2a.TaskPushConfig, error) {
-	return a2a.TaskPushConfig{}, ErrNotImplemented
+	return doCall(ctx, c, "SetTaskPushConfig", params, c.transport.SetTaskPushConfig)
 }
 
 func (c *Client) DeleteTaskPushConfig(ctx context.Context, params a2a.DeleteTaskPushConfigParams) error {
-	return ErrNotImplemented
+	_, err := doCall(ctx, c, "DeleteTaskPushConfig", params, func(ctx context.Context, params a2a.DeleteTaskPushConfigParams) (struct{}, error) {
+		return struct{}{}, c.transport.DeleteTaskPushConfig(ctx, params)
+	})
+	return err
 }
 
+// GetAgentCard fetches the AgentCard from the agent, which can be the authenticated extended card,
+// and caches it. The cached card is returned until a call fails with AuthChallengeError or
+// a2a.ErrUnsupportedOperation, which can mean the card the Client uses is outdated, for example
+// because the agent changed the security requirements or the transports it supports.
 func (c *Client) GetAgentCard(ctx context.Context) (*a2a.AgentCard, error) {
-	return &a2a.AgentCard{}, ErrNotImplemented
+	c.mu.RLock()
+	card, fetched := c.card, c.cardFetched
+	c.mu.RUnlock()
+	if fetched {
+		return card, nil
+	}
+
+	card, err := doCall(ctx, c, "GetAgentCard", struct{}{}, func(ctx context.Context, _ struct{}) (*a2a.AgentCard, error) {
+		return c.transport.GetAgentCard(ctx)
+	})
+	if err != nil || card == nil {
+		return card, err
+	}
+	c.mu.Lock()
+	c.card, c.cardFetched = card, true
+	c.mu.Unlock()
+	return card, nil
+}
+
+// AgentCard returns the latest AgentCard known to the Client without making a call: the card fetched
+// by GetAgentCard or the card the Client was created from. Nil if the Client was created without a card.
+// The returned card must not be modified.
+func (c *Client) AgentCard() *a2a.AgentCard {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.card
+}
+
+// observeCallError makes the next GetAgentCard call fetch the card again if err suggests it's outdated.
+func (c *Client) observeCallError(err error) {
+	var challenge *AuthChallengeError
+	if !errors.As(err, &challenge) && !errors.Is(err, a2a.ErrUnsupportedOperation) {
+		return
+	}
+	c.mu.Lock()
+	c.cardFetched = false
+	c.mu.Unlock()
 }
 
 func (c *Client) Destroy() error {
 	return c.transport.Destroy()
 }
+
+// withCallContext attaches the CallContext of a protocol method call to ctx.
+func (c *Client) withCallContext(ctx context.Context, method string) context.Context {
+	callCtx, _ := CallContextFrom(ctx)
+	callCtx.Method = method
+	callCtx.Card = c.AgentCard()
+	if callCtx.Agent == "" && callCtx.Card != nil {
+		callCtx.Agent = AgentID(callCtx.Card.URL)
+	}
+	return context.WithValue(ctx, callContextKey{}, callCtx)
+}
+
+// maxCallRetries limits the number of times a call is repeated when requested by a CallInterceptor.
+const maxCallRetries = 1
+
+// doCall applies CallInterceptors to a request and a response of a protocol method call delegated to Transport.
+// The call is repeated if an interceptor sets Response.Retry, but no more than maxCallRetries times.
+func doCall[Req, Resp any](ctx context.Context, c *Client, method string, payload Req, call func(context.Context, Req) (Resp, error)) (Resp, error) {
+	ctx = c.withCallContext(ctx, method)
+
+	interceptors := c.callInterceptors()
+	for attempt := 0; ; attempt++ {
+		resp, err := interceptCall(ctx, interceptors, payload, call)
+		if resp != nil {
+			c.observeCallError(resp.Err)
+		}
+		if err != nil || !resp.Retry || attempt >= maxCallRetries {
+			var result Resp
+			if resp != nil && resp.Payload != nil {
+				typed, ok := resp.Payload.(Resp)
+				if !ok {
+					return result, fmt.Errorf("unexpected %s response payload type: %T", method, resp.Payload)
+				}
+				result = typed
+			}
+			if err != nil {
+				return result, err
+			}
+			return result, resp.Err
+		}
+	}
+}
+
+// doStreamingCall applies CallInterceptors to a streaming protocol method call delegated to Transport.
+// Before is applied when iteration starts and After is applied once after the stream ends with
+// the error which terminated it. An error returned by After is yielded as the last element of the sequence
+// unless the consumer has already stopped iterating. Streaming calls are never retried.
+func doStreamingCall[Req, Resp any](ctx context.Context, c *Client, method string, payload Req, call func(context.Context, Req) iter.Seq2[Resp, error]) iter.Seq2[Resp, error] {
+	return func(yield func(Resp, error) bool) {
+		var zero Resp
+		ctx := c.withCallContext(ctx, method)
+
+		interceptors := c.callInterceptors()
+		req := &Request{Meta: CallMeta{}, Payload: payload}
+		for _, interceptor := range interceptors {
+			var err error
+			if ctx, err = interceptor.Before(ctx, req); err != nil {
+				yield(zero, err)
+				return
+			}
+		}
+		typedReq, ok := req.Payload.(Req)
+		if !ok {
+			yield(zero, fmt.Errorf("unexpected request payload type: %T", req.Payload))
+			return
+		}
+
+		transportCtx, counter := withPayloadCounter(transportContext(ctx, req.Meta))
+		var streamErr error
+		var received PayloadSize
+		stopped := false
+		for event, err := range call(transportCtx, typedReq) {
+			if err != nil {
+				streamErr = err
+			} else {
+				received.Events++
+				if !counter.isReported() {
+					received.ResponseBytes += estimatePayloadSize(event)
+				}
+			}
+			if !yield(event, err) {
+				stopped = true
+				break
+			}
+		}
+
+		size := counter.result(func() PayloadSize {
+			return PayloadSize{RequestBytes: estimatePayloadSize(typedReq), ResponseBytes: received.ResponseBytes, Estimated: true}
+		})
+		size.Events = received.Events
+		c.observeCallError(streamErr)
+		resp := &Response{Err: streamErr, Meta: CallMeta{}, Size: size}
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			if err := interceptors[i].After(ctx, resp); err != nil {
+				if !stopped {
+					yield(zero, err)
+				}
+				return
+			}
+		}
+	}
+}
+
+func interceptCall[Req, Resp any](ctx context.Context, interceptors []CallInterceptor, payload Req, call func(context.Context, Req) (Resp, error)) (*Response, error) {
+	req := &Request{Meta: CallMeta{}, Payload: payload}
+	for _, interceptor := range interceptors {
+		var err error
+		if ctx, err = interceptor.Before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	typedReq, ok := req.Payload.(Req)
+	if !ok {
+		return nil, fmt.Errorf("unexpected request payload type: %T", req.Payload)
+	}
+
+	transportCtx, counter := withPayloadCounter(transportContext(ctx, req.Meta))
+	result, err := call(transportCtx, typedReq)
+	size := counter.result(func() PayloadSize {
+		size := PayloadSize{RequestBytes: estimatePayloadSize(typedReq), Estimated: true}
+		if err == nil {
+			size.ResponseBytes = estimatePayloadSize(result)
+		}
+		return size
+	})
+	resp := &Response{Err: err, Meta: CallMeta{}, Payload: result, Size: size}
+
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		if err := interceptors[i].After(ctx, resp); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
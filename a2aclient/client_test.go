@@ -16,45 +16,80 @@ package a2aclient
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"iter"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/a2aproject/a2a-go/a2a"
 )
 
 // mockTransport is a mock implementation of the Transport interface for testing.
 type mockTransport struct {
-	destroyCalled bool
+	destroyCalled   bool
+	GetTaskFunc     func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error)
+	CancelTaskFunc  func(ctx context.Context, id a2a.TaskIDParams) (*a2a.Task, error)
+	SendMessageFunc func(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error)
+	StreamFunc      func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error]
+	SetPushFunc     func(ctx context.Context, params a2a.TaskPushConfig) (a2a.TaskPushConfig, error)
+	ListPushFunc    func(ctx context.Context, params a2a.ListTaskPushConfigParams) (*a2a.ListTaskPushConfigResult, error)
+	GetCardFunc     func(ctx context.Context) (*a2a.AgentCard, error)
 }
 
 func (m *mockTransport) GetTask(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+	if m.GetTaskFunc != nil {
+		return m.GetTaskFunc(ctx, query)
+	}
 	return nil, nil
 }
 func (m *mockTransport) CancelTask(ctx context.Context, id a2a.TaskIDParams) (*a2a.Task, error) {
+	if m.CancelTaskFunc != nil {
+		return m.CancelTaskFunc(ctx, id)
+	}
 	return nil, nil
 }
 func (m *mockTransport) SendMessage(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	if m.SendMessageFunc != nil {
+		return m.SendMessageFunc(ctx, message)
+	}
 	return nil, nil
 }
 func (m *mockTransport) ResubscribeToTask(ctx context.Context, id a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
-	return nil
+	return func(yield func(a2a.Event, error) bool) {}
 }
 func (m *mockTransport) SendStreamingMessage(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
-	return nil
+	if m.StreamFunc != nil {
+		return m.StreamFunc(ctx, message)
+	}
+	return func(yield func(a2a.Event, error) bool) {}
 }
 func (m *mockTransport) GetTaskPushConfig(ctx context.Context, params a2a.GetTaskPushConfigParams) (a2a.TaskPushConfig, error) {
 	return a2a.TaskPushConfig{}, nil
 }
-func (m *mockTransport) ListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) ([]a2a.TaskPushConfig, error) {
-	return nil, nil
+func (m *mockTransport) ListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) (*a2a.ListTaskPushConfigResult, error) {
+	if m.ListPushFunc != nil {
+		return m.ListPushFunc(ctx, params)
+	}
+	return &a2a.ListTaskPushConfigResult{}, nil
 }
 func (m *mockTransport) SetTaskPushConfig(ctx context.Context, params a2a.TaskPushConfig) (a2a.TaskPushConfig, error) {
+	if m.SetPushFunc != nil {
+		return m.SetPushFunc(ctx, params)
+	}
 	return a2a.TaskPushConfig{}, nil
 }
 func (m *mockTransport) DeleteTaskPushConfig(ctx context.Context, params a2a.DeleteTaskPushConfigParams) error {
 	return nil
 }
 func (m *mockTransport) GetAgentCard(ctx context.Context) (*a2a.AgentCard, error) {
+	if m.GetCardFunc != nil {
+		return m.GetCardFunc(ctx)
+	}
 	return nil, nil
 }
 func (m *mockTransport) Destroy() error {
@@ -72,6 +107,69 @@ func TestClient_AddCallInterceptor(t *testing.T) {
 	}
 }
 
+type countingInterceptor struct {
+	PassthroughInterceptor
+	calls atomic.Int64
+}
+
+func (i *countingInterceptor) Before(ctx context.Context, req *Request) (context.Context, error) {
+	i.calls.Add(1)
+	return ctx, nil
+}
+
+func TestClient_AddCallInterceptorConcurrentWithCalls(t *testing.T) {
+	t.Parallel()
+	transport := &mockTransport{
+		GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			return &a2a.Task{ID: query.ID}, nil
+		},
+	}
+	client := &Client{transport: transport}
+	first := &countingInterceptor{}
+	client.AddCallInterceptor(first)
+
+	numCallers, numCalls, numAdded := 8, 50, 20
+	var wg sync.WaitGroup
+	for i := 0; i < numCallers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numCalls; j++ {
+				if _, err := client.GetTask(t.Context(), a2a.TaskQueryParams{ID: "task-1"}); err != nil {
+					t.Errorf("GetTask() error = %v", err)
+				}
+				_ = client.Config.AcceptedOutputModes
+			}
+		}()
+	}
+	added := make([]*countingInterceptor, numAdded)
+	for i := range added {
+		added[i] = &countingInterceptor{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.AddCallInterceptor(added[i])
+		}()
+	}
+	wg.Wait()
+
+	if got, want := first.calls.Load(), int64(numCallers*numCalls); got != want {
+		t.Fatalf("interceptor added before calls ran %d times, want %d", got, want)
+	}
+	if got := len(client.callInterceptors()); got != numAdded+1 {
+		t.Fatalf("got %d interceptors, want %d", got, numAdded+1)
+	}
+	// interceptors added concurrently apply to calls started afterwards
+	if _, err := client.GetTask(t.Context(), a2a.TaskQueryParams{ID: "task-1"}); err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	for i, interceptor := range added {
+		if interceptor.calls.Load() == 0 {
+			t.Fatalf("interceptor %d was not applied to a call started after it was added", i)
+		}
+	}
+}
+
 func TestClient_Destroy(t *testing.T) {
 	transport := &mockTransport{}
 	client := &Client{transport: transport}
@@ -85,63 +183,310 @@ func TestClient_Destroy(t *testing.T) {
 	}
 }
 
-func TestClient_NotImplemented(t *testing.T) {
-	client := &Client{}
-	ctx := context.Background()
+type recordingInterceptor struct {
+	name  string
+	log   *[]string
+	retry bool
+}
+
+func (i *recordingInterceptor) Before(ctx context.Context, req *Request) (context.Context, error) {
+	*i.log = append(*i.log, "before "+i.name)
+	req.Meta[i.name] = "true"
+	return ctx, nil
+}
+
+func (i *recordingInterceptor) After(ctx context.Context, resp *Response) error {
+	*i.log = append(*i.log, "after "+i.name)
+	resp.Retry = i.retry
+	return nil
+}
+
+func TestClient_InterceptorsApplied(t *testing.T) {
+	var log []string
+	want := &a2a.Task{ID: "task-1"}
+	transport := &mockTransport{
+		GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			log = append(log, "call")
+			meta, ok := CallMetaFrom(ctx)
+			if !ok || meta["first"] != "true" || meta["second"] != "true" {
+				t.Errorf("CallMetaFrom() = %v, want meta set by interceptors", meta)
+			}
+			callCtx, ok := CallContextFrom(ctx)
+			if !ok || callCtx.Method != "GetTask" {
+				t.Errorf("CallContextFrom() = %v, want GetTask method", callCtx)
+			}
+			return want, nil
+		},
+	}
+	client := &Client{transport: transport}
+	client.AddCallInterceptor(&recordingInterceptor{name: "first", log: &log})
+	client.AddCallInterceptor(&recordingInterceptor{name: "second", log: &log})
+
+	got, err := client.GetTask(t.Context(), a2a.TaskQueryParams{ID: "task-1"})
+	if err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if got != want {
+		t.Fatalf("GetTask() = %v, want %v", got, want)
+	}
 
-	_, err := client.GetTask(ctx, a2a.TaskQueryParams{})
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+	wantLog := []string{"before first", "before second", "call", "after second", "after first"}
+	if len(log) != len(wantLog) {
+		t.Fatalf("call log = %v, want %v", log, wantLog)
+	}
+	for i := range wantLog {
+		if log[i] != wantLog[i] {
+			t.Fatalf("call log = %v, want %v", log, wantLog)
+		}
 	}
+}
 
-	_, err = client.CancelTask(ctx, a2a.TaskIDParams{})
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+func TestClient_RetryRequestedByInterceptor(t *testing.T) {
+	calls := 0
+	transport := &mockTransport{
+		GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			calls++
+			return nil, errors.New("call failed")
+		},
 	}
+	var log []string
+	client := &Client{transport: transport}
+	client.AddCallInterceptor(&recordingInterceptor{name: "retry", log: &log, retry: true})
 
-	_, err = client.SendMessage(ctx, a2a.MessageSendParams{})
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+	if _, err := client.GetTask(t.Context(), a2a.TaskQueryParams{}); err == nil {
+		t.Fatal("GetTask() error = nil, want transport error")
+	}
+	if calls != 1+maxCallRetries {
+		t.Fatalf("transport called %d times, want %d", calls, 1+maxCallRetries)
 	}
+}
+
+func TestClient_SendStreamingMessage(t *testing.T) {
+	streamErr := errors.New("stream broken")
+	var gotMeta CallMeta
+	transport := &mockTransport{
+		StreamFunc: func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+			gotMeta, _ = CallMetaFrom(ctx)
+			return func(yield func(a2a.Event, error) bool) {
+				if !yield(&a2a.Task{ID: "task-1"}, nil) {
+					return
+				}
+				yield(nil, streamErr)
+			}
+		},
+	}
+	var log []string
+	interceptor := &recordingInterceptor{name: "first", log: &log}
+	client := &Client{transport: transport, interceptors: []CallInterceptor{interceptor}}
 
-	resubscribeSeq := client.ResubscribeToTask(ctx, a2a.TaskIDParams{})
-	resubscribeSeq(func(e a2a.Event, err error) bool {
-		if err != ErrNotImplemented {
-			t.Errorf("expected ErrNotImplemented, got %v", err)
+	var events []a2a.Event
+	var gotErr error
+	for event, err := range client.SendStreamingMessage(t.Context(), a2a.MessageSendParams{}) {
+		if err != nil {
+			gotErr = err
+			break
 		}
-		return false
-	})
+		events = append(events, event)
+	}
+	if len(events) != 1 || !errors.Is(gotErr, streamErr) {
+		t.Fatalf("SendStreamingMessage() = %v, %v, want one event and %v", events, gotErr, streamErr)
+	}
+	if gotMeta["first"] != "true" {
+		t.Fatalf("transport got CallMeta %v, want interceptor meta", gotMeta)
+	}
+	want := []string{"before first", "after first"}
+	if !reflect.DeepEqual(log, want) {
+		t.Fatalf("interceptor log = %v, want %v", log, want)
+	}
+}
+
+type failingAfterInterceptor struct {
+	PassthroughInterceptor
+	err error
+}
 
-	sendStreamingSeq := client.SendStreamingMessage(ctx, a2a.MessageSendParams{})
-	sendStreamingSeq(func(e a2a.Event, err error) bool {
-		if err != ErrNotImplemented {
-			t.Errorf("expected ErrNotImplemented, got %v", err)
+func (i *failingAfterInterceptor) After(ctx context.Context, resp *Response) error {
+	return i.err
+}
+
+func TestClient_SendStreamingMessage_AfterError(t *testing.T) {
+	afterErr := errors.New("after failed")
+	transport := &mockTransport{
+		StreamFunc: func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+			return func(yield func(a2a.Event, error) bool) {
+				yield(&a2a.Task{ID: "task-1"}, nil)
+			}
+		},
+	}
+	client := &Client{transport: transport, interceptors: []CallInterceptor{&failingAfterInterceptor{err: afterErr}}}
+
+	var events []a2a.Event
+	var gotErr error
+	for event, err := range client.SendStreamingMessage(t.Context(), a2a.MessageSendParams{}) {
+		if err != nil {
+			gotErr = err
+			continue
 		}
-		return false
-	})
+		events = append(events, event)
+	}
+	if len(events) != 1 || !errors.Is(gotErr, afterErr) {
+		t.Fatalf("SendStreamingMessage() = %v, %v, want one event and %v", events, gotErr, afterErr)
+	}
+
+	// The error is not yielded after the consumer stopped iterating, which would panic.
+	for range client.SendStreamingMessage(t.Context(), a2a.MessageSendParams{}) {
+		break
+	}
+}
 
-	_, err = client.GetTaskPushConfig(ctx, a2a.GetTaskPushConfigParams{})
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+func TestClient_AgentFromCard(t *testing.T) {
+	var got []AgentID
+	transport := &mockTransport{
+		GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			callCtx, _ := CallContextFrom(ctx)
+			got = append(got, callCtx.Agent)
+			return &a2a.Task{}, nil
+		},
 	}
+	client := &Client{transport: transport, card: &a2a.AgentCard{URL: "https://agent.com"}}
 
-	_, err = client.ListTaskPushConfig(ctx, a2a.ListTaskPushConfigParams{})
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+	if _, err := client.GetTask(t.Context(), a2a.TaskQueryParams{}); err != nil {
+		t.Fatalf("GetTask() error = %v", err)
 	}
+	if _, err := client.GetTask(WithAgentID(t.Context(), "override"), a2a.TaskQueryParams{}); err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != "https://agent.com" || got[1] != "override" {
+		t.Fatalf("agents = %v, want [https://agent.com override]", got)
+	}
+}
 
-	_, err = client.SetTaskPushConfig(ctx, a2a.TaskPushConfig{})
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+func TestClient_GetAgentCardCache(t *testing.T) {
+	ctx := t.Context()
+	public := &a2a.AgentCard{URL: "https://agent.com", Version: "1"}
+	var fetches atomic.Int32
+	var taskErr error
+	var seenCard *a2a.AgentCard
+	transport := &mockTransport{
+		GetCardFunc: func(ctx context.Context) (*a2a.AgentCard, error) {
+			n := fetches.Add(1)
+			return &a2a.AgentCard{URL: "https://agent.com", Version: fmt.Sprint(n + 1)}, nil
+		},
+		GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			callCtx, _ := CallContextFrom(ctx)
+			seenCard = callCtx.Card
+			return &a2a.Task{}, taskErr
+		},
 	}
+	client := &Client{transport: transport, card: public}
 
-	err = client.DeleteTaskPushConfig(ctx, a2a.DeleteTaskPushConfigParams{})
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+	if got := client.AgentCard(); got != public {
+		t.Fatalf("AgentCard() = %v, want the card the client was created from", got)
+	}
+	first, err := client.GetAgentCard(ctx)
+	if err != nil || first.Version != "2" {
+		t.Fatalf("GetAgentCard() = %v, %v, want fetched card", first, err)
+	}
+	if got, _ := client.GetAgentCard(ctx); got != first || fetches.Load() != 1 {
+		t.Fatalf("GetAgentCard() = %v after %d fetches, want the cached card", got, fetches.Load())
+	}
+	if _, err := client.GetTask(ctx, a2a.TaskQueryParams{}); err != nil || seenCard != first {
+		t.Fatalf("CallContext.Card = %v, %v, want the cached card", seenCard, err)
 	}
 
-	_, err = client.GetAgentCard(ctx)
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+	// an unrelated failure doesn't invalidate the card
+	taskErr = a2a.ErrTaskNotFound
+	_, _ = client.GetTask(ctx, a2a.TaskQueryParams{})
+	_, _ = client.GetAgentCard(ctx)
+	if fetches.Load() != 1 {
+		t.Fatalf("GetAgentCard() fetched the card %d times, want 1", fetches.Load())
+	}
+
+	for _, err := range []error{&AuthChallengeError{Err: errors.New("401")}, fmt.Errorf("grpc: %w", a2a.ErrUnsupportedOperation)} {
+		taskErr = err
+		want := fetches.Load() + 1
+		_, _ = client.GetTask(ctx, a2a.TaskQueryParams{})
+		got, err := client.GetAgentCard(ctx)
+		if err != nil || fetches.Load() != want || client.AgentCard() != got {
+			t.Fatalf("GetAgentCard() after %v = %v, %v with %d fetches, want refreshed card", taskErr, got, err, fetches.Load())
+		}
+	}
+}
+
+func TestClient_AllTaskPushConfigs(t *testing.T) {
+	ctx := t.Context()
+	pages := map[string]*a2a.ListTaskPushConfigResult{
+		"":   {Configs: []a2a.TaskPushConfig{{TaskID: "1", Config: a2a.PushConfig{ID: "a"}}}, NextPageToken: "p2"},
+		"p2": {Configs: []a2a.TaskPushConfig{{TaskID: "1", Config: a2a.PushConfig{ID: "b"}}, {TaskID: "1", Config: a2a.PushConfig{ID: "c"}}}},
+	}
+	var sizes []int
+	transport := &mockTransport{
+		ListPushFunc: func(ctx context.Context, params a2a.ListTaskPushConfigParams) (*a2a.ListTaskPushConfigResult, error) {
+			sizes = append(sizes, params.PageSize)
+			return pages[params.PageToken], nil
+		},
+	}
+	client := &Client{transport: transport}
+
+	var got []string
+	for config, err := range client.AllTaskPushConfigs(ctx, a2a.ListTaskPushConfigParams{TaskID: "1", PageSize: 2}) {
+		if err != nil {
+			t.Fatalf("AllTaskPushConfigs() error = %v", err)
+		}
+		got = append(got, config.Config.ID)
+	}
+	if want := []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("AllTaskPushConfigs() = %v, want %v", got, want)
+	}
+	if want := []int{2, 2}; !slices.Equal(sizes, want) {
+		t.Fatalf("ListTaskPushConfig() page sizes = %v, want %v", sizes, want)
+	}
+}
+
+func TestClient_AllTaskPushConfigs_StuckToken(t *testing.T) {
+	transport := &mockTransport{
+		ListPushFunc: func(ctx context.Context, params a2a.ListTaskPushConfigParams) (*a2a.ListTaskPushConfigResult, error) {
+			return &a2a.ListTaskPushConfigResult{NextPageToken: "same"}, nil
+		},
+	}
+	client := &Client{transport: transport}
+
+	var lastErr error
+	for _, err := range client.AllTaskPushConfigs(t.Context(), a2a.ListTaskPushConfigParams{TaskID: "1", PageToken: "same"}) {
+		lastErr = err
+	}
+	if !errors.Is(lastErr, a2a.ErrInvalidAgentResponse) {
+		t.Fatalf("AllTaskPushConfigs() error = %v, want %v", lastErr, a2a.ErrInvalidAgentResponse)
+	}
+}
+
+func TestClient_RequestTimeoutHint(t *testing.T) {
+	var got CallMeta
+	transport := &mockTransport{
+		GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			got, _ = CallMetaFrom(ctx)
+			return &a2a.Task{}, nil
+		},
+	}
+	client := &Client{transport: transport}
+
+	if _, err := client.GetTask(t.Context(), a2a.TaskQueryParams{}); err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if hint, ok := got[a2a.RequestTimeoutMetaKey]; ok {
+		t.Fatalf("GetTask() without deadline sent timeout hint %q", hint)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Minute)
+	defer cancel()
+	if _, err := client.GetTask(ctx, a2a.TaskQueryParams{}); err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	timeout, err := a2a.ParseRequestTimeout(got[a2a.RequestTimeoutMetaKey])
+	if err != nil {
+		t.Fatalf("ParseRequestTimeout() error = %v", err)
+	}
+	if timeout <= 0 || timeout > time.Minute {
+		t.Fatalf("GetTask() sent timeout hint %v, want at most a minute", timeout)
 	}
 }
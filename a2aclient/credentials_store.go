@@ -0,0 +1,338 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileCredentialsStore implements CredentialsService persisting credentials in a file encrypted
+// with AES-GCM. It allows long-lived clients to keep credentials between restarts.
+// Credentials set for an agent take precedence over credentials set for all agents.
+// The agent is determined using CallContext.Agent.
+type FileCredentialsStore struct {
+	mu   sync.Mutex
+	path string
+	aead cipher.AEAD
+	// cached is the decrypted content of the file described by cachedInfo. The file is read again
+	// only if it was replaced or modified since, eg. by another process using the same file.
+	cached     fileCredentials
+	cachedInfo fs.FileInfo
+}
+
+// fileCredentials is the content of a FileCredentialsStore file. Credentials set for all agents
+// are stored under an empty AgentID.
+type fileCredentials map[AgentID]map[SessionID]map[string]AuthCredential
+
+// NewFileCredentialsStore creates a FileCredentialsStore which uses the provided key for encryption.
+// The key must be 16, 24 or 32 bytes long to select AES-128, AES-192 or AES-256.
+// The file is created on the first Set call if it doesn't exist.
+func NewFileCredentialsStore(path string, key []byte) (*FileCredentialsStore, error) {
+	aead, err := newFileAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &FileCredentialsStore{path: path, aead: aead}, nil
+}
+
+func (s *FileCredentialsStore) Get(ctx context.Context, sid SessionID, scheme string) (AuthCredential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credentials, err := s.load()
+	if err != nil {
+		return AuthCredential(""), err
+	}
+
+	if callCtx, ok := CallContextFrom(ctx); ok && callCtx.Agent != "" {
+		if credential, ok := credentials[callCtx.Agent][sid][scheme]; ok {
+			return credential, nil
+		}
+	}
+
+	credential, ok := credentials[""][sid][scheme]
+	if !ok {
+		return AuthCredential(""), ErrCredentialNotFound
+	}
+	return credential, nil
+}
+
+// Set stores a credential used for all agents and persists the updated file.
+func (s *FileCredentialsStore) Set(sid SessionID, scheme string, credential AuthCredential) error {
+	return s.SetForAgent("", sid, scheme, credential)
+}
+
+// SetForAgent stores a credential used only for the provided agent and persists the updated file.
+func (s *FileCredentialsStore) SetForAgent(agent AgentID, sid SessionID, scheme string, credential AuthCredential) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credentials, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := credentials[agent]; !ok {
+		credentials[agent] = make(map[SessionID]map[string]AuthCredential)
+	}
+	if _, ok := credentials[agent][sid]; !ok {
+		credentials[agent][sid] = make(map[string]AuthCredential)
+	}
+	credentials[agent][sid][scheme] = credential
+	return s.store(credentials)
+}
+
+// Delete removes all credentials of a session, including the ones set for specific agents,
+// and persists the updated file.
+func (s *FileCredentialsStore) Delete(sid SessionID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credentials, err := s.load()
+	if err != nil {
+		return err
+	}
+	for agent, sessions := range credentials {
+		delete(sessions, sid)
+		if len(sessions) == 0 {
+			delete(credentials, agent)
+		}
+	}
+	return s.store(credentials)
+}
+
+// load returns the content of the file, which must not be modified unless it's passed to store.
+// Must be called with mu held.
+func (s *FileCredentialsStore) load() (fileCredentials, error) {
+	info, err := os.Stat(s.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	if s.cached != nil && sameFileInfo(s.cachedInfo, info) {
+		return s.cached, nil
+	}
+
+	credentials := make(fileCredentials)
+	if err := readEncryptedFile(s.path, s.aead, &credentials); err != nil {
+		return nil, err
+	}
+	s.cached, s.cachedInfo = credentials, info
+	return credentials, nil
+}
+
+// store persists credentials and caches them. Must be called with mu held.
+func (s *FileCredentialsStore) store(credentials fileCredentials) error {
+	if err := writeEncryptedFile(s.path, s.aead, credentials); err != nil {
+		s.cached, s.cachedInfo = nil, nil
+		return err
+	}
+	info, err := os.Stat(s.path)
+	if err != nil {
+		s.cached, s.cachedInfo = nil, nil
+		return nil
+	}
+	s.cached, s.cachedInfo = credentials, info
+	return nil
+}
+
+// sameFileInfo reports whether a and b describe the same unmodified file. Nil describes a missing file.
+func sameFileInfo(a, b fs.FileInfo) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return os.SameFile(a, b) && a.Size() == b.Size() && a.ModTime().Equal(b.ModTime())
+}
+
+func newFileAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// readEncryptedFile decrypts the file and decodes its JSON content into v. v is left unchanged
+// if the file doesn't exist.
+func readEncryptedFile(path string, aead cipher.AEAD, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	nonceSize := aead.NonceSize()
+	if len(data) < nonceSize {
+		return fmt.Errorf("credentials file is corrupted")
+	}
+	plaintext, err := aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt credentials file: %w", err)
+	}
+	if err := json.Unmarshal(plaintext, v); err != nil {
+		return fmt.Errorf("failed to decode credentials file: %w", err)
+	}
+	return nil
+}
+
+func writeEncryptedFile(path string, aead cipher.AEAD, v any) error {
+	plaintext, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data := aead.Seal(nonce, nonce, plaintext, nil)
+
+	// Write to a temporary file first so that a crash doesn't leave a partially written file.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create credentials file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// SecretManager is implemented by adapters for external secret storage systems (eg. Vault or a cloud
+// provider secret manager). AccessSecret should return an error wrapping ErrCredentialNotFound if a secret
+// with the provided name doesn't exist.
+type SecretManager interface {
+	AccessSecret(ctx context.Context, name string) ([]byte, error)
+}
+
+// SecretManagerCredentials implements CredentialsService by reading credentials from a SecretManager.
+// A secret scoped to the agent determined using CallContext.Agent takes precedence over a secret
+// shared by all agents.
+type SecretManagerCredentials struct {
+	// Manager is used for reading secrets.
+	Manager SecretManager
+	// SecretName maps an (agent, session, scheme) triple to a secret name. The agent is empty when
+	// the name of a secret shared by all agents is requested. If nil, "a2a/{sid}/{scheme}" is used for
+	// shared secrets and "a2a/{agent}/{sid}/{scheme}" for agent secrets, with every component path-escaped.
+	SecretName func(agent AgentID, sid SessionID, scheme string) string
+}
+
+func (s *SecretManagerCredentials) Get(ctx context.Context, sid SessionID, scheme string) (AuthCredential, error) {
+	if callCtx, ok := CallContextFrom(ctx); ok && callCtx.Agent != "" {
+		secret, err := s.Manager.AccessSecret(ctx, s.secretName(callCtx.Agent, sid, scheme))
+		if err == nil {
+			return AuthCredential(secret), nil
+		}
+		if !errors.Is(err, ErrCredentialNotFound) {
+			return AuthCredential(""), err
+		}
+	}
+
+	secret, err := s.Manager.AccessSecret(ctx, s.secretName("", sid, scheme))
+	if err != nil {
+		return AuthCredential(""), err
+	}
+	return AuthCredential(secret), nil
+}
+
+func (s *SecretManagerCredentials) secretName(agent AgentID, sid SessionID, scheme string) string {
+	if s.SecretName != nil {
+		return s.SecretName(agent, sid, scheme)
+	}
+	return "a2a/" + escapedKey(agent, sid, scheme)
+}
+
+// Keyring is implemented by adapters for OS credential stores (eg. macOS Keychain, Windows Credential
+// Manager or Secret Service on Linux). Get should return an error wrapping ErrCredentialNotFound if
+// an item doesn't exist.
+type Keyring interface {
+	Get(service, user string) (string, error)
+	Set(service, user, secret string) error
+	Delete(service, user string) error
+}
+
+// KeyringCredentialsStore implements CredentialsService by storing credentials in a Keyring.
+// Credentials are stored under the Service name with a "{sid}/{scheme}" user, or an "{agent}/{sid}/{scheme}"
+// user if they were set for an agent, with every component path-escaped. Credentials set for the agent determined using CallContext.Agent
+// take precedence over credentials set for all agents.
+type KeyringCredentialsStore struct {
+	Keyring Keyring
+	Service string
+}
+
+func (s *KeyringCredentialsStore) Get(ctx context.Context, sid SessionID, scheme string) (AuthCredential, error) {
+	if callCtx, ok := CallContextFrom(ctx); ok && callCtx.Agent != "" {
+		secret, err := s.Keyring.Get(s.Service, escapedKey(callCtx.Agent, sid, scheme))
+		if err == nil {
+			return AuthCredential(secret), nil
+		}
+		if !errors.Is(err, ErrCredentialNotFound) {
+			return AuthCredential(""), err
+		}
+	}
+
+	secret, err := s.Keyring.Get(s.Service, escapedKey("", sid, scheme))
+	if err != nil {
+		return AuthCredential(""), err
+	}
+	return AuthCredential(secret), nil
+}
+
+// Set stores a credential used for all agents in the Keyring.
+func (s *KeyringCredentialsStore) Set(sid SessionID, scheme string, credential AuthCredential) error {
+	return s.SetForAgent("", sid, scheme, credential)
+}
+
+// SetForAgent stores a credential used only for the provided agent in the Keyring.
+func (s *KeyringCredentialsStore) SetForAgent(agent AgentID, sid SessionID, scheme string, credential AuthCredential) error {
+	return s.Keyring.Set(s.Service, escapedKey(agent, sid, scheme), string(credential))
+}
+
+// Delete removes the credential used for all agents from the Keyring.
+func (s *KeyringCredentialsStore) Delete(sid SessionID, scheme string) error {
+	return s.DeleteForAgent("", sid, scheme)
+}
+
+// DeleteForAgent removes the credential set for the provided agent from the Keyring.
+func (s *KeyringCredentialsStore) DeleteForAgent(agent AgentID, sid SessionID, scheme string) error {
+	return s.Keyring.Delete(s.Service, escapedKey(agent, sid, scheme))
+}
+
+// escapedKey joins the non-empty agent, the session and the scheme with "/". The components are
+// path-escaped, so that a "/" in one of them can't make keys of different triples collide.
+func escapedKey(agent AgentID, sid SessionID, scheme string) string {
+	key := url.PathEscape(string(sid)) + "/" + url.PathEscape(scheme)
+	if agent == "" {
+		return key
+	}
+	return url.PathEscape(string(agent)) + "/" + key
+}
@@ -0,0 +1,377 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestFileCredentialsStore(t *testing.T) {
+	ctx := t.Context()
+	path := filepath.Join(t.TempDir(), "credentials")
+	key := bytes.Repeat([]byte{1}, 32)
+
+	store, err := NewFileCredentialsStore(path, key)
+	if err != nil {
+		t.Fatalf("NewFileCredentialsStore() error = %v", err)
+	}
+	if _, err := store.Get(ctx, "session", "oauth2"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("Get() error = %v, want %v", err, ErrCredentialNotFound)
+	}
+	if err := store.Set("session", "oauth2", "token"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read credentials file: %v", err)
+	}
+	if bytes.Contains(data, []byte("token")) {
+		t.Fatal("credentials file contains a plaintext credential")
+	}
+
+	reopened, err := NewFileCredentialsStore(path, key)
+	if err != nil {
+		t.Fatalf("NewFileCredentialsStore() error = %v", err)
+	}
+	got, err := reopened.Get(ctx, "session", "oauth2")
+	if err != nil {
+		t.Fatalf("Get() after reopen error = %v", err)
+	}
+	if got != "token" {
+		t.Fatalf("Get() = %q, want token", got)
+	}
+
+	if err := reopened.Delete("session"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := reopened.Get(ctx, "session", "oauth2"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("Get() after Delete() error = %v, want %v", err, ErrCredentialNotFound)
+	}
+}
+
+func TestFileCredentialsStore_WrongKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	store, err := NewFileCredentialsStore(path, bytes.Repeat([]byte{1}, 16))
+	if err != nil {
+		t.Fatalf("NewFileCredentialsStore() error = %v", err)
+	}
+	if err := store.Set("session", "oauth2", "token"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	other, err := NewFileCredentialsStore(path, bytes.Repeat([]byte{2}, 16))
+	if err != nil {
+		t.Fatalf("NewFileCredentialsStore() error = %v", err)
+	}
+	if _, err := other.Get(t.Context(), "session", "oauth2"); err == nil {
+		t.Fatal("Get() with a wrong key succeeded")
+	}
+
+	if _, err := NewFileCredentialsStore(path, []byte("short")); err == nil {
+		t.Fatal("NewFileCredentialsStore() with invalid key size succeeded")
+	}
+}
+
+func TestFileCredentialsStore_CachesContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	key := bytes.Repeat([]byte{1}, 32)
+	store, err := NewFileCredentialsStore(path, key)
+	if err != nil {
+		t.Fatalf("NewFileCredentialsStore() error = %v", err)
+	}
+	if err := store.Set("session", "oauth2", "token"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	// Corrupt the file in place keeping its size and modification time: a cached store doesn't notice.
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("os.Stat() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+	if err := os.WriteFile(path, make([]byte, len(data)), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatalf("os.Chtimes() error = %v", err)
+	}
+	got, err := store.Get(t.Context(), "session", "oauth2")
+	if err != nil || got != "token" {
+		t.Fatalf("Get() = (%q, %v), want cached token", got, err)
+	}
+
+	// A file replaced by another store is read again.
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	other, err := NewFileCredentialsStore(path, key)
+	if err != nil {
+		t.Fatalf("NewFileCredentialsStore() error = %v", err)
+	}
+	if err := other.Set("session", "oauth2", "rotated"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	got, err = store.Get(t.Context(), "session", "oauth2")
+	if err != nil || got != "rotated" {
+		t.Fatalf("Get() after the file was replaced = (%q, %v), want rotated", got, err)
+	}
+}
+
+type mapSecretManager map[string][]byte
+
+func (m mapSecretManager) AccessSecret(ctx context.Context, name string) ([]byte, error) {
+	secret, ok := m[name]
+	if !ok {
+		return nil, ErrCredentialNotFound
+	}
+	return secret, nil
+}
+
+func TestFileCredentialsStore_Agents(t *testing.T) {
+	store, err := NewFileCredentialsStore(filepath.Join(t.TempDir(), "credentials"), bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatalf("NewFileCredentialsStore() error = %v", err)
+	}
+	if err := store.Set("session", "oauth2", "shared"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := store.SetForAgent("https://agent-a.com", "session", "oauth2", "agent-a"); err != nil {
+		t.Fatalf("SetForAgent() error = %v", err)
+	}
+
+	got, err := store.Get(WithAgentID(t.Context(), "https://agent-a.com"), "session", "oauth2")
+	if err != nil || got != "agent-a" {
+		t.Fatalf("Get() for agent-a = (%q, %v), want agent-a", got, err)
+	}
+	got, err = store.Get(WithAgentID(t.Context(), "https://agent-b.com"), "session", "oauth2")
+	if err != nil || got != "shared" {
+		t.Fatalf("Get() for agent-b = (%q, %v), want shared", got, err)
+	}
+
+	if err := store.Delete("session"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(WithAgentID(t.Context(), "https://agent-a.com"), "session", "oauth2"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("Get() after Delete() error = %v, want %v", err, ErrCredentialNotFound)
+	}
+}
+
+func TestSecretManagerCredentials(t *testing.T) {
+	manager := mapSecretManager{"a2a/session/oauth2": []byte("token"), "custom-name": []byte("custom")}
+
+	creds := &SecretManagerCredentials{Manager: manager}
+	got, err := creds.Get(t.Context(), "session", "oauth2")
+	if err != nil || got != "token" {
+		t.Fatalf("Get() = (%q, %v), want token", got, err)
+	}
+	if _, err := creds.Get(t.Context(), "other", "oauth2"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("Get() error = %v, want %v", err, ErrCredentialNotFound)
+	}
+
+	creds.SecretName = func(agent AgentID, sid SessionID, scheme string) string { return "custom-name" }
+	got, err = creds.Get(t.Context(), "session", "oauth2")
+	if err != nil || got != "custom" {
+		t.Fatalf("Get() with custom name = (%q, %v), want custom", got, err)
+	}
+}
+
+func TestCredentialsStores_AttachedToRequests(t *testing.T) {
+	testCases := []struct {
+		name  string
+		store func(t *testing.T) CredentialsService
+	}{
+		{
+			name: "file",
+			store: func(t *testing.T) CredentialsService {
+				store, err := NewFileCredentialsStore(filepath.Join(t.TempDir(), "credentials"), bytes.Repeat([]byte{1}, 32))
+				if err != nil {
+					t.Fatalf("NewFileCredentialsStore() error = %v", err)
+				}
+				if err := store.Set("session", "bearer", "stored-token"); err != nil {
+					t.Fatalf("Set() error = %v", err)
+				}
+				return store
+			},
+		},
+		{
+			name: "keyring",
+			store: func(t *testing.T) CredentialsService {
+				store := &KeyringCredentialsStore{Keyring: mapKeyring{}, Service: "my-app"}
+				if err := store.Set("session", "bearer", "stored-token"); err != nil {
+					t.Fatalf("Set() error = %v", err)
+				}
+				return store
+			},
+		},
+		{
+			name: "secret manager",
+			store: func(t *testing.T) CredentialsService {
+				return &SecretManagerCredentials{Manager: mapSecretManager{"a2a/session/bearer": []byte("stored-token")}}
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Authorization")
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(a2a.Task{ID: "task-1", ContextID: "ctx-1"})
+			}))
+			defer server.Close()
+
+			client := &Client{transport: NewHTTPJSONTransport(server.URL, server.Client()), card: bearerAuthCard}
+			client.AddCallInterceptor(AuthInterceptor{Service: tc.store(t)})
+			if _, err := client.GetTask(WithSessionID(t.Context(), "session"), a2a.TaskQueryParams{ID: "task-1"}); err != nil {
+				t.Fatalf("GetTask() error = %v", err)
+			}
+			if want := "Bearer stored-token"; got != want {
+				t.Fatalf("server received Authorization %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestSecretManagerCredentials_Agents(t *testing.T) {
+	manager := mapSecretManager{
+		"a2a/session/oauth2":                         []byte("shared"),
+		"a2a/https:%2F%2Fagent-a.com/session/oauth2": []byte("agent-a"),
+	}
+	creds := &SecretManagerCredentials{Manager: manager}
+
+	got, err := creds.Get(WithAgentID(t.Context(), "https://agent-a.com"), "session", "oauth2")
+	if err != nil || got != "agent-a" {
+		t.Fatalf("Get() for agent-a = (%q, %v), want agent-a", got, err)
+	}
+	got, err = creds.Get(WithAgentID(t.Context(), "https://agent-b.com"), "session", "oauth2")
+	if err != nil || got != "shared" {
+		t.Fatalf("Get() for agent-b = (%q, %v), want shared", got, err)
+	}
+}
+
+type mapKeyring map[string]string
+
+func (k mapKeyring) Get(service, user string) (string, error) {
+	secret, ok := k[service+":"+user]
+	if !ok {
+		return "", ErrCredentialNotFound
+	}
+	return secret, nil
+}
+
+func (k mapKeyring) Set(service, user, secret string) error {
+	k[service+":"+user] = secret
+	return nil
+}
+
+func (k mapKeyring) Delete(service, user string) error {
+	delete(k, service+":"+user)
+	return nil
+}
+
+func TestKeyringCredentialsStore(t *testing.T) {
+	keyring := mapKeyring{}
+	store := &KeyringCredentialsStore{Keyring: keyring, Service: "my-app"}
+
+	if err := store.Set("session", "oauth2", "token"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if keyring["my-app:session/oauth2"] != "token" {
+		t.Fatalf("keyring = %v, want token stored under my-app:session/oauth2", keyring)
+	}
+	got, err := store.Get(t.Context(), "session", "oauth2")
+	if err != nil || got != "token" {
+		t.Fatalf("Get() = (%q, %v), want token", got, err)
+	}
+	if err := store.Delete("session", "oauth2"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(t.Context(), "session", "oauth2"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("Get() after Delete() error = %v, want %v", err, ErrCredentialNotFound)
+	}
+}
+
+func TestKeyringCredentialsStore_EscapesUser(t *testing.T) {
+	keyring := mapKeyring{}
+	store := &KeyringCredentialsStore{Keyring: keyring, Service: "my-app"}
+
+	if err := store.SetForAgent("x", "y/z", "oauth2", "agent-x"); err != nil {
+		t.Fatalf("SetForAgent() error = %v", err)
+	}
+	if err := store.SetForAgent("x/y", "z", "oauth2", "agent-x/y"); err != nil {
+		t.Fatalf("SetForAgent() error = %v", err)
+	}
+	if err := store.Set("x/y", "z/oauth2", "shared"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if len(keyring) != 3 {
+		t.Fatalf("keyring = %v, want 3 distinct items", keyring)
+	}
+
+	got, err := store.Get(WithAgentID(t.Context(), "x"), "y/z", "oauth2")
+	if err != nil || got != "agent-x" {
+		t.Fatalf("Get() for agent x = (%q, %v), want agent-x", got, err)
+	}
+	got, err = store.Get(t.Context(), "x/y", "z/oauth2")
+	if err != nil || got != "shared" {
+		t.Fatalf("Get() = (%q, %v), want shared", got, err)
+	}
+}
+
+func TestKeyringCredentialsStore_Agents(t *testing.T) {
+	keyring := mapKeyring{}
+	store := &KeyringCredentialsStore{Keyring: keyring, Service: "my-app"}
+
+	if err := store.Set("session", "oauth2", "shared"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := store.SetForAgent("https://agent-a.com", "session", "oauth2", "agent-a"); err != nil {
+		t.Fatalf("SetForAgent() error = %v", err)
+	}
+	if keyring["my-app:https:%2F%2Fagent-a.com/session/oauth2"] != "agent-a" {
+		t.Fatalf("keyring = %v, want agent-a stored under my-app:https:%%2F%%2Fagent-a.com/session/oauth2", keyring)
+	}
+
+	got, err := store.Get(WithAgentID(t.Context(), "https://agent-a.com"), "session", "oauth2")
+	if err != nil || got != "agent-a" {
+		t.Fatalf("Get() for agent-a = (%q, %v), want agent-a", got, err)
+	}
+	got, err = store.Get(WithAgentID(t.Context(), "https://agent-b.com"), "session", "oauth2")
+	if err != nil || got != "shared" {
+		t.Fatalf("Get() for agent-b = (%q, %v), want shared", got, err)
+	}
+
+	if err := store.DeleteForAgent("https://agent-a.com", "session", "oauth2"); err != nil {
+		t.Fatalf("DeleteForAgent() error = %v", err)
+	}
+	got, err = store.Get(WithAgentID(t.Context(), "https://agent-a.com"), "session", "oauth2")
+	if err != nil || got != "shared" {
+		t.Fatalf("Get() after DeleteForAgent() = (%q, %v), want shared", got, err)
+	}
+}
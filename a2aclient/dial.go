@@ -0,0 +1,193 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient/agentcard"
+)
+
+// DefaultDialTimeout is the default timeout applied by Dial to fetching the AgentCard and to every
+// non-streaming call made by the returned Client.
+const DefaultDialTimeout = 30 * time.Second
+
+// DialOption customizes Dial behavior.
+type DialOption func(c *dialConfig)
+
+type dialConfig struct {
+	timeout    time.Duration
+	retries    bool
+	httpClient *http.Client
+	cardPath   string
+	options    []FactoryOption
+}
+
+// WithDialTimeout overrides DefaultDialTimeout. Zero disables the timeout.
+func WithDialTimeout(timeout time.Duration) DialOption {
+	return func(c *dialConfig) {
+		c.timeout = timeout
+	}
+}
+
+// WithoutDialRetries disables retrying idempotent calls which failed with a transient error.
+func WithoutDialRetries() DialOption {
+	return func(c *dialConfig) {
+		c.retries = false
+	}
+}
+
+// WithDialHTTPClient makes Dial use the provided client for fetching the AgentCard.
+func WithDialHTTPClient(client *http.Client) DialOption {
+	return func(c *dialConfig) {
+		c.httpClient = client
+	}
+}
+
+// WithDialCardPath makes Dial fetch the AgentCard from the provided path instead of the well-known one.
+func WithDialCardPath(path string) DialOption {
+	return func(c *dialConfig) {
+		c.cardPath = path
+	}
+}
+
+// WithDialFactoryOptions applies Factory options, eg. additional transports or interceptors.
+func WithDialFactoryOptions(opts ...FactoryOption) DialOption {
+	return func(c *dialConfig) {
+		c.options = append(c.options, opts...)
+	}
+}
+
+// Dial is a high-level entry point for scripts and CLIs. It fetches the AgentCard published at baseURL,
+// negotiates a transport supported by both sides and returns a ready Client.
+// The Client fails non-streaming calls which take longer than DefaultDialTimeout and retries
+// idempotent calls once if they failed with an error which doesn't come from the protocol.
+func Dial(ctx context.Context, baseURL string, opts ...DialOption) (*Client, error) {
+	config := &dialConfig{timeout: DefaultDialTimeout, retries: true}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	httpClient := config.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: config.timeout}
+	}
+	var resolveOpts []agentcard.ResolveOption
+	if config.cardPath != "" {
+		resolveOpts = append(resolveOpts, agentcard.WithPath(config.cardPath))
+	}
+	resolver := &agentcard.Resolver{BaseURL: baseURL, Client: httpClient}
+	card, err := resolver.Resolve(ctx, resolveOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var interceptors []CallInterceptor
+	if config.timeout > 0 {
+		interceptors = append(interceptors, timeoutInterceptor{timeout: config.timeout})
+	}
+	if config.retries {
+		interceptors = append(interceptors, retryInterceptor{})
+	}
+	options := append([]FactoryOption{WithInterceptors(interceptors...)}, config.options...)
+	client, err := NewFactory(options...).CreateFromCard(ctx, card)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", baseURL, err)
+	}
+	return client, nil
+}
+
+// streamingMethods are not subject to timeoutInterceptor, because streams are expected to be long-lived.
+var streamingMethods = map[string]bool{
+	"SendStreamingMessage": true,
+	"ResubscribeToTask":    true,
+}
+
+// idempotentMethods can be safely repeated by retryInterceptor.
+var idempotentMethods = map[string]bool{
+	"GetTask":            true,
+	"GetTaskPushConfig":  true,
+	"ListTaskPushConfig": true,
+	"GetAgentCard":       true,
+}
+
+// protocolErrors are reported by agents and are not going to go away on retry.
+var protocolErrors = []error{
+	a2a.ErrTaskNotFound,
+	a2a.ErrTaskNotCancelable,
+	a2a.ErrPushNotificationNotSupported,
+	a2a.ErrUnsupportedOperation,
+	a2a.ErrUnsupportedContentType,
+	a2a.ErrInvalidAgentResponse,
+	a2a.ErrInvalidRequest,
+	a2a.ErrAuthenticatedExtendedCardNotConfigured,
+	ErrNotImplemented,
+}
+
+type timeoutCancelKey struct{}
+
+// timeoutInterceptor applies a timeout to non-streaming calls without a deadline.
+type timeoutInterceptor struct {
+	timeout time.Duration
+}
+
+func (ti timeoutInterceptor) Before(ctx context.Context, req *Request) (context.Context, error) {
+	callCtx, _ := CallContextFrom(ctx)
+	if streamingMethods[callCtx.Method] {
+		return ctx, nil
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, ti.timeout)
+	return context.WithValue(ctx, timeoutCancelKey{}, cancel), nil
+}
+
+func (ti timeoutInterceptor) After(ctx context.Context, resp *Response) error {
+	if cancel, ok := ctx.Value(timeoutCancelKey{}).(context.CancelFunc); ok {
+		cancel()
+	}
+	return nil
+}
+
+// retryInterceptor requests a retry of idempotent calls which failed with a transient error.
+type retryInterceptor struct{}
+
+func (retryInterceptor) Before(ctx context.Context, req *Request) (context.Context, error) {
+	return ctx, nil
+}
+
+func (retryInterceptor) After(ctx context.Context, resp *Response) error {
+	callCtx, _ := CallContextFrom(ctx)
+	if resp.Err == nil || !idempotentMethods[callCtx.Method] || ctx.Err() != nil {
+		return nil
+	}
+	for _, err := range protocolErrors {
+		if errors.Is(resp.Err, err) {
+			return nil
+		}
+	}
+	var challenge *AuthChallengeError
+	if errors.As(resp.Err, &challenge) {
+		return nil
+	}
+	resp.Retry = true
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+const testProtocol = a2a.TransportProtocol("test")
+
+func newDialServer(t *testing.T, card *a2a.AgentCard) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/agent-card.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(card)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func dialTransport(transport *mockTransport) DialOption {
+	return WithDialFactoryOptions(WithTransport(testProtocol, TransportFactoryFn(func(ctx context.Context, url string, card *a2a.AgentCard) (Transport, error) {
+		return transport, nil
+	})))
+}
+
+func TestDial(t *testing.T) {
+	server := newDialServer(t, &a2a.AgentCard{Name: "agent", URL: "test://agent", PreferredTransport: testProtocol})
+	var gotDeadline bool
+	transport := &mockTransport{
+		GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			_, gotDeadline = ctx.Deadline()
+			return &a2a.Task{ID: query.ID}, nil
+		},
+	}
+
+	client, err := Dial(t.Context(), server.URL, dialTransport(transport))
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	if client.card.Name != "agent" {
+		t.Fatalf("Dial() card = %+v, want the fetched card", client.card)
+	}
+	if _, err := client.GetTask(t.Context(), a2a.TaskQueryParams{ID: "task"}); err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if !gotDeadline {
+		t.Fatal("GetTask() context has no deadline, want the default timeout applied")
+	}
+}
+
+func TestDial_NoCompatibleTransport(t *testing.T) {
+	server := newDialServer(t, &a2a.AgentCard{URL: "test://agent", PreferredTransport: "unknown"})
+
+	if _, err := Dial(t.Context(), server.URL, dialTransport(&mockTransport{})); err == nil {
+		t.Fatal("Dial() error = nil, want an error")
+	}
+}
+
+func TestDial_Retries(t *testing.T) {
+	server := newDialServer(t, &a2a.AgentCard{URL: "test://agent", PreferredTransport: testProtocol})
+	tests := []struct {
+		name      string
+		call      func(client *Client) error
+		err       error
+		opts      []DialOption
+		wantCalls int
+	}{
+		{
+			name:      "idempotent call retried",
+			call:      func(client *Client) error { _, err := client.GetTask(t.Context(), a2a.TaskQueryParams{}); return err },
+			err:       errors.New("connection reset"),
+			wantCalls: 2,
+		},
+		{
+			name:      "protocol error not retried",
+			call:      func(client *Client) error { _, err := client.GetTask(t.Context(), a2a.TaskQueryParams{}); return err },
+			err:       a2a.ErrTaskNotFound,
+			wantCalls: 1,
+		},
+		{
+			name:      "retries disabled",
+			call:      func(client *Client) error { _, err := client.GetTask(t.Context(), a2a.TaskQueryParams{}); return err },
+			err:       errors.New("connection reset"),
+			opts:      []DialOption{WithoutDialRetries()},
+			wantCalls: 1,
+		},
+		{
+			name: "non-idempotent call not retried",
+			call: func(client *Client) error {
+				_, err := client.SendMessage(t.Context(), a2a.MessageSendParams{})
+				return err
+			},
+			err:       errors.New("connection reset"),
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			transport := &mockTransport{
+				GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+					calls++
+					return nil, tt.err
+				},
+				SendMessageFunc: func(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+					calls++
+					return nil, tt.err
+				},
+			}
+			client, err := Dial(t.Context(), server.URL, append(tt.opts, dialTransport(transport))...)
+			if err != nil {
+				t.Fatalf("Dial() error = %v", err)
+			}
+			if err := tt.call(client); !errors.Is(err, tt.err) {
+				t.Fatalf("call error = %v, want %v", err, tt.err)
+			}
+			if calls != tt.wantCalls {
+				t.Fatalf("transport called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDial_Timeout(t *testing.T) {
+	server := newDialServer(t, &a2a.AgentCard{URL: "test://agent", PreferredTransport: testProtocol})
+	transport := &mockTransport{
+		GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+
+	client, err := Dial(t.Context(), server.URL, dialTransport(transport), WithDialTimeout(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	if _, err := client.GetTask(t.Context(), a2a.TaskQueryParams{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetTask() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
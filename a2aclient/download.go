@@ -0,0 +1,219 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// maxFileNameCollisions limits the number of suffixes tried when a file with the same name exists.
+const maxFileNameCollisions = 1000
+
+// DownloadArtifacts writes the files of all artifacts of the task to destDir and returns the paths of the
+// written files. FileBytes parts are decoded and FileURI parts are downloaded using Config.HTTPClient.
+// Downloads are made through CallInterceptors with the "DownloadArtifact" method and an a2a.FileURI payload,
+// and CallMeta is sent as HTTP headers if the file is hosted on the origin of the agent, so that
+// credentials are never sent to third parties. Text and data parts are skipped.
+//
+// File names are taken from the file, the artifact name or the artifact ID, in that order, stripped of
+// path separators and characters not allowed by common file systems. Existing files are never overwritten,
+// a "-1", "-2", ... suffix is added to the name instead. The first error stops the download.
+func (c *Client) DownloadArtifacts(ctx context.Context, task *a2a.Task, destDir string) ([]string, error) {
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create destination directory: %w", err)
+	}
+	var paths []string
+	for _, artifact := range task.Artifacts {
+		for _, part := range artifact.Parts {
+			filePart, ok := part.(a2a.FilePart)
+			if !ok {
+				continue
+			}
+			var path string
+			var err error
+			switch file := filePart.File.(type) {
+			case a2a.FileBytes:
+				path, err = c.writeFileBytes(artifact, file, destDir)
+			case a2a.FileURI:
+				path, err = doCall(ctx, c, "DownloadArtifact", file, func(ctx context.Context, file a2a.FileURI) (string, error) {
+					return c.downloadFile(ctx, artifact, file, destDir)
+				})
+			default:
+				err = fmt.Errorf("unsupported file content %T", file)
+			}
+			if err != nil {
+				return paths, fmt.Errorf("failed to download artifact %s: %w", artifact.ID, err)
+			}
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+func (c *Client) writeFileBytes(artifact *a2a.Artifact, file a2a.FileBytes, destDir string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(file.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode file bytes: %w", err)
+	}
+	out, err := createArtifactFile(destDir, artifactFileName(artifact, file.FileMeta))
+	if err != nil {
+		return "", err
+	}
+	if _, err := out.Write(data); err != nil {
+		_ = out.Close()
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	return out.Name(), out.Close()
+}
+
+func (c *Client) downloadFile(ctx context.Context, artifact *a2a.Artifact, file a2a.FileURI, destDir string) (string, error) {
+	u, err := url.Parse(file.URI)
+	if err != nil {
+		return "", fmt.Errorf("invalid file URI: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("unsupported file URI scheme %q", u.Scheme)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, file.URI, nil)
+	if err != nil {
+		return "", err
+	}
+	if meta, ok := CallMetaFrom(ctx); ok && c.sameOrigin(u) {
+		for k, v := range meta {
+			req.Header.Set(k, v)
+		}
+	}
+
+	client := c.Config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("download failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return "", &AuthChallengeError{
+			Challenge: resp.Header.Get("WWW-Authenticate"),
+			Err:       fmt.Errorf("download failed with status %s", resp.Status),
+		}
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("download failed with status %s", resp.Status)
+	}
+
+	meta := file.FileMeta
+	if meta.MimeType == "" {
+		meta.MimeType = resp.Header.Get("Content-Type")
+	}
+	if meta.Name == "" {
+		meta.Name = filepath.Base(u.Path)
+	}
+	out, err := createArtifactFile(destDir, artifactFileName(artifact, meta))
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		_ = out.Close()
+		_ = os.Remove(out.Name())
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	return out.Name(), out.Close()
+}
+
+// sameOrigin reports whether the URL has the scheme and host of the AgentCard URL.
+func (c *Client) sameOrigin(u *url.URL) bool {
+	card := c.AgentCard()
+	if card == nil {
+		return false
+	}
+	agent, err := url.Parse(card.URL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(agent.Scheme, u.Scheme) && strings.EqualFold(agent.Host, u.Host)
+}
+
+// artifactFileName returns a sanitized file name with an extension derived from the MIME type if it has none.
+func artifactFileName(artifact *a2a.Artifact, meta a2a.FileMeta) string {
+	name := meta.Name
+	if name == "" || name == "/" || name == "." {
+		name = artifact.Name
+	}
+	if name == "" {
+		name = string(artifact.ID)
+	}
+	name = sanitizeFileName(name)
+	if filepath.Ext(name) == "" && meta.MimeType != "" {
+		if mediaType, _, err := mime.ParseMediaType(meta.MimeType); err == nil {
+			if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+				name += exts[0]
+			}
+		}
+	}
+	return name
+}
+
+func sanitizeFileName(name string) string {
+	// agents might send names with either separator regardless of the client OS
+	name = name[strings.LastIndexAny(name, `/\`)+1:]
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(`<>:"|?*`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.TrimLeft(strings.TrimSpace(name), ".")
+	if name == "" {
+		return "artifact"
+	}
+	return name
+}
+
+// createArtifactFile exclusively creates a file with the name or with a numbered suffix if the name is taken.
+func createArtifactFile(dir, name string) (*os.File, error) {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 0; i < maxFileNameCollisions; i++ {
+		candidate := name
+		if i > 0 {
+			candidate = fmt.Sprintf("%s-%d%s", base, i, ext)
+		}
+		file, err := os.OpenFile(filepath.Join(dir, candidate), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create file: %w", err)
+		}
+		return file, nil
+	}
+	return nil, fmt.Errorf("too many files named %q", name)
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+type bearerInterceptor struct {
+	PassthroughInterceptor
+}
+
+func (bearerInterceptor) Before(ctx context.Context, req *Request) (context.Context, error) {
+	req.Meta["Authorization"] = "Bearer secret"
+	return ctx, nil
+}
+
+func TestClient_DownloadArtifacts(t *testing.T) {
+	agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("report"))
+	}))
+	defer agent.Close()
+	var thirdPartyAuth string
+	thirdParty := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		thirdPartyAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("png"))
+	}))
+	defer thirdParty.Close()
+
+	client := &Client{
+		transport:    &mockTransport{},
+		interceptors: []CallInterceptor{bearerInterceptor{}},
+		card:         &a2a.AgentCard{URL: agent.URL + "/a2a"},
+	}
+	task := &a2a.Task{Artifacts: []*a2a.Artifact{
+		{ID: "a1", Parts: a2a.ContentParts{
+			a2a.TextPart{Text: "skipped"},
+			a2a.FilePart{File: a2a.FileURI{URI: agent.URL + "/files/report.txt"}},
+			a2a.FilePart{File: a2a.FileBytes{
+				FileMeta: a2a.FileMeta{Name: "../report.txt"},
+				Bytes:    base64.StdEncoding.EncodeToString([]byte("inline")),
+			}},
+		}},
+		{ID: "chart", Parts: a2a.ContentParts{a2a.FilePart{File: a2a.FileURI{URI: thirdParty.URL + "/"}}}},
+	}}
+
+	dir := t.TempDir()
+	paths, err := client.DownloadArtifacts(t.Context(), task, dir)
+	if err != nil {
+		t.Fatalf("DownloadArtifacts() error = %v", err)
+	}
+	want := map[string]string{"report.txt": "report", "report-1.txt": "inline", "chart.png": "png"}
+	if len(paths) != len(want) {
+		t.Fatalf("DownloadArtifacts() = %v, want %d files", paths, len(want))
+	}
+	for name, content := range want {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(data) != content {
+			t.Fatalf("file %s = %q, %v, want %q", name, data, err, content)
+		}
+	}
+	if thirdPartyAuth != "" {
+		t.Fatalf("third party received Authorization %q, want none", thirdPartyAuth)
+	}
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	testCases := map[string]string{
+		"report.pdf":          "report.pdf",
+		"../../etc/passwd":    "passwd",
+		`C:\Users\me\a.txt`:   "a.txt",
+		"what?.txt":           "what_.txt",
+		".hidden":             "hidden",
+		"..":                  "artifact",
+		"line\nbreak<>.md":    "line_break__.md",
+		"  spaced name.txt  ": "spaced name.txt",
+	}
+	for name, want := range testCases {
+		if got := sanitizeFileName(name); got != want {
+			t.Errorf("sanitizeFileName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// ErrDryRunUnsupported is returned by ValidateMessage if the agent processed the message instead
+// of performing a dry run.
+var ErrDryRunUnsupported = errors.New("agent doesn't support dry runs")
+
+// ValidateMessage performs a dry run of SendMessage: the agent validates the message, the same as it would
+// before processing it, and returns the Task which would be created without doing any work. This lets UIs
+// verify user input before committing to expensive runs. Validation failures are returned as errors, while
+// a message vetoed by content moderation results in a Task in the rejected state. If the agent described
+// the rejection, the Task is returned together with RejectedError.
+//
+// ErrDryRunUnsupported is returned together with the result if the agent ignored the dry run request,
+// which means the message was processed.
+func (c *Client) ValidateMessage(ctx context.Context, message a2a.MessageSendParams) (*a2a.Task, error) {
+	result, err := c.SendMessage(ctx, a2a.WithDryRun(message))
+	var rejected *RejectedError
+	if errors.As(err, &rejected) {
+		return rejected.Task, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	task, ok := result.(*a2a.Task)
+	if !ok || !a2a.IsDryRunResult(task) {
+		return task, fmt.Errorf("%w: got %T result", ErrDryRunUnsupported, result)
+	}
+	return task, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestClient_ValidateMessage(t *testing.T) {
+	supported := true
+	transport := &mockTransport{
+		SendMessageFunc: func(ctx context.Context, params a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+			task := &a2a.Task{ID: params.Message.TaskID, Status: a2a.TaskStatus{State: a2a.TaskStateSubmitted}}
+			if supported && a2a.IsDryRun(&params) {
+				task.Metadata = map[string]any{a2a.DryRunMetaKey: true}
+			}
+			return task, nil
+		},
+	}
+	client := &Client{transport: transport}
+	params := a2a.MessageSendParams{Message: a2a.Message{TaskID: "task-1"}, Metadata: map[string]any{"key": "value"}}
+
+	task, err := client.ValidateMessage(t.Context(), params)
+	if err != nil || task.ID != "task-1" || !a2a.IsDryRunResult(task) {
+		t.Fatalf("ValidateMessage() = %+v, %v, want dry run Task", task, err)
+	}
+	if a2a.IsDryRun(&params) {
+		t.Fatalf("ValidateMessage() modified the caller params metadata: %v", params.Metadata)
+	}
+
+	supported = false
+	if _, err := client.ValidateMessage(t.Context(), params); !errors.Is(err, ErrDryRunUnsupported) {
+		t.Fatalf("ValidateMessage() error = %v, want %v", err, ErrDryRunUnsupported)
+	}
+}
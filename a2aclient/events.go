@@ -0,0 +1,205 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
+)
+
+// ErrStreamTimeout is returned by an iterator created with WithTimeout when no event arrived in time.
+var ErrStreamTimeout = errors.New("timed out waiting for the next event")
+
+// CollectTask consumes the stream and returns the Task built by applying Task snapshots, status
+// and artifact updates in the order they were received. Messages are skipped. An error is returned
+// if the stream fails or doesn't reference a Task.
+func CollectTask(events iter.Seq2[a2a.Event, error]) (*a2a.Task, error) {
+	var updates *taskupdate.Manager
+	for event, err := range events {
+		if err != nil {
+			return nil, err
+		}
+		if updates == nil {
+			task, ok := initialTask(event)
+			if !ok {
+				continue
+			}
+			updates = taskupdate.NewManager(discardSaver{}, task)
+		}
+		if err := updates.Process(context.Background(), event); err != nil {
+			return nil, fmt.Errorf("%w: failed to apply %T: %v", a2a.ErrInvalidAgentResponse, event, err)
+		}
+	}
+	if updates == nil {
+		return nil, fmt.Errorf("%w: stream had no task events", a2a.ErrInvalidAgentResponse)
+	}
+	return updates.Task, nil
+}
+
+// initialTask returns the Task the first Task event of a stream is applied to.
+func initialTask(event a2a.Event) (*a2a.Task, bool) {
+	switch v := event.(type) {
+	case *a2a.Task:
+		return &a2a.Task{ID: v.ID, ContextID: v.ContextID}, true
+	case *a2a.TaskStatusUpdateEvent:
+		return &a2a.Task{ID: v.TaskID, ContextID: v.ContextID}, true
+	case *a2a.TaskArtifactUpdateEvent:
+		return &a2a.Task{ID: v.TaskID, ContextID: v.ContextID}, true
+	default:
+		return nil, false
+	}
+}
+
+// discardSaver is a taskupdate.Saver for Tasks which are only aggregated in memory.
+type discardSaver struct{}
+
+func (discardSaver) Save(ctx context.Context, task *a2a.Task) error {
+	return nil
+}
+
+// FirstMessage returns the first Message in the stream and stops iteration. An error is returned
+// if the stream fails or ends without a Message.
+func FirstMessage(events iter.Seq2[a2a.Event, error]) (*a2a.Message, error) {
+	for event, err := range events {
+		if err != nil {
+			return nil, err
+		}
+		if msg, ok := event.(*a2a.Message); ok {
+			return msg, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: stream ended without a message", a2a.ErrInvalidAgentResponse)
+}
+
+// UntilTerminal yields events until a Task snapshot or status update with a terminal state,
+// which is the last yielded event. It is useful for streams which stay open after the Task finished.
+func UntilTerminal(events iter.Seq2[a2a.Event, error]) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		for event, err := range events {
+			if !yield(event, err) || err != nil {
+				return
+			}
+			if status, ok := statusOf(event); ok && status.State.Terminal() {
+				return
+			}
+		}
+	}
+}
+
+// OnStatus calls fn with the status of every Task snapshot and status update before yielding it.
+// Other events are passed through as is.
+func OnStatus(events iter.Seq2[a2a.Event, error], fn func(taskID a2a.TaskID, status a2a.TaskStatus)) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		for event, err := range events {
+			if err == nil {
+				if status, ok := statusOf(event); ok {
+					fn(taskIDOf(event), status)
+				}
+			}
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+func statusOf(event a2a.Event) (a2a.TaskStatus, bool) {
+	switch v := event.(type) {
+	case *a2a.Task:
+		return v.Status, true
+	case *a2a.TaskStatusUpdateEvent:
+		return v.Status, true
+	default:
+		return a2a.TaskStatus{}, false
+	}
+}
+
+func taskIDOf(event a2a.Event) a2a.TaskID {
+	tracker := taskTracker{}
+	tracker.observe(event)
+	return tracker.taskID
+}
+
+// MapEvents converts every event of the stream using fn. Iteration stops after the first error
+// of the stream or fn.
+func MapEvents[T any](events iter.Seq2[a2a.Event, error], fn func(a2a.Event) (T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for event, err := range events {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			mapped, err := fn(event)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(mapped, nil) {
+				return
+			}
+		}
+	}
+}
+
+// WithTimeout fails the stream with ErrStreamTimeout if no event arrives within timeout of the
+// previous one or of the start of iteration. The source is read in a separate goroutine which
+// exits once the source yields its next event, so the context of the streaming call should be
+// canceled after a timeout to release the connection.
+func WithTimeout(events iter.Seq2[a2a.Event, error], timeout time.Duration) iter.Seq2[a2a.Event, error] {
+	type result struct {
+		event a2a.Event
+		err   error
+	}
+	return func(yield func(a2a.Event, error) bool) {
+		results := make(chan result)
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			defer close(results)
+			for event, err := range events {
+				select {
+				case results <- result{event, err}:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		for {
+			select {
+			case r, ok := <-results:
+				if !ok {
+					return
+				}
+				if !yield(r.event, r.err) || r.err != nil {
+					return
+				}
+				timer.Reset(timeout)
+			case <-timer.C:
+				yield(nil, ErrStreamTimeout)
+				return
+			}
+		}
+	}
+}
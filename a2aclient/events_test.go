@@ -0,0 +1,166 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestCollectTask(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	events := []a2a.Event{
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil),
+		a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "progress"}),
+		&a2a.TaskArtifactUpdateEvent{TaskID: task.ID, ContextID: task.ContextID, Artifact: &a2a.Artifact{ID: "a", Parts: a2a.ContentParts{a2a.TextPart{Text: "Hel"}}}},
+		&a2a.TaskArtifactUpdateEvent{TaskID: task.ID, ContextID: task.ContextID, Append: true, Artifact: &a2a.Artifact{ID: "a", Parts: a2a.ContentParts{a2a.TextPart{Text: "lo"}}}},
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil),
+	}
+
+	got, err := CollectTask(eventSeq(events, nil))
+	if err != nil {
+		t.Fatalf("CollectTask() error = %v", err)
+	}
+	if got.ID != task.ID || got.Status.State != a2a.TaskStateCompleted {
+		t.Fatalf("CollectTask() = %+v, want completed %s", got, task.ID)
+	}
+	wantParts := a2a.ContentParts{a2a.TextPart{Text: "Hel"}, a2a.TextPart{Text: "lo"}}
+	if len(got.Artifacts) != 1 || !reflect.DeepEqual(got.Artifacts[0].Parts, wantParts) {
+		t.Fatalf("CollectTask() artifacts = %v, want one artifact with %v", got.Artifacts, wantParts)
+	}
+
+	if _, err := CollectTask(eventSeq([]a2a.Event{a2a.NewMessage(a2a.MessageRoleAgent)}, nil)); !errors.Is(err, a2a.ErrInvalidAgentResponse) {
+		t.Fatalf("CollectTask() without task events error = %v, want %v", err, a2a.ErrInvalidAgentResponse)
+	}
+	streamErr := errors.New("connection reset")
+	if _, err := CollectTask(eventSeq(events[:1], streamErr)); !errors.Is(err, streamErr) {
+		t.Fatalf("CollectTask() error = %v, want %v", err, streamErr)
+	}
+}
+
+func TestFirstMessage(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	first := a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "first"})
+	events := []a2a.Event{
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil),
+		first,
+		a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "second"}),
+	}
+
+	got, err := FirstMessage(eventSeq(events, errors.New("not reached")))
+	if err != nil || got != first {
+		t.Fatalf("FirstMessage() = %v, %v, want %v", got, err, first)
+	}
+	if _, err := FirstMessage(eventSeq(events[:1], nil)); !errors.Is(err, a2a.ErrInvalidAgentResponse) {
+		t.Fatalf("FirstMessage() without messages error = %v, want %v", err, a2a.ErrInvalidAgentResponse)
+	}
+}
+
+func TestUntilTerminal_OnStatus(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	events := []a2a.Event{
+		task,
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil),
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil),
+		a2a.NewMessage(a2a.MessageRoleAgent),
+	}
+
+	var states []a2a.TaskState
+	count := 0
+	for _, err := range UntilTerminal(OnStatus(eventSeq(events, nil), func(taskID a2a.TaskID, status a2a.TaskStatus) {
+		if taskID != task.ID {
+			t.Errorf("OnStatus() taskID = %s, want %s", taskID, task.ID)
+		}
+		states = append(states, status.State)
+	})) {
+		if err != nil {
+			t.Fatalf("iteration error = %v", err)
+		}
+		count++
+	}
+	if count != 3 {
+		t.Fatalf("UntilTerminal() yielded %d events, want 3", count)
+	}
+	// the Task snapshot has an empty state
+	want := []a2a.TaskState{"", a2a.TaskStateWorking, a2a.TaskStateCompleted}
+	if !reflect.DeepEqual(states, want) {
+		t.Fatalf("OnStatus() states = %v, want %v", states, want)
+	}
+}
+
+func TestMapEvents(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	events := []a2a.Event{task, a2a.NewMessage(a2a.MessageRoleAgent)}
+	mapErr := errors.New("unexpected message")
+
+	var got []string
+	var gotErr error
+	for kind, err := range MapEvents(eventSeq(events, nil), func(event a2a.Event) (string, error) {
+		if _, ok := event.(*a2a.Message); ok {
+			return "", mapErr
+		}
+		return "task", nil
+	}) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		got = append(got, kind)
+	}
+	if !reflect.DeepEqual(got, []string{"task"}) || !errors.Is(gotErr, mapErr) {
+		t.Fatalf("MapEvents() = %v, %v, want [task], %v", got, gotErr, mapErr)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	release := make(chan struct{})
+	defer close(release)
+	slow := func(yield func(a2a.Event, error) bool) {
+		if !yield(task, nil) {
+			return
+		}
+		<-release
+		yield(a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil), nil)
+	}
+
+	var got []a2a.Event
+	var gotErr error
+	for event, err := range WithTimeout(slow, 50*time.Millisecond) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		got = append(got, event)
+	}
+	if len(got) != 1 || !errors.Is(gotErr, ErrStreamTimeout) {
+		t.Fatalf("WithTimeout() = %v, %v, want 1 event and %v", got, gotErr, ErrStreamTimeout)
+	}
+
+	count := 0
+	for _, err := range WithTimeout(eventSeq([]a2a.Event{task, task}, nil), time.Second) {
+		if err != nil {
+			t.Fatalf("WithTimeout() error = %v", err)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Fatalf("WithTimeout() yielded %d events, want 2", count)
+	}
+}
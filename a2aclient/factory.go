@@ -16,39 +16,103 @@ package a2aclient
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
 
 	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2ahttp"
 )
 
 // Factory provides an API for creating Clients compatible with the requested transports.
 // Factory is immutable, but the configuration can be extended using WithAdditionalOptions(f, opts...) call.
 // Additional configurations can be applied at the moment of Client creation.
+// Factory is safe for concurrent use, and options passed to Create calls never modify it.
 type Factory struct {
 	config       Config
 	interceptors []CallInterceptor
 	transports   map[a2a.TransportProtocol]TransportFactory
+	// proxy is applied to the HTTP+JSON transport factory if not nil.
+	proxy *a2ahttp.ProxyConfig
 }
 
 // CreateFromCard returns a Client configured to communicate with the agent described by
 // the provided AgentCard or fails if we couldn't establish a compatible transport.
-func (f *Factory) CreateFromCard(ctx context.Context, card *a2a.AgentCard, opts ...FactoryOption) (Client, error) {
+func (f *Factory) CreateFromCard(ctx context.Context, card *a2a.AgentCard, opts ...FactoryOption) (*Client, error) {
 	if len(opts) > 0 {
 		extended := WithAdditionalOptions(*f, opts...)
 		return extended.CreateFromCard(ctx, card)
 	}
 
-	return Client{}, ErrNotImplemented
+	candidates := f.selectInterfaces(card)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no compatible transports found for agent %s", card.URL)
+	}
+
+	var errs []error
+	for _, iface := range candidates {
+		transport, err := f.transports[iface.protocol].Create(ctx, iface.url, card)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to create %s transport: %w", iface.protocol, err))
+			continue
+		}
+		return &Client{
+			Config:       f.config,
+			transport:    transport,
+			interceptors: slices.Clone(f.interceptors),
+			card:         card,
+		}, nil
+	}
+	return nil, errors.Join(errs...)
+}
+
+type agentInterface struct {
+	protocol a2a.TransportProtocol
+	url      string
+}
+
+// selectInterfaces returns interfaces declared by the card which have a registered TransportFactory.
+// Interfaces are ordered according to Config.PreferredTransports, and only preferred transports are
+// returned if the preference is specified. Otherwise server ordering is used.
+func (f *Factory) selectInterfaces(card *a2a.AgentCard) []agentInterface {
+	preferred := card.PreferredTransport
+	if preferred == "" {
+		preferred = a2a.TransportProtocolJSONRPC
+	}
+	declared := []agentInterface{{protocol: preferred, url: card.URL}}
+	for _, iface := range card.AdditionalInterfaces {
+		declared = append(declared, agentInterface{protocol: a2a.TransportProtocol(iface.Transport), url: iface.URL})
+	}
+
+	supported := slices.DeleteFunc(declared, func(iface agentInterface) bool {
+		_, ok := f.transports[iface.protocol]
+		return !ok
+	})
+	if len(f.config.PreferredTransports) == 0 {
+		return supported
+	}
+
+	var result []agentInterface
+	for _, protocol := range f.config.PreferredTransports {
+		for _, iface := range supported {
+			if iface.protocol == protocol {
+				result = append(result, iface)
+			}
+		}
+	}
+	return result
 }
 
 // CreateFromURL returns a Client configured to communicate with provided URL using
 // one of the provided protocols, or fails if we couldn't establish a compatible transport.
-func (f *Factory) CreateFromURL(ctx context.Context, url string, protocols []string, opts ...FactoryOption) (Client, error) {
+func (f *Factory) CreateFromURL(ctx context.Context, url string, protocols []string, opts ...FactoryOption) (*Client, error) {
 	if len(opts) > 0 {
 		extended := WithAdditionalOptions(*f, opts...)
 		return extended.CreateFromURL(ctx, url, protocols)
 	}
 
-	return Client{}, ErrNotImplemented
+	return nil, ErrNotImplemented
 }
 
 // FactoryOption represents a configuration applied to a Factory.
@@ -83,6 +147,18 @@ func WithInterceptors(interceptors ...CallInterceptor) FactoryOption {
 	})
 }
 
+// WithProxy makes the HTTP+JSON transport of clients created by the factory send requests through the provided
+// proxies instead of the ones configured with HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+// The proxy is set on a clone of the transport of the http.Client passed to WithHTTPJSONTransport, so its
+// other settings are preserved regardless of the order of options. The client must use an *http.Transport.
+// HTTP+JSON transport with http.DefaultClient is enabled if it wasn't configured. Custom factories registered
+// with WithTransport are not affected. Invalid proxy URLs are reported when a Client is created.
+func WithProxy(proxy a2ahttp.ProxyConfig) FactoryOption {
+	return factoryOptionFn(func(f *Factory) {
+		f.proxy = &proxy
+	})
+}
+
 // defaultsDisabledOpt is a marker for creating a Factory without any defaults set.
 type defaultsDisabledOpt struct{}
 
@@ -121,6 +197,16 @@ func NewFactory(options ...FactoryOption) *Factory {
 		o.apply(f)
 	}
 
+	if f.proxy != nil {
+		registered, ok := f.transports[a2a.TransportProtocolHTTPJSON]
+		if !ok {
+			registered = newHTTPJSONTransportFactory(func() (*http.Client, error) { return nil, nil })
+		}
+		if httpJSON, ok := registered.(*httpJSONTransportFactory); ok {
+			f.transports[a2a.TransportProtocolHTTPJSON] = httpJSON.withProxy(*f.proxy)
+		}
+	}
+
 	return f
 }
 
@@ -134,5 +220,8 @@ func WithAdditionalOptions(f Factory, opts ...FactoryOption) *Factory {
 	for k, v := range f.transports {
 		options = append(options, WithTransport(k, v))
 	}
+	if f.proxy != nil {
+		options = append(options, WithProxy(*f.proxy))
+	}
 	return NewFactory(append(options, opts...)...)
 }
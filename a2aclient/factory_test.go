@@ -16,9 +16,19 @@ package a2aclient
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2ahttp"
 )
 
 type mockTransportFactory struct{}
@@ -109,25 +119,217 @@ func TestFactory_CreateNotImplemented(t *testing.T) {
 	factory := NewFactory()
 	ctx := context.Background()
 
-	_, err := factory.CreateFromCard(ctx, &a2a.AgentCard{})
+	_, err := factory.CreateFromURL(ctx, "", nil)
 	if err != ErrNotImplemented {
 		t.Errorf("expected ErrNotImplemented, got %v", err)
 	}
 
 	// With options
-	_, err = factory.CreateFromCard(ctx, &a2a.AgentCard{}, WithConfig(Config{}))
+	_, err = factory.CreateFromURL(ctx, "", nil, WithConfig(Config{}))
 	if err != ErrNotImplemented {
 		t.Errorf("expected ErrNotImplemented, got %v", err)
 	}
+}
 
-	_, err = factory.CreateFromURL(ctx, "", nil)
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+type recordingTransportFactory struct {
+	urls *[]string
+	err  error
+}
+
+func (f recordingTransportFactory) Create(ctx context.Context, url string, card *a2a.AgentCard) (Transport, error) {
+	*f.urls = append(*f.urls, url)
+	if f.err != nil {
+		return nil, f.err
 	}
+	return &mockTransport{}, nil
+}
 
-	// With options
-	_, err = factory.CreateFromURL(ctx, "", nil, WithConfig(Config{}))
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+func TestFactory_CreateFromCard(t *testing.T) {
+	card := &a2a.AgentCard{
+		URL:                "https://agent.com/jsonrpc",
+		PreferredTransport: a2a.TransportProtocolJSONRPC,
+		AdditionalInterfaces: []a2a.AgentInterface{
+			{Transport: string(a2a.TransportProtocolGRPC), URL: "agent.com:443"},
+			{Transport: string(a2a.TransportProtocolHTTPJSON), URL: "https://agent.com/rest"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		supported []a2a.TransportProtocol
+		failing   []a2a.TransportProtocol
+		preferred []a2a.TransportProtocol
+		wantURLs  []string
+		wantErr   bool
+	}{
+		{
+			name:      "server ordering",
+			supported: []a2a.TransportProtocol{a2a.TransportProtocolGRPC, a2a.TransportProtocolJSONRPC},
+			wantURLs:  []string{"https://agent.com/jsonrpc"},
+		},
+		{
+			name:      "client preference",
+			supported: []a2a.TransportProtocol{a2a.TransportProtocolGRPC, a2a.TransportProtocolJSONRPC},
+			preferred: []a2a.TransportProtocol{a2a.TransportProtocolGRPC},
+			wantURLs:  []string{"agent.com:443"},
+		},
+		{
+			name:      "fallback on transport creation failure",
+			supported: []a2a.TransportProtocol{a2a.TransportProtocolHTTPJSON},
+			failing:   []a2a.TransportProtocol{a2a.TransportProtocolJSONRPC},
+			wantURLs:  []string{"https://agent.com/jsonrpc", "https://agent.com/rest"},
+		},
+		{
+			name:      "no overlap with preference",
+			supported: []a2a.TransportProtocol{a2a.TransportProtocolJSONRPC},
+			preferred: []a2a.TransportProtocol{a2a.TransportProtocolGRPC},
+			wantErr:   true,
+		},
+		{
+			name:     "no supported transports",
+			wantErr:  true,
+			wantURLs: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var urls []string
+			options := []FactoryOption{WithDefaultsDisabled(), WithConfig(Config{PreferredTransports: tt.preferred})}
+			for _, protocol := range tt.supported {
+				options = append(options, WithTransport(protocol, recordingTransportFactory{urls: &urls}))
+			}
+			for _, protocol := range tt.failing {
+				options = append(options, WithTransport(protocol, recordingTransportFactory{urls: &urls, err: errors.New("failed")}))
+			}
+
+			client, err := NewFactory(options...).CreateFromCard(t.Context(), card)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("CreateFromCard() error = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateFromCard() error = %v", err)
+			}
+			if client.card != card || client.transport == nil {
+				t.Fatalf("CreateFromCard() = %+v, want a client for the card", client)
+			}
+			if !reflect.DeepEqual(urls, tt.wantURLs) {
+				t.Fatalf("transports created for %v, want %v", urls, tt.wantURLs)
+			}
+		})
+	}
+}
+
+func TestFactory_CreateFromCardAgentID(t *testing.T) {
+	card := &a2a.AgentCard{URL: "https://agent.com/jsonrpc", PreferredTransport: a2a.TransportProtocolJSONRPC}
+	var got AgentID
+	transport := &mockTransport{
+		GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			callCtx, _ := CallContextFrom(ctx)
+			got = callCtx.Agent
+			return &a2a.Task{}, nil
+		},
+	}
+	factory := NewFactory(WithDefaultsDisabled(), WithTransport(a2a.TransportProtocolJSONRPC, TransportFactoryFn(
+		func(ctx context.Context, url string, card *a2a.AgentCard) (Transport, error) { return transport, nil },
+	)))
+
+	client, err := factory.CreateFromCard(t.Context(), card)
+	if err != nil {
+		t.Fatalf("CreateFromCard() error = %v", err)
+	}
+	if _, err := client.GetTask(t.Context(), a2a.TaskQueryParams{}); err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if got != AgentID(card.URL) {
+		t.Fatalf("CallContext.Agent = %q, want %q", got, card.URL)
+	}
+}
+
+func TestFactory_WithProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		_ = json.NewEncoder(w).Encode(a2a.Task{ID: "task-1", ContextID: "ctx"})
+	}))
+	t.Cleanup(proxy.Close)
+	card := &a2a.AgentCard{URL: "http://agent.example.com", PreferredTransport: a2a.TransportProtocolHTTPJSON}
+
+	factory := NewFactory(WithDefaultsDisabled(), WithHTTPJSONTransport(nil), WithProxy(a2ahttp.ProxyConfig{HTTPProxy: proxy.URL}))
+	client, err := factory.CreateFromCard(t.Context(), card)
+	if err != nil {
+		t.Fatalf("CreateFromCard() error = %v", err)
+	}
+	if _, err := client.GetTask(t.Context(), a2a.TaskQueryParams{ID: "task-1"}); err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if want := []string{"http://agent.example.com/v1/tasks/task-1"}; !reflect.DeepEqual(proxied, want) {
+		t.Fatalf("proxied requests = %v, want %v", proxied, want)
+	}
+
+	wrapped := &http.Client{Transport: a2ahttp.NewWireLogRoundTripper(http.DefaultTransport, io.Discard)}
+	unsupported := NewFactory(WithDefaultsDisabled(), WithHTTPJSONTransport(wrapped), WithProxy(a2ahttp.ProxyConfig{HTTPProxy: proxy.URL}))
+	if _, err := unsupported.CreateFromCard(t.Context(), card); err == nil {
+		t.Fatal("CreateFromCard() with a wrapped transport error = nil, want an error")
+	}
+
+	invalid := NewFactory(WithDefaultsDisabled(), WithProxy(a2ahttp.ProxyConfig{HTTPProxy: "http://[::1"}))
+	if _, err := invalid.CreateFromCard(t.Context(), card); err == nil {
+		t.Fatal("CreateFromCard() with invalid proxy URL error = nil, want an error")
+	}
+}
+
+func TestFactory_WithProxy_KeepsClientSettings(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		_ = json.NewEncoder(w).Encode(a2a.Task{ID: "task-1", ContextID: "ctx"})
+	}))
+	t.Cleanup(proxy.Close)
+	card := &a2a.AgentCard{URL: "http://agent.example.com", PreferredTransport: a2a.TransportProtocolHTTPJSON}
+
+	var dials atomic.Int32
+	dialer := &net.Dialer{}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dials.Add(1)
+		return dialer.DialContext(ctx, network, addr)
+	}
+	client := &http.Client{Transport: transport, Timeout: time.Minute}
+
+	// WithProxy is applied regardless of whether it comes before or after WithHTTPJSONTransport.
+	factory := NewFactory(WithDefaultsDisabled(), WithProxy(a2ahttp.ProxyConfig{HTTPProxy: proxy.URL}), WithHTTPJSONTransport(client))
+	created, err := factory.CreateFromCard(t.Context(), card)
+	if err != nil {
+		t.Fatalf("CreateFromCard() error = %v", err)
+	}
+	if _, err := created.GetTask(t.Context(), a2a.TaskQueryParams{ID: "task-1"}); err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if len(proxied) != 1 {
+		t.Fatalf("proxied requests = %v, want 1", proxied)
+	}
+	if dials.Load() == 0 {
+		t.Fatal("custom dialer was not used")
+	}
+	httpJSON := created.transport.(*httpJSONTransport)
+	if httpJSON.client.Timeout != time.Minute {
+		t.Fatalf("client timeout = %v, want %v", httpJSON.client.Timeout, time.Minute)
+	}
+	if transport.Proxy != nil {
+		t.Fatal("WithProxy modified the transport of the provided client")
+	}
+
+	extended := WithAdditionalOptions(*factory, WithConfig(Config{}))
+	again, err := extended.CreateFromCard(t.Context(), card)
+	if err != nil {
+		t.Fatalf("CreateFromCard() on extended factory error = %v", err)
+	}
+	proxied = nil
+	if _, err := again.GetTask(t.Context(), a2a.TaskQueryParams{ID: "task-1"}); err != nil || len(proxied) != 1 {
+		t.Fatalf("GetTask() on extended factory = %v, proxied %v, want proxied request", err, proxied)
 	}
 }
@@ -16,17 +16,27 @@ package a2aclient
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"iter"
+	"strings"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2apb"
+	"github.com/a2aproject/a2a-go/internal/pbconv"
 )
 
 // WithGRPCTransport returns a Client factory configuration option that if applied will
-// enable support of gRPC-A2A communication.
+// enable support of gRPC-A2A communication. Wire lengths of gRPC messages are reported
+// to CallInterceptors as Response.Size.
 func WithGRPCTransport(opts ...grpc.DialOption) FactoryOption {
+	opts = append([]grpc.DialOption{grpc.WithStatsHandler(grpcPayloadSizeHandler{})}, opts...)
 	return WithTransport(
 		a2a.TransportProtocolGRPC,
 		TransportFactoryFn(func(ctx context.Context, url string, card *a2a.AgentCard) (Transport, error) {
@@ -34,7 +44,11 @@ func WithGRPCTransport(opts ...grpc.DialOption) FactoryOption {
 			if err != nil {
 				return nil, err
 			}
-			return NewGRPCTransport(conn), nil
+			return &grpcTransport{
+				client:      a2apb.NewA2AServiceClient(conn),
+				closeConnFn: conn.Close,
+				card:        card,
+			}, nil
 		}),
 	)
 }
@@ -47,58 +61,199 @@ func NewGRPCTransport(conn *grpc.ClientConn) Transport {
 	}
 }
 
-// grpcTransport implements Transport by delegating to a2apb.A2AServiceClient.
+// grpcTransport implements Transport by delegating to a2apb.A2AServiceClient. CallMeta is sent
+// as outgoing gRPC metadata and gRPC status codes are mapped to the corresponding protocol errors.
 type grpcTransport struct {
 	client      a2apb.A2AServiceClient
 	closeConnFn func() error
+	// card is the public AgentCard the transport was created for. It can be nil.
+	card *a2a.AgentCard
 }
 
 // A2A protocol methods
 
 func (c *grpcTransport) GetTask(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
-	return &a2a.Task{}, ErrNotImplemented
+	req := &a2apb.GetTaskRequest{Name: pbconv.TaskName(query.ID)}
+	if query.HistoryLength != nil {
+		req.HistoryLength = int32(*query.HistoryLength)
+	}
+	resp, err := c.client.GetTask(grpcContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return convertResponse(pbconv.FromProtoTask(resp))
 }
 
 func (c *grpcTransport) CancelTask(ctx context.Context, id a2a.TaskIDParams) (*a2a.Task, error) {
-	return &a2a.Task{}, ErrNotImplemented
+	resp, err := c.client.CancelTask(grpcContext(ctx), &a2apb.CancelTaskRequest{Name: pbconv.TaskName(id.ID)})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return convertResponse(pbconv.FromProtoTask(resp))
 }
 
 func (c *grpcTransport) SendMessage(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
-	return &a2a.Task{}, ErrNotImplemented
+	req, err := pbconv.ToProtoSendMessageRequest(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert request: %w", err)
+	}
+	resp, err := c.client.SendMessage(grpcContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return convertResponse(pbconv.FromProtoSendMessageResponse(resp))
 }
 
 func (c *grpcTransport) ResubscribeToTask(ctx context.Context, id a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
 	return func(yield func(a2a.Event, error) bool) {
-		yield(&a2a.Message{}, ErrNotImplemented)
+		stream, err := c.client.TaskSubscription(grpcContext(ctx), &a2apb.TaskSubscriptionRequest{Name: pbconv.TaskName(id.ID)})
+		if err != nil {
+			yield(nil, grpcError(err))
+			return
+		}
+		readGRPCStream(stream, yield)
 	}
 }
 
 func (c *grpcTransport) SendStreamingMessage(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
 	return func(yield func(a2a.Event, error) bool) {
-		yield(&a2a.Message{}, ErrNotImplemented)
+		req, err := pbconv.ToProtoSendMessageRequest(message)
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to convert request: %w", err))
+			return
+		}
+		stream, err := c.client.SendStreamingMessage(grpcContext(ctx), req)
+		if err != nil {
+			yield(nil, grpcError(err))
+			return
+		}
+		readGRPCStream(stream, yield)
 	}
 }
 
 func (c *grpcTransport) GetTaskPushConfig(ctx context.Context, params a2a.GetTaskPushConfigParams) (a2a.TaskPushConfig, error) {
-	return a2a.TaskPushConfig{}, ErrNotImplemented
+	req := &a2apb.GetTaskPushNotificationConfigRequest{Name: pbconv.PushConfigName(params.TaskID, params.ConfigID)}
+	resp, err := c.client.GetTaskPushNotificationConfig(grpcContext(ctx), req)
+	if err != nil {
+		return a2a.TaskPushConfig{}, grpcError(err)
+	}
+	return convertResponse(pbconv.FromProtoTaskPushConfig(resp))
 }
 
-func (c *grpcTransport) ListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) ([]a2a.TaskPushConfig, error) {
-	return []a2a.TaskPushConfig{}, ErrNotImplemented
+func (c *grpcTransport) ListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) (*a2a.ListTaskPushConfigResult, error) {
+	req := &a2apb.ListTaskPushNotificationConfigRequest{
+		Parent:    pbconv.PushConfigCollectionName(params.TaskID),
+		PageSize:  int32(params.PageSize),
+		PageToken: params.PageToken,
+	}
+	resp, err := c.client.ListTaskPushNotificationConfig(grpcContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return convertResponse(pbconv.FromProtoListTaskPushConfig(resp))
 }
 
 func (c *grpcTransport) SetTaskPushConfig(ctx context.Context, params a2a.TaskPushConfig) (a2a.TaskPushConfig, error) {
-	return a2a.TaskPushConfig{}, ErrNotImplemented
+	req := &a2apb.CreateTaskPushNotificationConfigRequest{
+		Parent:   pbconv.PushConfigCollectionName(params.TaskID),
+		ConfigId: params.Config.ID,
+		Config:   pbconv.ToProtoTaskPushConfig(params),
+	}
+	resp, err := c.client.CreateTaskPushNotificationConfig(grpcContext(ctx), req)
+	if err != nil {
+		return a2a.TaskPushConfig{}, grpcError(err)
+	}
+	return convertResponse(pbconv.FromProtoTaskPushConfig(resp))
 }
 
 func (c *grpcTransport) DeleteTaskPushConfig(ctx context.Context, params a2a.DeleteTaskPushConfigParams) error {
-	return ErrNotImplemented
+	req := &a2apb.DeleteTaskPushNotificationConfigRequest{Name: pbconv.PushConfigName(params.TaskID, params.ConfigID)}
+	if _, err := c.client.DeleteTaskPushNotificationConfig(grpcContext(ctx), req); err != nil {
+		return grpcError(err)
+	}
+	return nil
 }
 
 func (c *grpcTransport) GetAgentCard(ctx context.Context) (*a2a.AgentCard, error) {
-	return &a2a.AgentCard{}, ErrNotImplemented
+	if !supportsExtendedCard(c.card) {
+		return c.card, nil
+	}
+	resp, err := c.client.GetAgentCard(grpcContext(ctx), &a2apb.GetAgentCardRequest{})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return convertResponse(pbconv.FromProtoAgentCard(resp))
 }
 
 func (c *grpcTransport) Destroy() error {
 	return c.closeConnFn()
 }
+
+// readGRPCStream yields events received from the stream until it ends, fails or the consumer stops.
+func readGRPCStream(stream grpc.ServerStreamingClient[a2apb.StreamResponse], yield func(a2a.Event, error) bool) {
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			yield(nil, grpcError(err))
+			return
+		}
+		event, err := pbconv.FromProtoStreamResponse(resp)
+		if err != nil {
+			yield(nil, fmt.Errorf("%w: %v", a2a.ErrInvalidAgentResponse, err))
+			return
+		}
+		if !yield(event, nil) {
+			return
+		}
+	}
+}
+
+// grpcContext attaches CallMeta to the context as outgoing gRPC metadata.
+func grpcContext(ctx context.Context) context.Context {
+	meta, ok := CallMetaFrom(ctx)
+	if !ok || len(meta) == 0 {
+		return ctx
+	}
+	kv := make([]string, 0, 2*len(meta))
+	for k, v := range meta {
+		kv = append(kv, strings.ToLower(k), v)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// convertResponse wraps a failure to convert a proto response into ErrInvalidAgentResponse.
+func convertResponse[T any](result T, err error) (T, error) {
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("%w: %v", a2a.ErrInvalidAgentResponse, err)
+	}
+	return result, nil
+}
+
+// grpcError maps a gRPC status to the corresponding protocol error. UNAUTHENTICATED becomes AuthChallengeError.
+func grpcError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	var sentinel error
+	switch st.Code() {
+	case codes.Unauthenticated:
+		return &AuthChallengeError{Err: fmt.Errorf("unauthenticated: %s", st.Message())}
+	case codes.InvalidArgument:
+		sentinel = a2a.ErrInvalidRequest
+	case codes.NotFound:
+		sentinel = a2a.ErrTaskNotFound
+	case codes.FailedPrecondition:
+		sentinel = a2a.ErrTaskNotCancelable
+	case codes.Unimplemented:
+		sentinel = a2a.ErrUnsupportedOperation
+	}
+	if sentinel == nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s", sentinel, st.Message())
+}
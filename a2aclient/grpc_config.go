@@ -0,0 +1,201 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/a2aproject/a2a-go/a2apb"
+)
+
+// GRPCConfig exposes commonly needed gRPC client settings without requiring
+// knowledge of raw grpc.DialOption-s.
+type GRPCConfig struct {
+	// Keepalive configures client-side keepalive pings if not nil.
+	Keepalive *keepalive.ClientParameters
+	// MaxRecvMsgSize is the maximum message size in bytes the client can receive. Zero means gRPC default.
+	MaxRecvMsgSize int
+	// MaxSendMsgSize is the maximum message size in bytes the client can send. Zero means gRPC default.
+	MaxSendMsgSize int
+	// Retry configures a retry policy applied to all A2A service methods if not nil.
+	Retry *GRPCRetryPolicy
+	// PerRPCCredentials are attached to every call if not nil.
+	PerRPCCredentials credentials.PerRPCCredentials
+	// TransportCredentials are used for securing the connection if not nil.
+	TransportCredentials credentials.TransportCredentials
+	// WireLog receives a dump of every call made through the connection if not nil.
+	// Secrets are redacted. See WithGRPCWireLogging.
+	WireLog io.Writer
+}
+
+// GRPCRetryPolicy describes how failed calls are retried by the gRPC client.
+// See https://github.com/grpc/proposal/blob/master/A6-client-retries.md for semantics.
+type GRPCRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the original call. Must be greater than 1.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the upper bound for the delay between retries.
+	MaxBackoff time.Duration
+	// BackoffMultiplier is applied to the backoff after every attempt.
+	BackoffMultiplier float64
+	// RetryableStatusCodes is the set of status codes which trigger a retry. Defaults to UNAVAILABLE.
+	RetryableStatusCodes []codes.Code
+}
+
+// WithGRPCTransportConfig is the same as WithGRPCTransport, but allows to configure the
+// transport using GRPCConfig. Additional raw grpc.DialOption-s are applied after the ones
+// derived from the config.
+func WithGRPCTransportConfig(cfg GRPCConfig, opts ...grpc.DialOption) (FactoryOption, error) {
+	cfgOpts, err := cfg.dialOptions()
+	if err != nil {
+		return nil, err
+	}
+	return WithGRPCTransport(append(cfgOpts, opts...)...), nil
+}
+
+func (cfg GRPCConfig) dialOptions() ([]grpc.DialOption, error) {
+	var opts []grpc.DialOption
+	if cfg.Keepalive != nil {
+		opts = append(opts, grpc.WithKeepaliveParams(*cfg.Keepalive))
+	}
+
+	var callOpts []grpc.CallOption
+	if cfg.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(cfg.MaxRecvMsgSize))
+	}
+	if cfg.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(cfg.MaxSendMsgSize))
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+
+	if cfg.Retry != nil {
+		serviceConfig, err := cfg.Retry.serviceConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithDefaultServiceConfig(serviceConfig))
+	}
+	if cfg.PerRPCCredentials != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(cfg.PerRPCCredentials))
+	}
+	if cfg.TransportCredentials != nil {
+		opts = append(opts, grpc.WithTransportCredentials(cfg.TransportCredentials))
+	}
+	if cfg.WireLog != nil {
+		opts = append(opts, WithGRPCWireLogging(cfg.WireLog))
+	}
+	return opts, nil
+}
+
+func (p *GRPCRetryPolicy) serviceConfig() (string, error) {
+	if p.MaxAttempts < 2 {
+		return "", fmt.Errorf("retry policy MaxAttempts must be greater than 1, got %d", p.MaxAttempts)
+	}
+	if p.InitialBackoff <= 0 || p.MaxBackoff <= 0 {
+		return "", fmt.Errorf("retry policy backoff durations must be positive")
+	}
+
+	multiplier := p.BackoffMultiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+
+	statusCodes := p.RetryableStatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = []codes.Code{codes.Unavailable}
+	}
+	codeNames := make([]string, len(statusCodes))
+	for i, c := range statusCodes {
+		name, ok := grpcStatusCodeNames[c]
+		if !ok {
+			return "", fmt.Errorf("retry policy has an unknown status code %d", c)
+		}
+		codeNames[i] = name
+	}
+
+	type retryPolicy struct {
+		MaxAttempts          int      `json:"maxAttempts"`
+		InitialBackoff       string   `json:"initialBackoff"`
+		MaxBackoff           string   `json:"maxBackoff"`
+		BackoffMultiplier    float64  `json:"backoffMultiplier"`
+		RetryableStatusCodes []string `json:"retryableStatusCodes"`
+	}
+	type methodName struct {
+		Service string `json:"service"`
+	}
+	type methodConfig struct {
+		Name        []methodName `json:"name"`
+		RetryPolicy retryPolicy  `json:"retryPolicy"`
+	}
+	type serviceConfig struct {
+		MethodConfig []methodConfig `json:"methodConfig"`
+	}
+
+	sc := serviceConfig{MethodConfig: []methodConfig{{
+		Name: []methodName{{Service: a2apb.A2AService_ServiceDesc.ServiceName}},
+		RetryPolicy: retryPolicy{
+			MaxAttempts:          p.MaxAttempts,
+			InitialBackoff:       serviceConfigDuration(p.InitialBackoff),
+			MaxBackoff:           serviceConfigDuration(p.MaxBackoff),
+			BackoffMultiplier:    multiplier,
+			RetryableStatusCodes: codeNames,
+		},
+	}}}
+	bytes, err := json.Marshal(sc)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// grpcStatusCodeNames are the representations of codes expected in a service config. They are the
+// canonical names of the codes, which don't always match codes.Code.String, eg. "CANCELLED".
+var grpcStatusCodeNames = map[codes.Code]string{
+	codes.OK:                 "OK",
+	codes.Canceled:           "CANCELLED",
+	codes.Unknown:            "UNKNOWN",
+	codes.InvalidArgument:    "INVALID_ARGUMENT",
+	codes.DeadlineExceeded:   "DEADLINE_EXCEEDED",
+	codes.NotFound:           "NOT_FOUND",
+	codes.AlreadyExists:      "ALREADY_EXISTS",
+	codes.PermissionDenied:   "PERMISSION_DENIED",
+	codes.ResourceExhausted:  "RESOURCE_EXHAUSTED",
+	codes.FailedPrecondition: "FAILED_PRECONDITION",
+	codes.Aborted:            "ABORTED",
+	codes.OutOfRange:         "OUT_OF_RANGE",
+	codes.Unimplemented:      "UNIMPLEMENTED",
+	codes.Internal:           "INTERNAL",
+	codes.Unavailable:        "UNAVAILABLE",
+	codes.DataLoss:           "DATA_LOSS",
+	codes.Unauthenticated:    "UNAUTHENTICATED",
+}
+
+// serviceConfigDuration formats d the way a service config expects it, eg. "0.00001s". Exponent notation
+// is not accepted.
+func serviceConfigDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestGRPCConfig_DialOptions(t *testing.T) {
+	cfg := GRPCConfig{
+		Keepalive:      &keepalive.ClientParameters{Time: time.Minute},
+		MaxRecvMsgSize: 1024,
+		MaxSendMsgSize: 1024,
+		Retry: &GRPCRetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     time.Second,
+		},
+		TransportCredentials: insecure.NewCredentials(),
+	}
+	opts, err := cfg.dialOptions()
+	if err != nil {
+		t.Fatalf("dialOptions() error = %v", err)
+	}
+	if len(opts) != 4 {
+		t.Fatalf("dialOptions() returned %d options, want 4", len(opts))
+	}
+
+	conn, err := grpc.NewClient("localhost:0", opts...)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() rejected config options: %v", err)
+	}
+	_ = conn.Close()
+}
+
+func TestGRPCRetryPolicy_ServiceConfig(t *testing.T) {
+	policy := &GRPCRetryPolicy{
+		MaxAttempts:          4,
+		InitialBackoff:       500 * time.Millisecond,
+		MaxBackoff:           2 * time.Second,
+		BackoffMultiplier:    2,
+		RetryableStatusCodes: []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.OK},
+	}
+	sc, err := policy.serviceConfig()
+	if err != nil {
+		t.Fatalf("serviceConfig() error = %v", err)
+	}
+
+	var decoded struct {
+		MethodConfig []struct {
+			Name        []struct{ Service string }
+			RetryPolicy struct {
+				MaxAttempts          int
+				InitialBackoff       string
+				MaxBackoff           string
+				RetryableStatusCodes []string
+			}
+		}
+	}
+	if err := json.Unmarshal([]byte(sc), &decoded); err != nil {
+		t.Fatalf("failed to decode service config: %v", err)
+	}
+	mc := decoded.MethodConfig[0]
+	if mc.Name[0].Service != "a2a.v1.A2AService" {
+		t.Errorf("service = %q, want a2a.v1.A2AService", mc.Name[0].Service)
+	}
+	if mc.RetryPolicy.InitialBackoff != "0.5s" || mc.RetryPolicy.MaxBackoff != "2s" {
+		t.Errorf("backoff = (%s, %s), want (0.5s, 2s)", mc.RetryPolicy.InitialBackoff, mc.RetryPolicy.MaxBackoff)
+	}
+	wantCodes := []string{"UNAVAILABLE", "DEADLINE_EXCEEDED", "OK"}
+	for i, c := range wantCodes {
+		if mc.RetryPolicy.RetryableStatusCodes[i] != c {
+			t.Errorf("status codes = %v, want %v", mc.RetryPolicy.RetryableStatusCodes, wantCodes)
+		}
+	}
+}
+
+func TestGRPCRetryPolicy_ServiceConfigFormat(t *testing.T) {
+	policy := &GRPCRetryPolicy{
+		MaxAttempts:          2,
+		InitialBackoff:       10 * time.Microsecond,
+		MaxBackoff:           1500 * time.Millisecond,
+		RetryableStatusCodes: []codes.Code{codes.Canceled, codes.ResourceExhausted},
+	}
+	sc, err := policy.serviceConfig()
+	if err != nil {
+		t.Fatalf("serviceConfig() error = %v", err)
+	}
+	for _, want := range []string{`"0.00001s"`, `"1.5s"`, `"CANCELLED"`, `"RESOURCE_EXHAUSTED"`} {
+		if !strings.Contains(sc, want) {
+			t.Errorf("serviceConfig() = %s, want it to contain %s", sc, want)
+		}
+	}
+
+	conn, err := grpc.NewClient("localhost:0",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultServiceConfig(sc),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() rejected service config: %v", err)
+	}
+	_ = conn.Close()
+}
+
+func TestGRPCRetryPolicy_UnknownStatusCode(t *testing.T) {
+	policy := &GRPCRetryPolicy{
+		MaxAttempts:          2,
+		InitialBackoff:       time.Second,
+		MaxBackoff:           time.Second,
+		RetryableStatusCodes: []codes.Code{codes.Code(42)},
+	}
+	if _, err := policy.serviceConfig(); err == nil {
+		t.Fatal("serviceConfig() error = nil, want error for unknown status code")
+	}
+}
+
+func TestGRPCRetryPolicy_Invalid(t *testing.T) {
+	policies := []*GRPCRetryPolicy{
+		{MaxAttempts: 1, InitialBackoff: time.Second, MaxBackoff: time.Second},
+		{MaxAttempts: 2, MaxBackoff: time.Second},
+		{MaxAttempts: 2, InitialBackoff: time.Second},
+	}
+	for _, p := range policies {
+		if _, err := WithGRPCTransportConfig(GRPCConfig{Retry: p}); err == nil {
+			t.Errorf("WithGRPCTransportConfig() expected error for policy %+v", p)
+		}
+	}
+}
+
+func TestWithGRPCTransportConfig(t *testing.T) {
+	opt, err := WithGRPCTransportConfig(GRPCConfig{TransportCredentials: insecure.NewCredentials()})
+	if err != nil {
+		t.Fatalf("WithGRPCTransportConfig() error = %v", err)
+	}
+	factory := NewFactory(WithDefaultsDisabled(), opt)
+	transportFactory := factory.transports[a2a.TransportProtocolGRPC]
+	if transportFactory == nil {
+		t.Fatal("gRPC transport factory not registered")
+	}
+	transport, err := transportFactory.Create(t.Context(), "localhost:0", nil)
+	if err != nil {
+		t.Fatalf("failed to create transport: %v", err)
+	}
+	_ = transport.Destroy()
+}
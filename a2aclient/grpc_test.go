@@ -16,14 +16,19 @@ package a2aclient
 
 import (
 	"context"
+	"errors"
 	"net"
 	"testing"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
 	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2apb"
 )
 
 func newTestGRPCServer(t *testing.T) (*grpc.Server, *bufconn.Listener) {
@@ -89,63 +94,201 @@ func TestGRPCTransport_Destroy(t *testing.T) {
 	}
 }
 
-func TestGRPCTransport_NotImplemented(t *testing.T) {
-	transport := &grpcTransport{}
-	ctx := context.Background()
+type fakeA2AServer struct {
+	a2apb.UnimplementedA2AServiceServer
+	gotMeta          metadata.MD
+	gotHistoryLength int32
+}
 
-	_, err := transport.GetTask(ctx, a2a.TaskQueryParams{})
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+func (s *fakeA2AServer) GetTask(ctx context.Context, req *a2apb.GetTaskRequest) (*a2apb.Task, error) {
+	s.gotMeta, _ = metadata.FromIncomingContext(ctx)
+	s.gotHistoryLength = req.HistoryLength
+	if req.Name != "tasks/task-1" {
+		return nil, status.Error(codes.NotFound, "no such task")
 	}
+	return &a2apb.Task{
+		Id:        "task-1",
+		ContextId: "ctx-1",
+		Status:    &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_WORKING},
+	}, nil
+}
+
+func (s *fakeA2AServer) CancelTask(ctx context.Context, req *a2apb.CancelTaskRequest) (*a2apb.Task, error) {
+	return nil, status.Error(codes.FailedPrecondition, "task is completed")
+}
 
-	_, err = transport.CancelTask(ctx, a2a.TaskIDParams{})
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+func (s *fakeA2AServer) SendMessage(ctx context.Context, req *a2apb.SendMessageRequest) (*a2apb.SendMessageResponse, error) {
+	reply := &a2apb.Message{MessageId: "reply", Role: a2apb.Role_ROLE_AGENT, Content: req.Request.Content}
+	return &a2apb.SendMessageResponse{Payload: &a2apb.SendMessageResponse_Msg{Msg: reply}}, nil
+}
+
+func (s *fakeA2AServer) SendStreamingMessage(req *a2apb.SendMessageRequest, stream grpc.ServerStreamingServer[a2apb.StreamResponse]) error {
+	task := &a2apb.Task{Id: "task-1", ContextId: "ctx-1", Status: &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_SUBMITTED}}
+	update := &a2apb.TaskStatusUpdateEvent{
+		TaskId:    "task-1",
+		ContextId: "ctx-1",
+		Status:    &a2apb.TaskStatus{State: a2apb.TaskState_TASK_STATE_COMPLETED},
+		Final:     true,
+	}
+	for _, resp := range []*a2apb.StreamResponse{
+		{Payload: &a2apb.StreamResponse_Task{Task: task}},
+		{Payload: &a2apb.StreamResponse_StatusUpdate{StatusUpdate: update}},
+	} {
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+func (s *fakeA2AServer) TaskSubscription(req *a2apb.TaskSubscriptionRequest, stream grpc.ServerStreamingServer[a2apb.StreamResponse]) error {
+	return status.Error(codes.NotFound, "no such task")
+}
 
-	_, err = transport.SendMessage(ctx, a2a.MessageSendParams{})
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+func (s *fakeA2AServer) CreateTaskPushNotificationConfig(ctx context.Context, req *a2apb.CreateTaskPushNotificationConfigRequest) (*a2apb.TaskPushNotificationConfig, error) {
+	if req.Parent != "tasks/task-1/pushNotificationConfigs" || req.ConfigId != "cfg-1" {
+		return nil, status.Errorf(codes.InvalidArgument, "unexpected request %v", req)
 	}
+	return req.Config, nil
+}
 
-	resubscribeSeq := transport.ResubscribeToTask(ctx, a2a.TaskIDParams{})
-	resubscribeSeq(func(e a2a.Event, err error) bool {
-		if err != ErrNotImplemented {
-			t.Errorf("expected ErrNotImplemented, got %v", err)
-		}
-		return false
-	})
+func (s *fakeA2AServer) ListTaskPushNotificationConfig(ctx context.Context, req *a2apb.ListTaskPushNotificationConfigRequest) (*a2apb.ListTaskPushNotificationConfigResponse, error) {
+	config := &a2apb.TaskPushNotificationConfig{
+		Name:                   "tasks/task-1/pushNotificationConfigs/cfg-1",
+		PushNotificationConfig: &a2apb.PushNotificationConfig{Url: "https://example.com/push"},
+	}
+	return &a2apb.ListTaskPushNotificationConfigResponse{Configs: []*a2apb.TaskPushNotificationConfig{config}, NextPageToken: req.PageToken + "-next"}, nil
+}
 
-	sendStreamingSeq := transport.SendStreamingMessage(ctx, a2a.MessageSendParams{})
-	sendStreamingSeq(func(e a2a.Event, err error) bool {
-		if err != ErrNotImplemented {
-			t.Errorf("expected ErrNotImplemented, got %v", err)
-		}
-		return false
-	})
+func (s *fakeA2AServer) GetAgentCard(ctx context.Context, req *a2apb.GetAgentCardRequest) (*a2apb.AgentCard, error) {
+	return &a2apb.AgentCard{Name: "agent", Capabilities: &a2apb.AgentCapabilities{Streaming: true}}, nil
+}
+
+func newFakeGRPCTransport(t *testing.T, srv a2apb.A2AServiceServer) Transport {
+	t.Helper()
+	s, lis := newTestGRPCServer(t)
+	a2apb.RegisterA2AServiceServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	transport := NewGRPCTransport(conn)
+	t.Cleanup(func() { _ = transport.Destroy() })
+	return transport
+}
+
+func TestGRPCTransport_UnaryMethods(t *testing.T) {
+	srv := &fakeA2AServer{}
+	transport := newFakeGRPCTransport(t, srv)
+	ctx := transportContext(t.Context(), CallMeta{"X-Custom": "value"})
+
+	historyLength := 2
+	task, err := transport.GetTask(ctx, a2a.TaskQueryParams{ID: "task-1", HistoryLength: &historyLength})
+	if err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if got := srv.gotMeta.Get("x-custom"); len(got) != 1 || got[0] != "value" {
+		t.Errorf("server got x-custom = %v, want [value]", got)
+	}
+	if srv.gotHistoryLength != 2 {
+		t.Errorf("server got history length = %d, want 2", srv.gotHistoryLength)
+	}
+	if task.ID != "task-1" || task.ContextID != "ctx-1" || task.Status.State != a2a.TaskStateWorking {
+		t.Errorf("GetTask() = %+v", task)
+	}
+
+	if _, err := transport.GetTask(ctx, a2a.TaskQueryParams{ID: "missing"}); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Errorf("GetTask() error = %v, want ErrTaskNotFound", err)
+	}
+	if _, err := transport.CancelTask(ctx, a2a.TaskIDParams{ID: "task-1"}); !errors.Is(err, a2a.ErrTaskNotCancelable) {
+		t.Errorf("CancelTask() error = %v, want ErrTaskNotCancelable", err)
+	}
+
+	result, err := transport.SendMessage(ctx, a2a.MessageSendParams{Message: *a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hi"})})
+	if err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	msg, ok := result.(*a2a.Message)
+	if !ok || msg.Role != a2a.MessageRoleAgent || len(msg.Parts) != 1 || msg.Parts[0].(a2a.TextPart).Text != "hi" {
+		t.Errorf("SendMessage() = %+v", result)
+	}
+
+	config := a2a.TaskPushConfig{TaskID: "task-1", Config: a2a.PushConfig{ID: "cfg-1", URL: "https://example.com/push"}}
+	saved, err := transport.SetTaskPushConfig(ctx, config)
+	if err != nil {
+		t.Fatalf("SetTaskPushConfig() error = %v", err)
+	}
+	if saved.TaskID != config.TaskID || saved.Config.ID != config.Config.ID || saved.Config.URL != config.Config.URL {
+		t.Errorf("SetTaskPushConfig() = %+v, want %+v", saved, config)
+	}
+
+	list, err := transport.ListTaskPushConfig(ctx, a2a.ListTaskPushConfigParams{TaskID: "task-1", PageToken: "p"})
+	if err != nil {
+		t.Fatalf("ListTaskPushConfig() error = %v", err)
+	}
+	if len(list.Configs) != 1 || list.Configs[0].TaskID != "task-1" || list.Configs[0].Config.ID != "cfg-1" || list.NextPageToken != "p-next" {
+		t.Errorf("ListTaskPushConfig() = %+v", list)
+	}
 
-	_, err = transport.GetTaskPushConfig(ctx, a2a.GetTaskPushConfigParams{})
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+	err = transport.DeleteTaskPushConfig(ctx, a2a.DeleteTaskPushConfigParams{TaskID: "task-1", ConfigID: "cfg-1"})
+	if !errors.Is(err, a2a.ErrUnsupportedOperation) {
+		t.Errorf("DeleteTaskPushConfig() error = %v, want ErrUnsupportedOperation", err)
 	}
 
-	_, err = transport.ListTaskPushConfig(ctx, a2a.ListTaskPushConfigParams{})
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+	card, err := transport.GetAgentCard(ctx)
+	if err != nil {
+		t.Fatalf("GetAgentCard() error = %v", err)
 	}
+	if card.Name != "agent" || !card.Capabilities.Streaming {
+		t.Errorf("GetAgentCard() = %+v", card)
+	}
+}
 
-	_, err = transport.SetTaskPushConfig(ctx, a2a.TaskPushConfig{})
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+func TestGRPCTransport_Streaming(t *testing.T) {
+	transport := newFakeGRPCTransport(t, &fakeA2AServer{})
+	ctx := t.Context()
+
+	var states []a2a.TaskState
+	for event, err := range transport.SendStreamingMessage(ctx, a2a.MessageSendParams{Message: *a2a.NewMessage(a2a.MessageRoleUser)}) {
+		if err != nil {
+			t.Fatalf("SendStreamingMessage() error = %v", err)
+		}
+		switch v := event.(type) {
+		case *a2a.Task:
+			states = append(states, v.Status.State)
+		case *a2a.TaskStatusUpdateEvent:
+			states = append(states, v.Status.State)
+		default:
+			t.Fatalf("unexpected event %T", event)
+		}
+	}
+	want := []a2a.TaskState{a2a.TaskStateSubmitted, a2a.TaskStateCompleted}
+	if len(states) != len(want) || states[0] != want[0] || states[1] != want[1] {
+		t.Errorf("SendStreamingMessage() states = %v, want %v", states, want)
 	}
 
-	err = transport.DeleteTaskPushConfig(ctx, a2a.DeleteTaskPushConfigParams{})
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+	for _, err := range transport.ResubscribeToTask(ctx, a2a.TaskIDParams{ID: "task-1"}) {
+		if !errors.Is(err, a2a.ErrTaskNotFound) {
+			t.Errorf("ResubscribeToTask() error = %v, want ErrTaskNotFound", err)
+		}
 	}
+}
 
-	_, err = transport.GetAgentCard(ctx)
-	if err != ErrNotImplemented {
-		t.Errorf("expected ErrNotImplemented, got %v", err)
+func TestGRPCTransport_GetAgentCardWithoutExtendedCard(t *testing.T) {
+	card := &a2a.AgentCard{Name: "public"}
+	transport := &grpcTransport{card: card}
+
+	got, err := transport.GetAgentCard(t.Context())
+	if err != nil {
+		t.Fatalf("GetAgentCard() error = %v", err)
+	}
+	if got != card {
+		t.Errorf("GetAgentCard() = %+v, want the public card without calling the agent", got)
 	}
 }
@@ -0,0 +1,83 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/internal/wirelog"
+)
+
+// WithGRPCWireLogging returns a grpc.DialOption which dumps headers, messages and trailers of every
+// call made through the connection to w. Messages are written in protojson format. Secret metadata
+// entries like authorization and secret message fields like tokens are redacted.
+func WithGRPCWireLogging(w io.Writer) grpc.DialOption {
+	return grpc.WithStatsHandler(&grpcWireLogHandler{log: wirelog.New(w)})
+}
+
+type grpcWireLogHandler struct {
+	log *wirelog.Logger
+}
+
+type grpcMethodKey struct{}
+
+func (h *grpcWireLogHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, grpcMethodKey{}, info.FullMethodName)
+}
+
+func (h *grpcWireLogHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	method, _ := ctx.Value(grpcMethodKey{}).(string)
+	switch v := s.(type) {
+	case *stats.OutHeader:
+		h.log.Frame(wirelog.Out, "headers "+method, v.Header, nil)
+	case *stats.OutPayload:
+		h.log.Frame(wirelog.Out, "message "+method, nil, marshalPayload(v.Payload))
+	case *stats.InHeader:
+		h.log.Frame(wirelog.In, "headers "+method, v.Header, nil)
+	case *stats.InPayload:
+		h.log.Frame(wirelog.In, "message "+method, nil, marshalPayload(v.Payload))
+	case *stats.InTrailer:
+		h.log.Frame(wirelog.In, "trailers "+method, v.Trailer, nil)
+	case *stats.End:
+		if v.Error != nil {
+			h.log.Frame(wirelog.In, fmt.Sprintf("end %s: %v", method, v.Error), nil, nil)
+		}
+	}
+}
+
+func (h *grpcWireLogHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *grpcWireLogHandler) HandleConn(ctx context.Context, s stats.ConnStats) {}
+
+func marshalPayload(payload any) []byte {
+	msg, ok := payload.(proto.Message)
+	if !ok {
+		return []byte(fmt.Sprintf("%v", payload))
+	}
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return []byte(fmt.Sprintf("failed to marshal %T: %v", payload, err))
+	}
+	return data
+}
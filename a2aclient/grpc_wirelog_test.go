@@ -0,0 +1,78 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/a2aproject/a2a-go/a2apb"
+)
+
+type getTaskServer struct {
+	a2apb.UnimplementedA2AServiceServer
+}
+
+func (getTaskServer) GetTask(ctx context.Context, req *a2apb.GetTaskRequest) (*a2apb.Task, error) {
+	return &a2apb.Task{Id: strings.TrimPrefix(req.Name, "tasks/"), ContextId: "ctx"}, nil
+}
+
+func TestWithGRPCWireLogging(t *testing.T) {
+	s, lis := newTestGRPCServer(t)
+	a2apb.RegisterA2AServiceServer(s, getTaskServer{})
+	go func() { _ = s.Serve(lis) }()
+	defer s.Stop()
+
+	var log bytes.Buffer
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		WithGRPCWireLogging(&log),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx := metadata.AppendToOutgoingContext(t.Context(), "authorization", "Bearer secret-token")
+	if _, err := a2apb.NewA2AServiceClient(conn).GetTask(ctx, &a2apb.GetTaskRequest{Name: "tasks/123"}); err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+
+	got := log.String()
+	for _, want := range []string{
+		"--> headers /a2a.v1.A2AService/GetTask",
+		"authorization: [REDACTED]",
+		`--> message /a2a.v1.A2AService/GetTask`,
+		`"name":"tasks/123"`,
+		"<-- message /a2a.v1.A2AService/GetTask",
+		`"id":"123"`,
+		"<-- trailers",
+	} {
+		if !strings.Contains(strings.ReplaceAll(got, `": "`, `":"`), want) {
+			t.Errorf("wire log doesn't contain %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "secret-token") {
+		t.Errorf("wire log contains a secret:\n%s", got)
+	}
+}
@@ -0,0 +1,399 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2ahttp"
+	"github.com/a2aproject/a2a-go/a2apb"
+	"github.com/a2aproject/a2a-go/internal/pbconv"
+)
+
+// WithHTTPJSONTransport returns a Client factory configuration option that if applied will
+// enable support of HTTP+JSON (REST) A2A communication. http.DefaultClient is used if client is nil.
+func WithHTTPJSONTransport(client *http.Client) FactoryOption {
+	return WithTransport(a2a.TransportProtocolHTTPJSON, newHTTPJSONTransportFactory(func() (*http.Client, error) {
+		return client, nil
+	}))
+}
+
+// newHTTPJSONTransportFactory creates a TransportFactory for HTTP+JSON transports using the client
+// returned by newClient. Errors of newClient are reported as transport creation failures.
+func newHTTPJSONTransportFactory(newClient func() (*http.Client, error)) TransportFactory {
+	return &httpJSONTransportFactory{newClient: newClient}
+}
+
+// httpJSONTransportFactory is the TransportFactory registered by WithHTTPJSONTransport.
+type httpJSONTransportFactory struct {
+	newClient func() (*http.Client, error)
+	// unproxied is the factory a proxy was applied to by withProxy.
+	unproxied *httpJSONTransportFactory
+}
+
+func (f *httpJSONTransportFactory) Create(ctx context.Context, url string, card *a2a.AgentCard) (Transport, error) {
+	client, err := f.newClient()
+	if err != nil {
+		return nil, err
+	}
+	transport := NewHTTPJSONTransport(url, client).(*httpJSONTransport)
+	transport.card = card
+	return transport, nil
+}
+
+// withProxy returns a factory which uses a copy of the client with the proxy set on a clone of its
+// http.Transport. Other settings of the client and its transport are preserved. An error is reported when
+// a transport is created if the client doesn't use an *http.Transport.
+func (f *httpJSONTransportFactory) withProxy(proxy a2ahttp.ProxyConfig) *httpJSONTransportFactory {
+	base := f
+	if f.unproxied != nil {
+		base = f.unproxied
+	}
+	newClient := sync.OnceValues(func() (*http.Client, error) {
+		client, err := base.newClient()
+		if err != nil {
+			return nil, err
+		}
+		if client == nil {
+			client = http.DefaultClient
+		}
+		roundTripper := client.Transport
+		if roundTripper == nil {
+			roundTripper = http.DefaultTransport
+		}
+		transport, ok := roundTripper.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("proxy can't be set on %T, an *http.Transport is required", roundTripper)
+		}
+		proxyFunc, err := proxy.ProxyFunc()
+		if err != nil {
+			return nil, err
+		}
+		transport = transport.Clone()
+		transport.Proxy = proxyFunc
+		proxied := *client
+		proxied.Transport = transport
+		return &proxied, nil
+	})
+	return &httpJSONTransportFactory{newClient: newClient, unproxied: base}
+}
+
+// NewHTTPJSONTransport creates a Transport which maps protocol methods to the REST binding
+// served at baseURL. Payloads are the proto JSON encoding of the a2apb messages the binding
+// is defined with. http.DefaultClient is used if client is nil.
+func NewHTTPJSONTransport(baseURL string, client *http.Client) Transport {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &httpJSONTransport{baseURL: strings.TrimSuffix(baseURL, "/"), client: client}
+}
+
+// httpJSONTransport implements Transport using the HTTP+JSON binding. CallMeta is sent as HTTP headers
+// and streaming methods consume server-sent events.
+type httpJSONTransport struct {
+	baseURL string
+	client  *http.Client
+	// card is the public AgentCard the transport was created for. It can be nil.
+	card *a2a.AgentCard
+}
+
+func (t *httpJSONTransport) GetTask(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+	values := url.Values{}
+	if query.HistoryLength != nil {
+		values.Set("historyLength", strconv.Itoa(*query.HistoryLength))
+	}
+	var task a2apb.Task
+	if err := t.do(ctx, http.MethodGet, taskPath(query.ID, ""), values, nil, &task); err != nil {
+		return nil, err
+	}
+	return convertResponse(pbconv.FromProtoTask(&task))
+}
+
+func (t *httpJSONTransport) CancelTask(ctx context.Context, id a2a.TaskIDParams) (*a2a.Task, error) {
+	var task a2apb.Task
+	req := &a2apb.CancelTaskRequest{Name: pbconv.TaskName(id.ID)}
+	if err := t.do(ctx, http.MethodPost, taskPath(id.ID, ":cancel"), nil, req, &task); err != nil {
+		return nil, err
+	}
+	return convertResponse(pbconv.FromProtoTask(&task))
+}
+
+func (t *httpJSONTransport) SendMessage(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	req, err := pbconv.ToProtoSendMessageRequest(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert request: %w", err)
+	}
+	var resp a2apb.SendMessageResponse
+	if err := t.do(ctx, http.MethodPost, "/v1/message:send", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return convertResponse(pbconv.FromProtoSendMessageResponse(&resp))
+}
+
+func (t *httpJSONTransport) ResubscribeToTask(ctx context.Context, id a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+	return t.stream(ctx, http.MethodGet, taskPath(id.ID, ":subscribe"), nil)
+}
+
+func (t *httpJSONTransport) SendStreamingMessage(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	req, err := pbconv.ToProtoSendMessageRequest(message)
+	if err != nil {
+		return func(yield func(a2a.Event, error) bool) {
+			yield(nil, fmt.Errorf("failed to convert request: %w", err))
+		}
+	}
+	return t.stream(ctx, http.MethodPost, "/v1/message:stream", req)
+}
+
+func (t *httpJSONTransport) GetTaskPushConfig(ctx context.Context, params a2a.GetTaskPushConfigParams) (a2a.TaskPushConfig, error) {
+	var config a2apb.TaskPushNotificationConfig
+	path := taskPath(params.TaskID, "/pushNotificationConfigs/"+url.PathEscape(params.ConfigID))
+	if err := t.do(ctx, http.MethodGet, path, nil, nil, &config); err != nil {
+		return a2a.TaskPushConfig{}, err
+	}
+	return convertResponse(pbconv.FromProtoTaskPushConfig(&config))
+}
+
+func (t *httpJSONTransport) ListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) (*a2a.ListTaskPushConfigResult, error) {
+	values := url.Values{}
+	if params.PageSize > 0 {
+		values.Set("pageSize", strconv.Itoa(params.PageSize))
+	}
+	if params.PageToken != "" {
+		values.Set("pageToken", params.PageToken)
+	}
+	var resp a2apb.ListTaskPushNotificationConfigResponse
+	if err := t.do(ctx, http.MethodGet, taskPath(params.TaskID, "/pushNotificationConfigs"), values, nil, &resp); err != nil {
+		return nil, err
+	}
+	return convertResponse(pbconv.FromProtoListTaskPushConfig(&resp))
+}
+
+func (t *httpJSONTransport) SetTaskPushConfig(ctx context.Context, params a2a.TaskPushConfig) (a2a.TaskPushConfig, error) {
+	// the config is the request body, the remaining fields of the request are sent as query parameters
+	values := url.Values{}
+	if params.Config.ID != "" {
+		values.Set("configId", params.Config.ID)
+	}
+	var config a2apb.TaskPushNotificationConfig
+	path := taskPath(params.TaskID, "/pushNotificationConfigs")
+	if err := t.do(ctx, http.MethodPost, path, values, pbconv.ToProtoTaskPushConfig(params), &config); err != nil {
+		return a2a.TaskPushConfig{}, err
+	}
+	return convertResponse(pbconv.FromProtoTaskPushConfig(&config))
+}
+
+func (t *httpJSONTransport) DeleteTaskPushConfig(ctx context.Context, params a2a.DeleteTaskPushConfigParams) error {
+	path := taskPath(params.TaskID, "/pushNotificationConfigs/"+url.PathEscape(params.ConfigID))
+	return t.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+func (t *httpJSONTransport) GetAgentCard(ctx context.Context) (*a2a.AgentCard, error) {
+	if !supportsExtendedCard(t.card) {
+		return t.card, nil
+	}
+	var card a2apb.AgentCard
+	if err := t.do(ctx, http.MethodGet, "/v1/card", nil, nil, &card); err != nil {
+		return nil, err
+	}
+	return convertResponse(pbconv.FromProtoAgentCard(&card))
+}
+
+func (t *httpJSONTransport) Destroy() error {
+	return nil
+}
+
+// unmarshalOptions ignore fields added to the proto by newer protocol versions.
+var unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+func taskPath(id a2a.TaskID, suffix string) string {
+	return "/v1/tasks/" + url.PathEscape(string(id)) + suffix
+}
+
+// do sends a request with the proto JSON encoded body and decodes the response into result unless it's nil.
+func (t *httpJSONTransport) do(ctx context.Context, method, path string, query url.Values, body, result proto.Message) error {
+	resp, err := t.send(ctx, method, path, query, body, "application/json")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	ReportPayloadSize(ctx, 0, int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if result == nil || len(data) == 0 {
+		return nil
+	}
+	if err := unmarshalOptions.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("%w: %v", a2a.ErrInvalidAgentResponse, err)
+	}
+	return nil
+}
+
+// stream sends a request with the proto JSON encoded body and yields events received as server-sent events.
+// Every event carries a StreamResponse.
+func (t *httpJSONTransport) stream(ctx context.Context, method, path string, body proto.Message) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		resp, err := t.send(ctx, method, path, nil, body, "text/event-stream")
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		for data, err := range readSSE(resp.Body) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			ReportPayloadSize(ctx, 0, int64(len(data)))
+			var resp a2apb.StreamResponse
+			if err := unmarshalOptions.Unmarshal(data, &resp); err != nil {
+				yield(nil, fmt.Errorf("%w: %v", a2a.ErrInvalidAgentResponse, err))
+				return
+			}
+			event, err := pbconv.FromProtoStreamResponse(&resp)
+			if err != nil {
+				yield(nil, fmt.Errorf("%w: %v", a2a.ErrInvalidAgentResponse, err))
+				return
+			}
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+// send sends a request with CallMeta as headers and checks the response status.
+func (t *httpJSONTransport) send(ctx context.Context, method, path string, query url.Values, body proto.Message, accept string) (*http.Response, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = protojson.Marshal(body); err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+	target := t.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", accept)
+	if meta, ok := CallMetaFrom(ctx); ok {
+		for k, v := range meta {
+			req.Header.Set(k, v)
+		}
+	}
+	ReportPayloadSize(ctx, int64(len(payload)), 0)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer func() { _ = resp.Body.Close() }()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, statusError(resp, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+// statusError maps an HTTP error status to the corresponding protocol error. 401 becomes AuthChallengeError.
+func statusError(resp *http.Response, msg string) error {
+	var sentinel error
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return &AuthChallengeError{
+			Challenge: resp.Header.Get("WWW-Authenticate"),
+			Err:       fmt.Errorf("unexpected status %s: %s", resp.Status, msg),
+		}
+	case http.StatusBadRequest:
+		sentinel = a2a.ErrInvalidRequest
+	case http.StatusNotFound:
+		sentinel = a2a.ErrTaskNotFound
+	case http.StatusConflict:
+		sentinel = a2a.ErrTaskNotCancelable
+	case http.StatusUnsupportedMediaType:
+		sentinel = a2a.ErrUnsupportedContentType
+	case http.StatusNotImplemented, http.StatusMethodNotAllowed:
+		sentinel = a2a.ErrUnsupportedOperation
+	}
+	if sentinel == nil {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, msg)
+	}
+	return fmt.Errorf("%w: %s", sentinel, msg)
+}
+
+// errStreamTruncated is returned when the event stream ends in the middle of an event.
+var errStreamTruncated = errors.New("event stream ended in the middle of an event")
+
+// readSSE yields data of every server-sent event read from r. Data of multi-line events is joined
+// with newlines. Comments and other fields are ignored.
+func readSSE(r io.Reader) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+		var data []byte
+		pending := false
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line == "" {
+				if pending && !yield(data, nil) {
+					return
+				}
+				data, pending = nil, false
+				continue
+			}
+			value, ok := strings.CutPrefix(line, "data:")
+			if !ok {
+				continue
+			}
+			if pending {
+				data = append(data, '\n')
+			}
+			data = append(data, strings.TrimPrefix(value, " ")...)
+			pending = true
+		}
+		if err := scanner.Err(); err != nil {
+			yield(nil, fmt.Errorf("failed to read event stream: %w", err))
+			return
+		}
+		if pending {
+			yield(nil, errStreamTruncated)
+		}
+	}
+}
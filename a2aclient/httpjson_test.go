@@ -0,0 +1,312 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2apb"
+	"github.com/a2aproject/a2a-go/internal/pbconv"
+)
+
+func newRESTServer(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+	config := a2a.TaskPushConfig{TaskID: "task-1", Config: a2a.PushConfig{ID: "cfg-1", URL: "https://client.com/push"}}
+	var requests []string
+
+	protoTask, _ := pbconv.ToProtoTask(task)
+	protoConfig := pbconv.ToProtoTaskPushConfig(config)
+
+	writeJSON := func(w http.ResponseWriter, v proto.Message) {
+		w.Header().Set("Content-Type", "application/json")
+		data, _ := protojson.Marshal(v)
+		_, _ = w.Write(data)
+	}
+	writeEvents := func(w http.ResponseWriter, events ...a2a.Event) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, ": keep-alive\n\n")
+		for _, event := range events {
+			resp, _ := pbconv.ToProtoStreamResponse(event)
+			data, _ := protojson.Marshal(resp)
+			_, _ = fmt.Fprintf(w, "data: %s\n\n", data)
+		}
+	}
+	readJSON := func(r *http.Request, v proto.Message) error {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+		return protojson.Unmarshal(data, v)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch id := r.PathValue("id"); id {
+		case "task-1":
+			requests = append(requests, "get "+r.URL.Query().Get("historyLength"))
+			writeJSON(w, protoTask)
+		case "task-1:subscribe":
+			requests = append(requests, "subscribe")
+			writeEvents(w, a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil))
+		default:
+			http.Error(w, "no task "+id, http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("POST /v1/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var req a2apb.CancelTaskRequest
+		if err := readJSON(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, "cancel "+r.PathValue("id")+" "+req.GetName())
+		http.Error(w, "task is completed", http.StatusConflict)
+	})
+	mux.HandleFunc("POST /v1/message:send", func(w http.ResponseWriter, r *http.Request) {
+		var req a2apb.SendMessageRequest
+		if err := readJSON(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, "send "+req.GetRequest().GetMessageId()+" "+r.Header.Get("X-Test"))
+		writeJSON(w, &a2apb.SendMessageResponse{Payload: &a2apb.SendMessageResponse_Task{Task: protoTask}})
+	})
+	mux.HandleFunc("POST /v1/message:stream", func(w http.ResponseWriter, r *http.Request) {
+		var req a2apb.SendMessageRequest
+		if err := readJSON(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, "stream "+r.Header.Get("Accept"))
+		writeEvents(w, task, a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil))
+	})
+	mux.HandleFunc("POST /v1/tasks/{id}/pushNotificationConfigs", func(w http.ResponseWriter, r *http.Request) {
+		var req a2apb.TaskPushNotificationConfig
+		if err := readJSON(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, "set "+r.URL.Query().Get("configId")+" "+req.GetName())
+		writeJSON(w, &req)
+	})
+	mux.HandleFunc("GET /v1/tasks/{id}/pushNotificationConfigs", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, "list "+r.URL.Query().Get("pageSize"))
+		writeJSON(w, &a2apb.ListTaskPushNotificationConfigResponse{Configs: []*a2apb.TaskPushNotificationConfig{protoConfig}})
+	})
+	mux.HandleFunc("GET /v1/tasks/{id}/pushNotificationConfigs/{configID}", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, "getConfig "+r.PathValue("configID"))
+		writeJSON(w, protoConfig)
+	})
+	mux.HandleFunc("DELETE /v1/tasks/{id}/pushNotificationConfigs/{configID}", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, "deleteConfig "+r.PathValue("configID"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /v1/card", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not implemented", http.StatusNotImplemented)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestHTTPJSONTransport(t *testing.T) {
+	server, requests := newRESTServer(t)
+	transport := NewHTTPJSONTransport(server.URL+"/", nil)
+	ctx := t.Context()
+
+	historyLength := 2
+	if task, err := transport.GetTask(ctx, a2a.TaskQueryParams{ID: "task-1", HistoryLength: &historyLength}); err != nil || task.ID != "task-1" {
+		t.Fatalf("GetTask() = (%v, %v), want task-1", task, err)
+	}
+	if _, err := transport.GetTask(ctx, a2a.TaskQueryParams{ID: "missing"}); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Fatalf("GetTask() error = %v, want ErrTaskNotFound", err)
+	}
+	if _, err := transport.CancelTask(ctx, a2a.TaskIDParams{ID: "task-1"}); !errors.Is(err, a2a.ErrTaskNotCancelable) {
+		t.Fatalf("CancelTask() error = %v, want ErrTaskNotCancelable", err)
+	}
+
+	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hi"})
+	sendCtx := transportContext(ctx, CallMeta{"X-Test": "meta"})
+	result, err := transport.SendMessage(sendCtx, a2a.MessageSendParams{Message: *msg})
+	if task, ok := result.(*a2a.Task); err != nil || !ok || task.ID != "task-1" {
+		t.Fatalf("SendMessage() = (%v, %v), want task-1", result, err)
+	}
+
+	var kinds []string
+	for event, err := range transport.SendStreamingMessage(ctx, a2a.MessageSendParams{Message: *msg}) {
+		if err != nil {
+			t.Fatalf("SendStreamingMessage() error = %v", err)
+		}
+		kinds = append(kinds, a2a.EventKindOf(event))
+	}
+	for event, err := range transport.ResubscribeToTask(ctx, a2a.TaskIDParams{ID: "task-1"}) {
+		if err != nil {
+			t.Fatalf("ResubscribeToTask() error = %v", err)
+		}
+		kinds = append(kinds, a2a.EventKindOf(event))
+	}
+	if want := []string{"task", "status-update", "status-update"}; !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("streamed %v, want %v", kinds, want)
+	}
+
+	config := a2a.TaskPushConfig{TaskID: "task-1", Config: a2a.PushConfig{ID: "cfg-1", URL: "https://client.com/push"}}
+	if got, err := transport.SetTaskPushConfig(ctx, config); err != nil || got.Config.ID != "cfg-1" {
+		t.Fatalf("SetTaskPushConfig() = (%v, %v), want cfg-1", got, err)
+	}
+	if got, err := transport.GetTaskPushConfig(ctx, a2a.GetTaskPushConfigParams{TaskID: "task-1", ConfigID: "cfg-1"}); err != nil || got.Config.ID != "cfg-1" {
+		t.Fatalf("GetTaskPushConfig() = (%v, %v), want cfg-1", got, err)
+	}
+	if got, err := transport.ListTaskPushConfig(ctx, a2a.ListTaskPushConfigParams{TaskID: "task-1", PageSize: 5}); err != nil || len(got.Configs) != 1 {
+		t.Fatalf("ListTaskPushConfig() = (%v, %v), want 1 config", got, err)
+	}
+	if err := transport.DeleteTaskPushConfig(ctx, a2a.DeleteTaskPushConfigParams{TaskID: "task-1", ConfigID: "cfg-1"}); err != nil {
+		t.Fatalf("DeleteTaskPushConfig() error = %v", err)
+	}
+	if _, err := transport.GetAgentCard(ctx); !errors.Is(err, a2a.ErrUnsupportedOperation) {
+		t.Fatalf("GetAgentCard() error = %v, want ErrUnsupportedOperation", err)
+	}
+
+	want := []string{
+		"get 2",
+		"cancel task-1:cancel tasks/task-1",
+		"send " + msg.ID + " meta",
+		"stream text/event-stream",
+		"subscribe",
+		"set cfg-1 tasks/task-1/pushNotificationConfigs/cfg-1",
+		"getConfig cfg-1",
+		"list 5",
+		"deleteConfig cfg-1",
+	}
+	if !reflect.DeepEqual(*requests, want) {
+		t.Fatalf("server got requests %v, want %v", *requests, want)
+	}
+}
+
+func TestHTTPJSONTransport_GetAgentCardWithoutExtendedCard(t *testing.T) {
+	server, requests := newRESTServer(t)
+	card := &a2a.AgentCard{Name: "public"}
+	factory := NewFactory(WithDefaultsDisabled(), WithHTTPJSONTransport(nil))
+	transport, err := factory.transports[a2a.TransportProtocolHTTPJSON].Create(t.Context(), server.URL, card)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := transport.GetAgentCard(t.Context())
+	if err != nil {
+		t.Fatalf("GetAgentCard() error = %v", err)
+	}
+	if got != card || len(*requests) != 0 {
+		t.Errorf("GetAgentCard() = %+v after %d requests, want the public card without calling the agent", got, len(*requests))
+	}
+}
+
+func TestHTTPJSONTransport_ReportsPayloadSize(t *testing.T) {
+	server, _ := newRESTServer(t)
+	interceptor := &sizeInterceptor{}
+	client := &Client{transport: NewHTTPJSONTransport(server.URL, nil)}
+	client.AddCallInterceptor(interceptor)
+
+	if _, err := client.GetTask(t.Context(), a2a.TaskQueryParams{ID: "task-1"}); err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if len(interceptor.sizes) != 1 {
+		t.Fatalf("got %d sizes, want 1", len(interceptor.sizes))
+	}
+	if size := interceptor.sizes[0]; size.Estimated || size.RequestBytes != 0 || size.ResponseBytes == 0 {
+		t.Fatalf("got size %+v, want reported response bytes", size)
+	}
+}
+
+func TestReadSSE(t *testing.T) {
+	stream := ": comment\n\ndata: {\"a\":\ndata: 1}\nevent: ignored\n\ndata:{}\n\ndata: partial"
+	var got []string
+	var gotErr error
+	for data, err := range readSSE(strings.NewReader(stream)) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		got = append(got, string(data))
+	}
+	if want := []string{"{\"a\":\n1}", "{}"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("readSSE() = %q, want %q", got, want)
+	}
+	if !errors.Is(gotErr, errStreamTruncated) {
+		t.Fatalf("readSSE() error = %v, want errStreamTruncated", gotErr)
+	}
+}
+
+func TestHTTPJSONTransport_ProtoJSONPayloads(t *testing.T) {
+	var sent []byte
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/message:send", func(w http.ResponseWriter, r *http.Request) {
+		sent, _ = io.ReadAll(r.Body)
+		_, _ = fmt.Fprint(w, `{"msg":{"messageId":"reply","role":"ROLE_AGENT","content":[{"text":"pong"}]}}`)
+	})
+	mux.HandleFunc("POST /v1/message:stream", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, "data: {\"task\":{\"id\":\"task-1\",\"contextId\":\"ctx-1\",\"status\":{\"state\":\"TASK_STATE_SUBMITTED\"}}}\n\n")
+		_, _ = fmt.Fprint(w, "data: {\"statusUpdate\":{\"taskId\":\"task-1\",\"contextId\":\"ctx-1\",\"status\":{\"state\":\"TASK_STATE_COMPLETED\"},\"final\":true}}\n\n")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	transport := NewHTTPJSONTransport(server.URL, nil)
+	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "ping"})
+
+	result, err := transport.SendMessage(t.Context(), a2a.MessageSendParams{Message: *msg})
+	if err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	reply, ok := result.(*a2a.Message)
+	if !ok || reply.ID != "reply" || reply.Role != a2a.MessageRoleAgent {
+		t.Fatalf("SendMessage() = %+v, want the agent reply", result)
+	}
+	var req map[string]any
+	if err := json.Unmarshal(sent, &req); err != nil {
+		t.Fatalf("request body %s is not JSON: %v", sent, err)
+	}
+	if _, ok := req["request"]; !ok || req["message"] != nil {
+		t.Fatalf("request body = %s, want a SendMessageRequest", sent)
+	}
+
+	var got []a2a.Event
+	for event, err := range transport.SendStreamingMessage(t.Context(), a2a.MessageSendParams{Message: *msg}) {
+		if err != nil {
+			t.Fatalf("SendStreamingMessage() error = %v", err)
+		}
+		got = append(got, event)
+	}
+	if len(got) != 2 {
+		t.Fatalf("SendStreamingMessage() yielded %d events, want 2", len(got))
+	}
+	if task, ok := got[0].(*a2a.Task); !ok || task.Status.State != a2a.TaskStateSubmitted {
+		t.Fatalf("first event = %+v, want a submitted task", got[0])
+	}
+	if update, ok := got[1].(*a2a.TaskStatusUpdateEvent); !ok || update.Status.State != a2a.TaskStateCompleted || !update.Final {
+		t.Fatalf("second event = %+v, want the final completed status update", got[1])
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+)
+
+// TextMessage creates a user Message with a single TextPart. taskID can be empty for starting a new Task.
+func TextMessage(text string, taskID a2a.TaskID) a2a.MessageSendParams {
+	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: text})
+	msg.TaskID = taskID
+	return a2a.MessageSendParams{Message: *msg}
+}
+
+// SendMessage makes a 'message/send' call and prints the result to w.
+func SendMessage(ctx context.Context, client *a2aclient.Client, params a2a.MessageSendParams, w io.Writer) error {
+	result, err := client.SendMessage(ctx, params)
+	if err != nil {
+		return err
+	}
+	return PrintJSON(w, result)
+}
+
+// StreamMessage makes a 'message/stream' call and prints every received event to w as
+// a line prefixed with the event kind. Returns the error which terminated the stream.
+func StreamMessage(ctx context.Context, client *a2aclient.Client, params a2a.MessageSendParams, w io.Writer) error {
+	return printEvents(client.SendStreamingMessage(ctx, params), w)
+}
+
+// Resubscribe makes a 'tasks/resubscribe' call and prints the received events like StreamMessage.
+func Resubscribe(ctx context.Context, client *a2aclient.Client, taskID a2a.TaskID, w io.Writer) error {
+	return printEvents(client.ResubscribeToTask(ctx, a2a.TaskIDParams{ID: taskID}), w)
+}
+
+// ListPushConfigs prints push notification configurations of the Task to w.
+func ListPushConfigs(ctx context.Context, client *a2aclient.Client, taskID a2a.TaskID, w io.Writer) error {
+	configs := []a2a.TaskPushConfig{}
+	for config, err := range client.AllTaskPushConfigs(ctx, a2a.ListTaskPushConfigParams{TaskID: taskID}) {
+		if err != nil {
+			return err
+		}
+		configs = append(configs, config)
+	}
+	return PrintJSON(w, configs)
+}
+
+func printEvents(events iter.Seq2[a2a.Event, error], w io.Writer) error {
+	for event, err := range events {
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %T: %w", event, err)
+		}
+		if _, err := fmt.Fprintf(w, "[%s] %s\n", EventKind(event), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EventKind returns the protocol kind of the event, eg. "message", "task", "status-update" or "artifact-update",
+// or the kind a custom event was registered with.
+func EventKind(event a2a.Event) string {
+	if kind := a2a.EventKindOf(event); kind != "" {
+		return kind
+	}
+	return fmt.Sprintf("%T", event)
+}
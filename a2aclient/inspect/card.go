@@ -0,0 +1,152 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient/agentcard"
+)
+
+// Severity tells if a Problem makes an AgentCard invalid or is a recommendation.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Problem is an issue found by ValidateCard.
+type Problem struct {
+	Severity Severity
+	// Field is a JSON path to the problematic AgentCard field, eg. "skills[0].id".
+	Field   string
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s: %s", p.Severity, p.Field, p.Message)
+}
+
+// FetchCard fetches an AgentCard from the well-known path of baseURL. http.DefaultClient is used if client is nil.
+func FetchCard(ctx context.Context, baseURL string, client *http.Client) (*a2a.AgentCard, error) {
+	resolver := &agentcard.Resolver{BaseURL: baseURL, Client: client}
+	return resolver.Resolve(ctx)
+}
+
+// PrintJSON writes an indented JSON representation of v to w.
+func PrintJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// ValidateCard checks that the AgentCard has all the required fields set and that its fields
+// are consistent with each other. Returns nil if no problems were found.
+func ValidateCard(card *a2a.AgentCard) []Problem {
+	var problems []Problem
+	report := func(severity Severity, field, format string, args ...any) {
+		problems = append(problems, Problem{Severity: severity, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	required := []struct{ field, value string }{
+		{"name", card.Name},
+		{"description", card.Description},
+		{"version", card.Version},
+		{"protocolVersion", card.ProtocolVersion},
+	}
+	for _, r := range required {
+		if r.value == "" {
+			report(SeverityError, r.field, "required field is empty")
+		}
+	}
+
+	validateURL(report, "url", card.URL)
+	for i, iface := range card.AdditionalInterfaces {
+		field := fmt.Sprintf("additionalInterfaces[%d]", i)
+		if iface.Transport == "" {
+			report(SeverityError, field+".transport", "required field is empty")
+		}
+		validateURL(report, field+".url", iface.URL)
+	}
+	for _, p := range a2a.CheckInterfaces(card) {
+		severity := SeverityWarning
+		if p.Invalid {
+			severity = SeverityError
+		}
+		report(severity, p.Field, "%s", p.Message)
+	}
+
+	if len(card.DefaultInputModes) == 0 {
+		report(SeverityWarning, "defaultInputModes", "no input MIME types declared")
+	}
+	if len(card.DefaultOutputModes) == 0 {
+		report(SeverityWarning, "defaultOutputModes", "no output MIME types declared")
+	}
+
+	if len(card.Skills) == 0 {
+		report(SeverityWarning, "skills", "no skills declared")
+	}
+	skillIDs := make(map[string]int)
+	for i, skill := range card.Skills {
+		field := fmt.Sprintf("skills[%d]", i)
+		if skill.ID == "" {
+			report(SeverityError, field+".id", "required field is empty")
+		} else if prev, ok := skillIDs[skill.ID]; ok {
+			report(SeverityError, field+".id", "duplicates the ID of skills[%d]", prev)
+		} else {
+			skillIDs[skill.ID] = i
+		}
+		if skill.Name == "" {
+			report(SeverityError, field+".name", "required field is empty")
+		}
+		for j, requirements := range skill.Security {
+			for scheme := range requirements {
+				validateScheme(report, card, fmt.Sprintf("%s.security[%d]", field, j), a2a.SecuritySchemeName(scheme))
+			}
+		}
+	}
+
+	for i, requirements := range card.Security {
+		for scheme := range requirements {
+			validateScheme(report, card, fmt.Sprintf("security[%d]", i), scheme)
+		}
+	}
+	return problems
+}
+
+type reportFn func(severity Severity, field, format string, args ...any)
+
+func validateURL(report reportFn, field, value string) {
+	if value == "" {
+		report(SeverityError, field, "required field is empty")
+		return
+	}
+	if _, err := url.Parse(value); err != nil {
+		report(SeverityError, field, "invalid URL: %v", err)
+	}
+}
+
+func validateScheme(report reportFn, card *a2a.AgentCard, field string, scheme a2a.SecuritySchemeName) {
+	if _, ok := card.SecuritySchemes[scheme]; !ok {
+		report(SeverityError, field, "references undeclared security scheme %q", scheme)
+	}
+}
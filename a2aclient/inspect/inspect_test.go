@@ -0,0 +1,135 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+)
+
+func validCard() *a2a.AgentCard {
+	return &a2a.AgentCard{
+		Name:               "agent",
+		Description:        "test agent",
+		Version:            "1.0.0",
+		ProtocolVersion:    "0.3.0",
+		URL:                "https://agent.com/a2a",
+		DefaultInputModes:  []string{"text/plain"},
+		DefaultOutputModes: []string{"text/plain"},
+		Skills:             []a2a.AgentSkill{{ID: "echo", Name: "Echo"}},
+	}
+}
+
+func TestValidateCard(t *testing.T) {
+	tests := []struct {
+		name       string
+		modify     func(card *a2a.AgentCard)
+		wantFields []string
+	}{
+		{
+			name:   "valid",
+			modify: func(card *a2a.AgentCard) {},
+		},
+		{
+			name: "missing required fields",
+			modify: func(card *a2a.AgentCard) {
+				card.Name, card.URL = "", ""
+			},
+			wantFields: []string{"name", "url"},
+		},
+		{
+			name: "duplicate skill",
+			modify: func(card *a2a.AgentCard) {
+				card.Skills = append(card.Skills, a2a.AgentSkill{ID: "echo", Name: "Echo again"})
+			},
+			wantFields: []string{"skills[1].id"},
+		},
+		{
+			name: "undeclared security scheme",
+			modify: func(card *a2a.AgentCard) {
+				card.Security = []a2a.SecurityRequirements{{"oauth": a2a.SecuritySchemeScopes{}}}
+			},
+			wantFields: []string{"security[0]"},
+		},
+		{
+			name: "additional interfaces without the main one",
+			modify: func(card *a2a.AgentCard) {
+				card.AdditionalInterfaces = []a2a.AgentInterface{{Transport: string(a2a.TransportProtocolGRPC), URL: "agent.com:443"}}
+			},
+			wantFields: []string{"additionalInterfaces"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := validCard()
+			tt.modify(card)
+
+			var fields []string
+			for _, p := range ValidateCard(card) {
+				fields = append(fields, p.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.wantFields, ",") {
+				t.Fatalf("ValidateCard() problem fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+type streamTransport struct {
+	a2aclient.Transport
+	events []a2a.Event
+}
+
+func (t *streamTransport) SendStreamingMessage(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		for _, event := range t.events {
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestStreamMessage(t *testing.T) {
+	transport := &streamTransport{events: []a2a.Event{
+		&a2a.Task{ID: "task", ContextID: "ctx"},
+		&a2a.TaskStatusUpdateEvent{TaskID: "task", ContextID: "ctx", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}},
+	}}
+	card := &a2a.AgentCard{URL: "test://agent", PreferredTransport: "test"}
+	factory := a2aclient.NewFactory(a2aclient.WithDefaultsDisabled(), a2aclient.WithTransport("test", a2aclient.TransportFactoryFn(
+		func(ctx context.Context, url string, card *a2a.AgentCard) (a2aclient.Transport, error) {
+			return transport, nil
+		},
+	)))
+	client, err := factory.CreateFromCard(t.Context(), card)
+	if err != nil {
+		t.Fatalf("CreateFromCard() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := StreamMessage(t.Context(), client, TextMessage("hi", ""), &out); err != nil {
+		t.Fatalf("StreamMessage() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "[task] ") || !strings.HasPrefix(lines[1], "[status-update] ") {
+		t.Fatalf("StreamMessage() output = %q, want a line per event prefixed with its kind", out.String())
+	}
+}
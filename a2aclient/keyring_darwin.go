@@ -0,0 +1,79 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build darwin
+
+package a2aclient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// securityItemNotFound is the exit code of the security tool for missing keychain items.
+const securityItemNotFound = 44
+
+// SystemKeyring returns a Keyring storing generic passwords in the macOS login keychain
+// using the security command line tool.
+func SystemKeyring() (Keyring, error) {
+	if _, err := exec.LookPath("security"); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyringUnavailable, err)
+	}
+	return macKeychain{}, nil
+}
+
+type macKeychain struct{}
+
+func (macKeychain) Get(service, user string) (string, error) {
+	return security(nil, "find-generic-password", "-s", service, "-a", user, "-w")
+}
+
+func (macKeychain) Set(service, user, secret string) error {
+	// The secret is passed on stdin in interactive mode so that it doesn't show up in the process list.
+	command := fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n",
+		securityQuote(service), securityQuote(user), hex.EncodeToString([]byte(secret)))
+	_, err := security(strings.NewReader(command), "-i")
+	return err
+}
+
+func (macKeychain) Delete(service, user string) error {
+	_, err := security(nil, "delete-generic-password", "-s", service, "-a", user)
+	return err
+}
+
+func security(stdin io.Reader, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("security", args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFound {
+			return "", ErrCredentialNotFound
+		}
+		return "", fmt.Errorf("security %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSuffix(stdout.String(), "\n"), nil
+}
+
+func securityQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package a2aclient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// SystemKeyring returns a Keyring storing secrets in the Secret Service (eg. GNOME Keyring or KWallet)
+// using the secret-tool command line tool. A D-Bus session is required.
+func SystemKeyring() (Keyring, error) {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyringUnavailable, err)
+	}
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return nil, fmt.Errorf("%w: no D-Bus session", ErrKeyringUnavailable)
+	}
+	return secretService{}, nil
+}
+
+type secretService struct{}
+
+func (secretService) Get(service, user string) (string, error) {
+	secret, err := secretTool(nil, "lookup", "service", service, "account", user)
+	if err != nil {
+		var exitErr *exec.ExitError
+		// secret-tool exits with 1 and no output for missing items.
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", ErrCredentialNotFound
+		}
+		return "", err
+	}
+	return secret, nil
+}
+
+func (secretService) Set(service, user, secret string) error {
+	label := fmt.Sprintf("--label=%s (%s)", service, user)
+	_, err := secretTool(strings.NewReader(secret), "store", label, "service", service, "account", user)
+	return err
+}
+
+func (secretService) Delete(service, user string) error {
+	_, err := secretTool(nil, "clear", "service", service, "account", user)
+	return err
+}
+
+func secretTool(stdin io.Reader, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("secret-tool %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
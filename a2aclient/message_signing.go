@@ -0,0 +1,62 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2acrypto"
+)
+
+// MessageSigningInterceptor is a CallInterceptor implementing the a2acrypto.MessageSignatureExtensionURI
+// extension. Messages sent with SendMessage and SendStreamingMessage are signed with Signer. If Verifier
+// is set, Message results of SendMessage must carry a valid signature.
+//
+// The interceptor should be added last, so that no other interceptor modifies a signed message.
+type MessageSigningInterceptor struct {
+	PassthroughInterceptor
+	// Signer signs outgoing messages. Required.
+	Signer *a2acrypto.MessageSigner
+	// Verifier verifies Message results if set.
+	Verifier *a2acrypto.MessageVerifier
+}
+
+func (i *MessageSigningInterceptor) Before(ctx context.Context, req *Request) (context.Context, error) {
+	params, ok := req.Payload.(a2a.MessageSendParams)
+	if !ok {
+		return ctx, nil
+	}
+	if err := i.Signer.SignMessage(&params.Message); err != nil {
+		return ctx, err
+	}
+	req.Payload = params
+	return ctx, nil
+}
+
+func (i *MessageSigningInterceptor) After(ctx context.Context, resp *Response) error {
+	if i.Verifier == nil || resp.Err != nil {
+		return nil
+	}
+	msg, ok := resp.Payload.(*a2a.Message)
+	if !ok || msg == nil {
+		return nil
+	}
+	if _, err := i.Verifier.VerifyMessage(msg); err != nil {
+		return fmt.Errorf("agent response verification failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2acrypto"
+)
+
+func TestMessageSigningInterceptor(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	keys := map[string]ed25519.PublicKey{"key-1": pub}
+	signer := a2acrypto.Ed25519MessageSigner("key-1", priv)
+	verifier := &a2acrypto.MessageVerifier{Verify: a2acrypto.VerifyEd25519(keys)}
+
+	signResponse := true
+	client := &Client{transport: &mockTransport{
+		SendMessageFunc: func(ctx context.Context, params a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+			if _, err := verifier.VerifyMessage(&params.Message); err != nil {
+				return nil, err
+			}
+			reply := a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "ok"})
+			if signResponse {
+				if err := signer.SignMessage(reply); err != nil {
+					return nil, err
+				}
+			}
+			return reply, nil
+		},
+	}}
+	client.AddCallInterceptor(&MessageSigningInterceptor{Signer: signer, Verifier: verifier})
+
+	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hi"})
+	if _, err := client.SendMessage(t.Context(), a2a.MessageSendParams{Message: *msg}); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	if _, ok := msg.Metadata[a2acrypto.MessageSignatureMetaKey]; ok {
+		t.Fatal("SendMessage() modified the caller's message")
+	}
+
+	signResponse = false
+	if _, err := client.SendMessage(t.Context(), a2a.MessageSendParams{Message: *msg}); !errors.Is(err, a2acrypto.ErrMissingSignature) {
+		t.Fatalf("SendMessage() with unsigned response error = %v, want %v", err, a2acrypto.ErrMissingSignature)
+	}
+}
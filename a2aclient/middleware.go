@@ -16,6 +16,9 @@ package a2aclient
 
 import (
 	"context"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
 )
 
 // Used to store a CallContext in context.Context.
@@ -43,6 +46,12 @@ type Response struct {
 	Err     error
 	Meta    CallMeta
 	Payload any
+	// Size holds the number of bytes transferred by the call. For streaming calls it holds the totals
+	// of the whole stream.
+	Size PayloadSize
+	// Retry can be set by a CallInterceptor to make the Client repeat the call, eg. after credentials
+	// were refreshed. A call is repeated at most once.
+	Retry bool
 }
 
 // CallInterceptor can be attached to an a2aclient.Client.
@@ -62,6 +71,10 @@ type CallInterceptor interface {
 type CallContext struct {
 	Method    string
 	SessionID SessionID
+	// Agent identifies the agent the call is made to.
+	Agent AgentID
+	// Card is the latest AgentCard known to the Client, see Client.AgentCard. Can be nil.
+	Card *a2a.AgentCard
 }
 
 // CallMetaFrom allows Transport implementations to access CallMeta after all
@@ -71,6 +84,21 @@ func CallMetaFrom(ctx context.Context) (CallMeta, bool) {
 	return meta, ok
 }
 
+// transportContext is passed to Transport methods after all the interceptors were applied.
+// The time left until the context deadline is added to meta as a hint for the server, unless
+// an interceptor already set it.
+func transportContext(ctx context.Context, meta CallMeta) context.Context {
+	if deadline, ok := ctx.Deadline(); ok {
+		if meta == nil {
+			meta = CallMeta{}
+		}
+		if _, set := meta[a2a.RequestTimeoutMetaKey]; !set {
+			meta[a2a.RequestTimeoutMetaKey] = a2a.FormatRequestTimeout(time.Until(deadline))
+		}
+	}
+	return context.WithValue(ctx, callMetaKey{}, meta)
+}
+
 // CallContextFrom allows CallInterceptors to get additional information about the intercepted request.
 func CallContextFrom(ctx context.Context) (CallContext, bool) {
 	callCtx, ok := ctx.Value(callContextKey{}).(CallContext)
@@ -89,6 +117,15 @@ func WithSessionID(ctx context.Context, sid SessionID) context.Context {
 	return context.WithValue(ctx, callContextKey{}, callCtx)
 }
 
+// WithAgentID allows callers to override the agent identifier attached to the request, which is
+// otherwise derived from the AgentCard URL of the Client.
+// CallInterceptor and CredentialsService can access this identifier through CallContext.
+func WithAgentID(ctx context.Context, agent AgentID) context.Context {
+	callCtx, _ := CallContextFrom(ctx)
+	callCtx.Agent = agent
+	return context.WithValue(ctx, callContextKey{}, callCtx)
+}
+
 // PassthroughInterceptor can be used by CallInterceptor implementers who don't need all methods.
 // The struct can be embedded for providing a no-op implementation.
 type PassthroughInterceptor struct{}
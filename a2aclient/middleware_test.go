@@ -108,3 +108,19 @@ func TestPassthroughInterceptor(t *testing.T) {
 		t.Errorf("unexpected error from After: %v", err)
 	}
 }
+
+func TestWithAgentID(t *testing.T) {
+	ctx := WithSessionID(context.Background(), "test-sid")
+	ctx = WithAgentID(ctx, "https://agent.com")
+
+	callCtx, ok := CallContextFrom(ctx)
+	if !ok {
+		t.Fatal("expected to find call context")
+	}
+	if callCtx.Agent != "https://agent.com" {
+		t.Errorf("unexpected agent: got %q, want %q", callCtx.Agent, "https://agent.com")
+	}
+	if callCtx.SessionID != "test-sid" {
+		t.Errorf("session id not preserved: got %q", callCtx.SessionID)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// MultipartFile is raw file content sent alongside a Message in a multipart/form-data request.
+type MultipartFile struct {
+	// Field is the form field name the Message references with a2a.MultipartFileURI.
+	Field string
+	// Meta is the file name and MIME type sent in form part headers.
+	Meta a2a.FileMeta
+	// Content is streamed to the agent.
+	Content io.Reader
+}
+
+// FilePart returns a FilePart referencing the file, which can be included in the sent Message.
+func (f MultipartFile) FilePart() a2a.FilePart {
+	return a2a.FilePart{File: a2a.FileURI{FileMeta: f.Meta, URI: a2a.MultipartFileURI(f.Field)}}
+}
+
+// MultipartSender submits messages with binary inputs to agents serving the HTTP+JSON
+// protocol binding as multipart/form-data, which avoids base64 encoding overhead of FileBytes.
+type MultipartSender struct {
+	// URL is the 'message/send' endpoint of the agent.
+	URL string
+	// Client is used for sending requests. http.DefaultClient is used if nil.
+	Client *http.Client
+}
+
+// SendMessage sends the message and streams the files in the same request.
+func (s *MultipartSender) SendMessage(ctx context.Context, params a2a.MessageSendParams, files ...MultipartFile) (a2a.SendMessageResult, error) {
+	envelope, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	body, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	go func() {
+		_ = pw.CloseWithError(writeMultipartMessage(writer, envelope, files))
+	}()
+	defer func() { _ = body.Close() }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send message: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("failed to send message: unexpected status %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var result struct {
+		Task    *a2a.Task    `json:"task"`
+		Message *a2a.Message `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	switch {
+	case result.Task != nil:
+		return result.Task, nil
+	case result.Message != nil:
+		return result.Message, nil
+	default:
+		return nil, a2a.ErrInvalidAgentResponse
+	}
+}
+
+// quoteEscaper escapes Content-Disposition parameter values the same way as multipart.Writer.CreateFormFile.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func writeMultipartMessage(writer *multipart.Writer, envelope []byte, files []MultipartFile) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, a2a.MultipartMessageField))
+	header.Set("Content-Type", "application/json")
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(envelope); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(file.Field), quoteEscaper.Replace(file.Meta.Name)))
+		contentType := file.Meta.MimeType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		header.Set("Content-Type", contentType)
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, file.Content); err != nil {
+			return fmt.Errorf("failed to read %s: %w", file.Field, err)
+		}
+	}
+	return writer.Close()
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+type fileEchoExecutor struct{}
+
+func (fileEchoExecutor) Execute(ctx context.Context, reqCtx a2asrv.RequestContext, q eventqueue.Queue) error {
+	file := reqCtx.Request.Message.Parts[0].(a2a.FilePart).File.(a2a.FileBytes)
+	content, err := base64.StdEncoding.DecodeString(file.Bytes)
+	if err != nil {
+		return err
+	}
+	return q.Write(ctx, a2a.NewMessageForTask(a2a.MessageRoleAgent, a2a.Task{ID: reqCtx.TaskID}, a2a.TextPart{Text: file.Name + ":" + string(content)}))
+}
+
+func (fileEchoExecutor) Cancel(ctx context.Context, reqCtx a2asrv.RequestContext, q eventqueue.Queue) error {
+	return nil
+}
+
+func TestMultipartSender_SendMessage(t *testing.T) {
+	server := httptest.NewServer(a2asrv.NewMultipartMessageHandler(a2asrv.NewHandler(fileEchoExecutor{}), a2asrv.MultipartConfig{}))
+	defer server.Close()
+
+	file := MultipartFile{Field: "doc", Meta: a2a.FileMeta{Name: `report "q3".pdf`}, Content: strings.NewReader("%PDF")}
+	msg := a2a.Message{TaskID: "task-1", ID: "request", Role: a2a.MessageRoleUser, Parts: a2a.ContentParts{file.FilePart()}}
+	sender := &MultipartSender{URL: server.URL, Client: server.Client()}
+
+	result, err := sender.SendMessage(t.Context(), a2a.MessageSendParams{Message: msg}, file)
+	if err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	reply, ok := result.(*a2a.Message)
+	if !ok {
+		t.Fatalf("SendMessage() = %T, want *a2a.Message", result)
+	}
+	want := `report "q3".pdf:%PDF`
+	if got := reply.Parts[0].(a2a.TextPart).Text; got != want {
+		t.Fatalf("SendMessage() reply = %q, want %q", got, want)
+	}
+}
+
+func TestMultipartSender_Error(t *testing.T) {
+	server := httptest.NewServer(a2asrv.NewMultipartMessageHandler(a2asrv.NewHandler(fileEchoExecutor{}), a2asrv.MultipartConfig{}))
+	defer server.Close()
+
+	msg := a2a.Message{TaskID: "task-1", Parts: a2a.ContentParts{a2a.FilePart{File: a2a.FileURI{URI: a2a.MultipartFileURI("missing")}}}}
+	sender := &MultipartSender{URL: server.URL, Client: server.Client()}
+	if _, err := sender.SendMessage(t.Context(), a2a.MessageSendParams{Message: msg}); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("SendMessage() error = %v, want an error about the missing field", err)
+	}
+}
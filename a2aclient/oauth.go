@@ -0,0 +1,371 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"golang.org/x/sync/singleflight"
+)
+
+// OAuth2Token is the result of a successful OAuth 2.0 token request.
+type OAuth2Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// Expired returns true if the token has an expiry time and it has passed.
+func (t *OAuth2Token) Expired() bool {
+	return !t.Expiry.IsZero() && time.Now().After(t.Expiry)
+}
+
+// AuthCodeFlow implements the OAuth 2.0 authorization code flow with PKCE (RFC 7636) for user-facing
+// clients. The authorization response is received by a loopback HTTP listener (RFC 8252).
+type AuthCodeFlow struct {
+	// Flow is the flow configuration declared in the AgentCard OAuth2SecurityScheme.
+	Flow *a2a.AuthorizationCodeOAuthFlow
+	// ClientID is the OAuth 2.0 client identifier.
+	ClientID string
+	// ClientSecret is an optional client secret. Public clients rely on PKCE only.
+	ClientSecret string
+	// Scopes are requested during authorization.
+	Scopes []string
+	// OpenURL is invoked with the authorization URL the user needs to visit, eg. by launching a browser.
+	OpenURL func(ctx context.Context, authURL string) error
+	// RedirectAddr is the address of the loopback listener. Defaults to "127.0.0.1:0".
+	RedirectAddr string
+	// Client is used for token requests. http.DefaultClient is used if nil.
+	Client *http.Client
+}
+
+// Authorize performs an interactive authorization and exchanges the received code for a token.
+// It blocks until the user completes the authorization or the context is canceled.
+func (f *AuthCodeFlow) Authorize(ctx context.Context) (*OAuth2Token, error) {
+	if f.OpenURL == nil {
+		return nil, fmt.Errorf("OpenURL must be set for interactive authorization")
+	}
+
+	addr := f.RedirectAddr
+	if addr == "" {
+		addr = "127.0.0.1:0"
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start redirect listener: %w", err)
+	}
+	defer func() { _ = listener.Close() }()
+	redirectURI := fmt.Sprintf("http://%s/callback", listener.Addr().String())
+
+	verifier, err := randomURLSafeString(32)
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomURLSafeString(16)
+	if err != nil {
+		return nil, err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	authURL, err := url.Parse(f.Flow.AuthorizationURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authorization URL: %w", err)
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", f.ClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("state", state)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	if len(f.Scopes) > 0 {
+		query.Set("scope", strings.Join(f.Scopes, " "))
+	}
+	authURL.RawQuery = query.Encode()
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/callback" {
+			http.NotFound(w, r)
+			return
+		}
+		query := r.URL.Query()
+		var res result
+		switch {
+		case query.Get("state") != state:
+			res.err = fmt.Errorf("authorization state mismatch")
+		case query.Get("error") != "":
+			res.err = fmt.Errorf("authorization failed: %s %s", query.Get("error"), query.Get("error_description"))
+		case query.Get("code") == "":
+			res.err = fmt.Errorf("authorization response is missing code")
+		default:
+			res.code = query.Get("code")
+		}
+		if res.err != nil {
+			http.Error(w, res.err.Error(), http.StatusBadRequest)
+		} else {
+			_, _ = fmt.Fprintln(w, "Authorization complete. You can close this window.")
+		}
+		select {
+		case results <- res:
+		default:
+		}
+	})}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Close() }()
+
+	if err := f.OpenURL(ctx, authURL.String()); err != nil {
+		return nil, fmt.Errorf("failed to open authorization URL: %w", err)
+	}
+
+	var res result
+	select {
+	case res = <-results:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	return f.requestToken(ctx, f.Flow.TokenURL, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {res.code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {verifier},
+	})
+}
+
+// Refresh exchanges a refresh token for a new token. RefreshURL is used if declared, TokenURL otherwise.
+func (f *AuthCodeFlow) Refresh(ctx context.Context, refreshToken string) (*OAuth2Token, error) {
+	tokenURL := f.Flow.RefreshURL
+	if tokenURL == "" {
+		tokenURL = f.Flow.TokenURL
+	}
+	token, err := f.requestToken(ctx, tokenURL, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+func (f *AuthCodeFlow) requestToken(ctx context.Context, tokenURL string, form url.Values) (*OAuth2Token, error) {
+	form.Set("client_id", f.ClientID)
+	if f.ClientSecret != "" {
+		form.Set("client_secret", f.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		RefreshToken     string `json:"refresh_token"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || body.Error != "" {
+		return nil, fmt.Errorf("token request failed with status %d: %s %s", resp.StatusCode, body.Error, body.ErrorDescription)
+	}
+	if body.AccessToken == "" {
+		return nil, fmt.Errorf("token response is missing access_token")
+	}
+
+	token := &OAuth2Token{AccessToken: body.AccessToken, TokenType: body.TokenType, RefreshToken: body.RefreshToken}
+	if body.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+func randomURLSafeString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// OAuth2Credentials implements CredentialsService and CredentialsRefresher using AuthCodeFlow.
+// A token is obtained interactively on the first Get for a session and is refreshed when it expires
+// or an agent responds with an auth challenge. If Cache is set, the last obtained token is reused
+// by new sessions, including sessions of later process invocations.
+type OAuth2Credentials struct {
+	// Flow is used for obtaining and refreshing tokens.
+	Flow *AuthCodeFlow
+	// Scheme is the name of the OAuth2SecurityScheme in the AgentCard the credentials are used for.
+	Scheme a2a.SecuritySchemeName
+	// Cache optionally persists tokens. Failures to store a token are ignored, because
+	// the token can still be used by the current process.
+	Cache TokenCache
+	// CacheKey is the key tokens are cached under. Defaults to "{clientID}@{tokenURL}/{scheme}".
+	CacheKey string
+
+	mu     sync.Mutex
+	tokens map[SessionID]*OAuth2Token
+	// renewals deduplicates concurrent token renewals for the same session.
+	renewals singleflight.Group
+}
+
+func (c *OAuth2Credentials) Get(ctx context.Context, sid SessionID, scheme string) (AuthCredential, error) {
+	if a2a.SecuritySchemeName(scheme) != c.Scheme {
+		return AuthCredential(""), ErrCredentialNotFound
+	}
+
+	token, err := c.currentToken(ctx, sid)
+	if err != nil {
+		return AuthCredential(""), err
+	}
+	if token != nil && !token.Expired() {
+		return AuthCredential(token.AccessToken), nil
+	}
+	token, err = c.renew(ctx, sid, token)
+	if err != nil {
+		return AuthCredential(""), err
+	}
+	return AuthCredential(token.AccessToken), nil
+}
+
+func (c *OAuth2Credentials) Refresh(ctx context.Context, sid SessionID, challenge *AuthChallengeError) error {
+	_, err := c.renew(ctx, sid, c.Token(sid))
+	return err
+}
+
+// currentToken returns the token of the session, falling back to the cached token.
+func (c *OAuth2Credentials) currentToken(ctx context.Context, sid SessionID) (*OAuth2Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if token := c.tokens[sid]; token != nil || c.Cache == nil {
+		return token, nil
+	}
+	cached, err := c.Cache.Load(ctx, c.cacheKey())
+	if err != nil && !errors.Is(err, ErrCredentialNotFound) {
+		return nil, fmt.Errorf("failed to load cached token: %w", err)
+	}
+	if cached != nil {
+		c.setToken(sid, cached)
+	}
+	return cached, nil
+}
+
+// renew obtains a new token for the session. The refresh or interactive authorization is performed
+// without holding mu and is shared by all the callers renewing the token of the session concurrently.
+// A valid token which replaced current after the caller read it is returned without renewal.
+func (c *OAuth2Credentials) renew(ctx context.Context, sid SessionID, current *OAuth2Token) (*OAuth2Token, error) {
+	result, err, _ := c.renewals.Do(string(sid), func() (any, error) {
+		if latest := c.Token(sid); latest != nil && latest != current && !latest.Expired() {
+			return latest, nil
+		}
+		token, err := c.obtain(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.setToken(sid, token)
+		c.mu.Unlock()
+		c.storeToken(ctx, token)
+		return token, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*OAuth2Token), nil
+}
+
+// Token returns the token obtained for the session or nil.
+func (c *OAuth2Credentials) Token(sid SessionID) *OAuth2Token {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokens[sid]
+}
+
+// obtain refreshes the current token if possible and falls back to interactive authorization.
+func (c *OAuth2Credentials) obtain(ctx context.Context, current *OAuth2Token) (*OAuth2Token, error) {
+	if current != nil && current.RefreshToken != "" {
+		token, err := c.Flow.Refresh(ctx, current.RefreshToken)
+		if err == nil {
+			return token, nil
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+	}
+	return c.Flow.Authorize(ctx)
+}
+
+// setToken must be called with mu held.
+func (c *OAuth2Credentials) setToken(sid SessionID, token *OAuth2Token) {
+	if c.tokens == nil {
+		c.tokens = make(map[SessionID]*OAuth2Token)
+	}
+	c.tokens[sid] = token
+}
+
+func (c *OAuth2Credentials) storeToken(ctx context.Context, token *OAuth2Token) {
+	if c.Cache != nil {
+		_ = c.Cache.Store(ctx, c.cacheKey(), token)
+	}
+}
+
+func (c *OAuth2Credentials) cacheKey() string {
+	if c.CacheKey != "" {
+		return c.CacheKey
+	}
+	return fmt.Sprintf("%s@%s/%s", c.Flow.ClientID, c.Flow.Flow.TokenURL, c.Scheme)
+}
@@ -0,0 +1,262 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+type fakeAuthServer struct {
+	server     *httptest.Server
+	challenges map[string]string
+	issued     int
+	refreshed  int
+}
+
+func newFakeAuthServer(t *testing.T) *fakeAuthServer {
+	t.Helper()
+	s := &fakeAuthServer{challenges: make(map[string]string)}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch r.PostForm.Get("grant_type") {
+		case "authorization_code":
+			hash := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+			if s.challenges[r.PostForm.Get("code")] != base64.RawURLEncoding.EncodeToString(hash[:]) {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+				return
+			}
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != "refresh" {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+				return
+			}
+			s.refreshed++
+		}
+		s.issued++
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  fmt.Sprintf("token-%d", s.issued),
+			"token_type":    "Bearer",
+			"refresh_token": "refresh",
+			"expires_in":    3600,
+		})
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+// approve simulates a user approving the authorization in a browser.
+func (s *fakeAuthServer) approve(ctx context.Context, authURL string) error {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return err
+	}
+	query := u.Query()
+	if query.Get("code_challenge_method") != "S256" {
+		return fmt.Errorf("unexpected challenge method %q", query.Get("code_challenge_method"))
+	}
+	code := fmt.Sprintf("code-%d", len(s.challenges))
+	s.challenges[code] = query.Get("code_challenge")
+
+	redirect := fmt.Sprintf("%s?code=%s&state=%s", query.Get("redirect_uri"), code, query.Get("state"))
+	go func() {
+		resp, err := http.Get(redirect)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	return nil
+}
+
+func (s *fakeAuthServer) flow() *AuthCodeFlow {
+	return &AuthCodeFlow{
+		Flow: &a2a.AuthorizationCodeOAuthFlow{
+			AuthorizationURL: "https://auth.example.com/authorize",
+			TokenURL:         s.server.URL + "/token",
+		},
+		ClientID: "client",
+		Scopes:   []string{"read"},
+		OpenURL:  s.approve,
+	}
+}
+
+func TestAuthCodeFlow_Authorize(t *testing.T) {
+	authServer := newFakeAuthServer(t)
+	flow := authServer.flow()
+
+	token, err := flow.Authorize(t.Context())
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if token.AccessToken != "token-1" || token.RefreshToken != "refresh" || token.Expired() {
+		t.Fatalf("Authorize() = %+v, want valid token-1", token)
+	}
+
+	refreshed, err := flow.Refresh(t.Context(), token.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if refreshed.AccessToken != "token-2" {
+		t.Fatalf("Refresh() = %+v, want token-2", refreshed)
+	}
+}
+
+func TestAuthCodeFlow_AuthorizeStateMismatch(t *testing.T) {
+	authServer := newFakeAuthServer(t)
+	flow := authServer.flow()
+	flow.OpenURL = func(ctx context.Context, authURL string) error {
+		u, _ := url.Parse(authURL)
+		go func() {
+			resp, err := http.Get(u.Query().Get("redirect_uri") + "?code=code&state=forged")
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+
+	if _, err := flow.Authorize(t.Context()); err == nil {
+		t.Fatal("Authorize() with forged state succeeded")
+	}
+}
+
+func TestOAuth2Credentials(t *testing.T) {
+	authServer := newFakeAuthServer(t)
+	creds := &OAuth2Credentials{Flow: authServer.flow(), Scheme: "oauth2"}
+	ctx := t.Context()
+
+	if _, err := creds.Get(ctx, "session", "apiKey"); err != ErrCredentialNotFound {
+		t.Fatalf("Get() for other scheme error = %v, want %v", err, ErrCredentialNotFound)
+	}
+
+	first, err := creds.Get(ctx, "session", "oauth2")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	cached, err := creds.Get(ctx, "session", "oauth2")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if first != cached || authServer.issued != 1 {
+		t.Fatalf("Get() = %q then %q with %d tokens issued, want cached token", first, cached, authServer.issued)
+	}
+
+	if err := creds.Refresh(ctx, "session", &AuthChallengeError{}); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	refreshed, err := creds.Get(ctx, "session", "oauth2")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if refreshed == first || authServer.refreshed != 1 {
+		t.Fatalf("Get() after Refresh() = %q, want a refreshed token", refreshed)
+	}
+}
+
+func TestOAuth2Credentials_ConcurrentGet(t *testing.T) {
+	authServer := newFakeAuthServer(t)
+	flow := authServer.flow()
+	var openOnce sync.Once
+	opened, release := make(chan struct{}), make(chan struct{})
+	flow.OpenURL = func(ctx context.Context, authURL string) error {
+		openOnce.Do(func() { close(opened) })
+		<-release
+		return authServer.approve(ctx, authURL)
+	}
+	creds := &OAuth2Credentials{Flow: flow, Scheme: "oauth2"}
+
+	var wg sync.WaitGroup
+	results := make([]AuthCredential, 5)
+	errs := make([]error, len(results))
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = creds.Get(t.Context(), "session", "oauth2")
+		}()
+	}
+
+	<-opened
+	// Token() would block if the lock was held while the user authorizes.
+	if token := creds.Token("session"); token != nil {
+		t.Fatalf("Token() during authorization = %+v, want nil", token)
+	}
+	close(release)
+	wg.Wait()
+
+	for i := range results {
+		if errs[i] != nil || results[i] != "token-1" {
+			t.Fatalf("Get() = (%q, %v), want token-1", results[i], errs[i])
+		}
+	}
+	if len(authServer.challenges) != 1 || authServer.issued != 1 {
+		t.Fatalf("%d authorizations with %d tokens issued, want one", len(authServer.challenges), authServer.issued)
+	}
+}
+
+func TestOAuth2Credentials_AttachedToRequests(t *testing.T) {
+	authServer := newFakeAuthServer(t)
+	var mu sync.Mutex
+	var received []string
+	agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		mu.Lock()
+		received = append(received, auth)
+		mu.Unlock()
+		// The first token is rejected as if it was revoked, so that the client has to refresh it.
+		if auth != "Bearer token-2" {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(a2a.Task{ID: "task-1", ContextID: "ctx-1"})
+	}))
+	defer agent.Close()
+
+	card := &a2a.AgentCard{
+		URL:             agent.URL,
+		Security:        []a2a.SecurityRequirements{{"oauth2": {"read"}}},
+		SecuritySchemes: a2a.NamedSecuritySchemes{"oauth2": a2a.OAuth2SecurityScheme{}},
+	}
+	client := &Client{transport: NewHTTPJSONTransport(agent.URL, agent.Client()), card: card}
+	client.AddCallInterceptor(AuthInterceptor{Service: &OAuth2Credentials{Flow: authServer.flow(), Scheme: "oauth2"}})
+
+	if _, err := client.GetTask(WithSessionID(t.Context(), "session"), a2a.TaskQueryParams{ID: "task-1"}); err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if want := []string{"Bearer token-1", "Bearer token-2"}; !slices.Equal(received, want) {
+		t.Fatalf("agent received Authorization %q, want %q", received, want)
+	}
+	if authServer.refreshed != 1 {
+		t.Fatalf("token refreshed %d times, want 1", authServer.refreshed)
+	}
+}
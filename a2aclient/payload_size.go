@@ -0,0 +1,130 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"google.golang.org/grpc/stats"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// PayloadSize holds the number of bytes transferred by a call. It is available to CallInterceptors
+// as Response.Size and can be used for bandwidth budgeting and detection of pathological payloads.
+type PayloadSize struct {
+	// RequestBytes is the size of the request payload.
+	RequestBytes int64
+	// ResponseBytes is the size of the response payload. For streaming calls it is the total
+	// size of all the received events.
+	ResponseBytes int64
+	// Events is the number of events received by a streaming call.
+	Events int
+	// Estimated is true if the Transport didn't report wire sizes and the numbers are lengths
+	// of JSON encodings of the payloads.
+	Estimated bool
+}
+
+// Used to store a payloadCounter in context.Context passed to Transport methods.
+type payloadCounterKey struct{}
+
+// payloadCounter accumulates sizes reported by a Transport during a call.
+type payloadCounter struct {
+	mu       sync.Mutex
+	size     PayloadSize
+	reported bool
+}
+
+func (c *payloadCounter) add(request, response int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.size.RequestBytes += request
+	c.size.ResponseBytes += response
+	c.reported = true
+}
+
+func (c *payloadCounter) isReported() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.reported
+}
+
+// result returns the reported sizes or falls back to the estimate if nothing was reported.
+func (c *payloadCounter) result(estimate func() PayloadSize) PayloadSize {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.reported {
+		return c.size
+	}
+	return estimate()
+}
+
+func withPayloadCounter(ctx context.Context) (context.Context, *payloadCounter) {
+	counter := &payloadCounter{}
+	return context.WithValue(ctx, payloadCounterKey{}, counter), counter
+}
+
+// ReportPayloadSize allows Transport implementations to report the number of bytes sent and received
+// on the wire for the call made with the context. It can be called multiple times, eg. once for every
+// streamed event, and the numbers are summed up. If a Transport doesn't report sizes, Client estimates
+// them from JSON encodings of the payloads.
+func ReportPayloadSize(ctx context.Context, sent, received int64) {
+	if counter, ok := ctx.Value(payloadCounterKey{}).(*payloadCounter); ok {
+		counter.add(sent, received)
+	}
+}
+
+// estimatePayloadSize returns the length of the JSON encoding of the payload. Protocol objects
+// are encoded with the "kind" discriminator and only params of a RawRequest are counted.
+func estimatePayloadSize(payload any) int64 {
+	if raw, ok := payload.(RawRequest); ok {
+		payload = raw.Params
+	}
+	var data []byte
+	var err error
+	if event, ok := payload.(a2a.Event); ok {
+		data, err = a2a.MarshalEvent(event)
+	} else {
+		data, err = json.Marshal(payload)
+	}
+	if err != nil {
+		return 0
+	}
+	return int64(len(data))
+}
+
+// grpcPayloadSizeHandler reports wire lengths of gRPC messages using ReportPayloadSize.
+type grpcPayloadSizeHandler struct{}
+
+func (grpcPayloadSizeHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (grpcPayloadSizeHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	switch v := s.(type) {
+	case *stats.OutPayload:
+		ReportPayloadSize(ctx, int64(v.WireLength), 0)
+	case *stats.InPayload:
+		ReportPayloadSize(ctx, 0, int64(v.WireLength))
+	}
+}
+
+func (grpcPayloadSizeHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (grpcPayloadSizeHandler) HandleConn(ctx context.Context, s stats.ConnStats) {}
@@ -0,0 +1,145 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"iter"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+type sizeInterceptor struct {
+	PassthroughInterceptor
+	sizes []PayloadSize
+}
+
+func (i *sizeInterceptor) After(ctx context.Context, resp *Response) error {
+	i.sizes = append(i.sizes, resp.Size)
+	return nil
+}
+
+func TestClient_PayloadSize(t *testing.T) {
+	message := a2a.MessageSendParams{Message: *a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hello"})}
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}}
+	events := []a2a.Event{
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil),
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil),
+	}
+
+	testCases := []struct {
+		name      string
+		transport *mockTransport
+		call      func(client *Client) error
+		want      PayloadSize
+	}{
+		{
+			name: "estimated",
+			transport: &mockTransport{
+				SendMessageFunc: func(ctx context.Context, params a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+					return task, nil
+				},
+			},
+			call: func(client *Client) error {
+				_, err := client.SendMessage(t.Context(), message)
+				return err
+			},
+			want: PayloadSize{RequestBytes: estimatePayloadSize(message), ResponseBytes: estimatePayloadSize(task), Estimated: true},
+		},
+		{
+			name: "reported",
+			transport: &mockTransport{
+				SendMessageFunc: func(ctx context.Context, params a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+					ReportPayloadSize(ctx, 100, 0)
+					ReportPayloadSize(ctx, 0, 250)
+					return task, nil
+				},
+			},
+			call: func(client *Client) error {
+				_, err := client.SendMessage(t.Context(), message)
+				return err
+			},
+			want: PayloadSize{RequestBytes: 100, ResponseBytes: 250},
+		},
+		{
+			name: "streamed totals",
+			transport: &mockTransport{
+				StreamFunc: func(ctx context.Context, params a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+					return func(yield func(a2a.Event, error) bool) {
+						for _, event := range events {
+							if !yield(event, nil) {
+								return
+							}
+						}
+					}
+				},
+			},
+			call: func(client *Client) error {
+				for _, err := range client.SendStreamingMessage(t.Context(), message) {
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			want: PayloadSize{
+				RequestBytes:  estimatePayloadSize(message),
+				ResponseBytes: estimatePayloadSize(events[0]) + estimatePayloadSize(events[1]),
+				Events:        2,
+				Estimated:     true,
+			},
+		},
+		{
+			name: "streamed reported",
+			transport: &mockTransport{
+				StreamFunc: func(ctx context.Context, params a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+					return func(yield func(a2a.Event, error) bool) {
+						ReportPayloadSize(ctx, 80, 0)
+						for _, event := range events {
+							ReportPayloadSize(ctx, 0, 40)
+							if !yield(event, nil) {
+								return
+							}
+						}
+					}
+				},
+			},
+			call: func(client *Client) error {
+				for _, err := range client.SendStreamingMessage(t.Context(), message) {
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			want: PayloadSize{RequestBytes: 80, ResponseBytes: 80, Events: 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			interceptor := &sizeInterceptor{}
+			client := &Client{transport: tc.transport}
+			client.AddCallInterceptor(interceptor)
+
+			if err := tc.call(client); err != nil {
+				t.Fatalf("call failed: %v", err)
+			}
+			if len(interceptor.sizes) != 1 || interceptor.sizes[0] != tc.want {
+				t.Fatalf("got sizes %+v, want [%+v]", interceptor.sizes, tc.want)
+			}
+		})
+	}
+}
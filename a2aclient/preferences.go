@@ -0,0 +1,52 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"maps"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+type preferencesKey struct{}
+
+// WithPreferences attaches the preferences of the user a call is made for to the context. Client attaches
+// them to messages sent with the context under a2a.PreferencesMetaKey, unless the message already specifies
+// preferences. This lets a single Client serve users in different regions.
+func WithPreferences(ctx context.Context, prefs a2a.Preferences) context.Context {
+	return context.WithValue(ctx, preferencesKey{}, prefs)
+}
+
+// PreferencesFrom returns the preferences attached to the context with WithPreferences.
+func PreferencesFrom(ctx context.Context) (a2a.Preferences, bool) {
+	prefs, ok := ctx.Value(preferencesKey{}).(a2a.Preferences)
+	return prefs, ok
+}
+
+// withContextPreferences returns the params with the context preferences added to the message metadata.
+// The metadata is copied so that the caller's message is never modified.
+func withContextPreferences(ctx context.Context, params a2a.MessageSendParams) a2a.MessageSendParams {
+	prefs, ok := PreferencesFrom(ctx)
+	if !ok || prefs == (a2a.Preferences{}) {
+		return params
+	}
+	if _, ok := params.Message.Metadata[a2a.PreferencesMetaKey]; ok {
+		return params
+	}
+	params.Message.Metadata = maps.Clone(params.Message.Metadata)
+	a2a.SetPreferences(&params.Message, prefs)
+	return params
+}
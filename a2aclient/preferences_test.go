@@ -0,0 +1,56 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestClient_ContextPreferences(t *testing.T) {
+	var sent []a2a.Message
+	transport := &mockTransport{
+		SendMessageFunc: func(ctx context.Context, params a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+			sent = append(sent, params.Message)
+			return &params.Message, nil
+		},
+	}
+	client := &Client{transport: transport}
+	prefs := a2a.Preferences{Locale: "fr-CA", TimeZone: "America/Toronto", Units: a2a.UnitSystemMetric}
+	ctx := WithPreferences(t.Context(), prefs)
+
+	msg := a2a.Message{ID: "msg-1", Metadata: map[string]any{"key": "value"}}
+	if _, err := client.SendMessage(ctx, a2a.MessageSendParams{Message: msg}); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	if got, err := a2a.PreferencesOf(&sent[0]); err != nil || got != prefs {
+		t.Fatalf("sent preferences = %+v, %v, want %+v", got, err, prefs)
+	}
+	if _, ok := msg.Metadata[a2a.PreferencesMetaKey]; ok {
+		t.Fatalf("SendMessage() modified the caller message metadata: %v", msg.Metadata)
+	}
+
+	// preferences set on the message take precedence
+	own := a2a.Preferences{Locale: "en-US", Units: a2a.UnitSystemImperial}
+	a2a.SetPreferences(&msg, own)
+	if _, err := client.SendMessage(ctx, a2a.MessageSendParams{Message: msg}); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	if got, _ := a2a.PreferencesOf(&sent[1]); got != own {
+		t.Fatalf("sent preferences = %+v, want %+v", got, own)
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2acrypto"
+)
+
+// PushTokenHeader is the HTTP header agents use for passing PushConfig.Token with notifications.
+const PushTokenHeader = "X-A2A-Notification-Token"
+
+// PushNonceHeader and PushTimestampHeader are the HTTP headers agents use for passing a unique
+// notification nonce and the sending time in seconds since Unix epoch.
+const (
+	PushNonceHeader     = "X-A2A-Notification-Nonce"
+	PushTimestampHeader = "X-A2A-Notification-Timestamp"
+)
+
+// defaultPushReceiverMaxTasks is the number of Tasks PushReceiver tracks ordering state for
+// if PushReceiver.MaxTasks is not set.
+const defaultPushReceiverMaxTasks = 10000
+
+// TaskGetter is used by PushReceiver for fetching the authoritative Task state. Client implements it.
+type TaskGetter interface {
+	GetTask(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error)
+}
+
+// PushReceiver is an http.Handler for consuming push notifications sent by agents.
+// Webhooks can be delivered more than once and out of order, so PushReceiver drops notifications
+// with a (TaskID, State, Timestamp) combination which was already handled and notifications
+// with a status timestamp older than the last handled one for the same Task.
+type PushReceiver struct {
+	// Handler is invoked for every accepted notification.
+	Handler func(ctx context.Context, task *a2a.Task) error
+	// Token is compared to the value of PushTokenHeader if not empty.
+	Token string
+	// Confirm is used for fetching the authoritative Task state before invoking Handler if not nil.
+	Confirm TaskGetter
+	// Replay is used for rejecting stale and replayed notifications based on the values of
+	// PushNonceHeader and PushTimestampHeader if not nil. The nonce of a notification which
+	// couldn't be decoded or handled is released, so that its redelivery is accepted.
+	Replay *a2acrypto.ReplayGuard
+	// MaxTasks is the number of Tasks ordering state is tracked for. The least recently notified
+	// Task is forgotten when the limit is exceeded. Defaults to 10000 if not positive.
+	MaxTasks int
+
+	mu     sync.Mutex
+	lru    *list.List
+	latest map[a2a.TaskID]*list.Element
+}
+
+type receivedStatus struct {
+	taskID a2a.TaskID
+	status a2a.TaskStatus
+}
+
+func (r *PushReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Token != "" && req.Header.Get(PushTokenHeader) != r.Token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.Replay != nil {
+		if err := r.checkReplay(req); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	if status, err := r.serve(req); err != nil {
+		if r.Replay != nil {
+			// the sender retries with the same nonce
+			_ = r.Replay.Release(req.Context(), req.Header.Get(PushNonceHeader))
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// serve decodes and receives the notification. Returns the response status for the error if it fails.
+func (r *PushReceiver) serve(req *http.Request) (int, error) {
+	var task a2a.Task
+	if err := json.NewDecoder(req.Body).Decode(&task); err != nil {
+		return http.StatusBadRequest, err
+	}
+	if err := r.Receive(req.Context(), &task); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+func (r *PushReceiver) checkReplay(req *http.Request) error {
+	var timestamp time.Time
+	if value := req.Header.Get(PushTimestampHeader); value != "" {
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("malformed %s: %w", PushTimestampHeader, err)
+		}
+		timestamp = time.Unix(seconds, 0)
+	}
+	return r.Replay.Check(req.Context(), req.Header.Get(PushNonceHeader), timestamp)
+}
+
+// Receive applies deduplication and ordering rules to a decoded notification and invokes Handler
+// if the notification was accepted. A notification is not recorded as handled if Confirm or Handler
+// fail, so that its redelivery is accepted.
+func (r *PushReceiver) Receive(ctx context.Context, task *a2a.Task) error {
+	received, prev, ok := r.accept(task)
+	if !ok {
+		return nil
+	}
+
+	if err := r.handle(ctx, task); err != nil {
+		r.forget(received, prev)
+		return err
+	}
+	return nil
+}
+
+func (r *PushReceiver) handle(ctx context.Context, task *a2a.Task) error {
+	if r.Confirm != nil {
+		confirmed, err := r.Confirm.GetTask(ctx, a2a.TaskQueryParams{ID: task.ID})
+		if err != nil {
+			return err
+		}
+		task = confirmed
+	}
+
+	if r.Handler == nil {
+		return nil
+	}
+	return r.Handler(ctx, task)
+}
+
+// accept records the notification status as the latest one for the Task and returns it together
+// with the previously recorded status, if there was one.
+func (r *PushReceiver) accept(task *a2a.Task) (*receivedStatus, *a2a.TaskStatus, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.latest == nil {
+		r.latest = make(map[a2a.TaskID]*list.Element)
+		r.lru = list.New()
+	}
+
+	status := task.Status
+	var prev *a2a.TaskStatus
+	if elem, ok := r.latest[task.ID]; ok {
+		last := elem.Value.(*receivedStatus).status
+		if status.Timestamp != nil && last.Timestamp != nil {
+			if status.Timestamp.Before(*last.Timestamp) {
+				return nil, nil, false
+			}
+			if status.Timestamp.Equal(*last.Timestamp) && status.State == last.State {
+				return nil, nil, false
+			}
+		}
+		prev = &last
+	}
+
+	received := &receivedStatus{
+		taskID: task.ID,
+		status: a2a.TaskStatus{State: status.State, Timestamp: copyTime(status.Timestamp)},
+	}
+	r.put(received)
+	return received, prev, true
+}
+
+// forget allows a notification to be redelivered if it couldn't be handled by restoring the status
+// recorded before it. Nothing is changed if a different notification was recorded in the meantime.
+func (r *PushReceiver) forget(received *receivedStatus, prev *a2a.TaskStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	elem, ok := r.latest[received.taskID]
+	if !ok || elem.Value != received {
+		return
+	}
+	if prev == nil {
+		r.lru.Remove(elem)
+		delete(r.latest, received.taskID)
+		return
+	}
+	elem.Value = &receivedStatus{taskID: received.taskID, status: *prev}
+}
+
+// put must be called with mu held.
+func (r *PushReceiver) put(received *receivedStatus) {
+	if elem, ok := r.latest[received.taskID]; ok {
+		elem.Value = received
+		r.lru.MoveToFront(elem)
+		return
+	}
+	r.latest[received.taskID] = r.lru.PushFront(received)
+
+	maxTasks := r.MaxTasks
+	if maxTasks <= 0 {
+		maxTasks = defaultPushReceiverMaxTasks
+	}
+	for r.lru.Len() > maxTasks {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.latest, oldest.Value.(*receivedStatus).taskID)
+	}
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
@@ -0,0 +1,303 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2acrypto"
+	"github.com/a2aproject/a2a-go/internal/push"
+)
+
+type taskGetterFn func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error)
+
+func (fn taskGetterFn) GetTask(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+	return fn(ctx, query)
+}
+
+func newPushTask(id a2a.TaskID, state a2a.TaskState, ts time.Time) *a2a.Task {
+	return &a2a.Task{ID: id, ContextID: "ctx", Status: a2a.TaskStatus{State: state, Timestamp: &ts}}
+}
+
+func TestPushReceiver_DedupeAndOrdering(t *testing.T) {
+	var handled []a2a.TaskState
+	receiver := &PushReceiver{
+		Handler: func(ctx context.Context, task *a2a.Task) error {
+			handled = append(handled, task.Status.State)
+			return nil
+		},
+	}
+
+	now := time.Now()
+	notifications := []*a2a.Task{
+		newPushTask("task-1", a2a.TaskStateSubmitted, now),
+		newPushTask("task-1", a2a.TaskStateSubmitted, now),                    // duplicate
+		newPushTask("task-1", a2a.TaskStateCompleted, now.Add(2*time.Second)), // reordered
+		newPushTask("task-1", a2a.TaskStateWorking, now.Add(time.Second)),     // stale
+		newPushTask("task-1", a2a.TaskStateCompleted, now.Add(2*time.Second)), // duplicate
+	}
+	for _, n := range notifications {
+		if err := receiver.Receive(t.Context(), n); err != nil {
+			t.Fatalf("Receive() error = %v", err)
+		}
+	}
+
+	want := []a2a.TaskState{a2a.TaskStateSubmitted, a2a.TaskStateCompleted}
+	if len(handled) != len(want) {
+		t.Fatalf("handled = %v, want %v", handled, want)
+	}
+	for i := range want {
+		if handled[i] != want[i] {
+			t.Fatalf("handled = %v, want %v", handled, want)
+		}
+	}
+}
+
+func TestPushReceiver_Confirm(t *testing.T) {
+	authoritative := newPushTask("task-1", a2a.TaskStateCompleted, time.Now())
+	var got *a2a.Task
+	receiver := &PushReceiver{
+		Confirm: taskGetterFn(func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			return authoritative, nil
+		}),
+		Handler: func(ctx context.Context, task *a2a.Task) error {
+			got = task
+			return nil
+		},
+	}
+
+	if err := receiver.Receive(t.Context(), newPushTask("task-1", a2a.TaskStateWorking, time.Now())); err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+	if got != authoritative {
+		t.Fatalf("Handler() got %v, want confirmed task %v", got, authoritative)
+	}
+}
+
+func TestPushReceiver_ConfirmFailureAllowsRedelivery(t *testing.T) {
+	confirmErr := errors.New("agent unavailable")
+	calls := 0
+	receiver := &PushReceiver{
+		Confirm: taskGetterFn(func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			calls++
+			if calls == 1 {
+				return nil, confirmErr
+			}
+			return &a2a.Task{ID: query.ID}, nil
+		}),
+	}
+
+	task := newPushTask("task-1", a2a.TaskStateWorking, time.Now())
+	if err := receiver.Receive(t.Context(), task); !errors.Is(err, confirmErr) {
+		t.Fatalf("Receive() error = %v, want %v", err, confirmErr)
+	}
+	if err := receiver.Receive(t.Context(), task); err != nil {
+		t.Fatalf("Receive() of redelivered notification error = %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("Confirm called %d times, want 2", calls)
+	}
+}
+
+func TestPushReceiver_HandlerFailureAllowsRedelivery(t *testing.T) {
+	handlerErr := errors.New("handler failed")
+	var handled []a2a.TaskState
+	fail := true
+	receiver := &PushReceiver{
+		Handler: func(ctx context.Context, task *a2a.Task) error {
+			if task.Status.State == a2a.TaskStateCompleted && fail {
+				fail = false
+				return handlerErr
+			}
+			handled = append(handled, task.Status.State)
+			return nil
+		},
+	}
+
+	now := time.Now()
+	if err := receiver.Receive(t.Context(), newPushTask("task-1", a2a.TaskStateWorking, now)); err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+	completed := newPushTask("task-1", a2a.TaskStateCompleted, now.Add(2*time.Second))
+	if err := receiver.Receive(t.Context(), completed); !errors.Is(err, handlerErr) {
+		t.Fatalf("Receive() error = %v, want %v", err, handlerErr)
+	}
+	// Ordering state of the previously handled notification must be kept.
+	if err := receiver.Receive(t.Context(), newPushTask("task-1", a2a.TaskStateWorking, now)); err != nil {
+		t.Fatalf("Receive() of duplicate error = %v", err)
+	}
+	if err := receiver.Receive(t.Context(), completed); err != nil {
+		t.Fatalf("Receive() of redelivered notification error = %v", err)
+	}
+
+	want := []a2a.TaskState{a2a.TaskStateWorking, a2a.TaskStateCompleted}
+	if len(handled) != len(want) || handled[0] != want[0] || handled[1] != want[1] {
+		t.Fatalf("handled = %v, want %v", handled, want)
+	}
+}
+
+func TestPushReceiver_MaxTasks(t *testing.T) {
+	handled := 0
+	receiver := &PushReceiver{
+		MaxTasks: 2,
+		Handler: func(ctx context.Context, task *a2a.Task) error {
+			handled++
+			return nil
+		},
+	}
+
+	now := time.Now()
+	for _, id := range []a2a.TaskID{"task-1", "task-2", "task-3"} {
+		if err := receiver.Receive(t.Context(), newPushTask(id, a2a.TaskStateWorking, now)); err != nil {
+			t.Fatalf("Receive() error = %v", err)
+		}
+	}
+	if len(receiver.latest) != 2 {
+		t.Fatalf("tracked %d tasks, want 2", len(receiver.latest))
+	}
+	// task-1 was evicted, so its duplicate is handled again while task-3 is still deduplicated.
+	for _, id := range []a2a.TaskID{"task-1", "task-3"} {
+		if err := receiver.Receive(t.Context(), newPushTask(id, a2a.TaskStateWorking, now)); err != nil {
+			t.Fatalf("Receive() error = %v", err)
+		}
+	}
+	if handled != 4 {
+		t.Fatalf("handled %d notifications, want 4", handled)
+	}
+}
+
+func TestPushReceiver_ServeHTTP(t *testing.T) {
+	handled := 0
+	receiver := &PushReceiver{
+		Token: "secret",
+		Handler: func(ctx context.Context, task *a2a.Task) error {
+			handled++
+			return nil
+		},
+	}
+	body, err := json.Marshal(newPushTask("task-1", a2a.TaskStateWorking, time.Now()))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	testCases := []struct {
+		name   string
+		method string
+		token  string
+		body   []byte
+		want   int
+	}{
+		{name: "accepted", method: http.MethodPost, token: "secret", body: body, want: http.StatusOK},
+		{name: "wrong method", method: http.MethodGet, token: "secret", body: body, want: http.StatusMethodNotAllowed},
+		{name: "wrong token", method: http.MethodPost, token: "wrong", body: body, want: http.StatusUnauthorized},
+		{name: "malformed body", method: http.MethodPost, token: "secret", body: []byte("{"), want: http.StatusBadRequest},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/push", bytes.NewReader(tc.body))
+			req.Header.Set(PushTokenHeader, tc.token)
+			rec := httptest.NewRecorder()
+			receiver.ServeHTTP(rec, req)
+			if rec.Code != tc.want {
+				t.Fatalf("ServeHTTP() status = %d, want %d", rec.Code, tc.want)
+			}
+		})
+	}
+	if handled != 1 {
+		t.Fatalf("Handler() called %d times, want 1", handled)
+	}
+}
+
+func TestPushReceiver_ReplayProtection(t *testing.T) {
+	handled := 0
+	receiver := &PushReceiver{
+		Replay: &a2acrypto.ReplayGuard{Cache: a2acrypto.NewMemNonceCache(), Window: time.Minute},
+		Handler: func(ctx context.Context, task *a2a.Task) error {
+			handled++
+			return nil
+		},
+	}
+	now := time.Now()
+	nowHeader := strconv.FormatInt(now.Unix(), 10)
+
+	testCases := []struct {
+		name      string
+		nonce     string
+		timestamp string
+		want      int
+	}{
+		{name: "accepted", nonce: "n1", timestamp: nowHeader, want: http.StatusOK},
+		{name: "replayed", nonce: "n1", timestamp: nowHeader, want: http.StatusUnauthorized},
+		{name: "stale", nonce: "n2", timestamp: strconv.FormatInt(now.Add(-time.Hour).Unix(), 10), want: http.StatusUnauthorized},
+		{name: "missing nonce", timestamp: nowHeader, want: http.StatusUnauthorized},
+		{name: "malformed timestamp", nonce: "n3", timestamp: "yesterday", want: http.StatusUnauthorized},
+	}
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// every notification has a new status so that it isn't deduplicated
+			task := newPushTask("task-1", a2a.TaskStateWorking, now.Add(time.Duration(i)*time.Second))
+			body, err := json.Marshal(task)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/push", bytes.NewReader(body))
+			req.Header.Set(PushNonceHeader, tc.nonce)
+			req.Header.Set(PushTimestampHeader, tc.timestamp)
+			rec := httptest.NewRecorder()
+			receiver.ServeHTTP(rec, req)
+			if rec.Code != tc.want {
+				t.Fatalf("ServeHTTP() status = %d, want %d", rec.Code, tc.want)
+			}
+		})
+	}
+	if handled != 1 {
+		t.Fatalf("Handler() called %d times, want 1", handled)
+	}
+}
+
+func TestPushReceiver_RedeliveryAfterHandlerFailure(t *testing.T) {
+	calls := 0
+	receiver := &PushReceiver{
+		Replay: &a2acrypto.ReplayGuard{Cache: a2acrypto.NewMemNonceCache(), Window: time.Minute},
+		Handler: func(ctx context.Context, task *a2a.Task) error {
+			calls++
+			if calls == 1 {
+				return errors.New("database unavailable")
+			}
+			return nil
+		},
+	}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	// every attempt of a delivery carries the same nonce
+	sender := &push.HTTPPushSender{Client: server.Client(), Backoff: time.Millisecond}
+	task := newPushTask("task-1", a2a.TaskStateCompleted, time.Now())
+	if err := sender.Send(t.Context(), a2a.PushConfig{URL: server.URL}, *task); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("Handler() called %d times, want 2", calls)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrRawCallUnsupported is returned by Client raw method calls when the Transport doesn't implement RawTransport.
+var ErrRawCallUnsupported = errors.New("transport doesn't support raw method calls")
+
+// RawTransport can be implemented by a Transport to allow invoking protocol methods which are not
+// modeled by the typed API, eg. vendor-specific methods or methods from a newer protocol version.
+type RawTransport interface {
+	// Call invokes the method with params and decodes the result into result, which must be a pointer
+	// or nil if the result should be discarded.
+	Call(ctx context.Context, method string, params any, result any) error
+
+	// CallStreaming invokes the streaming method with params and yields every received payload undecoded.
+	CallStreaming(ctx context.Context, method string, params any) iter.Seq2[json.RawMessage, error]
+}
+
+// RawRequest is the Request payload CallInterceptors observe for raw method calls.
+type RawRequest struct {
+	Method string
+	Params any
+}
+
+// Call invokes the protocol method using RawTransport and decodes the result into result.
+// CallInterceptors are applied with RawRequest payload and see the method as CallContext.Method.
+// The Response payload is result.
+func (c *Client) Call(ctx context.Context, method string, params any, result any) error {
+	raw, ok := c.transport.(RawTransport)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrRawCallUnsupported, method)
+	}
+	_, err := doCall(ctx, c, method, RawRequest{Method: method, Params: params}, func(ctx context.Context, req RawRequest) (any, error) {
+		if err := raw.Call(ctx, req.Method, req.Params, result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+	return err
+}
+
+// CallStreaming invokes the streaming protocol method using RawTransport and yields the received payloads.
+// CallInterceptors are applied like for other streaming methods, with RawRequest payload.
+func (c *Client) CallStreaming(ctx context.Context, method string, params any) iter.Seq2[json.RawMessage, error] {
+	raw, ok := c.transport.(RawTransport)
+	if !ok {
+		return func(yield func(json.RawMessage, error) bool) {
+			yield(nil, fmt.Errorf("%w: %s", ErrRawCallUnsupported, method))
+		}
+	}
+	return doStreamingCall(ctx, c, method, RawRequest{Method: method, Params: params}, func(ctx context.Context, req RawRequest) iter.Seq2[json.RawMessage, error] {
+		return raw.CallStreaming(ctx, req.Method, req.Params)
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+)
+
+type rawMockTransport struct {
+	mockTransport
+	method string
+	meta   CallMeta
+}
+
+func (m *rawMockTransport) Call(ctx context.Context, method string, params any, result any) error {
+	m.method = method
+	m.meta, _ = CallMetaFrom(ctx)
+	b, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, result)
+}
+
+func (m *rawMockTransport) CallStreaming(ctx context.Context, method string, params any) iter.Seq2[json.RawMessage, error] {
+	m.method = method
+	return func(yield func(json.RawMessage, error) bool) {
+		for _, payload := range []string{`{"n":1}`, `{"n":2}`} {
+			if !yield(json.RawMessage(payload), nil) {
+				return
+			}
+		}
+	}
+}
+
+type rawRequestInterceptor struct {
+	PassthroughInterceptor
+	methods []string
+}
+
+func (i *rawRequestInterceptor) Before(ctx context.Context, req *Request) (context.Context, error) {
+	callCtx, _ := CallContextFrom(ctx)
+	if raw, ok := req.Payload.(RawRequest); ok && raw.Method == callCtx.Method {
+		i.methods = append(i.methods, raw.Method)
+	}
+	req.Meta["x-vendor"] = "1"
+	return ctx, nil
+}
+
+func TestClient_Call(t *testing.T) {
+	transport := &rawMockTransport{}
+	interceptor := &rawRequestInterceptor{}
+	client := &Client{transport: transport, interceptors: []CallInterceptor{interceptor}}
+
+	var result struct {
+		Echo string `json:"echo"`
+	}
+	if err := client.Call(t.Context(), "vendor/echo", map[string]string{"echo": "hi"}, &result); err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if result.Echo != "hi" {
+		t.Fatalf("Call() result = %+v, want echo", result)
+	}
+	if transport.method != "vendor/echo" || transport.meta["x-vendor"] != "1" {
+		t.Fatalf("Call() transport got method %q with meta %v, want vendor/echo with interceptor meta", transport.method, transport.meta)
+	}
+
+	var got []string
+	for payload, err := range client.CallStreaming(t.Context(), "vendor/watch", nil) {
+		if err != nil {
+			t.Fatalf("CallStreaming() error = %v", err)
+		}
+		got = append(got, string(payload))
+	}
+	if want := []string{`{"n":1}`, `{"n":2}`}; !slices.Equal(got, want) {
+		t.Fatalf("CallStreaming() = %v, want %v", got, want)
+	}
+	if want := []string{"vendor/echo", "vendor/watch"}; !slices.Equal(interceptor.methods, want) {
+		t.Fatalf("interceptors saw raw requests %v, want %v", interceptor.methods, want)
+	}
+}
+
+func TestClient_Call_Unsupported(t *testing.T) {
+	client := &Client{transport: &mockTransport{}}
+
+	if err := client.Call(t.Context(), "vendor/echo", nil, nil); !errors.Is(err, ErrRawCallUnsupported) {
+		t.Fatalf("Call() error = %v, want %v", err, ErrRawCallUnsupported)
+	}
+	for _, err := range client.CallStreaming(t.Context(), "vendor/watch", nil) {
+		if !errors.Is(err, ErrRawCallUnsupported) {
+			t.Fatalf("CallStreaming() error = %v, want %v", err, ErrRawCallUnsupported)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// RejectedError is returned together with the Task by SendMessage when the agent rejected the message
+// and described the reason under a2a.RejectionMetaKey. Rejections because of unsupported content types
+// match a2a.ErrUnsupportedContentType with errors.Is.
+type RejectedError struct {
+	// Task is the Task in TaskStateRejected.
+	Task *a2a.Task
+	// Rejection describes why the message was rejected.
+	Rejection a2a.Rejection
+}
+
+func (e *RejectedError) Error() string {
+	if e.Rejection.Reason == a2a.RejectionReasonUnsupportedContentType {
+		return fmt.Sprintf("task %s rejected: unsupported content types %s, accepted: %s", e.Task.ID,
+			strings.Join(e.Rejection.UnsupportedTypes, ", "), strings.Join(e.Rejection.AcceptedInputModes, ", "))
+	}
+	return fmt.Sprintf("task %s rejected: %s", e.Task.ID, e.Rejection.Reason)
+}
+
+func (e *RejectedError) Unwrap() error {
+	if e.Rejection.Reason == a2a.RejectionReasonUnsupportedContentType {
+		return a2a.ErrUnsupportedContentType
+	}
+	return nil
+}
+
+// RejectionOf returns the machine-readable rejection of a Task. The second return value is false if
+// the Task is not in TaskStateRejected or the agent didn't describe the rejection.
+func RejectionOf(task *a2a.Task) (a2a.Rejection, bool) {
+	if task.Status.State != a2a.TaskStateRejected || task.Status.Message == nil {
+		return a2a.Rejection{}, false
+	}
+	return a2a.RejectionFromMeta(task.Status.Message.Metadata)
+}
+
+// rejectedErrorOf returns RejectedError if the result is a Task with a described rejection.
+func rejectedErrorOf(result a2a.SendMessageResult) error {
+	task, ok := result.(*a2a.Task)
+	if !ok || task == nil {
+		return nil
+	}
+	if rejection, ok := RejectionOf(task); ok {
+		return &RejectedError{Task: task, Rejection: rejection}
+	}
+	return nil
+}
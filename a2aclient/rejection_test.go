@@ -0,0 +1,58 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestClient_SendMessageRejected(t *testing.T) {
+	rejection := a2a.Rejection{
+		Reason:             a2a.RejectionReasonUnsupportedContentType,
+		UnsupportedTypes:   []string{"audio/wav"},
+		AcceptedInputModes: []string{"text/plain"},
+	}
+	var withDetails bool
+	client := &Client{transport: &mockTransport{
+		SendMessageFunc: func(ctx context.Context, params a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+			task := &a2a.Task{ID: "task-1", Status: a2a.TaskStatus{State: a2a.TaskStateRejected}}
+			task.Status.Message = a2a.NewMessageForTask(a2a.MessageRoleAgent, *task, a2a.TextPart{Text: "rejected"})
+			if withDetails {
+				task.Status.Message.Metadata = map[string]any{a2a.RejectionMetaKey: a2a.RejectionToMeta(rejection)}
+			}
+			return task, nil
+		},
+	}}
+	params := a2a.MessageSendParams{Message: *a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hi"})}
+
+	result, err := client.SendMessage(t.Context(), params)
+	if err != nil || result.(*a2a.Task).Status.State != a2a.TaskStateRejected {
+		t.Fatalf("SendMessage() = %v, %v, want rejected task without an error", result, err)
+	}
+
+	withDetails = true
+	result, err = client.SendMessage(t.Context(), params)
+	var rejected *RejectedError
+	if !errors.As(err, &rejected) || !errors.Is(err, a2a.ErrUnsupportedContentType) {
+		t.Fatalf("SendMessage() error = %v, want RejectedError for unsupported content", err)
+	}
+	if rejected.Task != result || rejected.Rejection.AcceptedInputModes[0] != "text/plain" {
+		t.Fatalf("RejectedError = %+v, want the returned task and the rejection", rejected)
+	}
+}
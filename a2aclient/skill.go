@@ -0,0 +1,57 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// InvokeSkill sends a user message invoking the skill with the provided input, which is converted to a message
+// part by a2a.NewSkillMessage. Structs and maps are sent as a DataPart the agent can decode with
+// a2a.DecodeSkillInput.
+func (c *Client) InvokeSkill(ctx context.Context, skillID string, input any) (a2a.SendMessageResult, error) {
+	msg, err := a2a.NewSkillMessage(skillID, input)
+	if err != nil {
+		return nil, err
+	}
+	return c.SendMessage(ctx, a2a.MessageSendParams{Message: *msg})
+}
+
+// SkillInputValidator is a CallInterceptor which validates DataParts of sent messages against
+// the InputSchema of the skill referenced by a2a.SkillIDMetaKey, so that malformed structured
+// inputs are rejected with an *a2a.SkillInputError before reaching the agent. Messages which
+// don't reference a skill are validated against the only skill of the card, if there is one.
+type SkillInputValidator struct {
+	PassthroughInterceptor
+	// Card is the AgentCard of the agent messages are sent to.
+	Card *a2a.AgentCard
+}
+
+var _ CallInterceptor = (*SkillInputValidator)(nil)
+
+func (v *SkillInputValidator) Before(ctx context.Context, req *Request) (context.Context, error) {
+	params, ok := req.Payload.(a2a.MessageSendParams)
+	if !ok || v.Card == nil {
+		return ctx, nil
+	}
+	skillID, _ := params.Message.Metadata[a2a.SkillIDMetaKey].(string)
+	skill, ok := v.Card.FindSkill(skillID)
+	if !ok {
+		return ctx, nil
+	}
+	return ctx, skill.ValidateInput(&params.Message)
+}
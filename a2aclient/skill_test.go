@@ -0,0 +1,93 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestSkillInputValidator(t *testing.T) {
+	card := &a2a.AgentCard{Skills: []a2a.AgentSkill{
+		{ID: "chat"},
+		{ID: "flights", InputSchema: map[string]any{"type": "object", "required": []string{"from"}}},
+	}}
+	sent := 0
+	transport := &mockTransport{
+		SendMessageFunc: func(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+			sent++
+			return &a2a.Message{}, nil
+		},
+	}
+	client := &Client{transport: transport, interceptors: []CallInterceptor{&SkillInputValidator{Card: card}}}
+
+	send := func(skillID string, data map[string]any) error {
+		msg := a2a.Message{
+			Metadata: map[string]any{a2a.SkillIDMetaKey: skillID},
+			Parts:    a2a.ContentParts{a2a.DataPart{Data: data}},
+		}
+		_, err := client.SendMessage(t.Context(), a2a.MessageSendParams{Message: msg})
+		return err
+	}
+
+	if err := send("flights", map[string]any{"to": "SFO"}); !errors.Is(err, a2a.ErrInvalidSkillInput) {
+		t.Fatalf("SendMessage() error = %v, want %v", err, a2a.ErrInvalidSkillInput)
+	}
+	if err := send("flights", map[string]any{"from": "JFK"}); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	if err := send("chat", map[string]any{"to": "SFO"}); err != nil {
+		t.Fatalf("SendMessage() to skill without schema error = %v", err)
+	}
+	if sent != 2 {
+		t.Fatalf("sent %d messages, want 2", sent)
+	}
+}
+
+func TestClient_InvokeSkill(t *testing.T) {
+	type flightQuery struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+	var received a2a.Message
+	transport := &mockTransport{
+		SendMessageFunc: func(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+			received = message.Message
+			return &a2a.Message{}, nil
+		},
+	}
+	client := &Client{transport: transport}
+
+	if _, err := client.InvokeSkill(t.Context(), "flights", flightQuery{From: "JFK", To: "SFO"}); err != nil {
+		t.Fatalf("InvokeSkill() error = %v", err)
+	}
+	if got := a2a.SkillIDOf(&received); got != "flights" {
+		t.Fatalf("SkillIDOf() = %q, want flights", got)
+	}
+	var query flightQuery
+	if err := a2a.DecodeSkillInput(&received, &query); err != nil {
+		t.Fatalf("DecodeSkillInput() error = %v", err)
+	}
+	if query != (flightQuery{From: "JFK", To: "SFO"}) {
+		t.Fatalf("DecodeSkillInput() = %+v, want the sent input", query)
+	}
+
+	if _, err := client.InvokeSkill(t.Context(), "flights", []string{"JFK"}); err == nil {
+		t.Fatal("InvokeSkill() with a non-object input succeeded, want error")
+	}
+}
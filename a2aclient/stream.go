@@ -0,0 +1,126 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// Stream is a handle for a streaming call. Unlike a raw iterator it allows an application to
+// abandon a stream from any goroutine and to cancel the remote Task which produced it.
+// Events can only be iterated once.
+type Stream struct {
+	client *Client
+	events iter.Seq2[a2a.Event, error]
+	stop   context.CancelFunc
+
+	mu       sync.Mutex
+	tracker  taskTracker
+	canceled bool
+	err      error
+}
+
+// StreamMessage is like SendStreamingMessage, but returns a Stream handle.
+func (c *Client) StreamMessage(ctx context.Context, message a2a.MessageSendParams) *Stream {
+	ctx, stop := context.WithCancel(ctx)
+	return &Stream{client: c, events: c.SendStreamingMessage(ctx, message), stop: stop}
+}
+
+// StreamResubscribe is like ResubscribeToTask, but returns a Stream handle.
+func (c *Client) StreamResubscribe(ctx context.Context, id a2a.TaskIDParams) *Stream {
+	ctx, stop := context.WithCancel(ctx)
+	return &Stream{client: c, events: c.ResubscribeToTask(ctx, id), stop: stop, tracker: taskTracker{taskID: id.ID}}
+}
+
+// Events returns the iterator over streamed events. Iteration stops after Cancel is called.
+// Breaking out of the iteration without calling Cancel applies Config.AbandonPolicy.
+func (s *Stream) Events() iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		defer s.stop()
+		for event, err := range s.events {
+			if s.isCanceled() {
+				// keep draining until the transport observes context cancelation, so that
+				// AbandonPolicy isn't applied on top of the Task cancelation
+				continue
+			}
+			if err != nil {
+				s.mu.Lock()
+				s.err = err
+				s.mu.Unlock()
+				yield(nil, err)
+				return
+			}
+			s.observe(event)
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+// TaskID returns the ID of the Task which produced the stream or an empty string if no Task
+// was referenced by events received so far.
+func (s *Stream) TaskID() a2a.TaskID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tracker.taskID
+}
+
+// Err returns the error which terminated the stream. Abandoning the stream with Cancel
+// is not considered an error.
+func (s *Stream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Cancel stops the stream and requests the agent to cancel the Task which produced it.
+// The remote call is skipped if the Task is unknown or has already reached a terminal state.
+// Cancel is safe to call concurrently with event iteration and more than once.
+func (s *Stream) Cancel(ctx context.Context) error {
+	s.mu.Lock()
+	alreadyCanceled := s.canceled
+	s.canceled = true
+	taskID, terminal := s.tracker.taskID, s.tracker.terminal
+	s.mu.Unlock()
+
+	s.stop()
+	if alreadyCanceled || taskID == "" || terminal {
+		return nil
+	}
+
+	_, err := s.client.CancelTask(ctx, a2a.TaskIDParams{ID: taskID})
+	if errors.Is(err, a2a.ErrTaskNotCancelable) {
+		return nil
+	}
+	return err
+}
+
+func (s *Stream) isCanceled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.canceled
+}
+
+func (s *Stream) observe(event a2a.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tracker.observe(event)
+}
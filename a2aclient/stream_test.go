@@ -0,0 +1,130 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// blockingStream emits the provided events and blocks until the context is canceled.
+func blockingStream(events ...a2a.Event) func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+		return func(yield func(a2a.Event, error) bool) {
+			for _, event := range events {
+				if !yield(event, nil) {
+					return
+				}
+			}
+			<-ctx.Done()
+			yield(nil, ctx.Err())
+		}
+	}
+}
+
+func TestStream_CancelWhileReading(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+	var canceled []a2a.TaskID
+	transport := &mockTransport{
+		StreamFunc: blockingStream(task),
+		CancelTaskFunc: func(ctx context.Context, id a2a.TaskIDParams) (*a2a.Task, error) {
+			canceled = append(canceled, id.ID)
+			return &a2a.Task{ID: id.ID, Status: a2a.TaskStatus{State: a2a.TaskStateCanceled}}, nil
+		},
+	}
+	client := &Client{transport: transport}
+
+	stream := client.StreamMessage(t.Context(), a2a.MessageSendParams{})
+	received := 0
+	cancelDone := make(chan struct{})
+	for _, err := range stream.Events() {
+		if err != nil {
+			t.Fatalf("Events() error = %v", err)
+		}
+		received++
+		go func() {
+			defer close(cancelDone)
+			if err := stream.Cancel(t.Context()); err != nil {
+				t.Errorf("Cancel() error = %v", err)
+			}
+		}()
+	}
+	<-cancelDone
+
+	if received != 1 {
+		t.Fatalf("Events() yielded %d events, want 1", received)
+	}
+	if stream.Err() != nil {
+		t.Fatalf("Err() = %v, want nil after Cancel", stream.Err())
+	}
+	if stream.TaskID() != task.ID {
+		t.Fatalf("TaskID() = %q, want %q", stream.TaskID(), task.ID)
+	}
+	if err := stream.Cancel(t.Context()); err != nil {
+		t.Fatalf("second Cancel() error = %v", err)
+	}
+	if len(canceled) != 1 || canceled[0] != task.ID {
+		t.Fatalf("CancelTask() called for %v, want [%s]", canceled, task.ID)
+	}
+}
+
+func TestStream_CancelSkipsRemoteCall(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	testCases := []struct {
+		name   string
+		events []a2a.Event
+	}{
+		{name: "no task", events: []a2a.Event{a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "hi"})}},
+		{name: "terminal task", events: []a2a.Event{task, a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil)}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transport := &mockTransport{
+				StreamFunc: func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+					return eventSeq(tc.events, nil)
+				},
+				CancelTaskFunc: func(ctx context.Context, id a2a.TaskIDParams) (*a2a.Task, error) {
+					t.Fatalf("unexpected CancelTask(%v)", id)
+					return nil, nil
+				},
+			}
+			stream := (&Client{transport: transport}).StreamMessage(t.Context(), a2a.MessageSendParams{})
+			for range stream.Events() {
+			}
+			if err := stream.Cancel(t.Context()); err != nil {
+				t.Fatalf("Cancel() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStream_Err(t *testing.T) {
+	streamErr := errors.New("connection reset")
+	transport := &mockTransport{
+		StreamFunc: func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+			return eventSeq([]a2a.Event{&a2a.Task{ID: "task-1"}}, streamErr)
+		},
+	}
+	stream := (&Client{transport: transport}).StreamMessage(t.Context(), a2a.MessageSendParams{})
+	for range stream.Events() {
+	}
+	if !errors.Is(stream.Err(), streamErr) {
+		t.Fatalf("Err() = %v, want %v", stream.Err(), streamErr)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"iter"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// SendTextStream sends a user message with a single text part and streams back the text
+// produced by the agent. It is a convenience for chat UIs rendering output incrementally.
+// See TextDeltas for how text is extracted from events.
+func (c *Client) SendTextStream(ctx context.Context, text string) iter.Seq2[string, error] {
+	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: text})
+	return TextDeltas(c.SendStreamingMessage(ctx, a2a.MessageSendParams{Message: *msg}))
+}
+
+// TextDeltas converts a stream of events into a stream of text chunks in the order they were produced.
+// Text is taken from the text parts of agent messages and artifact updates. Status update messages and
+// Task snapshots are skipped, because they describe progress or repeat content which was already streamed.
+// Iteration stops after the first error.
+func TextDeltas(events iter.Seq2[a2a.Event, error]) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for event, err := range events {
+			if err != nil {
+				yield("", err)
+				return
+			}
+
+			var parts a2a.ContentParts
+			switch v := event.(type) {
+			case *a2a.Message:
+				parts = v.Parts
+			case *a2a.TaskArtifactUpdateEvent:
+				if v.Artifact != nil {
+					parts = v.Artifact.Parts
+				}
+			}
+
+			for _, part := range parts {
+				text, ok := part.(a2a.TextPart)
+				if !ok || text.Text == "" {
+					continue
+				}
+				if !yield(text.Text, nil) {
+					return
+				}
+			}
+		}
+	}
+}
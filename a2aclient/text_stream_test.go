@@ -0,0 +1,113 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"reflect"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func eventSeq(events []a2a.Event, err error) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		for _, event := range events {
+			if !yield(event, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func TestTextDeltas(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	chunk := func(text string) a2a.Event {
+		return &a2a.TaskArtifactUpdateEvent{
+			TaskID:    task.ID,
+			ContextID: task.ContextID,
+			Append:    true,
+			Artifact:  &a2a.Artifact{ID: "answer", Parts: a2a.ContentParts{a2a.TextPart{Text: text}}},
+		}
+	}
+	events := []a2a.Event{
+		task,
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "thinking"})),
+		chunk("Hel"),
+		chunk("lo"),
+		&a2a.TaskArtifactUpdateEvent{Artifact: &a2a.Artifact{Parts: a2a.ContentParts{a2a.DataPart{Data: map[string]any{"k": "v"}}}}},
+		a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "!"}),
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil),
+	}
+
+	var got []string
+	for text, err := range TextDeltas(eventSeq(events, nil)) {
+		if err != nil {
+			t.Fatalf("TextDeltas() error = %v", err)
+		}
+		got = append(got, text)
+	}
+	want := []string{"Hel", "lo", "!"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("TextDeltas() = %v, want %v", got, want)
+	}
+}
+
+func TestTextDeltas_Error(t *testing.T) {
+	streamErr := errors.New("connection reset")
+	var got []string
+	var gotErr error
+	for text, err := range TextDeltas(eventSeq([]a2a.Event{a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "partial"})}, streamErr)) {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		got = append(got, text)
+	}
+	if !reflect.DeepEqual(got, []string{"partial"}) || !errors.Is(gotErr, streamErr) {
+		t.Fatalf("TextDeltas() = %v, %v, want [partial], %v", got, gotErr, streamErr)
+	}
+}
+
+func TestClient_SendTextStream(t *testing.T) {
+	var sent a2a.MessageSendParams
+	transport := &mockTransport{
+		StreamFunc: func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+			sent = message
+			return eventSeq([]a2a.Event{a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "pong"})}, nil)
+		},
+	}
+	client := &Client{transport: transport}
+
+	var got []string
+	for text, err := range client.SendTextStream(t.Context(), "ping") {
+		if err != nil {
+			t.Fatalf("SendTextStream() error = %v", err)
+		}
+		got = append(got, text)
+	}
+	if !reflect.DeepEqual(got, []string{"pong"}) {
+		t.Fatalf("SendTextStream() = %v, want [pong]", got)
+	}
+	wantParts := a2a.ContentParts{a2a.TextPart{Text: "ping"}}
+	if sent.Message.Role != a2a.MessageRoleUser || !reflect.DeepEqual(sent.Message.Parts, wantParts) {
+		t.Fatalf("SendTextStream() sent %+v, want a user message with %v", sent.Message, wantParts)
+	}
+}
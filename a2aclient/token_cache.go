@@ -0,0 +1,132 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"crypto/cipher"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrKeyringUnavailable is returned by SystemKeyring if the OS credential store can't be used,
+// eg. because the platform is not supported or the required tooling is not installed.
+var ErrKeyringUnavailable = errors.New("system keyring is unavailable")
+
+// TokenCache persists OAuth 2.0 tokens between process invocations, so that CLI tools don't
+// require an interactive authorization every time they are started. Load should return
+// an error wrapping ErrCredentialNotFound if no token is cached for the key.
+type TokenCache interface {
+	Load(ctx context.Context, key string) (*OAuth2Token, error)
+	Store(ctx context.Context, key string, token *OAuth2Token) error
+	Delete(ctx context.Context, key string) error
+}
+
+// NewTokenCache returns a TokenCache backed by the OS credential store returned by SystemKeyring
+// which stores tokens under the service name. If the OS credential store is unavailable, fallback is returned.
+// A FileTokenCache is the expected fallback, so that tokens are still encrypted at rest.
+func NewTokenCache(service string, fallback TokenCache) TokenCache {
+	keyring, err := SystemKeyring()
+	if err != nil {
+		return fallback
+	}
+	return &KeyringTokenCache{Keyring: keyring, Service: service}
+}
+
+// KeyringTokenCache implements TokenCache by storing JSON-encoded tokens in a Keyring.
+// Tokens are stored under the Service name with the cache key as the user.
+type KeyringTokenCache struct {
+	Keyring Keyring
+	Service string
+}
+
+func (c *KeyringTokenCache) Load(ctx context.Context, key string) (*OAuth2Token, error) {
+	secret, err := c.Keyring.Get(c.Service, key)
+	if err != nil {
+		return nil, err
+	}
+	var token OAuth2Token
+	if err := json.Unmarshal([]byte(secret), &token); err != nil {
+		return nil, fmt.Errorf("failed to decode cached token: %w", err)
+	}
+	return &token, nil
+}
+
+func (c *KeyringTokenCache) Store(ctx context.Context, key string, token *OAuth2Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	return c.Keyring.Set(c.Service, key, string(data))
+}
+
+func (c *KeyringTokenCache) Delete(ctx context.Context, key string) error {
+	return c.Keyring.Delete(c.Service, key)
+}
+
+// FileTokenCache implements TokenCache persisting tokens in a file encrypted with AES-GCM.
+type FileTokenCache struct {
+	mu   sync.Mutex
+	path string
+	aead cipher.AEAD
+}
+
+// NewFileTokenCache creates a FileTokenCache which uses the provided key for encryption.
+// The key must be 16, 24 or 32 bytes long to select AES-128, AES-192 or AES-256.
+// The file is created on the first Store call if it doesn't exist.
+func NewFileTokenCache(path string, key []byte) (*FileTokenCache, error) {
+	aead, err := newFileAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &FileTokenCache{path: path, aead: aead}, nil
+}
+
+func (c *FileTokenCache) Load(ctx context.Context, key string) (*OAuth2Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tokens := make(map[string]*OAuth2Token)
+	if err := readEncryptedFile(c.path, c.aead, &tokens); err != nil {
+		return nil, err
+	}
+	token, ok := tokens[key]
+	if !ok {
+		return nil, ErrCredentialNotFound
+	}
+	return token, nil
+}
+
+func (c *FileTokenCache) Store(ctx context.Context, key string, token *OAuth2Token) error {
+	return c.update(func(tokens map[string]*OAuth2Token) { tokens[key] = token })
+}
+
+func (c *FileTokenCache) Delete(ctx context.Context, key string) error {
+	return c.update(func(tokens map[string]*OAuth2Token) { delete(tokens, key) })
+}
+
+func (c *FileTokenCache) update(fn func(tokens map[string]*OAuth2Token)) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tokens := make(map[string]*OAuth2Token)
+	if err := readEncryptedFile(c.path, c.aead, &tokens); err != nil {
+		return err
+	}
+	fn(tokens)
+	return writeEncryptedFile(c.path, c.aead, tokens)
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestTokenCaches(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens")
+	fileCache, err := NewFileTokenCache(path, bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatalf("NewFileTokenCache() error = %v", err)
+	}
+
+	for name, cache := range map[string]TokenCache{
+		"file":    fileCache,
+		"keyring": &KeyringTokenCache{Keyring: mapKeyring{}, Service: "my-cli"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := t.Context()
+			if _, err := cache.Load(ctx, "key"); !errors.Is(err, ErrCredentialNotFound) {
+				t.Fatalf("Load() error = %v, want %v", err, ErrCredentialNotFound)
+			}
+			if err := cache.Store(ctx, "key", &OAuth2Token{AccessToken: "secret-token", RefreshToken: "refresh"}); err != nil {
+				t.Fatalf("Store() error = %v", err)
+			}
+			got, err := cache.Load(ctx, "key")
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got.AccessToken != "secret-token" || got.RefreshToken != "refresh" {
+				t.Fatalf("Load() = %+v, want stored token", got)
+			}
+			if err := cache.Delete(ctx, "key"); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if _, err := cache.Load(ctx, "key"); !errors.Is(err, ErrCredentialNotFound) {
+				t.Fatalf("Load() after Delete() error = %v, want %v", err, ErrCredentialNotFound)
+			}
+		})
+	}
+}
+
+func TestFileTokenCache_EncryptedAtRest(t *testing.T) {
+	ctx := t.Context()
+	path := filepath.Join(t.TempDir(), "tokens")
+	cache, err := NewFileTokenCache(path, bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatalf("NewFileTokenCache() error = %v", err)
+	}
+	if err := cache.Store(ctx, "key", &OAuth2Token{AccessToken: "secret-token"}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read token file: %v", err)
+	}
+	if bytes.Contains(data, []byte("secret-token")) {
+		t.Fatal("token file contains a plaintext token")
+	}
+
+	other, err := NewFileTokenCache(path, bytes.Repeat([]byte{2}, 32))
+	if err != nil {
+		t.Fatalf("NewFileTokenCache() error = %v", err)
+	}
+	if _, err := other.Load(ctx, "key"); err == nil || errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("Load() with wrong key error = %v, want decryption error", err)
+	}
+}
+
+func TestOAuth2Credentials_Cache(t *testing.T) {
+	ctx := t.Context()
+	authServer := newFakeAuthServer(t)
+	cache := &KeyringTokenCache{Keyring: mapKeyring{}, Service: "my-cli"}
+
+	first := &OAuth2Credentials{Flow: authServer.flow(), Scheme: "oauth2", Cache: cache}
+	token, err := first.Get(ctx, "session-1", "oauth2")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	// A later invocation reuses the cached token without an interactive authorization.
+	flow := authServer.flow()
+	flow.OpenURL = nil
+	second := &OAuth2Credentials{Flow: flow, Scheme: "oauth2", Cache: cache}
+	got, err := second.Get(ctx, "session-2", "oauth2")
+	if err != nil {
+		t.Fatalf("Get() with cached token error = %v", err)
+	}
+	if got != token || authServer.issued != 1 {
+		t.Fatalf("Get() = %q with %d tokens issued, want cached %q", got, authServer.issued, token)
+	}
+
+	if err := second.Refresh(ctx, "session-2", &AuthChallengeError{}); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	cached, err := cache.Load(ctx, second.cacheKey())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cached.AccessToken == string(token) {
+		t.Fatalf("cached token = %q, want the refreshed token", cached.AccessToken)
+	}
+}
+
+func TestOAuth2Credentials_CachedTokenAttachedToRequests(t *testing.T) {
+	var got string
+	agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(a2a.Task{ID: "task-1", ContextID: "ctx-1"})
+	}))
+	defer agent.Close()
+
+	// The token was cached in the keychain by an earlier invocation.
+	flow := newFakeAuthServer(t).flow()
+	flow.OpenURL = nil
+	creds := &OAuth2Credentials{Flow: flow, Scheme: "oauth2", Cache: &KeyringTokenCache{Keyring: mapKeyring{}, Service: "my-cli"}}
+	cached := &OAuth2Token{AccessToken: "cached-token", Expiry: time.Now().Add(time.Hour)}
+	if err := creds.Cache.Store(t.Context(), creds.cacheKey(), cached); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	card := &a2a.AgentCard{
+		URL:             agent.URL,
+		Security:        []a2a.SecurityRequirements{{"oauth2": {}}},
+		SecuritySchemes: a2a.NamedSecuritySchemes{"oauth2": a2a.OAuth2SecurityScheme{}},
+	}
+	client := &Client{transport: NewHTTPJSONTransport(agent.URL, agent.Client()), card: card}
+	client.AddCallInterceptor(AuthInterceptor{Service: creds})
+
+	if _, err := client.GetTask(WithSessionID(t.Context(), "session"), a2a.TaskQueryParams{ID: "task-1"}); err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if want := "Bearer cached-token"; got != want {
+		t.Fatalf("agent received Authorization %q, want %q", got, want)
+	}
+}
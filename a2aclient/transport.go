@@ -42,7 +42,7 @@ type Transport interface {
 	GetTaskPushConfig(ctx context.Context, params a2a.GetTaskPushConfigParams) (a2a.TaskPushConfig, error)
 
 	// ListTaskPushNotificationConfig calls the `tasks/pushNotificationConfig/list` protocol method.
-	ListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) ([]a2a.TaskPushConfig, error)
+	ListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) (*a2a.ListTaskPushConfigResult, error)
 
 	// SetTaskPushConfig calls the `tasks/pushNotificationConfig/set` protocol method.
 	SetTaskPushConfig(ctx context.Context, params a2a.TaskPushConfig) (a2a.TaskPushConfig, error)
@@ -52,6 +52,7 @@ type Transport interface {
 
 	// GetAgentCard resolves the AgentCard.
 	// If extended card is supported calls the 'agent/getAuthenticatedExtendedCard' protocol method.
+	// Otherwise the card the transport was created for is returned.
 	GetAgentCard(ctx context.Context) (*a2a.AgentCard, error)
 
 	// Clean up resources associated with the transport (eg. close a gRPC channel).
@@ -69,3 +70,9 @@ type TransportFactoryFn func(ctx context.Context, url string, card *a2a.AgentCar
 func (fn TransportFactoryFn) Create(ctx context.Context, url string, card *a2a.AgentCard) (Transport, error) {
 	return fn(ctx, url, card)
 }
+
+// supportsExtendedCard reports whether GetAgentCard needs to call the 'agent/getAuthenticatedExtendedCard'
+// protocol method. The method is called if the public card is unknown.
+func supportsExtendedCard(card *a2a.AgentCard) bool {
+	return card == nil || card.SupportsAuthenticatedExtendedCard
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// DefaultUploadChunkSize is the chunk size Uploader uses if none is configured.
+const DefaultUploadChunkSize = 4 << 20
+
+// maxChunkAttempts limits the number of times a chunk is sent before Uploader gives up.
+const maxChunkAttempts = 3
+
+// Uploader sends large files to an agent upload endpoint in chunks. The returned FilePart
+// references the assembled file and can be included in a Message instead of base64-encoded FileBytes.
+type Uploader struct {
+	// URL is the upload endpoint of the agent.
+	URL string
+	// ChunkSize is the maximum number of bytes sent in one request. DefaultUploadChunkSize is used if 0.
+	ChunkSize int
+	// Client is used for upload requests. http.DefaultClient is used if nil.
+	Client *http.Client
+}
+
+// Upload reads r until EOF and sends its content to the agent. Chunks which failed because of
+// network or server errors are resent after checking how much content the agent received.
+func (u *Uploader) Upload(ctx context.Context, r io.Reader, meta a2a.FileMeta) (a2a.FilePart, error) {
+	id, err := u.create(ctx)
+	if err != nil {
+		return a2a.FilePart{}, err
+	}
+
+	chunkSize := u.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultUploadChunkSize
+	}
+	buf := make([]byte, chunkSize)
+	var offset int64
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 {
+			if offset, err = u.sendChunk(ctx, id, offset, buf[:n]); err != nil {
+				return a2a.FilePart{}, err
+			}
+		}
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if readErr != nil {
+			return a2a.FilePart{}, fmt.Errorf("failed to read upload content: %w", readErr)
+		}
+	}
+
+	return a2a.FilePart{File: a2a.FileURI{FileMeta: meta, URI: a2a.UploadURI(id)}}, nil
+}
+
+func (u *Uploader) create(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := u.client().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create upload: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to create upload: unexpected status %s", resp.Status)
+	}
+	var body struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode upload: %w", err)
+	}
+	if body.ID == "" {
+		return "", fmt.Errorf("agent returned an empty upload id")
+	}
+	return body.ID, nil
+}
+
+// sendChunk sends a chunk starting at offset and returns the new offset.
+func (u *Uploader) sendChunk(ctx context.Context, id string, offset int64, chunk []byte) (int64, error) {
+	var lastErr error
+	for attempt := 0; attempt < maxChunkAttempts; attempt++ {
+		if attempt > 0 {
+			// the chunk might have been received even though the response was lost
+			received, err := u.offset(ctx, id)
+			if err != nil {
+				return 0, errors.Join(lastErr, err)
+			}
+			if received == offset+int64(len(chunk)) {
+				return received, nil
+			}
+			if received != offset {
+				return 0, fmt.Errorf("agent received %d bytes, expected %d: %w", received, offset, lastErr)
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPatch, u.uploadURL(id), bytes.NewReader(chunk))
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set(a2a.UploadOffsetHeader, strconv.FormatInt(offset, 10))
+		req.Header.Set("Content-Type", "application/octet-stream")
+
+		resp, err := u.client().Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+			lastErr = fmt.Errorf("failed to send upload chunk: %w", err)
+			continue
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		switch {
+		case resp.StatusCode < 300:
+			return offset + int64(len(chunk)), nil
+		case resp.StatusCode >= 500:
+			lastErr = fmt.Errorf("failed to send upload chunk: unexpected status %s", resp.Status)
+		default:
+			return 0, fmt.Errorf("failed to send upload chunk: unexpected status %s", resp.Status)
+		}
+	}
+	return 0, lastErr
+}
+
+func (u *Uploader) offset(ctx context.Context, id string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.uploadURL(id), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := u.client().Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get upload offset: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to get upload offset: unexpected status %s", resp.Status)
+	}
+	return strconv.ParseInt(resp.Header.Get(a2a.UploadOffsetHeader), 10, 64)
+}
+
+func (u *Uploader) uploadURL(id string) string {
+	result, err := url.JoinPath(u.URL, id)
+	if err != nil {
+		return u.URL + "/" + id
+	}
+	return result
+}
+
+func (u *Uploader) client() *http.Client {
+	if u.Client != nil {
+		return u.Client
+	}
+	return http.DefaultClient
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+func TestUploader_Upload(t *testing.T) {
+	store := a2asrv.NewUploadStore(t.TempDir(), 0)
+	uploads := a2asrv.NewUploadHandler(store)
+	var patches atomic.Int32
+	server := httptest.NewServer(http.StripPrefix("/uploads", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the second chunk is stored, but the response is lost
+		if r.Method == http.MethodPatch && patches.Add(1) == 2 {
+			uploads.ServeHTTP(httptest.NewRecorder(), r)
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		uploads.ServeHTTP(w, r)
+	})))
+	defer server.Close()
+
+	uploader := &Uploader{URL: server.URL + "/uploads", ChunkSize: 4, Client: server.Client()}
+	content := "a file larger than one chunk"
+	part, err := uploader.Upload(t.Context(), strings.NewReader(content), a2a.FileMeta{Name: "doc.txt", MimeType: "text/plain"})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	uri, ok := part.File.(a2a.FileURI)
+	if !ok || uri.Name != "doc.txt" || uri.MimeType != "text/plain" {
+		t.Fatalf("Upload() = %+v, want FileURI with file meta", part)
+	}
+	id, ok := a2a.UploadIDFromURI(uri.URI)
+	if !ok {
+		t.Fatalf("Upload() URI = %q, want an upload URI", uri.URI)
+	}
+	upload, err := store.Get(id)
+	if err != nil {
+		t.Fatalf("store.Get() error = %v", err)
+	}
+	got, err := os.ReadFile(upload.Path)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+	if string(got) != content {
+		t.Fatalf("uploaded content = %q, want %q", got, content)
+	}
+}
+
+func TestUploader_UploadRejected(t *testing.T) {
+	store := a2asrv.NewUploadStore(t.TempDir(), 5)
+	server := httptest.NewServer(http.StripPrefix("/uploads", a2asrv.NewUploadHandler(store)))
+	defer server.Close()
+
+	uploader := &Uploader{URL: server.URL + "/uploads", ChunkSize: 4, Client: server.Client()}
+	if _, err := uploader.Upload(t.Context(), strings.NewReader("too large"), a2a.FileMeta{}); err == nil {
+		t.Fatal("Upload() error = nil, want error for an upload over the limit")
+	}
+}
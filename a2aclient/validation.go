@@ -0,0 +1,217 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// ResponseValidator is an opt-in CallInterceptor which checks Tasks and Messages received from an agent
+// for protocol conformance, protecting applications from buggy remote agents. A violation replaces
+// the call result error with one matching a2a.ErrInvalidAgentResponse; the invalid payload is still returned.
+//
+// Interceptors don't observe individual streaming events, use ValidateEvents for streams.
+type ResponseValidator struct {
+	PassthroughInterceptor
+
+	// RequireTimestamps makes a task status without a timestamp invalid. Timestamps are optional in the protocol.
+	RequireTimestamps bool
+}
+
+var _ CallInterceptor = (*ResponseValidator)(nil)
+
+func (v *ResponseValidator) After(ctx context.Context, resp *Response) error {
+	if resp.Err != nil || resp.Payload == nil {
+		return nil
+	}
+	switch payload := resp.Payload.(type) {
+	case *a2a.Task:
+		resp.Err = v.ValidateTask(payload)
+	case *a2a.Message:
+		resp.Err = v.ValidateMessage(payload)
+	}
+	return nil
+}
+
+// ValidateTask checks that a Task has identifiers, a known state and valid messages and artifacts.
+func (v *ResponseValidator) ValidateTask(task *a2a.Task) error {
+	if task == nil {
+		return fmt.Errorf("%w: nil task", a2a.ErrInvalidAgentResponse)
+	}
+	if task.ID == "" {
+		return fmt.Errorf("%w: task id is empty", a2a.ErrInvalidAgentResponse)
+	}
+	if task.ContextID == "" {
+		return fmt.Errorf("%w: task %s context id is empty", a2a.ErrInvalidAgentResponse, task.ID)
+	}
+	if err := v.validateStatus(task.Status); err != nil {
+		return fmt.Errorf("task %s: %w", task.ID, err)
+	}
+	for _, msg := range task.History {
+		if err := v.ValidateMessage(msg); err != nil {
+			return fmt.Errorf("task %s history: %w", task.ID, err)
+		}
+	}
+	for _, artifact := range task.Artifacts {
+		if err := validateArtifact(artifact); err != nil {
+			return fmt.Errorf("task %s: %w", task.ID, err)
+		}
+	}
+	return nil
+}
+
+// ValidateMessage checks that a Message has an identifier, a known role and valid parts.
+func (v *ResponseValidator) ValidateMessage(msg *a2a.Message) error {
+	if msg == nil {
+		return fmt.Errorf("%w: nil message", a2a.ErrInvalidAgentResponse)
+	}
+	if msg.ID == "" {
+		return fmt.Errorf("%w: message id is empty", a2a.ErrInvalidAgentResponse)
+	}
+	if msg.Role != a2a.MessageRoleAgent && msg.Role != a2a.MessageRoleUser {
+		return fmt.Errorf("%w: message %s has unknown role %q", a2a.ErrInvalidAgentResponse, msg.ID, msg.Role)
+	}
+	for _, part := range msg.Parts {
+		if err := a2a.ValidatePart(part); err != nil {
+			return fmt.Errorf("%w: message %s: %v", a2a.ErrInvalidAgentResponse, msg.ID, err)
+		}
+	}
+	return nil
+}
+
+// ValidateEvent checks a single event received from a stream.
+func (v *ResponseValidator) ValidateEvent(event a2a.Event) error {
+	switch e := event.(type) {
+	case *a2a.Task:
+		return v.ValidateTask(e)
+	case *a2a.Message:
+		return v.ValidateMessage(e)
+	case *a2a.TaskStatusUpdateEvent:
+		if e.TaskID == "" || e.ContextID == "" {
+			return fmt.Errorf("%w: status update without task or context id", a2a.ErrInvalidAgentResponse)
+		}
+		if err := v.validateStatus(e.Status); err != nil {
+			return fmt.Errorf("task %s status update: %w", e.TaskID, err)
+		}
+		return nil
+	case *a2a.TaskArtifactUpdateEvent:
+		if e.TaskID == "" || e.ContextID == "" {
+			return fmt.Errorf("%w: artifact update without task or context id", a2a.ErrInvalidAgentResponse)
+		}
+		if err := validateArtifact(e.Artifact); err != nil {
+			return fmt.Errorf("task %s artifact update: %w", e.TaskID, err)
+		}
+		return nil
+	case nil:
+		return fmt.Errorf("%w: nil event", a2a.ErrInvalidAgentResponse)
+	default:
+		return nil
+	}
+}
+
+// ValidateEvents wraps an event stream and checks every event with the validator. Apart from
+// individual events the sequence is validated as well: all task events must refer to the same task
+// and no events are allowed after the task reached a terminal state. The stream stops after
+// the first violation, the invalid event is yielded together with the error.
+func (v *ResponseValidator) ValidateEvents(events iter.Seq2[a2a.Event, error]) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		var taskID a2a.TaskID
+		var terminal bool
+		for event, err := range events {
+			if err != nil {
+				if !yield(event, err) {
+					return
+				}
+				continue
+			}
+			if err := v.ValidateEvent(event); err != nil {
+				yield(event, err)
+				return
+			}
+			if err := checkEventSequence(event, &taskID, &terminal); err != nil {
+				yield(event, err)
+				return
+			}
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+func checkEventSequence(event a2a.Event, taskID *a2a.TaskID, terminal *bool) error {
+	var id a2a.TaskID
+	var state a2a.TaskState
+	switch e := event.(type) {
+	case *a2a.Task:
+		id, state = e.ID, e.Status.State
+	case *a2a.TaskStatusUpdateEvent:
+		id, state = e.TaskID, e.Status.State
+	case *a2a.TaskArtifactUpdateEvent:
+		id = e.TaskID
+	default:
+		return nil
+	}
+	if *terminal {
+		return fmt.Errorf("%w: event received after task %s reached a terminal state", a2a.ErrInvalidAgentResponse, *taskID)
+	}
+	if *taskID != "" && *taskID != id {
+		return fmt.Errorf("%w: event for task %s in a stream of task %s", a2a.ErrInvalidAgentResponse, id, *taskID)
+	}
+	*taskID = id
+	*terminal = state.Terminal()
+	return nil
+}
+
+func (v *ResponseValidator) validateStatus(status a2a.TaskStatus) error {
+	switch status.State {
+	case a2a.TaskStateSubmitted, a2a.TaskStateWorking, a2a.TaskStateInputRequired, a2a.TaskStateAuthRequired,
+		a2a.TaskStateCompleted, a2a.TaskStateCanceled, a2a.TaskStateFailed, a2a.TaskStateRejected:
+	default:
+		return fmt.Errorf("%w: illegal task state %q", a2a.ErrInvalidAgentResponse, status.State)
+	}
+	if status.Timestamp == nil {
+		if v.RequireTimestamps {
+			return fmt.Errorf("%w: status timestamp is missing", a2a.ErrInvalidAgentResponse)
+		}
+	} else if status.Timestamp.IsZero() {
+		return fmt.Errorf("%w: status timestamp is zero", a2a.ErrInvalidAgentResponse)
+	}
+	if status.Message != nil {
+		if err := v.ValidateMessage(status.Message); err != nil {
+			return fmt.Errorf("status: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateArtifact(artifact *a2a.Artifact) error {
+	if artifact == nil {
+		return fmt.Errorf("%w: nil artifact", a2a.ErrInvalidAgentResponse)
+	}
+	if artifact.ID == "" {
+		return fmt.Errorf("%w: artifact id is empty", a2a.ErrInvalidAgentResponse)
+	}
+	for _, part := range artifact.Parts {
+		if err := a2a.ValidatePart(part); err != nil {
+			return fmt.Errorf("%w: artifact %s: %v", a2a.ErrInvalidAgentResponse, artifact.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func validTask() *a2a.Task {
+	now := time.Now()
+	return &a2a.Task{
+		ID:        "task-1",
+		ContextID: "ctx-1",
+		Status:    a2a.TaskStatus{State: a2a.TaskStateWorking, Timestamp: &now},
+		History:   []*a2a.Message{a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hi"})},
+		Artifacts: []*a2a.Artifact{{ID: "artifact-1", Parts: a2a.ContentParts{a2a.TextPart{Text: "result"}}}},
+	}
+}
+
+func TestResponseValidator_ValidateTask(t *testing.T) {
+	t.Parallel()
+	var zero time.Time
+	testCases := []struct {
+		name              string
+		modify            func(*a2a.Task)
+		requireTimestamps bool
+		wantErr           bool
+	}{
+		{name: "valid", modify: func(*a2a.Task) {}},
+		{name: "missing id", modify: func(task *a2a.Task) { task.ID = "" }, wantErr: true},
+		{name: "missing context id", modify: func(task *a2a.Task) { task.ContextID = "" }, wantErr: true},
+		{name: "illegal state", modify: func(task *a2a.Task) { task.Status.State = "paused" }, wantErr: true},
+		{name: "unknown state", modify: func(task *a2a.Task) { task.Status.State = a2a.TaskStateUnknown }, wantErr: true},
+		{name: "zero timestamp", modify: func(task *a2a.Task) { task.Status.Timestamp = &zero }, wantErr: true},
+		{name: "missing timestamp", modify: func(task *a2a.Task) { task.Status.Timestamp = nil }},
+		{
+			name:              "missing required timestamp",
+			modify:            func(task *a2a.Task) { task.Status.Timestamp = nil },
+			requireTimestamps: true,
+			wantErr:           true,
+		},
+		{name: "history message without id", modify: func(task *a2a.Task) { task.History[0].ID = "" }, wantErr: true},
+		{name: "status message with unknown role", modify: func(task *a2a.Task) {
+			task.Status.Message = &a2a.Message{ID: "msg", Role: "robot"}
+		}, wantErr: true},
+		{name: "artifact without id", modify: func(task *a2a.Task) { task.Artifacts[0].ID = "" }, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			task := validTask()
+			tc.modify(task)
+			v := &ResponseValidator{RequireTimestamps: tc.requireTimestamps}
+			err := v.ValidateTask(task)
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("ValidateTask() error = %v, want error %v", err, tc.wantErr)
+			}
+			if err != nil && !errors.Is(err, a2a.ErrInvalidAgentResponse) {
+				t.Fatalf("ValidateTask() error = %v, want ErrInvalidAgentResponse", err)
+			}
+		})
+	}
+}
+
+func TestResponseValidator_Interceptor(t *testing.T) {
+	t.Parallel()
+	invalid := validTask()
+	invalid.ContextID = ""
+	transport := &mockTransport{
+		GetTaskFunc: func(ctx context.Context, query a2a.TaskQueryParams) (*a2a.Task, error) {
+			if query.ID == invalid.ID {
+				return invalid, nil
+			}
+			task := validTask()
+			task.ID = query.ID
+			return task, nil
+		},
+	}
+	client := &Client{transport: transport}
+	client.AddCallInterceptor(&ResponseValidator{})
+
+	if _, err := client.GetTask(t.Context(), a2a.TaskQueryParams{ID: "task-2"}); err != nil {
+		t.Fatalf("GetTask() error = %v for a valid task", err)
+	}
+	got, err := client.GetTask(t.Context(), a2a.TaskQueryParams{ID: invalid.ID})
+	if !errors.Is(err, a2a.ErrInvalidAgentResponse) {
+		t.Fatalf("GetTask() error = %v, want ErrInvalidAgentResponse", err)
+	}
+	if got != invalid {
+		t.Fatalf("GetTask() = %v, want the invalid task returned with the error", got)
+	}
+}
+
+func TestResponseValidator_ValidateEvents(t *testing.T) {
+	t.Parallel()
+	task := validTask()
+	working := a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil)
+	completed := a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil)
+	otherTask := validTask()
+	otherTask.ID = "task-2"
+	streamErr := errors.New("connection lost")
+
+	testCases := []struct {
+		name      string
+		events    []a2a.Event
+		err       error
+		wantCount int
+		wantErr   error
+	}{
+		{name: "valid", events: []a2a.Event{task, working, completed}, wantCount: 3},
+		{name: "message", events: []a2a.Event{a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "hi"})}, wantCount: 1},
+		{name: "stream error passed through", events: []a2a.Event{task}, err: streamErr, wantCount: 1, wantErr: streamErr},
+		{
+			name:      "invalid event",
+			events:    []a2a.Event{task, &a2a.TaskStatusUpdateEvent{TaskID: task.ID, ContextID: task.ContextID, Status: a2a.TaskStatus{State: "bogus"}}},
+			wantCount: 1,
+			wantErr:   a2a.ErrInvalidAgentResponse,
+		},
+		{name: "different task", events: []a2a.Event{task, otherTask}, wantCount: 1, wantErr: a2a.ErrInvalidAgentResponse},
+		{name: "event after terminal", events: []a2a.Event{task, completed, working}, wantCount: 2, wantErr: a2a.ErrInvalidAgentResponse},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			v := &ResponseValidator{}
+			count := 0
+			var gotErr error
+			for _, err := range v.ValidateEvents(eventSeq(tc.events, tc.err)) {
+				if err != nil {
+					gotErr = err
+					continue
+				}
+				count++
+			}
+			if count != tc.wantCount {
+				t.Fatalf("got %d valid events, want %d", count, tc.wantCount)
+			}
+			if !errors.Is(gotErr, tc.wantErr) {
+				t.Fatalf("ValidateEvents() error = %v, want %v", gotErr, tc.wantErr)
+			}
+		})
+	}
+}
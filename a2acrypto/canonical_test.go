@@ -0,0 +1,106 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2acrypto
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			// RFC 8785 section 3.2.2
+			name: "rfc example",
+			in: `{
+				"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+				"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+				"literals": [null, true, false]
+			}`,
+			want: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			// RFC 8785 section 3.2.3
+			name: "utf-16 key order",
+			in:   `{"\u20ac":"Euro Sign","\r":"Carriage Return","\ufb33":"Hebrew Letter Dalet With Dagesh","1":"One","\ud83d\ude00":"Emoji: Grinning Face","\u0080":"Control","\u00f6":"Latin Small Letter O With Diaeresis"}`,
+			want: "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"ö\":\"Latin Small Letter O With Diaeresis\",\"€\":\"Euro Sign\",\"😀\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		{
+			name: "numbers",
+			in:   `[0, -0, 1, -1.5, 100, 1e21, 1e20, 123456789012345678901234, 0.000001, 0.0000001, 5e-324, 1.7976931348623157e308]`,
+			want: `[0,0,1,-1.5,100,1e+21,100000000000000000000,1.2345678901234569e+23,0.000001,1e-7,5e-324,1.7976931348623157e+308]`,
+		},
+		{
+			name: "nested",
+			in:   `{"b": {"z": [], "a": {}}, "a": "<&>"}`,
+			want: `{"a":"<&>","b":{"a":{},"z":[]}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Canonicalize([]byte(tt.in))
+			if err != nil {
+				t.Fatalf("Canonicalize() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("Canonicalize() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalize_Invalid(t *testing.T) {
+	for _, in := range []string{`{"a":`, `{} {}`, `1e400`} {
+		if got, err := Canonicalize([]byte(in)); err == nil {
+			t.Errorf("Canonicalize(%s) = %s, want an error", in, got)
+		}
+	}
+}
+
+func TestCanonicalAgentCard(t *testing.T) {
+	card := &a2a.AgentCard{
+		Name:       "agent",
+		URL:        "https://agent.example.com",
+		Version:    "1.0",
+		Signatures: []a2a.AgentCardSignature{{Protected: "eyJhbGciOiJFUzI1NiJ9", Signature: "sig"}},
+	}
+	got, err := CanonicalAgentCard(card)
+	if err != nil {
+		t.Fatalf("CanonicalAgentCard() error = %v", err)
+	}
+	if strings.Contains(string(got), "signatures") {
+		t.Fatalf("CanonicalAgentCard() = %s, want signatures excluded", got)
+	}
+	if len(card.Signatures) != 1 {
+		t.Fatal("CanonicalAgentCard() modified the card")
+	}
+
+	input, err := CardSigningInput(card, card.Signatures[0])
+	if err != nil {
+		t.Fatalf("CardSigningInput() error = %v", err)
+	}
+	protected, payload, _ := strings.Cut(string(input), ".")
+	decoded, err := base64.RawURLEncoding.DecodeString(payload)
+	if protected != card.Signatures[0].Protected || err != nil || string(decoded) != string(got) {
+		t.Fatalf("CardSigningInput() = %s, want the protected header and the encoded canonical card", input)
+	}
+}
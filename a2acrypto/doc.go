@@ -0,0 +1,21 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package a2acrypto provides helpers for encrypting designated metadata entries of Messages and Tasks,
+// so that sensitive values can pass through intermediary task stores and queues without being readable.
+//
+// It also contains helpers for signed payloads: RFC 8785 canonical JSON serialization which AgentCard
+// signatures are computed over, signatures of individual Messages for deployments where TLS terminates
+// at intermediaries, replay protection based on nonces and timestamps and JWT validation tolerating clock skew.
+package a2acrypto
@@ -0,0 +1,177 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2acrypto
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// MessageSignatureExtensionURI identifies the extension for signing individual Messages. Signed messages
+// list the URI in Message.Extensions and carry the signature in the metadata under MessageSignatureMetaKey.
+const MessageSignatureExtensionURI = "https://github.com/a2aproject/a2a-go/extensions/message-signature/v1"
+
+// MessageSignatureMetaKey is the Message metadata key of the signature: a compact JWS with a detached
+// payload (RFC 7515 Appendix F) computed over the canonical message.
+const MessageSignatureMetaKey = "signature"
+
+// ErrMissingSignature is returned when a message which is required to be signed doesn't carry a signature.
+var ErrMissingSignature = errors.New("message is not signed")
+
+// CanonicalMessage returns the canonical JSON of the message which signatures are computed over.
+// The signature metadata entry is excluded.
+func CanonicalMessage(msg *a2a.Message) ([]byte, error) {
+	unsigned := *msg
+	if _, ok := msg.Metadata[MessageSignatureMetaKey]; ok {
+		unsigned.Metadata = maps.Clone(msg.Metadata)
+		delete(unsigned.Metadata, MessageSignatureMetaKey)
+	}
+	return CanonicalJSON(unsigned)
+}
+
+// MessageSigner signs Messages. Signing modifies the message, so it must happen after all other changes,
+// and intermediaries must not modify signed messages.
+type MessageSigner struct {
+	// Header are the values of the protected JWS header, eg. "kid". "alg" is required.
+	Header map[string]any
+	// Sign computes the signature over the signing input. Keys usually live in a KMS, so signing is
+	// left to the caller. Required.
+	Sign func(signingInput []byte) ([]byte, error)
+}
+
+// SignMessage adds MessageSignatureExtensionURI to the message extensions and stores the signature
+// in the message metadata. The metadata map is copied, so that it can be shared with other messages.
+func (s *MessageSigner) SignMessage(msg *a2a.Message) error {
+	if s.Sign == nil {
+		return fmt.Errorf("no signing function configured")
+	}
+	if _, ok := s.Header["alg"].(string); !ok {
+		return fmt.Errorf("signature header must declare the \"alg\"")
+	}
+	if !slices.Contains(msg.Extensions, MessageSignatureExtensionURI) {
+		msg.Extensions = append(slices.Clip(msg.Extensions), MessageSignatureExtensionURI)
+	}
+	header, err := json.Marshal(s.Header)
+	if err != nil {
+		return fmt.Errorf("failed to encode signature header: %w", err)
+	}
+	protected := base64.RawURLEncoding.EncodeToString(header)
+	input, err := messageSigningInput(msg, protected)
+	if err != nil {
+		return err
+	}
+	signature, err := s.Sign(input)
+	if err != nil {
+		return fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	meta := maps.Clone(msg.Metadata)
+	if meta == nil {
+		meta = map[string]any{}
+	}
+	meta[MessageSignatureMetaKey] = protected + ".." + base64.RawURLEncoding.EncodeToString(signature)
+	msg.Metadata = meta
+	return nil
+}
+
+// MessageVerifier verifies signatures of Messages signed with a MessageSigner.
+type MessageVerifier struct {
+	// Verify verifies the signature over the signing input using the key selected for the decoded protected
+	// header, eg. by "kid" and "alg". It has the same signature as JWTValidator.Verify, so the same key
+	// lookup can be used for both. Required.
+	Verify func(header map[string]any, signingInput, signature []byte) error
+}
+
+// VerifyMessage verifies the message signature and returns the decoded protected header. Errors match
+// ErrMissingSignature, ErrMalformedToken or ErrInvalidSignature.
+func (v *MessageVerifier) VerifyMessage(msg *a2a.Message) (map[string]any, error) {
+	value, ok := msg.Metadata[MessageSignatureMetaKey]
+	if !ok {
+		return nil, ErrMissingSignature
+	}
+	token, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: signature is not a string", ErrMalformedToken)
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[1] != "" {
+		return nil, fmt.Errorf("%w: expected a JWS with a detached payload", ErrMalformedToken)
+	}
+	var header map[string]any
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %w", ErrMalformedToken, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %w", ErrMalformedToken, err)
+	}
+	input, err := messageSigningInput(msg, parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedToken, err)
+	}
+	if v.Verify == nil {
+		return nil, fmt.Errorf("%w: no verifier configured", ErrInvalidSignature)
+	}
+	if err := v.Verify(header, input, signature); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	return header, nil
+}
+
+func messageSigningInput(msg *a2a.Message, protected string) ([]byte, error) {
+	payload, err := CanonicalMessage(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to canonicalize message: %w", err)
+	}
+	return []byte(protected + "." + base64.RawURLEncoding.EncodeToString(payload)), nil
+}
+
+// Ed25519MessageSigner creates a MessageSigner which signs messages with the key using the "EdDSA"
+// algorithm (RFC 8037). The kid is added to the protected header.
+func Ed25519MessageSigner(kid string, key ed25519.PrivateKey) *MessageSigner {
+	return &MessageSigner{
+		Header: map[string]any{"alg": "EdDSA", "kid": kid},
+		Sign: func(signingInput []byte) ([]byte, error) {
+			return ed25519.Sign(key, signingInput), nil
+		},
+	}
+}
+
+// VerifyEd25519 returns a verification function for MessageVerifier or JWTValidator which accepts
+// "EdDSA" signatures made with one of the keys, selected by the "kid" header.
+func VerifyEd25519(keys map[string]ed25519.PublicKey) func(header map[string]any, signingInput, signature []byte) error {
+	return func(header map[string]any, signingInput, signature []byte) error {
+		if alg, _ := header["alg"].(string); alg != "EdDSA" {
+			return fmt.Errorf("unsupported algorithm %q", header["alg"])
+		}
+		kid, _ := header["kid"].(string)
+		key, ok := keys[kid]
+		if !ok {
+			return fmt.Errorf("unknown key %q", kid)
+		}
+		if !ed25519.Verify(key, signingInput, signature) {
+			return fmt.Errorf("signature doesn't match key %q", kid)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2acrypto
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestMessageSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+	otherPub, _, _ := ed25519.GenerateKey(nil)
+	signer := Ed25519MessageSigner("key-1", priv)
+	verifier := &MessageVerifier{Verify: VerifyEd25519(map[string]ed25519.PublicKey{"key-1": pub})}
+
+	newSigned := func(t *testing.T) *a2a.Message {
+		t.Helper()
+		msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "transfer $100"}, a2a.DataPart{Data: map[string]any{"amount": 100}})
+		msg.Metadata = map[string]any{"trace": "abc"}
+		if err := signer.SignMessage(msg); err != nil {
+			t.Fatalf("SignMessage() error = %v", err)
+		}
+		// simulate a round trip over the wire
+		data, err := json.Marshal(msg)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		var decoded a2a.Message
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		return &decoded
+	}
+
+	testCases := []struct {
+		name     string
+		modify   func(msg *a2a.Message)
+		verifier *MessageVerifier
+		wantErr  error
+	}{
+		{name: "valid"},
+		{
+			name:    "tampered part",
+			modify:  func(msg *a2a.Message) { msg.Parts[0] = a2a.TextPart{Text: "transfer $1000"} },
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "tampered metadata",
+			modify:  func(msg *a2a.Message) { msg.Metadata["trace"] = "xyz" },
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:     "unknown key",
+			verifier: &MessageVerifier{Verify: VerifyEd25519(map[string]ed25519.PublicKey{"key-2": otherPub})},
+			wantErr:  ErrInvalidSignature,
+		},
+		{
+			name:    "missing",
+			modify:  func(msg *a2a.Message) { delete(msg.Metadata, MessageSignatureMetaKey) },
+			wantErr: ErrMissingSignature,
+		},
+		{
+			name:    "attached payload",
+			modify:  func(msg *a2a.Message) { msg.Metadata[MessageSignatureMetaKey] = "a.b.c" },
+			wantErr: ErrMalformedToken,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := newSigned(t)
+			if tc.modify != nil {
+				tc.modify(msg)
+			}
+			v := verifier
+			if tc.verifier != nil {
+				v = tc.verifier
+			}
+			header, err := v.VerifyMessage(msg)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("VerifyMessage() error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyMessage() error = %v", err)
+			}
+			if header["kid"] != "key-1" || len(msg.Extensions) != 1 || msg.Extensions[0] != MessageSignatureExtensionURI {
+				t.Fatalf("VerifyMessage() header = %v, extensions = %v, want key-1 and the signature extension", header, msg.Extensions)
+			}
+		})
+	}
+}
+
+func TestMessageSigner_KeepsOriginalMetadata(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(nil)
+	meta := map[string]any{"trace": "abc"}
+	msg := &a2a.Message{ID: "m1", Role: a2a.MessageRoleUser, Metadata: meta}
+	if err := Ed25519MessageSigner("key-1", priv).SignMessage(msg); err != nil {
+		t.Fatalf("SignMessage() error = %v", err)
+	}
+	if _, ok := meta[MessageSignatureMetaKey]; ok {
+		t.Fatal("SignMessage() modified the original metadata map")
+	}
+	if _, ok := msg.Metadata[MessageSignatureMetaKey]; !ok {
+		t.Fatal("SignMessage() didn't store the signature")
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2acrypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// EncryptedValueKey is the key of the object an encrypted metadata value gets replaced with.
+const EncryptedValueKey = "a2aEncrypted"
+
+// ErrNotEncrypted is returned when a value expected to be encrypted is stored in plaintext.
+var ErrNotEncrypted = errors.New("metadata value is not encrypted")
+
+// KeyProvider provides AES keys for metadata encryption. It is usually backed by a KMS.
+// Keys must be 16, 24 or 32 bytes long to select AES-128, AES-192 or AES-256.
+type KeyProvider interface {
+	// CurrentKey returns the key which should be used for encrypting new values together with its ID.
+	CurrentKey(ctx context.Context) (string, []byte, error)
+	// Key returns a key by the ID which was returned from CurrentKey.
+	Key(ctx context.Context, id string) ([]byte, error)
+}
+
+// StaticKeyProvider is a KeyProvider which uses keys known in advance.
+type StaticKeyProvider struct {
+	// CurrentID is the ID of the key used for encryption.
+	CurrentID string
+	// Keys are all the keys which can be used for decryption.
+	Keys map[string][]byte
+}
+
+func (p StaticKeyProvider) CurrentKey(ctx context.Context) (string, []byte, error) {
+	key, err := p.Key(ctx, p.CurrentID)
+	if err != nil {
+		return "", nil, err
+	}
+	return p.CurrentID, key, nil
+}
+
+func (p StaticKeyProvider) Key(ctx context.Context, id string) ([]byte, error) {
+	key, ok := p.Keys[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown key: %q", id)
+	}
+	return key, nil
+}
+
+// MetadataCipher encrypts and decrypts values stored under the designated metadata keys.
+// An encrypted value is replaced with an object holding the ID of the key and the ciphertext,
+// which survives JSON serialization. Other metadata entries are left untouched.
+type MetadataCipher struct {
+	// Keys provides encryption keys.
+	Keys KeyProvider
+	// Fields are the metadata keys which values get encrypted.
+	Fields []string
+}
+
+type encryptedValue struct {
+	KeyID string `json:"kid"`
+	Data  string `json:"data"`
+}
+
+// EncryptMetadata encrypts the designated entries in place. Values which are already encrypted are skipped.
+func (c *MetadataCipher) EncryptMetadata(ctx context.Context, meta map[string]any) error {
+	for _, field := range c.Fields {
+		value, ok := meta[field]
+		if !ok {
+			continue
+		}
+		if _, ok := parseEncrypted(value); ok {
+			continue
+		}
+		encrypted, err := c.encrypt(ctx, field, value)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt %q: %w", field, err)
+		}
+		meta[field] = encrypted
+	}
+	return nil
+}
+
+// DecryptMetadata decrypts the designated entries in place.
+// Returns ErrNotEncrypted if a designated entry is present in plaintext.
+func (c *MetadataCipher) DecryptMetadata(ctx context.Context, meta map[string]any) error {
+	for _, field := range c.Fields {
+		value, ok := meta[field]
+		if !ok {
+			continue
+		}
+		encrypted, ok := parseEncrypted(value)
+		if !ok {
+			return fmt.Errorf("failed to decrypt %q: %w", field, ErrNotEncrypted)
+		}
+		decrypted, err := c.decrypt(ctx, field, encrypted)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %q: %w", field, err)
+		}
+		meta[field] = decrypted
+	}
+	return nil
+}
+
+// EncryptMessage encrypts the designated entries of the Message metadata.
+func (c *MetadataCipher) EncryptMessage(ctx context.Context, msg *a2a.Message) error {
+	if msg == nil {
+		return nil
+	}
+	return c.EncryptMetadata(ctx, msg.Metadata)
+}
+
+// DecryptMessage decrypts the designated entries of the Message metadata.
+func (c *MetadataCipher) DecryptMessage(ctx context.Context, msg *a2a.Message) error {
+	if msg == nil {
+		return nil
+	}
+	return c.DecryptMetadata(ctx, msg.Metadata)
+}
+
+// EncryptTask encrypts the designated entries of the Task metadata, its status message and history.
+func (c *MetadataCipher) EncryptTask(ctx context.Context, task *a2a.Task) error {
+	return c.applyToTask(task, func(meta map[string]any) error { return c.EncryptMetadata(ctx, meta) })
+}
+
+// DecryptTask decrypts the designated entries of the Task metadata, its status message and history.
+func (c *MetadataCipher) DecryptTask(ctx context.Context, task *a2a.Task) error {
+	return c.applyToTask(task, func(meta map[string]any) error { return c.DecryptMetadata(ctx, meta) })
+}
+
+func (c *MetadataCipher) applyToTask(task *a2a.Task, fn func(map[string]any) error) error {
+	if task == nil {
+		return nil
+	}
+	if err := fn(task.Metadata); err != nil {
+		return err
+	}
+	if task.Status.Message != nil {
+		if err := fn(task.Status.Message.Metadata); err != nil {
+			return err
+		}
+	}
+	for _, msg := range task.History {
+		if msg == nil {
+			continue
+		}
+		if err := fn(msg.Metadata); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *MetadataCipher) encrypt(ctx context.Context, field string, value any) (map[string]any, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	keyID, key, err := c.Keys.CurrentKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	// field name is authenticated so that ciphertexts can't be swapped between metadata fields
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(field))
+	data := base64.StdEncoding.EncodeToString(sealed)
+	return map[string]any{EncryptedValueKey: map[string]any{"kid": keyID, "data": data}}, nil
+}
+
+func (c *MetadataCipher) decrypt(ctx context.Context, field string, value encryptedValue) (any, error) {
+	key, err := c.Keys.Key(ctx, value.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(value.Data)
+	if err != nil {
+		return nil, fmt.Errorf("malformed ciphertext: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("malformed ciphertext")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(field))
+	if err != nil {
+		return nil, err
+	}
+	var result any
+	if err := json.Unmarshal(plaintext, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func parseEncrypted(value any) (encryptedValue, bool) {
+	wrapper, ok := value.(map[string]any)
+	if !ok || len(wrapper) != 1 {
+		return encryptedValue{}, false
+	}
+	inner, ok := wrapper[EncryptedValueKey].(map[string]any)
+	if !ok {
+		return encryptedValue{}, false
+	}
+	keyID, ok := inner["kid"].(string)
+	if !ok {
+		return encryptedValue{}, false
+	}
+	data, ok := inner["data"].(string)
+	if !ok {
+		return encryptedValue{}, false
+	}
+	return encryptedValue{KeyID: keyID, Data: data}, true
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
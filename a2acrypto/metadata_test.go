@@ -0,0 +1,133 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2acrypto
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func newTestCipher() *MetadataCipher {
+	keys := StaticKeyProvider{
+		CurrentID: "k2",
+		Keys:      map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32), "k2": bytes.Repeat([]byte{2}, 32)},
+	}
+	return &MetadataCipher{Keys: keys, Fields: []string{"routing", "secret"}}
+}
+
+func TestMetadataCipher_RoundTrip(t *testing.T) {
+	ctx := t.Context()
+	c := newTestCipher()
+	routing := map[string]any{"tenant": "acme", "shard": 3.0}
+	task := &a2a.Task{
+		ID:       "task-1",
+		Metadata: map[string]any{"routing": routing, "public": "visible"},
+		Status: a2a.TaskStatus{
+			Message: &a2a.Message{Metadata: map[string]any{"secret": "s3cr3t"}},
+		},
+		History: []*a2a.Message{{Metadata: map[string]any{"secret": "older"}}, nil},
+	}
+
+	if err := c.EncryptTask(ctx, task); err != nil {
+		t.Fatalf("EncryptTask() error = %v", err)
+	}
+	// must survive a wire round-trip
+	data, err := json.Marshal(task)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if strings.Contains(string(data), "acme") || strings.Contains(string(data), "s3cr3t") {
+		t.Fatalf("encrypted task contains plaintext: %s", data)
+	}
+	if !strings.Contains(string(data), "visible") {
+		t.Fatalf("encrypted task lost plaintext fields: %s", data)
+	}
+	var decoded a2a.Task
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if err := c.DecryptTask(ctx, &decoded); err != nil {
+		t.Fatalf("DecryptTask() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Metadata["routing"], routing) {
+		t.Fatalf("decrypted routing = %v, want %v", decoded.Metadata["routing"], routing)
+	}
+	if got := decoded.Status.Message.Metadata["secret"]; got != "s3cr3t" {
+		t.Fatalf("decrypted status secret = %v, want s3cr3t", got)
+	}
+	if got := decoded.History[0].Metadata["secret"]; got != "older" {
+		t.Fatalf("decrypted history secret = %v, want older", got)
+	}
+}
+
+func TestMetadataCipher_KeyRotation(t *testing.T) {
+	ctx := t.Context()
+	c := newTestCipher()
+	meta := map[string]any{"secret": "value"}
+	if err := c.EncryptMetadata(ctx, meta); err != nil {
+		t.Fatalf("EncryptMetadata() error = %v", err)
+	}
+	encrypted := meta["secret"]
+
+	// encrypting twice is a no-op
+	if err := c.EncryptMetadata(ctx, meta); err != nil {
+		t.Fatalf("EncryptMetadata() error = %v", err)
+	}
+	if !reflect.DeepEqual(meta["secret"], encrypted) {
+		t.Fatalf("EncryptMetadata() re-encrypted an encrypted value")
+	}
+
+	keys := c.Keys.(StaticKeyProvider)
+	keys.CurrentID = "k1"
+	c.Keys = keys
+	if err := c.DecryptMetadata(ctx, meta); err != nil {
+		t.Fatalf("DecryptMetadata() after rotation error = %v", err)
+	}
+	if meta["secret"] != "value" {
+		t.Fatalf("decrypted secret = %v, want value", meta["secret"])
+	}
+}
+
+func TestMetadataCipher_DecryptFailures(t *testing.T) {
+	ctx := t.Context()
+	c := newTestCipher()
+
+	plain := map[string]any{"secret": "value"}
+	if err := c.DecryptMetadata(ctx, plain); !errors.Is(err, ErrNotEncrypted) {
+		t.Fatalf("DecryptMetadata() error = %v, want %v", err, ErrNotEncrypted)
+	}
+
+	// ciphertext moved to a different field must be rejected
+	meta := map[string]any{"secret": "value"}
+	if err := c.EncryptMetadata(ctx, meta); err != nil {
+		t.Fatalf("EncryptMetadata() error = %v", err)
+	}
+	swapped := map[string]any{"routing": meta["secret"]}
+	if err := c.DecryptMetadata(ctx, swapped); err == nil {
+		t.Fatal("DecryptMetadata() of swapped field succeeded")
+	}
+
+	other := &MetadataCipher{Keys: StaticKeyProvider{Keys: map[string][]byte{}}, Fields: c.Fields}
+	if err := other.DecryptMetadata(ctx, meta); err == nil {
+		t.Fatal("DecryptMetadata() with unknown key succeeded")
+	}
+}
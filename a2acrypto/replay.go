@@ -0,0 +1,193 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2acrypto
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+var (
+	// ErrStalePayload is matched by errors returned when a payload timestamp is outside of the accepted window.
+	// The errors are *TimeValidityError which also match ErrExpired or ErrNotYetValid.
+	ErrStalePayload = errors.New("payload timestamp is outside of the accepted window")
+	// ErrReplayedPayload is returned when a payload nonce was already seen.
+	ErrReplayedPayload = errors.New("payload nonce was already used")
+	// ErrMissingNonce is returned when a payload doesn't carry a nonce or a timestamp.
+	ErrMissingNonce = errors.New("payload nonce or timestamp is missing")
+)
+
+// DefaultReplayWindow is used by ReplayGuard if Window is not set.
+const DefaultReplayWindow = 5 * time.Minute
+
+// NonceCache remembers nonces until they expire.
+type NonceCache interface {
+	// Add stores the nonce for the ttl duration. Returns false if the nonce is already stored.
+	Add(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+	// Remove forgets the nonce, so that it is accepted by the next Add.
+	Remove(ctx context.Context, nonce string) error
+}
+
+// MemNonceCache is an in-memory NonceCache. Expired nonces are evicted by a sweep which runs
+// on Add at most once a minute.
+type MemNonceCache struct {
+	mu        sync.Mutex
+	nonces    map[string]time.Time
+	nextSweep time.Time
+	// now returns the current time. time.Now is used if nil.
+	now func() time.Time
+}
+
+// NewMemNonceCache creates an empty MemNonceCache.
+func NewMemNonceCache() *MemNonceCache {
+	return &MemNonceCache{nonces: make(map[string]time.Time)}
+}
+
+func (c *MemNonceCache) Add(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	if c.now != nil {
+		now = c.now()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.After(c.nextSweep) {
+		c.sweep(now)
+	}
+
+	if exp, ok := c.nonces[nonce]; ok && !now.After(exp) {
+		return false, nil
+	}
+	c.nonces[nonce] = now.Add(ttl)
+	return true, nil
+}
+
+// sweep removes expired nonces.
+func (c *MemNonceCache) sweep(now time.Time) {
+	for n, exp := range c.nonces {
+		if now.After(exp) {
+			delete(c.nonces, n)
+		}
+	}
+	c.nextSweep = now.Add(time.Minute)
+}
+
+func (c *MemNonceCache) Remove(ctx context.Context, nonce string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.nonces, nonce)
+	return nil
+}
+
+// ReplayGuard validates nonces and timestamps of signed payloads like push notifications
+// and agent cards. A payload is rejected if its timestamp differs from the current time by more
+// than Window or if its nonce was already seen within the window.
+type ReplayGuard struct {
+	// Cache stores seen nonces. Required.
+	Cache NonceCache
+	// Window is the maximum accepted clock difference. DefaultReplayWindow is used if zero.
+	Window time.Duration
+	// ClockSkew is the tolerance for senders with drifting clocks. If set, payloads are accepted
+	// up to Window+ClockSkew in the past and up to ClockSkew in the future. If zero, payloads
+	// are accepted up to Window in either direction.
+	ClockSkew time.Duration
+	// Now returns the current time. time.Now is used if nil.
+	Now func() time.Time
+
+	stats validationStats
+}
+
+// Metrics returns a snapshot of validation outcomes.
+func (g *ReplayGuard) Metrics() ValidationMetrics {
+	return g.stats.snapshot()
+}
+
+// Check validates the nonce and the timestamp of a payload.
+func (g *ReplayGuard) Check(ctx context.Context, nonce string, timestamp time.Time) error {
+	now := time.Now()
+	if g.Now != nil {
+		now = g.Now()
+	}
+	err := g.check(ctx, nonce, timestamp, now)
+	g.stats.record(err, timestamp.Sub(now))
+	return err
+}
+
+func (g *ReplayGuard) check(ctx context.Context, nonce string, timestamp, now time.Time) error {
+	if nonce == "" || timestamp.IsZero() {
+		return ErrMissingNonce
+	}
+
+	window := g.Window
+	if window == 0 {
+		window = DefaultReplayWindow
+	}
+	maxAge, maxAhead := window, window
+	if g.ClockSkew > 0 {
+		maxAge, maxAhead = window+g.ClockSkew, g.ClockSkew
+	}
+	switch diff := now.Sub(timestamp); {
+	case diff > maxAge:
+		return &TimeValidityError{Reason: ErrExpired, Time: timestamp, Now: now, ClockSkew: g.ClockSkew, stale: true}
+	case diff < -maxAhead:
+		return &TimeValidityError{Reason: ErrNotYetValid, Time: timestamp, Now: now, ClockSkew: g.ClockSkew, stale: true}
+	}
+
+	// the nonce needs to be remembered for as long as the timestamp is accepted
+	added, err := g.Cache.Add(ctx, nonce, timestamp.Add(maxAge).Sub(now))
+	if err != nil {
+		return fmt.Errorf("failed to store nonce: %w", err)
+	}
+	if !added {
+		return ErrReplayedPayload
+	}
+	return nil
+}
+
+// Release forgets a nonce accepted by Check, so that a payload which couldn't be processed
+// is accepted when it's redelivered with the same nonce.
+func (g *ReplayGuard) Release(ctx context.Context, nonce string) error {
+	if nonce == "" {
+		return nil
+	}
+	return g.Cache.Remove(ctx, nonce)
+}
+
+// CheckCardSignature validates the "nonce" and "iat" (issued at, seconds since Unix epoch) values
+// of the AgentCardSignature protected header. The signature itself must be verified separately.
+func (g *ReplayGuard) CheckCardSignature(ctx context.Context, sig a2a.AgentCardSignature) error {
+	raw, err := base64.RawURLEncoding.DecodeString(sig.Protected)
+	if err != nil {
+		return fmt.Errorf("malformed protected header: %w", err)
+	}
+	var header struct {
+		Nonce    string `json:"nonce"`
+		IssuedAt int64  `json:"iat"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return fmt.Errorf("malformed protected header: %w", err)
+	}
+	if header.IssuedAt == 0 {
+		return ErrMissingNonce
+	}
+	return g.Check(ctx, header.Nonce, time.Unix(header.IssuedAt, 0))
+}
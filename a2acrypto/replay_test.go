@@ -0,0 +1,142 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2acrypto
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestReplayGuard_Check(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	guard := &ReplayGuard{Cache: NewMemNonceCache(), Window: time.Minute, Now: func() time.Time { return now }}
+
+	testCases := []struct {
+		name      string
+		nonce     string
+		timestamp time.Time
+		wantErr   error
+	}{
+		{name: "accepted", nonce: "n1", timestamp: now.Add(-30 * time.Second)},
+		{name: "replayed", nonce: "n1", timestamp: now.Add(-30 * time.Second), wantErr: ErrReplayedPayload},
+		{name: "future within window", nonce: "n2", timestamp: now.Add(30 * time.Second)},
+		{name: "stale", nonce: "n3", timestamp: now.Add(-2 * time.Minute), wantErr: ErrStalePayload},
+		{name: "too far in future", nonce: "n4", timestamp: now.Add(2 * time.Minute), wantErr: ErrStalePayload},
+		{name: "missing nonce", timestamp: now, wantErr: ErrMissingNonce},
+		{name: "missing timestamp", nonce: "n5", wantErr: ErrMissingNonce},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := guard.Check(t.Context(), tc.nonce, tc.timestamp)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Check() error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestMemNonceCache_Expiry(t *testing.T) {
+	cache := NewMemNonceCache()
+	if ok, _ := cache.Add(t.Context(), "n", -time.Second); !ok {
+		t.Fatal("Add() = false for a new nonce")
+	}
+	if ok, _ := cache.Add(t.Context(), "n", time.Minute); !ok {
+		t.Fatal("Add() = false for an expired nonce")
+	}
+	if ok, _ := cache.Add(t.Context(), "n", time.Minute); ok {
+		t.Fatal("Add() = true for a stored nonce")
+	}
+}
+
+func TestMemNonceCache_Sweep(t *testing.T) {
+	now := time.Now()
+	cache := NewMemNonceCache()
+	cache.now = func() time.Time { return now }
+
+	for _, nonce := range []string{"a", "b", "c"} {
+		if ok, _ := cache.Add(t.Context(), nonce, time.Second); !ok {
+			t.Fatalf("Add(%q) = false for a new nonce", nonce)
+		}
+	}
+
+	now = now.Add(2 * time.Second)
+	if ok, _ := cache.Add(t.Context(), "d", time.Second); !ok {
+		t.Fatal("Add() = false for a new nonce")
+	}
+	if got := len(cache.nonces); got != 4 {
+		t.Fatalf("len(nonces) = %d before the next sweep, want 4", got)
+	}
+	if ok, _ := cache.Add(t.Context(), "a", time.Second); !ok {
+		t.Fatal("Add() = false for an expired nonce which wasn't swept yet")
+	}
+
+	now = now.Add(time.Minute)
+	if ok, _ := cache.Add(t.Context(), "e", time.Hour); !ok {
+		t.Fatal("Add() = false for a new nonce")
+	}
+	if got := len(cache.nonces); got != 1 {
+		t.Fatalf("len(nonces) = %d after a sweep, want 1", got)
+	}
+}
+
+func TestReplayGuard_Release(t *testing.T) {
+	guard := &ReplayGuard{Cache: NewMemNonceCache()}
+	now := time.Now()
+	if err := guard.Check(t.Context(), "n", now); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if err := guard.Release(t.Context(), "n"); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if err := guard.Check(t.Context(), "n", now); err != nil {
+		t.Fatalf("Check() after Release() error = %v", err)
+	}
+	if err := guard.Check(t.Context(), "n", now); !errors.Is(err, ErrReplayedPayload) {
+		t.Fatalf("Check() error = %v, want %v", err, ErrReplayedPayload)
+	}
+}
+
+func TestReplayGuard_CheckCardSignature(t *testing.T) {
+	now := time.Now()
+	guard := &ReplayGuard{Cache: NewMemNonceCache()}
+	encode := func(header string) a2a.AgentCardSignature {
+		return a2a.AgentCardSignature{Protected: base64.RawURLEncoding.EncodeToString([]byte(header))}
+	}
+
+	fresh := encode(`{"alg":"ES256","nonce":"abc","iat":` + strconv.FormatInt(now.Unix(), 10) + `}`)
+	if err := guard.CheckCardSignature(t.Context(), fresh); err != nil {
+		t.Fatalf("CheckCardSignature() error = %v", err)
+	}
+	if err := guard.CheckCardSignature(t.Context(), fresh); !errors.Is(err, ErrReplayedPayload) {
+		t.Fatalf("CheckCardSignature() error = %v, want %v", err, ErrReplayedPayload)
+	}
+
+	stale := encode(`{"alg":"ES256","nonce":"def","iat":` + strconv.FormatInt(now.Add(-time.Hour).Unix(), 10) + `}`)
+	if err := guard.CheckCardSignature(t.Context(), stale); !errors.Is(err, ErrStalePayload) {
+		t.Fatalf("CheckCardSignature() error = %v, want %v", err, ErrStalePayload)
+	}
+
+	if err := guard.CheckCardSignature(t.Context(), encode(`{"alg":"ES256"}`)); !errors.Is(err, ErrMissingNonce) {
+		t.Fatalf("CheckCardSignature() error = %v, want %v", err, ErrMissingNonce)
+	}
+	if err := guard.CheckCardSignature(t.Context(), a2a.AgentCardSignature{Protected: "!!"}); err == nil {
+		t.Fatal("CheckCardSignature() with malformed header succeeded")
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2acrypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrExpired is matched by validation errors of payloads and tokens which are older than accepted.
+	ErrExpired = errors.New("expired")
+	// ErrNotYetValid is matched by validation errors of payloads and tokens which are valid only in the future,
+	// even after accounting for clock skew.
+	ErrNotYetValid = errors.New("not yet valid")
+	// ErrInvalidSignature is matched by validation errors of tokens with a signature which failed verification.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrMalformedToken is returned for tokens which are not compact JWS serializations with a JSON payload.
+	ErrMalformedToken = errors.New("malformed token")
+)
+
+// TimeValidityError is returned for payloads and tokens rejected because of their timestamps.
+// It matches ErrExpired or ErrNotYetValid and, for ReplayGuard, ErrStalePayload.
+type TimeValidityError struct {
+	// Reason is ErrExpired or ErrNotYetValid.
+	Reason error
+	// Time is the validated timestamp and Now is the local time it was compared to.
+	Time time.Time
+	Now  time.Time
+	// ClockSkew is the tolerance which was applied.
+	ClockSkew time.Duration
+
+	stale bool
+}
+
+func (e *TimeValidityError) Error() string {
+	prefix := ""
+	if e.stale {
+		prefix = ErrStalePayload.Error() + ": "
+	}
+	if errors.Is(e.Reason, ErrNotYetValid) {
+		return fmt.Sprintf("%s%v: %v in the future, clock skew tolerance is %v", prefix, e.Reason, e.Time.Sub(e.Now), e.ClockSkew)
+	}
+	return fmt.Sprintf("%s%v: %v ago, clock skew tolerance is %v", prefix, e.Reason, e.Now.Sub(e.Time), e.ClockSkew)
+}
+
+func (e *TimeValidityError) Unwrap() []error {
+	if e.stale {
+		return []error{e.Reason, ErrStalePayload}
+	}
+	return []error{e.Reason}
+}
+
+// ValidationMetrics is a snapshot of validation outcomes of a ReplayGuard or a JWTValidator.
+// Watching NotYetValid and MaxSkew helps to tell clock drift in a fleet from attacks.
+type ValidationMetrics struct {
+	Accepted         uint64 `json:"accepted"`
+	Expired          uint64 `json:"expired"`
+	NotYetValid      uint64 `json:"notYetValid"`
+	InvalidSignature uint64 `json:"invalidSignature"`
+	Replayed         uint64 `json:"replayed"`
+	// Malformed counts payloads and tokens with missing or unparsable values.
+	Malformed uint64 `json:"malformed"`
+	// MaxSkew is the largest difference between an accepted timestamp in the future and the local time.
+	MaxSkew time.Duration `json:"maxSkew"`
+}
+
+// validationStats records ValidationMetrics. The zero value is ready to use.
+type validationStats struct {
+	mu      sync.Mutex
+	metrics ValidationMetrics
+}
+
+func (s *validationStats) snapshot() ValidationMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.metrics
+}
+
+// record counts the validation outcome. skew is the amount of time a timestamp was ahead of the local clock.
+func (s *validationStats) record(err error, skew time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case err == nil:
+		s.metrics.Accepted++
+		s.metrics.MaxSkew = max(s.metrics.MaxSkew, skew)
+	case errors.Is(err, ErrExpired):
+		s.metrics.Expired++
+	case errors.Is(err, ErrNotYetValid):
+		s.metrics.NotYetValid++
+	case errors.Is(err, ErrInvalidSignature):
+		s.metrics.InvalidSignature++
+	case errors.Is(err, ErrReplayedPayload):
+		s.metrics.Replayed++
+	case errors.Is(err, ErrMissingNonce), errors.Is(err, ErrMalformedToken):
+		s.metrics.Malformed++
+	}
+}
+
+// DefaultClockSkew is used by JWTValidator if ClockSkew is not set.
+const DefaultClockSkew = time.Minute
+
+// JWTValidator validates the signature and the "exp", "nbf" and "iat" claims (RFC 7519) of tokens
+// in the compact JWS serialization. Timestamps are accepted within ClockSkew of the local time,
+// so that minor clock drift between hosts doesn't cause spurious failures.
+type JWTValidator struct {
+	// Verify verifies the signature over the signing input ("{header}.{payload}") using the key selected
+	// for the decoded protected header, eg. by "kid" and "alg". Keys usually come from a KMS or JWKS,
+	// so verification is left to the caller. Required.
+	Verify func(header map[string]any, signingInput, signature []byte) error
+	// ClockSkew is the tolerance applied to time claims. DefaultClockSkew is used if zero.
+	ClockSkew time.Duration
+	// Now returns the current time. time.Now is used if nil.
+	Now func() time.Time
+
+	stats validationStats
+}
+
+// Metrics returns a snapshot of validation outcomes.
+func (v *JWTValidator) Metrics() ValidationMetrics {
+	return v.stats.snapshot()
+}
+
+// Validate verifies the token and returns its decoded claims. Errors match ErrMalformedToken,
+// ErrInvalidSignature, ErrExpired or ErrNotYetValid.
+func (v *JWTValidator) Validate(token string) (map[string]any, error) {
+	claims, skew, err := v.validate(token)
+	v.stats.record(err, skew)
+	return claims, err
+}
+
+func (v *JWTValidator) validate(token string) (map[string]any, time.Duration, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, 0, fmt.Errorf("%w: expected 3 segments, got %d", ErrMalformedToken, len(parts))
+	}
+	var header map[string]any
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, 0, fmt.Errorf("%w: header: %w", ErrMalformedToken, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: signature: %w", ErrMalformedToken, err)
+	}
+	if v.Verify == nil {
+		return nil, 0, fmt.Errorf("%w: no verifier configured", ErrInvalidSignature)
+	}
+	if err := v.Verify(header, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, 0, fmt.Errorf("%w: payload: %w", ErrMalformedToken, err)
+	}
+
+	skew := v.ClockSkew
+	if skew == 0 {
+		skew = DefaultClockSkew
+	}
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+	var ahead time.Duration
+	for _, name := range []string{"exp", "nbf", "iat"} {
+		value, ok := claims[name]
+		if !ok {
+			continue
+		}
+		seconds, ok := value.(json.Number)
+		if !ok {
+			return nil, 0, fmt.Errorf("%w: %q claim is not a number", ErrMalformedToken, name)
+		}
+		unix, err := seconds.Float64()
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %q claim: %w", ErrMalformedToken, name, err)
+		}
+		ts := time.Unix(0, 0).Add(time.Duration(unix * float64(time.Second)))
+		switch {
+		case name == "exp" && !now.Before(ts.Add(skew)):
+			return nil, 0, &TimeValidityError{Reason: ErrExpired, Time: ts, Now: now, ClockSkew: skew}
+		case name != "exp" && now.Add(skew).Before(ts):
+			return nil, 0, &TimeValidityError{Reason: ErrNotYetValid, Time: ts, Now: now, ClockSkew: skew}
+		case name != "exp":
+			ahead = max(ahead, ts.Sub(now))
+		}
+	}
+	return claims, ahead, nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2acrypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func testJWT(claims string) string {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	return encode(`{"alg":"HS256","kid":"k1"}`) + "." + encode(claims) + "." + encode("signature")
+}
+
+func TestJWTValidator(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) string { return strconv.FormatInt(now.Add(d).Unix(), 10) }
+	validator := &JWTValidator{
+		Verify: func(header map[string]any, signingInput, signature []byte) error {
+			if header["kid"] != "k1" || !bytes.Equal(signature, []byte("signature")) {
+				return errors.New("signature mismatch")
+			}
+			return nil
+		},
+		ClockSkew: 30 * time.Second,
+		Now:       func() time.Time { return now },
+	}
+
+	testCases := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid", token: testJWT(`{"sub":"agent","exp":` + at(time.Hour) + `,"iat":` + at(-time.Minute) + `}`)},
+		{name: "expired within skew", token: testJWT(`{"exp":` + at(-10*time.Second) + `}`)},
+		{name: "issued ahead within skew", token: testJWT(`{"iat":` + at(20*time.Second) + `}`)},
+		{name: "expired", token: testJWT(`{"exp":` + at(-time.Minute) + `}`), wantErr: ErrExpired},
+		{name: "not before", token: testJWT(`{"nbf":` + at(time.Minute) + `}`), wantErr: ErrNotYetValid},
+		{name: "issued in future", token: testJWT(`{"iat":` + at(time.Minute) + `}`), wantErr: ErrNotYetValid},
+		{name: "bad signature", token: testJWT(`{}`)[:len(testJWT(`{}`))-2] + "AA", wantErr: ErrInvalidSignature},
+		{name: "malformed", token: "not-a-jwt", wantErr: ErrMalformedToken},
+		{name: "non-numeric claim", token: testJWT(`{"exp":"tomorrow"}`), wantErr: ErrMalformedToken},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims, err := validator.Validate(tc.token)
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				if claims == nil {
+					t.Fatal("Validate() returned no claims")
+				}
+				return
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Validate() error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+
+	want := ValidationMetrics{Accepted: 3, Expired: 1, NotYetValid: 2, InvalidSignature: 1, Malformed: 2, MaxSkew: 20 * time.Second}
+	if got := validator.Metrics(); got != want {
+		t.Fatalf("Metrics() = %+v, want %+v", got, want)
+	}
+}
+
+func TestReplayGuard_ClockSkew(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	guard := &ReplayGuard{Cache: NewMemNonceCache(), Window: time.Minute, ClockSkew: 10 * time.Second, Now: func() time.Time { return now }}
+
+	testCases := []struct {
+		nonce     string
+		timestamp time.Time
+		wantErr   error
+	}{
+		{nonce: "old within skew", timestamp: now.Add(-65 * time.Second)},
+		{nonce: "ahead within skew", timestamp: now.Add(5 * time.Second)},
+		{nonce: "expired", timestamp: now.Add(-75 * time.Second), wantErr: ErrExpired},
+		{nonce: "ahead", timestamp: now.Add(30 * time.Second), wantErr: ErrNotYetValid},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.nonce, func(t *testing.T) {
+			err := guard.Check(t.Context(), tc.nonce, tc.timestamp)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Check() error = %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantErr != nil && !errors.Is(err, ErrStalePayload) {
+				t.Fatalf("Check() error = %v, want it to match %v", err, ErrStalePayload)
+			}
+		})
+	}
+
+	want := ValidationMetrics{Accepted: 2, Expired: 1, NotYetValid: 1, MaxSkew: 5 * time.Second}
+	if got := guard.Metrics(); got != want {
+		t.Fatalf("Metrics() = %+v, want %+v", got, want)
+	}
+}
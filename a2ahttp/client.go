@@ -0,0 +1,113 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ahttp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ClientConfig describes an http.Client used by HTTP-based A2A components.
+type ClientConfig struct {
+	// TLS is applied to all connections if not nil.
+	TLS *TLSConfig
+	// Timeout limits the time of a single request. Zero means no timeout.
+	Timeout time.Duration
+	// Proxy overrides proxy settings read from HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	// environment variables if not nil.
+	Proxy *ProxyConfig
+	// DialContext is used for establishing connections if not nil. Can be used for DNS pinning,
+	// custom resolvers or in-memory connections in tests, similar to grpc.WithContextDialer.
+	DialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+	// WireLog receives a dump of every request and response made by clients created with NewClient
+	// if not nil. Secrets are redacted. See NewWireLogRoundTripper.
+	WireLog io.Writer
+}
+
+// ProxyConfig describes proxies used for outgoing requests.
+type ProxyConfig struct {
+	// HTTPProxy is the proxy URL used for plain HTTP requests. An empty value means no proxy.
+	HTTPProxy string
+	// HTTPSProxy is the proxy URL used for HTTPS requests. An empty value means no proxy.
+	HTTPSProxy string
+	// NoProxy is a comma-separated list of hosts, domains and CIDR ranges which are reached directly.
+	// Uses the same format as NO_PROXY environment variable.
+	NoProxy string
+	// Disabled makes all requests go directly to the target ignoring the environment.
+	Disabled bool
+}
+
+// ProxyFunc returns a function which can be used as http.Transport.Proxy, or nil if proxies are disabled.
+// An error is returned if one of the proxy URLs is invalid.
+func (c *ProxyConfig) ProxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	if c.Disabled {
+		return nil, nil
+	}
+	for _, proxy := range []string{c.HTTPProxy, c.HTTPSProxy} {
+		if proxy == "" {
+			continue
+		}
+		if _, err := url.Parse(proxy); err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", proxy, err)
+		}
+	}
+	cfg := &httpproxy.Config{HTTPProxy: c.HTTPProxy, HTTPSProxy: c.HTTPSProxy, NoProxy: c.NoProxy}
+	fn := cfg.ProxyFunc()
+	return func(r *http.Request) (*url.URL, error) {
+		return fn(r.URL)
+	}, nil
+}
+
+// NewClient creates an http.Client with a dedicated transport configured according to ClientConfig.
+func (c ClientConfig) NewClient() (*http.Client, error) {
+	transport, err := c.NewTransport()
+	if err != nil {
+		return nil, err
+	}
+	if c.WireLog != nil {
+		return &http.Client{Transport: NewWireLogRoundTripper(transport, c.WireLog), Timeout: c.Timeout}, nil
+	}
+	return &http.Client{Transport: transport, Timeout: c.Timeout}, nil
+}
+
+// NewTransport creates an http.Transport configured according to ClientConfig.
+func (c ClientConfig) NewTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.Proxy != nil {
+		proxy, err := c.Proxy.ProxyFunc()
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = proxy
+	}
+	if c.DialContext != nil {
+		transport.DialContext = c.DialContext
+	}
+	if c.TLS != nil {
+		tlsConfig, err := c.TLS.Build()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	return transport, nil
+}
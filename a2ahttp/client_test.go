@@ -0,0 +1,132 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ahttp
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientConfig_Proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+
+	client, err := ClientConfig{Proxy: &ProxyConfig{HTTPProxy: proxy.URL}}.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	resp, err := client.Get("http://agent.example.com/.well-known/agent-card.json")
+	if err != nil {
+		t.Fatalf("request through proxy failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	want := "http://agent.example.com/.well-known/agent-card.json"
+	if len(proxied) != 1 || proxied[0] != want {
+		t.Fatalf("proxied requests = %v, want [%s]", proxied, want)
+	}
+}
+
+func TestClientConfig_NoProxy(t *testing.T) {
+	testCases := []struct {
+		name      string
+		proxy     *ProxyConfig
+		url       string
+		wantProxy string
+	}{
+		{
+			name:      "proxied",
+			proxy:     &ProxyConfig{HTTPProxy: "http://proxy:8080", HTTPSProxy: "http://secure-proxy:8080"},
+			url:       "https://agent.example.com",
+			wantProxy: "http://secure-proxy:8080",
+		},
+		{
+			name:  "no proxy domain",
+			proxy: &ProxyConfig{HTTPSProxy: "http://proxy:8080", NoProxy: ".internal.com"},
+			url:   "https://agent.internal.com",
+		},
+		{
+			name:  "disabled",
+			proxy: &ProxyConfig{HTTPSProxy: "http://proxy:8080", Disabled: true},
+			url:   "https://agent.example.com",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transport, err := ClientConfig{Proxy: tc.proxy}.NewTransport()
+			if err != nil {
+				t.Fatalf("NewTransport() error = %v", err)
+			}
+			if transport.Proxy == nil {
+				if tc.wantProxy != "" {
+					t.Fatalf("transport proxy not set, want %s", tc.wantProxy)
+				}
+				return
+			}
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			if err != nil {
+				t.Fatalf("http.NewRequest() error = %v", err)
+			}
+			got, err := transport.Proxy(req)
+			if err != nil {
+				t.Fatalf("Proxy() error = %v", err)
+			}
+			gotProxy := ""
+			if got != nil {
+				gotProxy = got.String()
+			}
+			if gotProxy != tc.wantProxy {
+				t.Fatalf("Proxy() = %q, want %q", gotProxy, tc.wantProxy)
+			}
+		})
+	}
+}
+
+func TestClientConfig_DialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var dialed []string
+	client, err := ClientConfig{
+		Proxy: &ProxyConfig{Disabled: true},
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialed = append(dialed, addr)
+			var d net.Dialer
+			return d.DialContext(ctx, network, server.Listener.Addr().String())
+		},
+	}.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	resp, err := client.Get("http://agent.example.com:8080")
+	if err != nil {
+		t.Fatalf("request with custom dialer failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if len(dialed) != 1 || dialed[0] != "agent.example.com:8080" {
+		t.Fatalf("dialed = %v, want [agent.example.com:8080]", dialed)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ahttp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSConfig is a declarative TLS configuration which can be converted to *tls.Config.
+type TLSConfig struct {
+	// CAFile is a path to a PEM-encoded CA bundle used for verifying servers. System roots are used if
+	// neither CAFile nor CAPEM is set.
+	CAFile string
+	// CAPEM is a PEM-encoded CA bundle used for verifying servers. Appended to CAFile certificates.
+	CAPEM []byte
+	// CertFile is a path to a PEM-encoded client certificate used for mutual TLS. Requires KeyFile.
+	CertFile string
+	// KeyFile is a path to a PEM-encoded client private key used for mutual TLS. Requires CertFile.
+	KeyFile string
+	// MinVersion is the minimum accepted TLS version. Defaults to TLS 1.2.
+	MinVersion uint16
+	// InsecureSkipVerify disables server certificate verification. Must only be used during development.
+	InsecureSkipVerify bool
+}
+
+// Build creates a *tls.Config loading all the referenced certificates.
+func (c *TLSConfig) Build() (*tls.Config, error) {
+	result := &tls.Config{
+		MinVersion:         c.MinVersion,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if result.MinVersion == 0 {
+		result.MinVersion = tls.VersionTLS12
+	}
+
+	if c.CAFile != "" || len(c.CAPEM) > 0 {
+		pool := x509.NewCertPool()
+		if c.CAFile != "" {
+			pem, err := os.ReadFile(c.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in CA file %s", c.CAFile)
+			}
+		}
+		if len(c.CAPEM) > 0 && !pool.AppendCertsFromPEM(c.CAPEM) {
+			return nil, fmt.Errorf("no certificates found in CA PEM")
+		}
+		result.RootCAs = pool
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		if c.CertFile == "" || c.KeyFile == "" {
+			return nil, fmt.Errorf("both CertFile and KeyFile must be set for client authentication")
+		}
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		result.Certificates = []tls.Certificate{cert}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ahttp
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestTLSServer(t *testing.T) (*httptest.Server, []byte) {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return server, caPEM
+}
+
+func TestTLSConfig_CAPEM(t *testing.T) {
+	server, caPEM := newTestTLSServer(t)
+
+	client, err := ClientConfig{TLS: &TLSConfig{CAPEM: caPEM}}.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with trusted CA failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	client, err = ClientConfig{}.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.Get(server.URL); err == nil {
+		t.Fatal("request without trusted CA succeeded")
+	}
+}
+
+func TestTLSConfig_CAFile(t *testing.T) {
+	server, caPEM := newTestTLSServer(t)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatalf("failed to write CA file: %v", err)
+	}
+
+	client, err := ClientConfig{TLS: &TLSConfig{CAFile: caFile}}.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with trusted CA file failed: %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestTLSConfig_InsecureSkipVerify(t *testing.T) {
+	server, _ := newTestTLSServer(t)
+
+	client, err := ClientConfig{TLS: &TLSConfig{InsecureSkipVerify: true}}.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with disabled verification failed: %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestTLSConfig_Build(t *testing.T) {
+	cfg, err := (&TLSConfig{}).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if cfg.MinVersion != tls.VersionTLS12 {
+		t.Errorf("MinVersion = %d, want %d", cfg.MinVersion, tls.VersionTLS12)
+	}
+
+	invalid := []*TLSConfig{
+		{CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+		{CAPEM: []byte("not a certificate")},
+		{CertFile: "cert.pem"},
+		{KeyFile: "key.pem"},
+	}
+	for _, c := range invalid {
+		if _, err := c.Build(); err == nil {
+			t.Errorf("Build() expected error for %+v", c)
+		}
+	}
+}
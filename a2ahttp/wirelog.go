@@ -0,0 +1,113 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ahttp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/internal/wirelog"
+)
+
+// NewWireLogRoundTripper wraps an http.RoundTripper to dump raw requests and responses to w, including
+// headers and JSON-RPC or REST payloads. Secret headers like Authorization and secret JSON fields like
+// tokens are redacted. Server-sent event streams are logged line by line as they are consumed.
+// http.DefaultTransport is used if next is nil.
+func NewWireLogRoundTripper(next http.RoundTripper, w io.Writer) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &wireLogRoundTripper{next: next, log: wirelog.New(w)}
+}
+
+type wireLogRoundTripper struct {
+	next http.RoundTripper
+	log  *wirelog.Logger
+}
+
+func (t *wireLogRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		_ = req.Body.Close()
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	t.log.Frame(wirelog.Out, req.Method+" "+req.URL.String(), req.Header, body)
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.log.Frame(wirelog.In, "error: "+err.Error(), nil, nil)
+		return nil, err
+	}
+
+	title := resp.Proto + " " + resp.Status
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		t.log.Frame(wirelog.In, title, resp.Header, nil)
+		resp.Body = &eventStreamLogger{ReadCloser: resp.Body, log: t.log}
+		return resp, nil
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	t.log.Frame(wirelog.In, title, resp.Header, respBody)
+	return resp, nil
+}
+
+// eventStreamLogger logs every server-sent event line as it is read by the consumer.
+type eventStreamLogger struct {
+	io.ReadCloser
+	log     *wirelog.Logger
+	pending []byte
+}
+
+func (r *eventStreamLogger) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.pending = append(r.pending, p[:n]...)
+	for {
+		i := bytes.IndexByte(r.pending, '\n')
+		if i < 0 {
+			break
+		}
+		r.logLine(r.pending[:i])
+		r.pending = r.pending[i+1:]
+	}
+	if err != nil && len(r.pending) > 0 {
+		r.logLine(r.pending)
+		r.pending = nil
+	}
+	return n, err
+}
+
+func (r *eventStreamLogger) logLine(line []byte) {
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	if len(line) == 0 {
+		return
+	}
+	if data, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+		line = append([]byte("data: "), wirelog.RedactJSON(bytes.TrimSpace(data))...)
+	}
+	r.log.Frame(wirelog.In, "event", nil, line)
+}
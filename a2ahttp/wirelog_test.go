@@ -0,0 +1,93 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ahttp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewWireLogRoundTripper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"echo":` + string(body) + `,"accessToken":"response-secret"}`))
+	}))
+	defer server.Close()
+
+	var log bytes.Buffer
+	client, err := ClientConfig{WireLog: &log}.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"method":"message/send","password":"request-secret"}`))
+	if err != nil {
+		t.Fatalf("http.NewRequest() error = %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer header-secret")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if !strings.Contains(string(body), "response-secret") || !strings.Contains(string(body), "request-secret") {
+		t.Fatalf("response body = %s, want payloads delivered unchanged", body)
+	}
+	got := log.String()
+	for _, want := range []string{"--> POST " + server.URL, "Authorization: [REDACTED]", `"method":"message/send"`, "<-- HTTP/1.1 200 OK"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("wire log doesn't contain %q:\n%s", want, got)
+		}
+	}
+	for _, secret := range []string{"header-secret", "request-secret", "response-secret"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("wire log contains %q:\n%s", secret, got)
+		}
+	}
+}
+
+func TestNewWireLogRoundTripper_EventStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"kind\":\"task\",\"token\":\"stream-secret\"}\n\ndata: {\"kind\":\"status-update\"}\n\n"))
+	}))
+	defer server.Close()
+
+	var log bytes.Buffer
+	client := &http.Client{Transport: NewWireLogRoundTripper(nil, &log)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if !strings.Contains(string(body), "stream-secret") {
+		t.Fatalf("response body = %s, want events delivered unchanged", body)
+	}
+	got := log.String()
+	if strings.Count(got, "<-- event") != 2 || !strings.Contains(got, `"kind":"status-update"`) {
+		t.Errorf("wire log = %s, want a frame per event", got)
+	}
+	if strings.Contains(got, "stream-secret") {
+		t.Errorf("wire log contains a secret:\n%s", got)
+	}
+}
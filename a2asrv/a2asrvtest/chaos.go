@@ -0,0 +1,142 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrvtest
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+var (
+	// ErrInjected is the transient error returned by queues of a chaos Manager.
+	ErrInjected = errors.New("injected transient failure")
+	// ErrStreamDropped is returned by a chaos queue Read to simulate a broken event stream.
+	ErrStreamDropped = errors.New("injected stream drop")
+)
+
+// Faults configures the failures injected by NewChaosManager. Rates are probabilities in the range [0, 1]
+// evaluated independently for every queue operation.
+type Faults struct {
+	// Rand is the source of randomness. Set it to a seeded source for reproducible runs.
+	Rand *rand.Rand
+	// Latency is the maximum delay added to an operation. The actual delay is uniformly distributed.
+	Latency time.Duration
+	// LatencyRate is the probability of delaying an operation by up to Latency.
+	LatencyRate float64
+	// ErrorRate is the probability of a Write failing with ErrInjected without writing the event.
+	ErrorRate float64
+	// DropRate is the probability of a Read failing with ErrStreamDropped. The event is not lost
+	// and will be returned by a subsequent Read.
+	DropRate float64
+	// MalformedRate is the probability of a Read returning an event which doesn't reference
+	// any Task and has an unknown state instead of the next event.
+	MalformedRate float64
+}
+
+// NewChaosManager wraps a Manager to inject latency, transient errors, stream drops and malformed events
+// into its queues, so that resilience of the server stack and clients can be verified under failure.
+// Use it with a2asrv.WithEventQueueManager.
+func NewChaosManager(manager eventqueue.Manager, faults Faults) eventqueue.Manager {
+	r := faults.Rand
+	if r == nil {
+		r = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	return &chaosManager{Manager: manager, chaos: &chaos{faults: faults, rand: r}}
+}
+
+type chaos struct {
+	faults Faults
+	mu     sync.Mutex
+	rand   *rand.Rand
+}
+
+func (c *chaos) happens(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rand.Float64() < rate
+}
+
+func (c *chaos) delay(ctx context.Context) error {
+	if c.faults.Latency <= 0 || !c.happens(c.faults.LatencyRate) {
+		return nil
+	}
+	c.mu.Lock()
+	d := time.Duration(c.rand.Int64N(int64(c.faults.Latency)))
+	c.mu.Unlock()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+type chaosManager struct {
+	eventqueue.Manager
+	chaos *chaos
+}
+
+func (m *chaosManager) GetOrCreate(ctx context.Context, taskId a2a.TaskID) (eventqueue.Queue, error) {
+	queue, err := m.Manager.GetOrCreate(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+	return &chaosQueue{Queue: queue, chaos: m.chaos}, nil
+}
+
+type chaosQueue struct {
+	eventqueue.Queue
+	chaos *chaos
+}
+
+func (q *chaosQueue) Write(ctx context.Context, event a2a.Event) error {
+	if err := q.chaos.delay(ctx); err != nil {
+		return err
+	}
+	if q.chaos.happens(q.chaos.faults.ErrorRate) {
+		return ErrInjected
+	}
+	return q.Queue.Write(ctx, event)
+}
+
+func (q *chaosQueue) Read(ctx context.Context) (a2a.Event, error) {
+	if err := q.chaos.delay(ctx); err != nil {
+		return nil, err
+	}
+	if q.chaos.happens(q.chaos.faults.DropRate) {
+		return nil, ErrStreamDropped
+	}
+	if q.chaos.happens(q.chaos.faults.MalformedRate) {
+		return MalformedEvent(), nil
+	}
+	return q.Queue.Read(ctx)
+}
+
+// MalformedEvent returns an event which doesn't reference any Task and has an unknown state.
+func MalformedEvent() a2a.Event {
+	return &a2a.TaskStatusUpdateEvent{Status: a2a.TaskStatus{State: "malformed"}}
+}
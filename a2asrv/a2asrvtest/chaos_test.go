@@ -0,0 +1,88 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrvtest
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+func TestChaosManager_AlwaysFails(t *testing.T) {
+	ctx := t.Context()
+	manager := NewChaosManager(eventqueue.NewInMemoryManager(), Faults{ErrorRate: 1, DropRate: 1})
+	queue, err := manager.GetOrCreate(ctx, "task")
+	if err != nil {
+		t.Fatalf("GetOrCreate() error = %v", err)
+	}
+
+	if err := queue.Write(ctx, &a2a.Message{}); !errors.Is(err, ErrInjected) {
+		t.Fatalf("Write() error = %v, want %v", err, ErrInjected)
+	}
+	if _, err := queue.Read(ctx); !errors.Is(err, ErrStreamDropped) {
+		t.Fatalf("Read() error = %v, want %v", err, ErrStreamDropped)
+	}
+}
+
+func TestChaosManager_Malformed(t *testing.T) {
+	ctx := t.Context()
+	manager := NewChaosManager(eventqueue.NewInMemoryManager(), Faults{MalformedRate: 1})
+	queue, err := manager.GetOrCreate(ctx, "task")
+	if err != nil {
+		t.Fatalf("GetOrCreate() error = %v", err)
+	}
+
+	event, err := queue.Read(ctx)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if update, ok := event.(*a2a.TaskStatusUpdateEvent); !ok || update.TaskID != "" {
+		t.Fatalf("Read() = %v, want a malformed event", event)
+	}
+}
+
+func TestChaosManager_Reproducible(t *testing.T) {
+	run := func() []bool {
+		ctx := t.Context()
+		faults := Faults{Rand: rand.New(rand.NewPCG(1, 2)), ErrorRate: 0.5, Latency: time.Millisecond, LatencyRate: 0.5}
+		queue, err := NewChaosManager(eventqueue.NewInMemoryManager(), faults).GetOrCreate(ctx, "task")
+		if err != nil {
+			t.Fatalf("GetOrCreate() error = %v", err)
+		}
+		var failed []bool
+		for range 20 {
+			failed = append(failed, queue.Write(ctx, &a2a.Message{}) != nil)
+		}
+		return failed
+	}
+
+	first, second := run(), run()
+	failures := 0
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("runs with the same seed differ: %v != %v", first, second)
+		}
+		if first[i] {
+			failures++
+		}
+	}
+	if failures == 0 || failures == len(first) {
+		t.Fatalf("got %d failures out of %d writes, want some of them to fail", failures, len(first))
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrvtest
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// VirtualClock is an a2asrv.Clock which only moves forward when Advance is called.
+type VirtualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []virtualTimer
+	blocked chan struct{}
+}
+
+type virtualTimer struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// NewVirtualClock creates a VirtualClock set to the provided time.
+func NewVirtualClock(start time.Time) *VirtualClock {
+	return &VirtualClock{now: start, blocked: make(chan struct{}, 1)}
+}
+
+func (c *VirtualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *VirtualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.timers = append(c.timers, virtualTimer{deadline: c.now.Add(d), ch: ch})
+	select {
+	case c.blocked <- struct{}{}:
+	default:
+	}
+	return ch
+}
+
+// Advance moves the clock forward firing all the timers which deadline has passed in deadline order.
+// Returns the number of fired timers.
+func (c *VirtualClock) Advance(d time.Duration) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		return c.timers[i].deadline.Before(c.timers[j].deadline)
+	})
+	fired := 0
+	for _, timer := range c.timers {
+		if timer.deadline.After(c.now) {
+			break
+		}
+		timer.ch <- timer.deadline
+		fired++
+	}
+	c.timers = c.timers[fired:]
+	return fired
+}
+
+// Blocked returns a channel which receives a value when someone starts waiting on the clock.
+func (c *VirtualClock) Blocked() <-chan struct{} {
+	return c.blocked
+}
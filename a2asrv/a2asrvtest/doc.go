@@ -0,0 +1,30 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package a2asrvtest provides utilities for testing AgentExecutor implementations.
+//
+// Simulation drives an executor through scripted multi-turn flows with a virtual clock
+// and records the emitted events, so that complex agent logic can be tested deterministically:
+//
+//	sim := a2asrvtest.NewSimulation(executor)
+//	sim.Run(t,
+//		a2asrvtest.Step{Do: a2asrvtest.SendText("book a flight"), Want: []a2asrvtest.EventMatcher{
+//			a2asrvtest.StatusUpdate(a2a.TaskStateInputRequired),
+//		}},
+//		a2asrvtest.Step{Do: a2asrvtest.SendText("to Paris"), Want: ...},
+//	)
+//
+// NewChaosManager wraps an eventqueue.Manager to inject latency, transient errors, stream drops
+// and malformed events, so that the resilience of clients and the server stack can be verified.
+package a2asrvtest
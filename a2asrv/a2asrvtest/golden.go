@@ -0,0 +1,140 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrvtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// UpdateGoldenEnv is the environment variable which makes AssertGolden overwrite golden files
+// with the actual output when set to a non-empty value.
+const UpdateGoldenEnv = "A2A_UPDATE_GOLDEN"
+
+// idKeys are JSON keys which values are generated and get replaced with stable placeholders.
+var idKeys = map[string]bool{
+	"id":         true,
+	"taskId":     true,
+	"contextId":  true,
+	"messageId":  true,
+	"artifactId": true,
+}
+
+// timeKeys are JSON keys which values depend on the current time.
+var timeKeys = map[string]bool{
+	"timestamp": true,
+}
+
+// CanonicalizeEvents serializes events to a diff-friendly form: an indented JSON array of
+// {"kind": ..., "event": ...} objects with sorted keys, generated IDs replaced with placeholders
+// numbered in the order of appearance (eg. "<taskId-1>") and timestamps replaced with "<timestamp>".
+// The same ID is always replaced with the same placeholder, so references between events are preserved.
+func CanonicalizeEvents(events []a2a.Event) ([]byte, error) {
+	n := &normalizer{ids: make(map[string]string), counts: make(map[string]int)}
+	result := make([]any, len(events))
+	for i, event := range events {
+		raw, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event %d: %w", i, err)
+		}
+		var decoded any
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event %d: %w", i, err)
+		}
+		result[i] = map[string]any{"kind": eventKind(event), "event": n.normalize("", decoded)}
+	}
+	// maps are marshaled with sorted keys
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(result); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+type normalizer struct {
+	ids    map[string]string
+	counts map[string]int
+}
+
+func (n *normalizer) normalize(key string, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		// placeholders are numbered in the order of appearance, so keys must be visited in a stable order
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			v[k] = n.normalize(k, v[k])
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = n.normalize(key, item)
+		}
+		return v
+	case string:
+		if timeKeys[key] {
+			return "<timestamp>"
+		}
+		if !idKeys[key] || v == "" {
+			return v
+		}
+		if placeholder, ok := n.ids[v]; ok {
+			return placeholder
+		}
+		n.counts[key]++
+		placeholder := fmt.Sprintf("<%s-%d>", key, n.counts[key])
+		n.ids[v] = placeholder
+		return placeholder
+	default:
+		return v
+	}
+}
+
+// AssertGolden compares canonicalized events with the content of the golden file at path.
+// If UpdateGoldenEnv is set the file is overwritten instead.
+func AssertGolden(t testing.TB, path string, events []a2a.Event) {
+	t.Helper()
+	got, err := CanonicalizeEvents(events)
+	if err != nil {
+		t.Fatalf("CanonicalizeEvents() error = %v", err)
+	}
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create golden file directory: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (set %s=1 to create it): %v", UpdateGoldenEnv, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("events don't match golden file %s (set %s=1 to update it):\ngot:\n%s\nwant:\n%s", path, UpdateGoldenEnv, got, want)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrvtest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func newGoldenEvents() []a2a.Event {
+	task := &a2a.Task{ID: a2a.NewTaskID(), ContextID: a2a.NewContextID(), Status: a2a.TaskStatus{State: a2a.TaskStateSubmitted}}
+	msg := a2a.NewMessageForTask(a2a.MessageRoleAgent, *task, a2a.TextPart{Text: "done"})
+	return []a2a.Event{
+		task,
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil),
+		a2a.NewArtifactEvent(*task, a2a.TextPart{Text: "result"}),
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, msg),
+	}
+}
+
+func TestCanonicalizeEvents_Stable(t *testing.T) {
+	first, err := CanonicalizeEvents(newGoldenEvents())
+	if err != nil {
+		t.Fatalf("CanonicalizeEvents() error = %v", err)
+	}
+	second, err := CanonicalizeEvents(newGoldenEvents())
+	if err != nil {
+		t.Fatalf("CanonicalizeEvents() error = %v", err)
+	}
+	if string(first) != string(second) {
+		t.Fatalf("CanonicalizeEvents() is not stable:\n%s\n%s", first, second)
+	}
+
+	out := string(first)
+	for _, want := range []string{`"id": "<id-1>"`, `"taskId": "<id-1>"`, `"contextId": "<contextId-1>"`, `"timestamp": "<timestamp>"`, `"kind": "artifact-update"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("CanonicalizeEvents() output is missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestCanonicalizeEvents_DeterministicPlaceholders(t *testing.T) {
+	// IDs first appearing in sibling map values must get the same placeholders regardless of
+	// the map iteration order.
+	newEvents := func() []a2a.Event {
+		task := &a2a.Task{ID: "task", ContextID: "ctx", Metadata: map[string]any{}}
+		for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+			task.Metadata[key] = map[string]any{"id": "id-of-" + key}
+		}
+		return []a2a.Event{task}
+	}
+
+	want, err := CanonicalizeEvents(newEvents())
+	if err != nil {
+		t.Fatalf("CanonicalizeEvents() error = %v", err)
+	}
+	for range 20 {
+		got, err := CanonicalizeEvents(newEvents())
+		if err != nil {
+			t.Fatalf("CanonicalizeEvents() error = %v", err)
+		}
+		if string(got) != string(want) {
+			t.Fatalf("CanonicalizeEvents() is not deterministic:\n%s\n%s", got, want)
+		}
+	}
+
+	var decoded []struct {
+		Event struct {
+			Metadata map[string]struct {
+				ID string `json:"id"`
+			} `json:"metadata"`
+		} `json:"event"`
+	}
+	if err := json.Unmarshal(want, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	// "<id-1>" is taken by the Task ID which is visited before metadata.
+	if got := decoded[0].Event.Metadata["a"].ID; got != "<id-2>" {
+		t.Errorf("metadata.a.id = %q, want placeholders numbered in sorted key order", got)
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	AssertGolden(t, "testdata/events.golden", newGoldenEvents())
+}
+
+func TestAssertGolden_Update(t *testing.T) {
+	t.Setenv(UpdateGoldenEnv, "1")
+	path := t.TempDir() + "/nested/events.golden"
+	AssertGolden(t, path, newGoldenEvents())
+
+	t.Setenv(UpdateGoldenEnv, "")
+	AssertGolden(t, path, newGoldenEvents())
+}
@@ -0,0 +1,402 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrvtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
+)
+
+// SimulationStart is the time VirtualClock of a new Simulation is set to.
+var SimulationStart = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Simulation is a scripted client driving an AgentExecutor through a single Task.
+// Execute and Cancel calls run until they return or until the executor starts waiting
+// on the Clock attached to the context with a2asrv.WithClock. Waiting executions resume
+// when the clock is advanced.
+type Simulation struct {
+	// Executor is the AgentExecutor under test.
+	Executor a2asrv.AgentExecutor
+	// Clock is the virtual clock attached to executor contexts.
+	Clock *VirtualClock
+	// TaskID is the ID of the simulated Task.
+	TaskID a2a.TaskID
+	// ContextID is the context ID of the simulated Task.
+	ContextID string
+
+	queue   *recordingQueue
+	sent    int
+	updates *taskupdate.Manager
+	applied int
+	running chan error
+	cancel  context.CancelFunc
+}
+
+// NewSimulation creates a Simulation with deterministic Task and context IDs.
+func NewSimulation(executor a2asrv.AgentExecutor) *Simulation {
+	return &Simulation{
+		Executor:  executor,
+		Clock:     NewVirtualClock(SimulationStart),
+		TaskID:    "task-1",
+		ContextID: "context-1",
+		queue:     &recordingQueue{},
+	}
+}
+
+// Task returns the Task state built from the events emitted so far or nil if there were no Task events.
+func (s *Simulation) Task() *a2a.Task {
+	if s.updates == nil {
+		return nil
+	}
+	return s.updates.Task
+}
+
+// Events returns all the events emitted by the executor.
+func (s *Simulation) Events() []a2a.Event {
+	return s.queue.snapshot(0)
+}
+
+// Send invokes Execute with the message and returns the events emitted until the execution
+// returned or started waiting on the clock.
+func (s *Simulation) Send(ctx context.Context, msg a2a.Message) ([]a2a.Event, error) {
+	if s.running != nil {
+		return nil, errors.New("executor is still running")
+	}
+	s.sent++
+	if msg.ID == "" {
+		msg.ID = fmt.Sprintf("message-%d", s.sent)
+	}
+	msg.TaskID, msg.ContextID = s.TaskID, s.ContextID
+	reqCtx := s.requestContext(a2a.MessageSendParams{Message: msg})
+	return s.start(ctx, func(ctx context.Context) error {
+		return s.Executor.Execute(ctx, reqCtx, s.queue)
+	})
+}
+
+// Cancel invokes executor Cancel and then cancels the context of the running execution if any.
+// Returns the events emitted until both calls returned or started waiting on the clock.
+func (s *Simulation) Cancel(ctx context.Context) ([]a2a.Event, error) {
+	mark := s.queue.len()
+	execution, cancelExecution := s.running, s.cancel
+	s.running, s.cancel = nil, nil
+
+	reqCtx := s.requestContext(a2a.MessageSendParams{})
+	if _, err := s.start(ctx, func(ctx context.Context) error {
+		return s.Executor.Cancel(ctx, reqCtx, s.queue)
+	}); err != nil {
+		return s.collect(mark, err)
+	}
+	if execution != nil {
+		cancelExecution()
+		if err := <-execution; err != nil && !errors.Is(err, context.Canceled) {
+			return s.collect(mark, err)
+		}
+	}
+	return s.collect(mark, nil)
+}
+
+// Advance moves the clock forward and returns the events emitted until the running execution
+// returned or started waiting on the clock again.
+func (s *Simulation) Advance(ctx context.Context, d time.Duration) ([]a2a.Event, error) {
+	mark := s.queue.len()
+	// a running execution can only make progress if it was waiting for one of the fired timers
+	if fired := s.Clock.Advance(d); fired == 0 || s.running == nil {
+		return s.collect(mark, nil)
+	}
+	return s.collect(mark, s.wait(ctx))
+}
+
+// Resubscribe returns the current Task state as a client resubscribing to the Task would see it.
+func (s *Simulation) Resubscribe(ctx context.Context) ([]a2a.Event, error) {
+	if s.updates == nil {
+		return nil, a2a.ErrTaskNotFound
+	}
+	task, err := copyEvent(s.updates.Task)
+	if err != nil {
+		return nil, err
+	}
+	return []a2a.Event{task}, nil
+}
+
+func (s *Simulation) requestContext(params a2a.MessageSendParams) a2asrv.RequestContext {
+	reqCtx := a2asrv.RequestContext{Request: params, TaskID: s.TaskID, ContextID: s.ContextID}
+	if s.updates != nil {
+		task := *s.updates.Task
+		reqCtx.Task = &task
+	}
+	return reqCtx
+}
+
+func (s *Simulation) start(ctx context.Context, call func(ctx context.Context) error) ([]a2a.Event, error) {
+	mark := s.queue.len()
+	callCtx, cancel := context.WithCancel(a2asrv.WithClock(ctx, s.Clock))
+	done := make(chan error, 1)
+	go func() { done <- call(callCtx) }()
+	s.running, s.cancel = done, cancel
+
+	return s.collect(mark, s.wait(ctx))
+}
+
+func (s *Simulation) wait(ctx context.Context) error {
+	select {
+	case err := <-s.running:
+		s.cancel()
+		s.running, s.cancel = nil, nil
+		return err
+	case <-s.Clock.Blocked():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// collect returns the events emitted since mark together with err and applies new events to the Task.
+func (s *Simulation) collect(mark int, err error) ([]a2a.Event, error) {
+	events := s.queue.snapshot(mark)
+	for _, event := range s.queue.snapshot(s.applied) {
+		if applyErr := s.apply(event); applyErr != nil {
+			err = errors.Join(err, applyErr)
+			break
+		}
+	}
+	s.applied = s.queue.len()
+	return events, err
+}
+
+// apply updates the Task the same way the default RequestHandler does.
+func (s *Simulation) apply(event a2a.Event) error {
+	switch event.(type) {
+	case *a2a.Task, *a2a.TaskStatusUpdateEvent, *a2a.TaskArtifactUpdateEvent:
+	default:
+		return nil
+	}
+	// the Task takes ownership of the applied events, so the recorded ones need to stay untouched
+	event, err := copyEvent(event)
+	if err != nil {
+		return err
+	}
+	if s.updates == nil {
+		s.updates = taskupdate.NewManager(nopSaver{}, &a2a.Task{ID: s.TaskID, ContextID: s.ContextID})
+	}
+	if err := s.updates.Process(context.Background(), event); err != nil {
+		return fmt.Errorf("failed to apply %s event: %w", eventKind(event), err)
+	}
+	return nil
+}
+
+func copyEvent(event a2a.Event) (a2a.Event, error) {
+	data, err := a2a.MarshalEvent(event)
+	if err != nil {
+		return nil, err
+	}
+	return a2a.UnmarshalEvent(data)
+}
+
+type nopSaver struct{}
+
+func (nopSaver) Save(ctx context.Context, task *a2a.Task) error {
+	return nil
+}
+
+// Action is a scripted client action.
+type Action func(ctx context.Context, s *Simulation) ([]a2a.Event, error)
+
+// SendText sends a user message with a single TextPart.
+func SendText(text string) Action {
+	return func(ctx context.Context, s *Simulation) ([]a2a.Event, error) {
+		return s.Send(ctx, a2a.Message{Role: a2a.MessageRoleUser, Parts: a2a.ContentParts{a2a.TextPart{Text: text}}})
+	}
+}
+
+// Cancel cancels the Task.
+func Cancel() Action {
+	return func(ctx context.Context, s *Simulation) ([]a2a.Event, error) {
+		return s.Cancel(ctx)
+	}
+}
+
+// Advance moves the virtual clock forward.
+func Advance(d time.Duration) Action {
+	return func(ctx context.Context, s *Simulation) ([]a2a.Event, error) {
+		return s.Advance(ctx, d)
+	}
+}
+
+// Resubscribe returns the current Task snapshot.
+func Resubscribe() Action {
+	return func(ctx context.Context, s *Simulation) ([]a2a.Event, error) {
+		return s.Resubscribe(ctx)
+	}
+}
+
+// EventMatcher returns an error if the event doesn't match the expectation.
+type EventMatcher func(event a2a.Event) error
+
+// StatusUpdate matches a TaskStatusUpdateEvent with the state.
+func StatusUpdate(state a2a.TaskState) EventMatcher {
+	return func(event a2a.Event) error {
+		update, ok := event.(*a2a.TaskStatusUpdateEvent)
+		if !ok {
+			return fmt.Errorf("got %T, want status update", event)
+		}
+		if update.Status.State != state {
+			return fmt.Errorf("got status %s, want %s", update.Status.State, state)
+		}
+		return nil
+	}
+}
+
+// TaskSnapshot matches a Task in the state.
+func TaskSnapshot(state a2a.TaskState) EventMatcher {
+	return func(event a2a.Event) error {
+		task, ok := event.(*a2a.Task)
+		if !ok {
+			return fmt.Errorf("got %T, want task", event)
+		}
+		if task.Status.State != state {
+			return fmt.Errorf("got task in %s, want %s", task.Status.State, state)
+		}
+		return nil
+	}
+}
+
+// ArtifactUpdate matches any TaskArtifactUpdateEvent.
+func ArtifactUpdate() EventMatcher {
+	return func(event a2a.Event) error {
+		if _, ok := event.(*a2a.TaskArtifactUpdateEvent); !ok {
+			return fmt.Errorf("got %T, want artifact update", event)
+		}
+		return nil
+	}
+}
+
+// AgentMessage matches any Message.
+func AgentMessage() EventMatcher {
+	return func(event a2a.Event) error {
+		if _, ok := event.(*a2a.Message); !ok {
+			return fmt.Errorf("got %T, want message", event)
+		}
+		return nil
+	}
+}
+
+// Step is a scripted client action with expectations for the events it produces.
+type Step struct {
+	// Name is used in failure messages. Defaults to the step index.
+	Name string
+	// Do is the action to perform.
+	Do Action
+	// Want must match the emitted events one-to-one and in order.
+	Want []EventMatcher
+	// WantErr is set when the action is expected to fail.
+	WantErr bool
+}
+
+// Run executes the steps in order failing the test on the first unmet expectation.
+func (s *Simulation) Run(t testing.TB, steps ...Step) {
+	t.Helper()
+	ctx := t.Context()
+	for i, step := range steps {
+		name := step.Name
+		if name == "" {
+			name = fmt.Sprintf("step %d", i)
+		}
+		events, err := step.Do(ctx, s)
+		if step.WantErr != (err != nil) {
+			t.Fatalf("%s: error = %v, want error %v", name, err, step.WantErr)
+		}
+		if len(events) != len(step.Want) {
+			t.Fatalf("%s: got %d events, want %d: %v", name, len(events), len(step.Want), describeEvents(events))
+		}
+		for j, match := range step.Want {
+			if err := match(events[j]); err != nil {
+				t.Fatalf("%s: event %d: %v", name, j, err)
+			}
+		}
+	}
+}
+
+func describeEvents(events []a2a.Event) []string {
+	result := make([]string, len(events))
+	for i, event := range events {
+		switch v := event.(type) {
+		case *a2a.TaskStatusUpdateEvent:
+			result[i] = eventKind(event) + ":" + string(v.Status.State)
+		case *a2a.Task:
+			result[i] = eventKind(event) + ":" + string(v.Status.State)
+		default:
+			result[i] = eventKind(event)
+		}
+	}
+	return result
+}
+
+func eventKind(event a2a.Event) string {
+	switch event.(type) {
+	case *a2a.Message:
+		return "message"
+	case *a2a.Task:
+		return "task"
+	case *a2a.TaskStatusUpdateEvent:
+		return "status-update"
+	case *a2a.TaskArtifactUpdateEvent:
+		return "artifact-update"
+	default:
+		return fmt.Sprintf("%T", event)
+	}
+}
+
+// recordingQueue is an unbounded eventqueue.Queue which records all the written events.
+type recordingQueue struct {
+	mu     sync.Mutex
+	events []a2a.Event
+}
+
+func (q *recordingQueue) Write(ctx context.Context, event a2a.Event) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.events = append(q.events, event)
+	return nil
+}
+
+func (q *recordingQueue) Read(ctx context.Context) (a2a.Event, error) {
+	return nil, errors.New("simulation queue doesn't support reads")
+}
+
+func (q *recordingQueue) Close() error {
+	return nil
+}
+
+func (q *recordingQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.events)
+}
+
+func (q *recordingQueue) snapshot(from int) []a2a.Event {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	result := make([]a2a.Event, len(q.events)-from)
+	copy(result, q.events[from:])
+	return result
+}
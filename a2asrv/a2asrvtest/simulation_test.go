@@ -0,0 +1,152 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrvtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// bookingExecutor asks for a destination, then spends an hour "booking" before completing.
+type bookingExecutor struct{}
+
+func (bookingExecutor) Execute(ctx context.Context, reqCtx a2asrv.RequestContext, queue eventqueue.Queue) error {
+	if reqCtx.Task == nil {
+		task := &a2a.Task{ID: reqCtx.TaskID, ContextID: reqCtx.ContextID, Status: a2a.TaskStatus{State: a2a.TaskStateSubmitted}}
+		if err := queue.Write(ctx, task); err != nil {
+			return err
+		}
+		return queue.Write(ctx, a2a.NewStatusUpdateEvent(task, a2a.TaskStateInputRequired, nil))
+	}
+
+	task := reqCtx.Task
+	if err := queue.Write(ctx, a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil)); err != nil {
+		return err
+	}
+	select {
+	case <-a2asrv.ClockFrom(ctx).After(time.Hour):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if err := queue.Write(ctx, a2a.NewArtifactEvent(*task, a2a.TextPart{Text: "ticket"})); err != nil {
+		return err
+	}
+	return queue.Write(ctx, a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil))
+}
+
+func (bookingExecutor) Cancel(ctx context.Context, reqCtx a2asrv.RequestContext, queue eventqueue.Queue) error {
+	if reqCtx.Task == nil {
+		return errors.New("nothing to cancel")
+	}
+	return queue.Write(ctx, a2a.NewStatusUpdateEvent(reqCtx.Task, a2a.TaskStateCanceled, nil))
+}
+
+func TestSimulation_MultiTurn(t *testing.T) {
+	sim := NewSimulation(bookingExecutor{})
+	sim.Run(t,
+		Step{Name: "ask", Do: SendText("book a flight"), Want: []EventMatcher{
+			TaskSnapshot(a2a.TaskStateSubmitted),
+			StatusUpdate(a2a.TaskStateInputRequired),
+		}},
+		Step{Name: "answer", Do: SendText("to Paris"), Want: []EventMatcher{
+			StatusUpdate(a2a.TaskStateWorking),
+		}},
+		Step{Name: "resubscribe", Do: Resubscribe(), Want: []EventMatcher{
+			TaskSnapshot(a2a.TaskStateWorking),
+		}},
+		Step{Name: "not yet", Do: Advance(59 * time.Minute)},
+		Step{Name: "booked", Do: Advance(time.Minute), Want: []EventMatcher{
+			ArtifactUpdate(),
+			StatusUpdate(a2a.TaskStateCompleted),
+		}},
+	)
+
+	task := sim.Task()
+	if task.Status.State != a2a.TaskStateCompleted || len(task.Artifacts) != 1 {
+		t.Fatalf("Task() = %+v, want completed task with an artifact", task)
+	}
+	if got := sim.Clock.Now(); !got.Equal(SimulationStart.Add(time.Hour)) {
+		t.Fatalf("Clock.Now() = %v, want %v", got, SimulationStart.Add(time.Hour))
+	}
+	if len(sim.Events()) != 5 {
+		t.Fatalf("Events() returned %d events, want 5", len(sim.Events()))
+	}
+}
+
+func TestSimulation_CancelWhileWaiting(t *testing.T) {
+	sim := NewSimulation(bookingExecutor{})
+	sim.Run(t,
+		Step{Do: SendText("book a flight"), Want: []EventMatcher{
+			TaskSnapshot(a2a.TaskStateSubmitted),
+			StatusUpdate(a2a.TaskStateInputRequired),
+		}},
+		Step{Do: SendText("to Paris"), Want: []EventMatcher{StatusUpdate(a2a.TaskStateWorking)}},
+		Step{Name: "cancel", Do: Cancel(), Want: []EventMatcher{StatusUpdate(a2a.TaskStateCanceled)}},
+		Step{Name: "nothing after cancel", Do: Advance(2 * time.Hour)},
+	)
+	if sim.Task().Status.State != a2a.TaskStateCanceled {
+		t.Fatalf("Task() state = %s, want %s", sim.Task().Status.State, a2a.TaskStateCanceled)
+	}
+}
+
+func TestSimulation_Failures(t *testing.T) {
+	sim := NewSimulation(bookingExecutor{})
+	sim.Run(t,
+		Step{Name: "resubscribe to unknown task", Do: Resubscribe(), WantErr: true},
+		Step{Name: "cancel unknown task", Do: Cancel(), WantErr: true},
+	)
+}
+
+// draftingExecutor streams an artifact in chunks and then replaces it.
+type draftingExecutor struct{}
+
+func (draftingExecutor) Execute(ctx context.Context, reqCtx a2asrv.RequestContext, queue eventqueue.Queue) error {
+	task := &a2a.Task{ID: reqCtx.TaskID, ContextID: reqCtx.ContextID, Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+	chunk := a2a.NewArtifactEvent(*task, a2a.TextPart{Text: "draft"})
+	appended := a2a.NewArtifactUpdateEvent(*task, chunk.Artifact.ID, a2a.TextPart{Text: " more"})
+	replaced := a2a.NewArtifactUpdateEvent(*task, chunk.Artifact.ID, a2a.TextPart{Text: "final"})
+	replaced.Append = false
+	for _, event := range []a2a.Event{task, chunk, appended, replaced} {
+		if err := queue.Write(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (draftingExecutor) Cancel(ctx context.Context, reqCtx a2asrv.RequestContext, queue eventqueue.Queue) error {
+	return nil
+}
+
+func TestSimulation_ArtifactAggregation(t *testing.T) {
+	sim := NewSimulation(draftingExecutor{})
+	sim.Run(t, Step{Do: SendText("write"), Want: []EventMatcher{
+		TaskSnapshot(a2a.TaskStateWorking),
+		ArtifactUpdate(),
+		ArtifactUpdate(),
+		ArtifactUpdate(),
+	}})
+
+	artifacts := sim.Task().Artifacts
+	if len(artifacts) != 1 || len(artifacts[0].Parts) != 1 || artifacts[0].Parts[0].(a2a.TextPart).Text != "final" {
+		t.Fatalf("Task().Artifacts = %v, want the replaced artifact", artifacts)
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
+)
+
+// ErrOperatorCanceled is the cause the execution context of a Task force-canceled through TaskAdmin gets canceled with.
+var ErrOperatorCanceled = fmt.Errorf("task canceled by operator: %w", context.Canceled)
+
+// TaskLister is an optional interface of TaskStore implementations which can enumerate stored Tasks.
+type TaskLister interface {
+	// ListTasks returns the Tasks in the provided state or all the Tasks if the state is empty.
+	// Tasks must be returned in a stable order for pagination to work.
+	ListTasks(ctx context.Context, state a2a.TaskState) ([]a2a.Task, error)
+}
+
+// ListTasksRequest selects the Tasks returned by TaskAdmin.
+type ListTasksRequest struct {
+	// State filters Tasks by state. All Tasks are returned if it's empty.
+	State a2a.TaskState
+	// PageSize is the maximum number of Tasks to return. Defaults to a2a.DefaultPageSize.
+	PageSize int
+	// PageToken is the NextPageToken of the previous page.
+	PageToken string
+}
+
+// ListTasksResult is a page of Tasks returned by TaskAdmin.
+type ListTasksResult struct {
+	Tasks         []a2a.Task `json:"tasks"`
+	NextPageToken string     `json:"nextPageToken,omitempty"`
+}
+
+// TaskAdmin is an optional interface of RequestHandler implementations which support operational actions
+// which are not part of the A2A protocol. It must only be exposed to operators, for example using NewAdminMux.
+type TaskAdmin interface {
+	// ListTasks returns a page of stored Tasks. Requires TaskStore to implement TaskLister.
+	ListTasks(ctx context.Context, req ListTasksRequest) (*ListTasksResult, error)
+
+	// ForceCancelTask stops the execution of the Task if it's running in this process and marks the Task as canceled
+	// without involving AgentExecutor. A Task executed by another replica is only canceled if its TaskOwnership can
+	// be acquired. Returns a2a.ErrTaskNotCancelable if the Task is in a terminal state.
+	ForceCancelTask(ctx context.Context, taskId a2a.TaskID) (a2a.Task, error)
+
+	// ReplayPush sends a push notification with the current state of the Task.
+	ReplayPush(ctx context.Context, taskId a2a.TaskID) error
+
+	// FlushQueue closes the event queue of the Task and drops events which were not consumed.
+	FlushQueue(ctx context.Context, taskId a2a.TaskID) error
+}
+
+// ListTasks implements TaskAdmin.
+func (h *defaultRequestHandler) ListTasks(ctx context.Context, req ListTasksRequest) (*ListTasksResult, error) {
+	lister, ok := h.taskStore.(TaskLister)
+	if !ok {
+		return nil, fmt.Errorf("task store doesn't support listing: %w", a2a.ErrUnsupportedOperation)
+	}
+	tasks, err := lister.ListTasks(ctx, req.State)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	page, next, err := Paginate(tasks, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	return &ListTasksResult{Tasks: page, NextPageToken: next}, nil
+}
+
+// ForceCancelTask implements TaskAdmin. The Task is canceled the same way as by OnCancelTask, except
+// that AgentExecutor.Cancel is not called.
+func (h *defaultRequestHandler) ForceCancelTask(ctx context.Context, taskId a2a.TaskID) (a2a.Task, error) {
+	if h.taskStore == nil {
+		return a2a.Task{}, errors.New("task store is not configured")
+	}
+	task, version, release, err := h.stopTask(ctx, taskId, ErrOperatorCanceled)
+	if err != nil {
+		return a2a.Task{}, err
+	}
+	if release == nil {
+		// canceled by the stopped execution
+		return task, nil
+	}
+	defer release()
+
+	canceledCtx, cancel := context.WithCancelCause(ctx)
+	cancel(ErrOperatorCanceled)
+	updates := taskupdate.NewManager(h.taskSaver(), &task)
+	updates.Version = version
+	if err := updates.Process(ctx, NewCancellationEvent(canceledCtx, &task)); err != nil {
+		return a2a.Task{}, fmt.Errorf("failed to save task: %w", err)
+	}
+	return *updates.Task, nil
+}
+
+// ReplayPush implements TaskAdmin.
+func (h *defaultRequestHandler) ReplayPush(ctx context.Context, taskId a2a.TaskID) error {
+	if h.taskStore == nil || h.pushNotifier == nil {
+		return errors.New("task store and push notifier must be configured")
+	}
+	task, err := h.taskStore.Get(ctx, taskId)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+	if err := h.pushNotifier.SendPush(ctx, task); err != nil {
+		return fmt.Errorf("failed to send push notification: %w", err)
+	}
+	return nil
+}
+
+// FlushQueue implements TaskAdmin.
+func (h *defaultRequestHandler) FlushQueue(ctx context.Context, taskId a2a.TaskID) error {
+	if err := h.queueManager.Destroy(ctx, taskId); err != nil {
+		return fmt.Errorf("failed to destroy queue: %w", err)
+	}
+	return nil
+}
+
+// AdminConfig configures the admin endpoints created by NewAdminMux.
+type AdminConfig struct {
+	// Handler is the RequestHandler which must implement TaskAdmin.
+	Handler RequestHandler
+	// Authorize is called for every request. Requests for which it returns an error are rejected.
+	// All requests are rejected if Authorize is nil. It should use credentials separate from the ones
+	// of A2A clients.
+	Authorize func(req *http.Request) error
+}
+
+// NewAdminMux creates an opt-in http.Handler with operational endpoints which should be served
+// separately from the A2A endpoints:
+//   - GET /admin/a2a/tasks?state=&pageSize=&pageToken= serves ListTasksResult,
+//   - POST /admin/a2a/tasks/{id}/cancel force-cancels the Task and serves it,
+//   - POST /admin/a2a/tasks/{id}/push replays the push notification of the Task,
+//   - POST /admin/a2a/tasks/{id}/flush flushes the event queue of the Task.
+func NewAdminMux(cfg AdminConfig) http.Handler {
+	admin, _ := cfg.Handler.(TaskAdmin)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/a2a/tasks", func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		listReq := ListTasksRequest{State: a2a.TaskState(query.Get("state")), PageToken: query.Get("pageToken")}
+		if size := query.Get("pageSize"); size != "" {
+			var err error
+			if listReq.PageSize, err = strconv.Atoi(size); err != nil {
+				http.Error(w, "invalid page size", http.StatusBadRequest)
+				return
+			}
+		}
+		result, err := admin.ListTasks(req.Context(), listReq)
+		if err != nil {
+			writeAdminError(w, err)
+			return
+		}
+		writeDebugJSON(w, result)
+	})
+	mux.HandleFunc("POST /admin/a2a/tasks/{id}/cancel", func(w http.ResponseWriter, req *http.Request) {
+		task, err := admin.ForceCancelTask(req.Context(), a2a.TaskID(req.PathValue("id")))
+		if err != nil {
+			writeAdminError(w, err)
+			return
+		}
+		writeDebugJSON(w, task)
+	})
+	mux.HandleFunc("POST /admin/a2a/tasks/{id}/push", func(w http.ResponseWriter, req *http.Request) {
+		if err := admin.ReplayPush(req.Context(), a2a.TaskID(req.PathValue("id"))); err != nil {
+			writeAdminError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /admin/a2a/tasks/{id}/flush", func(w http.ResponseWriter, req *http.Request) {
+		if err := admin.FlushQueue(req.Context(), a2a.TaskID(req.PathValue("id"))); err != nil {
+			writeAdminError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if cfg.Authorize == nil {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if err := cfg.Authorize(req); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if admin == nil {
+			http.Error(w, "handler doesn't support admin actions", http.StatusNotFound)
+			return
+		}
+		mux.ServeHTTP(w, req)
+	})
+}
+
+func writeAdminError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, a2a.ErrTaskNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, a2a.ErrTaskNotCancelable):
+		status = http.StatusConflict
+	case errors.Is(err, a2a.ErrInvalidRequest):
+		status = http.StatusBadRequest
+	case errors.Is(err, a2a.ErrUnsupportedOperation):
+		status = http.StatusNotImplemented
+	}
+	http.Error(w, err.Error(), status)
+}
+
+// runningExecutions tracks the executions in progress, so that they can be canceled.
+type runningExecutions struct {
+	mu         sync.Mutex
+	executions map[a2a.TaskID]*runningExecution
+}
+
+// runningExecution is an execution registered in runningExecutions.
+type runningExecution struct {
+	cancel context.CancelCauseFunc
+	// done is closed once the events of the execution were applied to the Task and its TaskOwnership was released.
+	done chan struct{}
+}
+
+func (e *runningExecutions) add(taskId a2a.TaskID, cancel context.CancelCauseFunc) *runningExecution {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.executions == nil {
+		e.executions = make(map[a2a.TaskID]*runningExecution)
+	}
+	execution := &runningExecution{cancel: cancel, done: make(chan struct{})}
+	e.executions[taskId] = execution
+	return execution
+}
+
+// finish unregisters the execution and wakes up the callers waiting for it. Nothing is done if it's nil.
+func (e *runningExecutions) finish(taskId a2a.TaskID, execution *runningExecution) {
+	if execution == nil {
+		return
+	}
+	e.mu.Lock()
+	if e.executions[taskId] == execution {
+		delete(e.executions, taskId)
+	}
+	e.mu.Unlock()
+	close(execution.done)
+}
+
+// cancel stops the execution of the Task if it's in progress in this process. The returned channel is closed
+// once the events of the execution were applied to the Task. It is nil if the Task is not executed by this process.
+func (e *runningExecutions) cancel(taskId a2a.TaskID, cause error) <-chan struct{} {
+	e.mu.Lock()
+	execution, ok := e.executions[taskId]
+	e.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	execution.cancel(cause)
+	return execution.done
+}
+
+// canceledByRequest reports whether the execution was stopped by OnCancelTask or TaskAdmin.ForceCancelTask,
+// in which case the Task is moved to a terminal state after its events are applied.
+func canceledByRequest(execCtx context.Context) bool {
+	cause := context.Cause(execCtx)
+	return errors.Is(cause, ErrTaskCanceled) || errors.Is(cause, ErrOperatorCanceled)
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// listingTaskStore is a testTaskStore which lists Tasks ordered by ID.
+type listingTaskStore struct {
+	testTaskStore
+}
+
+func newListingTaskStore(tasks ...a2a.Task) *listingTaskStore {
+	store := &listingTaskStore{testTaskStore{tasks: map[a2a.TaskID]a2a.Task{}, transitions: map[a2a.TaskID][]a2a.TaskStatus{}}}
+	for _, task := range tasks {
+		_ = store.Save(context.Background(), task)
+	}
+	return store
+}
+
+func (s *listingTaskStore) ListTasks(ctx context.Context, state a2a.TaskState) ([]a2a.Task, error) {
+	var result []a2a.Task
+	for _, task := range s.tasks {
+		if state == "" || task.Status.State == state {
+			result = append(result, task)
+		}
+	}
+	slices.SortFunc(result, func(a, b a2a.Task) int { return strings.Compare(string(a.ID), string(b.ID)) })
+	return result, nil
+}
+
+func newAdminMux(handler RequestHandler) http.Handler {
+	return NewAdminMux(AdminConfig{Handler: handler, Authorize: func(req *http.Request) error { return nil }})
+}
+
+func TestNewAdminMux_Authorization(t *testing.T) {
+	rejected := NewAdminMux(AdminConfig{Handler: NewHandler(&mockAgentExecutor{})})
+	rec := httptest.NewRecorder()
+	rejected.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/a2a/tasks", nil))
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("ServeHTTP() without Authorize status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	unsupported := newAdminMux(NewLoggingHandler(NewHandler(&mockAgentExecutor{}), nil))
+	rec = httptest.NewRecorder()
+	unsupported.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/a2a/tasks", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("ServeHTTP() for handler without TaskAdmin status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestNewAdminMux_ListTasks(t *testing.T) {
+	store := newListingTaskStore(
+		a2a.Task{ID: "a", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}},
+		a2a.Task{ID: "b", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}},
+		a2a.Task{ID: "c", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}},
+	)
+	mux := newAdminMux(NewHandler(&mockAgentExecutor{}, WithTaskStore(store)))
+
+	var got []a2a.TaskID
+	token := ""
+	for {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/a2a/tasks?state=working&pageSize=1&pageToken="+token, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET /admin/a2a/tasks status = %d, body = %s", rec.Code, rec.Body)
+		}
+		var result ListTasksResult
+		if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+			t.Fatalf("failed to decode result: %v", err)
+		}
+		for _, task := range result.Tasks {
+			got = append(got, task.ID)
+		}
+		if token = result.NextPageToken; token == "" {
+			break
+		}
+	}
+	if want := []a2a.TaskID{"a", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("listed tasks = %v, want %v", got, want)
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/a2a/tasks?pageSize=-1", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("GET /admin/a2a/tasks with negative page size status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestDefaultRequestHandler_ForceCancelTask(t *testing.T) {
+	ctx := t.Context()
+	store := newListingTaskStore(
+		a2a.Task{ID: taskID, Status: a2a.TaskStatus{State: a2a.TaskStateWorking}},
+		a2a.Task{ID: "done", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}},
+	)
+	started, stopped := make(chan struct{}), make(chan error, 1)
+	executor := &mockAgentExecutor{ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+		close(started)
+		<-ctx.Done()
+		stopped <- context.Cause(ctx)
+		return context.Cause(ctx)
+	}}
+	handler := NewHandler(executor, WithTaskStore(store))
+	go func() {
+		_, _ = handler.OnSendMessage(ctx, a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID}})
+	}()
+	<-started
+
+	task, err := handler.(TaskAdmin).ForceCancelTask(ctx, taskID)
+	if err != nil {
+		t.Fatalf("ForceCancelTask() error = %v", err)
+	}
+	if cause := <-stopped; !errors.Is(cause, ErrOperatorCanceled) {
+		t.Fatalf("execution canceled with %v, want %v", cause, ErrOperatorCanceled)
+	}
+	if task.Status.State != a2a.TaskStateCanceled || task.Status.Message.Metadata[CancelCauseMetaKey] != ErrOperatorCanceled.Error() {
+		t.Fatalf("ForceCancelTask() status = %+v, want canceled by operator", task.Status)
+	}
+	if _, err := handler.(TaskAdmin).ForceCancelTask(ctx, "done"); !errors.Is(err, a2a.ErrTaskNotCancelable) {
+		t.Fatalf("ForceCancelTask() for completed task error = %v, want %v", err, a2a.ErrTaskNotCancelable)
+	}
+}
+
+func TestDefaultRequestHandler_ForceCancelTask_OwnedElsewhere(t *testing.T) {
+	ctx := t.Context()
+	store := newListingTaskStore(a2a.Task{ID: taskID, Status: a2a.TaskStatus{State: a2a.TaskStateWorking}})
+	ownedErr := errors.New("owned by another replica")
+	ownership := &mockTaskOwnership{AcquireFunc: func(ctx context.Context, taskId a2a.TaskID) error {
+		return ownedErr
+	}}
+	handler := NewHandler(&mockAgentExecutor{}, WithTaskStore(store), WithTaskOwnership(ownership))
+
+	if _, err := handler.(TaskAdmin).ForceCancelTask(ctx, taskID); !errors.Is(err, ownedErr) {
+		t.Fatalf("ForceCancelTask() error = %v, want %v", err, ownedErr)
+	}
+	task, err := store.Get(ctx, taskID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if task.Status.State != a2a.TaskStateWorking {
+		t.Fatalf("stored task state = %v, want %v", task.Status.State, a2a.TaskStateWorking)
+	}
+}
+
+func TestNewAdminMux_Actions(t *testing.T) {
+	store := newListingTaskStore(a2a.Task{ID: taskID, Status: a2a.TaskStatus{State: a2a.TaskStateWorking}})
+	notifier := &blockingPushNotifier{release: make(chan struct{}), sent: make(chan a2a.Task, 10)}
+	close(notifier.release)
+	var destroyed []a2a.TaskID
+	queues := &mockQueueManager{DestroyFunc: func(ctx context.Context, taskId a2a.TaskID) error {
+		destroyed = append(destroyed, taskId)
+		return nil
+	}}
+	mux := newAdminMux(NewHandler(&mockAgentExecutor{}, WithTaskStore(store), WithPushNotifier(notifier), WithEventQueueManager(queues)))
+
+	testCases := []struct {
+		path string
+		want int
+	}{
+		{path: "/admin/a2a/tasks/" + string(taskID) + "/push", want: http.StatusNoContent},
+		{path: "/admin/a2a/tasks/missing/push", want: http.StatusNotFound},
+		{path: "/admin/a2a/tasks/" + string(taskID) + "/flush", want: http.StatusNoContent},
+		{path: "/admin/a2a/tasks/" + string(taskID) + "/cancel", want: http.StatusOK},
+		{path: "/admin/a2a/tasks/" + string(taskID) + "/cancel", want: http.StatusConflict},
+	}
+	for _, tc := range testCases {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tc.path, nil))
+		if rec.Code != tc.want {
+			t.Fatalf("POST %s status = %d, want %d, body = %s", tc.path, rec.Code, tc.want, rec.Body)
+		}
+	}
+	// the replayed notification is followed by the one about the forced cancellation
+	var states []a2a.TaskState
+	for range 2 {
+		select {
+		case sent := <-notifier.sent:
+			states = append(states, sent.Status.State)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("push notifications sent for states %v, want 2 notifications", states)
+		}
+	}
+	if want := []a2a.TaskState{a2a.TaskStateWorking, a2a.TaskStateCanceled}; !slices.Equal(states, want) {
+		t.Fatalf("push notifications sent for states %v, want %v", states, want)
+	}
+	if !slices.Equal(destroyed, []a2a.TaskID{taskID}) {
+		t.Fatalf("queues flushed for %v, want %v", destroyed, []a2a.TaskID{taskID})
+	}
+}
@@ -39,6 +39,22 @@ type AgentExecutor interface {
 	Cancel(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error
 }
 
+// RequestValidator can be implemented by an AgentExecutor to check requests without executing them.
+// The handler calls it instead of Execute for dry run requests, see a2a.DryRunMetaKey. Middlewares
+// created using AgentExecutorFuncs forward the call to the executor they wrap.
+type RequestValidator interface {
+	// Validate returns an error if Execute would reject the request.
+	Validate(ctx context.Context, reqCtx RequestContext) error
+}
+
+// validateRequest calls Validate if the executor implements RequestValidator.
+func validateRequest(ctx context.Context, executor AgentExecutor, reqCtx RequestContext) error {
+	if validator, ok := executor.(RequestValidator); ok {
+		return validator.Validate(ctx, reqCtx)
+	}
+	return nil
+}
+
 // AgentCardProducer creates an AgentCard instances used for agent discovery and capability negotiation.
 type AgentCardProducer interface {
 	// Card returns a self-describing manifest for an agent. It provides essential
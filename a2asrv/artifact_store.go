@@ -0,0 +1,309 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// ErrArtifactNotFound is returned by ArtifactStore for artifacts which were not stored.
+var ErrArtifactNotFound = errors.New("artifact not found")
+
+// ArtifactStore persists the content of artifacts produced by AgentExecutor, so that the output
+// survives a dropped client connection and can be downloaded later.
+type ArtifactStore interface {
+	// Write replaces the stored content of the artifact.
+	Write(ctx context.Context, taskID a2a.TaskID, artifactID a2a.ArtifactID, data []byte) error
+	// Append appends data to the stored content of the artifact.
+	Append(ctx context.Context, taskID a2a.TaskID, artifactID a2a.ArtifactID, data []byte) error
+	// URI returns the URI clients can download the stored content from.
+	URI(ctx context.Context, taskID a2a.TaskID, artifactID a2a.ArtifactID) (string, error)
+}
+
+// WithArtifactStore tees the content of artifact updates to the store while they are streamed to the client.
+// Tasks are saved to TaskStore with the content of stored artifacts replaced by a single FileURI part
+// referencing the stored copy, so that 'tasks/get' doesn't return large inline content.
+//
+// Only artifacts consisting of TextParts or of FileParts with inline bytes are stored, text being
+// stored as "text/plain". Artifacts with DataParts, FileURI parts or a mix of text and files are
+// saved unchanged, as are artifacts which failed to be written to the store.
+func WithArtifactStore(store ArtifactStore) RequestHandlerOption {
+	return func(h *defaultRequestHandler) {
+		h.artifacts = &artifactTee{store: store, artifacts: make(map[artifactKey]*teedArtifact)}
+	}
+}
+
+type artifactKey struct {
+	taskID     a2a.TaskID
+	artifactID a2a.ArtifactID
+}
+
+type teedArtifact struct {
+	mimeType string
+	name     string
+	failed   bool
+}
+
+// artifactTee writes artifact updates to an ArtifactStore and remembers which artifacts were stored.
+type artifactTee struct {
+	store ArtifactStore
+
+	mu        sync.Mutex
+	artifacts map[artifactKey]*teedArtifact
+}
+
+func (t *artifactTee) write(ctx context.Context, event *a2a.TaskArtifactUpdateEvent) {
+	if event.Artifact == nil {
+		return
+	}
+	key := artifactKey{taskID: event.TaskID, artifactID: event.Artifact.ID}
+	data, meta, ok := artifactContent(event.Artifact.Parts)
+	mimeType := meta.MimeType
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	existing := t.artifacts[key]
+	if event.Append && existing != nil {
+		if existing.failed {
+			return
+		}
+		if !ok || (len(data) > 0 && existing.mimeType != "" && mimeType != existing.mimeType) {
+			existing.failed = true
+			return
+		}
+		if existing.mimeType == "" {
+			existing.mimeType = mimeType
+		}
+		if err := t.store.Append(ctx, key.taskID, key.artifactID, data); err != nil {
+			existing.failed = true
+		}
+		return
+	}
+
+	name := event.Artifact.Name
+	if name == "" {
+		name = meta.Name
+	}
+	artifact := &teedArtifact{mimeType: mimeType, name: name, failed: !ok}
+	t.artifacts[key] = artifact
+	if ok {
+		artifact.failed = t.store.Write(ctx, key.taskID, key.artifactID, data) != nil
+	}
+}
+
+// rewrite returns a copy of the task with the content of stored artifacts replaced by FileURI references.
+// Artifacts of tasks in a terminal state are forgotten after the rewrite.
+func (t *artifactTee) rewrite(ctx context.Context, task *a2a.Task) *a2a.Task {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var artifacts []*a2a.Artifact
+	for i, artifact := range task.Artifacts {
+		teed := t.artifacts[artifactKey{taskID: task.ID, artifactID: artifact.ID}]
+		if teed == nil || teed.failed {
+			continue
+		}
+		uri, err := t.store.URI(ctx, task.ID, artifact.ID)
+		if err != nil {
+			continue
+		}
+		if artifacts == nil {
+			artifacts = append([]*a2a.Artifact(nil), task.Artifacts...)
+		}
+		stored := *artifact
+		stored.Parts = a2a.ContentParts{a2a.FilePart{File: a2a.FileURI{
+			FileMeta: a2a.FileMeta{MimeType: teed.mimeType, Name: teed.name},
+			URI:      uri,
+		}}}
+		artifacts[i] = &stored
+	}
+
+	if task.Status.State.Terminal() {
+		for key := range t.artifacts {
+			if key.taskID == task.ID {
+				delete(t.artifacts, key)
+			}
+		}
+	}
+	if artifacts == nil {
+		return task
+	}
+	rewritten := *task
+	rewritten.Artifacts = artifacts
+	return &rewritten
+}
+
+// artifactContent concatenates the content of parts which are all text or all inline files.
+// The returned FileMeta holds the content MIME type and the name of the first named file.
+func artifactContent(parts a2a.ContentParts) ([]byte, a2a.FileMeta, bool) {
+	var data []byte
+	var meta a2a.FileMeta
+	for _, part := range parts {
+		var chunk []byte
+		var partType string
+		switch v := part.(type) {
+		case a2a.TextPart:
+			chunk, partType = []byte(v.Text), "text/plain"
+		case a2a.FilePart:
+			file, ok := v.File.(a2a.FileBytes)
+			if !ok {
+				return nil, a2a.FileMeta{}, false
+			}
+			decoded, err := base64.StdEncoding.DecodeString(file.Bytes)
+			if err != nil {
+				return nil, a2a.FileMeta{}, false
+			}
+			if meta.Name == "" {
+				meta.Name = file.Name
+			}
+			chunk, partType = decoded, file.MimeType
+			if partType == "" {
+				partType = "application/octet-stream"
+			}
+		default:
+			return nil, a2a.FileMeta{}, false
+		}
+		if meta.MimeType != "" && partType != meta.MimeType {
+			return nil, a2a.FileMeta{}, false
+		}
+		meta.MimeType = partType
+		data = append(data, chunk...)
+	}
+	return data, meta, true
+}
+
+type artifactTeeManager struct {
+	eventqueue.Manager
+	tee *artifactTee
+}
+
+func (m *artifactTeeManager) GetOrCreate(ctx context.Context, taskId a2a.TaskID) (eventqueue.Queue, error) {
+	queue, err := m.Manager.GetOrCreate(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+	return &artifactTeeQueue{Queue: queue, tee: m.tee}, nil
+}
+
+type artifactTeeQueue struct {
+	eventqueue.Queue
+	tee *artifactTee
+}
+
+func (q *artifactTeeQueue) Write(ctx context.Context, event a2a.Event) error {
+	if err := q.Queue.Write(ctx, event); err != nil {
+		return err
+	}
+	if update, ok := event.(*a2a.TaskArtifactUpdateEvent); ok {
+		q.tee.write(ctx, update)
+	}
+	return nil
+}
+
+// FileArtifactStore implements ArtifactStore keeping artifact content in files under a directory.
+// It implements http.Handler serving the stored content at "/{taskID}/{artifactID}" and should be
+// mounted at the base URL it was created with.
+type FileArtifactStore struct {
+	dir     string
+	baseURL string
+}
+
+var _ ArtifactStore = (*FileArtifactStore)(nil)
+
+// NewFileArtifactStore creates a FileArtifactStore which keeps files in dir and produces URIs
+// relative to baseURL, eg. "https://agent.example.com/artifacts".
+func NewFileArtifactStore(dir, baseURL string) *FileArtifactStore {
+	return &FileArtifactStore{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func (s *FileArtifactStore) Write(ctx context.Context, taskID a2a.TaskID, artifactID a2a.ArtifactID, data []byte) error {
+	return s.write(taskID, artifactID, data, os.O_TRUNC)
+}
+
+func (s *FileArtifactStore) Append(ctx context.Context, taskID a2a.TaskID, artifactID a2a.ArtifactID, data []byte) error {
+	return s.write(taskID, artifactID, data, os.O_APPEND)
+}
+
+func (s *FileArtifactStore) URI(ctx context.Context, taskID a2a.TaskID, artifactID a2a.ArtifactID) (string, error) {
+	if _, err := os.Stat(s.path(taskID, artifactID)); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", ErrArtifactNotFound
+		}
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s/%s", s.baseURL, url.PathEscape(string(taskID)), url.PathEscape(string(artifactID))), nil
+}
+
+func (s *FileArtifactStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	taskID, artifactID, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if !ok || taskID == "" || artifactID == "" || strings.Contains(artifactID, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	file, err := os.Open(s.path(a2a.TaskID(taskID), a2a.ArtifactID(artifactID)))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer func() { _ = file.Close() }()
+	info, err := file.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.ServeContent(w, r, artifactID, info.ModTime(), file)
+}
+
+func (s *FileArtifactStore) write(taskID a2a.TaskID, artifactID a2a.ArtifactID, data []byte, flag int) error {
+	path := s.path(taskID, artifactID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create artifact directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|flag, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open artifact file: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write artifact file: %w", err)
+	}
+	return file.Close()
+}
+
+// path encodes the IDs, so that they can't escape the store directory.
+func (s *FileArtifactStore) path(taskID a2a.TaskID, artifactID a2a.ArtifactID) string {
+	return filepath.Join(s.dir,
+		base64.RawURLEncoding.EncodeToString([]byte(taskID)),
+		base64.RawURLEncoding.EncodeToString([]byte(artifactID)))
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+func TestWithArtifactStore(t *testing.T) {
+	artifacts := NewFileArtifactStore(t.TempDir(), "https://agent.example.com/artifacts/")
+	store := &testTaskStore{tasks: make(map[a2a.TaskID]a2a.Task), transitions: make(map[a2a.TaskID][]a2a.TaskStatus)}
+	executor := &mockAgentExecutor{ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+		task := &a2a.Task{ID: reqCtx.TaskID, ContextID: "ctx"}
+		report := a2a.NewArtifactEvent(*task, a2a.TextPart{Text: "Hello, "})
+		report.Artifact.ID = "report"
+		events := []a2a.Event{
+			report,
+			a2a.NewArtifactUpdateEvent(*task, "report", a2a.TextPart{Text: "world!"}),
+			a2a.NewArtifactEvent(*task, a2a.DataPart{Data: map[string]any{"score": 1}}),
+			a2a.NewArtifactEvent(*task, a2a.FilePart{File: a2a.FileBytes{
+				FileMeta: a2a.FileMeta{MimeType: "image/png", Name: "chart.png"},
+				Bytes:    base64.StdEncoding.EncodeToString([]byte("png")),
+			}}),
+			a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil),
+		}
+		for _, event := range events {
+			if err := queue.Write(ctx, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+	handler := NewHandler(executor, WithTaskStore(store), WithArtifactStore(artifacts))
+
+	result, err := handler.OnSendMessage(t.Context(), a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID}})
+	if err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	if got := result.(*a2a.Task).Artifacts[0].Parts; len(got) != 2 {
+		t.Fatalf("OnSendMessage() artifact parts = %v, want the streamed parts", got)
+	}
+
+	saved := store.tasks[taskID]
+	if len(saved.Artifacts) != 3 {
+		t.Fatalf("saved artifacts = %v, want 3", saved.Artifacts)
+	}
+	if _, ok := saved.Artifacts[1].Parts[0].(a2a.DataPart); !ok {
+		t.Fatalf("saved data artifact = %v, want it unchanged", saved.Artifacts[1].Parts)
+	}
+	for i, want := range []struct{ uri, mimeType, name, content string }{
+		{uri: "https://agent.example.com/artifacts/" + string(taskID) + "/report", mimeType: "text/plain", content: "Hello, world!"},
+		{mimeType: "image/png", name: "chart.png", content: "png"},
+	} {
+		artifact := saved.Artifacts[i*2]
+		if len(artifact.Parts) != 1 {
+			t.Fatalf("saved artifact %d parts = %v, want a single reference", i, artifact.Parts)
+		}
+		file, ok := artifact.Parts[0].(a2a.FilePart).File.(a2a.FileURI)
+		if !ok || file.MimeType != want.mimeType || file.Name != want.name || (want.uri != "" && file.URI != want.uri) {
+			t.Fatalf("saved artifact %d = %+v, want a FileURI with %+v", i, artifact.Parts[0], want)
+		}
+
+		path := strings.TrimPrefix(file.URI, "https://agent.example.com/artifacts")
+		rec := httptest.NewRecorder()
+		artifacts.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if body, _ := io.ReadAll(rec.Body); rec.Code != http.StatusOK || string(body) != want.content {
+			t.Fatalf("GET %s = %d %q, want %q", path, rec.Code, body, want.content)
+		}
+	}
+}
+
+func TestFileArtifactStore_NotFound(t *testing.T) {
+	artifacts := NewFileArtifactStore(t.TempDir(), "https://agent.example.com/artifacts")
+	if _, err := artifacts.URI(t.Context(), "task", "missing"); err != ErrArtifactNotFound {
+		t.Fatalf("URI() error = %v, want %v", err, ErrArtifactNotFound)
+	}
+	for _, path := range []string{"/task/missing", "/../etc/passwd", "/task"} {
+		rec := httptest.NewRecorder()
+		artifacts.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("GET %s = %d, want %d", path, rec.Code, http.StatusNotFound)
+		}
+	}
+}
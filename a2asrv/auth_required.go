@@ -0,0 +1,28 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// NewAuthRequiredEvent creates a TaskStatusUpdateEvent which moves the Task to TaskStateAuthRequired.
+// The provided requirements are attached to the status message metadata so that clients can
+// acquire the right credentials before resuming the Task.
+func NewAuthRequiredEvent(task *a2a.Task, text string, reqs ...a2a.AuthRequirement) *a2a.TaskStatusUpdateEvent {
+	msg := a2a.NewMessageForTask(a2a.MessageRoleAgent, *task, a2a.TextPart{Text: text})
+	msg.Metadata = map[string]any{a2a.AuthRequirementsMetaKey: a2a.AuthRequirementsToMeta(reqs...)}
+	return a2a.NewStatusUpdateEvent(task, a2a.TaskStateAuthRequired, msg)
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestNewAuthRequiredEvent(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	reqs := []a2a.AuthRequirement{
+		{Scheme: "oauth2", Scopes: []string{"read", "write"}},
+		{Scheme: "apiKey", Scopes: []string{}},
+	}
+	event := NewAuthRequiredEvent(task, "please log in", reqs...)
+
+	if event.Status.State != a2a.TaskStateAuthRequired {
+		t.Fatalf("event state = %s, want %s", event.Status.State, a2a.TaskStateAuthRequired)
+	}
+	if event.TaskID != task.ID || event.Status.Message.TaskID != task.ID {
+		t.Fatalf("event = %+v, want event for task %s", event, task.ID)
+	}
+
+	// requirements must survive a wire round-trip
+	bytes, err := json.Marshal(event.Status.Message)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var decoded a2a.Message
+	if err := json.Unmarshal(bytes, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	got := a2a.AuthRequirementsFromMeta(decoded.Metadata)
+	want := []a2a.AuthRequirement{reqs[0], {Scheme: "apiKey"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("AuthRequirementsFromMeta() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// Causes the execution context passed to AgentExecutor gets canceled with. They can be retrieved
+// using context.Cause and match context.Canceled or context.DeadlineExceeded with errors.Is,
+// so code which only checks ctx.Err() keeps working.
+var (
+	// ErrClientDisconnected means the client which triggered the execution went away.
+	ErrClientDisconnected = fmt.Errorf("client disconnected: %w", context.Canceled)
+	// ErrTaskCanceled means a client requested the Task to be canceled.
+	ErrTaskCanceled = fmt.Errorf("task canceled by client: %w", context.Canceled)
+	// ErrServerShutdown means the server is shutting down.
+	ErrServerShutdown = fmt.Errorf("server is shutting down: %w", context.Canceled)
+	// ErrExecutionTimeout means the request deadline passed before the execution finished.
+	ErrExecutionTimeout = fmt.Errorf("execution timed out: %w", context.DeadlineExceeded)
+)
+
+// CancelCauseMetaKey is the status Message metadata key under which the cancellation cause is recorded
+// by NewCancellationEvent.
+const CancelCauseMetaKey = "cancelCause"
+
+// NewCancellationEvent creates a final TaskStatusUpdateEvent for a Task whose execution was stopped
+// because ctx is done. The Task is moved to TaskStateFailed if the execution timed out and to
+// TaskStateCanceled otherwise. The cause is recorded in the status message, so that postmortems
+// can distinguish why Tasks ended.
+func NewCancellationEvent(ctx context.Context, task *a2a.Task) *a2a.TaskStatusUpdateEvent {
+	cause := context.Cause(ctx)
+	if cause == nil {
+		cause = context.Canceled
+	}
+	state := a2a.TaskStateCanceled
+	if errors.Is(cause, context.DeadlineExceeded) {
+		state = a2a.TaskStateFailed
+	}
+	msg := a2a.NewMessageForTask(a2a.MessageRoleAgent, *task, a2a.TextPart{Text: cause.Error()})
+	msg.Metadata = map[string]any{CancelCauseMetaKey: cause.Error()}
+	event := a2a.NewStatusUpdateEvent(task, state, msg)
+	event.Final = true
+	return event
+}
+
+// executionContext derives a context for AgentExecutor from the request context. The request context
+// getting canceled or timing out is reported as ErrClientDisconnected or ErrExecutionTimeout cause.
+// The returned function must be called when the execution finishes.
+func executionContext(ctx context.Context) (context.Context, context.CancelCauseFunc) {
+	execCtx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	cancelDeadline := context.CancelFunc(func() {})
+	if deadline, ok := ctx.Deadline(); ok {
+		execCtx, cancelDeadline = context.WithDeadlineCause(execCtx, deadline, ErrExecutionTimeout)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		cancel(requestCancelCause(ctx))
+	})
+	return execCtx, func(cause error) {
+		stop()
+		cancel(cause)
+		cancelDeadline()
+	}
+}
+
+// requestCancelCause translates the cause of the request context cancellation to an execution cancellation cause.
+func requestCancelCause(ctx context.Context) error {
+	cause := context.Cause(ctx)
+	switch cause {
+	case context.Canceled:
+		return ErrClientDisconnected
+	case context.DeadlineExceeded:
+		return ErrExecutionTimeout
+	default:
+		return cause
+	}
+}
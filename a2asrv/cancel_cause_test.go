@@ -0,0 +1,117 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestNewCancellationEvent(t *testing.T) {
+	task := &a2a.Task{ID: taskID, ContextID: "ctx"}
+	tests := []struct {
+		name      string
+		cause     error
+		wantState a2a.TaskState
+	}{
+		{name: "client disconnected", cause: ErrClientDisconnected, wantState: a2a.TaskStateCanceled},
+		{name: "explicit cancel", cause: ErrTaskCanceled, wantState: a2a.TaskStateCanceled},
+		{name: "shutdown", cause: ErrServerShutdown, wantState: a2a.TaskStateCanceled},
+		{name: "timeout", cause: ErrExecutionTimeout, wantState: a2a.TaskStateFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancelCause(t.Context())
+			cancel(tt.cause)
+
+			event := NewCancellationEvent(ctx, task)
+			if event.Status.State != tt.wantState || !event.Final {
+				t.Fatalf("NewCancellationEvent() state = %v, want final %v", event.Status.State, tt.wantState)
+			}
+			if got := event.Status.Message.Metadata[CancelCauseMetaKey]; got != tt.cause.Error() {
+				t.Fatalf("NewCancellationEvent() cause = %v, want %q", got, tt.cause)
+			}
+		})
+	}
+}
+
+func TestExecutionContext(t *testing.T) {
+	t.Run("client disconnected", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		execCtx, done := executionContext(ctx)
+		defer done(nil)
+
+		cancel()
+		<-execCtx.Done()
+		if cause := context.Cause(execCtx); !errors.Is(cause, ErrClientDisconnected) || !errors.Is(cause, context.Canceled) {
+			t.Fatalf("context.Cause() = %v, want %v", cause, ErrClientDisconnected)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+		execCtx, done := executionContext(ctx)
+		defer done(nil)
+
+		if _, ok := execCtx.Deadline(); !ok {
+			t.Fatal("execution context has no deadline, want the request deadline")
+		}
+		<-execCtx.Done()
+		if cause := context.Cause(execCtx); !errors.Is(cause, ErrExecutionTimeout) {
+			t.Fatalf("context.Cause() = %v, want %v", cause, ErrExecutionTimeout)
+		}
+	})
+
+	t.Run("custom cause", func(t *testing.T) {
+		ctx, cancel := context.WithCancelCause(t.Context())
+		execCtx, done := executionContext(ctx)
+		defer done(nil)
+
+		cancel(ErrServerShutdown)
+		<-execCtx.Done()
+		if cause := context.Cause(execCtx); cause != ErrServerShutdown {
+			t.Fatalf("context.Cause() = %v, want %v", cause, ErrServerShutdown)
+		}
+	})
+}
+
+func TestDefaultRequestHandler_ClientDisconnectCause(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	store := newCountingTaskStore()
+	executor := ExecutorFunc[[]a2a.Part](func(ctx context.Context, reqCtx RequestContext) ([]a2a.Part, error) {
+		cancel()
+		<-ctx.Done()
+		return nil, context.Cause(ctx)
+	})
+	handler := NewHandler(executor, WithTaskStore(store))
+
+	_, err := handler.OnSendMessage(ctx, a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID}})
+	if !errors.Is(err, ErrClientDisconnected) {
+		t.Fatalf("OnSendMessage() error = %v, want %v", err, ErrClientDisconnected)
+	}
+
+	final, err := store.Get(t.Context(), taskID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if final.Status.State != a2a.TaskStateCanceled || final.Status.Message.Metadata[CancelCauseMetaKey] != ErrClientDisconnected.Error() {
+		t.Fatalf("final status = %+v, want canceled because the client disconnected", final.Status)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// WellKnownAgentCardPath is the path at which agents publish their AgentCard.
+const WellKnownAgentCardPath = "/.well-known/agent-card.json"
+
+// AgentCardProducerFn adapts a function to AgentCardProducer.
+type AgentCardProducerFn func() *a2a.AgentCard
+
+func (fn AgentCardProducerFn) Card() *a2a.AgentCard {
+	return fn()
+}
+
+// NormalizedCardProducer wraps the producer so that interface declarations of the produced cards are
+// normalized with a2a.NormalizeInterfaces, eg. before they are served by NewAgentCardHandler.
+// The returned producer implements ExtendedAgentCardProducer if the wrapped one does.
+func NormalizedCardProducer(producer AgentCardProducer) AgentCardProducer {
+	normalized := normalizedCardProducer{producer: producer}
+	if extended, ok := producer.(ExtendedAgentCardProducer); ok {
+		return normalizedExtendedCardProducer{normalizedCardProducer: normalized, extended: extended}
+	}
+	return normalized
+}
+
+type normalizedCardProducer struct {
+	producer AgentCardProducer
+}
+
+func (p normalizedCardProducer) Card() *a2a.AgentCard {
+	return a2a.NormalizeInterfaces(p.producer.Card())
+}
+
+type normalizedExtendedCardProducer struct {
+	normalizedCardProducer
+	extended ExtendedAgentCardProducer
+}
+
+func (p normalizedExtendedCardProducer) ExtendedCard() *a2a.AgentCard {
+	return a2a.NormalizeInterfaces(p.extended.ExtendedCard())
+}
+
+// NewAgentCardHandler creates an http.Handler serving the AgentCard created by the producer as JSON.
+// If the card publishes translations using a2a.SetCardTranslations, the variant matching
+// the Accept-Language request header is served.
+func NewAgentCardHandler(producer AgentCardProducer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		card, lang, err := a2a.LocalizeCard(producer.Card(), req.Header.Get("Accept-Language"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Add("Vary", "Accept-Language")
+		if lang != "" {
+			w.Header().Set("Content-Language", lang)
+		}
+		if req.Method == http.MethodHead {
+			return
+		}
+		_ = json.NewEncoder(w).Encode(card)
+	})
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"gopkg.in/yaml.v3"
+)
+
+// CardFormat is the encoding of an AgentCard template.
+type CardFormat string
+
+const (
+	CardFormatJSON CardFormat = "json"
+	CardFormatYAML CardFormat = "yaml"
+)
+
+var cardVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// AgentCardLoader reads AgentCard templates and applies per-deployment changes before serving.
+// String values in a template can reference variables as ${NAME} or ${NAME:-default}.
+// Referencing a variable which is not set and has no default is an error.
+type AgentCardLoader struct {
+	// Lookup resolves template variables. os.LookupEnv is used if nil.
+	Lookup func(name string) (string, bool)
+	// URL overrides AgentCard.URL if not empty.
+	URL string
+	// Version overrides AgentCard.Version if not empty.
+	Version string
+	// Provider overrides AgentCard.Provider if not nil.
+	Provider *a2a.AgentProvider
+	// Override is called after all the other overrides were applied if not nil.
+	Override func(card *a2a.AgentCard) error
+}
+
+// LoadFile reads a template from a file. Files with .yaml or .yml extension are decoded as YAML,
+// others as JSON.
+func (l *AgentCardLoader) LoadFile(path string) (*a2a.AgentCard, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	format := CardFormatJSON
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		format = CardFormatYAML
+	}
+	return l.Load(data, format)
+}
+
+// Load decodes a template in the provided format, interpolates variables and applies overrides.
+func (l *AgentCardLoader) Load(data []byte, format CardFormat) (*a2a.AgentCard, error) {
+	var template any
+	switch format {
+	case CardFormatJSON:
+		if err := json.Unmarshal(data, &template); err != nil {
+			return nil, fmt.Errorf("failed to decode card: %w", err)
+		}
+	case CardFormatYAML:
+		if err := yaml.Unmarshal(data, &template); err != nil {
+			return nil, fmt.Errorf("failed to decode card: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported card format: %q", format)
+	}
+
+	var errs []error
+	template = l.interpolate(template, &errs)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// AgentCard is decoded from JSON so that custom unmarshalers are applied for both formats
+	raw, err := json.Marshal(template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode card: %w", err)
+	}
+	var card a2a.AgentCard
+	if err := json.Unmarshal(raw, &card); err != nil {
+		return nil, fmt.Errorf("failed to decode card: %w", err)
+	}
+
+	if l.URL != "" {
+		card.URL = l.URL
+	}
+	if l.Version != "" {
+		card.Version = l.Version
+	}
+	if l.Provider != nil {
+		provider := *l.Provider
+		card.Provider = &provider
+	}
+	if l.Override != nil {
+		if err := l.Override(&card); err != nil {
+			return nil, err
+		}
+	}
+	return &card, nil
+}
+
+func (l *AgentCardLoader) interpolate(value any, errs *[]error) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = l.interpolate(item, errs)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = l.interpolate(item, errs)
+		}
+		return v
+	case string:
+		return cardVarPattern.ReplaceAllStringFunc(v, func(ref string) string {
+			match := cardVarPattern.FindStringSubmatch(ref)
+			if value, ok := l.lookup(match[1]); ok {
+				return value
+			}
+			if match[2] != "" {
+				return match[3]
+			}
+			*errs = append(*errs, fmt.Errorf("card variable %s is not set", match[1]))
+			return ref
+		})
+	default:
+		return v
+	}
+}
+
+func (l *AgentCardLoader) lookup(name string) (string, bool) {
+	if l.Lookup != nil {
+		return l.Lookup(name)
+	}
+	return os.LookupEnv(name)
+}
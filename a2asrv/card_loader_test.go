@@ -0,0 +1,119 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+const cardYAML = `
+name: Travel agent
+description: Books trips in ${REGION:-Europe}
+url: http://localhost:8080
+version: dev
+protocolVersion: "0.3.0"
+capabilities:
+  streaming: true
+defaultInputModes: [text/plain]
+defaultOutputModes: [text/plain]
+skills:
+  - id: flights
+    name: Flights
+    description: Books flights for ${COMPANY}
+    tags: [travel]
+`
+
+const cardJSON = `{
+	"name": "Travel agent",
+	"description": "Books trips in ${REGION:-Europe}",
+	"url": "${PUBLIC_URL}",
+	"version": "dev",
+	"protocolVersion": "0.3.0",
+	"capabilities": {},
+	"defaultInputModes": ["text/plain"],
+	"defaultOutputModes": ["text/plain"],
+	"skills": []
+}`
+
+func mapLookup(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+}
+
+func TestAgentCardLoader_Load(t *testing.T) {
+	loader := &AgentCardLoader{
+		Lookup:   mapLookup(map[string]string{"COMPANY": "Acme", "PUBLIC_URL": "https://agent.example.com"}),
+		Version:  "1.2.3",
+		Provider: &a2a.AgentProvider{Org: "Acme", URL: "https://acme.example.com"},
+		Override: func(card *a2a.AgentCard) error {
+			card.Capabilities.PushNotifications = true
+			return nil
+		},
+	}
+
+	yamlCard, err := loader.Load([]byte(cardYAML), CardFormatYAML)
+	if err != nil {
+		t.Fatalf("Load(yaml) error = %v", err)
+	}
+	if yamlCard.Description != "Books trips in Europe" || yamlCard.Skills[0].Description != "Books flights for Acme" {
+		t.Errorf("Load(yaml) did not interpolate variables: %q, %q", yamlCard.Description, yamlCard.Skills[0].Description)
+	}
+	if yamlCard.Version != "1.2.3" || yamlCard.Provider.Org != "Acme" || !yamlCard.Capabilities.PushNotifications {
+		t.Errorf("Load(yaml) did not apply overrides: %+v", yamlCard)
+	}
+	if !yamlCard.Capabilities.Streaming || yamlCard.Skills[0].Tags[0] != "travel" {
+		t.Errorf("Load(yaml) lost template values: %+v", yamlCard)
+	}
+
+	loader.URL = "https://override.example.com"
+	jsonCard, err := loader.Load([]byte(cardJSON), CardFormatJSON)
+	if err != nil {
+		t.Fatalf("Load(json) error = %v", err)
+	}
+	if jsonCard.URL != "https://override.example.com" {
+		t.Errorf("Load(json) URL = %q, want override", jsonCard.URL)
+	}
+}
+
+func TestAgentCardLoader_MissingVariable(t *testing.T) {
+	loader := &AgentCardLoader{Lookup: mapLookup(nil)}
+	_, err := loader.Load([]byte(cardJSON), CardFormatJSON)
+	if err == nil || !strings.Contains(err.Error(), "PUBLIC_URL") {
+		t.Fatalf("Load() error = %v, want missing PUBLIC_URL", err)
+	}
+}
+
+func TestAgentCardLoader_LoadFile(t *testing.T) {
+	t.Setenv("COMPANY", "Initech")
+	path := filepath.Join(t.TempDir(), "card.yml")
+	if err := os.WriteFile(path, []byte(cardYAML), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	card, err := (&AgentCardLoader{}).LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if card.Skills[0].Description != "Books flights for Initech" {
+		t.Errorf("LoadFile() did not use environment: %q", card.Skills[0].Description)
+	}
+}
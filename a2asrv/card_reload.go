@@ -0,0 +1,117 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// AgentCardSource produces the current version of an AgentCard, eg. by reading a file.
+type AgentCardSource func(ctx context.Context) (*a2a.AgentCard, error)
+
+// FileCardSource creates an AgentCardSource which reads the card using the loader.
+func FileCardSource(loader *AgentCardLoader, path string) AgentCardSource {
+	return func(ctx context.Context) (*a2a.AgentCard, error) {
+		return loader.LoadFile(path)
+	}
+}
+
+// ReloadableCard is an AgentCardProducer which atomically swaps the served card when
+// the source changes. Components deriving state from the card, like capability checks
+// or security scheme enforcement, can register for changes using OnChange.
+type ReloadableCard struct {
+	source  AgentCardSource
+	current atomic.Pointer[a2a.AgentCard]
+
+	mu        sync.Mutex
+	encoded   []byte
+	listeners []func(card *a2a.AgentCard)
+}
+
+// NewReloadableCard creates a ReloadableCard loading the initial card from the source.
+func NewReloadableCard(ctx context.Context, source AgentCardSource) (*ReloadableCard, error) {
+	c := &ReloadableCard{source: source}
+	if _, err := c.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Card returns the current card.
+func (c *ReloadableCard) Card() *a2a.AgentCard {
+	return c.current.Load()
+}
+
+// OnChange registers a listener called with every new card. The listener is immediately called
+// with the current card, so that derived state can be initialized.
+func (c *ReloadableCard) OnChange(listener func(card *a2a.AgentCard)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listeners = append(c.listeners, listener)
+	listener(c.current.Load())
+}
+
+// Reload reads the card from the source and swaps it if it changed. Returns true if the card was swapped.
+// The previous card keeps being served if the source fails.
+func (c *ReloadableCard) Reload(ctx context.Context) (bool, error) {
+	card, err := c.source(ctx)
+	if err != nil {
+		return false, err
+	}
+	if card == nil {
+		return false, errors.New("card source returned nil")
+	}
+	encoded, err := json.Marshal(card)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.encoded != nil && bytes.Equal(c.encoded, encoded) {
+		return false, nil
+	}
+	c.encoded = encoded
+	c.current.Store(card)
+	for _, listener := range c.listeners {
+		listener(card)
+	}
+	return true, nil
+}
+
+// Watch calls Reload every interval until the context is canceled. Reload errors are passed
+// to onError if it is not nil.
+func (c *ReloadableCard) Watch(ctx context.Context, interval time.Duration, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if _, err := c.Reload(ctx); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestReloadableCard_Reload(t *testing.T) {
+	ctx := t.Context()
+	version := "1"
+	var sourceErr error
+	source := func(ctx context.Context) (*a2a.AgentCard, error) {
+		if sourceErr != nil {
+			return nil, sourceErr
+		}
+		return &a2a.AgentCard{Name: "agent", Version: version}, nil
+	}
+	card, err := NewReloadableCard(ctx, source)
+	if err != nil {
+		t.Fatalf("NewReloadableCard() error = %v", err)
+	}
+
+	var seen []string
+	card.OnChange(func(c *a2a.AgentCard) { seen = append(seen, c.Version) })
+
+	if changed, err := card.Reload(ctx); err != nil || changed {
+		t.Fatalf("Reload() of unchanged card = %v, %v, want false, nil", changed, err)
+	}
+	version = "2"
+	if changed, err := card.Reload(ctx); err != nil || !changed {
+		t.Fatalf("Reload() of changed card = %v, %v, want true, nil", changed, err)
+	}
+	sourceErr = errors.New("source failed")
+	if _, err := card.Reload(ctx); !errors.Is(err, sourceErr) {
+		t.Fatalf("Reload() error = %v, want %v", err, sourceErr)
+	}
+
+	if got := card.Card().Version; got != "2" {
+		t.Fatalf("Card().Version = %q, want 2", got)
+	}
+	if strings.Join(seen, ",") != "1,2" {
+		t.Fatalf("listener saw versions %v, want [1 2]", seen)
+	}
+}
+
+func TestReloadableCard_WatchFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "card.json")
+	write := func(version string) {
+		t.Helper()
+		data := `{"name": "agent", "version": "` + version + `"}`
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatalf("os.WriteFile() error = %v", err)
+		}
+	}
+	write("1")
+
+	card, err := NewReloadableCard(t.Context(), FileCardSource(&AgentCardLoader{}, path))
+	if err != nil {
+		t.Fatalf("NewReloadableCard() error = %v", err)
+	}
+	changed := make(chan string, 2)
+	card.OnChange(func(c *a2a.AgentCard) { changed <- c.Version })
+	<-changed
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() { done <- card.Watch(ctx, 5*time.Millisecond, nil) }()
+
+	write("2")
+	select {
+	case v := <-changed:
+		if v != "2" {
+			t.Fatalf("reloaded version = %q, want 2", v)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("card was not reloaded")
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Watch() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestDefaultRequestHandler_WithAgentCard(t *testing.T) {
+	ctx := t.Context()
+	capabilities := a2a.AgentCapabilities{}
+	card, err := NewReloadableCard(ctx, func(ctx context.Context) (*a2a.AgentCard, error) {
+		return &a2a.AgentCard{Name: "agent", Capabilities: capabilities}, nil
+	})
+	if err != nil {
+		t.Fatalf("NewReloadableCard() error = %v", err)
+	}
+	handler := NewHandler(&mockAgentExecutor{}, WithAgentCard(card))
+
+	if _, err := handler.OnSetTaskPushConfig(ctx, a2a.TaskPushConfig{TaskID: taskID}); !errors.Is(err, a2a.ErrPushNotificationNotSupported) {
+		t.Fatalf("OnSetTaskPushConfig() error = %v, want %v", err, a2a.ErrPushNotificationNotSupported)
+	}
+	for _, err := range handler.OnSendMessageStream(ctx, a2a.MessageSendParams{}) {
+		if !errors.Is(err, a2a.ErrUnsupportedOperation) {
+			t.Fatalf("OnSendMessageStream() error = %v, want %v", err, a2a.ErrUnsupportedOperation)
+		}
+	}
+
+	capabilities.PushNotifications = true
+	if _, err := card.Reload(ctx); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if _, err := handler.OnSetTaskPushConfig(ctx, a2a.TaskPushConfig{TaskID: taskID}); errors.Is(err, a2a.ErrPushNotificationNotSupported) {
+		t.Fatalf("OnSetTaskPushConfig() after reload error = %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestNewAgentCardHandler(t *testing.T) {
+	card := &a2a.AgentCard{Name: "Travel agent", Description: "Books trips"}
+	if err := a2a.SetCardTranslations(card, map[string]a2a.CardTranslation{"de": {Name: "Reiseagent"}}); err != nil {
+		t.Fatalf("SetCardTranslations() error = %v", err)
+	}
+	handler := NewAgentCardHandler(AgentCardProducerFn(func() *a2a.AgentCard { return card }))
+
+	testCases := []struct {
+		name         string
+		method       string
+		language     string
+		wantStatus   int
+		wantName     string
+		wantLanguage string
+	}{
+		{name: "default", method: http.MethodGet, wantStatus: http.StatusOK, wantName: "Travel agent"},
+		{name: "localized", method: http.MethodGet, language: "de-DE", wantStatus: http.StatusOK, wantName: "Reiseagent", wantLanguage: "de"},
+		{name: "wrong method", method: http.MethodPost, wantStatus: http.StatusMethodNotAllowed},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, WellKnownAgentCardPath, nil)
+			if tc.language != "" {
+				req.Header.Set("Accept-Language", tc.language)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("ServeHTTP() status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			var got a2a.AgentCard
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if got.Name != tc.wantName {
+				t.Errorf("card name = %q, want %q", got.Name, tc.wantName)
+			}
+			if lang := rec.Header().Get("Content-Language"); lang != tc.wantLanguage {
+				t.Errorf("Content-Language = %q, want %q", lang, tc.wantLanguage)
+			}
+			if rec.Header().Get("Vary") != "Accept-Language" {
+				t.Errorf("Vary = %q, want Accept-Language", rec.Header().Get("Vary"))
+			}
+		})
+	}
+}
+
+type extendedCardProducer struct {
+	card, extended *a2a.AgentCard
+}
+
+func (p extendedCardProducer) Card() *a2a.AgentCard         { return p.card }
+func (p extendedCardProducer) ExtendedCard() *a2a.AgentCard { return p.extended }
+
+func TestNormalizedCardProducer(t *testing.T) {
+	card := &a2a.AgentCard{URL: "https://agent.com", AdditionalInterfaces: []a2a.AgentInterface{{Transport: "grpc", URL: "agent.com:443"}}}
+	extended := &a2a.AgentCard{URL: "https://agent.com", PreferredTransport: "jsonrpc"}
+
+	producer := NormalizedCardProducer(extendedCardProducer{card: card, extended: extended})
+	if problems := a2a.CheckInterfaces(producer.Card()); len(problems) != 0 {
+		t.Fatalf("Card() has interface problems %v", problems)
+	}
+	extendedProducer, ok := producer.(ExtendedAgentCardProducer)
+	if !ok {
+		t.Fatal("NormalizedCardProducer() doesn't implement ExtendedAgentCardProducer for an extended producer")
+	}
+	if got := extendedProducer.ExtendedCard().PreferredTransport; got != a2a.TransportProtocolJSONRPC {
+		t.Fatalf("ExtendedCard().PreferredTransport = %q, want %q", got, a2a.TransportProtocolJSONRPC)
+	}
+
+	if _, ok := NormalizedCardProducer(AgentCardProducerFn(func() *a2a.AgentCard { return card })).(ExtendedAgentCardProducer); ok {
+		t.Fatal("NormalizedCardProducer() implements ExtendedAgentCardProducer for a public card producer")
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// StatsReporter is an optional interface of RequestHandler, TaskStore and eventqueue.Manager
+// implementations which can report their internal state for debugging.
+type StatsReporter interface {
+	// Stats returns a JSON-serializable snapshot of the internal state.
+	Stats(ctx context.Context) (any, error)
+}
+
+// HandlerStats is a snapshot of the default RequestHandler state.
+type HandlerStats struct {
+	// InFlightExecutions is the number of AgentExecutor calls in progress.
+	InFlightExecutions int64 `json:"inFlightExecutions"`
+	// Queues is reported by the configured eventqueue.Manager if it implements StatsReporter.
+	Queues any `json:"queues,omitempty"`
+	// TaskStore is reported by TaskStore if it implements StatsReporter.
+	TaskStore any `json:"taskStore,omitempty"`
+}
+
+// Stats returns HandlerStats.
+func (h *defaultRequestHandler) Stats(ctx context.Context) (any, error) {
+	stats := HandlerStats{InFlightExecutions: h.inFlight.Load()}
+	if reporter, ok := h.queueBackend.(StatsReporter); ok {
+		queues, err := reporter.Stats(ctx)
+		if err != nil {
+			return nil, err
+		}
+		stats.Queues = queues
+	}
+	if reporter, ok := h.taskStore.(StatsReporter); ok {
+		store, err := reporter.Stats(ctx)
+		if err != nil {
+			return nil, err
+		}
+		stats.TaskStore = store
+	}
+	return stats, nil
+}
+
+// TaskTransitionLog is an optional interface of TaskStore implementations which keep
+// the history of Task status changes.
+type TaskTransitionLog interface {
+	// Transitions returns all the statuses a Task went through in chronological order.
+	Transitions(ctx context.Context, taskId a2a.TaskID) ([]a2a.TaskStatus, error)
+}
+
+// TaskInspector is an optional interface of RequestHandler implementations which can dump
+// the full internal state of a Task for debugging.
+type TaskInspector interface {
+	// InspectTask returns the state of a Task. Unlike tasks/get it never truncates the history.
+	InspectTask(ctx context.Context, taskId a2a.TaskID) (TaskDump, error)
+}
+
+// TaskDump is the full internal state of a Task reported by TaskInspector.
+type TaskDump struct {
+	// Task is the stored Task including the full history and all artifacts.
+	Task a2a.Task `json:"task"`
+	// Transitions is reported if TaskStore implements TaskTransitionLog.
+	Transitions []a2a.TaskStatus `json:"transitions,omitempty"`
+	// PendingEvents is the number of events waiting in the Task queue.
+	// Nil if there's no active queue or the queue manager doesn't implement eventqueue.QueueStatsReporter.
+	PendingEvents *int `json:"pendingEvents,omitempty"`
+	// PushConfigs are the push notification configurations registered for the Task.
+	PushConfigs []a2a.PushConfig `json:"pushConfigs,omitempty"`
+}
+
+// InspectTask returns TaskDump.
+func (h *defaultRequestHandler) InspectTask(ctx context.Context, taskId a2a.TaskID) (TaskDump, error) {
+	if h.taskStore == nil {
+		return TaskDump{}, errors.New("task store is not configured")
+	}
+	task, err := h.taskStore.Get(ctx, taskId)
+	if err != nil {
+		return TaskDump{}, fmt.Errorf("failed to get task: %w", err)
+	}
+	dump := TaskDump{Task: task}
+
+	if log, ok := h.taskStore.(TaskTransitionLog); ok {
+		if dump.Transitions, err = log.Transitions(ctx, taskId); err != nil {
+			return TaskDump{}, fmt.Errorf("failed to get transitions: %w", err)
+		}
+	}
+	if reporter, ok := h.queueBackend.(eventqueue.QueueStatsReporter); ok {
+		queues, err := reporter.QueueStats(ctx)
+		if err != nil {
+			return TaskDump{}, fmt.Errorf("failed to get queue stats: %w", err)
+		}
+		for _, q := range queues {
+			if q.TaskID == taskId {
+				dump.PendingEvents = &q.Len
+				break
+			}
+		}
+	}
+	if h.pushConfigStore != nil {
+		if dump.PushConfigs, err = h.pushConfigStore.Get(ctx, taskId); err != nil {
+			return TaskDump{}, fmt.Errorf("failed to get push configs: %w", err)
+		}
+	}
+	return dump, nil
+}
+
+// DebugConfig configures the debug endpoints created by NewDebugMux.
+type DebugConfig struct {
+	// Handler is the RequestHandler which state gets reported if it implements StatsReporter or TaskInspector.
+	Handler RequestHandler
+	// Authorize is called for every request. Requests for which it returns an error are rejected.
+	// All requests are rejected if Authorize is nil.
+	Authorize func(req *http.Request) error
+}
+
+// NewDebugMux creates an opt-in http.Handler with runtime debug endpoints which should be served
+// separately from the A2A endpoints:
+//   - /debug/pprof/ serves net/http/pprof profiles,
+//   - /debug/vars serves expvar variables,
+//   - /debug/a2a/stats serves queue depths, in-flight executor counts and task store stats,
+//   - /debug/a2a/tasks/{id} serves the TaskDump if Handler implements TaskInspector.
+func NewDebugMux(cfg DebugConfig) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.HandleFunc("GET /debug/a2a/stats", func(w http.ResponseWriter, req *http.Request) {
+		reporter, ok := cfg.Handler.(StatsReporter)
+		if !ok {
+			http.Error(w, "handler doesn't report stats", http.StatusNotFound)
+			return
+		}
+		stats, err := reporter.Stats(req.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeDebugJSON(w, stats)
+	})
+	mux.HandleFunc("GET /debug/a2a/tasks/{id}", func(w http.ResponseWriter, req *http.Request) {
+		inspector, ok := cfg.Handler.(TaskInspector)
+		if !ok {
+			http.Error(w, "handler doesn't support task inspection", http.StatusNotFound)
+			return
+		}
+		dump, err := inspector.InspectTask(req.Context(), a2a.TaskID(req.PathValue("id")))
+		if errors.Is(err, a2a.ErrTaskNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeDebugJSON(w, dump)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if cfg.Authorize == nil {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if err := cfg.Authorize(req); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		mux.ServeHTTP(w, req)
+	})
+}
+
+func writeDebugJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(value)
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+func TestNewDebugMux_Authorization(t *testing.T) {
+	testCases := []struct {
+		name      string
+		authorize func(req *http.Request) error
+		want      int
+	}{
+		{name: "no authorize", want: http.StatusForbidden},
+		{name: "rejected", authorize: func(req *http.Request) error { return errors.New("denied") }, want: http.StatusForbidden},
+		{name: "accepted", authorize: func(req *http.Request) error { return nil }, want: http.StatusOK},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := NewDebugMux(DebugConfig{Handler: NewHandler(&mockAgentExecutor{}), Authorize: tc.authorize})
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+			if rec.Code != tc.want {
+				t.Fatalf("ServeHTTP() status = %d, want %d", rec.Code, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewDebugMux_Stats(t *testing.T) {
+	ctx := t.Context()
+	manager := eventqueue.NewInMemoryManager()
+	queue, err := manager.GetOrCreate(ctx, taskID)
+	if err != nil {
+		t.Fatalf("GetOrCreate() error = %v", err)
+	}
+	if err := queue.Write(ctx, &a2a.Task{ID: taskID}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	handler := NewHandler(
+		&mockAgentExecutor{},
+		WithEventQueueManager(manager),
+		WithEventTap(io.Discard),
+		WithQueueWriteDeadline(eventqueue.WriteDeadline{Timeout: time.Minute}),
+		WithEventSinks(EventSinkFunc(func(ctx context.Context, event SinkEvent) error { return nil })),
+		WithArtifactStore(NewFileArtifactStore(t.TempDir(), "http://localhost/artifacts")),
+	)
+	mux := NewDebugMux(DebugConfig{Handler: handler, Authorize: func(req *http.Request) error { return nil }})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/a2a/stats", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ServeHTTP() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var got struct {
+		InFlightExecutions int64                   `json:"inFlightExecutions"`
+		Queues             []eventqueue.QueueStats `json:"queues"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got.InFlightExecutions != 0 {
+		t.Errorf("InFlightExecutions = %d, want 0", got.InFlightExecutions)
+	}
+	if len(got.Queues) != 1 || got.Queues[0].TaskID != taskID || got.Queues[0].Len != 1 {
+		t.Errorf("Queues = %v, want one queue of %s with 1 event", got.Queues, taskID)
+	}
+}
+
+// testTaskStore is an in-memory TaskStore which records status transitions.
+type testTaskStore struct {
+	tasks       map[a2a.TaskID]a2a.Task
+	transitions map[a2a.TaskID][]a2a.TaskStatus
+}
+
+func (s *testTaskStore) Save(ctx context.Context, task a2a.Task) error {
+	s.tasks[task.ID] = task
+	s.transitions[task.ID] = append(s.transitions[task.ID], task.Status)
+	return nil
+}
+
+func (s *testTaskStore) Get(ctx context.Context, taskId a2a.TaskID) (a2a.Task, error) {
+	task, ok := s.tasks[taskId]
+	if !ok {
+		return a2a.Task{}, a2a.ErrTaskNotFound
+	}
+	return task, nil
+}
+
+func (s *testTaskStore) Transitions(ctx context.Context, taskId a2a.TaskID) ([]a2a.TaskStatus, error) {
+	return s.transitions[taskId], nil
+}
+
+// testPushConfigStore returns fixed configs.
+type testPushConfigStore struct {
+	PushConfigStore
+	configs []a2a.PushConfig
+}
+
+func (s *testPushConfigStore) Get(ctx context.Context, taskId a2a.TaskID) ([]a2a.PushConfig, error) {
+	return s.configs, nil
+}
+
+func TestNewDebugMux_InspectTask(t *testing.T) {
+	ctx := t.Context()
+	store := &testTaskStore{tasks: map[a2a.TaskID]a2a.Task{}, transitions: map[a2a.TaskID][]a2a.TaskStatus{}}
+	task := a2a.Task{ID: taskID, ContextID: "ctx", Status: a2a.TaskStatus{State: a2a.TaskStateSubmitted}}
+	_ = store.Save(ctx, task)
+	task.Status.State = a2a.TaskStateWorking
+	task.History = []*a2a.Message{{ID: "m1"}, {ID: "m2"}, {ID: "m3"}}
+	_ = store.Save(ctx, task)
+
+	manager := eventqueue.NewInMemoryManager()
+	queue, err := manager.GetOrCreate(ctx, taskID)
+	if err != nil {
+		t.Fatalf("GetOrCreate() error = %v", err)
+	}
+	if err := queue.Write(ctx, &task); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	pushStore := &testPushConfigStore{configs: []a2a.PushConfig{{ID: "cfg", URL: "https://example.com/push"}}}
+
+	handler := NewHandler(
+		&mockAgentExecutor{},
+		WithTaskStore(store),
+		WithEventQueueManager(manager),
+		WithPushConfigStore(pushStore),
+	)
+	mux := NewDebugMux(DebugConfig{Handler: handler, Authorize: func(req *http.Request) error { return nil }})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/a2a/tasks/"+string(taskID), nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ServeHTTP() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var got TaskDump
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if len(got.Task.History) != 3 {
+		t.Errorf("History length = %d, want 3", len(got.Task.History))
+	}
+	if len(got.Transitions) != 2 || got.Transitions[1].State != a2a.TaskStateWorking {
+		t.Errorf("Transitions = %v, want submitted and working", got.Transitions)
+	}
+	if got.PendingEvents == nil || *got.PendingEvents != 1 {
+		t.Errorf("PendingEvents = %v, want 1", got.PendingEvents)
+	}
+	if !reflect.DeepEqual(got.PushConfigs, pushStore.configs) {
+		t.Errorf("PushConfigs = %v, want %v", got.PushConfigs, pushStore.configs)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/a2a/tasks/unknown", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("ServeHTTP() for unknown task status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
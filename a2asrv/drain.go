@@ -0,0 +1,163 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// DrainingHandler wraps a RequestHandler to close open streams gracefully on shutdown. Instead of an abrupt EOF,
+// every stream ends with an event created by a2a.NewRedirectEvent, so that clients can resubscribe to the Task
+// using another replica.
+type DrainingHandler struct {
+	RequestHandler
+
+	mu       sync.Mutex
+	draining bool
+	hint     a2a.RedirectHint
+	streams  map[*drainedStream]struct{}
+	wg       sync.WaitGroup
+}
+
+type drainedStream struct {
+	cancel context.CancelCauseFunc
+}
+
+// NewDrainingHandler creates a DrainingHandler.
+func NewDrainingHandler(next RequestHandler) *DrainingHandler {
+	return &DrainingHandler{RequestHandler: next, streams: make(map[*drainedStream]struct{})}
+}
+
+// Drain cancels the handling of all open streams with ErrServerShutdown and waits until every stream sent
+// its redirect event or ctx is done. New streams are rejected with ErrServerShutdown after Drain is called.
+// Should be called before shutting down the transport servers.
+func (h *DrainingHandler) Drain(ctx context.Context, hint a2a.RedirectHint) error {
+	h.mu.Lock()
+	h.draining, h.hint = true, hint
+	for stream := range h.streams {
+		stream.cancel(ErrServerShutdown)
+	}
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+func (h *DrainingHandler) OnResubscribeToTask(ctx context.Context, id a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+	return h.drainable(ctx, func(ctx context.Context) iter.Seq2[a2a.Event, error] {
+		return h.RequestHandler.OnResubscribeToTask(ctx, id)
+	})
+}
+
+func (h *DrainingHandler) OnSendMessageStream(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return h.drainable(ctx, func(ctx context.Context) iter.Seq2[a2a.Event, error] {
+		return h.RequestHandler.OnSendMessageStream(ctx, message)
+	})
+}
+
+func (h *DrainingHandler) OnGetExtendedAgentCard(ctx context.Context) (*a2a.AgentCard, error) {
+	return getExtendedAgentCard(ctx, h.RequestHandler)
+}
+
+func (h *DrainingHandler) drainable(ctx context.Context, open func(ctx context.Context) iter.Seq2[a2a.Event, error]) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		streamCtx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+		stream := &drainedStream{cancel: cancel}
+		if !h.register(stream) {
+			yield(nil, ErrServerShutdown)
+			return
+		}
+		defer h.unregister(stream)
+
+		var last *a2a.TaskStatusUpdateEvent
+		events := open(streamCtx)
+		if events == nil {
+			return
+		}
+		for event, err := range events {
+			if errors.Is(context.Cause(streamCtx), ErrServerShutdown) {
+				break
+			}
+			if err == nil {
+				last = observeStatus(last, event)
+			}
+			if !yield(event, err) {
+				return
+			}
+		}
+		if !errors.Is(context.Cause(streamCtx), ErrServerShutdown) || (last != nil && last.Final) {
+			return
+		}
+		if last == nil {
+			yield(nil, ErrServerShutdown)
+			return
+		}
+		h.mu.Lock()
+		hint := h.hint
+		h.mu.Unlock()
+		yield(a2a.NewRedirectEvent(last.TaskID, last.ContextID, last.Status, hint), nil)
+	}
+}
+
+func (h *DrainingHandler) register(stream *drainedStream) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.draining {
+		return false
+	}
+	h.streams[stream] = struct{}{}
+	h.wg.Add(1)
+	return true
+}
+
+func (h *DrainingHandler) unregister(stream *drainedStream) {
+	h.mu.Lock()
+	delete(h.streams, stream)
+	h.mu.Unlock()
+	h.wg.Done()
+}
+
+// observeStatus returns the status update which describes the Task state after the event.
+func observeStatus(last *a2a.TaskStatusUpdateEvent, event a2a.Event) *a2a.TaskStatusUpdateEvent {
+	switch v := event.(type) {
+	case *a2a.Task:
+		return &a2a.TaskStatusUpdateEvent{TaskID: v.ID, ContextID: v.ContextID, Status: v.Status, Final: v.Status.State.Terminal()}
+	case *a2a.TaskStatusUpdateEvent:
+		return v
+	case *a2a.Message:
+		if last == nil {
+			// the Message concludes an execution which doesn't create a Task
+			return &a2a.TaskStatusUpdateEvent{Final: true}
+		}
+		return last
+	default:
+		return last
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// blockingStream yields a working Task and blocks until ctx is done.
+func blockingStream(started chan<- struct{}) func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+		return func(yield func(a2a.Event, error) bool) {
+			task := &a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+			if !yield(task, nil) {
+				return
+			}
+			close(started)
+			<-ctx.Done()
+			yield(nil, context.Cause(ctx))
+		}
+	}
+}
+
+func TestDrainingHandler_Drain(t *testing.T) {
+	ctx := t.Context()
+	started := make(chan struct{})
+	handler := NewDrainingHandler(&stubRequestHandler{stream: blockingStream(started)})
+	hint := a2a.RedirectHint{RetryAfter: 2 * time.Second, URL: "https://replica-2.example.com", Transport: a2a.TransportProtocolGRPC}
+
+	drained := make(chan error, 1)
+	go func() {
+		<-started
+		drained <- handler.Drain(ctx, hint)
+	}()
+
+	var events []a2a.Event
+	for event, err := range handler.OnSendMessageStream(ctx, a2a.MessageSendParams{}) {
+		if err != nil {
+			t.Fatalf("stream error = %v", err)
+		}
+		events = append(events, event)
+	}
+	if err := <-drained; err != nil {
+		t.Fatalf("Drain() error = %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want the Task and a redirect", len(events))
+	}
+	redirect, ok := events[1].(*a2a.TaskStatusUpdateEvent)
+	if !ok || redirect.TaskID != "task-1" || redirect.ContextID != "ctx-1" || redirect.Final || redirect.Status.State != a2a.TaskStateWorking {
+		t.Fatalf("last event = %+v, want a non-final working status update", events[1])
+	}
+	if got, ok := a2a.RedirectHintFrom(redirect); !ok || got != hint {
+		t.Fatalf("RedirectHintFrom() = %+v, %v, want %+v", got, ok, hint)
+	}
+
+	for _, err := range handler.OnSendMessageStream(ctx, a2a.MessageSendParams{}) {
+		if !errors.Is(err, ErrServerShutdown) {
+			t.Fatalf("stream after Drain() error = %v, want %v", err, ErrServerShutdown)
+		}
+	}
+}
+
+func TestDrainingHandler_FinishedStreams(t *testing.T) {
+	ctx := t.Context()
+	handler := NewDrainingHandler(&stubRequestHandler{
+		stream: func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+			return func(yield func(a2a.Event, error) bool) {
+				yield(&a2a.Task{ID: "task-1", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}}, nil)
+			}
+		},
+	})
+
+	count := 0
+	for _, err := range handler.OnSendMessageStream(ctx, a2a.MessageSendParams{}) {
+		if err != nil {
+			t.Fatalf("stream error = %v", err)
+		}
+		count++
+	}
+	if count != 1 {
+		t.Fatalf("got %d events, want 1", count)
+	}
+
+	drainCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if err := handler.Drain(drainCtx, a2a.RedirectHint{}); err != nil {
+		t.Fatalf("Drain() with no open streams error = %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// ErrSlowConsumer is returned by Write of a queue created by a write deadline Manager when
+// the consumer didn't read events for longer than the configured timeout.
+var ErrSlowConsumer = errors.New("queue consumer is not reading events")
+
+// WriteDeadline configures slow-consumer detection for queue writes.
+type WriteDeadline struct {
+	// Timeout is how long a Write can block on a full queue before the consumer is considered stuck.
+	Timeout time.Duration
+	// OnSlowConsumer is called when a Write was blocked for Timeout. stalled is the time which passed
+	// since the consumer last read an event from the queue or since the queue was created.
+	OnSlowConsumer func(taskID a2a.TaskID, stalled time.Duration)
+	// Wait makes Write continue blocking after OnSlowConsumer was called instead of failing
+	// with ErrSlowConsumer. Useful for only logging stuck downstreams.
+	Wait bool
+}
+
+// NewWriteDeadlineManager wraps a Manager so that Writes to its queues don't block indefinitely
+// when a consumer stops reading events. By default a Write blocked for longer than config.Timeout
+// fails with ErrSlowConsumer, surfacing the problem to AgentExecutor.
+func NewWriteDeadlineManager(manager Manager, config WriteDeadline) Manager {
+	return &deadlineManager{Manager: manager, config: config, lastReads: make(map[a2a.TaskID]*lastRead)}
+}
+
+type deadlineManager struct {
+	Manager
+	config WriteDeadline
+
+	mu        sync.Mutex
+	lastReads map[a2a.TaskID]*lastRead
+}
+
+// lastRead is shared by all the queues returned for a Task.
+type lastRead struct {
+	mu   sync.Mutex
+	time time.Time
+}
+
+func (r *lastRead) touch() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.time = time.Now()
+}
+
+func (r *lastRead) since() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return time.Since(r.time)
+}
+
+func (m *deadlineManager) GetOrCreate(ctx context.Context, taskId a2a.TaskID) (Queue, error) {
+	queue, err := m.Manager.GetOrCreate(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	read, ok := m.lastReads[taskId]
+	if !ok {
+		read = &lastRead{time: time.Now()}
+		m.lastReads[taskId] = read
+	}
+	return &deadlineQueue{Queue: queue, taskID: taskId, config: m.config, lastRead: read}, nil
+}
+
+func (m *deadlineManager) Destroy(ctx context.Context, taskId a2a.TaskID) error {
+	m.mu.Lock()
+	delete(m.lastReads, taskId)
+	m.mu.Unlock()
+	return m.Manager.Destroy(ctx, taskId)
+}
+
+type deadlineQueue struct {
+	Queue
+	taskID   a2a.TaskID
+	config   WriteDeadline
+	lastRead *lastRead
+}
+
+func (q *deadlineQueue) Write(ctx context.Context, event a2a.Event) error {
+	if q.config.Timeout <= 0 {
+		return q.Queue.Write(ctx, event)
+	}
+
+	writeCtx, cancel := context.WithTimeout(ctx, q.config.Timeout)
+	err := q.Queue.Write(writeCtx, event)
+	cancel()
+	if err == nil || !errors.Is(writeCtx.Err(), context.DeadlineExceeded) || ctx.Err() != nil {
+		return err
+	}
+
+	if q.config.OnSlowConsumer != nil {
+		q.config.OnSlowConsumer(q.taskID, q.lastRead.since())
+	}
+	if q.config.Wait {
+		return q.Queue.Write(ctx, event)
+	}
+	return fmt.Errorf("%w: write blocked for %v", ErrSlowConsumer, q.config.Timeout)
+}
+
+func (q *deadlineQueue) Read(ctx context.Context) (a2a.Event, error) {
+	event, err := q.Queue.Read(ctx)
+	if err == nil {
+		q.lastRead.touch()
+	}
+	return event, err
+}
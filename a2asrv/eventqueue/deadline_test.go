@@ -0,0 +1,105 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventqueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestWriteDeadlineManager_FailsFast(t *testing.T) {
+	ctx := t.Context()
+	var reported a2a.TaskID
+	manager := NewWriteDeadlineManager(NewInMemoryManager(), WriteDeadline{
+		Timeout:        10 * time.Millisecond,
+		OnSlowConsumer: func(taskID a2a.TaskID, stalled time.Duration) { reported = taskID },
+	})
+	queue, err := manager.GetOrCreate(ctx, "task")
+	if err != nil {
+		t.Fatalf("GetOrCreate() error = %v", err)
+	}
+
+	for range defaultMaxQueueSize {
+		if err := queue.Write(ctx, &a2a.Message{}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := queue.Write(ctx, &a2a.Message{}); !errors.Is(err, ErrSlowConsumer) {
+		t.Fatalf("Write() to a full queue error = %v, want %v", err, ErrSlowConsumer)
+	}
+	if reported != "task" {
+		t.Fatalf("OnSlowConsumer() taskID = %q, want task", reported)
+	}
+
+	if _, err := queue.Read(ctx); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if err := queue.Write(ctx, &a2a.Message{}); err != nil {
+		t.Fatalf("Write() after Read() error = %v", err)
+	}
+}
+
+func TestWriteDeadlineManager_Wait(t *testing.T) {
+	ctx := t.Context()
+	slow := make(chan time.Duration, 1)
+	manager := NewWriteDeadlineManager(NewInMemoryManager(), WriteDeadline{
+		Timeout:        10 * time.Millisecond,
+		OnSlowConsumer: func(taskID a2a.TaskID, stalled time.Duration) { slow <- stalled },
+		Wait:           true,
+	})
+	queue, err := manager.GetOrCreate(ctx, "task")
+	if err != nil {
+		t.Fatalf("GetOrCreate() error = %v", err)
+	}
+	for range defaultMaxQueueSize {
+		if err := queue.Write(ctx, &a2a.Message{}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	go func() {
+		stalled := <-slow
+		if stalled < 10*time.Millisecond {
+			t.Errorf("OnSlowConsumer() stalled = %v, want at least the timeout", stalled)
+		}
+		_, _ = queue.Read(ctx)
+	}()
+	if err := queue.Write(ctx, &a2a.Message{}); err != nil {
+		t.Fatalf("Write() error = %v, want the write to wait for the consumer", err)
+	}
+}
+
+func TestWriteDeadlineManager_ParentContextCanceled(t *testing.T) {
+	manager := NewWriteDeadlineManager(NewInMemoryManager(), WriteDeadline{Timeout: time.Hour})
+	queue, err := manager.GetOrCreate(t.Context(), "task")
+	if err != nil {
+		t.Fatalf("GetOrCreate() error = %v", err)
+	}
+	for range defaultMaxQueueSize {
+		if err := queue.Write(t.Context(), &a2a.Message{}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if err := queue.Write(ctx, &a2a.Message{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Write() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventqueue
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// DefaultFanoutWindow is the number of most recent events a Fanout keeps if FanoutConfig.Window is not set.
+const DefaultFanoutWindow = 256
+
+// ErrEventsEvicted is returned by Fanout.SubscribeFrom when the requested events are no longer buffered.
+var ErrEventsEvicted = errors.New("events are no longer buffered")
+
+// EventEncoder writes the wire representation of an event, eg. a server-sent event frame.
+type EventEncoder func(w io.Writer, event a2a.Event) error
+
+// EncodeEventJSON is an EventEncoder writing the event encoded with a2a.MarshalEvent.
+func EncodeEventJSON(w io.Writer, event a2a.Event) error {
+	data, err := a2a.MarshalEvent(event)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+var frameBuffers = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// Frame is an event delivered to all subscribers of a Fanout. The event is encoded at most once,
+// when a subscriber first asks for the bytes, and the encoding is shared by all subscribers.
+// Every subscriber must call Release after it's done with the frame, so that the encoding buffer
+// can be reused after the frame leaves the Fanout window.
+type Frame struct {
+	// Seq is the sequence number of the event in the Task stream, starting from 1. It can be passed
+	// to Fanout.SubscribeFrom for resuming the stream, eg. as a server-sent event ID.
+	Seq uint64
+	// Event is the event read from the source queue. It must not be modified.
+	Event a2a.Event
+
+	encode EventEncoder
+	once   sync.Once
+	buf    *bytes.Buffer
+	err    error
+	refs   atomic.Int32
+}
+
+// Bytes returns the encoded event. The returned slice must not be modified and is only valid until Release.
+func (f *Frame) Bytes() ([]byte, error) {
+	f.once.Do(func() {
+		buf := frameBuffers.Get().(*bytes.Buffer)
+		buf.Reset()
+		if err := f.encode(buf, f.Event); err != nil {
+			frameBuffers.Put(buf)
+			f.err = err
+			return
+		}
+		f.buf = buf
+	})
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.buf.Bytes(), nil
+}
+
+// Release gives up the subscriber reference to the frame.
+func (f *Frame) Release() {
+	if f.refs.Add(-1) != 0 {
+		return
+	}
+	// Bytes can't be called concurrently anymore, so once guarantees buf is not written to
+	f.once.Do(func() {})
+	if f.buf != nil {
+		frameBuffers.Put(f.buf)
+		f.buf = nil
+	}
+}
+
+// FanoutConfig configures a Fanout.
+type FanoutConfig struct {
+	// Encode writes the wire representation of events. EncodeEventJSON is used if nil.
+	Encode EventEncoder
+	// Window is the number of most recent events kept for resubscribing and lagging subscribers.
+	// It bounds the memory used per Task. DefaultFanoutWindow is used if zero.
+	Window int
+}
+
+// Fanout delivers events read from a Task queue to multiple subscribers, eg. several clients streaming
+// the same Task. The most recent events are kept in a ring buffer shared by all subscribers: live
+// subscribers follow the head of the buffer and resubscribers replay the buffered events before
+// catching up. Events are encoded once per event, not once per subscriber. A subscriber which falls
+// behind by more than the window is disconnected instead of slowing down the others.
+type Fanout struct {
+	source Reader
+	encode EventEncoder
+
+	mu      sync.Mutex
+	ring    []*Frame
+	next    uint64 // sequence number of the next event
+	changed chan struct{}
+	closed  bool
+	err     error
+}
+
+// NewFanout creates a Fanout reading events from source.
+func NewFanout(source Reader, config FanoutConfig) *Fanout {
+	if config.Encode == nil {
+		config.Encode = EncodeEventJSON
+	}
+	if config.Window <= 0 {
+		config.Window = DefaultFanoutWindow
+	}
+	return &Fanout{
+		source:  source,
+		encode:  config.Encode,
+		ring:    make([]*Frame, config.Window),
+		next:    1,
+		changed: make(chan struct{}),
+	}
+}
+
+// Subscribe attaches a subscriber which receives the events read after the call.
+func (f *Fanout) Subscribe() *Subscription {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return newSubscription(f, f.next)
+}
+
+// SubscribeFrom attaches a subscriber which receives the events following the one with the seq sequence
+// number, starting with the oldest buffered event if seq is zero. Returns ErrEventsEvicted if some
+// of the events were already evicted from the window.
+func (f *Fanout) SubscribeFrom(seq uint64) (*Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	oldest := f.oldest()
+	if seq != 0 && seq+1 < oldest {
+		return nil, fmt.Errorf("%w: oldest buffered event is %d, requested events after %d", ErrEventsEvicted, oldest, seq)
+	}
+	return newSubscription(f, min(max(seq+1, oldest), f.next)), nil
+}
+
+// Run reads events from the source and appends them to the window until the source is closed,
+// which ends subscriptions with ErrQueueClosed after the buffered events are read, or until
+// ctx is done or reading fails, which ends them with the error.
+func (f *Fanout) Run(ctx context.Context) error {
+	for {
+		event, err := f.source.Read(ctx)
+		if err != nil {
+			f.close(err)
+			if errors.Is(err, ErrQueueClosed) {
+				return nil
+			}
+			return err
+		}
+		f.append(event)
+	}
+}
+
+// oldest returns the sequence number of the oldest buffered event. Requires mu.
+func (f *Fanout) oldest() uint64 {
+	if window := uint64(len(f.ring)); f.next > window {
+		return f.next - window
+	}
+	return 1
+}
+
+func (f *Fanout) append(event a2a.Event) {
+	frame := &Frame{Seq: f.next, Event: event, encode: f.encode}
+	// the reference held by the window is released when the frame is evicted
+	frame.refs.Store(1)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	slot := f.next % uint64(len(f.ring))
+	if evicted := f.ring[slot]; evicted != nil {
+		evicted.Release()
+	}
+	f.ring[slot] = frame
+	f.next++
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func (f *Fanout) close(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed, f.err = true, err
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+// Subscription reads Frames from a Fanout window. Next must not be called concurrently.
+type Subscription struct {
+	fanout *Fanout
+	cursor uint64 // sequence number of the next frame to return
+	err    error
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newSubscription(f *Fanout, cursor uint64) *Subscription {
+	return &Subscription{fanout: f, cursor: cursor, closed: make(chan struct{})}
+}
+
+// Next returns the next frame. The caller must Release it. After the Fanout source is closed
+// the remaining frames are returned followed by ErrQueueClosed. ErrSlowConsumer is returned
+// if the subscriber fell behind by more than the Fanout window.
+func (s *Subscription) Next(ctx context.Context) (*Frame, error) {
+	f := s.fanout
+	for {
+		if s.err != nil {
+			return nil, s.err
+		}
+		select {
+		case <-s.closed:
+			s.err = context.Canceled
+			continue
+		default:
+		}
+
+		f.mu.Lock()
+		if s.cursor < f.oldest() {
+			f.mu.Unlock()
+			s.err = ErrSlowConsumer
+			continue
+		}
+		if s.cursor < f.next {
+			frame := f.ring[s.cursor%uint64(len(f.ring))]
+			frame.refs.Add(1)
+			f.mu.Unlock()
+			s.cursor++
+			return frame, nil
+		}
+		if f.closed {
+			f.mu.Unlock()
+			s.err = f.err
+			continue
+		}
+		changed := f.changed
+		f.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-s.closed:
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
+	}
+}
+
+// Close detaches the subscriber. A blocked Next call returns context.Canceled.
+func (s *Subscription) Close() {
+	s.closeOnce.Do(func() { close(s.closed) })
+}
@@ -0,0 +1,282 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func writeEvents(t testing.TB, q Queue, task *a2a.Task, n int) {
+	t.Helper()
+	for i := range n {
+		event := a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, a2a.NewMessageForTask(a2a.MessageRoleAgent, *task, a2a.TextPart{Text: fmt.Sprintf("step %d", i)}))
+		if err := q.Write(context.Background(), event); err != nil {
+			t.Fatalf("Write() failed: %v", err)
+		}
+	}
+}
+
+func readFrames(ctx context.Context, sub *Subscription) (int, error) {
+	n := 0
+	for {
+		frame, err := sub.Next(ctx)
+		if err != nil {
+			return n, err
+		}
+		if _, err := frame.Bytes(); err != nil {
+			return n, err
+		}
+		frame.Release()
+		n++
+	}
+}
+
+func TestFanout(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	task := &a2a.Task{ID: "task", ContextID: "ctx"}
+	q := NewInMemoryQueue(16)
+
+	var encodings atomic.Int32
+	fanout := NewFanout(q, FanoutConfig{Window: 16, Encode: func(w io.Writer, event a2a.Event) error {
+		encodings.Add(1)
+		return EncodeEventJSON(w, event)
+	}})
+	subs := []*Subscription{fanout.Subscribe(), fanout.Subscribe(), fanout.Subscribe()}
+
+	writeEvents(t, q, task, 10)
+	_ = q.Close()
+	if err := fanout.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	for i, sub := range subs {
+		n, err := readFrames(ctx, sub)
+		if !errors.Is(err, ErrQueueClosed) || n != 10 {
+			t.Fatalf("subscriber %d got %d frames and %v, want 10 frames and %v", i, n, err, ErrQueueClosed)
+		}
+	}
+	if got := encodings.Load(); got != 10 {
+		t.Fatalf("events encoded %d times, want 10", got)
+	}
+
+	late := fanout.Subscribe()
+	if _, err := late.Next(ctx); !errors.Is(err, ErrQueueClosed) {
+		t.Fatalf("Next() after Run finished error = %v, want %v", err, ErrQueueClosed)
+	}
+}
+
+func TestFanout_SharedEncoding(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	task := &a2a.Task{ID: "task", ContextID: "ctx"}
+	q := NewInMemoryQueue(1)
+	fanout := NewFanout(q, FanoutConfig{})
+	first, second := fanout.Subscribe(), fanout.Subscribe()
+
+	writeEvents(t, q, task, 1)
+	_ = q.Close()
+	if err := fanout.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	frame1, err := first.Next(ctx)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	frame2, err := second.Next(ctx)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	b1, _ := frame1.Bytes()
+	b2, _ := frame2.Bytes()
+	if frame1 != frame2 || &b1[0] != &b2[0] {
+		t.Fatal("subscribers got separate encodings, want a shared frame")
+	}
+	event, err := a2a.UnmarshalEvent(b1)
+	if err != nil || a2a.EventKindOf(event) != a2a.EventKindStatusUpdate || frame1.Seq != 1 {
+		t.Fatalf("frame %d = %s, want the first status update", frame1.Seq, b1)
+	}
+	frame1.Release()
+	frame2.Release()
+}
+
+func TestFanout_Window(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	task := &a2a.Task{ID: "task", ContextID: "ctx"}
+	q := NewInMemoryQueue(16)
+	fanout := NewFanout(q, FanoutConfig{Window: 2})
+	lagging := fanout.Subscribe()
+
+	writeEvents(t, q, task, 5)
+	_ = q.Close()
+	if err := fanout.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if n, err := readFrames(ctx, lagging); !errors.Is(err, ErrSlowConsumer) || n != 0 {
+		t.Fatalf("lagging subscriber got %d frames and %v, want %v", n, err, ErrSlowConsumer)
+	}
+	if _, err := fanout.SubscribeFrom(2); !errors.Is(err, ErrEventsEvicted) {
+		t.Fatalf("SubscribeFrom(2) error = %v, want %v", err, ErrEventsEvicted)
+	}
+	for _, seq := range []uint64{0, 3} {
+		sub, err := fanout.SubscribeFrom(seq)
+		if err != nil {
+			t.Fatalf("SubscribeFrom(%d) error = %v", seq, err)
+		}
+		frame, err := sub.Next(ctx)
+		if err != nil || frame.Seq != 4 {
+			t.Fatalf("SubscribeFrom(%d).Next() = %v, %v, want frame 4", seq, frame, err)
+		}
+		frame.Release()
+		if n, err := readFrames(ctx, sub); !errors.Is(err, ErrQueueClosed) || n != 1 {
+			t.Fatalf("resubscriber got %d more frames and %v, want 1 frame and %v", n, err, ErrQueueClosed)
+		}
+	}
+}
+
+func TestFanout_EvictedFrameHeld(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	task := &a2a.Task{ID: "task", ContextID: "ctx"}
+	q := NewInMemoryQueue(16)
+	fanout := NewFanout(q, FanoutConfig{Window: 1})
+	sub := fanout.Subscribe()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = fanout.Run(ctx)
+	}()
+	writeEvents(t, q, task, 1)
+	frame, err := sub.Next(ctx)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	want, _ := frame.Bytes()
+	want = append([]byte(nil), want...)
+
+	writeEvents(t, q, task, 3)
+	_ = q.Close()
+	wg.Wait()
+	if got, _ := frame.Bytes(); string(got) != string(want) {
+		t.Fatalf("evicted frame Bytes() = %s, want %s", got, want)
+	}
+	frame.Release()
+}
+
+func TestFanout_Close(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	task := &a2a.Task{ID: "task", ContextID: "ctx"}
+	q := NewInMemoryQueue(16)
+	fanout := NewFanout(q, FanoutConfig{})
+	sub := fanout.Subscribe()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = fanout.Run(ctx)
+	}()
+	writeEvents(t, q, task, 1)
+	frame, err := sub.Next(ctx)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	frame.Release()
+
+	go sub.Close()
+	if _, err := sub.Next(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Next() after Close() error = %v, want %v", err, context.Canceled)
+	}
+	_ = q.Close()
+	wg.Wait()
+}
+
+const benchmarkSubscribers = 100
+
+// BenchmarkFanout measures streaming one Task to 100 subscribers with a shared encoding per event.
+func BenchmarkFanout(b *testing.B) {
+	benchmarkStreaming(b, func(frame *Frame) error {
+		_, err := frame.Bytes()
+		return err
+	})
+}
+
+// BenchmarkFanout_PerSubscriberEncoding is the baseline where every subscriber encodes every event.
+func BenchmarkFanout_PerSubscriberEncoding(b *testing.B) {
+	benchmarkStreaming(b, func(frame *Frame) error {
+		_, err := a2a.MarshalEvent(frame.Event)
+		return err
+	})
+}
+
+func benchmarkStreaming(b *testing.B, consume func(*Frame) error) {
+	ctx := context.Background()
+	task := &a2a.Task{ID: "task", ContextID: "ctx"}
+	events := make([]a2a.Event, b.N)
+	for i := range events {
+		events[i] = a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, a2a.NewMessageForTask(a2a.MessageRoleAgent, *task, a2a.TextPart{Text: fmt.Sprintf("step %d", i)}))
+	}
+	q := NewInMemoryQueue(1024)
+	fanout := NewFanout(q, FanoutConfig{Window: b.N + 1})
+
+	var wg sync.WaitGroup
+	for range benchmarkSubscribers {
+		sub := fanout.Subscribe()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				frame, err := sub.Next(ctx)
+				if err != nil {
+					return
+				}
+				if err := consume(frame); err != nil {
+					b.Error(err)
+				}
+				frame.Release()
+			}
+		}()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	go func() {
+		for _, event := range events {
+			if err := q.Write(ctx, event); err != nil {
+				b.Error(err)
+				break
+			}
+		}
+		_ = q.Close()
+	}()
+	if err := fanout.Run(ctx); err != nil {
+		b.Fatal(err)
+	}
+	wg.Wait()
+}
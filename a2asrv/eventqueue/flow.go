@@ -0,0 +1,111 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventqueue
+
+import (
+	"context"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// CreditWindow implements credit-based flow control between an event producer and a consumer.
+// The producer spends a credit for every event and blocks when none are left. The consumer grants
+// credits back as it delivers events, so the number of events in flight never exceeds the window
+// and a fast producer is slowed down to the rate of the consumer.
+type CreditWindow struct {
+	mu      sync.Mutex
+	credits int
+	closed  bool
+	// granted is closed and replaced every time credits are granted to wake up blocked producers.
+	granted chan struct{}
+}
+
+// NewCreditWindow creates a CreditWindow with the initial number of credits.
+func NewCreditWindow(size int) *CreditWindow {
+	return &CreditWindow{credits: size, granted: make(chan struct{})}
+}
+
+// Acquire spends a credit, blocking until one is granted, the window is closed or ctx is canceled.
+func (w *CreditWindow) Acquire(ctx context.Context) error {
+	for {
+		w.mu.Lock()
+		if w.closed || w.credits > 0 {
+			if !w.closed {
+				w.credits--
+			}
+			w.mu.Unlock()
+			return nil
+		}
+		granted := w.granted
+		w.mu.Unlock()
+
+		select {
+		case <-granted:
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
+
+// Grant returns n credits to the window.
+func (w *CreditWindow) Grant(n int) {
+	if n <= 0 {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.credits += n
+	w.wake()
+}
+
+// Close disables flow control: blocked and future Acquire calls return immediately. Consumers
+// close the window when they stop delivering events, so that producers are not stuck forever.
+func (w *CreditWindow) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	w.wake()
+}
+
+// Available returns the number of credits left.
+func (w *CreditWindow) Available() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.credits
+}
+
+func (w *CreditWindow) wake() {
+	close(w.granted)
+	w.granted = make(chan struct{})
+}
+
+// NewFlowControlledQueue wraps a Queue so that every Write spends a credit of the window first.
+// A producer writing to the returned queue is blocked while the consumer is behind by the window size.
+func NewFlowControlledQueue(queue Queue, window *CreditWindow) Queue {
+	return &flowControlledQueue{Queue: queue, window: window}
+}
+
+type flowControlledQueue struct {
+	Queue
+	window *CreditWindow
+}
+
+func (q *flowControlledQueue) Write(ctx context.Context, event a2a.Event) error {
+	if err := q.window.Acquire(ctx); err != nil {
+		return err
+	}
+	return q.Queue.Write(ctx, event)
+}
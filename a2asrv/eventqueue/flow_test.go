@@ -0,0 +1,108 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventqueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestCreditWindow_BlocksUntilGranted(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	window := NewCreditWindow(1)
+
+	if err := window.Acquire(ctx); err != nil {
+		t.Fatalf("Acquire() failed: %v", err)
+	}
+	acquired := make(chan error)
+	go func() {
+		acquired <- window.Acquire(ctx)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Acquire() should block without credits")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	window.Grant(1)
+	if err := <-acquired; err != nil {
+		t.Fatalf("Acquire() failed: %v", err)
+	}
+	if got := window.Available(); got != 0 {
+		t.Errorf("Available() = %d, want 0", got)
+	}
+}
+
+func TestCreditWindow_ContextCanceled(t *testing.T) {
+	t.Parallel()
+	window := NewCreditWindow(0)
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	if err := window.Acquire(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Acquire() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestCreditWindow_Close(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	window := NewCreditWindow(0)
+	acquired := make(chan error)
+	go func() {
+		acquired <- window.Acquire(ctx)
+	}()
+
+	window.Close()
+	if err := <-acquired; err != nil {
+		t.Fatalf("Acquire() failed: %v", err)
+	}
+	if err := window.Acquire(ctx); err != nil {
+		t.Fatalf("Acquire() after Close() failed: %v", err)
+	}
+}
+
+func TestFlowControlledQueue(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	window := NewCreditWindow(2)
+	q := NewFlowControlledQueue(NewInMemoryQueue(10), window)
+
+	for i := range 2 {
+		if err := q.Write(ctx, &a2a.Message{ID: "msg"}); err != nil {
+			t.Fatalf("Write(%d) failed: %v", i, err)
+		}
+	}
+
+	writeCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := q.Write(writeCtx, &a2a.Message{ID: "msg"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Write() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if _, err := q.Read(ctx); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	window.Grant(1)
+	if err := q.Write(ctx, &a2a.Message{ID: "msg"}); err != nil {
+		t.Fatalf("Write() after Grant() failed: %v", err)
+	}
+}
@@ -17,43 +17,140 @@ package eventqueue
 import (
 	"context"
 	"fmt"
+	"hash/maphash"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/a2aproject/a2a-go/a2a"
 )
 
+// managerShards is the number of independently locked partitions of the queue map. Tasks are
+// spread between shards by a hash of their ID, so that concurrent operations on different tasks
+// rarely contend for the same lock.
+const managerShards = 64
+
 // Implements Manager interface
 type inMemoryManager struct {
+	seed     maphash.Seed
+	shards   [managerShards]managerShard
+	observer Observer
+}
+
+type managerShard struct {
 	mu     sync.Mutex
 	queues map[a2a.TaskID]Queue
 }
 
 // NewInMemoryManager creates a new queue manager
 func NewInMemoryManager() Manager {
-	return &inMemoryManager{
-		queues: make(map[a2a.TaskID]Queue),
+	return NewInMemoryManagerWithConfig(InMemoryManagerConfig{})
+}
+
+// InMemoryManagerConfig configures a Manager created by NewInMemoryManagerWithConfig.
+type InMemoryManagerConfig struct {
+	// Observer receives the metrics of all the queues created by the Manager, if set.
+	Observer Observer
+}
+
+// NewInMemoryManagerWithConfig creates a new queue manager configured by config.
+func NewInMemoryManagerWithConfig(config InMemoryManagerConfig) Manager {
+	m := &inMemoryManager{seed: maphash.MakeSeed(), observer: config.Observer}
+	for i := range m.shards {
+		m.shards[i].queues = make(map[a2a.TaskID]Queue)
 	}
+	return m
+}
+
+func (m *inMemoryManager) shard(taskID a2a.TaskID) *managerShard {
+	return &m.shards[maphash.String(m.seed, string(taskID))%managerShards]
 }
 
 func (m *inMemoryManager) GetOrCreate(ctx context.Context, taskId a2a.TaskID) (Queue, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	if _, ok := m.queues[taskId]; !ok {
-		queue := NewInMemoryQueue(defaultMaxQueueSize)
-		m.queues[taskId] = queue
+	shard := m.shard(taskId)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if _, ok := shard.queues[taskId]; !ok {
+		queue := newInMemoryQueue(defaultMaxQueueSize)
+		if m.observer != nil {
+			queue.taskID, queue.observer = taskId, m.observer
+		}
+		shard.queues[taskId] = queue
 	}
-	return m.queues[taskId], nil
+	return shard.queues[taskId], nil
 }
 
 func (m *inMemoryManager) Destroy(ctx context.Context, taskId a2a.TaskID) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	if _, ok := m.queues[taskId]; !ok {
+	shard := m.shard(taskId)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if _, ok := shard.queues[taskId]; !ok {
 		// todo: consider not failing when it already has desired state
 		return fmt.Errorf("queue cannot be destroyed as queue for taskId: %s does not exist", taskId)
 	}
-	queue := m.queues[taskId]
+	queue := shard.queues[taskId]
 	_ = queue.Close() // in memory queue close never fails
-	delete(m.queues, taskId)
+	delete(shard.queues, taskId)
 	return nil
 }
+
+// len returns the number of active queues.
+func (m *inMemoryManager) len() int {
+	count := 0
+	for i := range m.shards {
+		shard := &m.shards[i]
+		shard.mu.Lock()
+		count += len(shard.queues)
+		shard.mu.Unlock()
+	}
+	return count
+}
+
+// QueueStats is a snapshot of a queue state reported for debugging.
+type QueueStats struct {
+	// TaskID is the ID of the Task the queue was created for.
+	TaskID a2a.TaskID `json:"taskId"`
+	// Len is the number of events waiting to be read.
+	Len int `json:"len"`
+	// Capacity is the maximum number of events the queue can hold before Write blocks.
+	Capacity int `json:"capacity"`
+	// OldestEventAge is the time since the oldest unread event was written. Zero if the queue is empty.
+	OldestEventAge time.Duration `json:"oldestEventAge"`
+	// Reads is the number of events read from the queue.
+	Reads int64 `json:"reads"`
+	// Writes is the number of events written to the queue.
+	Writes int64 `json:"writes"`
+	// WriterBlocked is the total time writers spent waiting for room in a full queue.
+	WriterBlocked time.Duration `json:"writerBlocked"`
+}
+
+var _ QueueStatsReporter = (*inMemoryManager)(nil)
+
+// Stats returns the QueueStats slice reported by QueueStats, so that the state of the queues is included
+// in debug reports.
+func (m *inMemoryManager) Stats(ctx context.Context) (any, error) {
+	return m.QueueStats(ctx)
+}
+
+// QueueStats returns QueueStats for every active queue sorted by TaskID. Shards are visited one by one,
+// so the result is not an atomic snapshot of all the queues.
+func (m *inMemoryManager) QueueStats(ctx context.Context) ([]QueueStats, error) {
+	result := make([]QueueStats, 0)
+	for i := range m.shards {
+		shard := &m.shards[i]
+		shard.mu.Lock()
+		for taskID, queue := range shard.queues {
+			stats := QueueStats{TaskID: taskID}
+			if q, ok := queue.(*inMemoryQueue); ok {
+				stats = q.stats(taskID)
+			}
+			result = append(result, stats)
+		}
+		shard.mu.Unlock()
+	}
+	slices.SortFunc(result, func(a, b QueueStats) int {
+		return strings.Compare(string(a.TaskID), string(b.TaskID))
+	})
+	return result, nil
+}
@@ -15,10 +15,14 @@
 package eventqueue
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/a2aproject/a2a-go/a2a"
 )
@@ -147,7 +151,202 @@ func TestInMemoryManager_ConcurrentCreation(t *testing.T) {
 	}
 
 	imqm := m.(*inMemoryManager)
-	if len(imqm.queues) != numTaskIDs {
-		t.Fatalf("Expected %d queues to be created, but got %d", numTaskIDs, len(imqm.queues))
+	if imqm.len() != numTaskIDs {
+		t.Fatalf("Expected %d queues to be created, but got %d", numTaskIDs, imqm.len())
 	}
 }
+
+func TestInMemoryManager_Stats(t *testing.T) {
+	t.Parallel()
+	m := NewInMemoryManager()
+	ctx := t.Context()
+
+	q, err := m.GetOrCreate(ctx, "task-2")
+	if err != nil {
+		t.Fatalf("GetOrCreate() failed: %v", err)
+	}
+	if err := q.Write(ctx, &a2a.Message{ID: "msg"}); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	if _, err := m.GetOrCreate(ctx, "task-1"); err != nil {
+		t.Fatalf("GetOrCreate() failed: %v", err)
+	}
+
+	queues, err := m.(QueueStatsReporter).QueueStats(ctx)
+	if err != nil {
+		t.Fatalf("QueueStats() failed: %v", err)
+	}
+	if queues[1].OldestEventAge <= 0 {
+		t.Errorf("QueueStats()[1].OldestEventAge = %v, want > 0", queues[1].OldestEventAge)
+	}
+	queues[1].OldestEventAge = 0
+	want := []QueueStats{
+		{TaskID: "task-1", Len: 0, Capacity: defaultMaxQueueSize},
+		{TaskID: "task-2", Len: 1, Capacity: defaultMaxQueueSize, Writes: 1},
+	}
+	if !reflect.DeepEqual(queues, want) {
+		t.Errorf("QueueStats() = %v, want %v", queues, want)
+	}
+	// debug reports include the same stats
+	if got, err := m.(*inMemoryManager).Stats(ctx); err != nil || len(got.([]QueueStats)) != len(want) {
+		t.Errorf("Stats() = %v, %v, want %d queues", got, err, len(want))
+	}
+}
+
+// recordingObserver records the metrics reported by queues.
+type recordingObserver struct {
+	mu     sync.Mutex
+	depths []int
+	ages   []time.Duration
+	stalls []time.Duration
+}
+
+func (o *recordingObserver) QueueDepth(taskID a2a.TaskID, depth int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.depths = append(o.depths, depth)
+}
+
+func (o *recordingObserver) OldestEventAge(taskID a2a.TaskID, age time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.ages = append(o.ages, age)
+}
+
+func (o *recordingObserver) WriterStalled(taskID a2a.TaskID, stall time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.stalls = append(o.stalls, stall)
+}
+
+func TestInMemoryManager_Observer(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	observer := &recordingObserver{}
+	m := NewInMemoryManagerWithConfig(InMemoryManagerConfig{Observer: observer})
+	q, err := m.GetOrCreate(ctx, "task")
+	if err != nil {
+		t.Fatalf("GetOrCreate() failed: %v", err)
+	}
+
+	for i := range defaultMaxQueueSize {
+		if err := q.Write(ctx, &a2a.Message{ID: fmt.Sprint(i)}); err != nil {
+			t.Fatalf("Write() failed: %v", err)
+		}
+	}
+	written := make(chan error)
+	go func() {
+		written <- q.Write(ctx, &a2a.Message{ID: "blocked"})
+	}()
+	time.Sleep(20 * time.Millisecond)
+	if _, err := q.Read(ctx); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if err := <-written; err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	// every write and read reports the depth, the writes which filled the queue report the grown depth
+	if len(observer.depths) != defaultMaxQueueSize+2 || observer.depths[0] != 1 || observer.depths[defaultMaxQueueSize-1] != defaultMaxQueueSize {
+		t.Errorf("reported %d depths, want %d growing from 1 to %d", len(observer.depths), defaultMaxQueueSize+2, defaultMaxQueueSize)
+	}
+	if len(observer.ages) != 1 || observer.ages[0] < 20*time.Millisecond {
+		t.Errorf("reported ages = %v, want one >= 20ms", observer.ages)
+	}
+	if len(observer.stalls) != 1 || observer.stalls[0] < 20*time.Millisecond {
+		t.Errorf("reported stalls = %v, want one >= 20ms", observer.stalls)
+	}
+}
+
+func TestInMemoryManager_ConcurrentMixedWorkload(t *testing.T) {
+	t.Parallel()
+	m := NewInMemoryManager()
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	numTaskIDs, numWorkers, numEvents := 5, 20, 50
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		taskID := a2a.TaskID(fmt.Sprintf("task-%d", i%numTaskIDs))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numEvents; j++ {
+				q, err := m.GetOrCreate(ctx, taskID)
+				if err != nil {
+					t.Errorf("GetOrCreate() failed: %v", err)
+					return
+				}
+				// the queue might have been destroyed by another worker
+				writeCtx, cancelWrite := context.WithTimeout(ctx, 10*time.Millisecond)
+				err = q.Write(writeCtx, &a2a.Message{ID: fmt.Sprintf("msg-%d", j)})
+				cancelWrite()
+				if err != nil && !errors.Is(err, ErrQueueClosed) && !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("Write() failed: %v", err)
+				}
+			}
+		}()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numEvents; j++ {
+				q, err := m.GetOrCreate(ctx, taskID)
+				if err != nil {
+					t.Errorf("GetOrCreate() failed: %v", err)
+					return
+				}
+				readCtx, cancelRead := context.WithTimeout(ctx, time.Millisecond)
+				_, err = q.Read(readCtx)
+				cancelRead()
+				if err != nil && !errors.Is(err, ErrQueueClosed) && !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("Read() failed: %v", err)
+				}
+				if j%10 == 0 {
+					// fails if another worker has already destroyed the queue
+					_ = m.Destroy(ctx, taskID)
+				}
+				if _, err := m.(*inMemoryManager).Stats(ctx); err != nil {
+					t.Errorf("Stats() failed: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < numTaskIDs; i++ {
+		taskID := a2a.TaskID(fmt.Sprintf("task-%d", i))
+		_ = m.Destroy(ctx, taskID)
+	}
+	if got := m.(*inMemoryManager).len(); got != 0 {
+		t.Fatalf("got %d queues after destroying all, want 0", got)
+	}
+}
+
+// BenchmarkInMemoryManager_GetOrCreateDestroy measures concurrent task creation and cleanup
+// where every goroutine works with its own tasks, as on a server handling many tasks at once.
+func BenchmarkInMemoryManager_GetOrCreateDestroy(b *testing.B) {
+	ctx := context.Background()
+	m := NewInMemoryManager()
+	var nextID atomic.Int64
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			taskID := a2a.TaskID(fmt.Sprintf("task-%d", nextID.Add(1)))
+			if _, err := m.GetOrCreate(ctx, taskID); err != nil {
+				b.Error(err)
+				return
+			}
+			if _, err := m.GetOrCreate(ctx, taskID); err != nil {
+				b.Error(err)
+				return
+			}
+			if err := m.Destroy(ctx, taskID); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventqueue
+
+import (
+	"context"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// Observer receives the metrics of in-memory queues as they change, eg. to export them to a monitoring
+// system. Methods are called synchronously by queue operations, so they must be fast and safe for
+// concurrent use.
+type Observer interface {
+	// QueueDepth is called after an event is written to or read from the queue of the Task with
+	// the number of events waiting to be read.
+	QueueDepth(taskID a2a.TaskID, depth int)
+	// OldestEventAge is called when the oldest event of the queue is read with the time it waited
+	// in the queue.
+	OldestEventAge(taskID a2a.TaskID, age time.Duration)
+	// WriterStalled is called when a Write which waited for room in the full queue finishes with
+	// the time it waited.
+	WriterStalled(taskID a2a.TaskID, stall time.Duration)
+}
+
+// QueueStatsReporter is an optional interface of Manager implementations which report the state
+// of their queues.
+type QueueStatsReporter interface {
+	// QueueStats returns QueueStats for every active queue sorted by TaskID.
+	QueueStats(ctx context.Context) ([]QueueStats, error)
+}
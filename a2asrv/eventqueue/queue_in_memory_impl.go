@@ -17,6 +17,8 @@ package eventqueue
 import (
 	"context"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/a2aproject/a2a-go/a2a"
 )
@@ -48,6 +50,19 @@ type inMemoryQueue struct {
 	// closeChan is closed by Close() to ensure Write() calls are not blocked on trying to write
 	// to a full events channel, preventing Close() to close it.
 	closeChan chan struct{}
+
+	// enqueuedMu guards enqueued which holds the write time of every event in the events channel
+	// in FIFO order. Writes are serialized by the semaphore, so the order matches the channel.
+	enqueuedMu sync.Mutex
+	enqueued   []time.Time
+
+	reads         atomic.Int64
+	writes        atomic.Int64
+	writerBlocked atomic.Int64
+
+	// observer receives the metrics of the queue created for taskID by a Manager, if set.
+	observer Observer
+	taskID   a2a.TaskID
 }
 
 func newSemaphore(count int) *semaphore {
@@ -63,7 +78,7 @@ func (s *semaphore) acquireWithContext(ctx context.Context) error {
 	case s.tokens <- struct{}{}:
 		return nil
 	case <-ctx.Done():
-		return ctx.Err()
+		return context.Cause(ctx)
 	}
 }
 
@@ -73,6 +88,10 @@ func (s *semaphore) release() {
 
 // NewInMemoryQueue creates a new queue of desired size
 func NewInMemoryQueue(size int) Queue {
+	return newInMemoryQueue(size)
+}
+
+func newInMemoryQueue(size int) *inMemoryQueue {
 	return &inMemoryQueue{
 		// todo: consider using https://pkg.go.dev/golang.org/x/sync/semaphore instead
 		semaphore: newSemaphore(1),
@@ -95,13 +114,33 @@ func (q *inMemoryQueue) Write(ctx context.Context, event a2a.Event) error {
 		return ErrQueueClosed
 	}
 
+	q.pushEnqueued(time.Now())
 	select {
 	case q.events <- event:
+		q.written()
+		return nil
+	default:
+	}
+
+	// The channel is full, the time until a reader makes room is accounted as writer stall.
+	blockedSince := time.Now()
+	defer func() {
+		stall := time.Since(blockedSince)
+		q.writerBlocked.Add(int64(stall))
+		if q.observer != nil {
+			q.observer.WriterStalled(q.taskID, stall)
+		}
+	}()
+	select {
+	case q.events <- event:
+		q.written()
 		return nil
 	case <-q.closeChan:
+		q.popEnqueued(false)
 		return ErrQueueClosed
 	case <-ctx.Done():
-		return ctx.Err()
+		q.popEnqueued(false)
+		return context.Cause(ctx)
 	}
 }
 
@@ -112,9 +151,17 @@ func (q *inMemoryQueue) Read(ctx context.Context) (a2a.Event, error) {
 		if !ok {
 			return nil, ErrQueueClosed
 		}
+		enqueued := q.popEnqueued(true)
+		q.reads.Add(1)
+		if q.observer != nil {
+			if !enqueued.IsZero() {
+				q.observer.OldestEventAge(q.taskID, time.Since(enqueued))
+			}
+			q.observer.QueueDepth(q.taskID, len(q.events))
+		}
 		return event, nil
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return nil, context.Cause(ctx)
 	}
 }
 
@@ -136,3 +183,55 @@ func (q *inMemoryQueue) Close() error {
 
 	return nil
 }
+
+// written updates the metrics after an event was sent to the events channel.
+func (q *inMemoryQueue) written() {
+	q.writes.Add(1)
+	if q.observer != nil {
+		q.observer.QueueDepth(q.taskID, len(q.events))
+	}
+}
+
+// pushEnqueued records the write time of an event about to be sent to the events channel.
+func (q *inMemoryQueue) pushEnqueued(t time.Time) {
+	q.enqueuedMu.Lock()
+	defer q.enqueuedMu.Unlock()
+	q.enqueued = append(q.enqueued, t)
+}
+
+// popEnqueued removes and returns the write time of the oldest event if front is true, which happens
+// when the event is read. Otherwise it removes the write time of the newest event, which happens when
+// it was not sent to the channel.
+func (q *inMemoryQueue) popEnqueued(front bool) time.Time {
+	q.enqueuedMu.Lock()
+	defer q.enqueuedMu.Unlock()
+	if len(q.enqueued) == 0 {
+		return time.Time{}
+	}
+	if !front {
+		q.enqueued = q.enqueued[:len(q.enqueued)-1]
+		return time.Time{}
+	}
+	oldest := q.enqueued[0]
+	q.enqueued[0] = time.Time{}
+	q.enqueued = q.enqueued[1:]
+	return oldest
+}
+
+// stats returns a snapshot of the queue gauges and counters.
+func (q *inMemoryQueue) stats(taskID a2a.TaskID) QueueStats {
+	stats := QueueStats{
+		TaskID:        taskID,
+		Len:           len(q.events),
+		Capacity:      cap(q.events),
+		Reads:         q.reads.Load(),
+		Writes:        q.writes.Load(),
+		WriterBlocked: time.Duration(q.writerBlocked.Load()),
+	}
+	q.enqueuedMu.Lock()
+	if len(q.enqueued) > 0 {
+		stats.OldestEventAge = time.Since(q.enqueued[0])
+	}
+	q.enqueuedMu.Unlock()
+	return stats
+}
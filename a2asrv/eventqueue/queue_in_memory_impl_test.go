@@ -17,7 +17,9 @@ package eventqueue
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -139,6 +141,50 @@ func TestInMemoryQueue_WriteFull(t *testing.T) {
 	}
 }
 
+func TestInMemoryQueue_Stats(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	q := NewInMemoryQueue(1).(*inMemoryQueue)
+
+	if got := q.stats("task"); got != (QueueStats{TaskID: "task", Capacity: 1}) {
+		t.Fatalf("stats() = %+v, want empty", got)
+	}
+
+	if err := q.Write(ctx, &a2a.Message{ID: "1"}); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	written := make(chan error)
+	go func() {
+		written <- q.Write(ctx, &a2a.Message{ID: "2"})
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	got := q.stats("task")
+	if got.Len != 1 || got.Writes != 1 || got.Reads != 0 {
+		t.Errorf("stats() = %+v, want Len = 1, Writes = 1, Reads = 0", got)
+	}
+	if got.OldestEventAge < 50*time.Millisecond {
+		t.Errorf("stats().OldestEventAge = %v, want >= 50ms", got.OldestEventAge)
+	}
+
+	for range 2 {
+		if _, err := q.Read(ctx); err != nil {
+			t.Fatalf("Read() failed: %v", err)
+		}
+	}
+	if err := <-written; err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+
+	got = q.stats("task")
+	if got.Len != 0 || got.Writes != 2 || got.Reads != 2 || got.OldestEventAge != 0 {
+		t.Errorf("stats() = %+v, want Len = 0, Writes = 2, Reads = 2, OldestEventAge = 0", got)
+	}
+	if got.WriterBlocked < 50*time.Millisecond {
+		t.Errorf("stats().WriterBlocked = %v, want >= 50ms", got.WriterBlocked)
+	}
+}
+
 func TestInMemoryQueue_Close(t *testing.T) {
 	t.Parallel()
 	ctx := t.Context()
@@ -240,3 +286,112 @@ func TestInMemoryQueue_BlockedWriteOnFullQueueThenClose(t *testing.T) {
 		}
 	}
 }
+
+// The benchmarks below guard the throughput and contention characteristics of the in-memory queue.
+// They are compared against the base branch on every pull request, see tools/benchcheck.sh.
+
+func BenchmarkInMemoryQueue_SingleProducer(b *testing.B) {
+	benchmarkQueueThroughput(b, defaultMaxQueueSize, 1)
+}
+
+func BenchmarkInMemoryQueue_MultiProducer(b *testing.B) {
+	for _, producers := range []int{2, 8, 32} {
+		b.Run(fmt.Sprintf("producers=%d", producers), func(b *testing.B) {
+			benchmarkQueueThroughput(b, defaultMaxQueueSize, producers)
+		})
+	}
+}
+
+// BenchmarkInMemoryQueue_FullQueue measures writers contending for a single slot,
+// so that almost every Write blocks on a full channel while holding the semaphore.
+func BenchmarkInMemoryQueue_FullQueue(b *testing.B) {
+	for _, producers := range []int{1, 8} {
+		b.Run(fmt.Sprintf("producers=%d", producers), func(b *testing.B) {
+			benchmarkQueueThroughput(b, 1, producers)
+		})
+	}
+}
+
+// BenchmarkInMemoryQueue_CloseUnderLoad measures how long Close takes to unblock writers
+// which keep writing to a full queue.
+func BenchmarkInMemoryQueue_CloseUnderLoad(b *testing.B) {
+	const producers = 8
+	ctx := context.Background()
+	event := &a2a.Message{ID: "event"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		b.StopTimer()
+		q := NewInMemoryQueue(16)
+		var wg sync.WaitGroup
+		for range producers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					if err := q.Write(ctx, event); err != nil {
+						return
+					}
+				}
+			}()
+		}
+		for range 64 {
+			if _, err := q.Read(ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.StartTimer()
+
+		if err := q.Close(); err != nil {
+			b.Fatal(err)
+		}
+		wg.Wait()
+	}
+}
+
+// benchmarkQueueThroughput writes b.N events split between producers to a queue of the given size
+// while a single consumer drains it.
+func benchmarkQueueThroughput(b *testing.B, size, producers int) {
+	ctx := context.Background()
+	q := NewInMemoryQueue(size)
+	event := &a2a.Message{ID: "event"}
+
+	consumed := make(chan int)
+	go func() {
+		count := 0
+		for {
+			if _, err := q.Read(ctx); err != nil {
+				consumed <- count
+				return
+			}
+			count++
+		}
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	var wg sync.WaitGroup
+	for i := range producers {
+		n := b.N / producers
+		if i < b.N%producers {
+			n++
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range n {
+				if err := q.Write(ctx, event); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if err := q.Close(); err != nil {
+		b.Fatal(err)
+	}
+	if count := <-consumed; count != b.N {
+		b.Errorf("consumed %d events, want %d", count, b.N)
+	}
+}
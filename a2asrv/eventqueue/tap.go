@@ -0,0 +1,100 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventqueue
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// TapRecord is a line written by a tapped Manager for every event passing through its queues.
+type TapRecord struct {
+	// Time is when the event was written or read.
+	Time time.Time `json:"time"`
+	// Direction is "write" for events written by AgentExecutor and "read" for events consumed by the server.
+	Direction string `json:"direction"`
+	// TaskID is the ID of the Task the queue was created for.
+	TaskID a2a.TaskID `json:"taskId"`
+	// Kind is the protocol kind of the event: "message", "task", "status-update" or "artifact-update",
+	// or the kind a custom event was registered with.
+	Kind string `json:"kind"`
+	// Event is the event payload.
+	Event a2a.Event `json:"event"`
+}
+
+// NewTapManager wraps a Manager to mirror every event written to or read from its queues
+// to w as JSON lines of TapRecord. It is intended for watching the interplay of AgentExecutor
+// and the server during development. Failures to write to w are ignored.
+func NewTapManager(manager Manager, w io.Writer) Manager {
+	return &tapManager{Manager: manager, tap: &tap{enc: json.NewEncoder(w)}}
+}
+
+type tap struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (t *tap) record(direction string, taskID a2a.TaskID, event a2a.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_ = t.enc.Encode(TapRecord{
+		Time:      time.Now(),
+		Direction: direction,
+		TaskID:    taskID,
+		Kind:      a2a.EventKindOf(event),
+		Event:     event,
+	})
+}
+
+type tapManager struct {
+	Manager
+	tap *tap
+}
+
+func (m *tapManager) GetOrCreate(ctx context.Context, taskId a2a.TaskID) (Queue, error) {
+	queue, err := m.Manager.GetOrCreate(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+	return &tapQueue{Queue: queue, taskID: taskId, tap: m.tap}, nil
+}
+
+type tapQueue struct {
+	Queue
+	taskID a2a.TaskID
+	tap    *tap
+}
+
+func (q *tapQueue) Write(ctx context.Context, event a2a.Event) error {
+	if err := q.Queue.Write(ctx, event); err != nil {
+		return err
+	}
+	q.tap.record("write", q.taskID, event)
+	return nil
+}
+
+func (q *tapQueue) Read(ctx context.Context) (a2a.Event, error) {
+	event, err := q.Queue.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q.tap.record("read", q.taskID, event)
+	return event, nil
+}
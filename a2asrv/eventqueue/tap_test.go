@@ -0,0 +1,70 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventqueue
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestTapManager(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	var buf bytes.Buffer
+	m := NewTapManager(NewInMemoryManager(), &buf)
+
+	q, err := m.GetOrCreate(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("GetOrCreate() failed: %v", err)
+	}
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx"}
+	if err := q.Write(ctx, task); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	if err := q.Write(ctx, a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil)); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	if _, err := q.Read(ctx); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []struct{ direction, kind string }{{"write", "task"}, {"write", "status-update"}, {"read", "task"}}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d tap lines, want %d: %s", len(lines), len(want), buf.String())
+	}
+	for i, line := range lines {
+		var record struct {
+			Direction string         `json:"direction"`
+			TaskID    a2a.TaskID     `json:"taskId"`
+			Kind      string         `json:"kind"`
+			Time      string         `json:"time"`
+			Event     map[string]any `json:"event"`
+		}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("json.Unmarshal() failed: %v", err)
+		}
+		if record.Direction != want[i].direction || record.Kind != want[i].kind || record.TaskID != "task-1" {
+			t.Errorf("line %d = %s, want %s of %s", i, line, want[i].direction, want[i].kind)
+		}
+		if record.Time == "" || record.Event == nil {
+			t.Errorf("line %d = %s, want time and event", i, line)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsink
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+// KafkaProducer is implemented by a wrapper around a Kafka client.
+type KafkaProducer interface {
+	// Produce writes a record to the topic.
+	Produce(ctx context.Context, topic string, key, value []byte) error
+}
+
+// KafkaSink writes every event as a JSON-encoded a2asrv.SinkEvent record keyed by the Task ID,
+// so that the events of a Task land in the same partition and keep their order.
+type KafkaSink struct {
+	producer KafkaProducer
+	topic    string
+}
+
+var _ a2asrv.EventSink = (*KafkaSink)(nil)
+
+// NewKafkaSink creates a KafkaSink writing to the topic.
+func NewKafkaSink(producer KafkaProducer, topic string) *KafkaSink {
+	return &KafkaSink{producer: producer, topic: topic}
+}
+
+func (s *KafkaSink) Consume(ctx context.Context, event a2asrv.SinkEvent) error {
+	value, err := Encode(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	if err := s.producer.Produce(ctx, s.topic, []byte(event.TaskID), value); err != nil {
+		return fmt.Errorf("failed to produce event: %w", err)
+	}
+	return nil
+}
+
+// PubSubPublisher is implemented by a wrapper around a Pub/Sub client.
+type PubSubPublisher interface {
+	// Publish publishes a message to the topic. The ordering key should be used if ordering is enabled for the topic.
+	Publish(ctx context.Context, topic string, data []byte, attributes map[string]string, orderingKey string) error
+}
+
+// PubSubSink publishes every event as a JSON-encoded a2asrv.SinkEvent message with "taskId" and "kind"
+// attributes for subscription filters. The Task ID is used as the ordering key.
+type PubSubSink struct {
+	publisher PubSubPublisher
+	topic     string
+}
+
+var _ a2asrv.EventSink = (*PubSubSink)(nil)
+
+// NewPubSubSink creates a PubSubSink publishing to the topic.
+func NewPubSubSink(publisher PubSubPublisher, topic string) *PubSubSink {
+	return &PubSubSink{publisher: publisher, topic: topic}
+}
+
+func (s *PubSubSink) Consume(ctx context.Context, event a2asrv.SinkEvent) error {
+	data, err := Encode(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	attributes := map[string]string{"taskId": string(event.TaskID), "kind": event.Kind}
+	if err := s.publisher.Publish(ctx, s.topic, data, attributes, string(event.TaskID)); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eventsink provides a2asrv.EventSink implementations for forwarding the events produced by
+// agents to HTTP endpoints and message brokers.
+//
+// Broker sinks don't depend on a particular client library. They are created with a small interface
+// which can be implemented by a wrapper around the client the application already uses, eg.:
+//
+//	type producer struct{ w *kafka.Writer }
+//
+//	func (p producer) Produce(ctx context.Context, topic string, key, value []byte) error {
+//		return p.w.WriteMessages(ctx, kafka.Message{Topic: topic, Key: key, Value: value})
+//	}
+//
+//	handler := a2asrv.NewHandler(executor, a2asrv.WithEventSinks(eventsink.NewKafkaSink(producer{w}, "a2a-events")))
+package eventsink
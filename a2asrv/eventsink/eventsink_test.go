@@ -0,0 +1,121 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+func testEvent() a2asrv.SinkEvent {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	return a2asrv.SinkEvent{
+		Time:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		TaskID: task.ID,
+		Kind:   a2a.EventKindStatusUpdate,
+		Event:  a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil),
+	}
+}
+
+func decodeSinkEvent(t *testing.T, data []byte) map[string]any {
+	t.Helper()
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	if decoded["taskId"] != "task-1" || decoded["kind"] != a2a.EventKindStatusUpdate {
+		t.Fatalf("delivered event = %s, want status update of task-1", data)
+	}
+	return decoded
+}
+
+func TestHTTPSink(t *testing.T) {
+	status := http.StatusAccepted
+	var body []byte
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ = io.ReadAll(req.Body)
+		auth = req.Header.Get("Authorization")
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink := NewHTTPSink(server.URL)
+	sink.Header = http.Header{"Authorization": {"Bearer secret"}}
+	if err := sink.Consume(t.Context(), testEvent()); err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+	decodeSinkEvent(t, body)
+	if auth != "Bearer secret" {
+		t.Fatalf("Authorization header = %q, want %q", auth, "Bearer secret")
+	}
+
+	status = http.StatusBadGateway
+	if err := sink.Consume(t.Context(), testEvent()); err == nil {
+		t.Fatal("Consume() error = nil, want an error for 502 response")
+	}
+}
+
+type fakeProducer struct {
+	topic      string
+	key, value []byte
+}
+
+func (p *fakeProducer) Produce(ctx context.Context, topic string, key, value []byte) error {
+	p.topic, p.key, p.value = topic, key, value
+	return nil
+}
+
+func TestKafkaSink(t *testing.T) {
+	producer := &fakeProducer{}
+	if err := NewKafkaSink(producer, "events").Consume(t.Context(), testEvent()); err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+	if producer.topic != "events" || string(producer.key) != "task-1" {
+		t.Fatalf("produced to %q with key %q, want events and task-1", producer.topic, producer.key)
+	}
+	decodeSinkEvent(t, producer.value)
+}
+
+type fakePublisher struct {
+	topic       string
+	data        []byte
+	attributes  map[string]string
+	orderingKey string
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, topic string, data []byte, attributes map[string]string, orderingKey string) error {
+	p.topic, p.data, p.attributes, p.orderingKey = topic, data, attributes, orderingKey
+	return nil
+}
+
+func TestPubSubSink(t *testing.T) {
+	publisher := &fakePublisher{}
+	if err := NewPubSubSink(publisher, "events").Consume(t.Context(), testEvent()); err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+	if publisher.topic != "events" || publisher.orderingKey != "task-1" || publisher.attributes["kind"] != a2a.EventKindStatusUpdate {
+		t.Fatalf("published to %q with ordering key %q and attributes %v", publisher.topic, publisher.orderingKey, publisher.attributes)
+	}
+	decodeSinkEvent(t, publisher.data)
+}
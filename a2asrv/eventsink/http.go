@@ -0,0 +1,79 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+// Encode returns the JSON representation of the event all the sinks in this package deliver.
+func Encode(event a2asrv.SinkEvent) ([]byte, error) {
+	return json.Marshal(event)
+}
+
+// HTTPSink posts every event as a JSON-encoded a2asrv.SinkEvent to a webhook.
+type HTTPSink struct {
+	// URL is the webhook endpoint.
+	URL string
+	// Client is used for making requests. Defaults to http.DefaultClient.
+	Client *http.Client
+	// Header is added to every request, eg. for authentication.
+	Header http.Header
+}
+
+var _ a2asrv.EventSink = (*HTTPSink)(nil)
+
+// NewHTTPSink creates an HTTPSink posting events to the URL.
+func NewHTTPSink(url string) *HTTPSink {
+	return &HTTPSink{URL: url}
+}
+
+// Consume posts the event. Responses with a status other than 2xx are reported as errors.
+func (s *HTTPSink) Consume(ctx context.Context, event a2asrv.SinkEvent) error {
+	body, err := Encode(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range s.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver event: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("event delivery failed with status %s", resp.Status)
+	}
+	return nil
+}
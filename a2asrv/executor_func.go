@@ -0,0 +1,108 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// ExecutorReply is a result of a one-shot agent invocation: either a complete Message
+// or the parts of an agent Message.
+type ExecutorReply interface {
+	*a2a.Message | []a2a.Part
+}
+
+// ExecutorFunc adapts a function to AgentExecutor for agents which produce a single reply per request.
+// The adapter moves the Task to working state before the function is called and completes it with
+// the reply as the status message. If the function fails the Task is moved to failed state, or to
+// the state chosen by NewCancellationEvent if the execution context is done, and the error is returned.
+// Cancel moves the Task to canceled state, so the function is expected to respect context cancelation.
+//
+//	executor := a2asrv.ExecutorFunc[[]a2a.Part](func(ctx context.Context, reqCtx a2asrv.RequestContext) ([]a2a.Part, error) {
+//		return []a2a.Part{a2a.TextPart{Text: "Hello, world!"}}, nil
+//	})
+type ExecutorFunc[R ExecutorReply] func(ctx context.Context, reqCtx RequestContext) (R, error)
+
+func (fn ExecutorFunc[R]) Execute(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+	task := executorTask(reqCtx)
+	if err := queue.Write(ctx, a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil)); err != nil {
+		return err
+	}
+
+	reply, err := fn(ctx, reqCtx)
+	if err != nil && ctx.Err() != nil {
+		if writeErr := queue.Write(context.WithoutCancel(ctx), NewCancellationEvent(ctx, task)); writeErr != nil {
+			return writeErr
+		}
+		return err
+	}
+	if err != nil {
+		failure := a2a.NewMessageForTask(a2a.MessageRoleAgent, *task, a2a.TextPart{Text: err.Error()})
+		event := a2a.NewStatusUpdateEvent(task, a2a.TaskStateFailed, failure)
+		event.Final = true
+		if writeErr := queue.Write(ctx, event); writeErr != nil {
+			return writeErr
+		}
+		return err
+	}
+
+	event := a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, replyMessage(task, reply))
+	event.Final = true
+	return queue.Write(ctx, event)
+}
+
+func (fn ExecutorFunc[R]) Cancel(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+	event := a2a.NewStatusUpdateEvent(executorTask(reqCtx), a2a.TaskStateCanceled, nil)
+	event.Final = true
+	return queue.Write(ctx, event)
+}
+
+// executorTask returns the Task events produced for the request reference.
+func executorTask(reqCtx RequestContext) *a2a.Task {
+	if reqCtx.Task != nil {
+		return reqCtx.Task
+	}
+	contextID := reqCtx.ContextID
+	if contextID == "" {
+		contextID = reqCtx.Request.Message.ContextID
+	}
+	return &a2a.Task{ID: reqCtx.TaskID, ContextID: contextID}
+}
+
+// replyMessage converts an ExecutorReply to an agent Message which references the Task.
+func replyMessage[R ExecutorReply](task *a2a.Task, reply R) *a2a.Message {
+	switch v := any(reply).(type) {
+	case *a2a.Message:
+		if v == nil {
+			return nil
+		}
+		msg := *v
+		if msg.ID == "" {
+			msg.ID = a2a.NewMessageID()
+		}
+		if msg.Role == "" {
+			msg.Role = a2a.MessageRoleAgent
+		}
+		msg.TaskID, msg.ContextID = task.ID, task.ContextID
+		return &msg
+	case []a2a.Part:
+		return a2a.NewMessageForTask(a2a.MessageRoleAgent, *task, v...)
+	default:
+		return nil
+	}
+}
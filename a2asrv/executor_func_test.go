@@ -0,0 +1,120 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+func TestExecutorFunc_Parts(t *testing.T) {
+	executor := ExecutorFunc[[]a2a.Part](func(ctx context.Context, reqCtx RequestContext) ([]a2a.Part, error) {
+		return []a2a.Part{a2a.TextPart{Text: "pong"}}, nil
+	})
+	handler := NewHandler(executor)
+
+	result, err := handler.OnSendMessage(t.Context(), a2a.MessageSendParams{
+		Message: a2a.Message{TaskID: taskID, ContextID: "ctx", ID: "ping", Role: a2a.MessageRoleUser},
+	})
+	if err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	task, ok := result.(*a2a.Task)
+	if !ok {
+		t.Fatalf("OnSendMessage() = %T, want *a2a.Task", result)
+	}
+	if task.ID != taskID || task.ContextID != "ctx" || task.Status.State != a2a.TaskStateCompleted {
+		t.Fatalf("OnSendMessage() task = %+v, want completed %s in ctx", task, taskID)
+	}
+	reply := task.Status.Message
+	if reply == nil || reply.Role != a2a.MessageRoleAgent || reply.TaskID != taskID || reply.ContextID != "ctx" {
+		t.Fatalf("task status message = %+v, want agent reply for the task", reply)
+	}
+	if want := (a2a.ContentParts{a2a.TextPart{Text: "pong"}}); !reflect.DeepEqual(reply.Parts, want) {
+		t.Fatalf("reply parts = %v, want %v", reply.Parts, want)
+	}
+}
+
+func TestExecutorFunc_Message(t *testing.T) {
+	queue := eventqueue.NewInMemoryQueue(10)
+	executor := ExecutorFunc[*a2a.Message](func(ctx context.Context, reqCtx RequestContext) (*a2a.Message, error) {
+		return &a2a.Message{ID: "reply", Parts: a2a.ContentParts{a2a.TextPart{Text: "pong"}}}, nil
+	})
+
+	reqCtx := RequestContext{TaskID: taskID, ContextID: "ctx"}
+	if err := executor.Execute(t.Context(), reqCtx, queue); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	states := readStates(t, queue, 2)
+	if states[0].Status.State != a2a.TaskStateWorking || states[1].Status.State != a2a.TaskStateCompleted || !states[1].Final {
+		t.Fatalf("Execute() states = %v, %v, want working and final completed", states[0].Status.State, states[1].Status.State)
+	}
+	reply := states[1].Status.Message
+	if reply.ID != "reply" || reply.Role != a2a.MessageRoleAgent || reply.TaskID != taskID {
+		t.Fatalf("reply = %+v, want defaults filled in", reply)
+	}
+}
+
+func TestExecutorFunc_Failure(t *testing.T) {
+	queue := eventqueue.NewInMemoryQueue(10)
+	wantErr := errors.New("model unavailable")
+	executor := ExecutorFunc[[]a2a.Part](func(ctx context.Context, reqCtx RequestContext) ([]a2a.Part, error) {
+		return nil, wantErr
+	})
+
+	if err := executor.Execute(t.Context(), RequestContext{TaskID: taskID}, queue); !errors.Is(err, wantErr) {
+		t.Fatalf("Execute() error = %v, want %v", err, wantErr)
+	}
+	states := readStates(t, queue, 2)
+	if states[1].Status.State != a2a.TaskStateFailed || !states[1].Final {
+		t.Fatalf("Execute() final state = %v, want failed", states[1].Status.State)
+	}
+}
+
+func TestExecutorFunc_Cancel(t *testing.T) {
+	queue := eventqueue.NewInMemoryQueue(10)
+	executor := ExecutorFunc[[]a2a.Part](func(ctx context.Context, reqCtx RequestContext) ([]a2a.Part, error) {
+		return nil, nil
+	})
+
+	if err := executor.Cancel(t.Context(), RequestContext{TaskID: taskID}, queue); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if states := readStates(t, queue, 1); states[0].Status.State != a2a.TaskStateCanceled {
+		t.Fatalf("Cancel() state = %v, want canceled", states[0].Status.State)
+	}
+}
+
+func readStates(t *testing.T, queue eventqueue.Queue, n int) []*a2a.TaskStatusUpdateEvent {
+	t.Helper()
+	var states []*a2a.TaskStatusUpdateEvent
+	for range n {
+		event, err := queue.Read(t.Context())
+		if err != nil {
+			t.Fatalf("queue.Read() error = %v", err)
+		}
+		update, ok := event.(*a2a.TaskStatusUpdateEvent)
+		if !ok {
+			t.Fatalf("queue.Read() = %T, want *a2a.TaskStatusUpdateEvent", event)
+		}
+		states = append(states, update)
+	}
+	return states
+}
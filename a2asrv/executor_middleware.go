@@ -0,0 +1,84 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// AgentExecutorMiddleware wraps an AgentExecutor to add behavior around Execute and Cancel calls,
+// eg. input validation, rate limiting or metrics collection.
+type AgentExecutorMiddleware func(next AgentExecutor) AgentExecutor
+
+// ChainExecutor wraps the executor with the middlewares. The first middleware is the outermost,
+// so it sees the call first and the result last.
+func ChainExecutor(executor AgentExecutor, middlewares ...AgentExecutorMiddleware) AgentExecutor {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		executor = middlewares[i](executor)
+	}
+	return executor
+}
+
+// AgentExecutorFuncs adapts functions to AgentExecutor. A nil function delegates the call to Next,
+// which makes it convenient for middlewares which only intercept one of the methods.
+type AgentExecutorFuncs struct {
+	// Next is the executor the calls are delegated to.
+	Next AgentExecutor
+	// ExecuteFunc is called instead of Next.Execute if not nil.
+	ExecuteFunc func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error
+	// CancelFunc is called instead of Next.Cancel if not nil.
+	CancelFunc func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error
+	// ValidateFunc is called instead of Next.Validate if not nil. Validation succeeds if neither
+	// ValidateFunc is set nor Next implements RequestValidator.
+	ValidateFunc func(ctx context.Context, reqCtx RequestContext) error
+}
+
+var _ RequestValidator = (*AgentExecutorFuncs)(nil)
+
+func (e *AgentExecutorFuncs) Execute(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+	if e.ExecuteFunc != nil {
+		return e.ExecuteFunc(ctx, reqCtx, queue)
+	}
+	return e.Next.Execute(ctx, reqCtx, queue)
+}
+
+func (e *AgentExecutorFuncs) Cancel(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+	if e.CancelFunc != nil {
+		return e.CancelFunc(ctx, reqCtx, queue)
+	}
+	return e.Next.Cancel(ctx, reqCtx, queue)
+}
+
+func (e *AgentExecutorFuncs) Validate(ctx context.Context, reqCtx RequestContext) error {
+	if e.ValidateFunc != nil {
+		return e.ValidateFunc(ctx, reqCtx)
+	}
+	return validateRequest(ctx, e.Next, reqCtx)
+}
+
+// ExecuteMiddleware creates an AgentExecutorMiddleware which only intercepts Execute calls.
+// The interceptor is responsible for calling next.Execute.
+func ExecuteMiddleware(intercept func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue, next AgentExecutor) error) AgentExecutorMiddleware {
+	return func(next AgentExecutor) AgentExecutor {
+		return &AgentExecutorFuncs{
+			Next: next,
+			ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+				return intercept(ctx, reqCtx, queue, next)
+			},
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+func recordingMiddleware(name string, calls *[]string) AgentExecutorMiddleware {
+	return func(next AgentExecutor) AgentExecutor {
+		return &AgentExecutorFuncs{
+			Next: next,
+			ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+				*calls = append(*calls, name+":before")
+				err := next.Execute(ctx, reqCtx, queue)
+				*calls = append(*calls, name+":after")
+				return err
+			},
+		}
+	}
+}
+
+func TestChainExecutor_Order(t *testing.T) {
+	var calls []string
+	executor := &mockAgentExecutor{
+		ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+			calls = append(calls, "execute")
+			return nil
+		},
+		CancelFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+			calls = append(calls, "cancel")
+			return nil
+		},
+	}
+	chained := ChainExecutor(executor, recordingMiddleware("outer", &calls), recordingMiddleware("inner", &calls))
+
+	if err := chained.Execute(t.Context(), RequestContext{}, nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if err := chained.Cancel(t.Context(), RequestContext{}, nil); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	want := []string{"outer:before", "inner:before", "execute", "inner:after", "outer:after", "cancel"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestDefaultRequestHandler_WithExecutorMiddleware(t *testing.T) {
+	errEmptyMessage := errors.New("empty message")
+	validate := ExecuteMiddleware(func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue, next AgentExecutor) error {
+		if len(reqCtx.Request.Message.Parts) == 0 {
+			return errEmptyMessage
+		}
+		return next.Execute(ctx, reqCtx, queue)
+	})
+	executed := false
+	executor := &mockAgentExecutor{
+		ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+			executed = true
+			return queue.Write(ctx, &a2a.Message{TaskID: reqCtx.TaskID})
+		},
+	}
+	handler := NewHandler(executor, WithExecutorMiddleware(validate))
+
+	empty := a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID}}
+	if _, err := handler.OnSendMessage(t.Context(), empty); !errors.Is(err, errEmptyMessage) {
+		t.Fatalf("OnSendMessage() error = %v, want %v", err, errEmptyMessage)
+	}
+	if executed {
+		t.Fatal("executor called for a rejected message")
+	}
+
+	valid := a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID, Parts: a2a.ContentParts{a2a.TextPart{Text: "hi"}}}}
+	if _, err := handler.OnSendMessage(t.Context(), valid); err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	if !executed {
+		t.Fatal("executor not called for a valid message")
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// errUnauthenticated is returned when the extended card is requested by a caller without a principal.
+var errUnauthenticated = fmt.Errorf("%w: extended agent card requires an authenticated caller", a2a.ErrInvalidRequest)
+
+// ExtendedCardProvider creates the AgentCard returned by the 'agent/getAuthenticatedExtendedCard'
+// protocol method. It can be used to describe skills or capabilities available only to specific callers.
+type ExtendedCardProvider interface {
+	// ExtendedCard returns the card for the authenticated caller identified by the principal attached
+	// to the context with WithPrincipal.
+	ExtendedCard(ctx context.Context, principal string) (*a2a.AgentCard, error)
+}
+
+// ExtendedCardProviderFn adapts a function to ExtendedCardProvider.
+type ExtendedCardProviderFn func(ctx context.Context, principal string) (*a2a.AgentCard, error)
+
+func (fn ExtendedCardProviderFn) ExtendedCard(ctx context.Context, principal string) (*a2a.AgentCard, error) {
+	return fn(ctx, principal)
+}
+
+// ExtendedCardHandler can be implemented by a RequestHandler to serve the 'agent/getAuthenticatedExtendedCard'
+// protocol method. The handler created with NewHandler and the handlers wrapping it implement it.
+type ExtendedCardHandler interface {
+	// OnGetExtendedAgentCard handles the 'agent/getAuthenticatedExtendedCard' protocol method.
+	OnGetExtendedAgentCard(ctx context.Context) (*a2a.AgentCard, error)
+}
+
+// WithExtendedCard makes the handler serve the card created by the provider to authenticated callers.
+// Without it the card is taken from the ExtendedAgentCardProducer passed to WithAgentCard if it implements one.
+func WithExtendedCard(provider ExtendedCardProvider) RequestHandlerOption {
+	return func(h *defaultRequestHandler) {
+		h.extendedCard = provider
+	}
+}
+
+// OnGetExtendedAgentCard returns the extended card to callers with a principal attached to the context.
+// a2a.ErrAuthenticatedExtendedCardNotConfigured is returned if neither WithExtendedCard nor WithAgentCard
+// with an ExtendedAgentCardProducer was used.
+func (h *defaultRequestHandler) OnGetExtendedAgentCard(ctx context.Context) (*a2a.AgentCard, error) {
+	provider := h.extendedCard
+	if provider == nil {
+		if producer, ok := h.cardProducer.(ExtendedAgentCardProducer); ok {
+			provider = ExtendedCardProviderFn(func(context.Context, string) (*a2a.AgentCard, error) {
+				return producer.ExtendedCard(), nil
+			})
+		}
+	}
+	if provider == nil {
+		return nil, a2a.ErrAuthenticatedExtendedCardNotConfigured
+	}
+	principal, ok := PrincipalFrom(ctx)
+	if !ok || principal == "" {
+		return nil, errUnauthenticated
+	}
+	card, err := provider.ExtendedCard(ctx, principal)
+	if err != nil {
+		return nil, err
+	}
+	if card == nil {
+		return nil, a2a.ErrAuthenticatedExtendedCardNotConfigured
+	}
+	return card, nil
+}
+
+// getExtendedAgentCard forwards the call to the handler if it implements ExtendedCardHandler.
+func getExtendedAgentCard(ctx context.Context, handler RequestHandler) (*a2a.AgentCard, error) {
+	if extended, ok := handler.(ExtendedCardHandler); ok {
+		return extended.OnGetExtendedAgentCard(ctx)
+	}
+	return nil, a2a.ErrAuthenticatedExtendedCardNotConfigured
+}
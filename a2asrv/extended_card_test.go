@@ -0,0 +1,93 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestHandler_OnGetExtendedAgentCard(t *testing.T) {
+	public := &a2a.AgentCard{Name: "public"}
+	provider := ExtendedCardProviderFn(func(ctx context.Context, principal string) (*a2a.AgentCard, error) {
+		return &a2a.AgentCard{Name: "extended for " + principal}, nil
+	})
+	authenticated := WithPrincipal(t.Context(), "alice")
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		options  []RequestHandlerOption
+		wantName string
+		wantErr  error
+	}{
+		{
+			name:     "provider",
+			ctx:      authenticated,
+			options:  []RequestHandlerOption{WithExtendedCard(provider)},
+			wantName: "extended for alice",
+		},
+		{
+			name:     "extended card producer",
+			ctx:      authenticated,
+			options:  []RequestHandlerOption{WithAgentCard(extendedCardProducer{card: public, extended: &a2a.AgentCard{Name: "extended"}})},
+			wantName: "extended",
+		},
+		{
+			name:    "unauthenticated",
+			ctx:     t.Context(),
+			options: []RequestHandlerOption{WithExtendedCard(provider)},
+			wantErr: a2a.ErrInvalidRequest,
+		},
+		{
+			name:    "public card producer",
+			ctx:     authenticated,
+			options: []RequestHandlerOption{WithAgentCard(AgentCardProducerFn(func() *a2a.AgentCard { return public }))},
+			wantErr: a2a.ErrAuthenticatedExtendedCardNotConfigured,
+		},
+		{
+			name:    "not configured",
+			ctx:     authenticated,
+			wantErr: a2a.ErrAuthenticatedExtendedCardNotConfigured,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(echoExecutor, tc.options...)
+			// decorators must forward the call to the wrapped handler
+			handler = NewLoggingHandler(NewRedactingHandler(handler, HistoryRedactorFuncs{}), slog.New(slog.NewTextHandler(io.Discard, nil)))
+			handler = NewDrainingHandler(handler)
+
+			card, err := handler.(ExtendedCardHandler).OnGetExtendedAgentCard(tc.ctx)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("OnGetExtendedAgentCard() error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OnGetExtendedAgentCard() error = %v", err)
+			}
+			if card.Name != tc.wantName {
+				t.Fatalf("OnGetExtendedAgentCard() name = %q, want %q", card.Name, tc.wantName)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+type creditWindowKey struct{}
+
+// WithCreditWindow attaches the flow control window of a streaming request to the context.
+// Transports grant credits to the window as they deliver events to the client, and the handler
+// spends them when AgentExecutor writes events, so a fast agent is slowed down to the rate
+// at which a slow client consumes the stream.
+func WithCreditWindow(ctx context.Context, window *eventqueue.CreditWindow) context.Context {
+	return context.WithValue(ctx, creditWindowKey{}, window)
+}
+
+// CreditWindowFrom returns the flow control window attached with WithCreditWindow.
+func CreditWindowFrom(ctx context.Context) (*eventqueue.CreditWindow, bool) {
+	window, ok := ctx.Value(creditWindowKey{}).(*eventqueue.CreditWindow)
+	return window, ok
+}
@@ -18,15 +18,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"iter"
+	"net/url"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/a2aproject/a2a-go/internal/ownership"
+	"github.com/a2aproject/a2a-go/internal/push"
+	"github.com/a2aproject/a2a-go/internal/taskstore"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
+	"github.com/google/uuid"
 )
 
-var errUnimplemented = errors.New("unimplemented")
+var errNoTaskStore = fmt.Errorf("task store is not configured: %w", a2a.ErrUnsupportedOperation)
+
+var errNoPushConfigStore = fmt.Errorf("push config store is not configured: %w", a2a.ErrUnsupportedOperation)
+
+// defaultCancelQueueSize is the capacity of the queue AgentExecutor.Cancel writes events to.
+const defaultCancelQueueSize = 16
 
 // RequestHandler defines a transport-agnostic interface for handling incoming A2A requests.
+// Implementations must be safe for concurrent use, as transports call them from a goroutine per request.
 type RequestHandler interface {
 	// OnGetTask handles the 'tasks/get' protocol method.
 	OnGetTask(ctx context.Context, query a2a.TaskQueryParams) (a2a.Task, error)
@@ -47,7 +64,7 @@ type RequestHandler interface {
 	OnGetTaskPushConfig(ctx context.Context, params a2a.GetTaskPushConfigParams) (a2a.TaskPushConfig, error)
 
 	// OnListTaskPushNotificationConfig handles the `tasks/pushNotificationConfig/list` protocol method.
-	OnListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) ([]a2a.TaskPushConfig, error)
+	OnListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) (*a2a.ListTaskPushConfigResult, error)
 
 	// OnSetTaskPushConfig handles the `tasks/pushNotificationConfig/set` protocol method.
 	OnSetTaskPushConfig(ctx context.Context, params a2a.TaskPushConfig) (a2a.TaskPushConfig, error)
@@ -59,14 +76,45 @@ type RequestHandler interface {
 // Implements a2asrv.RequestHandler
 type defaultRequestHandler struct {
 	pushNotifier    PushNotifier
+	pushErrors      PushErrorHandler
+	pushes          *pushDispatcher
 	executor        AgentExecutor
 	queueManager    eventqueue.Manager
 	pushConfigStore PushConfigStore
 	taskStore       TaskStore
+	ownership       TaskOwnership
+
+	cardProducer  AgentCardProducer
+	extendedCard  ExtendedCardProvider
+	finalMessage  FinalMessageMode
+	uploads       *UploadStore
+	eventTap      io.Writer
+	writeDeadline *eventqueue.WriteDeadline
+	middleware    []AgentExecutorMiddleware
+	inFlight      atomic.Int64
+
+	queueBackend   eventqueue.Manager
+	maxPushBacklog int
+	executions     runningExecutions
+	streams        taskStreams
+	quotas         *quotaEnforcer
+	sinks          []EventSink
+	artifacts      *artifactTee
 }
 
 type RequestHandlerOption func(*defaultRequestHandler)
 
+// FinalMessageMode defines the result of a non-streaming 'message/send' call when AgentExecutor
+// concludes Task updates with a Message.
+type FinalMessageMode int
+
+const (
+	// FinalMessageInTask returns the Task with the Message appended to its History. The default.
+	FinalMessageInTask FinalMessageMode = iota
+	// FinalMessageOnly returns only the Message.
+	FinalMessageOnly
+)
+
 // WithTaskStore overrides TaskStore with custom implementation
 func WithTaskStore(store TaskStore) RequestHandlerOption {
 	return func(h *defaultRequestHandler) {
@@ -95,75 +143,779 @@ func WithPushNotifier(notifier PushNotifier) RequestHandlerOption {
 	}
 }
 
-// NewHandler creates a new request handler
+// WithTaskOwnership overrides default TaskOwnership with custom implementation.
+// The default implementation only prevents concurrent execution within a single process.
+func WithTaskOwnership(ownership TaskOwnership) RequestHandlerOption {
+	return func(h *defaultRequestHandler) {
+		h.ownership = ownership
+	}
+}
+
+// WithEventTap mirrors every event flowing through the event queues to w as JSON lines
+// with timestamps and direction. Intended for development only.
+func WithEventTap(w io.Writer) RequestHandlerOption {
+	return func(h *defaultRequestHandler) {
+		h.eventTap = w
+	}
+}
+
+// WithExecutorMiddleware wraps AgentExecutor with the middlewares. Can be used multiple times,
+// middlewares from earlier calls are applied as outer ones.
+func WithExecutorMiddleware(middlewares ...AgentExecutorMiddleware) RequestHandlerOption {
+	return func(h *defaultRequestHandler) {
+		h.middleware = append(h.middleware, middlewares...)
+	}
+}
+
+// WithAgentCard makes the handler reject requests for capabilities which are not declared
+// in the AgentCard. Messages with parts the agent doesn't accept are rejected by ContentNegotiationMiddleware,
+// which is applied before other middlewares. The card is read on every request, so ReloadableCard changes
+// apply immediately.
+func WithAgentCard(producer AgentCardProducer) RequestHandlerOption {
+	return func(h *defaultRequestHandler) {
+		h.cardProducer = producer
+	}
+}
+
+// WithUploads makes files uploaded to the store and referenced by a Message available to
+// AgentExecutor in RequestContext.Uploads. Messages referencing unknown uploads are rejected.
+func WithUploads(store *UploadStore) RequestHandlerOption {
+	return func(h *defaultRequestHandler) {
+		h.uploads = store
+	}
+}
+
+// WithFinalMessageMode overrides the default FinalMessageInTask mode.
+func WithFinalMessageMode(mode FinalMessageMode) RequestHandlerOption {
+	return func(h *defaultRequestHandler) {
+		h.finalMessage = mode
+	}
+}
+
+// WithQueueWriteDeadline makes AgentExecutor queue writes fail with eventqueue.ErrSlowConsumer, or only
+// report the problem if config.Wait is set, when events are not consumed for longer than config.Timeout.
+func WithQueueWriteDeadline(config eventqueue.WriteDeadline) RequestHandlerOption {
+	return func(h *defaultRequestHandler) {
+		h.writeDeadline = &config
+	}
+}
+
+// NewHandler creates a new request handler. Options are applied once and the handler is never
+// reconfigured afterwards. The AgentExecutor, TaskStore and other provided components are called concurrently
+// for different requests, so they must be safe for concurrent use.
 func NewHandler(executor AgentExecutor, options ...RequestHandlerOption) RequestHandler {
 	h := &defaultRequestHandler{
-		executor:     executor,
-		queueManager: eventqueue.NewInMemoryManager(),
+		executor:        executor,
+		queueManager:    eventqueue.NewInMemoryManager(),
+		ownership:       ownership.NewMem(),
+		pushConfigStore: push.NewInMemoryPushConfigStore(),
 	}
 	for _, option := range options {
 		option(h)
 	}
+	middleware := h.middleware
+	if h.cardProducer != nil {
+		middleware = append([]AgentExecutorMiddleware{ContentNegotiationMiddleware(h.cardProducer)}, middleware...)
+	}
+	h.executor = ChainExecutor(h.executor, middleware...)
+	if h.pushNotifier != nil {
+		h.pushes = newPushDispatcher(h.pushNotifier, h.pushErrors)
+	}
+	h.queueBackend = h.queueManager
+	if h.writeDeadline != nil {
+		h.queueManager = eventqueue.NewWriteDeadlineManager(h.queueManager, *h.writeDeadline)
+	}
+	if len(h.sinks) > 0 {
+		h.queueManager = &sinkManager{Manager: h.queueManager, sinks: h.sinks}
+	}
+	if h.artifacts != nil {
+		h.queueManager = &artifactTeeManager{Manager: h.queueManager, tee: h.artifacts}
+	}
+	if h.eventTap != nil {
+		h.queueManager = eventqueue.NewTapManager(h.queueManager, h.eventTap)
+	}
 	return h
 }
 
 func (h *defaultRequestHandler) OnGetTask(ctx context.Context, query a2a.TaskQueryParams) (a2a.Task, error) {
-	return a2a.Task{}, errUnimplemented
+	if h.taskStore == nil {
+		return a2a.Task{}, errNoTaskStore
+	}
+	if query.HistoryLength != nil && *query.HistoryLength < 0 {
+		return a2a.Task{}, fmt.Errorf("%w: historyLength must not be negative", a2a.ErrInvalidRequest)
+	}
+	task, err := h.taskStore.Get(ctx, query.ID)
+	if err != nil {
+		return a2a.Task{}, fmt.Errorf("failed to get task: %w", err)
+	}
+	if query.HistoryLength != nil && *query.HistoryLength < len(task.History) {
+		task.History = task.History[len(task.History)-*query.HistoryLength:]
+	}
+	return task, nil
 }
 
+// OnCancelTask stops the execution of the Task if it's running in this process and waits until its events
+// are applied. The execution moves the Task to the canceled state, so AgentExecutor.Cancel is only called
+// if the Task is not running in this process or the execution didn't produce a Task. Events written by
+// AgentExecutor.Cancel are applied to the stored Task. If they don't move the Task to a terminal state,
+// a canceled status update is applied on behalf of the agent. The Task is canceled only if its TaskOwnership
+// can be acquired, so that a Task executed by another replica is not modified concurrently.
 func (h *defaultRequestHandler) OnCancelTask(ctx context.Context, id a2a.TaskIDParams) (a2a.Task, error) {
-	return a2a.Task{}, errUnimplemented
+	if h.taskStore == nil {
+		return a2a.Task{}, errNoTaskStore
+	}
+	task, version, release, err := h.stopTask(ctx, id.ID, ErrTaskCanceled)
+	if err != nil {
+		return a2a.Task{}, err
+	}
+	if release == nil {
+		// canceled by the stopped execution
+		return task, nil
+	}
+	defer release()
+
+	reqCtx := RequestContext{TaskID: task.ID, Task: &task, ContextID: task.ContextID}
+	// the queue is private to the call, so the events don't interfere with the execution being stopped
+	queue := eventqueue.NewInMemoryQueue(defaultCancelQueueSize)
+	defer func() { _ = queue.Close() }()
+	cancelErr := make(chan error, 1)
+	go func() {
+		defer func() { _ = queue.Close() }()
+		cancelErr <- h.executor.Cancel(ctx, reqCtx, queue)
+	}()
+
+	updates := taskupdate.NewManager(h.taskSaver(), &task)
+	updates.Version = version
+	for {
+		event, err := queue.Read(ctx)
+		if errors.Is(err, eventqueue.ErrQueueClosed) {
+			break
+		}
+		if err != nil {
+			return a2a.Task{}, fmt.Errorf("failed to read event from queue: %w", err)
+		}
+		if _, ok := event.(*a2a.Message); ok {
+			continue
+		}
+		if _, ok := event.(a2a.CustomEvent); ok {
+			continue
+		}
+		if err := updates.Process(ctx, event); err != nil {
+			return a2a.Task{}, fmt.Errorf("failed to process %T: %w", event, err)
+		}
+	}
+	if err := <-cancelErr; err != nil {
+		return a2a.Task{}, fmt.Errorf("failed to cancel task: %w", err)
+	}
+
+	if !updates.Task.Status.State.Terminal() {
+		canceledCtx, cancel := context.WithCancelCause(ctx)
+		cancel(ErrTaskCanceled)
+		if err := updates.Process(ctx, NewCancellationEvent(canceledCtx, updates.Task)); err != nil {
+			return a2a.Task{}, fmt.Errorf("failed to save canceled task: %w", err)
+		}
+	}
+	return *updates.Task, nil
 }
 
+// stopTask prepares the Task for being canceled. If it's executed by this process, the execution is stopped
+// with cause and the Task it canceled is returned once all its events are applied. Otherwise the stored Task
+// is returned together with its version after TaskOwnership was acquired, so that a Task executed by another
+// replica is not modified concurrently. The returned function releases the ownership and is nil in the first
+// case. a2a.ErrTaskNotCancelable is returned if the Task is in a terminal state.
+func (h *defaultRequestHandler) stopTask(ctx context.Context, taskID a2a.TaskID, cause error) (a2a.Task, TaskVersion, func(), error) {
+	task, err := h.taskStore.Get(ctx, taskID)
+	if err != nil {
+		return a2a.Task{}, 0, nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	if task.Status.State.Terminal() {
+		return a2a.Task{}, 0, nil, fmt.Errorf("%w: task is %s", a2a.ErrTaskNotCancelable, task.Status.State)
+	}
+	stopped := false
+	if done := h.executions.cancel(taskID, cause); done != nil {
+		select {
+		case <-done:
+			stopped = true
+		case <-ctx.Done():
+			return a2a.Task{}, 0, nil, fmt.Errorf("failed to wait for the execution to stop: %w", context.Cause(ctx))
+		}
+	}
+
+	if err := h.ownership.Acquire(ctx, taskID); err != nil {
+		return a2a.Task{}, 0, nil, fmt.Errorf("failed to acquire task ownership: %w", err)
+	}
+	release := func() { _ = h.ownership.Release(context.WithoutCancel(ctx), taskID) }
+	// the Task is reloaded, because it could have been updated before the ownership was acquired
+	task, version, err := h.loadTask(ctx, taskID)
+	if err != nil {
+		release()
+		return a2a.Task{}, 0, nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	switch state := task.Status.State; {
+	case stopped && state == a2a.TaskStateCanceled:
+		release()
+		return task, version, nil, nil
+	case state.Terminal():
+		release()
+		return a2a.Task{}, 0, nil, fmt.Errorf("%w: task is %s", a2a.ErrTaskNotCancelable, state)
+	}
+	return task, version, release, nil
+}
+
+// OnSendMessage runs AgentExecutor and aggregates the events it produces into the result. A submitted Task
+// is created for a Message which doesn't reference one.
 func (h *defaultRequestHandler) OnSendMessage(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	prefs, err := a2a.PreferencesOf(&message.Message)
+	if err != nil {
+		return nil, err
+	}
+	if a2a.IsDryRun(&message) {
+		return h.dryRun(ctx, message, prefs)
+	}
+	created := newTaskForMessage(&message.Message)
 	taskID := message.Message.TaskID
-	if taskID == "" {
-		// todo: generate task id - https://github.com/a2aproject/a2a-go/issues/18
-		return nil, fmt.Errorf("message is missing TaskID")
+	var executionTime time.Duration
+	if h.quotas != nil {
+		release, err := h.quotas.acquire(ctx, message.Message.ContextID)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { release(executionTime) }()
+	}
+	if err := h.ownership.Acquire(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("failed to acquire task ownership: %w", err)
 	}
+	var execution *runningExecution
+	defer func() {
+		_ = h.ownership.Release(context.WithoutCancel(ctx), taskID)
+		// the callers waiting for the execution to finish can acquire the ownership
+		h.executions.finish(taskID, execution)
+	}()
 	queue, err := h.queueManager.GetOrCreate(ctx, taskID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve queue: %w", err)
 	}
-	if err := h.executor.Execute(ctx, RequestContext{
-		Request: message,
-		TaskID:  taskID,
-	}, queue); err != nil {
+	// closing the queue ends the collection of the result once all the produced events are read
+	destroyQueue := sync.OnceValue(func() error {
+		return h.queueManager.Destroy(context.WithoutCancel(ctx), taskID)
+	})
+	defer destroyQueue()
+	reqCtx := RequestContext{
+		Request:     message,
+		TaskID:      taskID,
+		SkillID:     a2a.SkillIDOf(&message.Message),
+		Preferences: prefs,
+	}
+	if h.uploads != nil {
+		if reqCtx.Uploads, err = h.uploads.resolve(message.Message, true); err != nil {
+			return nil, err
+		}
+		defer h.removeUploads(reqCtx.Uploads)
+	}
+	initial, err := h.prepareTask(ctx, created, &reqCtx)
+	if err != nil {
 		return nil, err
 	}
-	event, err := queue.Read(ctx)
+	execCtx, cancel := executionContext(withPreferences(ctx, prefs))
+	execution = h.executions.add(taskID, cancel)
+	h.inFlight.Add(1)
+
+	// events are read while the agent produces them, so that an execution is never blocked by a full queue,
+	// and until the queue is closed, so that the events of an execution stopped by the client are applied
+	collectCtx, stopCollecting := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopCollecting()
+	collected := make(chan collectedResult, 1)
+	go func() {
+		result, err := h.collectResult(collectCtx, execCtx, queue, initial)
+		if err != nil {
+			// the events can't be applied to the Task anymore, so there's no point in continuing
+			cancel(err)
+		}
+		collected <- collectedResult{result: result, err: err}
+	}()
+
+	start := ClockFrom(ctx).Now()
+	execErr := h.executor.Execute(execCtx, reqCtx, queue)
+	executionTime = ClockFrom(ctx).Now().Sub(start)
+	h.inFlight.Add(-1)
+	cancel(nil)
+	if err := destroyQueue(); err != nil {
+		stopCollecting()
+		<-collected
+		return nil, fmt.Errorf("failed to destroy queue: %w", err)
+	}
+	res := <-collected
+	switch {
+	case res.err != nil && errors.Is(context.Cause(execCtx), res.err):
+		// the execution was stopped because its events couldn't be collected
+		return nil, res.err
+	case res.err == nil && canceledByRequest(execCtx):
+		// the agent stopping with an error is the expected outcome of the cancelation
+		return res.result, nil
+	case execErr != nil:
+		return nil, execErr
+	}
+	return res.result, res.err
+}
+
+// collectedResult is the outcome of collectResult.
+type collectedResult struct {
+	result a2a.SendMessageResult
+	err    error
+}
+
+// newTaskForMessage creates a submitted Task for a Message which doesn't reference one and updates the Message
+// to reference the Task. Nil is returned if the Message already references a Task.
+func newTaskForMessage(msg *a2a.Message) *a2a.Task {
+	if msg.TaskID != "" {
+		return nil
+	}
+	task := taskupdate.NewSubmittedTask(msg)
+	msg.TaskID, msg.ContextID = task.ID, task.ContextID
+	return task
+}
+
+// prepareTask saves the Task created by newTaskForMessage if TaskStore is configured. Otherwise the Task
+// referenced by the Message is loaded to RequestContext.Task if TaskStore is configured and has it, so that
+// AgentExecutor can continue it. RequestContext.Task is left nil for created Tasks. The returned Manager
+// applies the events of the execution to the saved or loaded Task. It is nil if there's no such Task.
+func (h *defaultRequestHandler) prepareTask(ctx context.Context, created *a2a.Task, reqCtx *RequestContext) (*taskupdate.Manager, error) {
+	if created != nil {
+		updates := taskupdate.NewManager(h.taskSaver(), created)
+		if err := updates.Process(ctx, created); err != nil {
+			return nil, fmt.Errorf("failed to save task: %w", err)
+		}
+		reqCtx.ContextID = created.ContextID
+		return updates, nil
+	}
+	if h.taskStore == nil {
+		return nil, nil
+	}
+	task, version, err := h.loadTask(ctx, reqCtx.TaskID)
+	if errors.Is(err, a2a.ErrTaskNotFound) {
+		// the agent can start a Task with the ID chosen by the client
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	// the Task is updated by the events of the execution, so AgentExecutor gets a copy
+	updated, err := taskstore.DeepCopy(&task)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read event from queue: %w", err)
+		return nil, fmt.Errorf("failed to copy task: %w", err)
+	}
+	reqCtx.Task, reqCtx.ContextID = &task, task.ContextID
+	updates := taskupdate.NewManager(h.taskSaver(), updated)
+	updates.Version = version
+	return updates, nil
+}
+
+// loadTask returns the stored Task together with its version if TaskStore implements VersionedTaskStore.
+func (h *defaultRequestHandler) loadTask(ctx context.Context, taskID a2a.TaskID) (a2a.Task, TaskVersion, error) {
+	if versioned, ok := h.taskStore.(VersionedTaskStore); ok {
+		return versioned.GetVersioned(ctx, taskID)
+	}
+	task, err := h.taskStore.Get(ctx, taskID)
+	return task, 0, err
+}
+
+// dryRun validates the 'message/send' request without executing it and returns the Task which would be created.
+// Uploads referenced by the message are checked but not claimed. Quotas are neither checked nor consumed.
+func (h *defaultRequestHandler) dryRun(ctx context.Context, params a2a.MessageSendParams, prefs a2a.Preferences) (a2a.SendMessageResult, error) {
+	reqCtx := RequestContext{
+		Request:     params,
+		TaskID:      params.Message.TaskID,
+		SkillID:     a2a.SkillIDOf(&params.Message),
+		Preferences: prefs,
+	}
+	if h.uploads != nil {
+		uploads, err := h.uploads.resolve(params.Message, false)
+		if err != nil {
+			return nil, err
+		}
+		reqCtx.Uploads = uploads
 	}
 
-	// todo: handle returned update event
-	if _, ok := event.(a2a.SendMessageResult); !ok {
+	var task *a2a.Task
+	var rejected *ContentRejectedError
+	err := validateRequest(withPreferences(ctx, prefs), h.executor, reqCtx)
+	switch {
+	case errors.As(err, &rejected):
+		task = rejectedTask(reqCtx, a2a.TaskStateRejected, rejected)
+	case err != nil:
+		return nil, err
+	default:
+		msg := reqCtx.Request.Message
+		task = &a2a.Task{
+			ID:        reqCtx.TaskID,
+			ContextID: msg.ContextID,
+			Status:    a2a.TaskStatus{State: a2a.TaskStateSubmitted},
+			History:   []*a2a.Message{&msg},
+		}
+	}
+	task.Metadata = map[string]any{a2a.DryRunMetaKey: true}
+	return task, nil
+}
+
+// collectResult aggregates events produced by an execution into a 'message/send' result.
+// A Message is returned as is if there were no Task events. Otherwise Task snapshots and updates
+// are applied to the Task which is returned and saved if TaskStore is configured. Updates are applied
+// with initial if the Task was created or loaded by the handler. If execCtx was canceled by OnCancelTask or
+// TaskAdmin.ForceCancelTask, the Task is canceled after all the events of the execution are applied.
+func (h *defaultRequestHandler) collectResult(ctx, execCtx context.Context, queue eventqueue.Reader, initial *taskupdate.Manager) (a2a.SendMessageResult, error) {
+	var updates *taskupdate.Manager
+	var message *a2a.Message
+	for {
+		event, err := queue.Read(ctx)
+		if errors.Is(err, eventqueue.ErrQueueClosed) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read event from queue: %w", err)
+		}
+
+		if msg, ok := event.(*a2a.Message); ok {
+			message = msg
+			continue
+		}
+		if _, ok := event.(a2a.CustomEvent); ok {
+			// extension events are only delivered to streaming clients
+			continue
+		}
+		if updates == nil {
+			updates = initial
+		}
+		if updates == nil {
+			task, err := newTaskForEvent(event)
+			if err != nil {
+				return nil, err
+			}
+			updates = taskupdate.NewManager(h.taskSaver(), task)
+		}
+		update := event
+		if task, ok := event.(*a2a.Task); ok {
+			// the manager keeps updating the snapshot it's given, while the agent can still be reading the event
+			if update, err = taskstore.DeepCopy(task); err != nil {
+				return nil, fmt.Errorf("failed to copy task: %w", err)
+			}
+		}
+		if err := updates.Process(ctx, update); err != nil {
+			return nil, fmt.Errorf("failed to process %T: %w", event, err)
+		}
+	}
+
+	if canceledByRequest(execCtx) {
+		if updates == nil {
+			updates = initial
+		}
+		if updates != nil && !updates.Task.Status.State.Terminal() {
+			if err := updates.Process(ctx, NewCancellationEvent(execCtx, updates.Task)); err != nil {
+				return nil, fmt.Errorf("failed to save canceled task: %w", err)
+			}
+		}
+	}
+
+	switch {
+	case updates == nil && message == nil:
+		return nil, fmt.Errorf("execution finished without a result: %w", a2a.ErrInvalidAgentResponse)
+	case updates == nil || (message != nil && h.finalMessage == FinalMessageOnly):
+		return message, nil
+	case message != nil:
+		updates.Task.History = append(updates.Task.History, message)
+		if err := updates.Process(ctx, updates.Task); err != nil {
+			return nil, fmt.Errorf("failed to save final message: %w", err)
+		}
+	}
+	return updates.Task, nil
+}
+
+// newTaskForEvent returns the Task updated by the first Task event of an execution.
+func newTaskForEvent(event a2a.Event) (*a2a.Task, error) {
+	switch v := event.(type) {
+	case *a2a.Task:
+		return &a2a.Task{ID: v.ID, ContextID: v.ContextID}, nil
+	case *a2a.TaskStatusUpdateEvent:
+		return &a2a.Task{ID: v.TaskID, ContextID: v.ContextID}, nil
+	case *a2a.TaskArtifactUpdateEvent:
+		return &a2a.Task{ID: v.TaskID, ContextID: v.ContextID}, nil
+	default:
 		return nil, fmt.Errorf("unexpected event type: %T", event)
 	}
+}
 
-	return event.(a2a.SendMessageResult), nil
+// taskSaver returns the taskStoreSaver for applying execution events to Tasks. Push notifications are delivered
+// in the background unless TaskStore records them in a PushOutbox, in which case PushOutboxRelay delivers them.
+// Concurrent modifications are detected if TaskStore implements VersionedTaskStore.
+func (h *defaultRequestHandler) taskSaver() taskupdate.Saver {
+	saver := taskStoreSaver{store: h.taskStore, artifacts: h.artifacts}
+	if _, ok := h.taskStore.(PushOutbox); !ok {
+		saver.pushes = h.pushes
+	}
+	if versioned, ok := h.taskStore.(VersionedTaskStore); ok {
+		return versionedTaskStoreSaver{taskStoreSaver: saver, versioned: versioned}
+	}
+	return saver
 }
 
-func (h *defaultRequestHandler) OnResubscribeToTask(ctx context.Context, id a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+// taskStoreSaver adapts TaskStore for taskupdate.Manager. Tasks are not saved if the store is nil.
+// Artifacts written to ArtifactStore are saved as references to the stored copies.
+// Every update is followed by a push notification if the dispatcher is set.
+type taskStoreSaver struct {
+	store     TaskStore
+	artifacts *artifactTee
+	pushes    *pushDispatcher
+}
+
+func (s taskStoreSaver) Save(ctx context.Context, task *a2a.Task) error {
+	if s.store != nil {
+		if s.artifacts != nil {
+			task = s.artifacts.rewrite(ctx, task)
+		}
+		if err := s.store.Save(ctx, *task); err != nil {
+			return err
+		}
+	}
+	s.notify(ctx, task)
 	return nil
 }
 
+func (s taskStoreSaver) notify(ctx context.Context, task *a2a.Task) {
+	if s.pushes != nil {
+		// Delivery failures don't fail the execution, they are reported to PushErrorHandler
+		// and a notification can be resent with TaskAdmin.ReplayPush.
+		s.pushes.enqueue(ctx, task)
+	}
+}
+
+// versionedTaskStoreSaver is the taskStoreSaver of a VersionedTaskStore. It makes taskupdate.Manager
+// reload and update the Task again instead of overwriting a concurrent update.
+type versionedTaskStoreSaver struct {
+	taskStoreSaver
+	versioned VersionedTaskStore
+}
+
+var _ taskupdate.VersionedSaver = versionedTaskStoreSaver{}
+
+func (s versionedTaskStoreSaver) SaveVersioned(ctx context.Context, task *a2a.Task, prev TaskVersion) (TaskVersion, error) {
+	if s.artifacts != nil {
+		task = s.artifacts.rewrite(ctx, task)
+	}
+	version, err := s.versioned.SaveVersioned(ctx, *task, prev)
+	if err != nil {
+		return 0, err
+	}
+	s.notify(ctx, task)
+	return version, nil
+}
+
+func (s versionedTaskStoreSaver) GetVersioned(ctx context.Context, taskId a2a.TaskID) (*a2a.Task, TaskVersion, error) {
+	task, version, err := s.versioned.GetVersioned(ctx, taskId)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &task, version, nil
+}
+
+// OnResubscribeToTask yields the stored Task followed by the live events of the streaming execution of the Task
+// if one is in progress in this process. The stream ends after a Task reaches a terminal state, after a Message
+// which is the final result of the execution or when the execution finishes.
+func (h *defaultRequestHandler) OnResubscribeToTask(ctx context.Context, id a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+	if err := h.checkStreaming(); err != nil {
+		return errorSeq(err)
+	}
+	return func(yield func(a2a.Event, error) bool) {
+		if h.taskStore == nil {
+			yield(nil, errNoTaskStore)
+			return
+		}
+		// subscribing before reading the snapshot guarantees no events are lost in between,
+		// at the cost of possibly repeating updates which are already applied to the snapshot
+		var sub *eventqueue.Subscription
+		if fanout, ok := h.streams.get(id.ID); ok {
+			sub = fanout.Subscribe()
+			defer sub.Close()
+		}
+		task, err := h.taskStore.Get(ctx, id.ID)
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to get task: %w", err))
+			return
+		}
+		if !yield(&task, nil) || sub == nil || task.Status.State.Terminal() {
+			return
+		}
+		streamSubscription(ctx, sub, true, nil, yield)
+	}
+}
+
+// OnSendMessageStream runs AgentExecutor in a separate goroutine and yields the events it writes to the queue
+// after they are applied to the Task. The stream ends after a Task reaches a terminal state, after a Message
+// which is the final result of the execution or when the execution finishes. The execution is not stopped
+// when the client disconnects, so that it can resubscribe. OnCancelTask stops it. A submitted Task is created
+// for a Message which doesn't reference one.
 func (h *defaultRequestHandler) OnSendMessageStream(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
-	return nil
+	if err := h.checkStreaming(); err != nil {
+		return errorSeq(err)
+	}
+	return func(yield func(a2a.Event, error) bool) {
+		prefs, err := a2a.PreferencesOf(&message.Message)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		if a2a.IsDryRun(&message) {
+			result, err := h.dryRun(ctx, message, prefs)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			yield(result.(*a2a.Task), nil)
+			return
+		}
+
+		created := newTaskForMessage(&message.Message)
+		sub, execErr, err := h.startStreamingExecution(ctx, message, prefs, created)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer sub.Close()
+		streamSubscription(ctx, sub, false, execErr, yield)
+	}
 }
 
 func (h *defaultRequestHandler) OnGetTaskPushConfig(ctx context.Context, params a2a.GetTaskPushConfigParams) (a2a.TaskPushConfig, error) {
-	return a2a.TaskPushConfig{}, errUnimplemented
+	configs, err := h.taskPushConfigs(ctx, params.TaskID)
+	if err != nil {
+		return a2a.TaskPushConfig{}, err
+	}
+	// The first registered config is returned if the request doesn't specify one.
+	for _, config := range configs {
+		if params.ConfigID == "" || config.ID == params.ConfigID {
+			return a2a.TaskPushConfig{TaskID: params.TaskID, Config: config}, nil
+		}
+	}
+	return a2a.TaskPushConfig{}, pushConfigNotFound(params.TaskID, params.ConfigID)
 }
 
-func (h *defaultRequestHandler) OnListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) ([]a2a.TaskPushConfig, error) {
-	return nil, errUnimplemented
+func (h *defaultRequestHandler) OnListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) (*a2a.ListTaskPushConfigResult, error) {
+	if err := h.checkPushNotifications(); err != nil {
+		return nil, err
+	}
+	if _, err := a2a.EffectivePageSize(params.PageSize); err != nil {
+		return nil, err
+	}
+	configs, err := h.taskPushConfigs(ctx, params.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	page, next, err := Paginate(configs, params.PageSize, params.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	result := &a2a.ListTaskPushConfigResult{Configs: make([]a2a.TaskPushConfig, len(page)), NextPageToken: next}
+	for i, config := range page {
+		result.Configs[i] = a2a.TaskPushConfig{TaskID: params.TaskID, Config: config}
+	}
+	return result, nil
 }
 
 func (h *defaultRequestHandler) OnSetTaskPushConfig(ctx context.Context, params a2a.TaskPushConfig) (a2a.TaskPushConfig, error) {
-	return a2a.TaskPushConfig{}, errUnimplemented
+	if err := h.checkPushConfigTask(ctx, params.TaskID); err != nil {
+		return a2a.TaskPushConfig{}, err
+	}
+	if err := validateWebhookURL(params.Config.URL); err != nil {
+		return a2a.TaskPushConfig{}, err
+	}
+	if params.Config.ID == "" {
+		params.Config.ID = uuid.NewString()
+	}
+	if err := h.pushConfigStore.Save(ctx, params.TaskID, params.Config); err != nil {
+		return a2a.TaskPushConfig{}, fmt.Errorf("failed to save push config: %w", err)
+	}
+	return params, nil
 }
 
 func (h *defaultRequestHandler) OnDeleteTaskPushConfig(ctx context.Context, params a2a.DeleteTaskPushConfigParams) error {
-	return errUnimplemented
+	configs, err := h.taskPushConfigs(ctx, params.TaskID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(configs, func(c a2a.PushConfig) bool { return c.ID == params.ConfigID }) {
+		return pushConfigNotFound(params.TaskID, params.ConfigID)
+	}
+	if err := h.pushConfigStore.Delete(ctx, params.TaskID, params.ConfigID); err != nil {
+		return fmt.Errorf("failed to delete push config: %w", err)
+	}
+	return nil
+}
+
+// taskPushConfigs returns push configs registered for an existing Task.
+func (h *defaultRequestHandler) taskPushConfigs(ctx context.Context, taskID a2a.TaskID) ([]a2a.PushConfig, error) {
+	if err := h.checkPushConfigTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+	configs, err := h.pushConfigStore.Get(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get push configs: %w", err)
+	}
+	return configs, nil
+}
+
+// pushConfigNotFound is reported as a2a.ErrTaskNotFound, because the protocol doesn't define
+// a dedicated error for unknown push configs.
+func pushConfigNotFound(taskID a2a.TaskID, configID string) error {
+	return fmt.Errorf("%w: push config %q not found for task %s", a2a.ErrTaskNotFound, configID, taskID)
+}
+
+// checkPushConfigTask returns an error if push configs can't be managed or the Task doesn't exist.
+// Task existence is not checked if the handler has no TaskStore.
+func (h *defaultRequestHandler) checkPushConfigTask(ctx context.Context, taskID a2a.TaskID) error {
+	if err := h.checkPushNotifications(); err != nil {
+		return err
+	}
+	if h.pushConfigStore == nil {
+		return errNoPushConfigStore
+	}
+	if h.taskStore == nil {
+		return nil
+	}
+	if _, err := h.taskStore.Get(ctx, taskID); err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+	return nil
+}
+
+// validateWebhookURL checks that a push notification URL is an absolute http(s) URL.
+func validateWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || !u.IsAbs() || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("%w: push notification URL must be an absolute http(s) URL, got %q", a2a.ErrInvalidRequest, rawURL)
+	}
+	return nil
+}
+
+func (h *defaultRequestHandler) removeUploads(uploads map[string]Upload) {
+	for _, upload := range uploads {
+		_ = h.uploads.Remove(upload.ID)
+	}
+}
+
+func (h *defaultRequestHandler) checkStreaming() error {
+	if h.cardProducer != nil && !h.cardProducer.Card().Capabilities.Streaming {
+		return a2a.ErrUnsupportedOperation
+	}
+	return nil
+}
+
+func (h *defaultRequestHandler) checkPushNotifications() error {
+	if h.cardProducer != nil && !h.cardProducer.Card().Capabilities.PushNotifications {
+		return a2a.ErrPushNotificationNotSupported
+	}
+	return nil
+}
+
+func errorSeq(err error) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		yield(nil, err)
+	}
 }
@@ -15,14 +15,21 @@
 package a2asrv
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
 )
 
 var (
@@ -99,12 +106,32 @@ func (m *mockEventQueue) Close() error {
 	return errors.New("Close() not implemented")
 }
 
+// mockTaskOwnership is a mock of TaskOwnership
+type mockTaskOwnership struct {
+	AcquireFunc func(ctx context.Context, taskId a2a.TaskID) error
+	ReleaseFunc func(ctx context.Context, taskId a2a.TaskID) error
+}
+
+func (m *mockTaskOwnership) Acquire(ctx context.Context, taskId a2a.TaskID) error {
+	if m.AcquireFunc != nil {
+		return m.AcquireFunc(ctx, taskId)
+	}
+	return errors.New("Acquire() not implemented")
+}
+
+func (m *mockTaskOwnership) Release(ctx context.Context, taskId a2a.TaskID) error {
+	if m.ReleaseFunc != nil {
+		return m.ReleaseFunc(ctx, taskId)
+	}
+	return errors.New("Release() not implemented")
+}
+
 func newEventReplayQueueManager(t *testing.T, toSend ...a2a.Event) eventqueue.Manager {
 	i := 0
 	mockQ := &mockEventQueue{
 		ReadFunc: func(ctx context.Context) (a2a.Event, error) {
 			if i >= len(toSend) {
-				return nil, fmt.Errorf("The number of ReadFunc exceeded the number of events: %d", i)
+				return nil, eventqueue.ErrQueueClosed
 			}
 			e := toSend[i]
 			i++
@@ -118,6 +145,9 @@ func newEventReplayQueueManager(t *testing.T, toSend ...a2a.Event) eventqueue.Ma
 			}
 			return mockQ, nil
 		},
+		DestroyFunc: func(ctx context.Context, id a2a.TaskID) error {
+			return nil
+		},
 	}
 }
 
@@ -134,33 +164,58 @@ func newTestHandler(opts ...RequestHandlerOption) RequestHandler {
 }
 
 func TestDefaultRequestHandler_OnSendMessage(t *testing.T) {
+	task := &a2a.Task{ID: taskID, ContextID: "test-context"}
+	agentMessage := &a2a.Message{TaskID: taskID, ID: "agent-message", Role: a2a.MessageRoleAgent}
+	working := a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil)
+	completed := a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil)
+
 	tests := []struct {
-		name      string
-		message   a2a.MessageSendParams
-		wantEvent a2a.Event
-		wantErr   error
+		name    string
+		message a2a.MessageSendParams
+		events  []a2a.Event
+		want    a2a.SendMessageResult
+		wantErr error
 	}{
 		{
 			name: "success with TaskID",
 			message: a2a.MessageSendParams{
 				Message: a2a.Message{TaskID: taskID, ID: "test-message"},
 			},
-			wantEvent: &a2a.Message{TaskID: taskID, ID: "test-message"},
+			events: []a2a.Event{&a2a.Message{TaskID: taskID, ID: "test-message"}},
+			want:   &a2a.Message{TaskID: taskID, ID: "test-message"},
 		},
 		{
-			name: "missing TaskID",
+			name: "status updates aggregated",
 			message: a2a.MessageSendParams{
-				Message: a2a.Message{ID: "test-message"},
+				Message: a2a.Message{TaskID: taskID, ID: "test-message"},
 			},
-			wantErr: errors.New("message is missing TaskID"),
+			events: []a2a.Event{working, completed},
+			want:   &a2a.Task{ID: taskID, ContextID: task.ContextID, Status: completed.Status},
 		},
 		{
-			name: "type assertion fails",
+			name: "custom events skipped",
 			message: a2a.MessageSendParams{
 				Message: a2a.Message{TaskID: taskID, ID: "test-message"},
 			},
-			wantEvent: &a2a.TaskStatusUpdateEvent{TaskID: taskID},
-			wantErr:   errors.New("unexpected event type: *a2a.TaskStatusUpdateEvent"),
+			events: []a2a.Event{&customEvent{}, working, &customEvent{}, completed},
+			want:   &a2a.Task{ID: taskID, ContextID: task.ContextID, Status: completed.Status},
+		},
+		{
+			name: "final message attached to task",
+			message: a2a.MessageSendParams{
+				Message: a2a.Message{TaskID: taskID, ID: "test-message"},
+			},
+			events: []a2a.Event{
+				&a2a.Task{ID: taskID, ContextID: task.ContextID, Status: a2a.TaskStatus{State: a2a.TaskStateSubmitted}},
+				completed,
+				agentMessage,
+			},
+			want: &a2a.Task{
+				ID:        taskID,
+				ContextID: task.ContextID,
+				Status:    completed.Status,
+				History:   []*a2a.Message{agentMessage},
+			},
 		},
 		{
 			name: "GetOrCreate() fails",
@@ -177,31 +232,26 @@ func TestDefaultRequestHandler_OnSendMessage(t *testing.T) {
 			wantErr: errors.New("execute failed"),
 		},
 		{
-			name: "queue Read() fails",
+			name: "no result",
 			message: a2a.MessageSendParams{
 				Message: a2a.Message{TaskID: taskID, ID: "test-message"},
 			},
-			wantErr: errors.New("failed to read event from queue: The number of ReadFunc exceeded the number of events: 0"),
+			wantErr: fmt.Errorf("execution finished without a result: %w", a2a.ErrInvalidAgentResponse),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := t.Context()
-			var qm eventqueue.Manager
-			if tt.wantEvent == nil {
-				qm = newEventReplayQueueManager(t)
-			} else {
-				qm = newEventReplayQueueManager(t, tt.wantEvent)
-			}
+			qm := newEventReplayQueueManager(t, tt.events...)
 			handler := newTestHandler(WithEventQueueManager(qm))
 			result, gotErr := handler.OnSendMessage(ctx, tt.message)
 			if tt.wantErr == nil {
 				if gotErr != nil {
 					t.Fatalf("OnSendMessage() error = %v, wantErr nil", gotErr)
 				}
-				if !reflect.DeepEqual(result, tt.wantEvent) {
-					t.Errorf("OnSendMessage() got = %v, want %v", result, tt.wantEvent)
+				if !reflect.DeepEqual(result, tt.want) {
+					t.Errorf("OnSendMessage() got = %v, want %v", result, tt.want)
 				}
 			} else {
 				if gotErr == nil {
@@ -216,32 +266,1044 @@ func TestDefaultRequestHandler_OnSendMessage(t *testing.T) {
 	}
 }
 
-func TestDefaultRequestHandler_Unimplemented(t *testing.T) {
+func TestDefaultRequestHandler_CreatesTask(t *testing.T) {
+	var gotTaskID a2a.TaskID
+	executor := &mockAgentExecutor{ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+		gotTaskID = reqCtx.TaskID
+		// Task is only present for messages continuing a Task
+		if reqCtx.Task != nil || reqCtx.ContextID == "" || reqCtx.ContextID != reqCtx.Request.Message.ContextID {
+			return fmt.Errorf("unexpected request context %+v", reqCtx)
+		}
+		task := &a2a.Task{ID: reqCtx.TaskID, ContextID: reqCtx.ContextID}
+		return q.Write(ctx, a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil))
+	}}
+	userMessage := a2a.Message{ID: "test-message", Role: a2a.MessageRoleUser}
+
+	tests := []struct {
+		name string
+		send func(handler RequestHandler) (*a2a.Task, error)
+	}{
+		{
+			name: "OnSendMessage",
+			send: func(handler RequestHandler) (*a2a.Task, error) {
+				result, err := handler.OnSendMessage(t.Context(), a2a.MessageSendParams{Message: userMessage})
+				if err != nil {
+					return nil, err
+				}
+				return result.(*a2a.Task), nil
+			},
+		},
+		{
+			name: "OnSendMessageStream",
+			send: func(handler RequestHandler) (*a2a.Task, error) {
+				for event, err := range handler.OnSendMessageStream(t.Context(), a2a.MessageSendParams{Message: userMessage}) {
+					if err != nil {
+						return nil, err
+					}
+					if update, ok := event.(*a2a.TaskStatusUpdateEvent); ok && update.Status.State.Terminal() {
+						return &a2a.Task{ID: update.TaskID, ContextID: update.ContextID, Status: update.Status}, nil
+					}
+				}
+				return nil, errors.New("stream ended without a terminal update")
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newCountingTaskStore()
+			handler := NewHandler(executor, WithTaskStore(store))
+
+			result, err := tc.send(handler)
+			if err != nil {
+				t.Fatalf("%s() error = %v", tc.name, err)
+			}
+			if result.ID != gotTaskID || result.ContextID == "" || result.Status.State != a2a.TaskStateCompleted {
+				t.Fatalf("%s() = %+v, want completed task %s", tc.name, result, gotTaskID)
+			}
+			stored, err := store.Get(t.Context(), result.ID)
+			if err != nil {
+				t.Fatalf("store.Get() error = %v", err)
+			}
+			if stored.Status.State != a2a.TaskStateCompleted || len(stored.History) != 1 || stored.History[0].ID != userMessage.ID {
+				t.Fatalf("stored task = %+v, want completed task with the user message in history", stored)
+			}
+			if stored.History[0].TaskID != result.ID {
+				t.Fatalf("stored message TaskID = %q, want %q", stored.History[0].TaskID, result.ID)
+			}
+		})
+	}
+}
+
+func TestDefaultRequestHandler_OnSendMessage_FinalMessageOnly(t *testing.T) {
+	task := &a2a.Task{ID: taskID, ContextID: "test-context"}
+	agentMessage := &a2a.Message{TaskID: taskID, ID: "agent-message", Role: a2a.MessageRoleAgent}
+	qm := newEventReplayQueueManager(t, agentMessage, a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil))
+	store := newCountingTaskStore()
+	handler := newTestHandler(WithEventQueueManager(qm), WithTaskStore(store), WithFinalMessageMode(FinalMessageOnly))
+
+	result, err := handler.OnSendMessage(t.Context(), a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID, ID: "test-message"}})
+	if err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	if result != agentMessage {
+		t.Fatalf("OnSendMessage() = %v, want %v", result, agentMessage)
+	}
+	if stored, err := store.Get(t.Context(), taskID); err != nil || stored.Status.State != a2a.TaskStateCompleted {
+		t.Fatalf("stored task = %v, %v, want the aggregated task to be saved", stored, err)
+	}
+}
+
+type customEvent struct {
+	a2a.CustomEventBase
+}
+
+func (customEvent) EventKind() string { return "x-custom" }
+
+func TestDefaultRequestHandler_OnSendMessage_TaskOwnership(t *testing.T) {
+	ctx := t.Context()
+	message := a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID, ID: "test-message"}}
+
+	acquireErr := errors.New("owned by another replica")
+	released := false
+	ownership := &mockTaskOwnership{
+		AcquireFunc: func(ctx context.Context, id a2a.TaskID) error { return acquireErr },
+		ReleaseFunc: func(ctx context.Context, id a2a.TaskID) error {
+			released = true
+			return nil
+		},
+	}
+	executed := false
+	executor := &mockAgentExecutor{
+		ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+			executed = true
+			return nil
+		},
+	}
+	handler := NewHandler(executor, WithTaskOwnership(ownership), WithEventQueueManager(newEventReplayQueueManager(t)))
+
+	if _, err := handler.OnSendMessage(ctx, message); !errors.Is(err, acquireErr) {
+		t.Fatalf("OnSendMessage() error = %v, want %v", err, acquireErr)
+	}
+	if executed {
+		t.Fatal("executor invoked without task ownership")
+	}
+	if released {
+		t.Fatal("ownership released without being acquired")
+	}
+
+	ownership.AcquireFunc = func(ctx context.Context, id a2a.TaskID) error { return nil }
+	handler = NewHandler(executor, WithTaskOwnership(ownership), WithEventQueueManager(newEventReplayQueueManager(t, &message.Message)))
+	if _, err := handler.OnSendMessage(ctx, message); err != nil {
+		t.Fatalf("OnSendMessage() error = %v, want nil", err)
+	}
+	if !executed {
+		t.Fatal("executor not invoked after ownership was acquired")
+	}
+	if !released {
+		t.Fatal("ownership not released after execution")
+	}
+}
+
+// writeEvents returns an ExecuteFunc which writes the events and then returns err.
+func writeEvents(err error, events ...a2a.Event) func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+	return func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+		for _, event := range events {
+			if err := q.Write(ctx, event); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+}
+
+func TestDefaultRequestHandler_OnSendMessageStream(t *testing.T) {
+	task := &a2a.Task{ID: taskID, ContextID: "test-context"}
+	working := a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil)
+	artifact := &a2a.TaskArtifactUpdateEvent{TaskID: taskID, ContextID: "test-context", Artifact: &a2a.Artifact{ID: "a"}}
+	completed := a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil)
+	agentMessage := &a2a.Message{TaskID: taskID, ID: "agent-message", Role: a2a.MessageRoleAgent}
+	executeErr := errors.New("agent crashed")
+
+	tests := []struct {
+		name       string
+		execute    func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error
+		wantEvents []a2a.Event
+		wantErr    error
+		wantState  a2a.TaskState
+	}{
+		{
+			name:       "stops after terminal state",
+			execute:    writeEvents(nil, task, working, artifact, completed, agentMessage),
+			wantEvents: []a2a.Event{task, working, artifact, completed},
+			wantState:  a2a.TaskStateCompleted,
+		},
+		{
+			name:       "stops after final message",
+			execute:    writeEvents(nil, agentMessage),
+			wantEvents: []a2a.Event{agentMessage},
+		},
+		{
+			name:       "execution error",
+			execute:    writeEvents(executeErr, working),
+			wantEvents: []a2a.Event{working},
+			wantErr:    executeErr,
+			wantState:  a2a.TaskStateWorking,
+		},
+		{
+			name:    "no result",
+			execute: writeEvents(nil),
+			wantErr: a2a.ErrInvalidAgentResponse,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newCountingTaskStore()
+			handler := NewHandler(&mockAgentExecutor{ExecuteFunc: tc.execute}, WithTaskStore(store))
+
+			var events []a2a.Event
+			var gotErr error
+			for event, err := range handler.OnSendMessageStream(t.Context(), a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID, ID: "test-message"}}) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				events = append(events, event)
+			}
+			if !errors.Is(gotErr, tc.wantErr) {
+				t.Fatalf("OnSendMessageStream() error = %v, want %v", gotErr, tc.wantErr)
+			}
+			if !reflect.DeepEqual(events, tc.wantEvents) {
+				t.Fatalf("OnSendMessageStream() events = %v, want %v", events, tc.wantEvents)
+			}
+			stored, err := store.Get(t.Context(), taskID)
+			if tc.wantState == "" {
+				if err == nil {
+					t.Fatalf("stored task = %v, want none", stored)
+				}
+				return
+			}
+			if err != nil || stored.Status.State != tc.wantState {
+				t.Fatalf("stored task = %v, %v, want %s task", stored, err, tc.wantState)
+			}
+		})
+	}
+}
+
+func TestDefaultRequestHandler_OnSendMessageStream_EncodedEvents(t *testing.T) {
+	task := &a2a.Task{ID: taskID, ContextID: "test-context"}
+	completed := a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil)
+	handler := NewHandler(&mockAgentExecutor{ExecuteFunc: writeEvents(nil, task, completed)})
+
+	encoded := &EncodedEvents{}
+	ctx := WithEncodedEvents(t.Context(), encoded)
+	var last a2a.Event
+	for event, err := range handler.OnSendMessageStream(ctx, a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID, ID: "test-message"}}) {
+		if err != nil {
+			t.Fatalf("OnSendMessageStream() error = %v", err)
+		}
+		got, ok := encoded.Lookup(event)
+		if !ok {
+			t.Fatalf("Lookup(%T) found nothing, want shared encoding", event)
+		}
+		want, err := a2a.MarshalEvent(event)
+		if err != nil {
+			t.Fatalf("MarshalEvent() error = %v", err)
+		}
+		if string(got) != string(want) {
+			t.Fatalf("Lookup(%T) = %s, want %s", event, got, want)
+		}
+		if _, ok := encoded.Lookup(&a2a.Message{ID: "other"}); ok {
+			t.Fatal("Lookup() found an event which is not being yielded")
+		}
+		last = event
+	}
+	if _, ok := encoded.Lookup(last); ok {
+		t.Fatal("Lookup() found an event after it was handled")
+	}
+}
+
+func TestDefaultRequestHandler_OnSendMessageStream_Resubscribe(t *testing.T) {
+	ctx := t.Context()
+	task := &a2a.Task{ID: taskID, ContextID: "test-context"}
+	working := a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil)
+	artifact := &a2a.TaskArtifactUpdateEvent{TaskID: taskID, ContextID: "test-context", Artifact: &a2a.Artifact{ID: "a"}}
+	completed := a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil)
+	resume := make(chan struct{})
+	executor := &mockAgentExecutor{ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+		if err := q.Write(ctx, working); err != nil {
+			return err
+		}
+		<-resume
+		return writeEvents(nil, artifact, completed)(ctx, reqCtx, q)
+	}}
+	store := newCountingTaskStore()
+	handler := NewHandler(executor, WithTaskStore(store))
+
+	// the client disconnects after the first event, which doesn't stop the execution
+	for event, err := range handler.OnSendMessageStream(ctx, a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID}}) {
+		if err != nil || event != working {
+			t.Fatalf("OnSendMessageStream() = %v, %v, want %v", event, err, working)
+		}
+		break
+	}
+
+	var events []a2a.Event
+	for event, err := range handler.OnResubscribeToTask(ctx, a2a.TaskIDParams{ID: taskID}) {
+		if err != nil {
+			t.Fatalf("OnResubscribeToTask() error = %v", err)
+		}
+		events = append(events, event)
+		if len(events) == 1 {
+			close(resume)
+		}
+	}
+	if len(events) != 3 {
+		t.Fatalf("OnResubscribeToTask() yielded %d events, want the snapshot and 2 live events: %v", len(events), events)
+	}
+	if snapshot, ok := events[0].(*a2a.Task); !ok || snapshot.Status.State != a2a.TaskStateWorking {
+		t.Fatalf("OnResubscribeToTask() first event = %v, want working task snapshot", events[0])
+	}
+	if !reflect.DeepEqual(events[1:], []a2a.Event{artifact, completed}) {
+		t.Fatalf("OnResubscribeToTask() live events = %v, want %v", events[1:], []a2a.Event{artifact, completed})
+	}
+	if stored, err := store.Get(ctx, taskID); err != nil || stored.Status.State != a2a.TaskStateCompleted || len(stored.Artifacts) != 1 {
+		t.Fatalf("stored task = %v, %v, want completed task with the artifact", stored, err)
+	}
+}
+
+func TestDefaultRequestHandler_OnResubscribeToTask_NotRunning(t *testing.T) {
+	ctx := t.Context()
+	completed := a2a.Task{ID: taskID, Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}}
+	handler := NewHandler(&mockAgentExecutor{}, WithTaskStore(newListingTaskStore(completed)))
+
+	var events []a2a.Event
+	for event, err := range handler.OnResubscribeToTask(ctx, a2a.TaskIDParams{ID: taskID}) {
+		if err != nil {
+			t.Fatalf("OnResubscribeToTask() error = %v", err)
+		}
+		events = append(events, event)
+	}
+	if len(events) != 1 || !reflect.DeepEqual(*events[0].(*a2a.Task), completed) {
+		t.Fatalf("OnResubscribeToTask() = %v, want only the stored task", events)
+	}
+
+	for _, err := range handler.OnResubscribeToTask(ctx, a2a.TaskIDParams{ID: "unknown"}) {
+		if !errors.Is(err, a2a.ErrTaskNotFound) {
+			t.Fatalf("OnResubscribeToTask() error = %v, want %v", err, a2a.ErrTaskNotFound)
+		}
+	}
+	for _, err := range NewHandler(&mockAgentExecutor{}).OnResubscribeToTask(ctx, a2a.TaskIDParams{ID: taskID}) {
+		if !errors.Is(err, a2a.ErrUnsupportedOperation) {
+			t.Fatalf("OnResubscribeToTask() without task store error = %v, want %v", err, a2a.ErrUnsupportedOperation)
+		}
+	}
+}
+
+func TestDefaultRequestHandler_OnSendMessageStream_CreditWindow(t *testing.T) {
+	task := &a2a.Task{ID: taskID, ContextID: "test-context"}
+	var written atomic.Int32
+	executor := &mockAgentExecutor{ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+		for _, state := range []a2a.TaskState{a2a.TaskStateWorking, a2a.TaskStateWorking, a2a.TaskStateCompleted} {
+			if err := q.Write(ctx, a2a.NewStatusUpdateEvent(task, state, nil)); err != nil {
+				return err
+			}
+			written.Add(1)
+		}
+		return nil
+	}}
+	handler := NewHandler(executor)
+	window := eventqueue.NewCreditWindow(1)
+	ctx := WithCreditWindow(t.Context(), window)
+
+	count := 0
+	for _, err := range handler.OnSendMessageStream(ctx, a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID}}) {
+		if err != nil {
+			t.Fatalf("OnSendMessageStream() error = %v", err)
+		}
+		count++
+		time.Sleep(20 * time.Millisecond)
+		if got := written.Load(); got != int32(count) {
+			t.Fatalf("executor wrote %d events while %d were consumed, want the window to limit it", got, count)
+		}
+		window.Grant(1)
+	}
+	if count != 3 {
+		t.Fatalf("OnSendMessageStream() yielded %d events, want 3", count)
+	}
+}
+
+func TestDefaultRequestHandler_PushConfigNotConfigured(t *testing.T) {
+	handler := NewHandler(&mockAgentExecutor{}, WithTaskStore(newListingTaskStore()), WithPushConfigStore(nil))
+	ctx := t.Context()
+
+	if _, err := handler.OnGetTaskPushConfig(ctx, a2a.GetTaskPushConfigParams{}); !errors.Is(err, a2a.ErrUnsupportedOperation) {
+		t.Errorf("OnGetTaskPushConfig: expected unsupported operation error, got %v", err)
+	}
+	if _, err := handler.OnListTaskPushConfig(ctx, a2a.ListTaskPushConfigParams{}); !errors.Is(err, a2a.ErrUnsupportedOperation) {
+		t.Errorf("OnListTaskPushConfig: expected unsupported operation error, got %v", err)
+	}
+	if _, err := handler.OnSetTaskPushConfig(ctx, a2a.TaskPushConfig{}); !errors.Is(err, a2a.ErrUnsupportedOperation) {
+		t.Errorf("OnSetTaskPushConfig: expected unsupported operation error, got %v", err)
+	}
+	if err := handler.OnDeleteTaskPushConfig(ctx, a2a.DeleteTaskPushConfigParams{}); !errors.Is(err, a2a.ErrUnsupportedOperation) {
+		t.Errorf("OnDeleteTaskPushConfig: expected unsupported operation error, got %v", err)
+	}
+}
+
+func TestDefaultRequestHandler_PushConfigCRUD(t *testing.T) {
+	ctx := t.Context()
+	handler := NewHandler(&mockAgentExecutor{}, WithTaskStore(newListingTaskStore(a2a.Task{ID: taskID})))
+
+	generated, err := handler.OnSetTaskPushConfig(ctx, a2a.TaskPushConfig{TaskID: taskID, Config: a2a.PushConfig{URL: "https://example.com/a"}})
+	if err != nil {
+		t.Fatalf("OnSetTaskPushConfig() error = %v", err)
+	}
+	if generated.Config.ID == "" {
+		t.Fatal("OnSetTaskPushConfig() didn't generate a config ID")
+	}
+	named := a2a.TaskPushConfig{TaskID: taskID, Config: a2a.PushConfig{ID: "named", URL: "http://example.com/b"}}
+	if got, err := handler.OnSetTaskPushConfig(ctx, named); err != nil || got.Config.ID != "named" {
+		t.Fatalf("OnSetTaskPushConfig() = (%v, %v), want the named config", got, err)
+	}
+
+	got, err := handler.OnGetTaskPushConfig(ctx, a2a.GetTaskPushConfigParams{TaskID: taskID, ConfigID: "named"})
+	if err != nil || got.Config.URL != named.Config.URL {
+		t.Fatalf("OnGetTaskPushConfig() = (%v, %v), want %v", got, err, named)
+	}
+	if got, err := handler.OnGetTaskPushConfig(ctx, a2a.GetTaskPushConfigParams{TaskID: taskID}); err != nil || got.Config.ID != generated.Config.ID {
+		t.Fatalf("OnGetTaskPushConfig() without ID = (%v, %v), want the first config", got, err)
+	}
+
+	page, err := handler.OnListTaskPushConfig(ctx, a2a.ListTaskPushConfigParams{TaskID: taskID, PageSize: 1})
+	if err != nil || len(page.Configs) != 1 || page.NextPageToken == "" {
+		t.Fatalf("OnListTaskPushConfig() = (%v, %v), want a page with one config and a next page", page, err)
+	}
+	page, err = handler.OnListTaskPushConfig(ctx, a2a.ListTaskPushConfigParams{TaskID: taskID, PageToken: page.NextPageToken})
+	if err != nil || len(page.Configs) != 1 || page.Configs[0].Config.ID != "named" || page.NextPageToken != "" {
+		t.Fatalf("OnListTaskPushConfig() = (%v, %v), want the last page with the named config", page, err)
+	}
+
+	if err := handler.OnDeleteTaskPushConfig(ctx, a2a.DeleteTaskPushConfigParams{TaskID: taskID, ConfigID: "named"}); err != nil {
+		t.Fatalf("OnDeleteTaskPushConfig() error = %v", err)
+	}
+	if _, err := handler.OnGetTaskPushConfig(ctx, a2a.GetTaskPushConfigParams{TaskID: taskID, ConfigID: "named"}); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Fatalf("OnGetTaskPushConfig() error = %v for a deleted config, want ErrTaskNotFound", err)
+	}
+	if err := handler.OnDeleteTaskPushConfig(ctx, a2a.DeleteTaskPushConfigParams{TaskID: taskID, ConfigID: "named"}); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Fatalf("OnDeleteTaskPushConfig() error = %v for a deleted config, want ErrTaskNotFound", err)
+	}
+}
+
+func TestDefaultRequestHandler_PushConfigDefaults(t *testing.T) {
+	ctx := t.Context()
 	handler := NewHandler(&mockAgentExecutor{})
+
+	config := a2a.TaskPushConfig{TaskID: taskID, Config: a2a.PushConfig{ID: "cfg", URL: "https://example.com/push"}}
+	if _, err := handler.OnSetTaskPushConfig(ctx, config); err != nil {
+		t.Fatalf("OnSetTaskPushConfig() error = %v", err)
+	}
+	if got, err := handler.OnGetTaskPushConfig(ctx, a2a.GetTaskPushConfigParams{TaskID: taskID, ConfigID: "cfg"}); err != nil || got.Config.URL != config.Config.URL {
+		t.Fatalf("OnGetTaskPushConfig() = (%v, %v), want %v", got, err, config)
+	}
+	if page, err := handler.OnListTaskPushConfig(ctx, a2a.ListTaskPushConfigParams{TaskID: taskID}); err != nil || len(page.Configs) != 1 {
+		t.Fatalf("OnListTaskPushConfig() = (%v, %v), want one config", page, err)
+	}
+	if err := handler.OnDeleteTaskPushConfig(ctx, a2a.DeleteTaskPushConfigParams{TaskID: taskID, ConfigID: "cfg"}); err != nil {
+		t.Fatalf("OnDeleteTaskPushConfig() error = %v", err)
+	}
+}
+
+func TestDefaultRequestHandler_PushConfigErrors(t *testing.T) {
+	ctx := t.Context()
+	handler := NewHandler(&mockAgentExecutor{}, WithTaskStore(newListingTaskStore(a2a.Task{ID: taskID})))
+
+	for _, rawURL := range []string{"", "example.com/push", "/push", "ftp://example.com/push", "https://"} {
+		params := a2a.TaskPushConfig{TaskID: taskID, Config: a2a.PushConfig{URL: rawURL}}
+		if _, err := handler.OnSetTaskPushConfig(ctx, params); !errors.Is(err, a2a.ErrInvalidRequest) {
+			t.Errorf("OnSetTaskPushConfig() error = %v for URL %q, want ErrInvalidRequest", err, rawURL)
+		}
+	}
+
+	missing := a2a.TaskID("missing")
+	if _, err := handler.OnSetTaskPushConfig(ctx, a2a.TaskPushConfig{TaskID: missing, Config: a2a.PushConfig{URL: "https://example.com"}}); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Errorf("OnSetTaskPushConfig() error = %v, want ErrTaskNotFound", err)
+	}
+	if _, err := handler.OnGetTaskPushConfig(ctx, a2a.GetTaskPushConfigParams{TaskID: missing}); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Errorf("OnGetTaskPushConfig() error = %v, want ErrTaskNotFound", err)
+	}
+	if _, err := handler.OnListTaskPushConfig(ctx, a2a.ListTaskPushConfigParams{TaskID: missing}); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Errorf("OnListTaskPushConfig() error = %v, want ErrTaskNotFound", err)
+	}
+	if err := handler.OnDeleteTaskPushConfig(ctx, a2a.DeleteTaskPushConfigParams{TaskID: missing, ConfigID: "cfg"}); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Errorf("OnDeleteTaskPushConfig() error = %v, want ErrTaskNotFound", err)
+	}
+}
+
+func TestDefaultRequestHandler_OnGetTask(t *testing.T) {
+	ctx := t.Context()
+	history := []*a2a.Message{{ID: "m1"}, {ID: "m2"}, {ID: "m3"}}
+	store := newListingTaskStore(a2a.Task{ID: taskID, History: history})
+	handler := NewHandler(&mockAgentExecutor{}, WithTaskStore(store))
+	zero, two, ten, negative := 0, 2, 10, -1
+
+	tests := []struct {
+		name          string
+		historyLength *int
+		want          []*a2a.Message
+	}{
+		{name: "full history", want: history},
+		{name: "last messages", historyLength: &two, want: history[1:]},
+		{name: "no history", historyLength: &zero, want: []*a2a.Message{}},
+		{name: "longer than history", historyLength: &ten, want: history},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			task, err := handler.OnGetTask(ctx, a2a.TaskQueryParams{ID: taskID, HistoryLength: tc.historyLength})
+			if err != nil {
+				t.Fatalf("OnGetTask() error = %v", err)
+			}
+			if !reflect.DeepEqual(task.History, tc.want) {
+				t.Fatalf("OnGetTask() history = %v, want %v", task.History, tc.want)
+			}
+		})
+	}
+
+	if _, err := handler.OnGetTask(ctx, a2a.TaskQueryParams{ID: taskID, HistoryLength: &negative}); !errors.Is(err, a2a.ErrInvalidRequest) {
+		t.Errorf("OnGetTask() with negative history length error = %v, want %v", err, a2a.ErrInvalidRequest)
+	}
+	if _, err := handler.OnGetTask(ctx, a2a.TaskQueryParams{ID: "unknown"}); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Errorf("OnGetTask() for unknown task error = %v, want %v", err, a2a.ErrTaskNotFound)
+	}
+	if _, err := NewHandler(&mockAgentExecutor{}).OnGetTask(ctx, a2a.TaskQueryParams{ID: taskID}); !errors.Is(err, a2a.ErrUnsupportedOperation) {
+		t.Errorf("OnGetTask() without task store error = %v, want %v", err, a2a.ErrUnsupportedOperation)
+	}
+}
+
+func TestDefaultRequestHandler_OnCancelTask(t *testing.T) {
+	ctx := t.Context()
+	working := a2a.Task{ID: taskID, ContextID: "ctx", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+
+	tests := []struct {
+		name     string
+		cancel   func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error
+		wantText string
+	}{
+		{
+			name: "agent emits canceled status",
+			cancel: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+				msg := a2a.NewMessageForTask(a2a.MessageRoleAgent, *reqCtx.Task, a2a.TextPart{Text: "stopped"})
+				return queue.Write(ctx, a2a.NewStatusUpdateEvent(reqCtx.Task, a2a.TaskStateCanceled, msg))
+			},
+			wantText: "stopped",
+		},
+		{
+			name: "handler emits canceled status",
+			cancel: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+				return nil
+			},
+			wantText: ErrTaskCanceled.Error(),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newListingTaskStore(working)
+			handler := NewHandler(&mockAgentExecutor{CancelFunc: tc.cancel}, WithTaskStore(store))
+
+			task, err := handler.OnCancelTask(ctx, a2a.TaskIDParams{ID: taskID})
+			if err != nil {
+				t.Fatalf("OnCancelTask() error = %v", err)
+			}
+			if task.Status.State != a2a.TaskStateCanceled {
+				t.Fatalf("OnCancelTask() state = %s, want %s", task.Status.State, a2a.TaskStateCanceled)
+			}
+			if got := task.Status.Message.Parts[0].(a2a.TextPart).Text; got != tc.wantText {
+				t.Fatalf("OnCancelTask() status message = %q, want %q", got, tc.wantText)
+			}
+			stored, err := store.Get(ctx, taskID)
+			if err != nil || stored.Status.State != a2a.TaskStateCanceled {
+				t.Fatalf("stored task = %v, %v, want canceled task", stored, err)
+			}
+		})
+	}
+}
+
+func TestDefaultRequestHandler_OnCancelTask_Errors(t *testing.T) {
+	ctx := t.Context()
+	store := newListingTaskStore(
+		a2a.Task{ID: "completed", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}},
+		a2a.Task{ID: "working", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}},
+	)
+	cancelErr := errors.New("agent can't stop")
+	executor := &mockAgentExecutor{CancelFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+		return cancelErr
+	}}
+	handler := NewHandler(executor, WithTaskStore(store))
+
+	if _, err := handler.OnCancelTask(ctx, a2a.TaskIDParams{ID: "completed"}); !errors.Is(err, a2a.ErrTaskNotCancelable) {
+		t.Errorf("OnCancelTask() for completed task error = %v, want %v", err, a2a.ErrTaskNotCancelable)
+	}
+	if _, err := handler.OnCancelTask(ctx, a2a.TaskIDParams{ID: "unknown"}); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Errorf("OnCancelTask() for unknown task error = %v, want %v", err, a2a.ErrTaskNotFound)
+	}
+	if _, err := handler.OnCancelTask(ctx, a2a.TaskIDParams{ID: "working"}); !errors.Is(err, cancelErr) {
+		t.Errorf("OnCancelTask() error = %v, want %v", err, cancelErr)
+	}
+	if stored, _ := store.Get(ctx, "working"); stored.Status.State != a2a.TaskStateWorking {
+		t.Errorf("stored task state = %s after failed cancel, want %s", stored.Status.State, a2a.TaskStateWorking)
+	}
+}
+
+func TestDefaultRequestHandler_OnSendMessage_TaskAlreadyOwned(t *testing.T) {
+	ctx := t.Context()
+	message := a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID, ID: "test-message"}}
+
+	started, release := make(chan struct{}), make(chan struct{})
+	executor := &mockAgentExecutor{
+		ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+			close(started)
+			<-release
+			return errors.New("done")
+		},
+	}
+	handler := NewHandler(executor)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = handler.OnSendMessage(ctx, message)
+	}()
+	<-started
+
+	_, err := handler.OnSendMessage(ctx, message)
+	close(release)
+	<-done
+	if !errors.Is(err, ErrTaskAlreadyOwned) {
+		t.Fatalf("OnSendMessage() error = %v, want %v", err, ErrTaskAlreadyOwned)
+	}
+}
+
+func TestDefaultRequestHandler_OnCancelTask_TaskOwnership(t *testing.T) {
 	ctx := t.Context()
+	working := a2a.Task{ID: taskID, ContextID: "ctx", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+	canceled := false
+	executor := &mockAgentExecutor{CancelFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+		canceled = true
+		return nil
+	}}
+	released := false
+	ownership := &mockTaskOwnership{
+		AcquireFunc: func(ctx context.Context, id a2a.TaskID) error { return errors.New("owned by another replica") },
+		ReleaseFunc: func(ctx context.Context, id a2a.TaskID) error {
+			released = true
+			return nil
+		},
+	}
+	store := newListingTaskStore(working)
+	handler := NewHandler(executor, WithTaskStore(store), WithTaskOwnership(ownership))
 
-	if _, err := handler.OnGetTask(ctx, a2a.TaskQueryParams{}); !errors.Is(err, errUnimplemented) {
-		t.Errorf("OnGetTask: expected unimplemented error, got %v", err)
+	if _, err := handler.OnCancelTask(ctx, a2a.TaskIDParams{ID: taskID}); err == nil {
+		t.Fatal("OnCancelTask() error = nil, want an ownership error")
+	}
+	if canceled || released {
+		t.Fatal("task canceled without task ownership")
+	}
+	if stored, _ := store.Get(ctx, taskID); stored.Status.State != a2a.TaskStateWorking {
+		t.Fatalf("stored task state = %s, want %s", stored.Status.State, a2a.TaskStateWorking)
 	}
-	if _, err := handler.OnCancelTask(ctx, a2a.TaskIDParams{}); !errors.Is(err, errUnimplemented) {
-		t.Errorf("OnCancelTask: expected unimplemented error, got %v", err)
+
+	ownership.AcquireFunc = func(ctx context.Context, id a2a.TaskID) error { return nil }
+	task, err := handler.OnCancelTask(ctx, a2a.TaskIDParams{ID: taskID})
+	if err != nil {
+		t.Fatalf("OnCancelTask() error = %v", err)
 	}
-	if seq := handler.OnResubscribeToTask(ctx, a2a.TaskIDParams{}); seq != nil {
-		t.Error("OnResubscribeToTask: expected nil iterator, got non-nil")
+	if task.Status.State != a2a.TaskStateCanceled || !canceled {
+		t.Fatalf("OnCancelTask() state = %s, want %s", task.Status.State, a2a.TaskStateCanceled)
 	}
-	if seq := handler.OnSendMessageStream(ctx, a2a.MessageSendParams{}); seq != nil {
-		t.Error("OnSendMessageStream: expected nil iterator, got non-nil")
+	if !released {
+		t.Fatal("ownership not released after cancelation")
 	}
-	if _, err := handler.OnGetTaskPushConfig(ctx, a2a.GetTaskPushConfigParams{}); !errors.Is(err, errUnimplemented) {
-		t.Errorf("OnGetTaskPushConfig: expected unimplemented error, got %v", err)
+}
+
+// lateWritingExecutor writes an artifact after its execution is canceled.
+func lateWritingExecutor(started chan<- a2a.TaskID) *mockAgentExecutor {
+	return &mockAgentExecutor{
+		ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+			task := &a2a.Task{ID: reqCtx.TaskID, ContextID: reqCtx.ContextID}
+			if err := q.Write(ctx, a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil)); err != nil {
+				return err
+			}
+			started <- reqCtx.TaskID
+			<-ctx.Done()
+			if err := q.Write(context.WithoutCancel(ctx), a2a.NewArtifactEvent(*task, a2a.TextPart{Text: "late"})); err != nil {
+				return err
+			}
+			return context.Cause(ctx)
+		},
+		CancelFunc: func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+			return errors.New("running execution is canceled through its context")
+		},
+	}
+}
+
+func TestDefaultRequestHandler_OnCancelTask_RunningExecution(t *testing.T) {
+	ctx := t.Context()
+	store := newLockedTaskStore()
+	started := make(chan a2a.TaskID, 1)
+	handler := NewHandler(lateWritingExecutor(started), WithTaskStore(store))
+
+	sent := make(chan collectedResult, 1)
+	go func() {
+		result, err := handler.OnSendMessage(ctx, a2a.MessageSendParams{Message: *a2a.NewMessage(a2a.MessageRoleUser)})
+		sent <- collectedResult{result: result, err: err}
+	}()
+	taskID := <-started
+
+	task, err := handler.OnCancelTask(ctx, a2a.TaskIDParams{ID: taskID})
+	if err != nil {
+		t.Fatalf("OnCancelTask() error = %v", err)
 	}
-	if _, err := handler.OnListTaskPushConfig(ctx, a2a.ListTaskPushConfigParams{}); !errors.Is(err, errUnimplemented) {
-		t.Errorf("OnListTaskPushConfig: expected unimplemented error, got %v", err)
+	if task.Status.State != a2a.TaskStateCanceled || len(task.Artifacts) != 1 {
+		t.Fatalf("OnCancelTask() = %+v, want canceled task with the late artifact", task)
 	}
-	if _, err := handler.OnSetTaskPushConfig(ctx, a2a.TaskPushConfig{}); !errors.Is(err, errUnimplemented) {
-		t.Errorf("OnSetTaskPushConfig: expected unimplemented error, got %v", err)
+	res := <-sent
+	if res.err != nil {
+		t.Fatalf("OnSendMessage() error = %v", res.err)
 	}
-	if err := handler.OnDeleteTaskPushConfig(ctx, a2a.DeleteTaskPushConfigParams{}); !errors.Is(err, errUnimplemented) {
-		t.Errorf("OnDeleteTaskPushConfig: expected unimplemented error, got %v", err)
+	if got := res.result.(*a2a.Task).Status.State; got != a2a.TaskStateCanceled {
+		t.Fatalf("OnSendMessage() state = %s, want %s", got, a2a.TaskStateCanceled)
+	}
+	if stored, _ := store.Get(ctx, taskID); stored.Status.State != a2a.TaskStateCanceled {
+		t.Fatalf("stored task state = %s, want %s", stored.Status.State, a2a.TaskStateCanceled)
+	}
+}
+
+func TestDefaultRequestHandler_OnCancelTask_RunningStream(t *testing.T) {
+	ctx := t.Context()
+	store := newLockedTaskStore()
+	started := make(chan a2a.TaskID, 1)
+	handler := NewHandler(lateWritingExecutor(started), WithTaskStore(store))
+
+	streamed := make(chan []a2a.Event, 1)
+	go func() {
+		var events []a2a.Event
+		for event, err := range handler.OnSendMessageStream(ctx, a2a.MessageSendParams{Message: *a2a.NewMessage(a2a.MessageRoleUser)}) {
+			if err != nil {
+				t.Errorf("OnSendMessageStream() error = %v", err)
+				break
+			}
+			events = append(events, event)
+		}
+		streamed <- events
+	}()
+	taskID := <-started
+
+	task, err := handler.OnCancelTask(ctx, a2a.TaskIDParams{ID: taskID})
+	if err != nil {
+		t.Fatalf("OnCancelTask() error = %v", err)
+	}
+	if task.Status.State != a2a.TaskStateCanceled || len(task.Artifacts) != 1 {
+		t.Fatalf("OnCancelTask() = %+v, want canceled task with the late artifact", task)
+	}
+	events := <-streamed
+	last, ok := events[len(events)-1].(*a2a.TaskStatusUpdateEvent)
+	if !ok || last.Status.State != a2a.TaskStateCanceled {
+		t.Fatalf("last streamed event = %v, want canceled status update", events[len(events)-1])
+	}
+}
+
+// versionedTaskStore is a VersionedTaskStore which can apply updates on behalf of another replica.
+type versionedTaskStore struct {
+	mu       sync.Mutex
+	tasks    map[a2a.TaskID]a2a.Task
+	versions map[a2a.TaskID]TaskVersion
+}
+
+var _ VersionedTaskStore = (*versionedTaskStore)(nil)
+
+func newVersionedTaskStore() *versionedTaskStore {
+	return &versionedTaskStore{tasks: make(map[a2a.TaskID]a2a.Task), versions: make(map[a2a.TaskID]TaskVersion)}
+}
+
+func (s *versionedTaskStore) Save(ctx context.Context, task a2a.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks[task.ID] = task
+	s.versions[task.ID]++
+	return nil
+}
+
+func (s *versionedTaskStore) Get(ctx context.Context, taskId a2a.TaskID) (a2a.Task, error) {
+	task, _, err := s.GetVersioned(ctx, taskId)
+	return task, err
+}
+
+func (s *versionedTaskStore) SaveVersioned(ctx context.Context, task a2a.Task, prev TaskVersion) (TaskVersion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.versions[task.ID] != prev {
+		return 0, ErrConcurrentModification
+	}
+	s.tasks[task.ID] = task
+	s.versions[task.ID]++
+	return s.versions[task.ID], nil
+}
+
+func (s *versionedTaskStore) GetVersioned(ctx context.Context, taskId a2a.TaskID) (a2a.Task, TaskVersion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, ok := s.tasks[taskId]
+	if !ok {
+		return a2a.Task{}, 0, a2a.ErrTaskNotFound
+	}
+	return task, s.versions[taskId], nil
+}
+
+// update applies fn to the stored Task as another replica would.
+func (s *versionedTaskStore) update(taskId a2a.TaskID, fn func(task *a2a.Task)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task := s.tasks[taskId]
+	task.Metadata = maps.Clone(task.Metadata)
+	fn(&task)
+	s.tasks[taskId] = task
+	s.versions[taskId]++
+}
+
+func TestDefaultRequestHandler_VersionedTaskStore(t *testing.T) {
+	tests := []struct {
+		name      string
+		replica   func(task *a2a.Task)
+		wantErr   error
+		wantState a2a.TaskState
+	}{
+		{
+			name:      "concurrent update is kept",
+			replica:   func(task *a2a.Task) { task.Metadata = map[string]any{"replica": "other"} },
+			wantState: a2a.TaskStateCompleted,
+		},
+		{
+			name:      "concurrently canceled task is not updated",
+			replica:   func(task *a2a.Task) { task.Status.State = a2a.TaskStateCanceled },
+			wantErr:   taskupdate.ErrTaskTerminal,
+			wantState: a2a.TaskStateCanceled,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			store := newVersionedTaskStore()
+			var createdID a2a.TaskID
+			executor := &mockAgentExecutor{
+				ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+					createdID = reqCtx.TaskID
+					// the Task created by the handler is updated by another replica before the agent writes events
+					store.update(reqCtx.TaskID, tc.replica)
+					task := &a2a.Task{ID: reqCtx.TaskID, ContextID: reqCtx.ContextID}
+					if err := q.Write(ctx, a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil)); err != nil {
+						return err
+					}
+					return q.Write(ctx, a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil))
+				},
+			}
+			handler := NewHandler(executor, WithTaskStore(store))
+
+			_, err := handler.OnSendMessage(ctx, a2a.MessageSendParams{Message: *a2a.NewMessage(a2a.MessageRoleUser)})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("OnSendMessage() error = %v, want %v", err, tc.wantErr)
+			}
+			stored, err := store.Get(ctx, createdID)
+			if err != nil {
+				t.Fatalf("store.Get() error = %v", err)
+			}
+			if stored.Status.State != tc.wantState {
+				t.Fatalf("stored task state = %s, want %s", stored.Status.State, tc.wantState)
+			}
+			if tc.wantErr == nil && stored.Metadata["replica"] != "other" {
+				t.Fatalf("stored task metadata = %v, want the concurrent update kept", stored.Metadata)
+			}
+		})
+	}
+}
+
+func TestDefaultRequestHandler_WithEventTap(t *testing.T) {
+	var buf bytes.Buffer
+	executor := &mockAgentExecutor{
+		ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+			return q.Write(ctx, &a2a.Message{TaskID: reqCtx.TaskID, ID: "response"})
+		},
+	}
+	handler := NewHandler(executor, WithEventTap(&buf))
+
+	params := a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID, ID: "request"}}
+	if _, err := handler.OnSendMessage(t.Context(), params); err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"direction":"write"`) || !strings.Contains(lines[1], `"direction":"read"`) {
+		t.Fatalf("tap output = %s, want a write and a read", buf.String())
+	}
+}
+
+// blockingTaskStore blocks saving Tasks until release is closed.
+type blockingTaskStore struct {
+	*countingTaskStore
+	release chan struct{}
+}
+
+func (s *blockingTaskStore) Save(ctx context.Context, task a2a.Task) error {
+	<-s.release
+	return s.countingTaskStore.Save(ctx, task)
+}
+
+func TestDefaultRequestHandler_WithQueueWriteDeadline(t *testing.T) {
+	store := &blockingTaskStore{countingTaskStore: newCountingTaskStore(), release: make(chan struct{})}
+	executor := &mockAgentExecutor{
+		ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+			task := &a2a.Task{ID: reqCtx.TaskID, ContextID: "test-context"}
+			for {
+				if err := q.Write(ctx, a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil)); err != nil {
+					close(store.release)
+					return err
+				}
+			}
+		},
+	}
+	handler := NewHandler(executor, WithTaskStore(store), WithQueueWriteDeadline(eventqueue.WriteDeadline{Timeout: 10 * time.Millisecond}))
+
+	_, err := handler.OnSendMessage(t.Context(), a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID}})
+	if !errors.Is(err, eventqueue.ErrSlowConsumer) {
+		t.Fatalf("OnSendMessage() error = %v, want %v", err, eventqueue.ErrSlowConsumer)
+	}
+}
+
+func TestDefaultRequestHandler_OnSendMessage_ManyEvents(t *testing.T) {
+	const eventCount = 5000
+	task := &a2a.Task{ID: taskID, ContextID: "test-context"}
+	events := []a2a.Event{task}
+	for i := range eventCount {
+		events = append(events, &a2a.TaskArtifactUpdateEvent{TaskID: taskID, ContextID: "test-context", Artifact: &a2a.Artifact{ID: "a", Parts: []a2a.Part{a2a.TextPart{Text: "chunk"}}}, Append: i > 0})
+	}
+	events = append(events, a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil))
+	handler := NewHandler(&mockAgentExecutor{ExecuteFunc: writeEvents(nil, events...)})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	result, err := handler.OnSendMessage(ctx, a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID}})
+	if err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	got, ok := result.(*a2a.Task)
+	if !ok || got.Status.State != a2a.TaskStateCompleted || len(got.Artifacts) != 1 || len(got.Artifacts[0].Parts) != eventCount {
+		t.Fatalf("OnSendMessage() = %v, want completed task with %d artifact parts", result, eventCount)
+	}
+}
+
+// liveQueueManager tracks the queues which were created and not destroyed.
+type liveQueueManager struct {
+	eventqueue.Manager
+	mu   sync.Mutex
+	live map[a2a.TaskID]bool
+}
+
+func (m *liveQueueManager) GetOrCreate(ctx context.Context, taskId a2a.TaskID) (eventqueue.Queue, error) {
+	m.mu.Lock()
+	m.live[taskId] = true
+	m.mu.Unlock()
+	return m.Manager.GetOrCreate(ctx, taskId)
+}
+
+func (m *liveQueueManager) Destroy(ctx context.Context, taskId a2a.TaskID) error {
+	m.mu.Lock()
+	delete(m.live, taskId)
+	m.mu.Unlock()
+	return m.Manager.Destroy(ctx, taskId)
+}
+
+func TestDefaultRequestHandler_OnSendMessage_DestroysQueue(t *testing.T) {
+	failingStore := newCountingTaskStore()
+	failingStore.saveErr = errors.New("store unavailable")
+	tests := []struct {
+		name    string
+		message a2a.Message
+		options []RequestHandlerOption
+	}{
+		{
+			name:    "execution error",
+			message: a2a.Message{ID: "msg", TaskID: taskID},
+		},
+		{
+			// the Task is created for a Message which doesn't reference one
+			name:    "task store error",
+			message: a2a.Message{ID: "msg", Role: a2a.MessageRoleUser},
+			options: []RequestHandlerOption{WithTaskStore(failingStore)},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			queueManager := &liveQueueManager{Manager: eventqueue.NewInMemoryManager(), live: make(map[a2a.TaskID]bool)}
+			executor := &mockAgentExecutor{ExecuteFunc: writeEvents(errors.New("agent crashed"), &a2a.Task{ID: taskID, ContextID: "test-context"})}
+			handler := NewHandler(executor, append(tc.options, WithEventQueueManager(queueManager))...)
+
+			if _, err := handler.OnSendMessage(t.Context(), a2a.MessageSendParams{Message: tc.message}); err == nil {
+				t.Fatal("OnSendMessage() error = nil, want error")
+			}
+			if len(queueManager.live) != 0 {
+				t.Fatalf("queues %v still exist after OnSendMessage() failed", queueManager.live)
+			}
+		})
+	}
+}
+
+func TestDefaultRequestHandler_DryRun(t *testing.T) {
+	ctx := t.Context()
+	mux := NewSkillMux(AgentCardProducerFn(func() *a2a.AgentCard { return &a2a.AgentCard{} }))
+	var calls []string
+	_ = mux.Handle(a2a.AgentSkill{ID: "flights", InputSchema: map[string]any{
+		"type":     "object",
+		"required": []string{"from"},
+	}}, namedExecutor("flights", &calls))
+	screener := inboundScreenerFn(func(ctx context.Context, msg *a2a.Message) error {
+		if data, ok := msg.Parts[0].(a2a.DataPart); ok && data.Data["from"] == "Area 51" {
+			return &ContentRejectedError{Reason: "restricted airspace"}
+		}
+		return nil
+	})
+	uploads := NewUploadStore(t.TempDir(), 1024)
+	handler := NewHandler(mux, WithUploads(uploads), WithExecutorMiddleware(ModerationMiddleware(screener, nil)))
+
+	dryRun := func(from string, parts ...a2a.Part) (a2a.SendMessageResult, error) {
+		msg := a2a.Message{ID: "msg", TaskID: taskID, ContextID: "ctx", Metadata: map[string]any{SkillIDMetaKey: "flights"}}
+		input := map[string]any{}
+		if from != "" {
+			input["from"] = from
+		}
+		msg.Parts = append(a2a.ContentParts{a2a.DataPart{Data: input}}, parts...)
+		return handler.OnSendMessage(ctx, a2a.WithDryRun(a2a.MessageSendParams{Message: msg}))
+	}
+
+	result, err := dryRun("JFK")
+	task, ok := result.(*a2a.Task)
+	if err != nil || !ok {
+		t.Fatalf("OnSendMessage() = %v, %v, want Task", result, err)
+	}
+	if task.ID != taskID || task.ContextID != "ctx" || task.Status.State != a2a.TaskStateSubmitted || len(task.History) != 1 || !a2a.IsDryRunResult(task) {
+		t.Fatalf("OnSendMessage() = %+v, want submitted dry run Task with the message in history", task)
+	}
+
+	if _, err := dryRun(""); !errors.Is(err, a2a.ErrInvalidSkillInput) {
+		t.Fatalf("OnSendMessage() with invalid input error = %v, want %v", err, a2a.ErrInvalidSkillInput)
+	}
+	if _, err := dryRun("JFK", a2a.FilePart{File: a2a.FileURI{URI: a2a.UploadURI("missing")}}); !errors.Is(err, ErrUploadNotFound) {
+		t.Fatalf("OnSendMessage() with missing upload error = %v, want %v", err, ErrUploadNotFound)
+	}
+
+	result, err = dryRun("Area 51")
+	if task, ok := result.(*a2a.Task); err != nil || !ok || task.Status.State != a2a.TaskStateRejected || !a2a.IsDryRunResult(task) {
+		t.Fatalf("OnSendMessage() of vetoed message = %v, %v, want rejected dry run Task", result, err)
+	}
+
+	if len(calls) != 0 {
+		t.Fatalf("executor calls = %v, want none for dry runs", calls)
 	}
 }
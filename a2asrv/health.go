@@ -0,0 +1,221 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/a2aproject/a2a-go/a2apb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// HealthStatus is the state of a server or one of its components.
+type HealthStatus string
+
+const (
+	// HealthServing means the component works normally.
+	HealthServing HealthStatus = "serving"
+	// HealthDegraded means the component works, but is falling behind. The server keeps accepting traffic.
+	HealthDegraded HealthStatus = "degraded"
+	// HealthNotServing means the component is unreachable. The server should not receive traffic.
+	HealthNotServing HealthStatus = "not_serving"
+)
+
+// HealthChecker is an optional interface of TaskStore, eventqueue.Manager and PushNotifier implementations
+// backed by external services which can verify the service is reachable.
+type HealthChecker interface {
+	// CheckHealth returns an error if the backing service can't be reached.
+	CheckHealth(ctx context.Context) error
+}
+
+// BacklogReporter is an optional interface of PushNotifier and PushOutbox implementations which
+// deliver notifications asynchronously.
+type BacklogReporter interface {
+	// Backlog returns the number of notifications waiting to be delivered.
+	Backlog(ctx context.Context) (int, error)
+}
+
+// ComponentHealth is the state of a single server component.
+type ComponentHealth struct {
+	// Name identifies the component, for example "taskStore".
+	Name string `json:"name"`
+	// Status is the component state.
+	Status HealthStatus `json:"status"`
+	// Error describes the reason the component is not serving.
+	Error string `json:"error,omitempty"`
+	// Backlog is reported by components implementing BacklogReporter.
+	Backlog *int `json:"backlog,omitempty"`
+}
+
+// HealthReport aggregates the states of server components. Status is the worst of the component states.
+type HealthReport struct {
+	Status     HealthStatus      `json:"status"`
+	Components []ComponentHealth `json:"components,omitempty"`
+}
+
+// Ready reports whether the server should receive traffic.
+func (r HealthReport) Ready() bool {
+	return r.Status != HealthNotServing
+}
+
+// HealthReporter is an optional interface of RequestHandler implementations which can report
+// the state of the components they depend on.
+type HealthReporter interface {
+	// Health checks the components and returns the aggregated report.
+	Health(ctx context.Context) HealthReport
+}
+
+// Health returns the report of the handler if it implements HealthReporter.
+// Handlers which don't report their health are considered serving.
+func Health(ctx context.Context, handler RequestHandler) HealthReport {
+	if reporter, ok := handler.(HealthReporter); ok {
+		return reporter.Health(ctx)
+	}
+	return HealthReport{Status: HealthServing}
+}
+
+// WithMaxPushBacklog makes the handler report HealthDegraded when more than limit push notifications
+// are waiting to be delivered. The backlog is read from PushNotifier or TaskStore implementing BacklogReporter.
+func WithMaxPushBacklog(limit int) RequestHandlerOption {
+	return func(h *defaultRequestHandler) {
+		h.maxPushBacklog = limit
+	}
+}
+
+// Health returns HealthReport for the TaskStore, the event queue backend and the PushNotifier.
+func (h *defaultRequestHandler) Health(ctx context.Context) HealthReport {
+	report := HealthReport{Status: HealthServing}
+	add := func(name string, component any) {
+		if component == nil {
+			return
+		}
+		checker, isChecker := component.(HealthChecker)
+		backlogReporter, isBacklogReporter := component.(BacklogReporter)
+		if !isChecker && !isBacklogReporter {
+			return
+		}
+
+		health := ComponentHealth{Name: name, Status: HealthServing}
+		if isChecker {
+			if err := checker.CheckHealth(ctx); err != nil {
+				health.Status, health.Error = HealthNotServing, err.Error()
+			}
+		}
+		if isBacklogReporter && health.Status == HealthServing {
+			backlog, err := backlogReporter.Backlog(ctx)
+			if err != nil {
+				health.Status, health.Error = HealthNotServing, err.Error()
+			} else {
+				health.Backlog = &backlog
+				if h.maxPushBacklog > 0 && backlog > h.maxPushBacklog {
+					health.Status = HealthDegraded
+				}
+			}
+		}
+		report.Components = append(report.Components, health)
+		report.Status = worseHealth(report.Status, health.Status)
+	}
+
+	if h.taskStore != nil {
+		add("taskStore", h.taskStore)
+	}
+	add("queueManager", h.queueBackend)
+	if h.pushNotifier != nil {
+		add("pushNotifier", h.pushNotifier)
+	}
+	return report
+}
+
+func worseHealth(a, b HealthStatus) HealthStatus {
+	rank := func(s HealthStatus) int {
+		switch s {
+		case HealthServing:
+			return 0
+		case HealthDegraded:
+			return 1
+		default:
+			return 2
+		}
+	}
+	if rank(b) > rank(a) {
+		return b
+	}
+	return a
+}
+
+// NewHealthMux creates an http.Handler with health endpoints for orchestrators:
+//   - /healthz responds with 200 while the process is able to serve HTTP requests,
+//   - /readyz responds with the HealthReport of the handler and 503 status if it is not ready.
+func NewHealthMux(handler RequestHandler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, req *http.Request) {
+		report := Health(req.Context(), handler)
+		w.Header().Set("Content-Type", "application/json")
+		if !report.Ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	})
+	return mux
+}
+
+// NewGRPCHealthServer creates a grpc.health.v1.Health service implementation reporting the HealthReport of
+// the handler. The overall state is reported for the empty service name and for the A2A service, the states
+// of individual components are reported under their names. Watch is not supported.
+func NewGRPCHealthServer(handler RequestHandler) grpc_health_v1.HealthServer {
+	return &grpcHealthServer{handler: handler}
+}
+
+type grpcHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	handler RequestHandler
+}
+
+func (s *grpcHealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	statuses := grpcHealthStatuses(Health(ctx, s.handler))
+	if st, ok := statuses[req.GetService()]; ok {
+		return st, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+}
+
+func (s *grpcHealthServer) List(ctx context.Context, req *grpc_health_v1.HealthListRequest) (*grpc_health_v1.HealthListResponse, error) {
+	return &grpc_health_v1.HealthListResponse{Statuses: grpcHealthStatuses(Health(ctx, s.handler))}, nil
+}
+
+func grpcHealthStatuses(report HealthReport) map[string]*grpc_health_v1.HealthCheckResponse {
+	toGRPC := func(ready bool) *grpc_health_v1.HealthCheckResponse {
+		if ready {
+			return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}
+		}
+		return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}
+	}
+	statuses := map[string]*grpc_health_v1.HealthCheckResponse{
+		"":                                       toGRPC(report.Ready()),
+		a2apb.A2AService_ServiceDesc.ServiceName: toGRPC(report.Ready()),
+	}
+	for _, component := range report.Components {
+		statuses[component.Name] = toGRPC(component.Status != HealthNotServing)
+	}
+	return statuses
+}
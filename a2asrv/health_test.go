@@ -0,0 +1,149 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2apb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type healthTaskStore struct {
+	testTaskStore
+	err error
+}
+
+func (s *healthTaskStore) CheckHealth(ctx context.Context) error {
+	return s.err
+}
+
+type backlogPushNotifier struct {
+	testPushNotifier
+	backlog int
+}
+
+func (n *backlogPushNotifier) Backlog(ctx context.Context) (int, error) {
+	return n.backlog, nil
+}
+
+func TestHealth(t *testing.T) {
+	testCases := []struct {
+		name     string
+		storeErr error
+		backlog  int
+		want     HealthStatus
+	}{
+		{name: "serving", backlog: 10, want: HealthServing},
+		{name: "push backlog", backlog: 11, want: HealthDegraded},
+		{name: "store unreachable", storeErr: errors.New("connection refused"), backlog: 11, want: HealthNotServing},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(
+				&mockAgentExecutor{},
+				WithTaskStore(&healthTaskStore{err: tc.storeErr}),
+				WithPushNotifier(&backlogPushNotifier{backlog: tc.backlog}),
+				WithMaxPushBacklog(10),
+				WithEventTap(io.Discard),
+			)
+			report := Health(t.Context(), handler)
+			if report.Status != tc.want {
+				t.Fatalf("Health() status = %q, want %q, report = %+v", report.Status, tc.want, report)
+			}
+			if len(report.Components) != 2 || report.Components[0].Name != "taskStore" || report.Components[1].Name != "pushNotifier" {
+				t.Fatalf("Health() components = %+v, want taskStore and pushNotifier", report.Components)
+			}
+			if got := report.Components[1].Backlog; got == nil || *got != tc.backlog {
+				t.Fatalf("Health() backlog = %v, want %d", got, tc.backlog)
+			}
+		})
+	}
+}
+
+func TestHealth_NotReporting(t *testing.T) {
+	report := Health(t.Context(), NewLoggingHandler(NewHandler(&mockAgentExecutor{}), nil))
+	if report.Status != HealthServing || !report.Ready() {
+		t.Fatalf("Health() = %+v, want serving", report)
+	}
+}
+
+func TestNewHealthMux(t *testing.T) {
+	store := &healthTaskStore{}
+	mux := NewHealthMux(NewHandler(&mockAgentExecutor{}, WithTaskStore(store)))
+
+	for _, tc := range []struct {
+		path     string
+		storeErr error
+		want     int
+	}{
+		{path: "/healthz", storeErr: errors.New("down"), want: http.StatusOK},
+		{path: "/readyz", want: http.StatusOK},
+		{path: "/readyz", storeErr: errors.New("down"), want: http.StatusServiceUnavailable},
+	} {
+		store.err = tc.storeErr
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if rec.Code != tc.want {
+			t.Fatalf("GET %s with store error %v status = %d, want %d", tc.path, tc.storeErr, rec.Code, tc.want)
+		}
+		if tc.path != "/readyz" {
+			continue
+		}
+		var report HealthReport
+		if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+			t.Fatalf("failed to decode report: %v", err)
+		}
+		if report.Ready() != (tc.storeErr == nil) {
+			t.Fatalf("GET %s report = %+v, want ready = %v", tc.path, report, tc.storeErr == nil)
+		}
+	}
+}
+
+func TestGRPCHealthServer(t *testing.T) {
+	ctx := t.Context()
+	store := &healthTaskStore{err: errors.New("down")}
+	server := NewGRPCHealthServer(NewHandler(&mockAgentExecutor{}, WithTaskStore(store)))
+
+	for _, service := range []string{"", a2apb.A2AService_ServiceDesc.ServiceName, "taskStore"} {
+		resp, err := server.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%q) error = %v", service, err)
+		}
+		if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+			t.Fatalf("Check(%q) = %v, want NOT_SERVING", service, resp.GetStatus())
+		}
+	}
+	if _, err := server.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "unknown"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("Check(unknown) error = %v, want NotFound", err)
+	}
+
+	store.err = nil
+	list, err := server.List(ctx, &grpc_health_v1.HealthListRequest{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list.GetStatuses()) != 3 || list.GetStatuses()[""].GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Fatalf("List() = %v, want 3 serving statuses", list.GetStatuses())
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonrpc exposes an a2asrv.RequestHandler over HTTP using the JSON-RPC 2.0 binding of the A2A protocol.
+// Requests are POSTed to a single endpoint. Streaming methods respond with a text/event-stream where every event
+// carries a JSON-RPC response object. A stream ends after the last event or after an event carrying an error,
+// and comments are sent while the agent is idle to keep the connection open:
+//
+//	handler := a2asrv.NewHandler(executor, a2asrv.WithTaskStore(store))
+//	http.Handle("/", jsonrpc.NewHandler(handler, jsonrpc.Config{}))
+//	http.Handle(a2asrv.WellKnownAgentCardPath, a2asrv.NewAgentCardHandler(cardProducer))
+//
+// Errors returned by the RequestHandler are reported with the A2A error codes when they match one of
+// the a2a package errors. A handler can return an *Error to control the reported code directly.
+package jsonrpc
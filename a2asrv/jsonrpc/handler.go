@@ -0,0 +1,416 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// DefaultMaxRequestSize is the default limit of a request body size.
+const DefaultMaxRequestSize = 10 << 20
+
+// Config configures NewHandler.
+type Config struct {
+	// MaxRequestSize limits the size of a request body. DefaultMaxRequestSize is used if 0.
+	MaxRequestSize int64
+	// KeepAliveInterval is the interval of comments sent on event streams while the agent is not producing
+	// events, so that proxies don't close the connection as idle. DefaultKeepAliveInterval is used if 0
+	// and keep-alive comments are disabled if it's negative.
+	KeepAliveInterval time.Duration
+	// PayloadObserver is called after every request is served with the number of bytes read and written.
+	// It can be used for bandwidth budgeting and detection of pathological payloads.
+	PayloadObserver func(ctx context.Context, size PayloadSize)
+	// StreamWindow enables credit-based flow control of event streams. It is the maximum number of
+	// events AgentExecutor can produce ahead of the client. A credit is granted back every time an event
+	// is flushed to the client, so memory use is bounded for fast agents streaming to slow clients.
+	// Flow control is disabled if 0.
+	StreamWindow int
+}
+
+// PayloadSize holds the number of bytes transferred by a served request.
+type PayloadSize struct {
+	// Method is the requested method. It is empty if the request couldn't be parsed.
+	Method string
+	// RequestBytes is the size of the request body.
+	RequestBytes int64
+	// ResponseBytes is the size of the response body. For streaming methods it is the total size
+	// of the event stream, including keep-alive comments.
+	ResponseBytes int64
+	// Events is the number of events written to the event stream by a streaming method.
+	Events int
+}
+
+// countingWriter counts the number of bytes written to the response body.
+type countingWriter struct {
+	http.ResponseWriter
+	mu sync.Mutex
+	n  int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.mu.Lock()
+	w.n += int64(n)
+	w.mu.Unlock()
+	return n, err
+}
+
+func (w *countingWriter) written() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.n
+}
+
+// Unwrap allows http.ResponseController to access the flusher of the original writer.
+func (w *countingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// jsonNull is used as the ID of responses to malformed requests and as the result of methods which
+// don't return anything, because the result member is required in a successful response.
+var jsonNull = json.RawMessage("null")
+
+type handler struct {
+	handler a2asrv.RequestHandler
+	config  Config
+}
+
+// NewHandler creates an http.Handler which serves A2A protocol methods implemented by the RequestHandler
+// using the JSON-RPC binding. Responses always have 200 status, including the ones reporting errors.
+// Requests without an ID are handled as notifications and get an empty response with 204 status.
+// Batch requests are not supported.
+func NewHandler(requestHandler a2asrv.RequestHandler, config Config) http.Handler {
+	if config.MaxRequestSize <= 0 {
+		config.MaxRequestSize = DefaultMaxRequestSize
+	}
+	if config.KeepAliveInterval == 0 {
+		config.KeepAliveInterval = DefaultKeepAliveInterval
+	}
+	return &handler{handler: requestHandler, config: config}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, httpReq *http.Request) {
+	if httpReq.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if h.config.PayloadObserver == nil {
+		h.serve(w, httpReq, &PayloadSize{})
+		return
+	}
+	counter := &countingWriter{ResponseWriter: w}
+	var size PayloadSize
+	h.serve(counter, httpReq, &size)
+	size.ResponseBytes = counter.written()
+	h.config.PayloadObserver(httpReq.Context(), size)
+}
+
+// serve handles the request and records its method, the size of its body and the number
+// of streamed events in size.
+func (h *handler) serve(w http.ResponseWriter, httpReq *http.Request, size *PayloadSize) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, httpReq.Body, h.config.MaxRequestSize))
+	size.RequestBytes = int64(len(body))
+	if err != nil {
+		writeResponse(w, nil, nil, &Error{Code: CodeInvalidRequest, Message: fmt.Sprintf("failed to read request: %v", err)})
+		return
+	}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		writeResponse(w, nil, nil, &Error{Code: CodeInvalidRequest, Message: "batch requests are not supported"})
+		return
+	}
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeResponse(w, nil, nil, &Error{Code: CodeParseError, Message: fmt.Sprintf("failed to parse request: %v", err)})
+		return
+	}
+	size.Method = req.Method
+	if err := validateRequest(req); err != nil {
+		writeResponse(w, nil, nil, err)
+		return
+	}
+
+	ctx := httpReq.Context()
+	if req.Method == MethodSendStreamingMessage || req.Method == MethodResubscribeToTask {
+		var window *eventqueue.CreditWindow
+		if h.config.StreamWindow > 0 {
+			window = eventqueue.NewCreditWindow(h.config.StreamWindow)
+			defer window.Close()
+			ctx = a2asrv.WithCreditWindow(ctx, window)
+		}
+		encoded := &a2asrv.EncodedEvents{}
+		ctx = a2asrv.WithEncodedEvents(ctx, encoded)
+		events, err := h.stream(ctx, req.Method, req.Params)
+		if err != nil {
+			writeResponse(w, req.ID, nil, ToError(err))
+			return
+		}
+		size.Events = h.writeEvents(w, req.ID, events, window, encoded)
+		return
+	}
+
+	result, err := h.call(ctx, req.Method, req.Params)
+	if err == nil {
+		result, err = encodeResult(result)
+	}
+	if len(req.ID) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		writeResponse(w, req.ID, nil, ToError(err))
+		return
+	}
+	writeResponse(w, req.ID, result, nil)
+}
+
+func validateRequest(req request) *Error {
+	if req.JSONRPC != Version {
+		return &Error{Code: CodeInvalidRequest, Message: fmt.Sprintf("unsupported jsonrpc version %q", req.JSONRPC)}
+	}
+	if req.Method == "" {
+		return &Error{Code: CodeInvalidRequest, Message: "method is missing"}
+	}
+	if len(req.ID) > 0 {
+		var id any
+		_ = json.Unmarshal(req.ID, &id)
+		switch id.(type) {
+		case string, float64, nil:
+		default:
+			return &Error{Code: CodeInvalidRequest, Message: "id must be a string, a number or null"}
+		}
+	}
+	return nil
+}
+
+// call invokes the RequestHandler method for the non-streaming protocol method.
+func (h *handler) call(ctx context.Context, method string, params json.RawMessage) (any, error) {
+	switch method {
+	case MethodSendMessage:
+		return invoke(ctx, params, h.handler.OnSendMessage)
+	case MethodGetTask:
+		return invoke(ctx, params, h.handler.OnGetTask)
+	case MethodCancelTask:
+		return invoke(ctx, params, h.handler.OnCancelTask)
+	case MethodSetTaskPushConfig:
+		return invoke(ctx, params, h.handler.OnSetTaskPushConfig)
+	case MethodGetTaskPushConfig:
+		return invoke(ctx, params, h.handler.OnGetTaskPushConfig)
+	case MethodListTaskPushConfig:
+		return invoke(ctx, params, h.listTaskPushConfig)
+	case MethodDeleteTaskPushConfig:
+		return invoke(ctx, params, func(ctx context.Context, params a2a.DeleteTaskPushConfigParams) (any, error) {
+			return jsonNull, h.handler.OnDeleteTaskPushConfig(ctx, params)
+		})
+	case MethodGetAuthenticatedExtendedCard:
+		// the method has no params, so they are not decoded
+		extended, ok := h.handler.(a2asrv.ExtendedCardHandler)
+		if !ok {
+			return nil, a2a.ErrAuthenticatedExtendedCardNotConfigured
+		}
+		return extended.OnGetExtendedAgentCard(ctx)
+	default:
+		return nil, &Error{Code: CodeMethodNotFound, Message: fmt.Sprintf("method %q not found", method)}
+	}
+}
+
+// listTaskPushConfig returns all configurations of the Task as an array, the result defined by the
+// protocol, collecting them page by page. A page is returned as a ListTaskPushConfigResult only if
+// the client asked for one with pageSize or pageToken.
+func (h *handler) listTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) (any, error) {
+	if params.PageSize != 0 || params.PageToken != "" {
+		return h.handler.OnListTaskPushConfig(ctx, params)
+	}
+	configs := []a2a.TaskPushConfig{}
+	for {
+		result, err := h.handler.OnListTaskPushConfig(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, result.Configs...)
+		if result.NextPageToken == "" {
+			return configs, nil
+		}
+		params.PageToken = result.NextPageToken
+	}
+}
+
+// stream invokes the RequestHandler method for the streaming protocol method. An error is returned
+// if the handler doesn't support streaming.
+func (h *handler) stream(ctx context.Context, method string, raw json.RawMessage) (iter.Seq2[a2a.Event, error], error) {
+	var events iter.Seq2[a2a.Event, error]
+	switch method {
+	case MethodSendStreamingMessage:
+		params, err := decodeParams[a2a.MessageSendParams](raw)
+		if err != nil {
+			return nil, err
+		}
+		events = h.handler.OnSendMessageStream(ctx, params)
+	case MethodResubscribeToTask:
+		params, err := decodeParams[a2a.TaskIDParams](raw)
+		if err != nil {
+			return nil, err
+		}
+		events = h.handler.OnResubscribeToTask(ctx, params)
+	}
+	if events == nil {
+		return nil, fmt.Errorf("%s: %w", method, a2a.ErrUnsupportedOperation)
+	}
+	return events, nil
+}
+
+func invoke[P, R any](ctx context.Context, raw json.RawMessage, method func(context.Context, P) (R, error)) (any, error) {
+	params, err := decodeParams[P](raw)
+	if err != nil {
+		return nil, err
+	}
+	return method(ctx, params)
+}
+
+func decodeParams[P any](raw json.RawMessage) (P, error) {
+	var params P
+	if len(raw) == 0 || bytes.Equal(raw, jsonNull) {
+		return params, &Error{Code: CodeInvalidParams, Message: "params are missing"}
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return params, &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("failed to decode params: %v", err)}
+	}
+	return params, nil
+}
+
+// encodeResult encodes protocol objects with the "kind" discriminator. Other results are encoded as is.
+func encodeResult(result any) (any, error) {
+	var event a2a.Event
+	switch v := result.(type) {
+	case a2a.Task:
+		event = &v
+	case a2a.Event:
+		event = v
+	default:
+		return result, nil
+	}
+	data, err := a2a.MarshalEvent(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result: %w", err)
+	}
+	return json.RawMessage(data), nil
+}
+
+func writeResponse(w http.ResponseWriter, id json.RawMessage, result any, rpcErr *Error) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(newResponse(id, result, rpcErr))
+}
+
+// writeEvents writes every event as a JSON-RPC response in a server-sent event. The stream ends
+// with an error response if the iterator fails. Iteration stops if the client disconnects.
+// The number of written events is returned. A credit is granted to window, if not nil, for every
+// event flushed to the client. Events found in encoded are written using their shared encoding,
+// instead of encoding them for every client.
+func (h *handler) writeEvents(w http.ResponseWriter, id json.RawMessage, events iter.Seq2[a2a.Event, error], window *eventqueue.CreditWindow, encoded *a2asrv.EncodedEvents) int {
+	sse := newSSEWriter(w)
+	stop := sse.keepAlive(h.config.KeepAliveInterval)
+	defer stop()
+
+	prefix, prefixErr := resultPrefix(id)
+	var buf []byte
+	count := 0
+	for event, err := range events {
+		var shared []byte
+		if err == nil && prefixErr == nil && encoded != nil {
+			shared, _ = encoded.Lookup(event)
+		}
+		var data []byte
+		var resp response
+		if shared != nil {
+			buf = append(append(append(buf[:0], prefix...), shared...), '}')
+			data = buf
+		} else {
+			var result any
+			if err == nil {
+				result, err = encodeResult(event)
+			}
+			if err != nil {
+				resp = newResponse(id, nil, ToError(err))
+			} else {
+				resp = newResponse(id, result, nil)
+			}
+			if data, err = json.Marshal(resp); err != nil {
+				resp = newResponse(id, nil, ToError(fmt.Errorf("failed to encode response: %w", err)))
+				data, _ = json.Marshal(resp)
+			}
+		}
+		if err := sse.writeData(data); err != nil {
+			return count
+		}
+		if resp.Error != nil {
+			return count
+		}
+		if window != nil {
+			window.Grant(1)
+		}
+		count++
+	}
+	return count
+}
+
+// resultPrefix returns the encoding of a successful response with the ID up to the result, so that
+// the response for an already encoded result is the prefix, the result and the closing brace.
+func resultPrefix(id json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(newResponse(id, jsonNull, nil))
+	if err != nil {
+		return nil, err
+	}
+	prefix, ok := bytes.CutSuffix(data, []byte("null}"))
+	if !ok {
+		return nil, fmt.Errorf("unexpected response encoding: %s", data)
+	}
+	return prefix, nil
+}
+
+func newResponse(id json.RawMessage, result any, rpcErr *Error) response {
+	if len(id) == 0 {
+		id = jsonNull
+	}
+	if rpcErr == nil && result == nil {
+		result = jsonNull
+	}
+	return response{JSONRPC: Version, ID: id, Result: result, Error: rpcErr}
+}
@@ -0,0 +1,449 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// fakeHandler is a RequestHandler serving tasks from a map. Streaming methods are only
+// supported if events is set.
+type fakeHandler struct {
+	a2asrv.RequestHandler
+	tasks   map[a2a.TaskID]a2a.Task
+	events  []a2a.Event
+	deleted []a2a.DeleteTaskPushConfigParams
+	configs []a2a.TaskPushConfig
+}
+
+func (h *fakeHandler) OnGetTask(ctx context.Context, query a2a.TaskQueryParams) (a2a.Task, error) {
+	task, ok := h.tasks[query.ID]
+	if !ok {
+		return a2a.Task{}, a2a.ErrTaskNotFound
+	}
+	return task, nil
+}
+
+func (h *fakeHandler) OnCancelTask(ctx context.Context, id a2a.TaskIDParams) (a2a.Task, error) {
+	return a2a.Task{}, fmt.Errorf("task %s is completed: %w", id.ID, a2a.ErrTaskNotCancelable)
+}
+
+func (h *fakeHandler) OnSendMessage(ctx context.Context, params a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	if len(params.Message.Parts) == 0 {
+		return nil, &Error{Code: CodeContentTypeNotSupported, Message: "empty message", Data: map[string]any{"parts": 0}}
+	}
+	return a2a.NewMessage(a2a.MessageRoleAgent, params.Message.Parts...), nil
+}
+
+func (h *fakeHandler) OnSendMessageStream(ctx context.Context, params a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	if h.events == nil {
+		return nil
+	}
+	return func(yield func(a2a.Event, error) bool) {
+		for _, event := range h.events {
+			if !yield(event, nil) {
+				return
+			}
+		}
+		yield(nil, errors.New("agent crashed"))
+	}
+}
+
+func (h *fakeHandler) OnDeleteTaskPushConfig(ctx context.Context, params a2a.DeleteTaskPushConfigParams) error {
+	h.deleted = append(h.deleted, params)
+	return nil
+}
+
+func (h *fakeHandler) OnListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) (*a2a.ListTaskPushConfigResult, error) {
+	if h.configs == nil {
+		return nil, a2a.ErrPushNotificationNotSupported
+	}
+	page, next, err := a2asrv.Paginate(h.configs, params.PageSize, params.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	return &a2a.ListTaskPushConfigResult{Configs: page, NextPageToken: next}, nil
+}
+
+func post(t *testing.T, server *httptest.Server, body string) *http.Response {
+	t.Helper()
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("http.Post() error = %v", err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func decodeResponse(t *testing.T, resp *http.Response) map[string]any {
+	t.Helper()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d, want 200", resp.StatusCode)
+	}
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return body
+}
+
+func TestHandler_Methods(t *testing.T) {
+	fake := &fakeHandler{tasks: map[a2a.TaskID]a2a.Task{
+		"task-1": {ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}},
+	}}
+	server := httptest.NewServer(NewHandler(fake, Config{}))
+	defer server.Close()
+
+	testCases := []struct {
+		name string
+		body string
+		want map[string]any
+	}{
+		{
+			name: "tasks/get",
+			body: `{"jsonrpc":"2.0","id":1,"method":"tasks/get","params":{"id":"task-1"}}`,
+			want: map[string]any{"jsonrpc": "2.0", "id": 1.0, "result": map[string]any{
+				"kind": "task", "id": "task-1", "contextId": "ctx-1", "status": map[string]any{"state": "completed"},
+			}},
+		},
+		{
+			name: "message/send",
+			body: `{"jsonrpc":"2.0","id":"req-1","method":"message/send","params":{"message":{"kind":"message","messageId":"m1","role":"user","parts":[{"kind":"text","text":"hi"}]}}}`,
+		},
+		{
+			name: "delete push config",
+			body: `{"jsonrpc":"2.0","id":2,"method":"tasks/pushNotificationConfig/delete","params":{"id":"task-1","pushNotificationConfigId":"cfg"}}`,
+			want: map[string]any{"jsonrpc": "2.0", "id": 2.0, "result": nil},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := decodeResponse(t, post(t, server, tc.body))
+			if tc.want == nil {
+				result, ok := got["result"].(map[string]any)
+				if !ok || result["kind"] != "message" || result["role"] != "agent" {
+					t.Fatalf("got response %v, want agent message result", got)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got response %v, want %v", got, tc.want)
+			}
+		})
+	}
+	if len(fake.deleted) != 1 || fake.deleted[0].ConfigID != "cfg" {
+		t.Fatalf("OnDeleteTaskPushConfig() calls = %v, want one call for cfg", fake.deleted)
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	server := httptest.NewServer(NewHandler(&fakeHandler{}, Config{MaxRequestSize: 1024}))
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		body     string
+		wantCode int
+		wantID   any
+	}{
+		{name: "parse error", body: `{"jsonrpc":`, wantCode: CodeParseError},
+		{name: "batch", body: `[{"jsonrpc":"2.0","id":1,"method":"tasks/get"}]`, wantCode: CodeInvalidRequest},
+		{name: "wrong version", body: `{"jsonrpc":"1.0","id":1,"method":"tasks/get"}`, wantCode: CodeInvalidRequest},
+		{name: "object id", body: `{"jsonrpc":"2.0","id":{},"method":"tasks/get"}`, wantCode: CodeInvalidRequest},
+		{name: "too large", body: `{"jsonrpc":"2.0","id":1,"method":"` + strings.Repeat("x", 2048) + `"}`, wantCode: CodeInvalidRequest},
+		{name: "unknown method", body: `{"jsonrpc":"2.0","id":1,"method":"tasks/list"}`, wantCode: CodeMethodNotFound, wantID: 1.0},
+		{name: "missing params", body: `{"jsonrpc":"2.0","id":1,"method":"tasks/get"}`, wantCode: CodeInvalidParams, wantID: 1.0},
+		{name: "malformed params", body: `{"jsonrpc":"2.0","id":1,"method":"tasks/get","params":[]}`, wantCode: CodeInvalidParams, wantID: 1.0},
+		{name: "task not found", body: `{"jsonrpc":"2.0","id":1,"method":"tasks/get","params":{"id":"unknown"}}`, wantCode: CodeTaskNotFound, wantID: 1.0},
+		{name: "not cancelable", body: `{"jsonrpc":"2.0","id":1,"method":"tasks/cancel","params":{"id":"t"}}`, wantCode: CodeTaskNotCancelable, wantID: 1.0},
+		{name: "push not supported", body: `{"jsonrpc":"2.0","id":1,"method":"tasks/pushNotificationConfig/list","params":{"id":"t"}}`, wantCode: CodePushNotificationNotSupported, wantID: 1.0},
+		{name: "streaming not supported", body: `{"jsonrpc":"2.0","id":1,"method":"message/stream","params":{"message":{"kind":"message","messageId":"m1","role":"user","parts":[]}}}`, wantCode: CodeUnsupportedOperation, wantID: 1.0},
+		{name: "extended card not configured", body: `{"jsonrpc":"2.0","id":1,"method":"agent/getAuthenticatedExtendedCard"}`, wantCode: CodeAuthenticatedExtendedCardNotConfigured, wantID: 1.0},
+		{name: "handler error", body: `{"jsonrpc":"2.0","id":1,"method":"message/send","params":{"message":{"kind":"message","messageId":"m1","role":"user","parts":[]}}}`, wantCode: CodeContentTypeNotSupported, wantID: 1.0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := decodeResponse(t, post(t, server, tc.body))
+			rpcErr, ok := got["error"].(map[string]any)
+			if !ok || rpcErr["code"] != float64(tc.wantCode) {
+				t.Fatalf("got response %v, want error code %d", got, tc.wantCode)
+			}
+			if _, ok := got["result"]; ok {
+				t.Fatalf("got response %v with both error and result", got)
+			}
+			if got["id"] != tc.wantID {
+				t.Fatalf("got response id %v, want %v", got["id"], tc.wantID)
+			}
+		})
+	}
+}
+
+func TestHandler_ListTaskPushConfig(t *testing.T) {
+	fake := &fakeHandler{configs: []a2a.TaskPushConfig{}}
+	for i := range a2a.DefaultPageSize + 10 {
+		fake.configs = append(fake.configs, a2a.TaskPushConfig{TaskID: "task-1", Config: a2a.PushConfig{ID: fmt.Sprint("cfg-", i), URL: "https://client.com/push"}})
+	}
+	server := httptest.NewServer(NewHandler(fake, Config{}))
+	defer server.Close()
+
+	// without pagination params the result is an array of all configs
+	got := decodeResponse(t, post(t, server, `{"jsonrpc":"2.0","id":1,"method":"tasks/pushNotificationConfig/list","params":{"id":"task-1"}}`))
+	configs, ok := got["result"].([]any)
+	if !ok || len(configs) != len(fake.configs) {
+		t.Fatalf("got response %v, want an array of %d configs", got, len(fake.configs))
+	}
+	if config, ok := configs[0].(map[string]any); !ok || config["taskId"] != "task-1" {
+		t.Fatalf("got config %v, want a config of task-1", configs[0])
+	}
+
+	got = decodeResponse(t, post(t, server, `{"jsonrpc":"2.0","id":2,"method":"tasks/pushNotificationConfig/list","params":{"id":"task-1","pageSize":10}}`))
+	page, ok := got["result"].(map[string]any)
+	if !ok {
+		t.Fatalf("got response %v, want a page of configs", got)
+	}
+	if configs, _ := page["configs"].([]any); len(configs) != 10 || page["nextPageToken"] == nil {
+		t.Fatalf("got page %v, want 10 configs and the next page token", page)
+	}
+
+	empty := &fakeHandler{configs: []a2a.TaskPushConfig{}}
+	emptyServer := httptest.NewServer(NewHandler(empty, Config{}))
+	defer emptyServer.Close()
+	got = decodeResponse(t, post(t, emptyServer, `{"jsonrpc":"2.0","id":3,"method":"tasks/pushNotificationConfig/list","params":{"id":"task-1"}}`))
+	if configs, ok := got["result"].([]any); !ok || len(configs) != 0 {
+		t.Fatalf("got response %v, want an empty array", got)
+	}
+}
+
+func TestHandler_ExtendedCard(t *testing.T) {
+	provider := a2asrv.ExtendedCardProviderFn(func(ctx context.Context, principal string) (*a2a.AgentCard, error) {
+		return &a2a.AgentCard{Name: "agent for " + principal}, nil
+	})
+	handler := NewHandler(a2asrv.NewHandler(nil, a2asrv.WithExtendedCard(provider)), Config{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(a2asrv.WithPrincipal(r.Context(), "alice")))
+	}))
+	defer server.Close()
+
+	got := decodeResponse(t, post(t, server, `{"jsonrpc":"2.0","id":1,"method":"agent/getAuthenticatedExtendedCard"}`))
+	result, ok := got["result"].(map[string]any)
+	if !ok || result["name"] != "agent for alice" {
+		t.Fatalf("got response %v, want the extended card for alice", got)
+	}
+}
+
+func TestHandler_Notification(t *testing.T) {
+	fake := &fakeHandler{}
+	server := httptest.NewServer(NewHandler(fake, Config{}))
+	defer server.Close()
+
+	resp := post(t, server, `{"jsonrpc":"2.0","method":"tasks/pushNotificationConfig/delete","params":{"id":"t","pushNotificationConfigId":"cfg"}}`)
+	if resp.StatusCode != http.StatusNoContent || len(fake.deleted) != 1 {
+		t.Fatalf("got status %d and %d deletes, want 204 and 1 delete", resp.StatusCode, len(fake.deleted))
+	}
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("http.Get() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("GET got status %d, want 405", resp.StatusCode)
+	}
+}
+
+func TestHandler_Stream(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	fake := &fakeHandler{events: []a2a.Event{
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil),
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil),
+	}}
+	server := httptest.NewServer(NewHandler(fake, Config{}))
+	defer server.Close()
+
+	resp := post(t, server, `{"jsonrpc":"2.0","id":7,"method":"message/stream","params":{"message":{"kind":"message","messageId":"m1","role":"user","parts":[]}}}`)
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("got Content-Type %q, want text/event-stream", got)
+	}
+
+	var responses []map[string]any
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		var body map[string]any
+		if err := json.Unmarshal([]byte(data), &body); err != nil {
+			t.Fatalf("failed to decode event %q: %v", data, err)
+		}
+		responses = append(responses, body)
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, io.EOF) {
+		t.Fatalf("failed to read stream: %v", err)
+	}
+
+	if len(responses) != 3 {
+		t.Fatalf("got %d events, want 3: %v", len(responses), responses)
+	}
+	for i, state := range []string{"working", "completed"} {
+		result, _ := responses[i]["result"].(map[string]any)
+		status, _ := result["status"].(map[string]any)
+		if responses[i]["id"] != 7.0 || result["kind"] != "status-update" || status["state"] != state {
+			t.Fatalf("event %d = %v, want %s status update", i, responses[i], state)
+		}
+	}
+	rpcErr, _ := responses[2]["error"].(map[string]any)
+	if rpcErr["code"] != float64(CodeInternalError) || rpcErr["message"] != "agent crashed" {
+		t.Fatalf("last event = %v, want internal error", responses[2])
+	}
+}
+
+// windowHandler produces events spending credits of the stream flow control window.
+type windowHandler struct {
+	fakeHandler
+	window    *eventqueue.CreditWindow
+	available []int
+}
+
+func (h *windowHandler) OnSendMessageStream(ctx context.Context, params a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	h.window, _ = a2asrv.CreditWindowFrom(ctx)
+	return func(yield func(a2a.Event, error) bool) {
+		for _, event := range h.events {
+			if err := h.window.Acquire(ctx); err != nil {
+				yield(nil, err)
+				return
+			}
+			h.available = append(h.available, h.window.Available())
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestHandler_StreamWindow(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	fake := &windowHandler{fakeHandler: fakeHandler{events: []a2a.Event{
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil),
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil),
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil),
+	}}}
+	server := httptest.NewServer(NewHandler(fake, Config{StreamWindow: 2}))
+	defer server.Close()
+
+	resp := post(t, server, `{"jsonrpc":"2.0","id":7,"method":"message/stream","params":{"message":{"kind":"message","messageId":"m1","role":"user","parts":[]}}}`)
+	if _, err := io.ReadAll(resp.Body); err != nil {
+		t.Fatalf("failed to read stream: %v", err)
+	}
+
+	if fake.window == nil {
+		t.Fatal("CreditWindowFrom() found no window")
+	}
+	// every flushed event grants the spent credit back
+	if want := []int{1, 1, 1}; !reflect.DeepEqual(fake.available, want) {
+		t.Fatalf("available credits = %v, want %v", fake.available, want)
+	}
+	// the window is closed when the stream ends, so producers are never blocked
+	if got := fake.window.Available(); got != 2 {
+		t.Fatalf("Available() = %d, want 2", got)
+	}
+	for range 3 {
+		if err := fake.window.Acquire(t.Context()); err != nil {
+			t.Fatalf("Acquire() after stream end failed: %v", err)
+		}
+	}
+}
+
+func TestToError(t *testing.T) {
+	err := ToError(fmt.Errorf("lookup failed: %w", a2a.ErrTaskNotFound))
+	if err.Code != CodeTaskNotFound || !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Fatalf("ToError() = %v, want task not found error", err)
+	}
+	if err := ToError(errors.New("boom")); err.Code != CodeInternalError || errors.Unwrap(err) != nil {
+		t.Fatalf("ToError() = %v, want internal error", err)
+	}
+	custom := &Error{Code: -32099, Message: "custom"}
+	if got := ToError(fmt.Errorf("wrapped: %w", custom)); got != custom {
+		t.Fatalf("ToError() = %v, want %v", got, custom)
+	}
+}
+
+func TestHandler_PayloadObserver(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	fake := &fakeHandler{
+		tasks:  map[a2a.TaskID]a2a.Task{"task-1": *task},
+		events: []a2a.Event{a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil)},
+	}
+	observed := make(chan PayloadSize, 1)
+	server := httptest.NewServer(NewHandler(fake, Config{
+		KeepAliveInterval: -1,
+		PayloadObserver: func(ctx context.Context, size PayloadSize) {
+			observed <- size
+		},
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name   string
+		body   string
+		method string
+		events int
+	}{
+		{
+			name:   "tasks/get",
+			body:   `{"jsonrpc":"2.0","id":1,"method":"tasks/get","params":{"id":"task-1"}}`,
+			method: MethodGetTask,
+		},
+		{
+			name:   "message/stream",
+			body:   `{"jsonrpc":"2.0","id":2,"method":"message/stream","params":{"message":{"kind":"message","messageId":"m1","role":"user","parts":[]}}}`,
+			method: MethodSendStreamingMessage,
+			events: 1,
+		},
+		{
+			name: "malformed",
+			body: `{"jsonrpc":`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := post(t, server, tc.body)
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("failed to read response: %v", err)
+			}
+			size := <-observed
+			want := PayloadSize{Method: tc.method, RequestBytes: int64(len(tc.body)), ResponseBytes: int64(len(body)), Events: tc.events}
+			if size != want {
+				t.Fatalf("observed %+v, want %+v", size, want)
+			}
+		})
+	}
+}
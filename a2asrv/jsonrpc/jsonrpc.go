@@ -0,0 +1,113 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// Version is the JSON-RPC protocol version requests must specify.
+const Version = "2.0"
+
+// Methods of the A2A protocol JSON-RPC binding.
+const (
+	MethodSendMessage          = "message/send"
+	MethodSendStreamingMessage = "message/stream"
+	MethodGetTask              = "tasks/get"
+	MethodCancelTask           = "tasks/cancel"
+	MethodResubscribeToTask    = "tasks/resubscribe"
+	MethodSetTaskPushConfig    = "tasks/pushNotificationConfig/set"
+	MethodGetTaskPushConfig    = "tasks/pushNotificationConfig/get"
+	MethodListTaskPushConfig   = "tasks/pushNotificationConfig/list"
+	MethodDeleteTaskPushConfig = "tasks/pushNotificationConfig/delete"
+
+	MethodGetAuthenticatedExtendedCard = "agent/getAuthenticatedExtendedCard"
+)
+
+// Error codes defined by JSON-RPC and the A2A protocol.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+
+	CodeTaskNotFound                           = -32001
+	CodeTaskNotCancelable                      = -32002
+	CodePushNotificationNotSupported           = -32003
+	CodeUnsupportedOperation                   = -32004
+	CodeContentTypeNotSupported                = -32005
+	CodeInvalidAgentResponse                   = -32006
+	CodeAuthenticatedExtendedCardNotConfigured = -32007
+)
+
+// protocolErrors maps a2a package errors to A2A error codes. a2a.ErrInvalidRequest is reported as
+// invalid params, because it signals a semantically invalid request which was a well-formed JSON-RPC request.
+var protocolErrors = []struct {
+	err  error
+	code int
+}{
+	{err: a2a.ErrTaskNotFound, code: CodeTaskNotFound},
+	{err: a2a.ErrTaskNotCancelable, code: CodeTaskNotCancelable},
+	{err: a2a.ErrPushNotificationNotSupported, code: CodePushNotificationNotSupported},
+	{err: a2a.ErrUnsupportedOperation, code: CodeUnsupportedOperation},
+	{err: a2a.ErrUnsupportedContentType, code: CodeContentTypeNotSupported},
+	{err: a2a.ErrInvalidAgentResponse, code: CodeInvalidAgentResponse},
+	{err: a2a.ErrAuthenticatedExtendedCardNotConfigured, code: CodeAuthenticatedExtendedCardNotConfigured},
+	{err: a2a.ErrInvalidRequest, code: CodeInvalidParams},
+}
+
+// Error is a JSON-RPC error object.
+type Error struct {
+	// Code identifies the error type.
+	Code int `json:"code"`
+	// Message is a short description of the error.
+	Message string `json:"message"`
+	// Data contains additional information about the error.
+	Data any `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// Unwrap returns the a2a package error corresponding to the A2A error code, so that errors.Is
+// can be used for checking protocol errors.
+func (e *Error) Unwrap() error {
+	for _, p := range protocolErrors {
+		if p.code == e.Code {
+			return p.err
+		}
+	}
+	return nil
+}
+
+// ToError converts err to a JSON-RPC error object. *Error is returned as is, a2a package errors
+// are reported with their A2A error codes and all the other errors become internal errors.
+func ToError(err error) *Error {
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+	for _, p := range protocolErrors {
+		if errors.Is(err, p.err) {
+			return &Error{Code: p.code, Message: err.Error()}
+		}
+	}
+	return &Error{Code: CodeInternalError, Message: err.Error()}
+}
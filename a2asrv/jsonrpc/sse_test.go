@@ -0,0 +1,310 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+func TestSSEWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	sse := newSSEWriter(rec)
+	if err := sse.writeData([]byte("first\nsecond\r\nthird")); err != nil {
+		t.Fatalf("writeData() error = %v", err)
+	}
+	if err := sse.writeComment("ping"); err != nil {
+		t.Fatalf("writeComment() error = %v", err)
+	}
+
+	if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("got Content-Type %q, want text/event-stream", got)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Fatalf("got Cache-Control %q, want no-cache", got)
+	}
+	if !rec.Flushed {
+		t.Fatal("events were not flushed")
+	}
+	want := "data: first\ndata: second\ndata: third\n\n: ping\n\n"
+	if got := rec.Body.String(); got != want {
+		t.Fatalf("got body %q, want %q", got, want)
+	}
+}
+
+// streamingHandler streams events written to the channel until it's closed.
+type streamingHandler struct {
+	fakeHandler
+	events  chan a2a.Event
+	stopped chan struct{}
+}
+
+func (h *streamingHandler) OnResubscribeToTask(ctx context.Context, id a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		defer close(h.stopped)
+		for {
+			select {
+			case event, ok := <-h.events:
+				if !ok || !yield(event, nil) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func TestHandler_StreamKeepAlive(t *testing.T) {
+	fake := &streamingHandler{events: make(chan a2a.Event), stopped: make(chan struct{})}
+	server := httptest.NewServer(NewHandler(fake, Config{KeepAliveInterval: 10 * time.Millisecond}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	body := `{"jsonrpc":"2.0","id":1,"method":"tasks/resubscribe","params":{"id":"task-1"}}`
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, strings.NewReader(body))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("http.Do() error = %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// the stream is idle until the keep-alive comment is received
+	reader := bufio.NewReader(resp.Body)
+	if line, err := reader.ReadString('\n'); err != nil || line != ": keep-alive\n" {
+		t.Fatalf("got line %q, %v, want keep-alive comment", line, err)
+	}
+
+	fake.events <- &a2a.TaskStatusUpdateEvent{TaskID: "task-1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read stream: %v", err)
+		}
+		if strings.HasPrefix(line, "data: ") {
+			if !strings.Contains(line, `"kind":"status-update"`) {
+				t.Fatalf("got event %q, want status update", line)
+			}
+			break
+		}
+	}
+
+	// disconnecting the client stops the iteration
+	cancel()
+	select {
+	case <-fake.stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream was not stopped after the client disconnected")
+	}
+}
+
+func TestHandler_StreamSharedEncoding(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	events := []a2a.Event{
+		task,
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, a2a.NewMessageForTask(a2a.MessageRoleAgent, *task, a2a.TextPart{Text: "<b>working</b>"})),
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil),
+	}
+	executor := &eventsExecutor{events: events}
+	server := NewHandler(a2asrv.NewHandler(executor), Config{KeepAliveInterval: -1})
+
+	rec := httptest.NewRecorder()
+	body := `{"jsonrpc":"2.0","id":"req-1","method":"message/stream","params":{"message":{"kind":"message","messageId":"m1","taskId":"task-1","role":"user","parts":[]}}}`
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	// the shared encoding must produce the same responses as encoding every event for the client
+	want := httptest.NewRecorder()
+	seq := func(yield func(a2a.Event, error) bool) {
+		for _, event := range events {
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+	h := &handler{config: Config{KeepAliveInterval: -1}}
+	h.writeEvents(want, json.RawMessage(`"req-1"`), seq, nil, nil)
+	if got := rec.Body.String(); got != want.Body.String() {
+		t.Fatalf("got stream\n%s\nwant\n%s", got, want.Body.String())
+	}
+}
+
+// eventsExecutor writes the events, waiting for start, if not nil, after the first one.
+type eventsExecutor struct {
+	events []a2a.Event
+	start  chan struct{}
+	paced  func(written int)
+}
+
+func (e *eventsExecutor) Execute(ctx context.Context, reqCtx a2asrv.RequestContext, q eventqueue.Queue) error {
+	for i, event := range e.events {
+		if err := q.Write(ctx, event); err != nil {
+			return err
+		}
+		if i == 0 && e.start != nil {
+			<-e.start
+		}
+		if e.paced != nil {
+			e.paced(i + 1)
+		}
+	}
+	return nil
+}
+
+func (e *eventsExecutor) Cancel(ctx context.Context, reqCtx a2asrv.RequestContext, q eventqueue.Queue) error {
+	return nil
+}
+
+// streamClient is an http.ResponseWriter discarding a stream and counting its writes,
+// each of which is a server-sent event.
+type streamClient struct {
+	header  http.Header
+	written atomic.Int64
+	started chan struct{}
+	once    sync.Once
+}
+
+func newStreamClient() *streamClient {
+	return &streamClient{header: make(http.Header), started: make(chan struct{})}
+}
+
+func (c *streamClient) Header() http.Header { return c.header }
+
+func (c *streamClient) WriteHeader(int) {}
+
+func (c *streamClient) Write(p []byte) (int, error) {
+	c.written.Add(1)
+	c.once.Do(func() { close(c.started) })
+	return len(p), nil
+}
+
+// memTaskStore keeps the latest version of every Task.
+type memTaskStore struct {
+	mu    sync.Mutex
+	tasks map[a2a.TaskID]a2a.Task
+}
+
+func (s *memTaskStore) Save(ctx context.Context, task a2a.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks[task.ID] = task
+	return nil
+}
+
+func (s *memTaskStore) Get(ctx context.Context, taskId a2a.TaskID) (a2a.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, ok := s.tasks[taskId]
+	if !ok {
+		return a2a.Task{}, a2a.ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// benchmarkSubscribers is the number of clients streaming the same Task in benchmarks.
+const benchmarkSubscribers = 100
+
+// BenchmarkHandler_Stream measures streaming one Task to 100 clients, one of which sent the message
+// and the others resubscribed, with the shared encoding of every event.
+func BenchmarkHandler_Stream(b *testing.B) {
+	benchmarkStream(b, func(h a2asrv.RequestHandler) a2asrv.RequestHandler { return h })
+}
+
+// BenchmarkHandler_Stream_PerSubscriberEncoding is the baseline where every client encodes every event.
+func BenchmarkHandler_Stream_PerSubscriberEncoding(b *testing.B) {
+	benchmarkStream(b, func(h a2asrv.RequestHandler) a2asrv.RequestHandler { return &unsharedHandler{h} })
+}
+
+// unsharedHandler hides the shared encoding of events from the transport.
+type unsharedHandler struct {
+	a2asrv.RequestHandler
+}
+
+func (h *unsharedHandler) OnSendMessageStream(ctx context.Context, params a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return h.RequestHandler.OnSendMessageStream(a2asrv.WithEncodedEvents(ctx, nil), params)
+}
+
+func (h *unsharedHandler) OnResubscribeToTask(ctx context.Context, id a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+	return h.RequestHandler.OnResubscribeToTask(a2asrv.WithEncodedEvents(ctx, nil), id)
+}
+
+func benchmarkStream(b *testing.B, wrap func(a2asrv.RequestHandler) a2asrv.RequestHandler) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	events := []a2a.Event{task}
+	for i := range b.N {
+		events = append(events, a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, a2a.NewMessageForTask(a2a.MessageRoleAgent, *task, a2a.TextPart{Text: fmt.Sprintf("step %d", i)})))
+	}
+	events = append(events, a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil))
+
+	clients := make([]*streamClient, benchmarkSubscribers)
+	for i := range clients {
+		clients[i] = newStreamClient()
+	}
+	// clients which fall behind by more than the Fanout window are disconnected, so the executor
+	// waits for all of them to receive the events from time to time
+	pace := int64(eventqueue.DefaultFanoutWindow / 2)
+	executor := &eventsExecutor{events: events, start: make(chan struct{}), paced: func(written int) {
+		if int64(written)%pace != 0 {
+			return
+		}
+		for _, c := range clients {
+			for c.written.Load() < int64(written) {
+				runtime.Gosched()
+			}
+		}
+	}}
+	server := NewHandler(wrap(a2asrv.NewHandler(executor, a2asrv.WithTaskStore(&memTaskStore{tasks: make(map[a2a.TaskID]a2a.Task)}))), Config{KeepAliveInterval: -1})
+
+	var wg sync.WaitGroup
+	stream := func(client *streamClient, body string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			server.ServeHTTP(client, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		}()
+		<-client.started
+	}
+	stream(clients[0], `{"jsonrpc":"2.0","id":1,"method":"message/stream","params":{"message":{"kind":"message","messageId":"m1","taskId":"task-1","role":"user","parts":[]}}}`)
+	for _, client := range clients[1:] {
+		// the first event of a resubscription is the stored Task, written after subscribing
+		stream(client, `{"jsonrpc":"2.0","id":1,"method":"tasks/resubscribe","params":{"id":"task-1"}}`)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	close(executor.start)
+	wg.Wait()
+	b.StopTimer()
+	for i, client := range clients {
+		if got := client.written.Load(); got != int64(len(events)) {
+			b.Fatalf("client %d received %d events, want %d", i, got, len(events))
+		}
+	}
+}
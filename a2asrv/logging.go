@@ -0,0 +1,214 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"iter"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/google/uuid"
+)
+
+type requestIDKey struct{}
+
+// RequestIDFrom returns the ID assigned to the request by the handler created with NewLoggingHandler.
+func RequestIDFrom(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// NewLoggingHandler wraps a RequestHandler to log a single structured summary line per call.
+// Every call is assigned a request ID which is available to the wrapped handler through RequestIDFrom.
+// The summary contains the protocol method, duration, outcome, the ID of the created or updated Task
+// and the sequence of Task states observed in the response.
+func NewLoggingHandler(next RequestHandler, logger *slog.Logger) RequestHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &loggingHandler{next: next, logger: logger}
+}
+
+type loggingHandler struct {
+	next   RequestHandler
+	logger *slog.Logger
+}
+
+// callSummary accumulates the data of a single call to be logged once the call completes.
+type callSummary struct {
+	logger    *slog.Logger
+	requestID string
+	method    string
+	start     time.Time
+	taskID    a2a.TaskID
+	states    []a2a.TaskState
+}
+
+func (h *loggingHandler) begin(ctx context.Context, method string, taskID a2a.TaskID) (context.Context, *callSummary) {
+	requestID := uuid.NewString()
+	summary := &callSummary{
+		logger:    h.logger,
+		requestID: requestID,
+		method:    method,
+		start:     time.Now(),
+		taskID:    taskID,
+	}
+	return context.WithValue(ctx, requestIDKey{}, requestID), summary
+}
+
+func (s *callSummary) observe(event a2a.Event) {
+	var taskID a2a.TaskID
+	var state a2a.TaskState
+	switch v := event.(type) {
+	case *a2a.Task:
+		taskID, state = v.ID, v.Status.State
+	case *a2a.TaskStatusUpdateEvent:
+		taskID, state = v.TaskID, v.Status.State
+	case *a2a.TaskArtifactUpdateEvent:
+		taskID = v.TaskID
+	case *a2a.Message:
+		taskID = v.TaskID
+	}
+	if s.taskID == "" {
+		s.taskID = taskID
+	}
+	if state != "" && (len(s.states) == 0 || s.states[len(s.states)-1] != state) {
+		s.states = append(s.states, state)
+	}
+}
+
+func (s *callSummary) end(ctx context.Context, err error) {
+	attrs := []slog.Attr{
+		slog.String("requestId", s.requestID),
+		slog.String("method", s.method),
+		slog.Duration("duration", time.Since(s.start)),
+	}
+	if s.taskID != "" {
+		attrs = append(attrs, slog.String("taskId", string(s.taskID)))
+	}
+	if len(s.states) > 0 {
+		states := make([]string, len(s.states))
+		for i, state := range s.states {
+			states[i] = string(state)
+		}
+		attrs = append(attrs, slog.String("taskStates", strings.Join(states, "->")))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("outcome", "error"), slog.String("error", err.Error()))
+		s.logger.LogAttrs(ctx, slog.LevelError, "a2a call", attrs...)
+		return
+	}
+	attrs = append(attrs, slog.String("outcome", "ok"))
+	s.logger.LogAttrs(ctx, slog.LevelInfo, "a2a call", attrs...)
+}
+
+func (h *loggingHandler) OnGetTask(ctx context.Context, query a2a.TaskQueryParams) (a2a.Task, error) {
+	ctx, summary := h.begin(ctx, "tasks/get", query.ID)
+	task, err := h.next.OnGetTask(ctx, query)
+	if err == nil {
+		summary.observe(&task)
+	}
+	summary.end(ctx, err)
+	return task, err
+}
+
+func (h *loggingHandler) OnCancelTask(ctx context.Context, id a2a.TaskIDParams) (a2a.Task, error) {
+	ctx, summary := h.begin(ctx, "tasks/cancel", id.ID)
+	task, err := h.next.OnCancelTask(ctx, id)
+	if err == nil {
+		summary.observe(&task)
+	}
+	summary.end(ctx, err)
+	return task, err
+}
+
+func (h *loggingHandler) OnGetExtendedAgentCard(ctx context.Context) (*a2a.AgentCard, error) {
+	ctx, summary := h.begin(ctx, "agent/getAuthenticatedExtendedCard", "")
+	card, err := getExtendedAgentCard(ctx, h.next)
+	summary.end(ctx, err)
+	return card, err
+}
+
+func (h *loggingHandler) OnSendMessage(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	ctx, summary := h.begin(ctx, "message/send", message.Message.TaskID)
+	result, err := h.next.OnSendMessage(ctx, message)
+	if event, ok := result.(a2a.Event); ok && err == nil {
+		summary.observe(event)
+	}
+	summary.end(ctx, err)
+	return result, err
+}
+
+func (h *loggingHandler) OnResubscribeToTask(ctx context.Context, id a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+	ctx, summary := h.begin(ctx, "tasks/resubscribe", id.ID)
+	return h.logStream(ctx, summary, h.next.OnResubscribeToTask(ctx, id))
+}
+
+func (h *loggingHandler) OnSendMessageStream(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	ctx, summary := h.begin(ctx, "message/stream", message.Message.TaskID)
+	return h.logStream(ctx, summary, h.next.OnSendMessageStream(ctx, message))
+}
+
+func (h *loggingHandler) logStream(ctx context.Context, summary *callSummary, events iter.Seq2[a2a.Event, error]) iter.Seq2[a2a.Event, error] {
+	if events == nil {
+		summary.end(ctx, nil)
+		return nil
+	}
+	return func(yield func(a2a.Event, error) bool) {
+		var streamErr error
+		defer func() { summary.end(ctx, streamErr) }()
+		for event, err := range events {
+			if err != nil {
+				streamErr = err
+			} else {
+				summary.observe(event)
+			}
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+func (h *loggingHandler) OnGetTaskPushConfig(ctx context.Context, params a2a.GetTaskPushConfigParams) (a2a.TaskPushConfig, error) {
+	ctx, summary := h.begin(ctx, "tasks/pushNotificationConfig/get", params.TaskID)
+	config, err := h.next.OnGetTaskPushConfig(ctx, params)
+	summary.end(ctx, err)
+	return config, err
+}
+
+func (h *loggingHandler) OnListTaskPushConfig(ctx context.Context, params a2a.ListTaskPushConfigParams) (*a2a.ListTaskPushConfigResult, error) {
+	ctx, summary := h.begin(ctx, "tasks/pushNotificationConfig/list", params.TaskID)
+	result, err := h.next.OnListTaskPushConfig(ctx, params)
+	summary.end(ctx, err)
+	return result, err
+}
+
+func (h *loggingHandler) OnSetTaskPushConfig(ctx context.Context, params a2a.TaskPushConfig) (a2a.TaskPushConfig, error) {
+	ctx, summary := h.begin(ctx, "tasks/pushNotificationConfig/set", params.TaskID)
+	config, err := h.next.OnSetTaskPushConfig(ctx, params)
+	summary.end(ctx, err)
+	return config, err
+}
+
+func (h *loggingHandler) OnDeleteTaskPushConfig(ctx context.Context, params a2a.DeleteTaskPushConfigParams) error {
+	ctx, summary := h.begin(ctx, "tasks/pushNotificationConfig/delete", params.TaskID)
+	err := h.next.OnDeleteTaskPushConfig(ctx, params)
+	summary.end(ctx, err)
+	return err
+}
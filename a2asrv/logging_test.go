@@ -0,0 +1,147 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// stubRequestHandler overrides selected RequestHandler methods.
+type stubRequestHandler struct {
+	RequestHandler
+	sendMessage func(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error)
+	stream      func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error]
+}
+
+func (h *stubRequestHandler) OnSendMessage(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	return h.sendMessage(ctx, message)
+}
+
+func (h *stubRequestHandler) OnSendMessageStream(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return h.stream(ctx, message)
+}
+
+func parseLogLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var result []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+func TestLoggingHandler_OnSendMessage(t *testing.T) {
+	var buf bytes.Buffer
+	var requestID string
+	next := &stubRequestHandler{
+		sendMessage: func(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+			requestID, _ = RequestIDFrom(ctx)
+			return &a2a.Task{ID: "task-1", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}}, nil
+		},
+	}
+	handler := NewLoggingHandler(next, slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	if _, err := handler.OnSendMessage(t.Context(), a2a.MessageSendParams{}); err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+
+	lines := parseLogLines(t, &buf)
+	if len(lines) != 1 {
+		t.Fatalf("got %d log lines, want 1", len(lines))
+	}
+	entry := lines[0]
+	if requestID == "" || entry["requestId"] != requestID {
+		t.Fatalf("requestId = %v, want %q", entry["requestId"], requestID)
+	}
+	want := map[string]any{"method": "message/send", "outcome": "ok", "taskId": "task-1", "taskStates": "completed"}
+	for k, v := range want {
+		if entry[k] != v {
+			t.Errorf("log %s = %v, want %v", k, entry[k], v)
+		}
+	}
+	if _, ok := entry["duration"]; !ok {
+		t.Error("log is missing duration")
+	}
+}
+
+func TestLoggingHandler_OnSendMessageStream(t *testing.T) {
+	var buf bytes.Buffer
+	streamErr := errors.New("executor failed")
+	task := &a2a.Task{ID: "task-1", Status: a2a.TaskStatus{State: a2a.TaskStateSubmitted}}
+	next := &stubRequestHandler{
+		stream: func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+			return func(yield func(a2a.Event, error) bool) {
+				events := []a2a.Event{
+					task,
+					a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil),
+					a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil),
+					a2a.NewStatusUpdateEvent(task, a2a.TaskStateInputRequired, nil),
+				}
+				for _, event := range events {
+					if !yield(event, nil) {
+						return
+					}
+				}
+				yield(nil, streamErr)
+			}
+		},
+	}
+	handler := NewLoggingHandler(next, slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	count := 0
+	for _, err := range handler.OnSendMessageStream(t.Context(), a2a.MessageSendParams{}) {
+		if err != nil {
+			break
+		}
+		count++
+	}
+	if count != 4 {
+		t.Fatalf("got %d events, want 4", count)
+	}
+
+	lines := parseLogLines(t, &buf)
+	if len(lines) != 1 {
+		t.Fatalf("got %d log lines, want 1", len(lines))
+	}
+	want := map[string]any{
+		"method":     "message/stream",
+		"outcome":    "error",
+		"error":      streamErr.Error(),
+		"taskId":     "task-1",
+		"taskStates": "submitted->working->input-required",
+		"level":      "ERROR",
+	}
+	for k, v := range want {
+		if lines[0][k] != v {
+			t.Errorf("log %s = %v, want %v", k, lines[0][k], v)
+		}
+	}
+}
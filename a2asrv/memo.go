@@ -0,0 +1,247 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
+)
+
+// MemoizedMetaKey is the Task metadata key set to true on Tasks served by Memoize from the cache.
+const MemoizedMetaKey = "memoized"
+
+const (
+	defaultMemoTTL        = 10 * time.Minute
+	defaultMemoMaxEntries = 1000
+)
+
+// MemoConfig configures the Memoize middleware.
+type MemoConfig struct {
+	// TTL is the time a completed Task is reused for identical requests. Defaults to 10 minutes.
+	TTL time.Duration
+	// MaxEntries is the maximum number of cached Tasks. The least recently used ones are evicted first.
+	// Defaults to 1000.
+	MaxEntries int
+	// MetadataKeys are the Message metadata keys which are included in the input hash, eg. model parameters.
+	// Other metadata is ignored.
+	MetadataKeys []string
+	// PerContext makes results only reusable for requests within the same context.
+	PerContext bool
+	// Key overrides the input hash. Requests for which it returns false are not memoized.
+	Key func(reqCtx RequestContext) (string, bool)
+}
+
+// Memoize creates an AgentExecutorMiddleware which caches the Tasks completed by the executor keyed by
+// a hash of the normalized input Message: its role, parts and the configured metadata keys.
+// If an identical request completed within MemoConfig.TTL, the cached Task is written to the queue under
+// the new Task ID instead of running the executor. Only Tasks which ended in TaskStateCompleted are cached.
+// Requests continuing an existing Task or referencing other Tasks are never memoized.
+// Intended for expensive deterministic skills.
+func Memoize(config MemoConfig) AgentExecutorMiddleware {
+	if config.TTL <= 0 {
+		config.TTL = defaultMemoTTL
+	}
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = defaultMemoMaxEntries
+	}
+	if config.Key == nil {
+		config.Key = func(reqCtx RequestContext) (string, bool) {
+			return memoKey(reqCtx, config)
+		}
+	}
+	cache := &memoCache{size: config.MaxEntries, ttl: config.TTL, lru: list.New(), entries: make(map[string]*list.Element)}
+
+	return ExecuteMiddleware(func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue, next AgentExecutor) error {
+		if reqCtx.Task != nil || len(reqCtx.Request.Message.ReferenceTasks) > 0 {
+			return next.Execute(ctx, reqCtx, queue)
+		}
+		key, ok := config.Key(reqCtx)
+		if !ok {
+			return next.Execute(ctx, reqCtx, queue)
+		}
+
+		now := ClockFrom(ctx).Now()
+		if cached, ok := cache.get(key, now); ok {
+			return queue.Write(ctx, memoizedTask(cached, reqCtx))
+		}
+
+		recorder := &memoRecorder{Queue: queue}
+		if err := next.Execute(ctx, reqCtx, recorder); err != nil {
+			return err
+		}
+		if recorder.updates != nil && recorder.updates.Task.Status.State == a2a.TaskStateCompleted {
+			if task, err := copyTask(recorder.updates.Task); err == nil {
+				cache.put(key, task, now)
+			}
+		}
+		return nil
+	})
+}
+
+// memoKey hashes the normalized input Message.
+func memoKey(reqCtx RequestContext, config MemoConfig) (string, bool) {
+	msg := reqCtx.Request.Message
+	input := struct {
+		Role      a2a.MessageRole  `json:"role"`
+		Parts     a2a.ContentParts `json:"parts"`
+		Metadata  map[string]any   `json:"metadata,omitempty"`
+		ContextID string           `json:"contextId,omitempty"`
+	}{Role: msg.Role, Parts: msg.Parts}
+	for _, key := range config.MetadataKeys {
+		if value, ok := msg.Metadata[key]; ok {
+			if input.Metadata == nil {
+				input.Metadata = make(map[string]any)
+			}
+			input.Metadata[key] = value
+		}
+	}
+	if config.PerContext {
+		input.ContextID = reqCtx.ContextID
+	}
+	// encoding/json sorts map keys, so equal inputs always produce the same encoding
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), true
+}
+
+// memoizedTask returns the cached Task re-keyed for the new request.
+func memoizedTask(cached a2a.Task, reqCtx RequestContext) *a2a.Task {
+	task := cached
+	task.ID = reqCtx.TaskID
+	if reqCtx.ContextID != "" {
+		task.ContextID = reqCtx.ContextID
+	}
+	request := reqCtx.Request.Message
+	task.History = []*a2a.Message{&request}
+	if status := cached.Status.Message; status != nil {
+		msg := *status
+		msg.ID, msg.TaskID, msg.ContextID = a2a.NewMessageID(), task.ID, task.ContextID
+		task.Status.Message = &msg
+		task.History = append(task.History, &msg)
+	}
+	metadata := make(map[string]any, len(cached.Metadata)+1)
+	for k, v := range cached.Metadata {
+		metadata[k] = v
+	}
+	metadata[MemoizedMetaKey] = true
+	task.Metadata = metadata
+	return &task
+}
+
+// memoRecorder applies the events written by the executor to a Task.
+type memoRecorder struct {
+	eventqueue.Queue
+	updates *taskupdate.Manager
+	failed  bool
+}
+
+func (r *memoRecorder) Write(ctx context.Context, event a2a.Event) error {
+	if err := r.Queue.Write(ctx, event); err != nil {
+		return err
+	}
+	if r.failed {
+		return nil
+	}
+	switch event.(type) {
+	case *a2a.Message, a2a.CustomEvent:
+		return nil
+	}
+	if r.updates == nil {
+		task, err := newTaskForEvent(event)
+		if err != nil {
+			r.failed = true
+			return nil
+		}
+		r.updates = taskupdate.NewManager(taskStoreSaver{}, task)
+	}
+	update := event
+	if task, ok := event.(*a2a.Task); ok {
+		// the manager keeps updating the snapshot it's given, while the handler is reading the event
+		copy, err := copyTask(task)
+		if err != nil {
+			r.updates, r.failed = nil, true
+			return nil
+		}
+		update = &copy
+	}
+	if err := r.updates.Process(ctx, update); err != nil {
+		// the result can't be reconstructed, so it's not cached
+		r.updates, r.failed = nil, true
+	}
+	return nil
+}
+
+// memoCache is an LRU cache of completed Tasks which expire after ttl.
+type memoCache struct {
+	size int
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type memoEntry struct {
+	key     string
+	task    a2a.Task
+	expires time.Time
+}
+
+func (c *memoCache) get(key string, now time.Time) (a2a.Task, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return a2a.Task{}, false
+	}
+	entry := elem.Value.(*memoEntry)
+	if !now.Before(entry.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return a2a.Task{}, false
+	}
+	c.lru.MoveToFront(elem)
+	task, err := copyTask(&entry.task)
+	return task, err == nil
+}
+
+func (c *memoCache) put(key string, task a2a.Task, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &memoEntry{key: key, task: task, expires: now.Add(c.ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoEntry).key)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"container/list"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+func TestMemoize(t *testing.T) {
+	clock := &manualClock{now: time.Now()}
+	ctx := WithClock(t.Context(), clock)
+	executions := 0
+	state := a2a.TaskStateCompleted
+	executor := &mockAgentExecutor{
+		ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+			executions++
+			task := &a2a.Task{ID: reqCtx.TaskID, ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+			if err := queue.Write(ctx, task); err != nil {
+				return err
+			}
+			if err := queue.Write(ctx, a2a.NewArtifactEvent(*task, a2a.TextPart{Text: "report"})); err != nil {
+				return err
+			}
+			return queue.Write(ctx, a2a.NewStatusUpdateEvent(task, state, nil))
+		},
+	}
+	memo := Memoize(MemoConfig{TTL: time.Minute, MetadataKeys: []string{"model"}})
+	handler := NewHandler(executor, WithExecutorMiddleware(memo))
+
+	send := func(taskID a2a.TaskID, text string, metadata map[string]any) *a2a.Task {
+		t.Helper()
+		msg := a2a.Message{ID: a2a.NewMessageID(), TaskID: taskID, Role: a2a.MessageRoleUser, Parts: a2a.ContentParts{a2a.TextPart{Text: text}}, Metadata: metadata}
+		result, err := handler.OnSendMessage(ctx, a2a.MessageSendParams{Message: msg})
+		if err != nil {
+			t.Fatalf("OnSendMessage() error = %v", err)
+		}
+		task, ok := result.(*a2a.Task)
+		if !ok {
+			t.Fatalf("OnSendMessage() = %T, want *a2a.Task", result)
+		}
+		return task
+	}
+
+	first := send("task-1", "analyze", map[string]any{"model": "large", "traceId": "1"})
+	second := send("task-2", "analyze", map[string]any{"model": "large", "traceId": "2"})
+	if executions != 1 {
+		t.Fatalf("executor called %d times for identical requests, want 1", executions)
+	}
+	if second.ID != "task-2" || second.Status.State != a2a.TaskStateCompleted || second.Metadata[MemoizedMetaKey] != true {
+		t.Fatalf("memoized task = %+v, want completed task-2 marked as memoized", second)
+	}
+	if len(second.Artifacts) != 1 || second.Artifacts[0].ID != first.Artifacts[0].ID {
+		t.Fatalf("memoized artifacts = %+v, want %+v", second.Artifacts, first.Artifacts)
+	}
+
+	send("task-3", "analyze", map[string]any{"model": "small"})
+	send("task-4", "summarize", map[string]any{"model": "large"})
+	if executions != 3 {
+		t.Fatalf("executor called %d times for different requests, want 3", executions)
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	send("task-5", "analyze", map[string]any{"model": "large"})
+	if executions != 4 {
+		t.Fatalf("executor called %d times after TTL, want 4", executions)
+	}
+
+	state = a2a.TaskStateFailed
+	send("task-6", "fail", nil)
+	send("task-7", "fail", nil)
+	if executions != 6 {
+		t.Fatalf("executor called %d times for failing requests, want 6", executions)
+	}
+}
+
+func TestMemoize_CreatedAndContinuedTasks(t *testing.T) {
+	ctx := t.Context()
+	executions := 0
+	executor := &mockAgentExecutor{
+		ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+			executions++
+			task := &a2a.Task{ID: reqCtx.TaskID, ContextID: reqCtx.ContextID}
+			return queue.Write(ctx, a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil))
+		},
+	}
+	store := newCountingTaskStore()
+	handler := NewHandler(executor, WithTaskStore(store), WithExecutorMiddleware(Memoize(MemoConfig{})))
+
+	send := func(taskID a2a.TaskID) *a2a.Task {
+		t.Helper()
+		msg := a2a.Message{ID: a2a.NewMessageID(), TaskID: taskID, Role: a2a.MessageRoleUser, Parts: a2a.ContentParts{a2a.TextPart{Text: "analyze"}}}
+		result, err := handler.OnSendMessage(ctx, a2a.MessageSendParams{Message: msg})
+		if err != nil {
+			t.Fatalf("OnSendMessage() error = %v", err)
+		}
+		return result.(*a2a.Task)
+	}
+
+	first := send("")
+	second := send("")
+	if executions != 1 {
+		t.Fatalf("executor called %d times for identical messages without a TaskID, want 1", executions)
+	}
+	if second.ID == first.ID || second.Status.State != a2a.TaskStateCompleted || second.Metadata[MemoizedMetaKey] != true {
+		t.Fatalf("memoized task = %+v, want a new completed task marked as memoized", second)
+	}
+
+	working := a2a.Task{ID: "stored", ContextID: "ctx", Status: a2a.TaskStatus{State: a2a.TaskStateInputRequired}}
+	if err := store.Save(ctx, working); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	continued := send(working.ID)
+	if executions != 2 || continued.Metadata[MemoizedMetaKey] == true {
+		t.Fatalf("executor called %d times, want the message continuing a stored task to be executed", executions)
+	}
+}
+
+func TestMemoize_LRU(t *testing.T) {
+	cache := &memoCache{size: 2, ttl: time.Minute, lru: list.New(), entries: make(map[string]*list.Element)}
+	now := time.Now()
+	cache.put("a", a2a.Task{ID: "a"}, now)
+	cache.put("b", a2a.Task{ID: "b"}, now)
+	cache.get("a", now)
+	cache.put("c", a2a.Task{ID: "c"}, now)
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := cache.get(key, now); ok != want {
+			t.Fatalf("get(%q) found = %v, want %v", key, ok, want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2acrypto"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// MessageSigningMiddleware creates an AgentExecutorMiddleware implementing the a2acrypto.MessageSignatureExtensionURI
+// extension. Signatures of incoming messages are checked with the verifier and messages with a missing or
+// invalid signature fail the request with a2a.ErrInvalidRequest. Messages written by AgentExecutor, including
+// status messages of Task events, are signed with the signer. Either of them can be nil.
+//
+// Other middlewares, like ModerationMiddleware, can modify the request message, so the middleware should be the
+// first one.
+func MessageSigningMiddleware(verifier *a2acrypto.MessageVerifier, signer *a2acrypto.MessageSigner) AgentExecutorMiddleware {
+	verify := func(reqCtx RequestContext) error {
+		if verifier == nil {
+			return nil
+		}
+		if _, err := verifier.VerifyMessage(&reqCtx.Request.Message); err != nil {
+			return fmt.Errorf("%w: %w", a2a.ErrInvalidRequest, err)
+		}
+		return nil
+	}
+	return func(next AgentExecutor) AgentExecutor {
+		return &AgentExecutorFuncs{
+			Next: next,
+			ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+				if err := verify(reqCtx); err != nil {
+					return err
+				}
+				if signer == nil {
+					return next.Execute(ctx, reqCtx, queue)
+				}
+				return next.Execute(ctx, reqCtx, &signingQueue{Queue: queue, signer: signer})
+			},
+			ValidateFunc: func(ctx context.Context, reqCtx RequestContext) error {
+				if err := verify(reqCtx); err != nil {
+					return err
+				}
+				return validateRequest(ctx, next, reqCtx)
+			},
+		}
+	}
+}
+
+// signingQueue signs messages of written events. Events are copied, so that the originals
+// AgentExecutor holds on to are not modified.
+type signingQueue struct {
+	eventqueue.Queue
+	signer *a2acrypto.MessageSigner
+}
+
+func (q *signingQueue) Write(ctx context.Context, event a2a.Event) error {
+	signed, err := q.sign(event)
+	if err != nil {
+		return err
+	}
+	return q.Queue.Write(ctx, signed)
+}
+
+func (q *signingQueue) sign(event a2a.Event) (a2a.Event, error) {
+	switch v := event.(type) {
+	case *a2a.Message:
+		msg, err := q.signMessage(v)
+		return msg, err
+	case *a2a.Task:
+		if v.Status.Message == nil {
+			return v, nil
+		}
+		task := *v
+		msg, err := q.signMessage(v.Status.Message)
+		task.Status.Message = msg
+		return &task, err
+	case *a2a.TaskStatusUpdateEvent:
+		if v.Status.Message == nil {
+			return v, nil
+		}
+		update := *v
+		msg, err := q.signMessage(v.Status.Message)
+		update.Status.Message = msg
+		return &update, err
+	default:
+		return event, nil
+	}
+}
+
+func (q *signingQueue) signMessage(msg *a2a.Message) (*a2a.Message, error) {
+	signed := *msg
+	if err := q.signer.SignMessage(&signed); err != nil {
+		return nil, err
+	}
+	return &signed, nil
+}
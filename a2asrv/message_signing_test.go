@@ -0,0 +1,55 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2acrypto"
+)
+
+func TestMessageSigningMiddleware(t *testing.T) {
+	clientPub, clientPriv, _ := ed25519.GenerateKey(nil)
+	agentPub, agentPriv, _ := ed25519.GenerateKey(nil)
+	clientSigner := a2acrypto.Ed25519MessageSigner("client", clientPriv)
+	verifier := &a2acrypto.MessageVerifier{Verify: a2acrypto.VerifyEd25519(map[string]ed25519.PublicKey{"client": clientPub})}
+	handler := NewHandler(echoExecutor, WithExecutorMiddleware(
+		MessageSigningMiddleware(verifier, a2acrypto.Ed25519MessageSigner("agent", agentPriv)),
+	))
+
+	params := newTextParams("hello")
+	if err := clientSigner.SignMessage(&params.Message); err != nil {
+		t.Fatalf("SignMessage() error = %v", err)
+	}
+	result, err := handler.OnSendMessage(t.Context(), params)
+	if err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	agentVerifier := &a2acrypto.MessageVerifier{Verify: a2acrypto.VerifyEd25519(map[string]ed25519.PublicKey{"agent": agentPub})}
+	if _, err := agentVerifier.VerifyMessage(result.(*a2a.Message)); err != nil {
+		t.Fatalf("response verification error = %v", err)
+	}
+
+	if _, err := handler.OnSendMessage(t.Context(), newTextParams("hello")); !errors.Is(err, a2a.ErrInvalidRequest) || !errors.Is(err, a2acrypto.ErrMissingSignature) {
+		t.Fatalf("OnSendMessage() of unsigned message error = %v, want %v", err, a2acrypto.ErrMissingSignature)
+	}
+	params.Message.Parts = a2a.ContentParts{a2a.TextPart{Text: "tampered"}}
+	if _, err := handler.OnSendMessage(t.Context(), a2a.WithDryRun(params)); !errors.Is(err, a2acrypto.ErrInvalidSignature) {
+		t.Fatalf("OnSendMessage() dry run of tampered message error = %v, want %v", err, a2acrypto.ErrInvalidSignature)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// ContentRejectedError is returned by content screeners to veto a message or an event.
+// A rejected inbound message moves the Task to TaskStateRejected, a rejected outbound event
+// moves the Task to TaskStateFailed. Reason is sent to the client in the status message.
+type ContentRejectedError struct {
+	Reason string
+	// Rejection is an optional machine-readable description of the rejection, which is sent to the
+	// client in the status message metadata under a2a.RejectionMetaKey.
+	Rejection *a2a.Rejection
+}
+
+func (e *ContentRejectedError) Error() string {
+	return fmt.Sprintf("content rejected: %s", e.Reason)
+}
+
+// InboundScreener screens user messages before they reach AgentExecutor.
+type InboundScreener interface {
+	// ScreenMessage can redact the message in place or veto it by returning ContentRejectedError.
+	// Other errors fail the request.
+	ScreenMessage(ctx context.Context, msg *a2a.Message) error
+}
+
+// OutboundScreener screens events produced by AgentExecutor before they are written to the queue.
+type OutboundScreener interface {
+	// ScreenEvent returns the event to write, which can be a redacted copy of the original one,
+	// or vetoes the event by returning ContentRejectedError. Other errors are returned to AgentExecutor.
+	ScreenEvent(ctx context.Context, event a2a.Event) (a2a.Event, error)
+}
+
+// ModerationMiddleware creates an AgentExecutorMiddleware which applies the screeners to Execute calls.
+// Either of the screeners can be nil. Dry run requests are screened by the inbound screener and
+// fail with ContentRejectedError if the message is vetoed.
+func ModerationMiddleware(inbound InboundScreener, outbound OutboundScreener) AgentExecutorMiddleware {
+	screenInbound := func(ctx context.Context, reqCtx *RequestContext) error {
+		if inbound == nil {
+			return nil
+		}
+		err := inbound.ScreenMessage(ctx, &reqCtx.Request.Message)
+		var rejected *ContentRejectedError
+		if err != nil && !errors.As(err, &rejected) {
+			return fmt.Errorf("inbound screening failed: %w", err)
+		}
+		return err
+	}
+	return func(next AgentExecutor) AgentExecutor {
+		return &AgentExecutorFuncs{
+			Next: next,
+			ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+				if err := screenInbound(ctx, &reqCtx); err != nil {
+					var rejected *ContentRejectedError
+					if errors.As(err, &rejected) {
+						return queue.Write(ctx, rejectedTask(reqCtx, a2a.TaskStateRejected, rejected))
+					}
+					return err
+				}
+				if outbound == nil {
+					return next.Execute(ctx, reqCtx, queue)
+				}
+
+				screened := &screenedQueue{Queue: queue, screener: outbound, reqCtx: reqCtx}
+				err := next.Execute(ctx, reqCtx, screened)
+				if screened.rejected != nil && errors.Is(err, screened.rejected) {
+					// the rejection was already reported to the client as a Task failure
+					return nil
+				}
+				return err
+			},
+			ValidateFunc: func(ctx context.Context, reqCtx RequestContext) error {
+				if err := screenInbound(ctx, &reqCtx); err != nil {
+					return err
+				}
+				return validateRequest(ctx, next, reqCtx)
+			},
+		}
+	}
+}
+
+// screenedQueue applies OutboundScreener to every written event.
+type screenedQueue struct {
+	eventqueue.Queue
+	screener OutboundScreener
+	reqCtx   RequestContext
+	rejected *ContentRejectedError
+}
+
+func (q *screenedQueue) Write(ctx context.Context, event a2a.Event) error {
+	if q.rejected != nil {
+		return q.rejected
+	}
+
+	screened, err := q.screener.ScreenEvent(ctx, event)
+	var rejected *ContentRejectedError
+	if !errors.As(err, &rejected) {
+		if err != nil {
+			return fmt.Errorf("outbound screening failed: %w", err)
+		}
+		return q.Queue.Write(ctx, screened)
+	}
+
+	q.rejected = rejected
+	var failure a2a.Event
+	if _, ok := event.(a2a.SendMessageResult); ok {
+		failure = rejectedTask(q.reqCtx, a2a.TaskStateFailed, rejected)
+	} else {
+		task := &a2a.Task{ID: q.reqCtx.TaskID, ContextID: q.reqCtx.ContextID}
+		update := a2a.NewStatusUpdateEvent(task, a2a.TaskStateFailed, rejectionMessage(task, rejected))
+		update.Final = true
+		failure = update
+	}
+	if err := q.Queue.Write(ctx, failure); err != nil {
+		return err
+	}
+	return rejected
+}
+
+func rejectedTask(reqCtx RequestContext, state a2a.TaskState, rejected *ContentRejectedError) *a2a.Task {
+	var task a2a.Task
+	if reqCtx.Task != nil {
+		task = *reqCtx.Task
+	} else {
+		task = a2a.Task{ID: reqCtx.TaskID, ContextID: reqCtx.ContextID}
+		if task.ContextID == "" {
+			task.ContextID = reqCtx.Request.Message.ContextID
+		}
+		msg := reqCtx.Request.Message
+		task.History = []*a2a.Message{&msg}
+	}
+	task.Status = a2a.TaskStatus{State: state, Message: rejectionMessage(&task, rejected)}
+	return &task
+}
+
+func rejectionMessage(task *a2a.Task, rejected *ContentRejectedError) *a2a.Message {
+	msg := a2a.NewMessageForTask(a2a.MessageRoleAgent, *task, a2a.TextPart{Text: rejected.Reason})
+	if rejected.Rejection != nil {
+		msg.Metadata = map[string]any{a2a.RejectionMetaKey: a2a.RejectionToMeta(*rejected.Rejection)}
+	}
+	return msg
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+type inboundScreenerFn func(ctx context.Context, msg *a2a.Message) error
+
+func (fn inboundScreenerFn) ScreenMessage(ctx context.Context, msg *a2a.Message) error {
+	return fn(ctx, msg)
+}
+
+type outboundScreenerFn func(ctx context.Context, event a2a.Event) (a2a.Event, error)
+
+func (fn outboundScreenerFn) ScreenEvent(ctx context.Context, event a2a.Event) (a2a.Event, error) {
+	return fn(ctx, event)
+}
+
+// blocklist rejects messages and events containing "forbidden" and redacts "secret".
+var blocklist = struct {
+	inboundScreenerFn
+	outboundScreenerFn
+}{
+	inboundScreenerFn: func(ctx context.Context, msg *a2a.Message) error {
+		for i, part := range msg.Parts {
+			text, ok := part.(a2a.TextPart)
+			if !ok {
+				continue
+			}
+			if strings.Contains(text.Text, "forbidden") {
+				return &ContentRejectedError{Reason: "forbidden topic"}
+			}
+			msg.Parts[i] = a2a.TextPart{Text: strings.ReplaceAll(text.Text, "secret", "***")}
+		}
+		return nil
+	},
+	outboundScreenerFn: func(ctx context.Context, event a2a.Event) (a2a.Event, error) {
+		msg, ok := event.(*a2a.Message)
+		if !ok {
+			return event, nil
+		}
+		if text, ok := msg.Parts[0].(a2a.TextPart); ok && strings.Contains(text.Text, "forbidden") {
+			return nil, &ContentRejectedError{Reason: "unsafe response"}
+		}
+		return event, nil
+	},
+}
+
+// echoExecutor responds with the text of the request.
+var echoExecutor = &mockAgentExecutor{
+	ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+		msg := reqCtx.Request.Message
+		return queue.Write(ctx, &a2a.Message{TaskID: reqCtx.TaskID, Role: a2a.MessageRoleAgent, Parts: msg.Parts})
+	},
+}
+
+func newTextParams(text string) a2a.MessageSendParams {
+	return a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID, Parts: a2a.ContentParts{a2a.TextPart{Text: text}}}}
+}
+
+func TestModerationMiddleware_Inbound(t *testing.T) {
+	handler := NewHandler(echoExecutor, WithExecutorMiddleware(ModerationMiddleware(blocklist, nil)))
+
+	result, err := handler.OnSendMessage(t.Context(), newTextParams("tell me a secret"))
+	if err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	if got := result.(*a2a.Message).Parts[0].(a2a.TextPart).Text; got != "tell me a ***" {
+		t.Fatalf("OnSendMessage() response = %q, want redacted text", got)
+	}
+
+	result, err = handler.OnSendMessage(t.Context(), newTextParams("forbidden question"))
+	if err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	task, ok := result.(*a2a.Task)
+	if !ok || task.Status.State != a2a.TaskStateRejected {
+		t.Fatalf("OnSendMessage() = %v, want rejected task", result)
+	}
+	if got := task.Status.Message.Parts[0].(a2a.TextPart).Text; got != "forbidden topic" {
+		t.Fatalf("rejection reason = %q, want %q", got, "forbidden topic")
+	}
+}
+
+func TestModerationMiddleware_Outbound(t *testing.T) {
+	handler := NewHandler(echoExecutor, WithExecutorMiddleware(ModerationMiddleware(nil, blocklist)))
+
+	result, err := handler.OnSendMessage(t.Context(), newTextParams("say forbidden"))
+	if err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	task, ok := result.(*a2a.Task)
+	if !ok || task.Status.State != a2a.TaskStateFailed {
+		t.Fatalf("OnSendMessage() = %v, want failed task", result)
+	}
+}
+
+func TestModerationMiddleware_OutboundStatusUpdate(t *testing.T) {
+	queue := eventqueue.NewInMemoryQueue(10)
+	rejectAll := outboundScreenerFn(func(ctx context.Context, event a2a.Event) (a2a.Event, error) {
+		return nil, &ContentRejectedError{Reason: "nope"}
+	})
+	var writeErr error
+	executor := ChainExecutor(&mockAgentExecutor{
+		ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+			task := &a2a.Task{ID: reqCtx.TaskID, ContextID: "ctx"}
+			writeErr = q.Write(ctx, a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil))
+			return writeErr
+		},
+	}, ModerationMiddleware(nil, rejectAll))
+
+	if err := executor.Execute(t.Context(), RequestContext{TaskID: taskID, ContextID: "ctx"}, queue); err != nil {
+		t.Fatalf("Execute() error = %v, want rejection to be handled", err)
+	}
+	var rejected *ContentRejectedError
+	if !errors.As(writeErr, &rejected) {
+		t.Fatalf("Write() error = %v, want ContentRejectedError", writeErr)
+	}
+	event, err := queue.Read(t.Context())
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	update, ok := event.(*a2a.TaskStatusUpdateEvent)
+	if !ok || update.Status.State != a2a.TaskStateFailed || !update.Final {
+		t.Fatalf("Read() = %v, want final failed status update", event)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// DefaultMaxInlineFileSize is the default limit of a file received in a multipart/form-data
+// request which is converted to FileBytes.
+const DefaultMaxInlineFileSize = 10 << 20
+
+// MultipartConfig configures NewMultipartMessageHandler.
+type MultipartConfig struct {
+	// Uploads stores received files, which are then passed to AgentExecutor through RequestContext.Uploads.
+	// The handler needs to be created WithUploads using the same store. If nil, files are converted
+	// to base64-encoded FileBytes.
+	Uploads *UploadStore
+	// MaxInlineSize limits the size of a file converted to FileBytes. DefaultMaxInlineFileSize is used if 0.
+	MaxInlineSize int64
+}
+
+// NewMultipartMessageHandler creates an http.Handler for 'message/send' requests submitted as
+// multipart/form-data. The a2a.MultipartMessageField field contains JSON-encoded MessageSendParams
+// and the rest of the fields contain raw file content. The Message references files with FileURI parts
+// created using a2a.MultipartFileURI. The response is a JSON object with either "task" or "message" set.
+// Sending binary inputs this way avoids base64 encoding overhead of FileBytes.
+func NewMultipartMessageHandler(handler RequestHandler, config MultipartConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		if err != nil || mediaType != "multipart/form-data" {
+			http.Error(w, "expected multipart/form-data request", http.StatusUnsupportedMediaType)
+			return
+		}
+
+		params, uploads, err := decodeMultipartMessage(req, config)
+		defer func() {
+			// uploads referenced by the message are removed by the handler after execution
+			for _, id := range uploads {
+				_ = config.Uploads.Remove(id)
+			}
+		}()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		result, err := handler.OnSendMessage(req.Context(), params)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, a2a.ErrInvalidRequest) {
+				status = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		var body struct {
+			Task    *a2a.Task    `json:"task,omitempty"`
+			Message *a2a.Message `json:"message,omitempty"`
+		}
+		switch v := result.(type) {
+		case *a2a.Task:
+			body.Task = v
+		case *a2a.Message:
+			body.Message = v
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+}
+
+// decodeMultipartMessage reads the message envelope and files from the request and replaces file
+// references with upload URIs or FileBytes. The returned upload IDs were not consumed by the message
+// and need to be removed by the caller.
+func decodeMultipartMessage(req *http.Request, config MultipartConfig) (a2a.MessageSendParams, []string, error) {
+	var params a2a.MessageSendParams
+	var unclaimed []string
+	reader, err := req.MultipartReader()
+	if err != nil {
+		return params, nil, err
+	}
+
+	maxInline := config.MaxInlineSize
+	if maxInline <= 0 {
+		maxInline = DefaultMaxInlineFileSize
+	}
+
+	hasEnvelope := false
+	files := make(map[string]a2a.FilePartContent)
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return params, unclaimed, fmt.Errorf("failed to read multipart request: %w", err)
+		}
+
+		field := part.FormName()
+		meta := a2a.FileMeta{Name: part.FileName(), MimeType: part.Header.Get("Content-Type")}
+		switch {
+		case field == a2a.MultipartMessageField:
+			if err := json.NewDecoder(part).Decode(&params); err != nil {
+				return params, unclaimed, fmt.Errorf("failed to decode %s: %w", field, err)
+			}
+			hasEnvelope = true
+
+		case config.Uploads != nil:
+			upload, err := config.Uploads.Create()
+			if err != nil {
+				return params, unclaimed, err
+			}
+			unclaimed = append(unclaimed, upload.ID)
+			if _, err := config.Uploads.Append(upload.ID, 0, part); err != nil {
+				return params, unclaimed, fmt.Errorf("failed to store %s: %w", field, err)
+			}
+			files[field] = a2a.FileURI{FileMeta: meta, URI: a2a.UploadURI(upload.ID)}
+
+		default:
+			content, err := io.ReadAll(io.LimitReader(part, maxInline+1))
+			if err != nil {
+				return params, unclaimed, fmt.Errorf("failed to read %s: %w", field, err)
+			}
+			if int64(len(content)) > maxInline {
+				return params, unclaimed, fmt.Errorf("%s: %w", field, ErrUploadTooLarge)
+			}
+			files[field] = a2a.FileBytes{FileMeta: meta, Bytes: base64.StdEncoding.EncodeToString(content)}
+		}
+	}
+	if !hasEnvelope {
+		return params, unclaimed, fmt.Errorf("missing %s field", a2a.MultipartMessageField)
+	}
+
+	claimed := make(map[string]bool)
+	for i, part := range params.Message.Parts {
+		filePart, ok := part.(a2a.FilePart)
+		if !ok {
+			continue
+		}
+		ref, ok := filePart.File.(a2a.FileURI)
+		if !ok {
+			continue
+		}
+		field, ok := a2a.MultipartFieldFromURI(ref.URI)
+		if !ok {
+			continue
+		}
+		content, ok := files[field]
+		if !ok {
+			return params, unclaimed, fmt.Errorf("message references missing field %s", field)
+		}
+		filePart.File = withFileMeta(content, ref.FileMeta)
+		params.Message.Parts[i] = filePart
+		if uri, ok := content.(a2a.FileURI); ok {
+			id, _ := a2a.UploadIDFromURI(uri.URI)
+			claimed[id] = true
+		}
+	}
+
+	var rest []string
+	for _, id := range unclaimed {
+		if !claimed[id] {
+			rest = append(rest, id)
+		}
+	}
+	return params, rest, nil
+}
+
+// withFileMeta overrides file metadata from a form part with the non-empty values from the message envelope.
+func withFileMeta(content a2a.FilePartContent, meta a2a.FileMeta) a2a.FilePartContent {
+	merge := func(m a2a.FileMeta) a2a.FileMeta {
+		if meta.Name != "" {
+			m.Name = meta.Name
+		}
+		if meta.MimeType != "" {
+			m.MimeType = meta.MimeType
+		}
+		return m
+	}
+	switch v := content.(type) {
+	case a2a.FileURI:
+		v.FileMeta = merge(v.FileMeta)
+		return v
+	case a2a.FileBytes:
+		v.FileMeta = merge(v.FileMeta)
+		return v
+	}
+	return content
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+func newMultipartRequest(t *testing.T, params a2a.MessageSendParams, files map[string]string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	envelope, err := writer.CreateFormField(a2a.MultipartMessageField)
+	if err != nil {
+		t.Fatalf("CreateFormField() error = %v", err)
+	}
+	if err := json.NewEncoder(envelope).Encode(params); err != nil {
+		t.Fatalf("json.Encode() error = %v", err)
+	}
+	for field, content := range files {
+		part, err := writer.CreateFormFile(field, field+".bin")
+		if err != nil {
+			t.Fatalf("CreateFormFile() error = %v", err)
+		}
+		_, _ = io.WriteString(part, content)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("writer.Close() error = %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/v1/message:send", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestMultipartMessageHandler(t *testing.T) {
+	ref := a2a.FilePart{File: a2a.FileURI{FileMeta: a2a.FileMeta{MimeType: "image/png"}, URI: a2a.MultipartFileURI("image")}}
+	params := a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID, ID: "request", Parts: a2a.ContentParts{a2a.TextPart{Text: "describe"}, ref}}}
+
+	testCases := []struct {
+		name    string
+		uploads bool
+	}{
+		{name: "inline"},
+		{name: "uploads", uploads: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := MultipartConfig{}
+			var opts []RequestHandlerOption
+			if tc.uploads {
+				config.Uploads = NewUploadStore(t.TempDir(), 0)
+				opts = append(opts, WithUploads(config.Uploads))
+			}
+
+			var gotMeta a2a.FileMeta
+			var gotContent string
+			executor := &mockAgentExecutor{
+				ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+					switch file := reqCtx.Request.Message.Parts[1].(a2a.FilePart).File.(type) {
+					case a2a.FileBytes:
+						content, _ := base64.StdEncoding.DecodeString(file.Bytes)
+						gotMeta, gotContent = file.FileMeta, string(content)
+					case a2a.FileURI:
+						f, err := reqCtx.Uploads[file.URI].Open()
+						if err != nil {
+							return err
+						}
+						defer func() { _ = f.Close() }()
+						content, _ := io.ReadAll(f)
+						gotMeta, gotContent = file.FileMeta, string(content)
+					}
+					return q.Write(ctx, &a2a.Task{ID: reqCtx.TaskID, Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}})
+				},
+			}
+			handler := NewMultipartMessageHandler(NewHandler(executor, opts...), config)
+
+			files := map[string]string{"image": "\x89PNG raw bytes", "unreferenced": "ignored"}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, newMultipartRequest(t, params, files))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("ServeHTTP() status = %d, body = %s", rec.Code, rec.Body.String())
+			}
+
+			var resp struct{ Task *a2a.Task }
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil || resp.Task == nil || resp.Task.ID != taskID {
+				t.Fatalf("response = %+v, %v, want task %s", resp, err, taskID)
+			}
+			wantMeta := a2a.FileMeta{Name: "image.bin", MimeType: "image/png"}
+			if gotMeta != wantMeta || gotContent != files["image"] {
+				t.Fatalf("executor got %+v %q, want %+v %q", gotMeta, gotContent, wantMeta, files["image"])
+			}
+			if config.Uploads != nil && config.Uploads.RemoveStale(0) != 0 {
+				t.Fatal("uploads were left after the request")
+			}
+		})
+	}
+}
+
+func TestMultipartMessageHandler_BadRequest(t *testing.T) {
+	handler := NewMultipartMessageHandler(newTestHandler(), MultipartConfig{MaxInlineSize: 4})
+	missingRef := a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID, Parts: a2a.ContentParts{
+		a2a.FilePart{File: a2a.FileURI{URI: a2a.MultipartFileURI("missing")}},
+	}}}
+	tooLarge := a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID, Parts: a2a.ContentParts{
+		a2a.FilePart{File: a2a.FileURI{URI: a2a.MultipartFileURI("file")}},
+	}}}
+
+	testCases := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{name: "missing file", req: newMultipartRequest(t, missingRef, nil), want: http.StatusBadRequest},
+		{name: "file too large", req: newMultipartRequest(t, tooLarge, map[string]string{"file": "12345"}), want: http.StatusBadRequest},
+		{name: "not multipart", req: httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("{}"))), want: http.StatusUnsupportedMediaType},
+		{name: "wrong method", req: httptest.NewRequest(http.MethodGet, "/", nil), want: http.StatusMethodNotAllowed},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, tc.req)
+			if rec.Code != tc.want {
+				t.Fatalf("ServeHTTP() status = %d, want %d", rec.Code, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// ContentNegotiationMiddleware creates an AgentExecutorMiddleware which rejects messages with parts
+// the agent doesn't accept. A message for a skill must match the skill InputModes or the card
+// DefaultInputModes if the skill doesn't declare any. A message which doesn't reference a skill
+// must match the DefaultInputModes or InputModes of any skill.
+//
+// A rejected message moves the Task to TaskStateRejected and the status message lists the accepted
+// modes under a2a.RejectionMetaKey. Dry run requests fail with ContentRejectedError.
+// The middleware is applied by the handler created with WithAgentCard.
+func ContentNegotiationMiddleware(card AgentCardProducer) AgentExecutorMiddleware {
+	return func(next AgentExecutor) AgentExecutor {
+		return &AgentExecutorFuncs{
+			Next: next,
+			ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+				if err := negotiateContent(card.Card(), reqCtx); err != nil {
+					var rejected *ContentRejectedError
+					if errors.As(err, &rejected) {
+						return queue.Write(ctx, rejectedTask(reqCtx, a2a.TaskStateRejected, rejected))
+					}
+					return err
+				}
+				return next.Execute(ctx, reqCtx, queue)
+			},
+			ValidateFunc: func(ctx context.Context, reqCtx RequestContext) error {
+				if err := negotiateContent(card.Card(), reqCtx); err != nil {
+					return err
+				}
+				return validateRequest(ctx, next, reqCtx)
+			},
+		}
+	}
+}
+
+func negotiateContent(card *a2a.AgentCard, reqCtx RequestContext) error {
+	modes := acceptedInputModes(card, reqCtx)
+	unsupported := a2a.UnsupportedMediaTypes(&reqCtx.Request.Message, modes)
+	if len(unsupported) == 0 {
+		return nil
+	}
+	return &ContentRejectedError{
+		Reason: fmt.Sprintf("unsupported content types %s, accepted: %s", strings.Join(unsupported, ", "), strings.Join(modes, ", ")),
+		Rejection: &a2a.Rejection{
+			Reason:             a2a.RejectionReasonUnsupportedContentType,
+			UnsupportedTypes:   unsupported,
+			AcceptedInputModes: modes,
+		},
+	}
+}
+
+// acceptedInputModes returns the modes accepted by the skill referenced by the request or by
+// any skill if there's no reference. Nil is returned if the card doesn't restrict input modes.
+func acceptedInputModes(card *a2a.AgentCard, reqCtx RequestContext) []string {
+	skillID := reqCtx.SkillID
+	if skillID == "" {
+		skillID = a2a.SkillIDOf(&reqCtx.Request.Message)
+	}
+	if skillID != "" {
+		for _, skill := range card.Skills {
+			if skill.ID != skillID {
+				continue
+			}
+			if len(skill.InputModes) > 0 {
+				return skill.InputModes
+			}
+			return card.DefaultInputModes
+		}
+		// unknown skills are reported by the skill router
+		return nil
+	}
+
+	if len(card.DefaultInputModes) == 0 {
+		return nil
+	}
+	modes := slices.Clone(card.DefaultInputModes)
+	for _, skill := range card.Skills {
+		for _, mode := range skill.InputModes {
+			if !slices.Contains(modes, mode) {
+				modes = append(modes, mode)
+			}
+		}
+	}
+	return modes
+}
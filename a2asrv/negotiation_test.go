@@ -0,0 +1,123 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestContentNegotiation(t *testing.T) {
+	card := &a2a.AgentCard{
+		DefaultInputModes: []string{"text/plain", "application/json"},
+		Skills: []a2a.AgentSkill{
+			{ID: "vision", InputModes: []string{"image/*"}},
+			{ID: "chat"},
+		},
+	}
+	handler := NewHandler(echoExecutor, WithAgentCard(AgentCardProducerFn(func() *a2a.AgentCard { return card })))
+	image := a2a.FilePart{File: a2a.FileBytes{FileMeta: a2a.FileMeta{MimeType: "image/png"}, Bytes: "AA=="}}
+	audio := a2a.FilePart{File: a2a.FileBytes{FileMeta: a2a.FileMeta{MimeType: "audio/wav"}, Bytes: "AA=="}}
+
+	testCases := []struct {
+		name  string
+		skill string
+		parts []a2a.Part
+		want  *a2a.Rejection
+	}{
+		{
+			name:  "default modes",
+			parts: []a2a.Part{a2a.TextPart{Text: "hi"}},
+		},
+		{
+			name:  "any skill mode",
+			parts: []a2a.Part{image},
+		},
+		{
+			name:  "skill modes",
+			skill: "vision",
+			parts: []a2a.Part{image},
+		},
+		{
+			name:  "not accepted by skill",
+			skill: "vision",
+			parts: []a2a.Part{a2a.TextPart{Text: "what's this?"}, image},
+			want: &a2a.Rejection{
+				Reason:             a2a.RejectionReasonUnsupportedContentType,
+				UnsupportedTypes:   []string{"text/plain"},
+				AcceptedInputModes: []string{"image/*"},
+			},
+		},
+		{
+			name:  "skill without modes uses defaults",
+			skill: "chat",
+			parts: []a2a.Part{image},
+			want: &a2a.Rejection{
+				Reason:             a2a.RejectionReasonUnsupportedContentType,
+				UnsupportedTypes:   []string{"image/png"},
+				AcceptedInputModes: []string{"text/plain", "application/json"},
+			},
+		},
+		{
+			name:  "not accepted by any skill",
+			parts: []a2a.Part{audio, audio},
+			want: &a2a.Rejection{
+				Reason:             a2a.RejectionReasonUnsupportedContentType,
+				UnsupportedTypes:   []string{"audio/wav"},
+				AcceptedInputModes: []string{"text/plain", "application/json", "image/*"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := a2a.Message{TaskID: taskID, Parts: tc.parts}
+			if tc.skill != "" {
+				msg.Metadata = map[string]any{a2a.SkillIDMetaKey: tc.skill}
+			}
+			params := a2a.MessageSendParams{Message: msg}
+
+			result, err := handler.OnSendMessage(t.Context(), params)
+			if err != nil {
+				t.Fatalf("OnSendMessage() error = %v", err)
+			}
+			dryRunResult, err := handler.OnSendMessage(t.Context(), a2a.WithDryRun(params))
+			if err != nil {
+				t.Fatalf("OnSendMessage() dry run error = %v", err)
+			}
+
+			if tc.want == nil {
+				if _, ok := result.(*a2a.Message); !ok {
+					t.Fatalf("OnSendMessage() = %v, want the echoed message", result)
+				}
+				if task := dryRunResult.(*a2a.Task); task.Status.State != a2a.TaskStateSubmitted {
+					t.Fatalf("dry run state = %s, want %s", task.Status.State, a2a.TaskStateSubmitted)
+				}
+				return
+			}
+			for _, result := range []a2a.SendMessageResult{result, dryRunResult} {
+				task, ok := result.(*a2a.Task)
+				if !ok || task.Status.State != a2a.TaskStateRejected {
+					t.Fatalf("OnSendMessage() = %v, want rejected task", result)
+				}
+				got, ok := a2a.RejectionFromMeta(task.Status.Message.Metadata)
+				if !ok || !reflect.DeepEqual(got, *tc.want) {
+					t.Fatalf("rejection = %+v, want %+v", got, *tc.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+const (
+	defaultRelayInterval  = time.Second
+	defaultRelayBatchSize = 100
+	maxRelayBackoff       = 5 * time.Minute
+)
+
+// PushOutboxRecord is a pending push notification which was committed together with a Task update.
+type PushOutboxRecord struct {
+	// ID identifies the record within the outbox.
+	ID string
+	// Task is the Task snapshot which needs to be delivered.
+	Task a2a.Task
+}
+
+// PushOutbox is implemented by persistent TaskStores which record a push notification in the same
+// transaction as the Task update, so that a notification can't get lost if the process dies between
+// TaskStore.Save and PushNotifier.SendPush. Recorded notifications are delivered by PushOutboxRelay.
+type PushOutbox interface {
+	// Pending returns up to limit undelivered records in the order they were committed.
+	Pending(ctx context.Context, limit int) ([]PushOutboxRecord, error)
+
+	// Ack marks a record as delivered so that it is not returned by Pending anymore.
+	Ack(ctx context.Context, id string) error
+}
+
+// PushOutboxRelay delivers push notifications recorded in a PushOutbox using a PushNotifier.
+// Delivery is at-least-once: a record is acknowledged only after SendPush succeeded.
+// Records of a Task are delivered in the order they were committed. After a failed delivery the remaining
+// records of the Task are skipped and the Task is backed off, starting with Interval and doubling up to
+// five minutes, while the records of other Tasks continue to be delivered.
+type PushOutboxRelay struct {
+	// Outbox is the source of pending notifications.
+	Outbox PushOutbox
+	// Notifier is used for delivering notifications.
+	Notifier PushNotifier
+	// Interval is the delay between outbox polls. Defaults to one second.
+	Interval time.Duration
+	// BatchSize is the maximum number of records delivered per poll. Defaults to 100.
+	BatchSize int
+	// OnError is called by Run with the errors of every poll. Errors are logged with slog.Default if not set.
+	OnError func(ctx context.Context, err error)
+
+	mu      sync.Mutex
+	backoff map[a2a.TaskID]relayBackoff
+}
+
+// relayBackoff delays the delivery of the records of a Task after a failure.
+type relayBackoff struct {
+	until time.Time
+	delay time.Duration
+}
+
+// RelayPending delivers a single batch of pending notifications and returns the number of acknowledged records.
+// Records which failed to be delivered are left in the outbox and retried on a later call once the backoff of
+// their Task passed. The returned error joins the errors of all the Tasks which failed.
+func (r *PushOutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	now := ClockFrom(ctx).Now()
+	records, err := r.pending(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	failed := make(map[a2a.TaskID]bool)
+	var errs []error
+	for _, record := range records {
+		taskID := record.Task.ID
+		if failed[taskID] {
+			continue
+		}
+		if err := r.Notifier.SendPush(ctx, record.Task); err != nil {
+			failed[taskID] = true
+			r.backOff(taskID, now)
+			errs = append(errs, fmt.Errorf("failed to deliver notification %s of task %s: %w", record.ID, taskID, err))
+			continue
+		}
+		r.reset(taskID)
+		if err := r.Outbox.Ack(ctx, record.ID); err != nil {
+			// the record is delivered again, so the following records of the Task must wait
+			failed[taskID] = true
+			errs = append(errs, fmt.Errorf("failed to ack notification %s of task %s: %w", record.ID, taskID, err))
+			continue
+		}
+		delivered++
+	}
+	return delivered, errors.Join(errs...)
+}
+
+// pending returns up to BatchSize records of Tasks which are not backed off. The records of backed off Tasks
+// are read too, because PushOutbox returns records in the commit order, so the limit is raised until enough
+// records are found or the outbox has no more.
+func (r *PushOutboxRelay) pending(ctx context.Context, now time.Time) ([]PushOutboxRecord, error) {
+	batchSize := r.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultRelayBatchSize
+	}
+
+	limit := batchSize
+	for {
+		records, err := r.Outbox.Pending(ctx, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pending notifications: %w", err)
+		}
+		ready := make([]PushOutboxRecord, 0, len(records))
+		for _, record := range records {
+			if !r.backedOff(record.Task.ID, now) {
+				ready = append(ready, record)
+			}
+		}
+		if len(ready) >= batchSize || len(records) < limit {
+			return ready[:min(len(ready), batchSize)], nil
+		}
+		limit = batchSize + len(records) - len(ready)
+	}
+}
+
+func (r *PushOutboxRelay) backedOff(taskID a2a.TaskID, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.backoff[taskID]
+	return ok && now.Before(b.until)
+}
+
+func (r *PushOutboxRelay) backOff(taskID a2a.TaskID, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.backoff == nil {
+		r.backoff = make(map[a2a.TaskID]relayBackoff)
+	}
+	delay := r.interval()
+	if b, ok := r.backoff[taskID]; ok {
+		delay = min(2*b.delay, maxRelayBackoff)
+	}
+	r.backoff[taskID] = relayBackoff{until: now.Add(delay), delay: delay}
+}
+
+func (r *PushOutboxRelay) reset(taskID a2a.TaskID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.backoff, taskID)
+}
+
+func (r *PushOutboxRelay) interval() time.Duration {
+	if r.Interval <= 0 {
+		return defaultRelayInterval
+	}
+	return r.Interval
+}
+
+// Run polls the outbox and delivers notifications until the context is canceled.
+// Delivery errors do not stop the relay, they are reported to OnError and failed records are retried later.
+func (r *PushOutboxRelay) Run(ctx context.Context) error {
+	onError := r.OnError
+	if onError == nil {
+		onError = logRelayError
+	}
+
+	ticker := time.NewTicker(r.interval())
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.RelayPending(ctx); err != nil && ctx.Err() == nil {
+			onError(ctx, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func logRelayError(ctx context.Context, err error) {
+	slog.Default().LogAttrs(ctx, slog.LevelWarn, "a2a push outbox relay failed", slog.String("error", err.Error()))
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+type testOutbox struct {
+	records []PushOutboxRecord
+}
+
+func (o *testOutbox) Pending(ctx context.Context, limit int) ([]PushOutboxRecord, error) {
+	n := min(limit, len(o.records))
+	return append([]PushOutboxRecord{}, o.records[:n]...), nil
+}
+
+func (o *testOutbox) Ack(ctx context.Context, id string) error {
+	for i, r := range o.records {
+		if r.ID == id {
+			o.records = append(o.records[:i], o.records[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("record not found")
+}
+
+type testPushNotifier struct {
+	sent []a2a.TaskID
+	fail map[a2a.TaskID]error
+}
+
+func (n *testPushNotifier) SendPush(ctx context.Context, task a2a.Task) error {
+	if err, ok := n.fail[task.ID]; ok {
+		return err
+	}
+	n.sent = append(n.sent, task.ID)
+	return nil
+}
+
+func TestPushOutboxRelay_RelayPending(t *testing.T) {
+	outbox := &testOutbox{records: []PushOutboxRecord{
+		{ID: "1", Task: a2a.Task{ID: "task-1"}},
+		{ID: "2", Task: a2a.Task{ID: "task-2"}},
+		{ID: "3", Task: a2a.Task{ID: "task-3"}},
+	}}
+	notifier := &testPushNotifier{}
+	relay := &PushOutboxRelay{Outbox: outbox, Notifier: notifier, BatchSize: 2}
+
+	delivered, err := relay.RelayPending(t.Context())
+	if err != nil {
+		t.Fatalf("RelayPending() error = %v", err)
+	}
+	if delivered != 2 {
+		t.Fatalf("RelayPending() delivered = %d, want 2", delivered)
+	}
+
+	delivered, err = relay.RelayPending(t.Context())
+	if err != nil {
+		t.Fatalf("RelayPending() error = %v", err)
+	}
+	if delivered != 1 {
+		t.Fatalf("RelayPending() delivered = %d, want 1", delivered)
+	}
+
+	want := []a2a.TaskID{"task-1", "task-2", "task-3"}
+	if !reflect.DeepEqual(notifier.sent, want) {
+		t.Fatalf("notifications sent = %v, want %v", notifier.sent, want)
+	}
+	if len(outbox.records) != 0 {
+		t.Fatalf("outbox has %d records after delivery, want 0", len(outbox.records))
+	}
+}
+
+func TestPushOutboxRelay_FailedDeliveryKeepsRecord(t *testing.T) {
+	outbox := &testOutbox{records: []PushOutboxRecord{
+		{ID: "1", Task: a2a.Task{ID: "task-1"}},
+		{ID: "2", Task: a2a.Task{ID: "task-2"}},
+	}}
+	sendErr := errors.New("webhook unavailable")
+	notifier := &testPushNotifier{fail: map[a2a.TaskID]error{"task-1": sendErr}}
+	relay := &PushOutboxRelay{Outbox: outbox, Notifier: notifier}
+
+	delivered, err := relay.RelayPending(t.Context())
+	if !errors.Is(err, sendErr) {
+		t.Fatalf("RelayPending() error = %v, want %v", err, sendErr)
+	}
+	if delivered != 1 {
+		t.Fatalf("RelayPending() delivered = %d, want 1", delivered)
+	}
+	if len(outbox.records) != 1 || outbox.records[0].ID != "1" {
+		t.Fatalf("outbox records after failed delivery = %v, want the record of task-1", outbox.records)
+	}
+}
+
+func TestPushOutboxRelay_FailingTaskIsBackedOff(t *testing.T) {
+	outbox := &testOutbox{records: []PushOutboxRecord{
+		{ID: "1", Task: a2a.Task{ID: "dead"}},
+		{ID: "2", Task: a2a.Task{ID: "dead"}},
+		{ID: "3", Task: a2a.Task{ID: "dead"}},
+		{ID: "4", Task: a2a.Task{ID: "task-1"}},
+		{ID: "5", Task: a2a.Task{ID: "task-2"}},
+	}}
+	sendErr := errors.New("webhook unavailable")
+	notifier := &counterPushNotifier{testPushNotifier: testPushNotifier{fail: map[a2a.TaskID]error{"dead": sendErr}}}
+	relay := &PushOutboxRelay{Outbox: outbox, Notifier: notifier, BatchSize: 2, Interval: time.Second}
+	clock := &manualClock{now: time.Now()}
+	ctx := WithClock(t.Context(), clock)
+
+	// the remaining records of the failed Task are skipped
+	if _, err := relay.RelayPending(ctx); !errors.Is(err, sendErr) {
+		t.Fatalf("RelayPending() error = %v, want %v", err, sendErr)
+	}
+	if notifier.attempts["dead"] != 1 {
+		t.Fatalf("delivery of dead attempted %d times, want 1", notifier.attempts["dead"])
+	}
+
+	// while the Task is backed off, records of other Tasks are delivered even if they are behind a full batch
+	delivered, err := relay.RelayPending(ctx)
+	if err != nil || delivered != 2 {
+		t.Fatalf("RelayPending() = (%d, %v), want 2 delivered", delivered, err)
+	}
+	if want := []a2a.TaskID{"task-1", "task-2"}; !reflect.DeepEqual(notifier.sent, want) {
+		t.Fatalf("notifications sent = %v, want %v", notifier.sent, want)
+	}
+	if notifier.attempts["dead"] != 1 {
+		t.Fatalf("delivery of backed off task attempted %d times, want 1", notifier.attempts["dead"])
+	}
+
+	// the backoff doubles after every failure
+	for _, wait := range []time.Duration{time.Second, 2 * time.Second} {
+		clock.now = clock.now.Add(wait)
+		if _, err := relay.RelayPending(ctx); !errors.Is(err, sendErr) {
+			t.Fatalf("RelayPending() after %v error = %v, want %v", wait, err, sendErr)
+		}
+	}
+	clock.now = clock.now.Add(2 * time.Second)
+	if _, err := relay.RelayPending(ctx); err != nil {
+		t.Fatalf("RelayPending() within backoff error = %v", err)
+	}
+	if notifier.attempts["dead"] != 3 {
+		t.Fatalf("delivery of dead attempted %d times, want 3", notifier.attempts["dead"])
+	}
+}
+
+// counterPushNotifier counts delivery attempts per Task.
+type counterPushNotifier struct {
+	testPushNotifier
+	attempts map[a2a.TaskID]int
+}
+
+func (n *counterPushNotifier) SendPush(ctx context.Context, task a2a.Task) error {
+	if n.attempts == nil {
+		n.attempts = make(map[a2a.TaskID]int)
+	}
+	n.attempts[task.ID]++
+	return n.testPushNotifier.SendPush(ctx, task)
+}
+
+func TestPushOutboxRelay_RunReportsErrors(t *testing.T) {
+	outbox := &testOutbox{records: []PushOutboxRecord{{ID: "1", Task: a2a.Task{ID: "task-1"}}}}
+	sendErr := errors.New("webhook unavailable")
+	reported := make(chan error, 1)
+	relay := &PushOutboxRelay{
+		Outbox:   outbox,
+		Notifier: &testPushNotifier{fail: map[a2a.TaskID]error{"task-1": sendErr}},
+		Interval: time.Hour,
+		OnError: func(ctx context.Context, err error) {
+			select {
+			case reported <- err:
+			default:
+			}
+		},
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() { done <- relay.Run(ctx) }()
+	if err := <-reported; !errors.Is(err, sendErr) {
+		t.Fatalf("reported error = %v, want %v", err, sendErr)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// Paginate returns the page of items referenced by the page token and the token of the next page,
+// which is empty when the returned page is the last one. Tokens are opaque offsets into items, so
+// the implementation which lists items must return them in a stable order.
+// Returns an error wrapping a2a.ErrInvalidRequest for invalid page sizes and tokens.
+func Paginate[T any](items []T, pageSize int, pageToken string) ([]T, string, error) {
+	size, err := a2a.EffectivePageSize(pageSize)
+	if err != nil {
+		return nil, "", err
+	}
+	offset, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if offset > len(items) {
+		return nil, "", fmt.Errorf("%w: page token is out of range", a2a.ErrInvalidRequest)
+	}
+
+	end := min(offset+size, len(items))
+	next := ""
+	if end < len(items) {
+		next = encodePageToken(end)
+	}
+	return items[offset:end], next, nil
+}
+
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed page token", a2a.ErrInvalidRequest)
+	}
+	offset, err := strconv.Atoi(string(decoded))
+	if err != nil || offset <= 0 {
+		return 0, fmt.Errorf("%w: malformed page token", a2a.ErrInvalidRequest)
+	}
+	return offset, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestPaginate(t *testing.T) {
+	items := make([]int, 250)
+	for i := range items {
+		items[i] = i
+	}
+
+	var got []int
+	var pages []int
+	token := ""
+	for {
+		page, next, err := Paginate(items, 0, token)
+		if err != nil {
+			t.Fatalf("Paginate() error = %v", err)
+		}
+		got = append(got, page...)
+		pages = append(pages, len(page))
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	if !slices.Equal(got, items) {
+		t.Fatalf("Paginate() returned %d items, want %d in order", len(got), len(items))
+	}
+	if want := []int{a2a.DefaultPageSize, a2a.DefaultPageSize, a2a.DefaultPageSize, a2a.DefaultPageSize, a2a.DefaultPageSize}; !slices.Equal(pages, want) {
+		t.Fatalf("Paginate() page sizes = %v, want %v", pages, want)
+	}
+}
+
+func TestPaginate_PageSize(t *testing.T) {
+	items := make([]int, 250)
+	tests := []struct {
+		pageSize int
+		want     int
+	}{
+		{pageSize: 10, want: 10},
+		{pageSize: 1000, want: a2a.MaxPageSize},
+	}
+	for _, tt := range tests {
+		page, next, err := Paginate(items, tt.pageSize, "")
+		if err != nil {
+			t.Fatalf("Paginate(%d) error = %v", tt.pageSize, err)
+		}
+		if len(page) != tt.want || next == "" {
+			t.Fatalf("Paginate(%d) = %d items, next %q, want %d items and a next page", tt.pageSize, len(page), next, tt.want)
+		}
+	}
+
+	page, next, err := Paginate([]int{}, 0, "")
+	if err != nil || len(page) != 0 || next != "" {
+		t.Fatalf("Paginate(empty) = %v, %q, %v, want an empty last page", page, next, err)
+	}
+}
+
+func TestPaginate_InvalidParams(t *testing.T) {
+	items := []int{1, 2, 3}
+	tests := []struct {
+		name      string
+		pageSize  int
+		pageToken string
+	}{
+		{name: "negative size", pageSize: -1},
+		{name: "malformed token", pageToken: "not base64!"},
+		{name: "non-numeric token", pageToken: "YWJj"},
+		{name: "out of range token", pageToken: encodePageToken(10)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := Paginate(items, tt.pageSize, tt.pageToken); !errors.Is(err, a2a.ErrInvalidRequest) {
+				t.Fatalf("Paginate() error = %v, want %v", err, a2a.ErrInvalidRequest)
+			}
+		})
+	}
+}
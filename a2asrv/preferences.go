@@ -0,0 +1,35 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+type preferencesKey struct{}
+
+// PreferencesFrom returns the user preferences sent with the message which triggered the execution.
+// The handler attaches them to the context passed to AgentExecutor, so that tools called deep within
+// the executor can localize their output without access to RequestContext.
+func PreferencesFrom(ctx context.Context) (a2a.Preferences, bool) {
+	prefs, ok := ctx.Value(preferencesKey{}).(a2a.Preferences)
+	return prefs, ok
+}
+
+func withPreferences(ctx context.Context, prefs a2a.Preferences) context.Context {
+	return context.WithValue(ctx, preferencesKey{}, prefs)
+}
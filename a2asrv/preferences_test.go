@@ -0,0 +1,49 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+func TestDefaultRequestHandler_Preferences(t *testing.T) {
+	want := a2a.Preferences{Locale: "ja-JP", TimeZone: "Asia/Tokyo", Units: a2a.UnitSystemMetric}
+	var fromReqCtx, fromCtx a2a.Preferences
+	executor := &mockAgentExecutor{ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+		fromReqCtx = reqCtx.Preferences
+		fromCtx, _ = PreferencesFrom(ctx)
+		return queue.Write(ctx, &a2a.Message{TaskID: reqCtx.TaskID})
+	}}
+	handler := NewHandler(executor)
+
+	msg := a2a.Message{TaskID: taskID}
+	a2a.SetPreferences(&msg, want)
+	if _, err := handler.OnSendMessage(t.Context(), a2a.MessageSendParams{Message: msg}); err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	if fromReqCtx != want || fromCtx != want {
+		t.Fatalf("executor got preferences %+v and %+v from context, want %+v", fromReqCtx, fromCtx, want)
+	}
+
+	msg.Metadata[a2a.PreferencesMetaKey] = map[string]any{"units": "cubits"}
+	if _, err := handler.OnSendMessage(t.Context(), a2a.MessageSendParams{Message: msg}); !errors.Is(err, a2a.ErrInvalidRequest) {
+		t.Fatalf("OnSendMessage() with malformed preferences error = %v, want %v", err, a2a.ErrInvalidRequest)
+	}
+}
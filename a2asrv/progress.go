@@ -0,0 +1,147 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// ProgressMetaKey is the TaskStatusUpdateEvent metadata key under which Progress reports are stored.
+// The value is a map with "percent", "stage" and "message" keys.
+const ProgressMetaKey = "progress"
+
+// DefaultProgressInterval is the minimum time between two progress updates emitted by Progress.
+const DefaultProgressInterval = time.Second
+
+// ProgressReport describes how far a long-running Task got.
+type ProgressReport struct {
+	// Percent is the completion percentage in the range [0, 100].
+	Percent float64
+	// Stage is an optional name of the current execution phase, eg. "downloading".
+	Stage string
+	// Message is an optional human-readable description of the current activity.
+	Message string
+}
+
+func (r ProgressReport) toMeta() map[string]any {
+	meta := map[string]any{"percent": r.Percent}
+	if r.Stage != "" {
+		meta["stage"] = r.Stage
+	}
+	if r.Message != "" {
+		meta["message"] = r.Message
+	}
+	return meta
+}
+
+// ProgressFromMeta extracts a report stored under ProgressMetaKey in Task or event metadata.
+func ProgressFromMeta(meta map[string]any) (ProgressReport, bool) {
+	entry, ok := meta[ProgressMetaKey].(map[string]any)
+	if !ok {
+		return ProgressReport{}, false
+	}
+	var report ProgressReport
+	switch v := entry["percent"].(type) {
+	case float64:
+		report.Percent = v
+	case int:
+		report.Percent = float64(v)
+	default:
+		return ProgressReport{}, false
+	}
+	report.Stage, _ = entry["stage"].(string)
+	report.Message, _ = entry["message"].(string)
+	return report, true
+}
+
+// Progress emits TaskStatusUpdateEvents in TaskStateWorking with a ProgressReport attached to the event
+// metadata. Updates are rate limited, so that an executor can report progress from a tight loop:
+// a report is written only if Interval passed since the previous one, the stage changed or
+// the work is complete. Skipped reports are not lost, the latest one can be written using Flush.
+// Progress is safe for concurrent use.
+type Progress struct {
+	// Interval is the minimum time between two written updates. DefaultProgressInterval is used if zero.
+	Interval time.Duration
+
+	task  *a2a.Task
+	queue eventqueue.Writer
+
+	mu      sync.Mutex
+	last    time.Time
+	written *ProgressReport
+	pending *ProgressReport
+}
+
+// NewProgress creates a Progress reporter for the Task which writes updates to the queue.
+func NewProgress(task *a2a.Task, queue eventqueue.Writer) *Progress {
+	return &Progress{task: task, queue: queue}
+}
+
+// Report records the progress and writes it to the queue unless it's throttled.
+// Percent is clamped to [0, 100]. Time is measured using the Clock attached to the context.
+func (p *Progress) Report(ctx context.Context, percent float64, stage, message string) error {
+	report := ProgressReport{Percent: min(max(percent, 0), 100), Stage: stage, Message: message}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := ClockFrom(ctx).Now()
+	if !p.shouldWrite(now, report) {
+		p.pending = &report
+		return nil
+	}
+	return p.write(ctx, now, report)
+}
+
+// Flush writes the latest throttled report, if there is one.
+func (p *Progress) Flush(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.pending == nil {
+		return nil
+	}
+	return p.write(ctx, ClockFrom(ctx).Now(), *p.pending)
+}
+
+func (p *Progress) shouldWrite(now time.Time, report ProgressReport) bool {
+	if p.written == nil || report.Percent >= 100 || report.Stage != p.written.Stage {
+		return true
+	}
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultProgressInterval
+	}
+	return now.Sub(p.last) >= interval
+}
+
+func (p *Progress) write(ctx context.Context, now time.Time, report ProgressReport) error {
+	var msg *a2a.Message
+	if report.Message != "" {
+		msg = a2a.NewMessageForTask(a2a.MessageRoleAgent, *p.task, a2a.TextPart{Text: report.Message})
+	}
+	event := a2a.NewStatusUpdateEvent(p.task, a2a.TaskStateWorking, msg)
+	event.Metadata = map[string]any{ProgressMetaKey: report.toMeta()}
+	if err := p.queue.Write(ctx, event); err != nil {
+		return err
+	}
+	p.last, p.written, p.pending = now, &report, nil
+	return nil
+}
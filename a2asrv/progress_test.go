@@ -0,0 +1,134 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- c.now.Add(d)
+	return ch
+}
+
+type recordingWriter struct {
+	events []a2a.Event
+}
+
+func (w *recordingWriter) Write(ctx context.Context, event a2a.Event) error {
+	w.events = append(w.events, event)
+	return nil
+}
+
+func (w *recordingWriter) reports(t *testing.T) []ProgressReport {
+	t.Helper()
+	var result []ProgressReport
+	for _, event := range w.events {
+		update := event.(*a2a.TaskStatusUpdateEvent)
+		if update.Status.State != a2a.TaskStateWorking {
+			t.Fatalf("progress update state = %v, want working", update.Status.State)
+		}
+		report, ok := ProgressFromMeta(update.Metadata)
+		if !ok {
+			t.Fatalf("progress update metadata = %v, want a report", update.Metadata)
+		}
+		result = append(result, report)
+	}
+	return result
+}
+
+func TestProgress_Throttling(t *testing.T) {
+	clock := &manualClock{now: time.Unix(0, 0)}
+	ctx := WithClock(t.Context(), clock)
+	writer := &recordingWriter{}
+	progress := NewProgress(&a2a.Task{ID: taskID}, writer)
+
+	for i := range 50 {
+		if err := progress.Report(ctx, float64(i), "download", ""); err != nil {
+			t.Fatalf("Report() error = %v", err)
+		}
+	}
+	clock.now = clock.now.Add(DefaultProgressInterval)
+	if err := progress.Report(ctx, 60, "download", ""); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if err := progress.Report(ctx, 0, "extract", "extracting files"); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if err := progress.Report(ctx, 10, "extract", ""); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if err := progress.Report(ctx, 150, "extract", ""); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	want := []ProgressReport{
+		{Percent: 0, Stage: "download"},
+		{Percent: 60, Stage: "download"},
+		{Percent: 0, Stage: "extract", Message: "extracting files"},
+		{Percent: 100, Stage: "extract"},
+	}
+	got := writer.reports(t)
+	if len(got) != len(want) {
+		t.Fatalf("reports = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("reports = %v, want %v", got, want)
+		}
+	}
+	if msg := writer.events[2].(*a2a.TaskStatusUpdateEvent).Status.Message; msg == nil || msg.TaskID != taskID {
+		t.Fatalf("status message = %v, want a message referencing the task", msg)
+	}
+}
+
+func TestProgress_Flush(t *testing.T) {
+	ctx := WithClock(t.Context(), &manualClock{now: time.Unix(0, 0)})
+	writer := &recordingWriter{}
+	progress := NewProgress(&a2a.Task{ID: taskID}, writer)
+
+	if err := progress.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	for _, percent := range []float64{10, 20, 30} {
+		if err := progress.Report(ctx, percent, "", ""); err != nil {
+			t.Fatalf("Report() error = %v", err)
+		}
+	}
+	if err := progress.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if err := progress.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	got := writer.reports(t)
+	if len(got) != 2 || got[0].Percent != 10 || got[1].Percent != 30 {
+		t.Fatalf("reports = %v, want 10 and the flushed 30", got)
+	}
+}
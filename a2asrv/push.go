@@ -0,0 +1,86 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/internal/push"
+)
+
+// NewInMemoryPushConfigStore creates a PushConfigStore which keeps configs in memory. It is the default store
+// of NewHandler. Create one explicitly to share it between the handler and NewHTTPPushNotifier.
+func NewInMemoryPushConfigStore() PushConfigStore {
+	return push.NewInMemoryPushConfigStore()
+}
+
+// HTTPPushNotifierConfig configures a PushNotifier created by NewHTTPPushNotifier.
+type HTTPPushNotifierConfig struct {
+	// Client is used for making webhook requests. http.DefaultClient is used if not set.
+	Client *http.Client
+	// Timeout limits the duration of a single delivery attempt. Defaults to 10 seconds.
+	Timeout time.Duration
+	// MaxRetries is the number of repeated attempts after a delivery failed because of a network error,
+	// a 5xx or a 429 response. Defaults to 3, a negative value disables retries.
+	MaxRetries int
+	// Backoff is the delay before the first retry, doubled after every attempt. Defaults to 500ms.
+	Backoff time.Duration
+	// TokenHeader is the header PushConfig.Token is passed in. Defaults to X-A2A-Notification-Token.
+	TokenHeader string
+}
+
+// NewHTTPPushNotifier creates a PushNotifier which POSTs Task snapshots as JSON to every PushConfig
+// registered for the Task in the store. Bearer and Basic PushAuthInfo schemes are supported.
+// The handler sends a notification after every Task update, pass the same store to WithPushConfigStore:
+//
+//	configs := a2asrv.NewInMemoryPushConfigStore()
+//	notifier := a2asrv.NewHTTPPushNotifier(configs, a2asrv.HTTPPushNotifierConfig{})
+//	handler := a2asrv.NewHandler(executor, a2asrv.WithPushConfigStore(configs), a2asrv.WithPushNotifier(notifier))
+func NewHTTPPushNotifier(store PushConfigStore, config HTTPPushNotifierConfig) PushNotifier {
+	return &httpPushNotifier{
+		store: store,
+		sender: &push.HTTPPushSender{
+			Client:      config.Client,
+			Timeout:     config.Timeout,
+			MaxRetries:  config.MaxRetries,
+			Backoff:     config.Backoff,
+			TokenHeader: config.TokenHeader,
+		},
+	}
+}
+
+type httpPushNotifier struct {
+	store  PushConfigStore
+	sender *push.HTTPPushSender
+}
+
+func (n *httpPushNotifier) SendPush(ctx context.Context, task a2a.Task) error {
+	configs, err := n.store.Get(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get push configs: %w", err)
+	}
+	var errs []error
+	for _, config := range configs {
+		if err := n.sender.Send(ctx, config, task); err != nil {
+			errs = append(errs, fmt.Errorf("failed to notify %s: %w", config.URL, err))
+		}
+	}
+	return errors.Join(errs...)
+}
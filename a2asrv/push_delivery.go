@@ -0,0 +1,117 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// ErrPushBacklogFull is reported to the push error handler when a notification is dropped because too many
+// notifications of the same Task are waiting to be delivered, eg. because its webhook doesn't respond.
+var ErrPushBacklogFull = errors.New("too many undelivered push notifications for the task")
+
+// maxPendingPushes is the number of undelivered notifications kept for a single Task.
+const maxPendingPushes = 100
+
+// PushErrorHandler is called with a Task snapshot which couldn't be delivered by PushNotifier.
+type PushErrorHandler func(ctx context.Context, task a2a.Task, err error)
+
+// WithPushErrorHandler sets the function called when a push notification sent after a Task update couldn't
+// be delivered. Failures are logged with slog.Default if not set.
+func WithPushErrorHandler(handler PushErrorHandler) RequestHandlerOption {
+	return func(h *defaultRequestHandler) {
+		h.pushErrors = handler
+	}
+}
+
+func logPushError(ctx context.Context, task a2a.Task, err error) {
+	slog.Default().LogAttrs(ctx, slog.LevelWarn, "a2a push notification failed",
+		slog.String("taskId", string(task.ID)),
+		slog.String("taskState", string(task.Status.State)),
+		slog.String("error", err.Error()),
+	)
+}
+
+// pushDispatcher delivers notifications about Task updates in the background, so that slow or unreachable
+// webhooks don't delay the execution and its subscribers. Notifications of a Task are delivered one by one
+// in the order of updates, notifications of different Tasks are delivered concurrently.
+type pushDispatcher struct {
+	notifier PushNotifier
+	onError  PushErrorHandler
+
+	mu sync.Mutex
+	// pending has an entry for every Task with a running delivery goroutine.
+	pending map[a2a.TaskID][]a2a.Task
+}
+
+func newPushDispatcher(notifier PushNotifier, onError PushErrorHandler) *pushDispatcher {
+	if onError == nil {
+		onError = logPushError
+	}
+	return &pushDispatcher{notifier: notifier, onError: onError, pending: make(map[a2a.TaskID][]a2a.Task)}
+}
+
+// enqueue schedules the delivery of a snapshot of the Task. The snapshot is taken immediately,
+// because the Task continues to be updated.
+func (d *pushDispatcher) enqueue(ctx context.Context, task *a2a.Task) {
+	ctx = context.WithoutCancel(ctx)
+	snapshot, err := copyTask(task)
+	if err != nil {
+		d.onError(ctx, *task, fmt.Errorf("failed to copy task: %w", err))
+		return
+	}
+
+	d.mu.Lock()
+	queue, running := d.pending[task.ID]
+	var dropped *a2a.Task
+	if len(queue) >= maxPendingPushes {
+		dropped, queue = &queue[0], queue[1:]
+	}
+	d.pending[task.ID] = append(queue, snapshot)
+	d.mu.Unlock()
+
+	if dropped != nil {
+		d.onError(ctx, *dropped, ErrPushBacklogFull)
+	}
+	if !running {
+		go d.deliver(ctx, task.ID)
+	}
+}
+
+// deliver sends the pending notifications of the Task until there are none left.
+func (d *pushDispatcher) deliver(ctx context.Context, taskID a2a.TaskID) {
+	for {
+		d.mu.Lock()
+		queue := d.pending[taskID]
+		if len(queue) == 0 {
+			delete(d.pending, taskID)
+			d.mu.Unlock()
+			return
+		}
+		task := queue[0]
+		d.pending[taskID] = queue[1:]
+		d.mu.Unlock()
+
+		if err := d.notifier.SendPush(ctx, task); err != nil {
+			d.onError(ctx, task, err)
+		}
+	}
+}
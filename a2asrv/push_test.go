@@ -0,0 +1,168 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestHTTPPushNotifier_SendPush(t *testing.T) {
+	t.Parallel()
+	var delivered atomic.Int32
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered.Add(1)
+	}))
+	defer ok.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer failing.Close()
+
+	store := &testPushConfigStore{configs: []a2a.PushConfig{
+		{ID: "first", URL: ok.URL},
+		{ID: "failing", URL: failing.URL},
+		{ID: "second", URL: ok.URL},
+	}}
+	notifier := NewHTTPPushNotifier(store, HTTPPushNotifierConfig{})
+
+	err := notifier.SendPush(t.Context(), a2a.Task{ID: taskID, Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}})
+	if err == nil {
+		t.Fatal("SendPush() succeeded, want error for the failing webhook")
+	}
+	if got := delivered.Load(); got != 2 {
+		t.Fatalf("delivered %d notifications, want 2", got)
+	}
+}
+
+func TestHTTPPushNotifier_TaskUpdates(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	states := make(chan a2a.TaskState, 10)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var task a2a.Task
+		if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
+			t.Errorf("failed to decode notification: %v", err)
+		}
+		states <- task.Status.State
+	}))
+	defer webhook.Close()
+
+	task := a2a.Task{ID: taskID, ContextID: "ctx", Status: a2a.TaskStatus{State: a2a.TaskStateInputRequired}}
+	configs := NewInMemoryPushConfigStore()
+	executor := &mockAgentExecutor{ExecuteFunc: writeEvents(nil,
+		a2a.NewStatusUpdateEvent(&task, a2a.TaskStateWorking, nil),
+		a2a.NewStatusUpdateEvent(&task, a2a.TaskStateCompleted, nil),
+	)}
+	handler := NewHandler(executor,
+		WithTaskStore(newListingTaskStore(task)),
+		WithPushConfigStore(configs),
+		WithPushNotifier(NewHTTPPushNotifier(configs, HTTPPushNotifierConfig{})),
+	)
+
+	params := a2a.TaskPushConfig{TaskID: taskID, Config: a2a.PushConfig{URL: webhook.URL}}
+	if _, err := handler.OnSetTaskPushConfig(ctx, params); err != nil {
+		t.Fatalf("OnSetTaskPushConfig() error = %v", err)
+	}
+	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "continue"})
+	msg.TaskID, msg.ContextID = taskID, task.ContextID
+	if _, err := handler.OnSendMessage(ctx, a2a.MessageSendParams{Message: *msg}); err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+
+	// notifications are delivered in the background
+	var got []a2a.TaskState
+	for range 2 {
+		select {
+		case state := <-states:
+			got = append(got, state)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("webhook received states %v, want 2 notifications", got)
+		}
+	}
+	want := []a2a.TaskState{a2a.TaskStateWorking, a2a.TaskStateCompleted}
+	if !slices.Equal(got, want) {
+		t.Fatalf("webhook received states %v, want %v", got, want)
+	}
+}
+
+// blockingPushNotifier blocks SendPush until release is closed and records the delivered Tasks.
+type blockingPushNotifier struct {
+	release chan struct{}
+	err     error
+	sent    chan a2a.Task
+}
+
+func (n *blockingPushNotifier) SendPush(ctx context.Context, task a2a.Task) error {
+	<-n.release
+	n.sent <- task
+	return n.err
+}
+
+func TestHandler_PushDeliveryDoesNotBlockExecution(t *testing.T) {
+	t.Parallel()
+	task := a2a.Task{ID: taskID, ContextID: "ctx", Status: a2a.TaskStatus{State: a2a.TaskStateInputRequired}}
+	executor := &mockAgentExecutor{ExecuteFunc: writeEvents(nil,
+		a2a.NewStatusUpdateEvent(&task, a2a.TaskStateWorking, nil),
+		a2a.NewArtifactEvent(task, a2a.TextPart{Text: "result"}),
+		a2a.NewStatusUpdateEvent(&task, a2a.TaskStateCompleted, nil),
+	)}
+	deliveryErr := errors.New("webhook unreachable")
+	notifier := &blockingPushNotifier{release: make(chan struct{}), err: deliveryErr, sent: make(chan a2a.Task, 10)}
+	failed := make(chan error, 10)
+	handler := NewHandler(executor,
+		WithTaskStore(newListingTaskStore(task)),
+		WithPushNotifier(notifier),
+		WithPushErrorHandler(func(ctx context.Context, task a2a.Task, err error) { failed <- err }),
+	)
+
+	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "continue"})
+	msg.TaskID, msg.ContextID = taskID, task.ContextID
+	if _, err := handler.OnSendMessage(t.Context(), a2a.MessageSendParams{Message: *msg}); err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	close(notifier.release)
+
+	var states []a2a.TaskState
+	var artifacts []int
+	for range 3 {
+		select {
+		case sent := <-notifier.sent:
+			states = append(states, sent.Status.State)
+			artifacts = append(artifacts, len(sent.Artifacts))
+		case <-time.After(5 * time.Second):
+			t.Fatalf("delivered states %v, want 3 notifications", states)
+		}
+		if err := <-failed; !errors.Is(err, deliveryErr) {
+			t.Fatalf("reported error = %v, want %v", err, deliveryErr)
+		}
+	}
+	// every notification carries the snapshot of the Task at the time of its update
+	if want := []a2a.TaskState{a2a.TaskStateWorking, a2a.TaskStateWorking, a2a.TaskStateCompleted}; !slices.Equal(states, want) {
+		t.Fatalf("delivered states %v, want %v", states, want)
+	}
+	if want := []int{0, 1, 1}; !slices.Equal(artifacts, want) {
+		t.Fatalf("delivered artifact counts %v, want %v", artifacts, want)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExceeded is matched by QuotaExceededError with errors.Is.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// QuotaKind identifies a quota configured in Quotas.
+type QuotaKind string
+
+const (
+	// QuotaTasksPerHour limits the number of executions a principal can start in an hour.
+	QuotaTasksPerHour QuotaKind = "tasksPerHour"
+	// QuotaConcurrentTasks limits the number of executions of a principal in progress.
+	QuotaConcurrentTasks QuotaKind = "concurrentTasks"
+	// QuotaContextCompute limits the total execution time spent on a context.
+	QuotaContextCompute QuotaKind = "contextCompute"
+)
+
+// QuotaExceededError is returned by the handler when a request is rejected because of a quota.
+type QuotaExceededError struct {
+	// Kind is the exceeded quota.
+	Kind QuotaKind
+	// Key is the principal or the context ID the quota applies to.
+	Key string
+	// Limit is the configured limit. Compute budgets are in milliseconds.
+	Limit int64
+	// RetryAfter is the time until the quota resets. Zero if it's unknown.
+	RetryAfter time.Duration
+}
+
+func (e *QuotaExceededError) Error() string {
+	msg := fmt.Sprintf("%s: %s limit of %d for %q", ErrQuotaExceeded, e.Kind, e.Limit, e.Key)
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(", retry after %v", e.RetryAfter)
+	}
+	return msg
+}
+
+func (e *QuotaExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// Quotas configures the limits enforced by the handler created WithQuotas. Zero values disable a limit.
+// Principals are identified with PrincipalFrom. Callers without a principal share the quotas of the empty one.
+type Quotas struct {
+	// TasksPerHour is the maximum number of executions a principal can start within a calendar hour.
+	TasksPerHour int
+	// ConcurrentTasks is the maximum number of executions of a principal in progress.
+	ConcurrentTasks int
+	// ContextCompute is the maximum total AgentExecutor time spent on requests of a context within
+	// ContextComputeWindow. An execution which starts within the budget is never interrupted, so the budget
+	// can be overrun by one execution.
+	ContextCompute time.Duration
+	// ContextComputeWindow is the time since the first request of a context after which its compute budget
+	// resets and its counter is removed from QuotaStore. DefaultContextComputeWindow is used if zero.
+	ContextComputeWindow time.Duration
+}
+
+// DefaultContextComputeWindow is the default Quotas.ContextComputeWindow.
+const DefaultContextComputeWindow = 24 * time.Hour
+
+// QuotaStore keeps the counters used for quota enforcement. Implementations backed by shared storage
+// make quotas apply across replicas.
+type QuotaStore interface {
+	// Add adds delta to the counter and returns the new value. A counter which doesn't exist starts at zero.
+	// A non-zero ttl makes the counter expire after that time since it was created.
+	Add(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+}
+
+// WithQuotas makes the handler reject requests which would start an AgentExecutor execution exceeding
+// the quotas with QuotaExceededError. Counters are kept in the store, or in memory if the store is nil.
+func WithQuotas(quotas Quotas, store QuotaStore) RequestHandlerOption {
+	return func(h *defaultRequestHandler) {
+		if store == nil {
+			store = NewInMemoryQuotaStore()
+		}
+		if quotas.ContextComputeWindow <= 0 {
+			quotas.ContextComputeWindow = DefaultContextComputeWindow
+		}
+		h.quotas = &quotaEnforcer{quotas: quotas, store: store}
+	}
+}
+
+type quotaEnforcer struct {
+	quotas Quotas
+	store  QuotaStore
+}
+
+// acquire checks the quotas before an execution starts. The returned function must be called
+// with the execution duration once it finishes.
+func (q *quotaEnforcer) acquire(ctx context.Context, contextID string) (func(time.Duration), error) {
+	principal, _ := PrincipalFrom(ctx)
+	now := ClockFrom(ctx).Now()
+	var undo []func()
+	rollback := func() {
+		for _, f := range undo {
+			f()
+		}
+	}
+	add := func(key string, delta int64, ttl time.Duration) (int64, error) {
+		value, err := q.store.Add(ctx, key, delta, ttl)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update quota counter: %w", err)
+		}
+		undo = append(undo, func() { _, _ = q.store.Add(context.WithoutCancel(ctx), key, -delta, ttl) })
+		return value, nil
+	}
+
+	if limit := q.quotas.TasksPerHour; limit > 0 {
+		hour := now.Truncate(time.Hour)
+		count, err := add("tasks/"+principal+"/"+strconv.FormatInt(hour.Unix(), 10), 1, time.Hour)
+		if err != nil {
+			return nil, err
+		}
+		if count > int64(limit) {
+			rollback()
+			return nil, &QuotaExceededError{Kind: QuotaTasksPerHour, Key: principal, Limit: int64(limit), RetryAfter: hour.Add(time.Hour).Sub(now)}
+		}
+	}
+
+	if limit := q.quotas.ContextCompute; limit > 0 && contextID != "" {
+		used, err := q.store.Add(ctx, "compute/"+contextID, 0, q.quotas.ContextComputeWindow)
+		if err != nil {
+			rollback()
+			return nil, fmt.Errorf("failed to read quota counter: %w", err)
+		}
+		if used >= limit.Milliseconds() {
+			rollback()
+			return nil, &QuotaExceededError{Kind: QuotaContextCompute, Key: contextID, Limit: limit.Milliseconds()}
+		}
+	}
+
+	concurrentKey := "concurrent/" + principal
+	if limit := q.quotas.ConcurrentTasks; limit > 0 {
+		count, err := add(concurrentKey, 1, 0)
+		if err != nil {
+			rollback()
+			return nil, err
+		}
+		if count > int64(limit) {
+			rollback()
+			return nil, &QuotaExceededError{Kind: QuotaConcurrentTasks, Key: principal, Limit: int64(limit)}
+		}
+	}
+
+	return func(elapsed time.Duration) {
+		ctx := context.WithoutCancel(ctx)
+		if q.quotas.ConcurrentTasks > 0 {
+			_, _ = q.store.Add(ctx, concurrentKey, -1, 0)
+		}
+		if q.quotas.ContextCompute > 0 && contextID != "" {
+			_, _ = q.store.Add(ctx, "compute/"+contextID, elapsed.Milliseconds(), q.quotas.ContextComputeWindow)
+		}
+	}, nil
+}
+
+// InMemoryQuotaStore is a QuotaStore for a single process.
+type InMemoryQuotaStore struct {
+	mu        sync.Mutex
+	counters  map[string]*quotaCounter
+	nextSweep time.Time
+}
+
+type quotaCounter struct {
+	value   int64
+	expires time.Time
+}
+
+// NewInMemoryQuotaStore creates an empty InMemoryQuotaStore.
+func NewInMemoryQuotaStore() *InMemoryQuotaStore {
+	return &InMemoryQuotaStore{counters: make(map[string]*quotaCounter)}
+}
+
+func (s *InMemoryQuotaStore) Add(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	now := ClockFrom(ctx).Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.After(s.nextSweep) {
+		s.sweep(now)
+	}
+	counter, ok := s.counters[key]
+	if ok && !counter.expires.IsZero() && !now.Before(counter.expires) {
+		ok = false
+	}
+	if !ok {
+		counter = &quotaCounter{}
+		if ttl > 0 {
+			counter.expires = now.Add(ttl)
+		}
+		s.counters[key] = counter
+	}
+	counter.value += delta
+	if counter.value == 0 && counter.expires.IsZero() {
+		delete(s.counters, key)
+	}
+	return counter.value, nil
+}
+
+// sweep removes expired counters, eg. the ones of past hours.
+func (s *InMemoryQuotaStore) sweep(now time.Time) {
+	for key, counter := range s.counters {
+		if !counter.expires.IsZero() && !now.Before(counter.expires) {
+			delete(s.counters, key)
+		}
+	}
+	s.nextSweep = now.Add(time.Minute)
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+func quotaTestExecutor(clock *manualClock, duration time.Duration) *mockAgentExecutor {
+	return &mockAgentExecutor{ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+		clock.now = clock.now.Add(duration)
+		return queue.Write(ctx, &a2a.Message{TaskID: reqCtx.TaskID})
+	}}
+}
+
+func sendQuotaMessage(ctx context.Context, handler RequestHandler, contextID string) error {
+	_, err := handler.OnSendMessage(ctx, a2a.MessageSendParams{Message: a2a.Message{TaskID: a2a.TaskID(a2a.NewMessageID()), ContextID: contextID}})
+	return err
+}
+
+func TestWithQuotas_TasksPerHour(t *testing.T) {
+	clock := &manualClock{now: time.Date(2025, 1, 1, 10, 15, 0, 0, time.UTC)}
+	handler := NewHandler(quotaTestExecutor(clock, 0), WithQuotas(Quotas{TasksPerHour: 2}, nil))
+	alice := WithPrincipal(WithClock(t.Context(), clock), "alice")
+	bob := WithPrincipal(WithClock(t.Context(), clock), "bob")
+
+	for range 2 {
+		if err := sendQuotaMessage(alice, handler, ""); err != nil {
+			t.Fatalf("OnSendMessage() error = %v", err)
+		}
+	}
+	err := sendQuotaMessage(alice, handler, "")
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) || !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("OnSendMessage() over quota error = %v, want %v", err, ErrQuotaExceeded)
+	}
+	if quotaErr.Kind != QuotaTasksPerHour || quotaErr.Key != "alice" || quotaErr.RetryAfter != 45*time.Minute {
+		t.Fatalf("QuotaExceededError = %+v, want tasksPerHour of alice retrying after 45m", quotaErr)
+	}
+	if err := sendQuotaMessage(bob, handler, ""); err != nil {
+		t.Fatalf("OnSendMessage() of another principal error = %v", err)
+	}
+
+	clock.now = clock.now.Add(45 * time.Minute)
+	if err := sendQuotaMessage(alice, handler, ""); err != nil {
+		t.Fatalf("OnSendMessage() in the next hour error = %v", err)
+	}
+}
+
+func TestWithQuotas_ConcurrentTasks(t *testing.T) {
+	ctx := WithPrincipal(t.Context(), "alice")
+	started, finish := make(chan struct{}), make(chan struct{})
+	executor := &mockAgentExecutor{ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+		if reqCtx.Request.Message.ContextID == "blocking" {
+			close(started)
+			<-finish
+		}
+		return queue.Write(ctx, &a2a.Message{TaskID: reqCtx.TaskID})
+	}}
+	handler := NewHandler(executor, WithQuotas(Quotas{ConcurrentTasks: 1}, nil))
+
+	done := make(chan error, 1)
+	go func() { done <- sendQuotaMessage(ctx, handler, "blocking") }()
+	<-started
+	var quotaErr *QuotaExceededError
+	if err := sendQuotaMessage(ctx, handler, ""); !errors.As(err, &quotaErr) || quotaErr.Kind != QuotaConcurrentTasks {
+		t.Fatalf("OnSendMessage() during another execution error = %v, want %s quota error", err, QuotaConcurrentTasks)
+	}
+	close(finish)
+	if err := <-done; err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	if err := sendQuotaMessage(ctx, handler, ""); err != nil {
+		t.Fatalf("OnSendMessage() after execution finished error = %v", err)
+	}
+}
+
+func TestWithQuotas_ContextCompute(t *testing.T) {
+	clock := &manualClock{now: time.Now()}
+	ctx := WithClock(t.Context(), clock)
+	handler := NewHandler(quotaTestExecutor(clock, 3*time.Second), WithQuotas(Quotas{ContextCompute: 5 * time.Second}, nil))
+
+	for range 2 {
+		if err := sendQuotaMessage(ctx, handler, "ctx-1"); err != nil {
+			t.Fatalf("OnSendMessage() within budget error = %v", err)
+		}
+	}
+	var quotaErr *QuotaExceededError
+	if err := sendQuotaMessage(ctx, handler, "ctx-1"); !errors.As(err, &quotaErr) || quotaErr.Kind != QuotaContextCompute || quotaErr.Limit != 5000 {
+		t.Fatalf("OnSendMessage() over budget error = %v, want %s quota error", err, QuotaContextCompute)
+	}
+	if err := sendQuotaMessage(ctx, handler, "ctx-2"); err != nil {
+		t.Fatalf("OnSendMessage() in another context error = %v", err)
+	}
+}
+
+func TestWithQuotas_ContextComputeWindow(t *testing.T) {
+	clock := &manualClock{now: time.Now()}
+	ctx := WithClock(t.Context(), clock)
+	store := NewInMemoryQuotaStore()
+	quotas := Quotas{ContextCompute: 5 * time.Second, ContextComputeWindow: time.Hour}
+	handler := NewHandler(quotaTestExecutor(clock, 5*time.Second), WithQuotas(quotas, store))
+
+	if err := sendQuotaMessage(ctx, handler, "ctx-1"); err != nil {
+		t.Fatalf("OnSendMessage() within budget error = %v", err)
+	}
+	if err := sendQuotaMessage(ctx, handler, "ctx-1"); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("OnSendMessage() over budget error = %v, want %v", err, ErrQuotaExceeded)
+	}
+
+	clock.now = clock.now.Add(time.Hour)
+	if err := sendQuotaMessage(ctx, handler, "ctx-1"); err != nil {
+		t.Fatalf("OnSendMessage() after the window error = %v", err)
+	}
+	// the counter of an inactive context is removed once its window passes
+	clock.now = clock.now.Add(2 * time.Hour)
+	_, _ = store.Add(ctx, "other", 0, 0)
+	if len(store.counters) != 0 {
+		t.Fatalf("store has %d counters after the window, want 0", len(store.counters))
+	}
+}
+
+func TestInMemoryQuotaStore(t *testing.T) {
+	clock := &manualClock{now: time.Now()}
+	ctx := WithClock(t.Context(), clock)
+	store := NewInMemoryQuotaStore()
+
+	for i, want := range []int64{1, 2} {
+		if got, err := store.Add(ctx, "key", 1, time.Minute); err != nil || got != want {
+			t.Fatalf("Add() #%d = %d, %v, want %d", i, got, err, want)
+		}
+	}
+	clock.now = clock.now.Add(time.Minute)
+	if got, _ := store.Add(ctx, "key", 1, time.Minute); got != 1 {
+		t.Fatalf("Add() after expiry = %d, want 1", got)
+	}
+	clock.now = clock.now.Add(2 * time.Minute)
+	_, _ = store.Add(ctx, "other", 0, 0)
+	if len(store.counters) != 0 {
+		t.Fatalf("store has %d counters after expiry, want 0", len(store.counters))
+	}
+}
+
+func TestInMemoryQuotaStore_Concurrent(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	store := NewInMemoryQuotaStore()
+
+	numWorkers, numOps := 16, 100
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numOps; j++ {
+				if _, err := store.Add(ctx, "shared", 1, time.Hour); err != nil {
+					t.Errorf("Add() error = %v", err)
+				}
+				// short-lived counters exercise the expiry sweep
+				if _, err := store.Add(ctx, fmt.Sprintf("key-%d", i), 1, time.Nanosecond); err != nil {
+					t.Errorf("Add() error = %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, _ := store.Add(ctx, "shared", 0, time.Hour); got != int64(numWorkers*numOps) {
+		t.Fatalf("Add() = %d after concurrent increments, want %d", got, numWorkers*numOps)
+	}
+}
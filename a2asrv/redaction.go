@@ -0,0 +1,167 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"iter"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// HistoryRedactor filters Task contents before they are returned to a caller. The caller can be
+// identified using the request context, eg. with PrincipalFrom.
+type HistoryRedactor interface {
+	// RedactMessage returns the message to send to the caller, which can be a redacted copy of the
+	// original one, or nil to drop the message. The original message must not be modified.
+	RedactMessage(ctx context.Context, msg *a2a.Message) *a2a.Message
+
+	// RedactArtifact returns the artifact to send to the caller, which can be a redacted copy of the
+	// original one, or nil to drop the artifact. The original artifact must not be modified.
+	RedactArtifact(ctx context.Context, artifact *a2a.Artifact) *a2a.Artifact
+}
+
+// HistoryRedactorFuncs adapts functions to HistoryRedactor. Nil functions keep the values as is.
+type HistoryRedactorFuncs struct {
+	MessageFunc  func(ctx context.Context, msg *a2a.Message) *a2a.Message
+	ArtifactFunc func(ctx context.Context, artifact *a2a.Artifact) *a2a.Artifact
+}
+
+func (f HistoryRedactorFuncs) RedactMessage(ctx context.Context, msg *a2a.Message) *a2a.Message {
+	if f.MessageFunc == nil {
+		return msg
+	}
+	return f.MessageFunc(ctx, msg)
+}
+
+func (f HistoryRedactorFuncs) RedactArtifact(ctx context.Context, artifact *a2a.Artifact) *a2a.Artifact {
+	if f.ArtifactFunc == nil {
+		return artifact
+	}
+	return f.ArtifactFunc(ctx, artifact)
+}
+
+// NewRedactingHandler wraps a RequestHandler to apply the redactor to Tasks returned by 'tasks/get',
+// 'tasks/cancel' and 'message/send' and to Task snapshots, status messages and artifact updates
+// streamed by 'message/stream' and 'tasks/resubscribe'. Artifact updates with a dropped artifact are
+// not streamed. Messages sent directly as a response are replies to the caller and are not redacted.
+func NewRedactingHandler(next RequestHandler, redactor HistoryRedactor) RequestHandler {
+	return &redactingHandler{RequestHandler: next, redactor: redactor}
+}
+
+type redactingHandler struct {
+	RequestHandler
+	redactor HistoryRedactor
+}
+
+func (h *redactingHandler) OnGetTask(ctx context.Context, query a2a.TaskQueryParams) (a2a.Task, error) {
+	task, err := h.RequestHandler.OnGetTask(ctx, query)
+	if err != nil {
+		return task, err
+	}
+	return *h.redactTask(ctx, &task), nil
+}
+
+func (h *redactingHandler) OnCancelTask(ctx context.Context, id a2a.TaskIDParams) (a2a.Task, error) {
+	task, err := h.RequestHandler.OnCancelTask(ctx, id)
+	if err != nil {
+		return task, err
+	}
+	return *h.redactTask(ctx, &task), nil
+}
+
+func (h *redactingHandler) OnSendMessage(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	result, err := h.RequestHandler.OnSendMessage(ctx, message)
+	if task, ok := result.(*a2a.Task); ok && task != nil && err == nil {
+		return h.redactTask(ctx, task), nil
+	}
+	return result, err
+}
+
+func (h *redactingHandler) OnResubscribeToTask(ctx context.Context, id a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+	return h.redactEvents(ctx, h.RequestHandler.OnResubscribeToTask(ctx, id))
+}
+
+func (h *redactingHandler) OnSendMessageStream(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return h.redactEvents(ctx, h.RequestHandler.OnSendMessageStream(ctx, message))
+}
+
+func (h *redactingHandler) OnGetExtendedAgentCard(ctx context.Context) (*a2a.AgentCard, error) {
+	return getExtendedAgentCard(ctx, h.RequestHandler)
+}
+
+func (h *redactingHandler) redactEvents(ctx context.Context, events iter.Seq2[a2a.Event, error]) iter.Seq2[a2a.Event, error] {
+	if events == nil {
+		return nil
+	}
+	return func(yield func(a2a.Event, error) bool) {
+		for event, err := range events {
+			if err == nil {
+				if event = h.redactEvent(ctx, event); event == nil {
+					continue
+				}
+			}
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+// redactEvent returns the redacted copy of the event or nil if the event should be dropped.
+func (h *redactingHandler) redactEvent(ctx context.Context, event a2a.Event) a2a.Event {
+	switch v := event.(type) {
+	case *a2a.Task:
+		return h.redactTask(ctx, v)
+	case *a2a.TaskStatusUpdateEvent:
+		if v.Status.Message == nil {
+			return v
+		}
+		update := *v
+		update.Status.Message = h.redactor.RedactMessage(ctx, v.Status.Message)
+		return &update
+	case *a2a.TaskArtifactUpdateEvent:
+		artifact := h.redactor.RedactArtifact(ctx, v.Artifact)
+		if artifact == nil {
+			return nil
+		}
+		update := *v
+		update.Artifact = artifact
+		return &update
+	default:
+		return event
+	}
+}
+
+// redactTask returns a copy of the task with the redacted history, status message and artifacts.
+func (h *redactingHandler) redactTask(ctx context.Context, task *a2a.Task) *a2a.Task {
+	redacted := *task
+	if task.Status.Message != nil {
+		redacted.Status.Message = h.redactor.RedactMessage(ctx, task.Status.Message)
+	}
+	redacted.History = nil
+	for _, msg := range task.History {
+		if msg := h.redactor.RedactMessage(ctx, msg); msg != nil {
+			redacted.History = append(redacted.History, msg)
+		}
+	}
+	redacted.Artifacts = nil
+	for _, artifact := range task.Artifacts {
+		if artifact := h.redactor.RedactArtifact(ctx, artifact); artifact != nil {
+			redacted.Artifacts = append(redacted.Artifacts, artifact)
+		}
+	}
+	return &redacted
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"iter"
+	"reflect"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// ownMessagesOnly drops user messages of other principals and artifacts named "trace".
+var ownMessagesOnly = HistoryRedactorFuncs{
+	MessageFunc: func(ctx context.Context, msg *a2a.Message) *a2a.Message {
+		principal, _ := PrincipalFrom(ctx)
+		if msg.Role == a2a.MessageRoleUser && msg.Metadata["principal"] != principal {
+			return nil
+		}
+		return msg
+	},
+	ArtifactFunc: func(ctx context.Context, artifact *a2a.Artifact) *a2a.Artifact {
+		if artifact.Name == "trace" {
+			return nil
+		}
+		return artifact
+	},
+}
+
+func TestRedactingHandler(t *testing.T) {
+	alice := &a2a.Message{ID: "1", Role: a2a.MessageRoleUser, Metadata: map[string]any{"principal": "alice"}}
+	bob := &a2a.Message{ID: "2", Role: a2a.MessageRoleUser, Metadata: map[string]any{"principal": "bob"}}
+	reply := &a2a.Message{ID: "3", Role: a2a.MessageRoleAgent}
+	task := &a2a.Task{
+		ID:        taskID,
+		Status:    a2a.TaskStatus{State: a2a.TaskStateInputRequired, Message: bob},
+		History:   []*a2a.Message{alice, bob, reply},
+		Artifacts: []*a2a.Artifact{{ID: "a1", Name: "report"}, {ID: "a2", Name: "trace"}},
+	}
+	stub := &stubRequestHandler{
+		sendMessage: func(ctx context.Context, message a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+			return task, nil
+		},
+		stream: func(ctx context.Context, message a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+			return func(yield func(a2a.Event, error) bool) {
+				events := []a2a.Event{
+					task,
+					a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, bob),
+					&a2a.TaskArtifactUpdateEvent{TaskID: taskID, Artifact: &a2a.Artifact{ID: "a2", Name: "trace"}},
+					&a2a.TaskArtifactUpdateEvent{TaskID: taskID, Artifact: &a2a.Artifact{ID: "a3", Name: "summary"}},
+				}
+				for _, event := range events {
+					if !yield(event, nil) {
+						return
+					}
+				}
+			}
+		},
+	}
+	handler := NewRedactingHandler(stub, ownMessagesOnly)
+	ctx := WithPrincipal(t.Context(), "alice")
+
+	checkTask := func(t *testing.T, got *a2a.Task) {
+		t.Helper()
+		var history, artifacts []string
+		for _, msg := range got.History {
+			history = append(history, msg.ID)
+		}
+		for _, artifact := range got.Artifacts {
+			artifacts = append(artifacts, string(artifact.ID))
+		}
+		if got.Status.Message != nil || !reflect.DeepEqual(history, []string{"1", "3"}) || !reflect.DeepEqual(artifacts, []string{"a1"}) {
+			t.Fatalf("got task with status message %v, history %v and artifacts %v, want alice's history without the trace", got.Status.Message, history, artifacts)
+		}
+	}
+
+	result, err := handler.OnSendMessage(ctx, a2a.MessageSendParams{})
+	if err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	checkTask(t, result.(*a2a.Task))
+	if len(task.History) != 3 || len(task.Artifacts) != 2 || task.Status.Message != bob {
+		t.Fatal("OnSendMessage() modified the original task")
+	}
+
+	var events []a2a.Event
+	for event, err := range handler.OnSendMessageStream(ctx, a2a.MessageSendParams{}) {
+		if err != nil {
+			t.Fatalf("OnSendMessageStream() error = %v", err)
+		}
+		events = append(events, event)
+	}
+	if len(events) != 3 {
+		t.Fatalf("OnSendMessageStream() produced %d events, want 3", len(events))
+	}
+	checkTask(t, events[0].(*a2a.Task))
+	if update := events[1].(*a2a.TaskStatusUpdateEvent); update.Status.Message != nil {
+		t.Fatalf("status update message = %v, want it dropped", update.Status.Message)
+	}
+	if update := events[2].(*a2a.TaskArtifactUpdateEvent); update.Artifact.ID != "a3" {
+		t.Fatalf("artifact update = %v, want a3", update.Artifact)
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package replay reconstructs Tasks from archived event streams for forensic debugging.
+//
+// An archive is a sequence of JSON lines written by a2asrv/eventsink sinks or by the event tap
+// (a2asrv.WithEventTap). The state of every Task at any point in time is exposed through a read-only
+// a2asrv.TaskStore, so it can be inspected with the same tools as a live store, eg. a2asrv.NewDebugMux:
+//
+//	archive, err := replay.Load(file)
+//	store := archive.StoreAt(incidentTime)
+//	task, err := store.Get(ctx, taskID)
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
+)
+
+// ErrReadOnly is returned by Store.Save.
+var ErrReadOnly = errors.New("replay store is read-only")
+
+// maxLineSize limits the size of a single archived event.
+const maxLineSize = 16 << 20
+
+// Archive holds the archived events of Tasks in chronological order.
+type Archive struct {
+	events map[a2a.TaskID][]a2asrv.SinkEvent
+}
+
+// record is the common subset of a2asrv.SinkEvent and eventqueue.TapRecord lines.
+type record struct {
+	Time      time.Time       `json:"time"`
+	Direction string          `json:"direction"`
+	TaskID    a2a.TaskID      `json:"taskId"`
+	Kind      string          `json:"kind"`
+	Event     json.RawMessage `json:"event"`
+}
+
+// NewArchive creates an Archive with the events.
+func NewArchive(events ...a2asrv.SinkEvent) *Archive {
+	archive := &Archive{events: make(map[a2a.TaskID][]a2asrv.SinkEvent)}
+	archive.Add(events...)
+	return archive
+}
+
+// Load reads an archive of JSON lines. Events read by the server from the queue, which are recorded
+// by the event tap in addition to the written ones, are skipped. Empty lines are ignored.
+func Load(r io.Reader) (*Archive, error) {
+	archive := NewArchive()
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		var rec record
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if rec.Direction == "read" {
+			continue
+		}
+		event, err := decodeEvent(rec.Kind, rec.Event)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		archive.Add(a2asrv.SinkEvent{Time: rec.Time, TaskID: rec.TaskID, Kind: rec.Kind, Event: event})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// decodeEvent decodes an event payload which is encoded without the "kind" field.
+func decodeEvent(kind string, data json.RawMessage) (a2a.Event, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("invalid event: %w", err)
+	}
+	if _, ok := fields["kind"]; !ok {
+		encodedKind, err := json.Marshal(kind)
+		if err != nil {
+			return nil, err
+		}
+		fields["kind"] = encodedKind
+		if data, err = json.Marshal(fields); err != nil {
+			return nil, err
+		}
+	}
+	return a2a.UnmarshalEvent(data)
+}
+
+// Add adds events to the archive.
+func (a *Archive) Add(events ...a2asrv.SinkEvent) {
+	for _, event := range events {
+		taskEvents := a.events[event.TaskID]
+		// insert after the events with the same time to keep the archive order
+		i := len(taskEvents)
+		for i > 0 && taskEvents[i-1].Time.After(event.Time) {
+			i--
+		}
+		a.events[event.TaskID] = slices.Insert(taskEvents, i, event)
+	}
+}
+
+// TaskIDs returns the IDs of the archived Tasks in lexical order.
+func (a *Archive) TaskIDs() []a2a.TaskID {
+	ids := make([]a2a.TaskID, 0, len(a.events))
+	for id := range a.events {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Events returns the archived events of the Task in chronological order.
+func (a *Archive) Events(taskId a2a.TaskID) []a2asrv.SinkEvent {
+	return slices.Clone(a.events[taskId])
+}
+
+// StoreAt returns a read-only TaskStore with the state of the Tasks after all the events archived
+// up to and including the time were applied. Use the zero time for the latest state.
+func (a *Archive) StoreAt(at time.Time) *Store {
+	return &Store{archive: a, at: at}
+}
+
+// Store is a read-only a2asrv.TaskStore which rebuilds Tasks from an Archive.
+// It also implements a2asrv.TaskTransitionLog and a2asrv.TaskLister.
+type Store struct {
+	archive *Archive
+	at      time.Time
+}
+
+var (
+	_ a2asrv.TaskStore         = (*Store)(nil)
+	_ a2asrv.TaskTransitionLog = (*Store)(nil)
+	_ a2asrv.TaskLister        = (*Store)(nil)
+)
+
+// Save always fails with ErrReadOnly.
+func (s *Store) Save(ctx context.Context, task a2a.Task) error {
+	return ErrReadOnly
+}
+
+// Get rebuilds the Task. Returns a2a.ErrTaskNotFound if no events of the Task were archived before the time of the Store.
+func (s *Store) Get(ctx context.Context, taskId a2a.TaskID) (a2a.Task, error) {
+	task, _, err := s.rebuild(ctx, taskId)
+	return task, err
+}
+
+// Transitions returns the statuses the Task went through up to the time of the Store.
+func (s *Store) Transitions(ctx context.Context, taskId a2a.TaskID) ([]a2a.TaskStatus, error) {
+	_, transitions, err := s.rebuild(ctx, taskId)
+	return transitions, err
+}
+
+// ListTasks returns the Tasks in the state at the time of the Store, ordered by ID.
+func (s *Store) ListTasks(ctx context.Context, state a2a.TaskState) ([]a2a.Task, error) {
+	var result []a2a.Task
+	for _, id := range s.archive.TaskIDs() {
+		task, err := s.Get(ctx, id)
+		if errors.Is(err, a2a.ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if state == "" || task.Status.State == state {
+			result = append(result, task)
+		}
+	}
+	return result, nil
+}
+
+func (s *Store) rebuild(ctx context.Context, taskId a2a.TaskID) (a2a.Task, []a2a.TaskStatus, error) {
+	var updates *taskupdate.Manager
+	var transitions []a2a.TaskStatus
+	for _, archived := range s.archive.events[taskId] {
+		if !s.at.IsZero() && archived.Time.After(s.at) {
+			break
+		}
+		if _, ok := archived.Event.(a2a.CustomEvent); ok {
+			continue
+		}
+		// the rebuilt Task takes ownership of the applied events, so the archived ones need to stay untouched
+		event, err := copyEvent(archived.Event)
+		if err != nil {
+			return a2a.Task{}, nil, err
+		}
+		if updates == nil {
+			task, err := newTaskForEvent(taskId, event)
+			if err != nil {
+				return a2a.Task{}, nil, err
+			}
+			updates = taskupdate.NewManager(nopSaver{}, task)
+		}
+		if msg, ok := event.(*a2a.Message); ok {
+			updates.Task.History = append(updates.Task.History, msg)
+			continue
+		}
+		prev := updates.Task.Status
+		if err := updates.Process(ctx, event); err != nil {
+			return a2a.Task{}, nil, fmt.Errorf("failed to apply %s event from %v: %w", archived.Kind, archived.Time, err)
+		}
+		if status := updates.Task.Status; status.State != prev.State || !equalTimestamps(status.Timestamp, prev.Timestamp) {
+			transitions = append(transitions, status)
+		}
+	}
+	if updates == nil {
+		return a2a.Task{}, nil, a2a.ErrTaskNotFound
+	}
+	return *updates.Task, transitions, nil
+}
+
+func newTaskForEvent(taskId a2a.TaskID, event a2a.Event) (*a2a.Task, error) {
+	switch v := event.(type) {
+	case *a2a.Task:
+		return &a2a.Task{ID: v.ID, ContextID: v.ContextID}, nil
+	case *a2a.TaskStatusUpdateEvent:
+		return &a2a.Task{ID: v.TaskID, ContextID: v.ContextID}, nil
+	case *a2a.TaskArtifactUpdateEvent:
+		return &a2a.Task{ID: v.TaskID, ContextID: v.ContextID}, nil
+	case *a2a.Message:
+		return &a2a.Task{ID: taskId, ContextID: v.ContextID}, nil
+	default:
+		return nil, fmt.Errorf("unexpected event type: %T", event)
+	}
+}
+
+func copyEvent(event a2a.Event) (a2a.Event, error) {
+	data, err := a2a.MarshalEvent(event)
+	if err != nil {
+		return nil, err
+	}
+	return a2a.UnmarshalEvent(data)
+}
+
+func equalTimestamps(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+type nopSaver struct{}
+
+func (nopSaver) Save(ctx context.Context, task *a2a.Task) error {
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventsink"
+)
+
+func writeArchive(t *testing.T, start time.Time) *bytes.Buffer {
+	t.Helper()
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateSubmitted}}
+	events := []a2a.Event{
+		task,
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil),
+		a2a.NewArtifactEvent(*task, a2a.TextPart{Text: "report"}),
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil),
+	}
+	var buf bytes.Buffer
+	for i, event := range events {
+		line, err := eventsink.Encode(a2asrv.SinkEvent{
+			Time:   start.Add(time.Duration(i) * time.Second),
+			TaskID: task.ID,
+			Kind:   a2a.EventKindOf(event),
+			Event:  event,
+		})
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		buf.Write(line)
+		buf.WriteString("\n")
+	}
+	// the event tap additionally records events read by the server
+	buf.WriteString(`{"time":"` + start.Add(time.Hour).Format(time.RFC3339) + `","direction":"read","taskId":"task-1","kind":"status-update","event":{"taskId":"task-1","contextId":"ctx-1","status":{"state":"failed"}}}` + "\n")
+	return &buf
+}
+
+func TestStoreAt(t *testing.T) {
+	ctx := t.Context()
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	archive, err := Load(writeArchive(t, start))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	testCases := []struct {
+		at        time.Time
+		state     a2a.TaskState
+		artifacts int
+	}{
+		{at: start, state: a2a.TaskStateSubmitted},
+		{at: start.Add(1500 * time.Millisecond), state: a2a.TaskStateWorking},
+		{at: start.Add(2 * time.Second), state: a2a.TaskStateWorking, artifacts: 1},
+		{state: a2a.TaskStateCompleted, artifacts: 1},
+	}
+	for _, tc := range testCases {
+		task, err := archive.StoreAt(tc.at).Get(ctx, "task-1")
+		if err != nil {
+			t.Fatalf("Get() at %v error = %v", tc.at, err)
+		}
+		if task.Status.State != tc.state || len(task.Artifacts) != tc.artifacts {
+			t.Fatalf("Get() at %v = %s with %d artifacts, want %s with %d", tc.at, task.Status.State, len(task.Artifacts), tc.state, tc.artifacts)
+		}
+	}
+
+	store := archive.StoreAt(time.Time{})
+	transitions, err := store.Transitions(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("Transitions() error = %v", err)
+	}
+	var states []string
+	for _, status := range transitions {
+		states = append(states, string(status.State))
+	}
+	if got := strings.Join(states, ","); got != "submitted,working,completed" {
+		t.Fatalf("Transitions() = %s, want submitted,working,completed", got)
+	}
+
+	if _, err := archive.StoreAt(start.Add(-time.Second)).Get(ctx, "task-1"); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Fatalf("Get() before the first event error = %v, want %v", err, a2a.ErrTaskNotFound)
+	}
+	if err := store.Save(ctx, a2a.Task{ID: "task-1"}); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("Save() error = %v, want %v", err, ErrReadOnly)
+	}
+	if tasks, err := store.ListTasks(ctx, a2a.TaskStateCompleted); err != nil || len(tasks) != 1 {
+		t.Fatalf("ListTasks(completed) = %d tasks, %v, want 1", len(tasks), err)
+	}
+}
+
+func TestArchive_Add(t *testing.T) {
+	start := time.Now()
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	working := a2asrv.SinkEvent{Time: start, TaskID: task.ID, Event: a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil)}
+	completed := a2asrv.SinkEvent{Time: start.Add(time.Second), TaskID: task.ID, Event: a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil)}
+	archive := NewArchive(completed, working)
+
+	events := archive.Events(task.ID)
+	if len(events) != 2 || events[0].Time != start {
+		t.Fatalf("Events() = %+v, want events in chronological order", events)
+	}
+	if got, err := archive.StoreAt(time.Time{}).Get(t.Context(), task.ID); err != nil || got.Status.State != a2a.TaskStateCompleted {
+		t.Fatalf("Get() = %s, %v, want completed", got.Status.State, err)
+	}
+}
+
+func TestLoad_Malformed(t *testing.T) {
+	if _, err := Load(strings.NewReader("{\"kind\":\"unknown\",\"event\":{}}\n")); !errors.Is(err, a2a.ErrUnknownEventKind) {
+		t.Fatalf("Load() error = %v, want %v", err, a2a.ErrUnknownEventKind)
+	}
+}
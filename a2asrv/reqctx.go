@@ -33,10 +33,25 @@ type RequestContext struct {
 	Request a2a.MessageSendParams
 	// TaskID is an ID of the task or a newly generated UUIDv4 in case Message did not reference any Task.
 	TaskID a2a.TaskID
-	// Task is present if request message specified a TaskID.
+	// Task is present if request message specified a TaskID of a Task found in TaskStore. It is nil for Tasks
+	// created for messages which didn't reference one.
 	Task *a2a.Task
 	// RelatedTasks can be present when Message includes Task references and RequestContextBuilder is configured to load them.
 	RelatedTasks []a2a.Task
 	// ContextID is a server-generated identifier for maintaining context across multiple related tasks or interactions. Matches the Task ContextID.
 	ContextID string
+	// SkillID is the ID of the skill the Message references with a2a.SkillIDMetaKey or an empty string.
+	SkillID string
+	// Preferences are the user preferences the Message specified with a2a.PreferencesMetaKey for localizing
+	// agent output. Empty if the client didn't send any.
+	Preferences a2a.Preferences
+	// Uploads are files assembled by UploadStore which are referenced by Message parts, keyed by FileURI.URI.
+	// Present when the handler was created WithUploads. Files are removed once execution finishes.
+	Uploads map[string]Upload
+}
+
+// DecodeSkillInput decodes the structured input of the skill invocation into v.
+// See a2a.DecodeSkillInput for details.
+func (r *RequestContext) DecodeSkillInput(v any) error {
+	return a2a.DecodeSkillInput(&r.Request.Message, v)
 }
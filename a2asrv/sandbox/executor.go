@@ -0,0 +1,346 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sandbox isolates untrusted or crash-prone agent code from the server process by running
+// a2asrv.AgentExecutor in a subprocess. Executor is an AgentExecutor which starts a subprocess for every
+// execution and proxies Execute and Cancel calls to it over stdio, while the subprocess serves
+// the calls with ServeStdio:
+//
+//	// server process
+//	executor, err := sandbox.NewExecutor(sandbox.Config{
+//		Command: func() *exec.Cmd { return exec.Command("./agent-worker") },
+//		Restart: sandbox.RestartPolicy{MaxRestarts: 2},
+//	})
+//	handler := a2asrv.NewHandler(executor, a2asrv.WithTaskStore(store))
+//
+//	// agent-worker
+//	func main() {
+//		if err := sandbox.ServeStdio(context.Background(), myExecutor); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+//
+// Messages are exchanged as JSON lines: the server sends "execute" and "cancel" requests with
+// the a2asrv.RequestContext, the subprocess answers with "event" messages carrying events encoded
+// with a2a.MarshalEvent and concludes every call with a "done" message.
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+const defaultKillTimeout = 5 * time.Second
+
+// ErrProcessExited is returned if the subprocess exited before the call it was handling returned.
+var ErrProcessExited = errors.New("sandbox process exited")
+
+// ResourceLimits is a hook for confining subprocesses, eg. with rlimits, cgroups or namespaces.
+type ResourceLimits interface {
+	// Prepare is called before a process for the task is started and can modify the command,
+	// eg. to set SysProcAttr or wrap it with prlimit.
+	Prepare(cmd *exec.Cmd, reqCtx a2asrv.RequestContext) error
+
+	// Started is called after the process was started, eg. to move it into a cgroup. The process is killed
+	// if an error is returned. The returned release function is called after the process exited.
+	Started(process *os.Process, reqCtx a2asrv.RequestContext) (release func(), err error)
+}
+
+// RestartPolicy defines how Executor handles subprocesses which exited during Execute.
+// A restarted execution invokes Execute in a new subprocess with the same RequestContext, so agent code
+// must be prepared to continue a Task it already wrote events for.
+type RestartPolicy struct {
+	// MaxRestarts is the number of times an execution is restarted. Zero disables restarts.
+	MaxRestarts int
+	// Backoff is the delay before a restart.
+	Backoff time.Duration
+}
+
+// Config configures Executor.
+type Config struct {
+	// Command creates the command which runs ServeStdio. It is called for every started subprocess
+	// and must not set Stdin and Stdout. Required.
+	Command func() *exec.Cmd
+	// Restart defines what happens when a subprocess exits during Execute.
+	Restart RestartPolicy
+	// Limits is an optional hook for confining subprocesses.
+	Limits ResourceLimits
+	// Stderr receives the standard error of subprocesses. Discarded if not set.
+	Stderr io.Writer
+	// KillTimeout is how long a subprocess is given to exit after its input was closed before it is killed.
+	// Defaults to 5 seconds.
+	KillTimeout time.Duration
+}
+
+// Executor is an a2asrv.AgentExecutor which runs every execution in a dedicated subprocess.
+// Cancel is delivered to the subprocess running the Task, or to a new subprocess if the Task is not
+// executed by this Executor.
+type Executor struct {
+	config Config
+
+	mu      sync.Mutex
+	running map[a2a.TaskID]*process
+}
+
+var _ a2asrv.AgentExecutor = (*Executor)(nil)
+
+// NewExecutor creates an Executor. An error is returned if Config.Command is not set.
+func NewExecutor(config Config) (*Executor, error) {
+	if config.Command == nil {
+		return nil, fmt.Errorf("sandbox command is required")
+	}
+	if config.KillTimeout <= 0 {
+		config.KillTimeout = defaultKillTimeout
+	}
+	return &Executor{config: config, running: make(map[a2a.TaskID]*process)}, nil
+}
+
+func (e *Executor) Execute(ctx context.Context, reqCtx a2asrv.RequestContext, queue eventqueue.Queue) error {
+	for attempt := 0; ; attempt++ {
+		p, err := e.start(reqCtx)
+		if err != nil {
+			return err
+		}
+		e.setRunning(reqCtx.TaskID, p)
+		err = p.call(ctx, msgExecute, reqCtx, queue)
+		e.clearRunning(reqCtx.TaskID, p)
+		p.stop(e.config.KillTimeout)
+
+		if !errors.Is(err, ErrProcessExited) || attempt >= e.config.Restart.MaxRestarts {
+			return err
+		}
+		if e.config.Restart.Backoff > 0 {
+			timer := time.NewTimer(e.config.Restart.Backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+func (e *Executor) Cancel(ctx context.Context, reqCtx a2asrv.RequestContext, queue eventqueue.Queue) error {
+	e.mu.Lock()
+	p := e.running[reqCtx.TaskID]
+	e.mu.Unlock()
+	if p != nil {
+		return p.call(ctx, msgCancel, reqCtx, queue)
+	}
+
+	p, err := e.start(reqCtx)
+	if err != nil {
+		return err
+	}
+	defer p.stop(e.config.KillTimeout)
+	return p.call(ctx, msgCancel, reqCtx, queue)
+}
+
+func (e *Executor) setRunning(taskID a2a.TaskID, p *process) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.running[taskID] = p
+}
+
+func (e *Executor) clearRunning(taskID a2a.TaskID, p *process) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.running[taskID] == p {
+		delete(e.running, taskID)
+	}
+}
+
+// start launches a subprocess for the request.
+func (e *Executor) start(reqCtx a2asrv.RequestContext) (*process, error) {
+	cmd := e.config.Command()
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sandbox stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sandbox stdout: %w", err)
+	}
+	if e.config.Stderr != nil {
+		cmd.Stderr = e.config.Stderr
+	}
+	if e.config.Limits != nil {
+		if err := e.config.Limits.Prepare(cmd, reqCtx); err != nil {
+			return nil, fmt.Errorf("failed to prepare sandbox limits: %w", err)
+		}
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start sandbox: %w", err)
+	}
+
+	release := func() {}
+	if e.config.Limits != nil {
+		if release, err = e.config.Limits.Started(cmd.Process, reqCtx); err != nil {
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+			return nil, fmt.Errorf("failed to apply sandbox limits: %w", err)
+		}
+	}
+
+	p := &process{
+		cmd:    cmd,
+		stdin:  stdin,
+		enc:    newEncoder(stdin),
+		calls:  make(map[int]*call),
+		exited: make(chan struct{}),
+	}
+	go p.readLoop(stdout, release)
+	return p, nil
+}
+
+// process is a running subprocess which handles calls concurrently.
+type process struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	enc   *encoder
+
+	mu       sync.Mutex
+	calls    map[int]*call
+	nextCall int
+
+	exited  chan struct{}
+	exitErr error
+}
+
+// call is an execute or cancel request in progress.
+type call struct {
+	ctx   context.Context
+	queue eventqueue.Queue
+	done  chan error
+}
+
+// call sends a request to the subprocess and forwards the events it produces to the queue until
+// the subprocess reports the result.
+func (p *process) call(ctx context.Context, msgType string, reqCtx a2asrv.RequestContext, queue eventqueue.Queue) error {
+	c := &call{ctx: ctx, queue: queue, done: make(chan error, 1)}
+	p.mu.Lock()
+	id := p.nextCall
+	p.nextCall++
+	p.calls[id] = c
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		delete(p.calls, id)
+		p.mu.Unlock()
+	}()
+
+	if err := p.enc.send(message{Type: msgType, Call: id, Request: &reqCtx}); err != nil {
+		select {
+		case <-p.exited:
+			return fmt.Errorf("%w: %w", ErrProcessExited, p.exitErr)
+		default:
+			return fmt.Errorf("failed to send %s request: %w", msgType, err)
+		}
+	}
+
+	select {
+	case err := <-c.done:
+		return err
+	case <-p.exited:
+		// The result might have been delivered right before the process exited.
+		select {
+		case err := <-c.done:
+			return err
+		default:
+			return fmt.Errorf("%w: %w", ErrProcessExited, p.exitErr)
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// readLoop dispatches messages from the subprocess to calls until its output is closed.
+func (p *process) readLoop(stdout io.Reader, release func()) {
+	dec := json.NewDecoder(stdout)
+	var protocolErr error
+	for {
+		var msg message
+		if err := dec.Decode(&msg); err != nil {
+			if !errors.Is(err, io.EOF) {
+				protocolErr = fmt.Errorf("invalid sandbox output: %w", err)
+				_ = p.cmd.Process.Kill()
+			}
+			break
+		}
+		p.mu.Lock()
+		c := p.calls[msg.Call]
+		p.mu.Unlock()
+		if c == nil {
+			continue
+		}
+
+		switch msg.Type {
+		case msgEvent:
+			event, err := a2a.UnmarshalEvent(msg.Event)
+			if err == nil {
+				err = c.queue.Write(c.ctx, event)
+			}
+			if err != nil {
+				c.done <- fmt.Errorf("failed to forward sandbox event: %w", err)
+				// The call is abandoned, further messages for it are dropped.
+				p.mu.Lock()
+				delete(p.calls, msg.Call)
+				p.mu.Unlock()
+			}
+		case msgDone:
+			var err error
+			if msg.Error != "" {
+				err = errors.New(msg.Error)
+			}
+			c.done <- err
+			p.mu.Lock()
+			delete(p.calls, msg.Call)
+			p.mu.Unlock()
+		}
+	}
+
+	waitErr := p.cmd.Wait()
+	release()
+	p.exitErr = errors.Join(protocolErr, waitErr)
+	if p.exitErr == nil {
+		p.exitErr = errors.New("exit status 0")
+	}
+	close(p.exited)
+}
+
+// stop closes the input of the subprocess which makes ServeStdio return and kills the process
+// if it didn't exit within the timeout.
+func (p *process) stop(timeout time.Duration) {
+	_ = p.stdin.Close()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-p.exited:
+	case <-timer.C:
+		_ = p.cmd.Process.Kill()
+		<-p.exited
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+// Types of the messages exchanged with a subprocess, one JSON object per line.
+const (
+	// msgExecute is sent to the subprocess to invoke AgentExecutor.Execute.
+	msgExecute = "execute"
+	// msgCancel is sent to the subprocess to invoke AgentExecutor.Cancel.
+	msgCancel = "cancel"
+	// msgEvent is sent by the subprocess for every event written to the queue of a call.
+	msgEvent = "event"
+	// msgDone is sent by the subprocess when a call returned.
+	msgDone = "done"
+)
+
+// message is the envelope of the stdio protocol. Call identifies the execute or cancel request
+// the message belongs to.
+type message struct {
+	Type    string                 `json:"type"`
+	Call    int                    `json:"call"`
+	Request *a2asrv.RequestContext `json:"request,omitempty"`
+	Event   json.RawMessage        `json:"event,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+// encoder serializes concurrent message writes.
+type encoder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func newEncoder(w io.Writer) *encoder {
+	return &encoder{enc: json.NewEncoder(w)}
+}
+
+func (e *encoder) send(msg message) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.enc.Encode(msg)
+}
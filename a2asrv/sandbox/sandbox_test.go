@@ -0,0 +1,246 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+const (
+	workerModeEnv   = "A2A_SANDBOX_TEST_MODE"
+	workerMarkerEnv = "A2A_SANDBOX_TEST_MARKER"
+)
+
+// TestMain runs the test binary as a sandbox worker when started by workerCommand.
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(workerModeEnv); mode != "" {
+		if err := ServeStdio(context.Background(), &testWorker{mode: mode}); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+type testWorker struct {
+	mode string
+}
+
+func statusEvent(reqCtx a2asrv.RequestContext, state a2a.TaskState) a2a.Event {
+	task := &a2a.Task{ID: reqCtx.TaskID, ContextID: reqCtx.ContextID}
+	return a2a.NewStatusUpdateEvent(task, state, nil)
+}
+
+func (w *testWorker) Execute(ctx context.Context, reqCtx a2asrv.RequestContext, queue eventqueue.Queue) error {
+	switch w.mode {
+	case "fail":
+		return errors.New("agent failed")
+	case "crash":
+		os.Exit(3)
+	case "crash-once":
+		marker := os.Getenv(workerMarkerEnv)
+		if _, err := os.Stat(marker); err != nil {
+			_ = os.WriteFile(marker, nil, 0o600)
+			os.Exit(3)
+		}
+	case "block":
+		if err := queue.Write(ctx, statusEvent(reqCtx, a2a.TaskStateWorking)); err != nil {
+			return err
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return queue.Write(ctx, statusEvent(reqCtx, a2a.TaskStateCompleted))
+}
+
+func (w *testWorker) Cancel(ctx context.Context, reqCtx a2asrv.RequestContext, queue eventqueue.Queue) error {
+	return queue.Write(ctx, statusEvent(reqCtx, a2a.TaskStateCanceled))
+}
+
+func workerCommand(mode string, env ...string) func() *exec.Cmd {
+	return func() *exec.Cmd {
+		cmd := exec.Command(os.Args[0], "-test.run=^$")
+		cmd.Env = append(os.Environ(), append(env, workerModeEnv+"="+mode)...)
+		return cmd
+	}
+}
+
+func newTestExecutor(t *testing.T, config Config) *Executor {
+	t.Helper()
+	executor, err := NewExecutor(config)
+	if err != nil {
+		t.Fatalf("NewExecutor() error = %v", err)
+	}
+	return executor
+}
+
+func readState(t *testing.T, queue eventqueue.Queue) a2a.TaskState {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	event, err := queue.Read(ctx)
+	if err != nil {
+		t.Fatalf("queue.Read() error = %v", err)
+	}
+	update, ok := event.(*a2a.TaskStatusUpdateEvent)
+	if !ok {
+		t.Fatalf("got event %T, want *a2a.TaskStatusUpdateEvent", event)
+	}
+	return update.Status.State
+}
+
+type recordingLimits struct {
+	prepared, started, released atomic.Int32
+}
+
+func (l *recordingLimits) Prepare(cmd *exec.Cmd, reqCtx a2asrv.RequestContext) error {
+	l.prepared.Add(1)
+	return nil
+}
+
+func (l *recordingLimits) Started(process *os.Process, reqCtx a2asrv.RequestContext) (func(), error) {
+	l.started.Add(1)
+	return func() { l.released.Add(1) }, nil
+}
+
+func TestExecutor_Execute(t *testing.T) {
+	t.Parallel()
+	limits := &recordingLimits{}
+	executor := newTestExecutor(t, Config{Command: workerCommand("complete"), Limits: limits})
+	queue := eventqueue.NewInMemoryQueue(10)
+	reqCtx := a2asrv.RequestContext{TaskID: "task-1", ContextID: "ctx-1"}
+
+	if err := executor.Execute(t.Context(), reqCtx, queue); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := readState(t, queue); got != a2a.TaskStateCompleted {
+		t.Fatalf("got state %s, want %s", got, a2a.TaskStateCompleted)
+	}
+	if limits.prepared.Load() != 1 || limits.started.Load() != 1 || limits.released.Load() != 1 {
+		t.Fatalf("limits hooks called %d/%d/%d times, want once each",
+			limits.prepared.Load(), limits.started.Load(), limits.released.Load())
+	}
+}
+
+func TestExecutor_Errors(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name    string
+		mode    string
+		restart RestartPolicy
+		wantErr error
+		wantMsg string
+	}{
+		{name: "agent error", mode: "fail", wantMsg: "agent failed"},
+		{name: "crash", mode: "crash", wantErr: ErrProcessExited},
+		{name: "crash after restarts", mode: "crash", restart: RestartPolicy{MaxRestarts: 2}, wantErr: ErrProcessExited},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			executor := newTestExecutor(t, Config{Command: workerCommand(tc.mode), Restart: tc.restart})
+			err := executor.Execute(t.Context(), a2asrv.RequestContext{TaskID: "task-1"}, eventqueue.NewInMemoryQueue(10))
+			if err == nil {
+				t.Fatal("Execute() succeeded, want error")
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantMsg != "" && err.Error() != tc.wantMsg {
+				t.Fatalf("Execute() error = %q, want %q", err, tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestExecutor_Restart(t *testing.T) {
+	t.Parallel()
+	marker := filepath.Join(t.TempDir(), "crashed")
+	executor := newTestExecutor(t, Config{
+		Command: workerCommand("crash-once", workerMarkerEnv+"="+marker),
+		Restart: RestartPolicy{MaxRestarts: 1, Backoff: time.Millisecond},
+	})
+	queue := eventqueue.NewInMemoryQueue(10)
+
+	if err := executor.Execute(t.Context(), a2asrv.RequestContext{TaskID: "task-1"}, queue); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := readState(t, queue); got != a2a.TaskStateCompleted {
+		t.Fatalf("got state %s, want %s", got, a2a.TaskStateCompleted)
+	}
+}
+
+func TestExecutor_Cancel(t *testing.T) {
+	t.Parallel()
+	executor := newTestExecutor(t, Config{Command: workerCommand("block")})
+	reqCtx := a2asrv.RequestContext{TaskID: "task-1", ContextID: "ctx-1"}
+	execQueue := eventqueue.NewInMemoryQueue(10)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	execErr := make(chan error, 1)
+	go func() { execErr <- executor.Execute(ctx, reqCtx, execQueue) }()
+	if got := readState(t, execQueue); got != a2a.TaskStateWorking {
+		t.Fatalf("got state %s, want %s", got, a2a.TaskStateWorking)
+	}
+
+	cancelQueue := eventqueue.NewInMemoryQueue(10)
+	if err := executor.Cancel(t.Context(), reqCtx, cancelQueue); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if got := readState(t, cancelQueue); got != a2a.TaskStateCanceled {
+		t.Fatalf("got state %s, want %s", got, a2a.TaskStateCanceled)
+	}
+
+	cancel()
+	select {
+	case err := <-execErr:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Execute() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Execute() didn't return after the context was canceled")
+	}
+}
+
+func TestExecutor_CancelNotRunning(t *testing.T) {
+	t.Parallel()
+	executor := newTestExecutor(t, Config{Command: workerCommand("complete")})
+	queue := eventqueue.NewInMemoryQueue(10)
+
+	if err := executor.Cancel(t.Context(), a2asrv.RequestContext{TaskID: "task-1"}, queue); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if got := readState(t, queue); got != a2a.TaskStateCanceled {
+		t.Fatalf("got state %s, want %s", got, a2a.TaskStateCanceled)
+	}
+}
+
+func TestNewExecutor_RequiresCommand(t *testing.T) {
+	t.Parallel()
+	if _, err := NewExecutor(Config{}); err == nil {
+		t.Fatal("NewExecutor() succeeded without a command")
+	}
+}
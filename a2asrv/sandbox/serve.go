@@ -0,0 +1,107 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// ServeStdio runs the executor in a subprocess started by Executor, communicating over
+// the standard input and output. Agent code must not write anything else to the standard output.
+func ServeStdio(ctx context.Context, executor a2asrv.AgentExecutor) error {
+	return Serve(ctx, executor, os.Stdin, os.Stdout)
+}
+
+// Serve reads execute and cancel requests from r and invokes the executor for them, writing
+// the events and results to w. Requests are handled concurrently. Serve returns when r reaches EOF,
+// which is how Executor stops the subprocess, after contexts of in-flight calls were canceled and
+// the calls returned.
+func Serve(ctx context.Context, executor a2asrv.AgentExecutor, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	err := serveRequests(ctx, executor, json.NewDecoder(r), newEncoder(w), &wg)
+	cancel()
+	wg.Wait()
+	return err
+}
+
+func serveRequests(ctx context.Context, executor a2asrv.AgentExecutor, dec *json.Decoder, enc *encoder, wg *sync.WaitGroup) error {
+	for {
+		var msg message
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to read request: %w", err)
+		}
+		if msg.Request == nil {
+			return fmt.Errorf("call %d: %s request without a request context", msg.Call, msg.Type)
+		}
+
+		var call func(context.Context, a2asrv.RequestContext, eventqueue.Queue) error
+		switch msg.Type {
+		case msgExecute:
+			call = executor.Execute
+		case msgCancel:
+			call = executor.Cancel
+		default:
+			return fmt.Errorf("call %d: unknown request type %q", msg.Call, msg.Type)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			queue := &pipeQueue{call: msg.Call, enc: enc}
+			done := message{Type: msgDone, Call: msg.Call}
+			if err := call(ctx, *msg.Request, queue); err != nil {
+				done.Error = err.Error()
+			}
+			// The host is gone if the result can't be delivered, it will notice the exit.
+			_ = enc.send(done)
+		}()
+	}
+}
+
+// pipeQueue is the write-only eventqueue.Queue given to the executor in the subprocess.
+type pipeQueue struct {
+	call int
+	enc  *encoder
+}
+
+func (q *pipeQueue) Write(ctx context.Context, event a2a.Event) error {
+	data, err := a2a.MarshalEvent(event)
+	if err != nil {
+		return fmt.Errorf("failed to serialize event: %w", err)
+	}
+	return q.enc.send(message{Type: msgEvent, Call: q.call, Event: data})
+}
+
+func (q *pipeQueue) Read(ctx context.Context) (a2a.Event, error) {
+	return nil, fmt.Errorf("reading from a sandboxed queue: %w", a2a.ErrUnsupportedOperation)
+}
+
+func (q *pipeQueue) Close() error {
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// SinkEvent is an event delivered to EventSinks.
+type SinkEvent struct {
+	// Time is when AgentExecutor wrote the event.
+	Time time.Time `json:"time"`
+	// TaskID is the ID of the Task the event was produced for.
+	TaskID a2a.TaskID `json:"taskId"`
+	// Kind is the protocol kind of the event or the kind a custom event was registered with.
+	Kind string `json:"kind"`
+	// Event is the event payload.
+	Event a2a.Event `json:"event"`
+}
+
+// EventSink receives every event AgentExecutor produces, for example to feed an analytics pipeline.
+// Unlike push notifications, sinks are not configured by clients and see all Tasks.
+// Implementations for HTTP endpoints and message brokers are provided by the eventsink package.
+type EventSink interface {
+	// Consume is called synchronously after an event was written to the Task queue, so slow sinks
+	// delay AgentExecutor. Errors are ignored by the handler: sinks are responsible for their own
+	// retries and error reporting.
+	Consume(ctx context.Context, event SinkEvent) error
+}
+
+// EventSinkFunc adapts a function to EventSink.
+type EventSinkFunc func(ctx context.Context, event SinkEvent) error
+
+func (f EventSinkFunc) Consume(ctx context.Context, event SinkEvent) error {
+	return f(ctx, event)
+}
+
+// WithEventSinks delivers every event written by AgentExecutor to the sinks. Can be used multiple times.
+func WithEventSinks(sinks ...EventSink) RequestHandlerOption {
+	return func(h *defaultRequestHandler) {
+		h.sinks = append(h.sinks, sinks...)
+	}
+}
+
+type sinkManager struct {
+	eventqueue.Manager
+	sinks []EventSink
+}
+
+func (m *sinkManager) GetOrCreate(ctx context.Context, taskId a2a.TaskID) (eventqueue.Queue, error) {
+	queue, err := m.Manager.GetOrCreate(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+	return &sinkQueue{Queue: queue, taskID: taskId, sinks: m.sinks}, nil
+}
+
+type sinkQueue struct {
+	eventqueue.Queue
+	taskID a2a.TaskID
+	sinks  []EventSink
+}
+
+func (q *sinkQueue) Write(ctx context.Context, event a2a.Event) error {
+	if err := q.Queue.Write(ctx, event); err != nil {
+		return err
+	}
+	record := SinkEvent{Time: ClockFrom(ctx).Now(), TaskID: q.taskID, Kind: a2a.EventKindOf(event), Event: event}
+	for _, sink := range q.sinks {
+		_ = sink.Consume(ctx, record)
+	}
+	return nil
+}
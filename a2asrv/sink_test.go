@@ -0,0 +1,51 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+func TestWithEventSinks(t *testing.T) {
+	var kinds []string
+	sink := EventSinkFunc(func(ctx context.Context, event SinkEvent) error {
+		if event.TaskID != taskID {
+			t.Errorf("SinkEvent.TaskID = %q, want %q", event.TaskID, taskID)
+		}
+		kinds = append(kinds, event.Kind)
+		return nil
+	})
+	executor := &mockAgentExecutor{ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+		task := &a2a.Task{ID: reqCtx.TaskID, ContextID: "ctx"}
+		if err := queue.Write(ctx, a2a.NewStatusUpdateEvent(task, a2a.TaskStateWorking, nil)); err != nil {
+			return err
+		}
+		return queue.Write(ctx, a2a.NewArtifactEvent(*task, a2a.TextPart{Text: "result"}))
+	}}
+	handler := NewHandler(executor, WithEventSinks(sink))
+
+	if _, err := handler.OnSendMessage(t.Context(), a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID}}); err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	want := []string{a2a.EventKindStatusUpdate, a2a.EventKindArtifactUpdate}
+	if !slices.Equal(kinds, want) {
+		t.Fatalf("sink received %v, want %v", kinds, want)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// SkillIDMetaKey is the Message metadata key clients use for selecting the skill which should handle the message.
+const SkillIDMetaKey = a2a.SkillIDMetaKey
+
+// ErrUnknownSkill is returned by SkillMux when a message can't be routed to a registered skill.
+var ErrUnknownSkill = errors.New("unknown skill")
+
+// SkillMux is a runtime skill registry. It routes messages to the executor of the skill referenced
+// by SkillIDMetaKey and produces an AgentCard reflecting the currently registered skills.
+// Messages with DataParts not conforming to the InputSchema of the skill are rejected with
+// an *a2a.SkillInputError before reaching the executor.
+// Skills can be registered and removed while the server is running.
+type SkillMux struct {
+	// Fallback handles messages which don't reference a skill. If nil, such messages are routed
+	// to the only registered skill or rejected with ErrUnknownSkill if there are multiple skills.
+	Fallback AgentExecutor
+
+	base AgentCardProducer
+
+	mu     sync.RWMutex
+	skills []a2a.AgentSkill
+	execs  map[string]AgentExecutor
+	tasks  map[a2a.TaskID]routedTask
+}
+
+// routedTask remembers which skill handled a Task.
+type routedTask struct {
+	skillID  string
+	executor AgentExecutor
+}
+
+// NewSkillMux creates a SkillMux which adds registered skills to the card produced by base.
+func NewSkillMux(base AgentCardProducer) *SkillMux {
+	return &SkillMux{base: base, execs: make(map[string]AgentExecutor), tasks: make(map[a2a.TaskID]routedTask)}
+}
+
+// Handle registers the skill and its executor. Returns an error if a skill with the same ID is already registered.
+func (m *SkillMux) Handle(skill a2a.AgentSkill, executor AgentExecutor) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.execs[skill.ID]; ok {
+		return fmt.Errorf("skill %q is already registered", skill.ID)
+	}
+	m.skills = append(m.skills, skill)
+	m.execs[skill.ID] = executor
+	return nil
+}
+
+// Remove unregisters the skill. Tasks already routed to the skill can still be canceled.
+// Returns false if the skill was not registered.
+func (m *SkillMux) Remove(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.execs[id]; !ok {
+		return false
+	}
+	delete(m.execs, id)
+	for i, skill := range m.skills {
+		if skill.ID == id {
+			m.skills = append(m.skills[:i:i], m.skills[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// Card returns a copy of the base card with the registered skills appended to its skills.
+func (m *SkillMux) Card() *a2a.AgentCard {
+	card := *m.base.Card()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	skills := make([]a2a.AgentSkill, 0, len(card.Skills)+len(m.skills))
+	skills = append(skills, card.Skills...)
+	card.Skills = append(skills, m.skills...)
+	return &card
+}
+
+func (m *SkillMux) Execute(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+	executor, skillID, err := m.route(reqCtx)
+	if err != nil {
+		return err
+	}
+	if skillID != "" {
+		m.mu.Lock()
+		m.tasks[reqCtx.TaskID] = routedTask{skillID: skillID, executor: executor}
+		m.mu.Unlock()
+	}
+	return executor.Execute(ctx, reqCtx, queue)
+}
+
+// Validate routes the request to a skill and validates the message against the skill InputSchema.
+// The request is then validated by the skill executor if it implements RequestValidator.
+func (m *SkillMux) Validate(ctx context.Context, reqCtx RequestContext) error {
+	executor, _, err := m.route(reqCtx)
+	if err != nil {
+		return err
+	}
+	return validateRequest(ctx, executor, reqCtx)
+}
+
+func (m *SkillMux) Cancel(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+	m.mu.RLock()
+	routed, ok := m.tasks[reqCtx.TaskID]
+	m.mu.RUnlock()
+	if ok {
+		return routed.executor.Cancel(ctx, reqCtx, queue)
+	}
+	if m.Fallback != nil {
+		return m.Fallback.Cancel(ctx, reqCtx, queue)
+	}
+	return fmt.Errorf("%w: no skill handled task %s", ErrUnknownSkill, reqCtx.TaskID)
+}
+
+func (m *SkillMux) route(reqCtx RequestContext) (AgentExecutor, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	skillID := reqCtx.SkillID
+	if skillID == "" {
+		skillID = a2a.SkillIDOf(&reqCtx.Request.Message)
+	}
+	if skillID == "" {
+		// follow-up messages continue with the skill which started the task
+		skillID = m.tasks[reqCtx.TaskID].skillID
+	}
+	if skillID != "" {
+		executor, ok := m.execs[skillID]
+		if !ok {
+			return nil, "", fmt.Errorf("%w: %s", ErrUnknownSkill, skillID)
+		}
+		return executor, skillID, m.validateInput(skillID, &reqCtx.Request.Message)
+	}
+	if m.Fallback != nil {
+		return m.Fallback, "", nil
+	}
+	if len(m.skills) == 1 {
+		id := m.skills[0].ID
+		return m.execs[id], id, m.validateInput(id, &reqCtx.Request.Message)
+	}
+	return nil, "", fmt.Errorf("%w: message doesn't reference a skill", ErrUnknownSkill)
+}
+
+func (m *SkillMux) validateInput(skillID string, msg *a2a.Message) error {
+	for i := range m.skills {
+		if m.skills[i].ID == skillID {
+			return m.skills[i].ValidateInput(msg)
+		}
+	}
+	return nil
+}
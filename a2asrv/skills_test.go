@@ -0,0 +1,170 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+func namedExecutor(name string, calls *[]string) AgentExecutor {
+	return &mockAgentExecutor{
+		ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+			*calls = append(*calls, name+":execute")
+			return nil
+		},
+		CancelFunc: func(ctx context.Context, reqCtx RequestContext, queue eventqueue.Queue) error {
+			*calls = append(*calls, name+":cancel")
+			return nil
+		},
+	}
+}
+
+func skillRequest(taskID a2a.TaskID, skillID string) RequestContext {
+	msg := a2a.Message{TaskID: taskID}
+	if skillID != "" {
+		msg.Metadata = map[string]any{SkillIDMetaKey: skillID}
+	}
+	return RequestContext{TaskID: taskID, Request: a2a.MessageSendParams{Message: msg}}
+}
+
+func TestSkillMux_Card(t *testing.T) {
+	base := &a2a.AgentCard{Name: "agent", Skills: []a2a.AgentSkill{{ID: "static"}}}
+	mux := NewSkillMux(AgentCardProducerFn(func() *a2a.AgentCard { return base }))
+	var calls []string
+
+	if err := mux.Handle(a2a.AgentSkill{ID: "flights"}, namedExecutor("flights", &calls)); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if err := mux.Handle(a2a.AgentSkill{ID: "hotels"}, namedExecutor("hotels", &calls)); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if err := mux.Handle(a2a.AgentSkill{ID: "hotels"}, namedExecutor("hotels", &calls)); err == nil {
+		t.Fatal("Handle() of a duplicate skill succeeded")
+	}
+	if got := skillIDs(mux.Card()); got != "static,flights,hotels" {
+		t.Fatalf("Card() skills = %s, want static,flights,hotels", got)
+	}
+
+	if !mux.Remove("flights") || mux.Remove("flights") {
+		t.Fatal("Remove() must succeed only for a registered skill")
+	}
+	if got := skillIDs(mux.Card()); got != "static,hotels" {
+		t.Fatalf("Card() skills after Remove() = %s, want static,hotels", got)
+	}
+	if len(base.Skills) != 1 {
+		t.Fatalf("Card() modified the base card")
+	}
+}
+
+func TestSkillMux_Routing(t *testing.T) {
+	ctx := t.Context()
+	mux := NewSkillMux(AgentCardProducerFn(func() *a2a.AgentCard { return &a2a.AgentCard{} }))
+	var calls []string
+	_ = mux.Handle(a2a.AgentSkill{ID: "flights"}, namedExecutor("flights", &calls))
+
+	// the only skill handles messages without a skill reference
+	if err := mux.Execute(ctx, skillRequest("t1", ""), nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	_ = mux.Handle(a2a.AgentSkill{ID: "hotels"}, namedExecutor("hotels", &calls))
+	if err := mux.Execute(ctx, skillRequest("t2", "hotels"), nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	// follow-up continues with the skill which started the task
+	if err := mux.Execute(ctx, skillRequest("t2", ""), nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if err := mux.Execute(ctx, skillRequest("t3", ""), nil); !errors.Is(err, ErrUnknownSkill) {
+		t.Fatalf("Execute() ambiguous error = %v, want %v", err, ErrUnknownSkill)
+	}
+	if err := mux.Execute(ctx, skillRequest("t3", "cars"), nil); !errors.Is(err, ErrUnknownSkill) {
+		t.Fatalf("Execute() unknown error = %v, want %v", err, ErrUnknownSkill)
+	}
+
+	// removed skills can still cancel their tasks
+	mux.Remove("hotels")
+	if err := mux.Cancel(ctx, skillRequest("t2", ""), nil); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if err := mux.Cancel(ctx, skillRequest("t3", ""), nil); !errors.Is(err, ErrUnknownSkill) {
+		t.Fatalf("Cancel() error = %v, want %v", err, ErrUnknownSkill)
+	}
+
+	want := []string{"flights:execute", "hotels:execute", "hotels:execute", "hotels:cancel"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("calls = %v, want %v", calls, want)
+		}
+	}
+}
+
+func skillIDs(card *a2a.AgentCard) string {
+	result := ""
+	for i, skill := range card.Skills {
+		if i > 0 {
+			result += ","
+		}
+		result += skill.ID
+	}
+	return result
+}
+
+func TestSkillMux_InputSchema(t *testing.T) {
+	ctx := t.Context()
+	mux := NewSkillMux(AgentCardProducerFn(func() *a2a.AgentCard { return &a2a.AgentCard{} }))
+	var calls []string
+	skill := a2a.AgentSkill{ID: "flights", InputSchema: map[string]any{
+		"type":     "object",
+		"required": []string{"from"},
+	}}
+	_ = mux.Handle(skill, namedExecutor("flights", &calls))
+
+	req := skillRequest("t1", "flights")
+	req.Request.Message.Parts = a2a.ContentParts{a2a.DataPart{Data: map[string]any{"to": "SFO"}}}
+	var inputErr *a2a.SkillInputError
+	if err := mux.Execute(ctx, req, nil); !errors.As(err, &inputErr) || !errors.Is(err, a2a.ErrInvalidRequest) {
+		t.Fatalf("Execute() error = %v, want SkillInputError", err)
+	}
+
+	req.Request.Message.Parts = a2a.ContentParts{a2a.DataPart{Data: map[string]any{"from": "JFK"}}}
+	if err := mux.Execute(ctx, req, nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("calls = %v, want only the valid message executed", calls)
+	}
+}
+
+func TestSkillMux_RequestContextSkillID(t *testing.T) {
+	mux := NewSkillMux(AgentCardProducerFn(func() *a2a.AgentCard { return &a2a.AgentCard{} }))
+	var calls []string
+	_ = mux.Handle(a2a.AgentSkill{ID: "flights"}, namedExecutor("flights", &calls))
+	_ = mux.Handle(a2a.AgentSkill{ID: "hotels"}, namedExecutor("hotels", &calls))
+
+	if err := mux.Execute(t.Context(), RequestContext{TaskID: "t1", SkillID: "hotels"}, nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(calls) != 1 || calls[0] != "hotels:execute" {
+		t.Fatalf("calls = %v, want hotels:execute", calls)
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/a2aproject/a2a-go/internal/taskstore"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
+)
+
+// startStreamingExecution acquires the resources of an execution and starts AgentExecutor in a separate
+// goroutine which releases them once the execution finishes. Events are delivered through a Fanout registered
+// for the Task, so that clients can resubscribe, and the returned Subscription receives all of them. The
+// returned channel receives the result of the execution before the queue gets destroyed. Events are applied
+// to created if the Task was created by the handler or to the stored Task the message continues.
+func (h *defaultRequestHandler) startStreamingExecution(ctx context.Context, message a2a.MessageSendParams, prefs a2a.Preferences, created *a2a.Task) (*eventqueue.Subscription, <-chan error, error) {
+	taskID := message.Message.TaskID
+	var cleanup []func()
+	rollback := func() {
+		for _, f := range slices.Backward(cleanup) {
+			f()
+		}
+	}
+	releaseQuota := func(time.Duration) {}
+	if h.quotas != nil {
+		release, err := h.quotas.acquire(ctx, message.Message.ContextID)
+		if err != nil {
+			return nil, nil, err
+		}
+		releaseQuota = release
+		cleanup = append(cleanup, func() { release(0) })
+	}
+	if err := h.ownership.Acquire(ctx, taskID); err != nil {
+		rollback()
+		return nil, nil, fmt.Errorf("failed to acquire task ownership: %w", err)
+	}
+	releaseOwnership := func() { _ = h.ownership.Release(context.WithoutCancel(ctx), taskID) }
+	cleanup = append(cleanup, releaseOwnership)
+	queue, err := h.queueManager.GetOrCreate(ctx, taskID)
+	if err != nil {
+		rollback()
+		return nil, nil, fmt.Errorf("failed to retrieve queue: %w", err)
+	}
+	reqCtx := RequestContext{
+		Request:     message,
+		TaskID:      taskID,
+		SkillID:     a2a.SkillIDOf(&message.Message),
+		Preferences: prefs,
+	}
+	if h.uploads != nil {
+		if reqCtx.Uploads, err = h.uploads.resolve(message.Message, true); err != nil {
+			rollback()
+			return nil, nil, err
+		}
+		cleanup = append(cleanup, func() { h.removeUploads(reqCtx.Uploads) })
+	}
+	initial, err := h.prepareTask(ctx, created, &reqCtx)
+	if err != nil {
+		rollback()
+		return nil, nil, err
+	}
+
+	// the execution outlives the request, so the client can resubscribe after disconnecting
+	execCtx, cancel := executionContext(withPreferences(context.WithoutCancel(ctx), prefs))
+	execution := h.executions.add(taskID, cancel)
+	reader := &updatingReader{queue: queue, saver: h.taskSaver(), updates: initial, execCtx: execCtx}
+	fanout := eventqueue.NewFanout(reader, eventqueue.FanoutConfig{})
+	sub := fanout.Subscribe()
+	h.streams.add(taskID, fanout)
+	go func() {
+		if err := fanout.Run(context.WithoutCancel(ctx)); err != nil {
+			// the events can't be applied to the Task anymore, so there's no point in continuing
+			h.executions.cancel(taskID, err)
+		}
+		h.streams.remove(taskID, fanout)
+		// the ownership is kept until all the events of the execution are applied
+		releaseOwnership()
+		h.executions.finish(taskID, execution)
+	}()
+
+	writeQueue := queue
+	if window, ok := CreditWindowFrom(ctx); ok {
+		writeQueue = eventqueue.NewFlowControlledQueue(queue, window)
+	}
+	h.inFlight.Add(1)
+	execErr := make(chan error, 1)
+	go func() {
+		if h.uploads != nil {
+			defer h.removeUploads(reqCtx.Uploads)
+		}
+		start := ClockFrom(ctx).Now()
+		err := h.executor.Execute(execCtx, reqCtx, writeQueue)
+		releaseQuota(ClockFrom(ctx).Now().Sub(start))
+		h.inFlight.Add(-1)
+		cancel(nil)
+		execErr <- err
+		// closing the queue ends the subscriptions once all the produced events are read
+		_ = h.queueManager.Destroy(context.WithoutCancel(ctx), taskID)
+	}()
+	return sub, execErr, nil
+}
+
+// streamSubscription yields events from the subscription until a Task reaches a terminal state, a Message
+// is yielded or the execution finishes. If execErr is not nil, the result of the execution is yielded
+// as an error when it fails. hasTask must be set if the Task was already yielded, otherwise an execution
+// which didn't produce any Task events or Messages is reported as an invalid agent response.
+func streamSubscription(ctx context.Context, sub *eventqueue.Subscription, hasTask bool, execErr <-chan error, yield func(a2a.Event, error) bool) {
+	for {
+		frame, err := sub.Next(ctx)
+		if errors.Is(err, eventqueue.ErrQueueClosed) {
+			break
+		}
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		event := frame.Event
+		encoded, share := encodedEventsFrom(ctx)
+		if share {
+			encoded.set(frame)
+		}
+		more := yield(event, nil)
+		if share {
+			encoded.set(nil)
+		}
+		// the shared encoding can be used by the transport until the event is handled
+		frame.Release()
+		if !more {
+			return
+		}
+
+		switch v := event.(type) {
+		case *a2a.Message:
+			return
+		case *a2a.Task:
+			if v.Status.State.Terminal() {
+				return
+			}
+		case *a2a.TaskStatusUpdateEvent:
+			if v.Status.State.Terminal() {
+				return
+			}
+		}
+		if _, ok := event.(a2a.CustomEvent); !ok {
+			hasTask = true
+		}
+	}
+
+	if execErr != nil {
+		if err := <-execErr; err != nil {
+			yield(nil, err)
+			return
+		}
+	}
+	if !hasTask {
+		yield(nil, fmt.Errorf("execution finished without a result: %w", a2a.ErrInvalidAgentResponse))
+	}
+}
+
+// EncodedEvents gives transports access to the encoding of streamed events, which is shared by all
+// subscribers of a Task, so that every event is encoded once instead of once per client.
+type EncodedEvents struct {
+	frame *eventqueue.Frame
+}
+
+type encodedEventsKey struct{}
+
+// WithEncodedEvents returns a context which makes streaming methods of the RequestHandler record the
+// shared encoding of the events they yield in encoded.
+func WithEncodedEvents(ctx context.Context, encoded *EncodedEvents) context.Context {
+	return context.WithValue(ctx, encodedEventsKey{}, encoded)
+}
+
+func encodedEventsFrom(ctx context.Context) (*EncodedEvents, bool) {
+	encoded, ok := ctx.Value(encodedEventsKey{}).(*EncodedEvents)
+	return encoded, ok && encoded != nil
+}
+
+func (e *EncodedEvents) set(frame *eventqueue.Frame) {
+	e.frame = frame
+}
+
+// Lookup returns the a2a.MarshalEvent encoding of the event if it's the event being yielded by the stream.
+// Events replaced by a wrapping RequestHandler, eg. redacted copies, are not found. The data is only valid
+// until the loop body handling the event returns and must not be modified.
+func (e *EncodedEvents) Lookup(event a2a.Event) ([]byte, bool) {
+	if e.frame == nil || !sameEvent(e.frame.Event, event) {
+		return nil, false
+	}
+	data, err := e.frame.Bytes()
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// sameEvent reports whether a and b are the same protocol object. Custom events are never the same,
+// because they can be values of types which are not comparable.
+func sameEvent(a, b a2a.Event) bool {
+	switch a := a.(type) {
+	case *a2a.Message:
+		b, ok := b.(*a2a.Message)
+		return ok && a == b
+	case *a2a.Task:
+		b, ok := b.(*a2a.Task)
+		return ok && a == b
+	case *a2a.TaskStatusUpdateEvent:
+		b, ok := b.(*a2a.TaskStatusUpdateEvent)
+		return ok && a == b
+	case *a2a.TaskArtifactUpdateEvent:
+		b, ok := b.(*a2a.TaskArtifactUpdateEvent)
+		return ok && a == b
+	}
+	return false
+}
+
+// updatingReader applies the Task events it reads from the queue to the Task, which is saved if TaskStore
+// is configured, before returning them. Reading through it guarantees that every event delivered to the
+// clients is reflected in the stored Task.
+// If execCtx was canceled by OnCancelTask or TaskAdmin.ForceCancelTask, the Task is canceled after all
+// the events of the execution are applied and the cancellation is returned as the last event.
+type updatingReader struct {
+	queue   eventqueue.Reader
+	saver   taskupdate.Saver
+	updates *taskupdate.Manager
+	execCtx context.Context
+	// closed is set once the queue was closed.
+	closed bool
+}
+
+func (r *updatingReader) Read(ctx context.Context) (a2a.Event, error) {
+	event, err := r.queue.Read(ctx)
+	if errors.Is(err, eventqueue.ErrQueueClosed) && !r.closed {
+		r.closed = true
+		if r.updates != nil && canceledByRequest(r.execCtx) && !r.updates.Task.Status.State.Terminal() {
+			event := NewCancellationEvent(r.execCtx, r.updates.Task)
+			if err := r.updates.Process(ctx, event); err != nil {
+				return nil, fmt.Errorf("failed to save canceled task: %w", err)
+			}
+			return event, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch event.(type) {
+	case *a2a.Message, a2a.CustomEvent:
+		return event, nil
+	}
+
+	if r.updates == nil {
+		task, err := newTaskForEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		r.updates = taskupdate.NewManager(r.saver, task)
+	}
+	update := event
+	if task, ok := event.(*a2a.Task); ok {
+		// the manager keeps updating the snapshot it's given, while subscribers can still be reading the event
+		if update, err = taskstore.DeepCopy(task); err != nil {
+			return nil, fmt.Errorf("failed to copy task: %w", err)
+		}
+	}
+	if err := r.updates.Process(ctx, update); err != nil {
+		return nil, fmt.Errorf("failed to process %T: %w", event, err)
+	}
+	return event, nil
+}
+
+// taskStreams tracks the Fanouts of streaming executions in progress, so that clients can resubscribe.
+type taskStreams struct {
+	mu      sync.Mutex
+	fanouts map[a2a.TaskID]*eventqueue.Fanout
+}
+
+func (s *taskStreams) add(taskId a2a.TaskID, fanout *eventqueue.Fanout) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fanouts == nil {
+		s.fanouts = make(map[a2a.TaskID]*eventqueue.Fanout)
+	}
+	s.fanouts[taskId] = fanout
+}
+
+func (s *taskStreams) get(taskId a2a.TaskID) (*eventqueue.Fanout, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fanout, ok := s.fanouts[taskId]
+	return fanout, ok
+}
+
+// remove unregisters the Fanout unless another execution of the Task replaced it already.
+func (s *taskStreams) remove(taskId a2a.TaskID, fanout *eventqueue.Fanout) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fanouts[taskId] == fanout {
+		delete(s.fanouts, taskId)
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/internal/taskstore"
+)
+
+// OperationMetrics describes calls of a single TaskStore method.
+type OperationMetrics struct {
+	// Count is the number of calls.
+	Count int64 `json:"count"`
+	// Errors is the number of calls which returned an error.
+	Errors int64 `json:"errors"`
+	// TotalLatency is the time spent in all the calls.
+	TotalLatency time.Duration `json:"totalLatency"`
+	// MaxLatency is the time spent in the slowest call.
+	MaxLatency time.Duration `json:"maxLatency"`
+}
+
+// MeanLatency returns the average call duration.
+func (m OperationMetrics) MeanLatency() time.Duration {
+	if m.Count == 0 {
+		return 0
+	}
+	return m.TotalLatency / time.Duration(m.Count)
+}
+
+func (m *OperationMetrics) record(start time.Time, err error) {
+	latency := time.Since(start)
+	m.Count++
+	m.TotalLatency += latency
+	m.MaxLatency = max(m.MaxLatency, latency)
+	if err != nil {
+		m.Errors++
+	}
+}
+
+// TaskStoreMetrics is a snapshot of CachingTaskStore metrics.
+type TaskStoreMetrics struct {
+	// Get describes reads from the underlying TaskStore, which happen on cache misses.
+	Get OperationMetrics `json:"get"`
+	// Save describes writes to the underlying TaskStore.
+	Save OperationMetrics `json:"save"`
+	// CacheHits is the number of Get calls served from the cache.
+	CacheHits int64 `json:"cacheHits"`
+	// CacheMisses is the number of Get calls forwarded to the underlying TaskStore.
+	CacheMisses int64 `json:"cacheMisses"`
+	// CachedTasks is the number of Tasks currently in the cache.
+	CachedTasks int `json:"cachedTasks"`
+	// Backend is reported by the underlying TaskStore if it implements StatsReporter.
+	Backend any `json:"backend,omitempty"`
+}
+
+// HitRate returns the fraction of Get calls served from the cache.
+func (m TaskStoreMetrics) HitRate() float64 {
+	total := m.CacheHits + m.CacheMisses
+	if total == 0 {
+		return 0
+	}
+	return float64(m.CacheHits) / float64(total)
+}
+
+// CachingTaskStore is a TaskStore decorator which keeps recently used Tasks in memory and collects
+// operation metrics. tasks/get is called heavily by polling clients, so the cache takes load off
+// persistent backends. Writes go to the underlying TaskStore before the cache is updated.
+// The cache is only consistent if all writes to the underlying TaskStore go through the same
+// CachingTaskStore, eg. when TaskOwnership routes all work on a Task to a single replica.
+type CachingTaskStore struct {
+	store TaskStore
+	size  int
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[a2a.TaskID]*list.Element
+	metrics TaskStoreMetrics
+}
+
+// NewCachingTaskStore creates a CachingTaskStore which keeps up to size most recently used Tasks.
+// No Tasks are cached if size is 0, which can be used for collecting metrics only.
+func NewCachingTaskStore(store TaskStore, size int) *CachingTaskStore {
+	return &CachingTaskStore{
+		store:   store,
+		size:    size,
+		lru:     list.New(),
+		entries: make(map[a2a.TaskID]*list.Element),
+	}
+}
+
+func (s *CachingTaskStore) Save(ctx context.Context, task a2a.Task) error {
+	start := time.Now()
+	err := s.store.Save(ctx, task)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metrics.Save.record(start, err)
+	if err != nil {
+		// the stored state is unknown
+		s.evict(task.ID)
+		return err
+	}
+	s.put(task)
+	return nil
+}
+
+func (s *CachingTaskStore) Get(ctx context.Context, taskId a2a.TaskID) (a2a.Task, error) {
+	s.mu.Lock()
+	if elem, ok := s.entries[taskId]; ok {
+		s.metrics.CacheHits++
+		s.lru.MoveToFront(elem)
+		task := elem.Value.(*a2a.Task)
+		s.mu.Unlock()
+		return copyTask(task)
+	}
+	s.metrics.CacheMisses++
+	s.mu.Unlock()
+
+	start := time.Now()
+	task, err := s.store.Get(ctx, taskId)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metrics.Get.record(start, err)
+	if err != nil {
+		return a2a.Task{}, err
+	}
+	if _, ok := s.entries[taskId]; !ok {
+		s.put(task)
+	}
+	return task, nil
+}
+
+// SaveAll implements BatchTaskStore. The underlying TaskStore is used for a batch write if it implements
+// BatchTaskStore. A batch counts as a single Save operation in metrics.
+func (s *CachingTaskStore) SaveAll(ctx context.Context, tasks []a2a.Task) error {
+	start := time.Now()
+	err := SaveAll(ctx, s.store, tasks)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metrics.Save.record(start, err)
+	for _, task := range tasks {
+		if err != nil {
+			s.evict(task.ID)
+		} else {
+			s.put(task)
+		}
+	}
+	return err
+}
+
+// GetAll implements BatchTaskStore. Tasks missing from the cache are requested from the underlying
+// TaskStore in a single batch if it implements BatchTaskStore.
+func (s *CachingTaskStore) GetAll(ctx context.Context, taskIds []a2a.TaskID) (map[a2a.TaskID]a2a.Task, error) {
+	result := make(map[a2a.TaskID]a2a.Task, len(taskIds))
+	var missing []a2a.TaskID
+	s.mu.Lock()
+	for _, id := range taskIds {
+		elem, ok := s.entries[id]
+		if !ok {
+			s.metrics.CacheMisses++
+			missing = append(missing, id)
+			continue
+		}
+		s.metrics.CacheHits++
+		s.lru.MoveToFront(elem)
+		task, err := copyTask(elem.Value.(*a2a.Task))
+		if err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+		result[id] = task
+	}
+	s.mu.Unlock()
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	start := time.Now()
+	loaded, err := GetAll(ctx, s.store, missing)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metrics.Get.record(start, err)
+	if err != nil {
+		return nil, err
+	}
+	for id, task := range loaded {
+		if _, ok := s.entries[id]; !ok {
+			s.put(task)
+		}
+		result[id] = task
+	}
+	return result, nil
+}
+
+// Metrics returns a snapshot of collected metrics.
+func (s *CachingTaskStore) Metrics() TaskStoreMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	metrics := s.metrics
+	metrics.CachedTasks = s.lru.Len()
+	return metrics
+}
+
+// Stats returns TaskStoreMetrics.
+func (s *CachingTaskStore) Stats(ctx context.Context) (any, error) {
+	metrics := s.Metrics()
+	if reporter, ok := s.store.(StatsReporter); ok {
+		backend, err := reporter.Stats(ctx)
+		if err != nil {
+			return nil, err
+		}
+		metrics.Backend = backend
+	}
+	return metrics, nil
+}
+
+// put must be called with mu held.
+func (s *CachingTaskStore) put(task a2a.Task) {
+	if s.size <= 0 {
+		return
+	}
+	cached, err := taskstore.DeepCopy(&task)
+	if err != nil {
+		s.evict(task.ID)
+		return
+	}
+	if elem, ok := s.entries[task.ID]; ok {
+		elem.Value = cached
+		s.lru.MoveToFront(elem)
+		return
+	}
+	s.entries[task.ID] = s.lru.PushFront(cached)
+	if s.lru.Len() > s.size {
+		oldest := s.lru.Back()
+		s.lru.Remove(oldest)
+		delete(s.entries, oldest.Value.(*a2a.Task).ID)
+	}
+}
+
+// evict must be called with mu held.
+func (s *CachingTaskStore) evict(taskId a2a.TaskID) {
+	if elem, ok := s.entries[taskId]; ok {
+		s.lru.Remove(elem)
+		delete(s.entries, taskId)
+	}
+}
+
+func copyTask(task *a2a.Task) (a2a.Task, error) {
+	copy, err := taskstore.DeepCopy(task)
+	if err != nil {
+		return a2a.Task{}, err
+	}
+	return *copy, nil
+}
@@ -0,0 +1,274 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// countingTaskStore counts Get calls and can be configured to fail Save.
+type countingTaskStore struct {
+	testTaskStore
+	gets    int
+	saveErr error
+}
+
+func newCountingTaskStore() *countingTaskStore {
+	return &countingTaskStore{testTaskStore: testTaskStore{
+		tasks:       make(map[a2a.TaskID]a2a.Task),
+		transitions: make(map[a2a.TaskID][]a2a.TaskStatus),
+	}}
+}
+
+func (s *countingTaskStore) Save(ctx context.Context, task a2a.Task) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	return s.testTaskStore.Save(ctx, task)
+}
+
+func (s *countingTaskStore) Get(ctx context.Context, taskId a2a.TaskID) (a2a.Task, error) {
+	s.gets++
+	return s.testTaskStore.Get(ctx, taskId)
+}
+
+func TestCachingTaskStore(t *testing.T) {
+	ctx := t.Context()
+	backend := newCountingTaskStore()
+	store := NewCachingTaskStore(backend, 2)
+
+	for _, id := range []a2a.TaskID{"task-1", "task-2", "task-3"} {
+		if err := store.Save(ctx, a2a.Task{ID: id, Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	// task-1 was evicted by task-3
+	for _, id := range []a2a.TaskID{"task-3", "task-2", "task-1", "task-1"} {
+		task, err := store.Get(ctx, id)
+		if err != nil || task.ID != id {
+			t.Fatalf("Get(%s) = %v, %v", id, task.ID, err)
+		}
+	}
+	if _, err := store.Get(ctx, "unknown"); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Fatalf("Get() of unknown task error = %v, want %v", err, a2a.ErrTaskNotFound)
+	}
+
+	metrics := store.Metrics()
+	if metrics.CacheHits != 3 || metrics.CacheMisses != 2 || backend.gets != 2 {
+		t.Fatalf("Metrics() = %+v with %d backend gets, want 3 hits and 2 misses", metrics, backend.gets)
+	}
+	if metrics.Save.Count != 3 || metrics.Get.Count != 2 || metrics.Get.Errors != 1 || metrics.CachedTasks != 2 {
+		t.Fatalf("Metrics() = %+v, want 3 saves, 2 gets with 1 error and 2 cached tasks", metrics)
+	}
+	if got := metrics.HitRate(); got != 0.6 {
+		t.Fatalf("HitRate() = %v, want 0.6", got)
+	}
+}
+
+func TestCachingTaskStore_ReturnsCopies(t *testing.T) {
+	ctx := t.Context()
+	store := NewCachingTaskStore(newCountingTaskStore(), 10)
+	task := a2a.Task{ID: "task-1", History: []*a2a.Message{{ID: "m1"}}}
+	if err := store.Save(ctx, task); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	task.History[0].ID = "modified after save"
+
+	got, err := store.Get(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	got.History[0].ID = "modified after get"
+
+	got, _ = store.Get(ctx, "task-1")
+	if got.History[0].ID != "m1" {
+		t.Fatalf("Get() history = %s, want cached task to be unaffected by modifications", got.History[0].ID)
+	}
+}
+
+func TestCachingTaskStore_SaveFailureEvicts(t *testing.T) {
+	ctx := t.Context()
+	backend := newCountingTaskStore()
+	store := NewCachingTaskStore(backend, 10)
+	if err := store.Save(ctx, a2a.Task{ID: "task-1"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	backend.saveErr = errors.New("db unavailable")
+	if err := store.Save(ctx, a2a.Task{ID: "task-1", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}}); !errors.Is(err, backend.saveErr) {
+		t.Fatalf("Save() error = %v, want %v", err, backend.saveErr)
+	}
+	if _, err := store.Get(ctx, "task-1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if backend.gets != 1 {
+		t.Fatalf("backend got %d Get calls, want the task to be re-read after a failed Save", backend.gets)
+	}
+	if metrics := store.Metrics(); metrics.Save.Errors != 1 {
+		t.Fatalf("Metrics().Save.Errors = %d, want 1", metrics.Save.Errors)
+	}
+}
+
+// batchTaskStore counts batch calls.
+type batchTaskStore struct {
+	*countingTaskStore
+	batchGets int
+}
+
+func (s *batchTaskStore) SaveAll(ctx context.Context, tasks []a2a.Task) error {
+	for _, task := range tasks {
+		if err := s.Save(ctx, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *batchTaskStore) GetAll(ctx context.Context, taskIds []a2a.TaskID) (map[a2a.TaskID]a2a.Task, error) {
+	s.batchGets++
+	result := make(map[a2a.TaskID]a2a.Task)
+	for _, id := range taskIds {
+		if task, ok := s.tasks[id]; ok {
+			result[id] = task
+		}
+	}
+	return result, nil
+}
+
+func TestGetAll(t *testing.T) {
+	tasks := []a2a.Task{{ID: "task-1"}, {ID: "task-2"}}
+	ids := []a2a.TaskID{"task-1", "missing", "task-2"}
+
+	t.Run("fallback", func(t *testing.T) {
+		store := newCountingTaskStore()
+		if err := SaveAll(t.Context(), store, tasks); err != nil {
+			t.Fatalf("SaveAll() error = %v", err)
+		}
+		got, err := GetAll(t.Context(), store, ids)
+		if err != nil || len(got) != 2 || store.gets != 3 {
+			t.Fatalf("GetAll() = %v, %v with %d Get calls, want 2 tasks and 3 calls", got, err, store.gets)
+		}
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		store := &batchTaskStore{countingTaskStore: newCountingTaskStore()}
+		if err := SaveAll(t.Context(), store, tasks); err != nil {
+			t.Fatalf("SaveAll() error = %v", err)
+		}
+		got, err := GetAll(t.Context(), store, ids)
+		if err != nil || len(got) != 2 || store.gets != 0 || store.batchGets != 1 {
+			t.Fatalf("GetAll() = %v, %v with %d Get and %d GetAll calls, want 2 tasks from one GetAll", got, err, store.gets, store.batchGets)
+		}
+	})
+}
+
+func TestCachingTaskStore_GetAll(t *testing.T) {
+	ctx := t.Context()
+	backend := &batchTaskStore{countingTaskStore: newCountingTaskStore()}
+	if err := backend.SaveAll(ctx, []a2a.Task{{ID: "task-1"}, {ID: "task-2"}, {ID: "task-3"}}); err != nil {
+		t.Fatalf("SaveAll() error = %v", err)
+	}
+	store := NewCachingTaskStore(backend, 10)
+	if _, err := store.Get(ctx, "task-1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	got, err := store.GetAll(ctx, []a2a.TaskID{"task-1", "task-2", "task-3", "missing"})
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(got) != 3 || backend.batchGets != 1 {
+		t.Fatalf("GetAll() = %v with %d backend batches, want 3 tasks and 1 batch", got, backend.batchGets)
+	}
+	if metrics := store.Metrics(); metrics.CacheHits != 1 || metrics.CacheMisses != 4 || metrics.CachedTasks != 3 {
+		t.Fatalf("Metrics() = %+v, want 1 hit, 4 misses and 3 cached tasks", metrics)
+	}
+}
+
+// lockedTaskStore makes testTaskStore safe for concurrent use.
+type lockedTaskStore struct {
+	mu sync.Mutex
+	testTaskStore
+}
+
+func newLockedTaskStore() *lockedTaskStore {
+	return &lockedTaskStore{testTaskStore: testTaskStore{
+		tasks:       make(map[a2a.TaskID]a2a.Task),
+		transitions: make(map[a2a.TaskID][]a2a.TaskStatus),
+	}}
+}
+
+func (s *lockedTaskStore) Save(ctx context.Context, task a2a.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.testTaskStore.Save(ctx, task)
+}
+
+func (s *lockedTaskStore) Get(ctx context.Context, taskId a2a.TaskID) (a2a.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.testTaskStore.Get(ctx, taskId)
+}
+
+func TestCachingTaskStore_ConcurrentMixedWorkload(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	backend := newLockedTaskStore()
+	store := NewCachingTaskStore(backend, 4)
+
+	numTaskIDs, numWorkers, numOps := 8, 16, 100
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numOps; j++ {
+				id := a2a.TaskID(fmt.Sprintf("task-%d", (i+j)%numTaskIDs))
+				switch j % 4 {
+				case 0:
+					task := a2a.Task{ID: id, Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+					if err := store.Save(ctx, task); err != nil {
+						t.Errorf("Save() error = %v", err)
+					}
+				case 1, 2:
+					task, err := store.Get(ctx, id)
+					if err != nil && !errors.Is(err, a2a.ErrTaskNotFound) {
+						t.Errorf("Get() error = %v", err)
+					}
+					// returned tasks are copies which can be modified by the caller
+					task.Metadata = map[string]any{"worker": i}
+				default:
+					_ = store.Metrics()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	metrics := store.Metrics()
+	if want := int64(numWorkers * numOps / 4); metrics.Save.Count != want {
+		t.Fatalf("Metrics().Save.Count = %d, want %d", metrics.Save.Count, want)
+	}
+	if metrics.CachedTasks > 4 {
+		t.Fatalf("Metrics().CachedTasks = %d, want at most 4", metrics.CachedTasks)
+	}
+}
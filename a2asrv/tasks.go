@@ -16,8 +16,11 @@ package a2asrv
 
 import (
 	"context"
+	"errors"
 
 	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/internal/ownership"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
 )
 
 // PushNotifier defines the interface for sending push notifications
@@ -44,6 +47,8 @@ type PushConfigStore interface {
 }
 
 // TaskStore provides storage for A2A tasks.
+// Persistent implementations can also implement PushOutbox to make notification delivery
+// reliable in case of process failures.
 type TaskStore interface {
 	// Save stores a task.
 	Save(ctx context.Context, task a2a.Task) error
@@ -51,3 +56,82 @@ type TaskStore interface {
 	// Get retrieves a task by ID.
 	Get(ctx context.Context, taskId a2a.TaskID) (a2a.Task, error)
 }
+
+// BatchTaskStore is an optional interface of TaskStore implementations which can operate on many Tasks
+// in one round-trip. Use SaveAll and GetAll helpers to fall back to single-Task operations for other stores.
+type BatchTaskStore interface {
+	// SaveAll stores multiple tasks.
+	SaveAll(ctx context.Context, tasks []a2a.Task) error
+
+	// GetAll retrieves tasks by IDs. Tasks which were not found are omitted from the result.
+	GetAll(ctx context.Context, taskIds []a2a.TaskID) (map[a2a.TaskID]a2a.Task, error)
+}
+
+// SaveAll stores tasks using BatchTaskStore if the store implements it or saves them one by one otherwise.
+func SaveAll(ctx context.Context, store TaskStore, tasks []a2a.Task) error {
+	if batch, ok := store.(BatchTaskStore); ok {
+		return batch.SaveAll(ctx, tasks)
+	}
+	for _, task := range tasks {
+		if err := store.Save(ctx, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAll retrieves tasks using BatchTaskStore if the store implements it or gets them one by one otherwise.
+// Tasks which were not found are omitted from the result.
+func GetAll(ctx context.Context, store TaskStore, taskIds []a2a.TaskID) (map[a2a.TaskID]a2a.Task, error) {
+	if batch, ok := store.(BatchTaskStore); ok {
+		return batch.GetAll(ctx, taskIds)
+	}
+	result := make(map[a2a.TaskID]a2a.Task, len(taskIds))
+	for _, id := range taskIds {
+		task, err := store.Get(ctx, id)
+		if errors.Is(err, a2a.ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result[id] = task
+	}
+	return result, nil
+}
+
+// TaskVersion identifies a stored Task state for optimistic concurrency control.
+// Zero means the Task was not stored yet.
+type TaskVersion = taskupdate.TaskVersion
+
+// ErrConcurrentModification is returned by VersionedTaskStore when the stored Task was updated after
+// the version the caller based its changes on. The update is retried after reloading the Task.
+var ErrConcurrentModification = taskupdate.ErrConcurrentModification
+
+// VersionedTaskStore is an optional interface of TaskStore implementations supporting compare-and-swap
+// writes. It prevents concurrent updates from executor events and cancel requests handled by different
+// replicas from silently overwriting each other.
+type VersionedTaskStore interface {
+	// SaveVersioned stores the task only if the stored version is equal to prev and returns the new version.
+	// Returns ErrConcurrentModification otherwise.
+	SaveVersioned(ctx context.Context, task a2a.Task, prev TaskVersion) (TaskVersion, error)
+
+	// GetVersioned retrieves a task by ID together with its version.
+	GetVersioned(ctx context.Context, taskId a2a.TaskID) (a2a.Task, TaskVersion, error)
+}
+
+// ErrTaskAlreadyOwned is returned by TaskOwnership when a Task is owned by someone else, eg. because
+// it is already being executed. Errors of request handler methods wrap it in this case.
+var ErrTaskAlreadyOwned = ownership.ErrAlreadyOwned
+
+// TaskOwnership is consulted by the handler before executing or canceling a task. It prevents
+// multiple server replicas sharing a TaskStore from concurrently working on the same task.
+// Implementations can be backed by a distributed lock service (eg. Redis or SQL advisory locks).
+type TaskOwnership interface {
+	// Acquire claims exclusive ownership of a Task.
+	// Returns an error wrapping ErrTaskAlreadyOwned if the Task is owned by someone else.
+	Acquire(ctx context.Context, taskId a2a.TaskID) error
+
+	// Release gives up ownership of a Task allowing others to acquire it.
+	Release(ctx context.Context, taskId a2a.TaskID) error
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// WithRequestTimeout is used by transports for applying the a2a.RequestTimeoutMetaKey hint sent by a client
+// to the request context, so that the work done on behalf of the client is bounded by the client deadline.
+// An empty hint leaves the context deadline unchanged. A positive limit caps the timeout a client can request.
+// The deadline is reported to AgentExecutor as ErrExecutionTimeout cancellation cause.
+// Returns an error wrapping a2a.ErrInvalidRequest if the hint is malformed.
+func WithRequestTimeout(ctx context.Context, hint string, limit time.Duration) (context.Context, context.CancelFunc, error) {
+	if hint == "" {
+		if limit > 0 {
+			ctx, cancel := context.WithTimeout(ctx, limit)
+			return ctx, cancel, nil
+		}
+		return ctx, func() {}, nil
+	}
+	timeout, err := a2a.ParseRequestTimeout(hint)
+	if err != nil {
+		return ctx, func() {}, err
+	}
+	if limit > 0 {
+		timeout = min(timeout, limit)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, nil
+}
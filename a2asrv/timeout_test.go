@@ -0,0 +1,78 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestWithRequestTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		hint         string
+		limit        time.Duration
+		wantDeadline bool
+		wantMax      time.Duration
+	}{
+		{name: "no hint"},
+		{name: "no hint with limit", limit: time.Second, wantDeadline: true, wantMax: time.Second},
+		{name: "hint", hint: "2000", wantDeadline: true, wantMax: 2 * time.Second},
+		{name: "hint capped by limit", hint: "60000", limit: time.Second, wantDeadline: true, wantMax: time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel, err := WithRequestTimeout(t.Context(), tt.hint, tt.limit)
+			if err != nil {
+				t.Fatalf("WithRequestTimeout() error = %v", err)
+			}
+			defer cancel()
+			deadline, ok := ctx.Deadline()
+			if ok != tt.wantDeadline {
+				t.Fatalf("WithRequestTimeout() deadline set = %v, want %v", ok, tt.wantDeadline)
+			}
+			if left := time.Until(deadline); ok && left > tt.wantMax {
+				t.Fatalf("WithRequestTimeout() deadline in %v, want at most %v", left, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestWithRequestTimeout_InvalidHint(t *testing.T) {
+	_, cancel, err := WithRequestTimeout(t.Context(), "soon", 0)
+	defer cancel()
+	if !errors.Is(err, a2a.ErrInvalidRequest) {
+		t.Fatalf("WithRequestTimeout() error = %v, want %v", err, a2a.ErrInvalidRequest)
+	}
+}
+
+func TestWithRequestTimeout_ExecutionCause(t *testing.T) {
+	ctx, cancel, err := WithRequestTimeout(t.Context(), "1", 0)
+	if err != nil {
+		t.Fatalf("WithRequestTimeout() error = %v", err)
+	}
+	defer cancel()
+	execCtx, execCancel := executionContext(ctx)
+	defer execCancel(nil)
+
+	<-execCtx.Done()
+	if cause := context.Cause(execCtx); !errors.Is(cause, ErrExecutionTimeout) {
+		t.Fatalf("context.Cause() = %v, want %v", cause, ErrExecutionTimeout)
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrUploadNotFound is returned for unknown or removed upload IDs.
+	ErrUploadNotFound = errors.New("upload not found")
+	// ErrUploadOffsetMismatch is returned when a chunk doesn't continue the received content.
+	// Clients can recover by requesting the current offset and resending the rest.
+	ErrUploadOffsetMismatch = errors.New("upload offset mismatch")
+	// ErrUploadTooLarge is returned when an upload exceeds the configured size limit.
+	ErrUploadTooLarge = errors.New("upload too large")
+)
+
+// Upload is a file assembled from chunks sent by a client.
+// Executors find uploads referenced by a Message in RequestContext.Uploads.
+type Upload struct {
+	// ID is a server-generated identifier of the upload.
+	ID string
+	// Path is the location of the assembled file.
+	Path string
+	// Size is the number of bytes received.
+	Size int64
+	// Name and MimeType are copied from the FilePart which referenced the upload.
+	Name     string
+	MimeType string
+}
+
+// Open opens the assembled file for reading.
+func (u Upload) Open() (*os.File, error) {
+	return os.Open(u.Path)
+}
+
+// UploadStore assembles chunked uploads in temporary files. It allows clients to send inputs
+// which are too large for base64 encoding inside a single Message. Files are referenced from
+// messages with a2a.UploadURI and are removed once the execution which consumed them finishes.
+type UploadStore struct {
+	dir     string
+	maxSize int64
+
+	mu      sync.Mutex
+	uploads map[string]*uploadEntry
+}
+
+type uploadEntry struct {
+	// writeMu serializes chunk writes without blocking other uploads
+	writeMu sync.Mutex
+	upload  Upload
+	updated time.Time
+	inUse   bool
+}
+
+// NewUploadStore creates an UploadStore which keeps files in dir. Uploads larger than maxSize
+// bytes are rejected, 0 means no limit.
+func NewUploadStore(dir string, maxSize int64) *UploadStore {
+	return &UploadStore{dir: dir, maxSize: maxSize, uploads: make(map[string]*uploadEntry)}
+}
+
+// Create starts a new empty upload.
+func (s *UploadStore) Create() (Upload, error) {
+	id := uuid.NewString()
+	path := filepath.Join(s.dir, id)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return Upload{}, fmt.Errorf("failed to create upload file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return Upload{}, fmt.Errorf("failed to create upload file: %w", err)
+	}
+
+	upload := Upload{ID: id, Path: path}
+	s.mu.Lock()
+	s.uploads[id] = &uploadEntry{upload: upload, updated: time.Now()}
+	s.mu.Unlock()
+	return upload, nil
+}
+
+// Append writes a chunk to the upload. The offset must be equal to the number of bytes received so far.
+// Returns the new upload size.
+func (s *UploadStore) Append(id string, offset int64, chunk io.Reader) (int64, error) {
+	s.mu.Lock()
+	entry, ok := s.uploads[id]
+	s.mu.Unlock()
+	if !ok {
+		return 0, ErrUploadNotFound
+	}
+
+	entry.writeMu.Lock()
+	defer entry.writeMu.Unlock()
+
+	size := s.size(entry)
+	if offset != size {
+		return size, ErrUploadOffsetMismatch
+	}
+
+	file, err := os.OpenFile(entry.upload.Path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return size, fmt.Errorf("failed to open upload file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if s.maxSize > 0 {
+		chunk = io.LimitReader(chunk, s.maxSize-size+1)
+	}
+	n, err := io.Copy(file, chunk)
+	if err == nil && s.maxSize > 0 && size+n > s.maxSize {
+		err = ErrUploadTooLarge
+	}
+	if err != nil {
+		// drop the partial chunk, so that the client can retry from the same offset
+		if truncErr := file.Truncate(size); truncErr != nil {
+			return size, errors.Join(err, truncErr)
+		}
+		return size, err
+	}
+
+	s.mu.Lock()
+	entry.upload.Size = size + n
+	entry.updated = time.Now()
+	s.mu.Unlock()
+	return size + n, nil
+}
+
+func (s *UploadStore) size(entry *uploadEntry) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return entry.upload.Size
+}
+
+// Get returns an upload by ID.
+func (s *UploadStore) Get(id string) (Upload, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.uploads[id]
+	if !ok {
+		return Upload{}, ErrUploadNotFound
+	}
+	return entry.upload, nil
+}
+
+// Remove deletes an upload and its file.
+func (s *UploadStore) Remove(id string) error {
+	s.mu.Lock()
+	entry, ok := s.uploads[id]
+	delete(s.uploads, id)
+	s.mu.Unlock()
+
+	if !ok {
+		return ErrUploadNotFound
+	}
+	if err := os.Remove(entry.upload.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// RemoveStale deletes uploads which didn't receive chunks for longer than maxAge and were never
+// referenced by a Message. Returns the number of removed uploads.
+func (s *UploadStore) RemoveStale(maxAge time.Duration) int {
+	s.mu.Lock()
+	var stale []string
+	for id, entry := range s.uploads {
+		if !entry.inUse && time.Since(entry.updated) > maxAge {
+			stale = append(stale, id)
+		}
+	}
+	s.mu.Unlock()
+
+	removed := 0
+	for _, id := range stale {
+		if s.Remove(id) == nil {
+			removed++
+		}
+	}
+	return removed
+}
+
+// resolve returns uploads referenced by FileURI parts of the message keyed by URI. Claimed uploads
+// are marked as in use, so that they are not removed by RemoveStale during execution.
+func (s *UploadStore) resolve(msg a2a.Message, claim bool) (map[string]Upload, error) {
+	var result map[string]Upload
+	for _, part := range msg.Parts {
+		filePart, ok := part.(a2a.FilePart)
+		if !ok {
+			continue
+		}
+		uri, ok := filePart.File.(a2a.FileURI)
+		if !ok {
+			continue
+		}
+		id, ok := a2a.UploadIDFromURI(uri.URI)
+		if !ok {
+			continue
+		}
+		s.mu.Lock()
+		entry, ok := s.uploads[id]
+		var upload Upload
+		if ok {
+			entry.inUse = entry.inUse || claim
+			upload = entry.upload
+		}
+		s.mu.Unlock()
+		if !ok {
+			return nil, fmt.Errorf("%w: %s: %w", a2a.ErrInvalidRequest, uri.URI, ErrUploadNotFound)
+		}
+		upload.Name, upload.MimeType = uri.Name, uri.MimeType
+		if result == nil {
+			result = make(map[string]Upload)
+		}
+		result[uri.URI] = upload
+	}
+	return result, nil
+}
+
+// NewUploadHandler creates an http.Handler implementing chunked uploads on top of the store.
+// It is expected to be mounted with http.StripPrefix and supports the following requests:
+//   - POST / creates an upload and responds with {"id": "..."} and status 201
+//   - PATCH /{id} appends the request body at the offset from a2a.UploadOffsetHeader
+//   - HEAD /{id} responds with the current offset, so that an interrupted upload can be resumed
+//   - DELETE /{id} discards the upload
+func NewUploadHandler(store *UploadStore) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /{$}", func(w http.ResponseWriter, req *http.Request) {
+		upload, err := store.Create()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(a2a.UploadOffsetHeader, "0")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": upload.ID})
+	})
+
+	mux.HandleFunc("PATCH /{id}", func(w http.ResponseWriter, req *http.Request) {
+		offset, err := strconv.ParseInt(req.Header.Get(a2a.UploadOffsetHeader), 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("malformed %s: %v", a2a.UploadOffsetHeader, err), http.StatusBadRequest)
+			return
+		}
+		size, err := store.Append(req.PathValue("id"), offset, req.Body)
+		if !errors.Is(err, ErrUploadNotFound) {
+			w.Header().Set(a2a.UploadOffsetHeader, strconv.FormatInt(size, 10))
+		}
+		if err != nil {
+			http.Error(w, err.Error(), uploadErrorStatus(err))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("HEAD /{id}", func(w http.ResponseWriter, req *http.Request) {
+		upload, err := store.Get(req.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(uploadErrorStatus(err))
+			return
+		}
+		w.Header().Set(a2a.UploadOffsetHeader, strconv.FormatInt(upload.Size, 10))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	mux.HandleFunc("DELETE /{id}", func(w http.ResponseWriter, req *http.Request) {
+		if err := store.Remove(req.PathValue("id")); err != nil {
+			http.Error(w, err.Error(), uploadErrorStatus(err))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// http.StripPrefix leaves an empty path for requests to the mount point itself
+		if req.URL.Path == "" {
+			req.URL.Path = "/"
+		}
+		mux.ServeHTTP(w, req)
+	})
+}
+
+func uploadErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrUploadNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrUploadOffsetMismatch):
+		return http.StatusConflict
+	case errors.Is(err, ErrUploadTooLarge):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2asrv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+func TestUploadStore_Append(t *testing.T) {
+	store := NewUploadStore(t.TempDir(), 8)
+	upload, err := store.Create()
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if size, err := store.Append(upload.ID, 0, strings.NewReader("hello")); err != nil || size != 5 {
+		t.Fatalf("Append() = %d, %v, want 5, nil", size, err)
+	}
+	if size, err := store.Append(upload.ID, 0, strings.NewReader("hello")); !errors.Is(err, ErrUploadOffsetMismatch) || size != 5 {
+		t.Fatalf("Append() at stale offset = %d, %v, want 5, %v", size, err, ErrUploadOffsetMismatch)
+	}
+	if size, err := store.Append(upload.ID, 5, strings.NewReader("world")); !errors.Is(err, ErrUploadTooLarge) || size != 5 {
+		t.Fatalf("Append() over limit = %d, %v, want 5, %v", size, err, ErrUploadTooLarge)
+	}
+	if size, err := store.Append(upload.ID, 5, strings.NewReader("!!!")); err != nil || size != 8 {
+		t.Fatalf("Append() = %d, %v, want 8, nil", size, err)
+	}
+	if _, err := store.Append("unknown", 0, strings.NewReader("x")); !errors.Is(err, ErrUploadNotFound) {
+		t.Fatalf("Append() to unknown upload error = %v, want %v", err, ErrUploadNotFound)
+	}
+
+	content, err := os.ReadFile(upload.Path)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+	if string(content) != "hello!!!" {
+		t.Fatalf("upload content = %q, want %q", content, "hello!!!")
+	}
+
+	if err := store.Remove(upload.ID); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(upload.Path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("os.Stat() after Remove() error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestUploadStore_RemoveStale(t *testing.T) {
+	store := NewUploadStore(t.TempDir(), 0)
+	stale, _ := store.Create()
+	referenced, _ := store.Create()
+	msg := a2a.Message{Parts: a2a.ContentParts{a2a.FilePart{File: a2a.FileURI{URI: a2a.UploadURI(referenced.ID)}}}}
+	if _, err := store.resolve(msg, true); err != nil {
+		t.Fatalf("resolve() error = %v", err)
+	}
+
+	if removed := store.RemoveStale(0); removed != 1 {
+		t.Fatalf("RemoveStale() = %d, want 1", removed)
+	}
+	if _, err := store.Get(stale.ID); !errors.Is(err, ErrUploadNotFound) {
+		t.Fatalf("Get() of stale upload error = %v, want %v", err, ErrUploadNotFound)
+	}
+	if _, err := store.Get(referenced.ID); err != nil {
+		t.Fatalf("Get() of referenced upload error = %v", err)
+	}
+}
+
+func TestUploadHandler(t *testing.T) {
+	store := NewUploadStore(t.TempDir(), 0)
+	server := httptest.NewServer(http.StripPrefix("/uploads", NewUploadHandler(store)))
+	defer server.Close()
+
+	do := func(method, path, offset, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL+"/uploads"+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("http.NewRequest() error = %v", err)
+		}
+		if offset != "" {
+			req.Header.Set(a2a.UploadOffsetHeader, offset)
+		}
+		resp, err := server.Client().Do(req)
+		if err != nil {
+			t.Fatalf("%s %s error = %v", method, path, err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	resp := do(http.MethodPost, "/", "", "")
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	var created struct{ ID string }
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		t.Fatalf("json.Decode() error = %v", err)
+	}
+
+	testCases := []struct {
+		name       string
+		method     string
+		path       string
+		offset     string
+		body       string
+		wantStatus int
+		wantOffset string
+	}{
+		{name: "first chunk", method: http.MethodPatch, path: "/" + created.ID, offset: "0", body: "abc", wantStatus: http.StatusNoContent, wantOffset: "3"},
+		{name: "repeated chunk", method: http.MethodPatch, path: "/" + created.ID, offset: "0", body: "abc", wantStatus: http.StatusConflict, wantOffset: "3"},
+		{name: "malformed offset", method: http.MethodPatch, path: "/" + created.ID, offset: "x", wantStatus: http.StatusBadRequest},
+		{name: "unknown upload", method: http.MethodPatch, path: "/unknown", offset: "0", wantStatus: http.StatusNotFound},
+		{name: "offset", method: http.MethodHead, path: "/" + created.ID, wantStatus: http.StatusOK, wantOffset: "3"},
+		{name: "delete", method: http.MethodDelete, path: "/" + created.ID, wantStatus: http.StatusNoContent},
+		{name: "deleted", method: http.MethodHead, path: "/" + created.ID, wantStatus: http.StatusNotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := do(tc.method, tc.path, tc.offset, tc.body)
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if got := resp.Header.Get(a2a.UploadOffsetHeader); got != tc.wantOffset {
+				t.Fatalf("%s = %q, want %q", a2a.UploadOffsetHeader, got, tc.wantOffset)
+			}
+		})
+	}
+}
+
+func TestDefaultRequestHandler_WithUploads(t *testing.T) {
+	store := NewUploadStore(t.TempDir(), 0)
+	upload, _ := store.Create()
+	if _, err := store.Append(upload.ID, 0, strings.NewReader("large input")); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	uri := a2a.UploadURI(upload.ID)
+
+	var got string
+	executor := &mockAgentExecutor{
+		ExecuteFunc: func(ctx context.Context, reqCtx RequestContext, q eventqueue.Queue) error {
+			file, err := reqCtx.Uploads[uri].Open()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = file.Close() }()
+			content, err := io.ReadAll(file)
+			if err != nil {
+				return err
+			}
+			got = reqCtx.Uploads[uri].Name + ":" + string(content)
+			return q.Write(ctx, &a2a.Message{TaskID: reqCtx.TaskID, ID: "response"})
+		},
+	}
+	handler := NewHandler(executor, WithUploads(store))
+
+	part := a2a.FilePart{File: a2a.FileURI{FileMeta: a2a.FileMeta{Name: "input.bin"}, URI: uri}}
+	params := a2a.MessageSendParams{Message: a2a.Message{TaskID: taskID, ID: "request", Parts: a2a.ContentParts{part}}}
+	if _, err := handler.OnSendMessage(t.Context(), params); err != nil {
+		t.Fatalf("OnSendMessage() error = %v", err)
+	}
+	if got != "input.bin:large input" {
+		t.Fatalf("executor read %q, want %q", got, "input.bin:large input")
+	}
+	if _, err := store.Get(upload.ID); !errors.Is(err, ErrUploadNotFound) {
+		t.Fatalf("Get() after execution error = %v, want %v", err, ErrUploadNotFound)
+	}
+
+	if _, err := handler.OnSendMessage(t.Context(), params); !errors.Is(err, a2a.ErrInvalidRequest) {
+		t.Fatalf("OnSendMessage() with removed upload error = %v, want %v", err, a2a.ErrInvalidRequest)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interop
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+// Container describes a reference SDK image: a server started by StartContainer or a client run by RunContainer.
+type Container struct {
+	// Image is the docker image running an A2A server.
+	Image string
+	// Port is the container port the server listens on.
+	Port int
+	// Env is passed to the container as environment variables.
+	Env map[string]string
+	// ReadyPath is polled until it responds with 200 OK. Defaults to the well-known AgentCard path.
+	ReadyPath string
+	// ReadyTimeout limits how long to wait for the server to become ready. Defaults to 2 minutes.
+	ReadyTimeout time.Duration
+}
+
+// Running is a started Container.
+type Running struct {
+	// ID is the docker container ID.
+	ID string
+	// BaseURL is the URL at which the container server is reachable from the host.
+	BaseURL string
+}
+
+// StartContainer runs the container in the background, publishes its port on a random host port
+// and waits until the server responds. The returned stop function removes the container.
+func StartContainer(ctx context.Context, c Container) (*Running, func(), error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil, nil, fmt.Errorf("docker is not available: %w", err)
+	}
+
+	args := []string{"run", "--detach", "--rm", "--publish", fmt.Sprintf("127.0.0.1::%d", c.Port)}
+	for k, v := range c.Env {
+		args = append(args, "--env", k+"="+v)
+	}
+	args = append(args, c.Image)
+	id, err := docker(ctx, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to start %s: %w", c.Image, err)
+	}
+	stop := func() { _, _ = docker(context.Background(), "rm", "--force", id) }
+
+	addr, err := docker(ctx, "port", id, fmt.Sprintf("%d/tcp", c.Port))
+	if err != nil {
+		stop()
+		return nil, nil, fmt.Errorf("failed to find the published port: %w", err)
+	}
+	// docker port prints a line per bound address
+	addr, _, _ = strings.Cut(addr, "\n")
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		stop()
+		return nil, nil, fmt.Errorf("unexpected published address %q: %w", addr, err)
+	}
+
+	running := &Running{ID: id, BaseURL: "http://" + addr}
+	if err := c.waitReady(ctx, running.BaseURL); err != nil {
+		logs, _ := docker(context.Background(), "logs", id)
+		stop()
+		return nil, nil, fmt.Errorf("%s didn't become ready: %w\n%s", c.Image, err, logs)
+	}
+	return running, stop, nil
+}
+
+// RunContainer runs the container to completion and returns what it printed to stdout. The container
+// shares the host network, so that it can reach servers started by the test on the loopback interface,
+// which requires a Linux docker host. Port and readiness settings are ignored.
+func RunContainer(ctx context.Context, c Container) (string, error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return "", fmt.Errorf("docker is not available: %w", err)
+	}
+
+	args := []string{"run", "--rm", "--network", "host"}
+	for k, v := range c.Env {
+		args = append(args, "--env", k+"="+v)
+	}
+	args = append(args, c.Image)
+	out, err := docker(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s: %w", c.Image, err)
+	}
+	return out, nil
+}
+
+func (c Container) waitReady(ctx context.Context, baseURL string) error {
+	path := c.ReadyPath
+	if path == "" {
+		path = a2asrv.WellKnownAgentCardPath
+	}
+	timeout := c.ReadyTimeout
+	if timeout == 0 {
+		timeout = 2 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	var lastErr error
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+			err = fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), lastErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package interop contains helpers for testing a2a-go against reference A2A SDK implementations
+// to catch cross-SDK serialization drift before a release.
+//
+// The tests which start reference servers in containers are guarded by the interop build tag and
+// require docker to be available. They run against the Python and the JS SDK, the images of each are
+// configured with variables named after the SDK (PYTHON or JS):
+//
+//	A2A_INTEROP_PYTHON_IMAGE=<image> A2A_INTEROP_JS_IMAGE=<image> go test -tags interop ./a2atest/interop/...
+//
+// The image must run an A2A server listening on the port set by A2A_INTEROP_<SDK>_PORT (8080 by default).
+// The tests fetch its AgentCard and send it a message using the JSON-RPC binding, and using the HTTP+JSON
+// binding if the card declares an interface for it.
+//
+// The a2asrv JSON-RPC server is tested with a reference client image:
+//
+//	A2A_INTEROP_PYTHON_CLIENT_IMAGE=<image> A2A_INTEROP_JS_CLIENT_IMAGE=<image> go test -tags interop ./a2atest/interop/...
+//
+// The image must send a 'message/send' request with a text part to the agent at A2A_SERVER_URL and print
+// the JSON result as the last line of its output. It runs on the host network, which requires a Linux
+// docker host. Tests are skipped if their image is not configured.
+package interop
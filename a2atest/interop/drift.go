@@ -0,0 +1,121 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interop
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// Drift decodes the JSON payload produced by a peer SDK into v, encodes v back and reports
+// the values which were lost or changed in the round trip, eg. because of a renamed or
+// unknown field. Fields which were captured as unknown by types preserving them (see a2a.DecodeMode)
+// are reported as not modeled. Fields which are only present in the re-encoded payload are not reported,
+// because SDKs are free to omit default values.
+func Drift(raw []byte, v any) ([]string, error) {
+	var original any
+	if err := json.Unmarshal(raw, &original); err != nil {
+		return nil, fmt.Errorf("failed to decode the original payload: %w", err)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return nil, fmt.Errorf("failed to decode the payload into %T: %w", v, err)
+	}
+	encoded, err := encode(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %T: %w", v, err)
+	}
+	var roundTripped any
+	if err := json.Unmarshal(encoded, &roundTripped); err != nil {
+		return nil, fmt.Errorf("failed to decode the re-encoded payload: %w", err)
+	}
+
+	var diffs []string
+	compare("$", original, roundTripped, &diffs)
+	for _, path := range a2a.UnknownFields(v) {
+		diffs = append(diffs, path+": not modeled")
+	}
+	return diffs, nil
+}
+
+// encode encodes v, adding the "kind" discriminator to events, which is not a field of the event types.
+func encode(v any) ([]byte, error) {
+	if event, ok := v.(a2a.Event); ok {
+		return a2a.MarshalEvent(event)
+	}
+	return json.Marshal(v)
+}
+
+func compare(path string, want, got any, diffs *[]string) {
+	switch want := want.(type) {
+	case map[string]any:
+		gotMap, ok := got.(map[string]any)
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("%s: object became %s", path, jsonKind(got)))
+			return
+		}
+		keys := make([]string, 0, len(want))
+		for k := range want {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			gotValue, ok := gotMap[k]
+			if !ok {
+				if want[k] != nil {
+					*diffs = append(*diffs, fmt.Sprintf("%s.%s: dropped", path, k))
+				}
+				continue
+			}
+			compare(path+"."+k, want[k], gotValue, diffs)
+		}
+
+	case []any:
+		gotSlice, ok := got.([]any)
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("%s: array became %s", path, jsonKind(got)))
+			return
+		}
+		if len(want) != len(gotSlice) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: length changed from %d to %d", path, len(want), len(gotSlice)))
+			return
+		}
+		for i := range want {
+			compare(path+"["+strconv.Itoa(i)+"]", want[i], gotSlice[i], diffs)
+		}
+
+	default:
+		if !reflect.DeepEqual(want, got) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: changed from %v to %v", path, want, got))
+		}
+	}
+}
+
+func jsonKind(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
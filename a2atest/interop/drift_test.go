@@ -0,0 +1,93 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interop
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestDrift(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []string
+	}{
+		{
+			name: "no drift",
+			raw:  `{"name":"agent","url":"http://localhost","skills":[{"id":"echo","name":"Echo","tags":["test"]}]}`,
+		},
+		{
+			name: "unknown field dropped",
+			raw:  `{"name":"agent","supportsExtendedCard":true,"skills":[{"id":"echo","unknownField":"x"}]}`,
+			want: []string{"$.skills[0].unknownField: dropped", "$.supportsExtendedCard: not modeled"},
+		},
+		{
+			name: "null field ignored",
+			raw:  `{"name":"agent","iconUrl":null}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Drift([]byte(tt.raw), &a2a.AgentCard{})
+			if err != nil {
+				t.Fatalf("Drift() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("Drift() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type upperState string
+
+func (s *upperState) UnmarshalJSON(b []byte) error {
+	*s = upperState(strings.ToUpper(strings.Trim(string(b), `"`)))
+	return nil
+}
+
+func TestDrift_ValueChanged(t *testing.T) {
+	type payload struct {
+		State upperState `json:"state"`
+		Items []string   `json:"items"`
+	}
+	got, err := Drift([]byte(`{"state":"working","items":["a"]}`), &payload{})
+	if err != nil {
+		t.Fatalf("Drift() error = %v", err)
+	}
+	want := []string{"$.state: changed from working to WORKING"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Drift() = %v, want %v", got, want)
+	}
+
+	if _, err := Drift([]byte(`{"items":{"k":"v"}}`), &payload{}); err == nil {
+		t.Fatal("Drift() error = nil, want a decoding error for mismatched types")
+	}
+}
+
+func TestDrift_Event(t *testing.T) {
+	raw := `{"kind":"message","messageId":"m1","role":"agent","parts":[{"kind":"text","text":"pong"}]}`
+	got, err := Drift([]byte(raw), &a2a.Message{})
+	if err != nil {
+		t.Fatalf("Drift() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("Drift() = %v, want no drift", got)
+	}
+}
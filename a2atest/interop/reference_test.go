@@ -0,0 +1,363 @@
+//go:build interop
+
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interop
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/a2aproject/a2a-go/a2aclient/inspect"
+	"github.com/a2aproject/a2a-go/a2apb"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/jsonrpc"
+	"github.com/a2aproject/a2a-go/internal/pbconv"
+)
+
+// referenceSDKs are the SDKs the tests run against. The images of an SDK are configured with the
+// A2A_INTEROP_<SDK>_IMAGE, A2A_INTEROP_<SDK>_PORT and A2A_INTEROP_<SDK>_CLIENT_IMAGE variables.
+var referenceSDKs = []string{"PYTHON", "JS"}
+
+// forEachSDK runs the test for every reference SDK as a subtest.
+func forEachSDK(t *testing.T, test func(t *testing.T, sdk string)) {
+	for _, sdk := range referenceSDKs {
+		t.Run(strings.ToLower(sdk), func(t *testing.T) {
+			test(t, sdk)
+		})
+	}
+}
+
+func startReferenceServer(t *testing.T, sdk string) *Running {
+	t.Helper()
+	imageVar, portVar := "A2A_INTEROP_"+sdk+"_IMAGE", "A2A_INTEROP_"+sdk+"_PORT"
+	image := os.Getenv(imageVar)
+	if image == "" {
+		t.Skip(imageVar + " is not set")
+	}
+	port := 8080
+	if v := os.Getenv(portVar); v != "" {
+		var err error
+		if port, err = strconv.Atoi(v); err != nil {
+			t.Fatalf("invalid %s: %v", portVar, err)
+		}
+	}
+
+	running, stop, err := StartContainer(t.Context(), Container{Image: image, Port: port})
+	if err != nil {
+		t.Fatalf("StartContainer() error = %v", err)
+	}
+	t.Cleanup(stop)
+	return running
+}
+
+func TestReferenceServer_AgentCard(t *testing.T) {
+	forEachSDK(t, testServerAgentCard)
+}
+
+func testServerAgentCard(t *testing.T, sdk string) {
+	server := startReferenceServer(t, sdk)
+
+	resp, err := http.Get(server.BaseURL + a2asrv.WellKnownAgentCardPath)
+	if err != nil {
+		t.Fatalf("failed to fetch the card: %v", err)
+	}
+	raw, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		t.Fatalf("failed to read the card: %v", err)
+	}
+
+	var card a2a.AgentCard
+	diffs, err := Drift(raw, &card)
+	if err != nil {
+		t.Fatalf("Drift() error = %v", err)
+	}
+	for _, diff := range diffs {
+		t.Errorf("AgentCard drift: %s", diff)
+	}
+	for _, problem := range inspect.ValidateCard(&card) {
+		if problem.Severity == inspect.SeverityError {
+			t.Errorf("invalid AgentCard: %s", problem)
+		}
+	}
+
+	resolved, err := inspect.FetchCard(context.Background(), server.BaseURL, nil)
+	if err != nil {
+		t.Fatalf("FetchCard() error = %v", err)
+	}
+	if got, _ := json.Marshal(resolved); string(got) != mustMarshal(t, &card) {
+		t.Errorf("FetchCard() = %s, want %s", got, mustMarshal(t, &card))
+	}
+}
+
+func TestReferenceServer_Messaging(t *testing.T) {
+	forEachSDK(t, testServerMessaging)
+}
+
+func testServerMessaging(t *testing.T, sdk string) {
+	server := startReferenceServer(t, sdk)
+	card, err := inspect.FetchCard(t.Context(), server.BaseURL, nil)
+	if err != nil {
+		t.Fatalf("FetchCard() error = %v", err)
+	}
+	endpoint, ok := interfaceEndpoint(server, card, a2a.TransportProtocolJSONRPC)
+	if !ok {
+		t.Fatalf("the server doesn't offer the JSON-RPC transport")
+	}
+
+	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "ping"})
+	body, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "message/send",
+		"params":  a2a.MessageSendParams{Message: *msg},
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	resp, err := http.Post(endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("message/send failed: %v", err)
+	}
+	var rpcResp struct {
+		Result json.RawMessage `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&rpcResp)
+	_ = resp.Body.Close()
+	if err != nil {
+		t.Fatalf("failed to decode the message/send response: %v", err)
+	}
+	if len(rpcResp.Error) > 0 {
+		t.Fatalf("message/send error = %s", rpcResp.Error)
+	}
+	reportDrift(t, "message/send result", rpcResp.Result, newResult(t, rpcResp.Result))
+}
+
+func TestReferenceServer_HTTPJSON(t *testing.T) {
+	forEachSDK(t, testServerHTTPJSON)
+}
+
+func testServerHTTPJSON(t *testing.T, sdk string) {
+	server := startReferenceServer(t, sdk)
+	card, err := inspect.FetchCard(t.Context(), server.BaseURL, nil)
+	if err != nil {
+		t.Fatalf("FetchCard() error = %v", err)
+	}
+	endpoint, ok := interfaceEndpoint(server, card, a2a.TransportProtocolHTTPJSON)
+	if !ok {
+		t.Skip("the server doesn't offer the HTTP+JSON transport")
+	}
+
+	// the reference payload must decode without unknown fields
+	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "ping"})
+	req, err := pbconv.ToProtoSendMessageRequest(a2a.MessageSendParams{Message: *msg})
+	if err != nil {
+		t.Fatalf("ToProtoSendMessageRequest() error = %v", err)
+	}
+	body, err := protojson.Marshal(req)
+	if err != nil {
+		t.Fatalf("protojson.Marshal() error = %v", err)
+	}
+	resp, err := http.Post(endpoint+"/v1/message:send", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("message:send failed: %v", err)
+	}
+	raw, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("message:send response = (%s %s, %v), want 200 OK", resp.Status, raw, err)
+	}
+	if err := protojson.Unmarshal(raw, &a2apb.SendMessageResponse{}); err != nil {
+		t.Errorf("message:send response drift: %v", err)
+	}
+
+	transport := a2aclient.NewHTTPJSONTransport(endpoint, nil)
+	if _, err := transport.SendMessage(t.Context(), a2a.MessageSendParams{Message: *a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "ping"})}); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	if !card.Capabilities.Streaming {
+		return
+	}
+	var events int
+	for _, err := range transport.SendStreamingMessage(t.Context(), a2a.MessageSendParams{Message: *a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "ping"})}) {
+		if err != nil {
+			t.Fatalf("SendStreamingMessage() error = %v", err)
+		}
+		events++
+	}
+	if events == 0 {
+		t.Fatalf("SendStreamingMessage() yielded no events")
+	}
+}
+
+// interfaceEndpoint returns the host-reachable URL of the interface the card declares for the transport.
+func interfaceEndpoint(server *Running, card *a2a.AgentCard, transport a2a.TransportProtocol) (string, bool) {
+	preferred := card.PreferredTransport
+	if preferred == "" {
+		preferred = a2a.TransportProtocolJSONRPC
+	}
+	interfaces := append([]a2a.AgentInterface{{Transport: string(preferred), URL: card.URL}}, card.AdditionalInterfaces...)
+	for _, iface := range interfaces {
+		if !strings.EqualFold(iface.Transport, string(transport)) {
+			continue
+		}
+		// the host in the card URL is only reachable from the container network
+		endpoint := server.BaseURL
+		if u, err := url.Parse(iface.URL); err == nil {
+			endpoint += strings.TrimSuffix(u.Path, "/")
+		}
+		return endpoint, true
+	}
+	return "", false
+}
+
+func TestGoServer_ReferenceClient(t *testing.T) {
+	forEachSDK(t, testGoServerClient)
+}
+
+func testGoServerClient(t *testing.T, sdk string) {
+	imageVar := "A2A_INTEROP_" + sdk + "_CLIENT_IMAGE"
+	image := os.Getenv(imageVar)
+	if image == "" {
+		t.Skip(imageVar + " is not set")
+	}
+
+	executor := a2asrv.ExecutorFunc[[]a2a.Part](func(ctx context.Context, reqCtx a2asrv.RequestContext) ([]a2a.Part, error) {
+		return []a2a.Part{a2a.TextPart{Text: "pong"}}, nil
+	})
+	var mu sync.Mutex
+	var requests [][]byte
+	rpc := jsonrpc.NewHandler(a2asrv.NewHandler(executor), jsonrpc.Config{})
+	mux := http.NewServeMux()
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		requests = append(requests, body)
+		mu.Unlock()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		rpc.ServeHTTP(w, req)
+	}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	card := &a2a.AgentCard{
+		Name:               "echo",
+		Description:        "interop test agent",
+		Version:            "1.0.0",
+		ProtocolVersion:    "0.3.0",
+		URL:                server.URL,
+		PreferredTransport: a2a.TransportProtocolJSONRPC,
+		DefaultInputModes:  []string{"text/plain"},
+		DefaultOutputModes: []string{"text/plain"},
+		Skills:             []a2a.AgentSkill{{ID: "echo", Name: "Echo", Description: "Replies to every message", Tags: []string{"test"}}},
+	}
+	mux.Handle(a2asrv.WellKnownAgentCardPath, a2asrv.NewAgentCardHandler(a2asrv.AgentCardProducerFn(func() *a2a.AgentCard { return card })))
+
+	out, err := RunContainer(t.Context(), Container{Image: image, Env: map[string]string{"A2A_SERVER_URL": server.URL}})
+	if err != nil {
+		t.Fatalf("RunContainer() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var sent bool
+	for _, body := range requests {
+		var req struct {
+			Method string `json:"method"`
+			Params struct {
+				Message json.RawMessage `json:"message"`
+			} `json:"params"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil || req.Method != "message/send" {
+			continue
+		}
+		sent = true
+		reportDrift(t, "message/send message", req.Params.Message, &a2a.Message{})
+	}
+	if !sent {
+		t.Fatalf("the client sent no message/send requests, got %d requests", len(requests))
+	}
+
+	// the result is printed last
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	raw := []byte(lines[len(lines)-1])
+	result := newResult(t, raw)
+	reportDrift(t, "printed result", raw, result)
+	task, ok := result.(*a2a.Task)
+	if !ok || task.Status.State != a2a.TaskStateCompleted {
+		t.Fatalf("client result = %s, want completed task", raw)
+	}
+}
+
+// newResult returns the value to decode a 'message/send' result into based on its kind.
+func newResult(t *testing.T, raw []byte) any {
+	t.Helper()
+	var typed struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(raw, &typed); err != nil {
+		t.Fatalf("failed to decode the result %s: %v", raw, err)
+	}
+	switch typed.Kind {
+	case a2a.EventKindMessage:
+		return &a2a.Message{}
+	case a2a.EventKindTask:
+		return &a2a.Task{}
+	default:
+		t.Fatalf("result %s has unexpected kind %q", raw, typed.Kind)
+		return nil
+	}
+}
+
+// reportDrift fails the test if the payload produced by the reference SDK doesn't survive a round trip
+// through v.
+func reportDrift(t *testing.T, what string, raw []byte, v any) {
+	t.Helper()
+	diffs, err := Drift(raw, v)
+	if err != nil {
+		t.Fatalf("Drift() error = %v", err)
+	}
+	for _, diff := range diffs {
+		t.Errorf("%s drift: %s", what, diff)
+	}
+}
+
+func mustMarshal(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	return string(b)
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mockserver provides a scripted A2A agent for local development and testing of clients.
+// The agent serves a configurable AgentCard and replies to messages with fixed events selected
+// by the skill a message references, optionally with delays and injected errors:
+//
+//	server, err := mockserver.New(mockserver.Config{
+//		Card: &a2a.AgentCard{Name: "mock", URL: "http://localhost:8080"},
+//		Skills: map[string]mockserver.Script{
+//			"echo": {Events: []a2a.Event{a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "pong"})}},
+//		},
+//	})
+//	http.Handle("/", server)
+//
+// The Server serves the AgentCard and the JSON-RPC binding. Other protocol bindings are mounted using
+// the a2asrv.RequestHandler returned by Server.RequestHandler.
+package mockserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/a2aproject/a2a-go/a2asrv/jsonrpc"
+)
+
+// ErrInjected is returned for requests failed according to Script.ErrorRate.
+var ErrInjected = errors.New("injected mock server failure")
+
+// Script defines how the mock agent responds to a message.
+type Script struct {
+	// Events are written to the event queue in order. TaskID and ContextID of the events are replaced
+	// with the IDs of the request Task.
+	Events []a2a.Event
+	// Delay is the time the agent waits before writing every event.
+	Delay time.Duration
+	// Err is returned after all the events were written, if set.
+	Err error
+	// ErrorRate is the probability in the range [0, 1] of failing a request with ErrInjected
+	// before any events are written.
+	ErrorRate float64
+}
+
+// Config is used for creating a Server.
+type Config struct {
+	// Card is the AgentCard served by the mock agent. Skills from the Skills map
+	// which are not declared by the card are added to it.
+	Card *a2a.AgentCard
+	// Skills maps skill IDs to responses. Messages select a skill using a2asrv.SkillIDMetaKey metadata.
+	Skills map[string]Script
+	// Default is used for messages which don't reference a skill.
+	Default Script
+	// Options are applied to the RequestHandler, eg. a2asrv.WithTaskStore.
+	Options []a2asrv.RequestHandlerOption
+	// JSONRPC configures the JSON-RPC binding served by the Server.
+	JSONRPC jsonrpc.Config
+}
+
+// Server is a scripted A2A agent. It implements http.Handler serving the AgentCard and the JSON-RPC binding.
+type Server struct {
+	mux     *a2asrv.SkillMux
+	handler a2asrv.RequestHandler
+	card    http.Handler
+	rpc     http.Handler
+}
+
+// New creates a mock Server from the Config.
+func New(config Config) (*Server, error) {
+	card := config.Card
+	if card == nil {
+		card = &a2a.AgentCard{Name: "mock agent"}
+	}
+
+	base := *card
+	base.Skills = nil
+	declared := make(map[string]a2a.AgentSkill)
+	for _, skill := range card.Skills {
+		if _, ok := config.Skills[skill.ID]; ok {
+			declared[skill.ID] = skill
+		} else {
+			base.Skills = append(base.Skills, skill)
+		}
+	}
+	mux := a2asrv.NewSkillMux(a2asrv.AgentCardProducerFn(func() *a2a.AgentCard { return &base }))
+	mux.Fallback = newScriptExecutor(config.Default)
+	for _, id := range slices.Sorted(maps.Keys(config.Skills)) {
+		skill, ok := declared[id]
+		if !ok {
+			skill = a2a.AgentSkill{ID: id, Name: id}
+		}
+		if err := mux.Handle(skill, newScriptExecutor(config.Skills[id])); err != nil {
+			return nil, fmt.Errorf("failed to register skill %q: %w", id, err)
+		}
+	}
+
+	handler := a2asrv.NewHandler(mux, config.Options...)
+	return &Server{
+		mux:     mux,
+		handler: handler,
+		card:    a2asrv.NewAgentCardHandler(mux),
+		rpc:     jsonrpc.NewHandler(handler, config.JSONRPC),
+	}, nil
+}
+
+// RequestHandler returns the protocol-agnostic handler of the mock agent.
+func (s *Server) RequestHandler() a2asrv.RequestHandler {
+	return s.handler
+}
+
+// Executor returns the scripted AgentExecutor.
+func (s *Server) Executor() a2asrv.AgentExecutor {
+	return s.mux
+}
+
+// ServeHTTP serves the AgentCard at a2asrv.WellKnownAgentCardPath and the JSON-RPC binding at other paths.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == a2asrv.WellKnownAgentCardPath {
+		s.card.ServeHTTP(w, req)
+		return
+	}
+	s.rpc.ServeHTTP(w, req)
+}
+
+type scriptExecutor struct {
+	script Script
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func newScriptExecutor(script Script) *scriptExecutor {
+	return &scriptExecutor{script: script, rand: rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))}
+}
+
+func (e *scriptExecutor) failed() bool {
+	if e.script.ErrorRate <= 0 {
+		return false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.rand.Float64() < e.script.ErrorRate
+}
+
+func (e *scriptExecutor) Execute(ctx context.Context, reqCtx a2asrv.RequestContext, queue eventqueue.Queue) error {
+	if e.failed() {
+		return ErrInjected
+	}
+	task := taskRef(reqCtx)
+	for _, event := range e.script.Events {
+		if e.script.Delay > 0 {
+			select {
+			case <-a2asrv.ClockFrom(ctx).After(e.script.Delay):
+			case <-ctx.Done():
+				return context.Cause(ctx)
+			}
+		}
+		if err := queue.Write(ctx, bindEvent(event, task)); err != nil {
+			return err
+		}
+	}
+	return e.script.Err
+}
+
+func (e *scriptExecutor) Cancel(ctx context.Context, reqCtx a2asrv.RequestContext, queue eventqueue.Queue) error {
+	event := a2a.NewStatusUpdateEvent(taskRef(reqCtx), a2a.TaskStateCanceled, nil)
+	event.Final = true
+	return queue.Write(ctx, event)
+}
+
+func taskRef(reqCtx a2asrv.RequestContext) *a2a.Task {
+	if reqCtx.Task != nil {
+		return reqCtx.Task
+	}
+	contextID := reqCtx.ContextID
+	if contextID == "" {
+		contextID = reqCtx.Request.Message.ContextID
+	}
+	return &a2a.Task{ID: reqCtx.TaskID, ContextID: contextID}
+}
+
+// bindEvent returns a copy of the scripted event referencing the Task.
+func bindEvent(event a2a.Event, task *a2a.Task) a2a.Event {
+	switch v := event.(type) {
+	case *a2a.Message:
+		msg := *v
+		if msg.ID == "" {
+			msg.ID = a2a.NewMessageID()
+		}
+		msg.TaskID, msg.ContextID = task.ID, task.ContextID
+		return &msg
+	case *a2a.Task:
+		snapshot := *v
+		snapshot.ID, snapshot.ContextID = task.ID, task.ContextID
+		return &snapshot
+	case *a2a.TaskStatusUpdateEvent:
+		update := *v
+		update.TaskID, update.ContextID = task.ID, task.ContextID
+		return &update
+	case *a2a.TaskArtifactUpdateEvent:
+		update := *v
+		update.TaskID, update.ContextID = task.ID, task.ContextID
+		return &update
+	default:
+		return event
+	}
+}
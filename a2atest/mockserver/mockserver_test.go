@@ -0,0 +1,151 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	server, err := New(Config{
+		Card: &a2a.AgentCard{
+			Name:   "mock",
+			Skills: []a2a.AgentSkill{{ID: "echo", Name: "Echo", Description: "declared"}},
+		},
+		Skills: map[string]Script{
+			"echo": {Events: []a2a.Event{a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "pong"})}},
+			"task": {
+				Delay: time.Millisecond,
+				Events: []a2a.Event{
+					&a2a.Task{Status: a2a.TaskStatus{State: a2a.TaskStateSubmitted}},
+					&a2a.TaskStatusUpdateEvent{Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}, Final: true},
+				},
+			},
+			"broken": {ErrorRate: 1},
+		},
+		Default: Script{Err: errors.New("no skill selected")},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return server
+}
+
+func send(t *testing.T, server *Server, skill string) (a2a.SendMessageResult, error) {
+	t.Helper()
+	msg := a2a.Message{ID: "msg", TaskID: a2a.TaskID("task-" + skill), ContextID: "ctx-1", Role: a2a.MessageRoleUser}
+	if skill != "" {
+		msg.Metadata = map[string]any{a2asrv.SkillIDMetaKey: skill}
+	}
+	return server.RequestHandler().OnSendMessage(t.Context(), a2a.MessageSendParams{Message: msg})
+}
+
+func TestServer_Card(t *testing.T) {
+	server := newTestServer(t)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, a2asrv.WellKnownAgentCardPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET card status = %d, want 200", rec.Code)
+	}
+
+	var card a2a.AgentCard
+	if err := json.NewDecoder(rec.Body).Decode(&card); err != nil {
+		t.Fatalf("failed to decode card: %v", err)
+	}
+	var ids []string
+	for _, skill := range card.Skills {
+		ids = append(ids, skill.ID)
+	}
+	if len(ids) != 3 || ids[0] != "broken" || ids[1] != "echo" || ids[2] != "task" {
+		t.Fatalf("card skills = %v, want [broken echo task]", ids)
+	}
+	if card.Skills[1].Description != "declared" {
+		t.Fatalf("card skill = %+v, want the declared skill", card.Skills[1])
+	}
+
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/other", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("GET /other status = %d, want 405", rec.Code)
+	}
+}
+
+func TestServer_JSONRPC(t *testing.T) {
+	server := newTestServer(t)
+	body := `{"jsonrpc":"2.0","id":1,"method":"message/send","params":{"message":{"kind":"message","messageId":"msg","taskId":"task-1","role":"user","parts":[],"metadata":{"` + a2asrv.SkillIDMetaKey + `":"echo"}}}}`
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST / status = %d, want 200", rec.Code)
+	}
+
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Error != nil {
+		t.Fatalf("message/send error = %s", resp.Error)
+	}
+	event, err := a2a.UnmarshalEvent(resp.Result)
+	if err != nil {
+		t.Fatalf("UnmarshalEvent() error = %v", err)
+	}
+	msg, ok := event.(*a2a.Message)
+	if !ok || msg.TaskID != "task-1" || len(msg.Parts) != 1 {
+		t.Fatalf("message/send result = %s, want the echo message", resp.Result)
+	}
+}
+
+func TestServer_Scripts(t *testing.T) {
+	server := newTestServer(t)
+
+	result, err := send(t, server, "echo")
+	if err != nil {
+		t.Fatalf("send(echo) error = %v", err)
+	}
+	msg, ok := result.(*a2a.Message)
+	if !ok || msg.TaskID != "task-echo" || msg.ContextID != "ctx-1" {
+		t.Fatalf("send(echo) = %+v, want a message bound to the task", result)
+	}
+
+	result, err = send(t, server, "task")
+	if err != nil {
+		t.Fatalf("send(task) error = %v", err)
+	}
+	task, ok := result.(*a2a.Task)
+	if !ok || task.ID != "task-task" || task.Status.State != a2a.TaskStateCompleted {
+		t.Fatalf("send(task) = %+v, want a completed task", result)
+	}
+
+	if _, err := send(t, server, "broken"); !errors.Is(err, ErrInjected) {
+		t.Fatalf("send(broken) error = %v, want %v", err, ErrInjected)
+	}
+	if _, err := send(t, server, ""); err == nil || err.Error() != "no skill selected" {
+		t.Fatalf("send() error = %v, want the default script error", err)
+	}
+}
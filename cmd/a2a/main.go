@@ -0,0 +1,195 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command a2a inspects A2A agents. It can fetch and validate an AgentCard, send messages,
+// stream Task events, list push notification configurations and rebuild Tasks from event archives:
+//
+//	a2a card https://agent.example.com
+//	a2a validate https://agent.example.com
+//	a2a fix https://agent.example.com
+//	a2a send https://agent.example.com "Hello"
+//	a2a stream -task 123 https://agent.example.com "Continue"
+//	a2a resubscribe https://agent.example.com 123
+//	a2a push-configs https://agent.example.com 123
+//	a2a replay -at 2025-01-01T12:00:00Z events.jsonl 123
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/a2aproject/a2a-go/a2aclient/inspect"
+	"github.com/a2aproject/a2a-go/a2asrv/replay"
+)
+
+const usage = `Usage: a2a <command> [flags] <agent-url> [args]
+
+Commands:
+  card          fetch and print the AgentCard
+  validate      fetch the AgentCard and report problems
+  fix           fetch the AgentCard and print it with interface declarations normalized
+  send          send a text message: a2a send <agent-url> <text>
+  stream        send a text message and print streamed events: a2a stream <agent-url> <text>
+  resubscribe   print events of a running task: a2a resubscribe <agent-url> <task-id>
+  push-configs  list push notification configs: a2a push-configs <agent-url> <task-id>
+  replay        print a task rebuilt from an event archive: a2a replay <archive-file> <task-id>
+
+Flags:
+`
+
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	if errors.Is(err, errUsage) {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "a2a:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("a2a", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	timeout := flags.Duration("timeout", a2aclient.DefaultDialTimeout, "timeout of non-streaming calls")
+	taskID := flags.String("task", "", "ID of the task a message is sent to")
+	at := flags.String("at", "", "RFC 3339 time of the task state printed by replay, the latest state by default")
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	if len(args) == 0 {
+		flags.Usage()
+		return errUsage
+	}
+	command := args[0]
+	if err := flags.Parse(args[1:]); err != nil {
+		return errUsage
+	}
+	rest := flags.Args()
+	if len(rest) == 0 {
+		flags.Usage()
+		return errUsage
+	}
+	agentURL, params := rest[0], rest[1:]
+
+	switch command {
+	case "replay":
+		if len(params) != 1 {
+			flags.Usage()
+			return errUsage
+		}
+		return replayTask(ctx, agentURL, a2a.TaskID(params[0]), *at, stdout)
+	case "card", "validate", "fix":
+		card, err := inspect.FetchCard(ctx, agentURL, &http.Client{Timeout: *timeout})
+		if err != nil {
+			return err
+		}
+		switch command {
+		case "card":
+			return inspect.PrintJSON(stdout, card)
+		case "fix":
+			return inspect.PrintJSON(stdout, a2a.NormalizeInterfaces(card))
+		}
+		return printProblems(stdout, inspect.ValidateCard(card))
+	}
+
+	var call func(client *a2aclient.Client) error
+	switch command {
+	case "send":
+		call = func(client *a2aclient.Client) error {
+			return inspect.SendMessage(ctx, client, inspect.TextMessage(params[0], a2a.TaskID(*taskID)), stdout)
+		}
+	case "stream":
+		call = func(client *a2aclient.Client) error {
+			return inspect.StreamMessage(ctx, client, inspect.TextMessage(params[0], a2a.TaskID(*taskID)), stdout)
+		}
+	case "resubscribe":
+		call = func(client *a2aclient.Client) error {
+			return inspect.Resubscribe(ctx, client, a2a.TaskID(params[0]), stdout)
+		}
+	case "push-configs":
+		call = func(client *a2aclient.Client) error {
+			return inspect.ListPushConfigs(ctx, client, a2a.TaskID(params[0]), stdout)
+		}
+	}
+	if call == nil || len(params) != 1 {
+		flags.Usage()
+		return errUsage
+	}
+
+	client, err := a2aclient.Dial(ctx, agentURL, a2aclient.WithDialTimeout(*timeout))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Destroy() }()
+	return call(client)
+}
+
+func printProblems(w io.Writer, problems []inspect.Problem) error {
+	if len(problems) == 0 {
+		_, err := fmt.Fprintln(w, "no problems found")
+		return err
+	}
+	invalid := false
+	for _, p := range problems {
+		if _, err := fmt.Fprintln(w, p); err != nil {
+			return err
+		}
+		invalid = invalid || p.Severity == inspect.SeverityError
+	}
+	if invalid {
+		return errors.New("agent card is invalid")
+	}
+	return nil
+}
+
+func replayTask(ctx context.Context, path string, taskID a2a.TaskID, at string, w io.Writer) error {
+	var atTime time.Time
+	if at != "" {
+		var err error
+		if atTime, err = time.Parse(time.RFC3339, at); err != nil {
+			return fmt.Errorf("invalid -at time: %w", err)
+		}
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+	archive, err := replay.Load(file)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	task, err := archive.StoreAt(atTime).Get(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	return inspect.PrintJSON(w, task)
+}
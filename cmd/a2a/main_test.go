@@ -0,0 +1,106 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestRun_Card(t *testing.T) {
+	card := &a2a.AgentCard{Name: "agent", URL: "https://agent.com"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(card)
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	if err := run(t.Context(), []string{"card", server.URL}, &out, io.Discard); err != nil {
+		t.Fatalf("run(card) error = %v", err)
+	}
+	if !strings.Contains(out.String(), `"name": "agent"`) {
+		t.Fatalf("run(card) output = %s, want the card", out.String())
+	}
+
+	out.Reset()
+	if err := run(t.Context(), []string{"validate", server.URL}, &out, io.Discard); err == nil {
+		t.Fatal("run(validate) error = nil, want the card to be invalid")
+	}
+	if !strings.Contains(out.String(), "error: description") {
+		t.Fatalf("run(validate) output = %s, want reported problems", out.String())
+	}
+}
+
+func TestRun_Fix(t *testing.T) {
+	card := &a2a.AgentCard{
+		Name:                 "agent",
+		URL:                  "https://agent.com",
+		AdditionalInterfaces: []a2a.AgentInterface{{Transport: "grpc", URL: "agent.com:443"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(card)
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	if err := run(t.Context(), []string{"fix", server.URL}, &out, io.Discard); err != nil {
+		t.Fatalf("run(fix) error = %v", err)
+	}
+	var fixed a2a.AgentCard
+	if err := json.Unmarshal(out.Bytes(), &fixed); err != nil {
+		t.Fatalf("failed to decode run(fix) output %s: %v", out.String(), err)
+	}
+	if problems := a2a.CheckInterfaces(&fixed); len(problems) != 0 {
+		t.Fatalf("run(fix) printed a card with problems %v", problems)
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	for _, args := range [][]string{nil, {"card"}, {"unknown", "http://localhost"}, {"send", "http://localhost"}} {
+		if err := run(t.Context(), args, io.Discard, io.Discard); !errors.Is(err, errUsage) {
+			t.Errorf("run(%v) error = %v, want %v", args, err, errUsage)
+		}
+	}
+}
+
+func TestRun_Replay(t *testing.T) {
+	archive := `{"time":"2025-01-01T12:00:00Z","taskId":"123","kind":"task","event":{"id":"123","contextId":"ctx","status":{"state":"working"}}}
+{"time":"2025-01-01T12:01:00Z","taskId":"123","kind":"status-update","event":{"taskId":"123","contextId":"ctx","final":true,"status":{"state":"completed"}}}
+`
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	if err := os.WriteFile(path, []byte(archive), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	for at, want := range map[string]string{"": "completed", "2025-01-01T12:00:30Z": "working"} {
+		var out bytes.Buffer
+		if err := run(t.Context(), []string{"replay", "-at", at, path, "123"}, &out, io.Discard); err != nil {
+			t.Fatalf("run(replay -at %q) error = %v", at, err)
+		}
+		if !strings.Contains(out.String(), `"state": "`+want+`"`) {
+			t.Fatalf("run(replay -at %q) output = %s, want %s task", at, out.String(), want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ownership
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// ErrAlreadyOwned is returned by Acquire if the task is owned by someone else.
+var ErrAlreadyOwned = errors.New("task is already owned")
+
+// Mem tracks task ownership in memory. It only guarantees exclusive execution within a single process.
+type Mem struct {
+	mu    sync.Mutex
+	owned map[a2a.TaskID]struct{}
+}
+
+// NewMem creates an empty Mem registry.
+func NewMem() *Mem {
+	return &Mem{
+		owned: make(map[a2a.TaskID]struct{}),
+	}
+}
+
+func (m *Mem) Acquire(ctx context.Context, taskId a2a.TaskID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.owned[taskId]; ok {
+		return ErrAlreadyOwned
+	}
+	m.owned[taskId] = struct{}{}
+	return nil
+}
+
+func (m *Mem) Release(ctx context.Context, taskId a2a.TaskID) error {
+	m.mu.Lock()
+	delete(m.owned, taskId)
+	m.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ownership
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestMem_AcquireRelease(t *testing.T) {
+	ctx := t.Context()
+	m := NewMem()
+	taskID := a2a.NewTaskID()
+
+	if err := m.Acquire(ctx, taskID); err != nil {
+		t.Fatalf("Acquire() failed: %v", err)
+	}
+	if err := m.Acquire(ctx, taskID); !errors.Is(err, ErrAlreadyOwned) {
+		t.Fatalf("second Acquire() error = %v, want %v", err, ErrAlreadyOwned)
+	}
+	if err := m.Acquire(ctx, a2a.NewTaskID()); err != nil {
+		t.Fatalf("Acquire() of a different task failed: %v", err)
+	}
+
+	if err := m.Release(ctx, taskID); err != nil {
+		t.Fatalf("Release() failed: %v", err)
+	}
+	if err := m.Acquire(ctx, taskID); err != nil {
+		t.Fatalf("Acquire() after Release() failed: %v", err)
+	}
+}
+
+func TestMem_ReleaseNotOwned(t *testing.T) {
+	m := NewMem()
+	if err := m.Release(t.Context(), a2a.NewTaskID()); err != nil {
+		t.Fatalf("Release() of not owned task failed: %v", err)
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbconv
+
+import (
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2apb"
+)
+
+// ToProtoAgentCard converts an AgentCard to its proto representation.
+func ToProtoAgentCard(card *a2a.AgentCard) (*a2apb.AgentCard, error) {
+	if card == nil {
+		return nil, nil
+	}
+	result := &a2apb.AgentCard{
+		ProtocolVersion:                   card.ProtocolVersion,
+		Name:                              card.Name,
+		Description:                       card.Description,
+		Url:                               card.URL,
+		PreferredTransport:                string(card.PreferredTransport),
+		Version:                           card.Version,
+		DocumentationUrl:                  card.DocumentationURL,
+		DefaultInputModes:                 card.DefaultInputModes,
+		DefaultOutputModes:                card.DefaultOutputModes,
+		SupportsAuthenticatedExtendedCard: card.SupportsAuthenticatedExtendedCard,
+		Capabilities: &a2apb.AgentCapabilities{
+			Streaming:         card.Capabilities.Streaming,
+			PushNotifications: card.Capabilities.PushNotifications,
+		},
+	}
+	for _, iface := range card.AdditionalInterfaces {
+		result.AdditionalInterfaces = append(result.AdditionalInterfaces, &a2apb.AgentInterface{Url: iface.URL, Transport: iface.Transport})
+	}
+	if card.Provider != nil {
+		result.Provider = &a2apb.AgentProvider{Url: card.Provider.URL, Organization: card.Provider.Org}
+	}
+	for _, ext := range card.Capabilities.Extensions {
+		params, err := toProtoStruct(ext.Params)
+		if err != nil {
+			return nil, err
+		}
+		result.Capabilities.Extensions = append(result.Capabilities.Extensions, &a2apb.AgentExtension{
+			Uri:         ext.URI,
+			Description: ext.Description,
+			Required:    ext.Required,
+			Params:      params,
+		})
+	}
+	if len(card.SecuritySchemes) > 0 {
+		result.SecuritySchemes = make(map[string]*a2apb.SecurityScheme, len(card.SecuritySchemes))
+		for name, scheme := range card.SecuritySchemes {
+			pb, err := toProtoSecurityScheme(scheme)
+			if err != nil {
+				return nil, fmt.Errorf("security scheme %q: %w", name, err)
+			}
+			result.SecuritySchemes[string(name)] = pb
+		}
+	}
+	for _, req := range card.Security {
+		security := &a2apb.Security{Schemes: make(map[string]*a2apb.StringList, len(req))}
+		for name, scopes := range req {
+			security.Schemes[string(name)] = &a2apb.StringList{List: scopes}
+		}
+		result.Security = append(result.Security, security)
+	}
+	for _, skill := range card.Skills {
+		result.Skills = append(result.Skills, &a2apb.AgentSkill{
+			Id:          skill.ID,
+			Name:        skill.Name,
+			Description: skill.Description,
+			Tags:        skill.Tags,
+			Examples:    skill.Examples,
+			InputModes:  skill.InputModes,
+			OutputModes: skill.OutputModes,
+		})
+	}
+	return result, nil
+}
+
+// FromProtoAgentCard converts a proto AgentCard to an AgentCard.
+func FromProtoAgentCard(card *a2apb.AgentCard) (*a2a.AgentCard, error) {
+	if card == nil {
+		return nil, fmt.Errorf("agent card is missing")
+	}
+	result := &a2a.AgentCard{
+		ProtocolVersion:                   card.GetProtocolVersion(),
+		Name:                              card.GetName(),
+		Description:                       card.GetDescription(),
+		URL:                               card.GetUrl(),
+		PreferredTransport:                a2a.TransportProtocol(card.GetPreferredTransport()),
+		Version:                           card.GetVersion(),
+		DocumentationURL:                  card.GetDocumentationUrl(),
+		DefaultInputModes:                 card.GetDefaultInputModes(),
+		DefaultOutputModes:                card.GetDefaultOutputModes(),
+		SupportsAuthenticatedExtendedCard: card.GetSupportsAuthenticatedExtendedCard(),
+		Capabilities: a2a.AgentCapabilities{
+			Streaming:         card.GetCapabilities().GetStreaming(),
+			PushNotifications: card.GetCapabilities().GetPushNotifications(),
+		},
+		Skills: make([]a2a.AgentSkill, 0, len(card.GetSkills())),
+	}
+	for _, iface := range card.GetAdditionalInterfaces() {
+		result.AdditionalInterfaces = append(result.AdditionalInterfaces, a2a.AgentInterface{URL: iface.GetUrl(), Transport: iface.GetTransport()})
+	}
+	if provider := card.GetProvider(); provider != nil {
+		result.Provider = &a2a.AgentProvider{URL: provider.GetUrl(), Org: provider.GetOrganization()}
+	}
+	for _, ext := range card.GetCapabilities().GetExtensions() {
+		result.Capabilities.Extensions = append(result.Capabilities.Extensions, a2a.AgentExtension{
+			URI:         ext.GetUri(),
+			Description: ext.GetDescription(),
+			Required:    ext.GetRequired(),
+			Params:      fromProtoStruct(ext.GetParams()),
+		})
+	}
+	if len(card.GetSecuritySchemes()) > 0 {
+		result.SecuritySchemes = make(a2a.NamedSecuritySchemes, len(card.GetSecuritySchemes()))
+		for name, scheme := range card.GetSecuritySchemes() {
+			converted, err := fromProtoSecurityScheme(scheme)
+			if err != nil {
+				return nil, fmt.Errorf("security scheme %q: %w", name, err)
+			}
+			result.SecuritySchemes[a2a.SecuritySchemeName(name)] = converted
+		}
+	}
+	for _, security := range card.GetSecurity() {
+		req := make(a2a.SecurityRequirements, len(security.GetSchemes()))
+		for name, scopes := range security.GetSchemes() {
+			req[a2a.SecuritySchemeName(name)] = a2a.SecuritySchemeScopes(scopes.GetList())
+		}
+		result.Security = append(result.Security, req)
+	}
+	for _, skill := range card.GetSkills() {
+		result.Skills = append(result.Skills, a2a.AgentSkill{
+			ID:          skill.GetId(),
+			Name:        skill.GetName(),
+			Description: skill.GetDescription(),
+			Tags:        skill.GetTags(),
+			Examples:    skill.GetExamples(),
+			InputModes:  skill.GetInputModes(),
+			OutputModes: skill.GetOutputModes(),
+		})
+	}
+	return result, nil
+}
+
+func toProtoSecurityScheme(scheme a2a.SecurityScheme) (*a2apb.SecurityScheme, error) {
+	switch s := scheme.(type) {
+	case a2a.APIKeySecurityScheme:
+		return &a2apb.SecurityScheme{Scheme: &a2apb.SecurityScheme_ApiKeySecurityScheme{
+			ApiKeySecurityScheme: &a2apb.APIKeySecurityScheme{Description: s.Description, Location: string(s.In), Name: s.Name},
+		}}, nil
+	case a2a.HTTPAuthSecurityScheme:
+		return &a2apb.SecurityScheme{Scheme: &a2apb.SecurityScheme_HttpAuthSecurityScheme{
+			HttpAuthSecurityScheme: &a2apb.HTTPAuthSecurityScheme{Description: s.Description, Scheme: s.Scheme, BearerFormat: s.BearerFormat},
+		}}, nil
+	case a2a.OpenIDConnectSecurityScheme:
+		return &a2apb.SecurityScheme{Scheme: &a2apb.SecurityScheme_OpenIdConnectSecurityScheme{
+			OpenIdConnectSecurityScheme: &a2apb.OpenIdConnectSecurityScheme{Description: s.Description, OpenIdConnectUrl: s.OpenIDConnectURL},
+		}}, nil
+	case a2a.OAuth2SecurityScheme:
+		flows, err := toProtoOAuthFlows(s.Flows)
+		if err != nil {
+			return nil, err
+		}
+		return &a2apb.SecurityScheme{Scheme: &a2apb.SecurityScheme_Oauth2SecurityScheme{
+			Oauth2SecurityScheme: &a2apb.OAuth2SecurityScheme{Description: s.Description, Flows: flows},
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported security scheme type %T", scheme)
+	}
+}
+
+// toProtoOAuthFlows converts OAuthFlows to a proto oneof, which can only hold a single flow.
+func toProtoOAuthFlows(flows a2a.OAuthFlows) (*a2apb.OAuthFlows, error) {
+	switch {
+	case flows.AuthorizationCode != nil:
+		f := flows.AuthorizationCode
+		return &a2apb.OAuthFlows{Flow: &a2apb.OAuthFlows_AuthorizationCode{AuthorizationCode: &a2apb.AuthorizationCodeOAuthFlow{
+			AuthorizationUrl: f.AuthorizationURL, TokenUrl: f.TokenURL, RefreshUrl: f.RefreshURL, Scopes: f.Scopes,
+		}}}, nil
+	case flows.ClientCredentials != nil:
+		f := flows.ClientCredentials
+		return &a2apb.OAuthFlows{Flow: &a2apb.OAuthFlows_ClientCredentials{ClientCredentials: &a2apb.ClientCredentialsOAuthFlow{
+			TokenUrl: f.TokenURL, RefreshUrl: f.RefreshURL, Scopes: f.Scopes,
+		}}}, nil
+	case flows.Implicit != nil:
+		f := flows.Implicit
+		return &a2apb.OAuthFlows{Flow: &a2apb.OAuthFlows_Implicit{Implicit: &a2apb.ImplicitOAuthFlow{
+			AuthorizationUrl: f.AuthorizationURL, RefreshUrl: f.RefreshURL, Scopes: f.Scopes,
+		}}}, nil
+	case flows.Password != nil:
+		f := flows.Password
+		return &a2apb.OAuthFlows{Flow: &a2apb.OAuthFlows_Password{Password: &a2apb.PasswordOAuthFlow{
+			TokenUrl: f.TokenURL, RefreshUrl: f.RefreshURL, Scopes: f.Scopes,
+		}}}, nil
+	default:
+		return nil, fmt.Errorf("no oauth flow defined")
+	}
+}
+
+func fromProtoSecurityScheme(scheme *a2apb.SecurityScheme) (a2a.SecurityScheme, error) {
+	switch s := scheme.GetScheme().(type) {
+	case *a2apb.SecurityScheme_ApiKeySecurityScheme:
+		return a2a.APIKeySecurityScheme{
+			Description: s.ApiKeySecurityScheme.GetDescription(),
+			In:          a2a.APIKeySecuritySchemeIn(s.ApiKeySecurityScheme.GetLocation()),
+			Name:        s.ApiKeySecurityScheme.GetName(),
+		}, nil
+	case *a2apb.SecurityScheme_HttpAuthSecurityScheme:
+		return a2a.HTTPAuthSecurityScheme{
+			Description:  s.HttpAuthSecurityScheme.GetDescription(),
+			Scheme:       s.HttpAuthSecurityScheme.GetScheme(),
+			BearerFormat: s.HttpAuthSecurityScheme.GetBearerFormat(),
+		}, nil
+	case *a2apb.SecurityScheme_OpenIdConnectSecurityScheme:
+		return a2a.OpenIDConnectSecurityScheme{
+			Description:      s.OpenIdConnectSecurityScheme.GetDescription(),
+			OpenIDConnectURL: s.OpenIdConnectSecurityScheme.GetOpenIdConnectUrl(),
+		}, nil
+	case *a2apb.SecurityScheme_Oauth2SecurityScheme:
+		return a2a.OAuth2SecurityScheme{
+			Description: s.Oauth2SecurityScheme.GetDescription(),
+			Flows:       fromProtoOAuthFlows(s.Oauth2SecurityScheme.GetFlows()),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown security scheme type %T", s)
+	}
+}
+
+func fromProtoOAuthFlows(flows *a2apb.OAuthFlows) a2a.OAuthFlows {
+	var result a2a.OAuthFlows
+	switch f := flows.GetFlow().(type) {
+	case *a2apb.OAuthFlows_AuthorizationCode:
+		result.AuthorizationCode = &a2a.AuthorizationCodeOAuthFlow{
+			AuthorizationURL: f.AuthorizationCode.GetAuthorizationUrl(),
+			TokenURL:         f.AuthorizationCode.GetTokenUrl(),
+			RefreshURL:       f.AuthorizationCode.GetRefreshUrl(),
+			Scopes:           f.AuthorizationCode.GetScopes(),
+		}
+	case *a2apb.OAuthFlows_ClientCredentials:
+		result.ClientCredentials = &a2a.ClientCredentialsOAuthFlow{
+			TokenURL:   f.ClientCredentials.GetTokenUrl(),
+			RefreshURL: f.ClientCredentials.GetRefreshUrl(),
+			Scopes:     f.ClientCredentials.GetScopes(),
+		}
+	case *a2apb.OAuthFlows_Implicit:
+		result.Implicit = &a2a.ImplicitOAuthFlow{
+			AuthorizationURL: f.Implicit.GetAuthorizationUrl(),
+			RefreshURL:       f.Implicit.GetRefreshUrl(),
+			Scopes:           f.Implicit.GetScopes(),
+		}
+	case *a2apb.OAuthFlows_Password:
+		result.Password = &a2a.PasswordOAuthFlow{
+			TokenURL:   f.Password.GetTokenUrl(),
+			RefreshURL: f.Password.GetRefreshUrl(),
+			Scopes:     f.Password.GetScopes(),
+		}
+	}
+	return result
+}
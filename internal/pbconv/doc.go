@@ -0,0 +1,23 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pbconv provides conversions between the a2a core types and their a2apb protocol buffer
+// counterparts used by the gRPC transport.
+//
+// The proto schema is narrower than the JSON one, so some fields don't survive a round trip:
+// Part metadata, file names, Message reference task IDs, unknown JSON fields (Extra) and a number
+// of AgentCard fields (icon URL, signatures, state transition history capability, skill security
+// and input schema, OAuth2 metadata URL, all but one OAuth2 flow) are dropped. Mutual TLS security schemes can't be
+// represented and result in an error.
+package pbconv
@@ -0,0 +1,619 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbconv
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2apb"
+)
+
+const (
+	taskNamePrefix      = "tasks/"
+	pushConfigNameInfix = "/pushNotificationConfigs/"
+)
+
+// TaskName returns the resource name of a Task in the "tasks/{id}" format.
+func TaskName(id a2a.TaskID) string {
+	return taskNamePrefix + string(id)
+}
+
+// PushConfigCollectionName returns the parent resource name used for creating and listing
+// push configs of a Task.
+func PushConfigCollectionName(taskID a2a.TaskID) string {
+	return TaskName(taskID) + strings.TrimSuffix(pushConfigNameInfix, "/")
+}
+
+// PushConfigName returns the resource name of a push config in the
+// "tasks/{id}/pushNotificationConfigs/{configId}" format.
+func PushConfigName(taskID a2a.TaskID, configID string) string {
+	return TaskName(taskID) + pushConfigNameInfix + configID
+}
+
+// ParseTaskName extracts the Task ID from a "tasks/{id}" resource name or a name of a resource
+// nested under a Task.
+func ParseTaskName(name string) (a2a.TaskID, error) {
+	rest, ok := strings.CutPrefix(name, taskNamePrefix)
+	if !ok || rest == "" {
+		return "", fmt.Errorf("invalid task resource name %q", name)
+	}
+	id, _, _ := strings.Cut(rest, "/")
+	return a2a.TaskID(id), nil
+}
+
+// ToProtoSendMessageRequest converts MessageSendParams to a SendMessage or SendStreamingMessage request.
+func ToProtoSendMessageRequest(params a2a.MessageSendParams) (*a2apb.SendMessageRequest, error) {
+	msg, err := ToProtoMessage(&params.Message)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := toProtoStruct(params.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	req := &a2apb.SendMessageRequest{Request: msg, Metadata: meta}
+	if cfg := params.Config; cfg != nil {
+		req.Configuration = &a2apb.SendMessageConfiguration{
+			AcceptedOutputModes: cfg.AcceptedOutputModes,
+			Blocking:            cfg.Blocking,
+			PushNotification:    toProtoPushConfig(cfg.PushConfig),
+		}
+		if cfg.HistoryLength != nil {
+			req.Configuration.HistoryLength = int32(*cfg.HistoryLength)
+		}
+	}
+	return req, nil
+}
+
+// FromProtoSendMessageResponse converts a SendMessage response to a Task or a Message.
+func FromProtoSendMessageResponse(resp *a2apb.SendMessageResponse) (a2a.SendMessageResult, error) {
+	switch payload := resp.GetPayload().(type) {
+	case *a2apb.SendMessageResponse_Task:
+		return FromProtoTask(payload.Task)
+	case *a2apb.SendMessageResponse_Msg:
+		return FromProtoMessage(payload.Msg)
+	default:
+		return nil, fmt.Errorf("unknown send message response payload %T", payload)
+	}
+}
+
+// FromProtoStreamResponse converts a streaming response to the Event it carries.
+func FromProtoStreamResponse(resp *a2apb.StreamResponse) (a2a.Event, error) {
+	switch payload := resp.GetPayload().(type) {
+	case *a2apb.StreamResponse_Task:
+		return FromProtoTask(payload.Task)
+	case *a2apb.StreamResponse_Msg:
+		return FromProtoMessage(payload.Msg)
+	case *a2apb.StreamResponse_StatusUpdate:
+		return FromProtoStatusUpdate(payload.StatusUpdate)
+	case *a2apb.StreamResponse_ArtifactUpdate:
+		return FromProtoArtifactUpdate(payload.ArtifactUpdate)
+	default:
+		return nil, fmt.Errorf("unknown stream response payload %T", payload)
+	}
+}
+
+// ToProtoStreamResponse wraps the Event into a streaming response.
+func ToProtoStreamResponse(event a2a.Event) (*a2apb.StreamResponse, error) {
+	switch v := event.(type) {
+	case *a2a.Task:
+		task, err := ToProtoTask(v)
+		if err != nil {
+			return nil, err
+		}
+		return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_Task{Task: task}}, nil
+	case *a2a.Message:
+		msg, err := ToProtoMessage(v)
+		if err != nil {
+			return nil, err
+		}
+		return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_Msg{Msg: msg}}, nil
+	case *a2a.TaskStatusUpdateEvent:
+		update, err := ToProtoStatusUpdate(v)
+		if err != nil {
+			return nil, err
+		}
+		return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_StatusUpdate{StatusUpdate: update}}, nil
+	case *a2a.TaskArtifactUpdateEvent:
+		update, err := ToProtoArtifactUpdate(v)
+		if err != nil {
+			return nil, err
+		}
+		return &a2apb.StreamResponse{Payload: &a2apb.StreamResponse_ArtifactUpdate{ArtifactUpdate: update}}, nil
+	default:
+		return nil, fmt.Errorf("unknown event type %T", event)
+	}
+}
+
+// ToProtoTask converts a Task to its proto representation.
+func ToProtoTask(task *a2a.Task) (*a2apb.Task, error) {
+	if task == nil {
+		return nil, nil
+	}
+	status, err := toProtoStatus(task.Status)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := toProtoStruct(task.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	result := &a2apb.Task{Id: string(task.ID), ContextId: task.ContextID, Status: status, Metadata: meta}
+	for _, artifact := range task.Artifacts {
+		pb, err := ToProtoArtifact(artifact)
+		if err != nil {
+			return nil, err
+		}
+		result.Artifacts = append(result.Artifacts, pb)
+	}
+	for _, msg := range task.History {
+		pb, err := ToProtoMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+		result.History = append(result.History, pb)
+	}
+	return result, nil
+}
+
+// FromProtoTask converts a proto Task to a Task.
+func FromProtoTask(task *a2apb.Task) (*a2a.Task, error) {
+	if task == nil {
+		return nil, fmt.Errorf("task is missing")
+	}
+	status, err := fromProtoStatus(task.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+	result := &a2a.Task{
+		ID:        a2a.TaskID(task.GetId()),
+		ContextID: task.GetContextId(),
+		Status:    status,
+		Metadata:  fromProtoStruct(task.GetMetadata()),
+	}
+	for _, artifact := range task.GetArtifacts() {
+		converted, err := FromProtoArtifact(artifact)
+		if err != nil {
+			return nil, err
+		}
+		result.Artifacts = append(result.Artifacts, converted)
+	}
+	for _, msg := range task.GetHistory() {
+		converted, err := FromProtoMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+		result.History = append(result.History, converted)
+	}
+	return result, nil
+}
+
+// ToProtoMessage converts a Message to its proto representation.
+func ToProtoMessage(msg *a2a.Message) (*a2apb.Message, error) {
+	if msg == nil {
+		return nil, nil
+	}
+	parts, err := toProtoParts(msg.Parts)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := toProtoStruct(msg.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &a2apb.Message{
+		MessageId:  msg.ID,
+		ContextId:  msg.ContextID,
+		TaskId:     string(msg.TaskID),
+		Role:       toProtoRole(msg.Role),
+		Content:    parts,
+		Metadata:   meta,
+		Extensions: msg.Extensions,
+	}, nil
+}
+
+// FromProtoMessage converts a proto Message to a Message.
+func FromProtoMessage(msg *a2apb.Message) (*a2a.Message, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("message is missing")
+	}
+	parts, err := fromProtoParts(msg.GetContent())
+	if err != nil {
+		return nil, err
+	}
+	return &a2a.Message{
+		ID:         msg.GetMessageId(),
+		ContextID:  msg.GetContextId(),
+		TaskID:     a2a.TaskID(msg.GetTaskId()),
+		Role:       fromProtoRole(msg.GetRole()),
+		Parts:      parts,
+		Metadata:   fromProtoStruct(msg.GetMetadata()),
+		Extensions: msg.GetExtensions(),
+	}, nil
+}
+
+// ToProtoArtifact converts an Artifact to its proto representation.
+func ToProtoArtifact(artifact *a2a.Artifact) (*a2apb.Artifact, error) {
+	if artifact == nil {
+		return nil, nil
+	}
+	parts, err := toProtoParts(artifact.Parts)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := toProtoStruct(artifact.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &a2apb.Artifact{
+		ArtifactId:  string(artifact.ID),
+		Name:        artifact.Name,
+		Description: artifact.Description,
+		Parts:       parts,
+		Metadata:    meta,
+		Extensions:  artifact.Extensions,
+	}, nil
+}
+
+// FromProtoArtifact converts a proto Artifact to an Artifact.
+func FromProtoArtifact(artifact *a2apb.Artifact) (*a2a.Artifact, error) {
+	if artifact == nil {
+		return nil, fmt.Errorf("artifact is missing")
+	}
+	parts, err := fromProtoParts(artifact.GetParts())
+	if err != nil {
+		return nil, err
+	}
+	return &a2a.Artifact{
+		ID:          a2a.ArtifactID(artifact.GetArtifactId()),
+		Name:        artifact.GetName(),
+		Description: artifact.GetDescription(),
+		Parts:       parts,
+		Metadata:    fromProtoStruct(artifact.GetMetadata()),
+		Extensions:  artifact.GetExtensions(),
+	}, nil
+}
+
+// ToProtoStatusUpdate converts a TaskStatusUpdateEvent to its proto representation.
+func ToProtoStatusUpdate(event *a2a.TaskStatusUpdateEvent) (*a2apb.TaskStatusUpdateEvent, error) {
+	status, err := toProtoStatus(event.Status)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := toProtoStruct(event.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &a2apb.TaskStatusUpdateEvent{
+		TaskId:    string(event.TaskID),
+		ContextId: event.ContextID,
+		Status:    status,
+		Final:     event.Final,
+		Metadata:  meta,
+	}, nil
+}
+
+// FromProtoStatusUpdate converts a proto TaskStatusUpdateEvent to a TaskStatusUpdateEvent.
+func FromProtoStatusUpdate(event *a2apb.TaskStatusUpdateEvent) (*a2a.TaskStatusUpdateEvent, error) {
+	status, err := fromProtoStatus(event.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+	return &a2a.TaskStatusUpdateEvent{
+		TaskID:    a2a.TaskID(event.GetTaskId()),
+		ContextID: event.GetContextId(),
+		Status:    status,
+		Final:     event.GetFinal(),
+		Metadata:  fromProtoStruct(event.GetMetadata()),
+	}, nil
+}
+
+// ToProtoArtifactUpdate converts a TaskArtifactUpdateEvent to its proto representation.
+func ToProtoArtifactUpdate(event *a2a.TaskArtifactUpdateEvent) (*a2apb.TaskArtifactUpdateEvent, error) {
+	artifact, err := ToProtoArtifact(event.Artifact)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := toProtoStruct(event.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &a2apb.TaskArtifactUpdateEvent{
+		TaskId:    string(event.TaskID),
+		ContextId: event.ContextID,
+		Artifact:  artifact,
+		Append:    event.Append,
+		LastChunk: event.LastChunk,
+		Metadata:  meta,
+	}, nil
+}
+
+// FromProtoArtifactUpdate converts a proto TaskArtifactUpdateEvent to a TaskArtifactUpdateEvent.
+func FromProtoArtifactUpdate(event *a2apb.TaskArtifactUpdateEvent) (*a2a.TaskArtifactUpdateEvent, error) {
+	artifact, err := FromProtoArtifact(event.GetArtifact())
+	if err != nil {
+		return nil, err
+	}
+	return &a2a.TaskArtifactUpdateEvent{
+		TaskID:    a2a.TaskID(event.GetTaskId()),
+		ContextID: event.GetContextId(),
+		Artifact:  artifact,
+		Append:    event.GetAppend(),
+		LastChunk: event.GetLastChunk(),
+		Metadata:  fromProtoStruct(event.GetMetadata()),
+	}, nil
+}
+
+// ToProtoTaskPushConfig converts a TaskPushConfig to its proto representation.
+func ToProtoTaskPushConfig(config a2a.TaskPushConfig) *a2apb.TaskPushNotificationConfig {
+	return &a2apb.TaskPushNotificationConfig{
+		Name:                   PushConfigName(config.TaskID, config.Config.ID),
+		PushNotificationConfig: toProtoPushConfig(&config.Config),
+	}
+}
+
+// FromProtoTaskPushConfig converts a proto TaskPushNotificationConfig to a TaskPushConfig.
+// The Task ID is taken from the resource name.
+func FromProtoTaskPushConfig(config *a2apb.TaskPushNotificationConfig) (a2a.TaskPushConfig, error) {
+	if config == nil {
+		return a2a.TaskPushConfig{}, fmt.Errorf("push config is missing")
+	}
+	taskID, err := ParseTaskName(config.GetName())
+	if err != nil {
+		return a2a.TaskPushConfig{}, err
+	}
+	result := a2a.TaskPushConfig{TaskID: taskID}
+	if pc := fromProtoPushConfig(config.GetPushNotificationConfig()); pc != nil {
+		result.Config = *pc
+	}
+	if result.Config.ID == "" {
+		_, result.Config.ID, _ = strings.Cut(config.GetName(), pushConfigNameInfix)
+	}
+	return result, nil
+}
+
+// FromProtoListTaskPushConfig converts a proto list push configs response.
+func FromProtoListTaskPushConfig(resp *a2apb.ListTaskPushNotificationConfigResponse) (*a2a.ListTaskPushConfigResult, error) {
+	result := &a2a.ListTaskPushConfigResult{
+		Configs:       make([]a2a.TaskPushConfig, 0, len(resp.GetConfigs())),
+		NextPageToken: resp.GetNextPageToken(),
+	}
+	for _, config := range resp.GetConfigs() {
+		converted, err := FromProtoTaskPushConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		result.Configs = append(result.Configs, converted)
+	}
+	return result, nil
+}
+
+func toProtoPushConfig(config *a2a.PushConfig) *a2apb.PushNotificationConfig {
+	if config == nil {
+		return nil
+	}
+	result := &a2apb.PushNotificationConfig{Id: config.ID, Url: config.URL, Token: config.Token}
+	if config.Auth != nil {
+		result.Authentication = &a2apb.AuthenticationInfo{
+			Schemes:     config.Auth.Schemes,
+			Credentials: config.Auth.Credentials,
+		}
+	}
+	return result
+}
+
+func fromProtoPushConfig(config *a2apb.PushNotificationConfig) *a2a.PushConfig {
+	if config == nil {
+		return nil
+	}
+	result := &a2a.PushConfig{ID: config.GetId(), URL: config.GetUrl(), Token: config.GetToken()}
+	if auth := config.GetAuthentication(); auth != nil {
+		result.Auth = &a2a.PushAuthInfo{Schemes: auth.GetSchemes(), Credentials: auth.GetCredentials()}
+	}
+	return result
+}
+
+func toProtoStatus(status a2a.TaskStatus) (*a2apb.TaskStatus, error) {
+	msg, err := ToProtoMessage(status.Message)
+	if err != nil {
+		return nil, err
+	}
+	result := &a2apb.TaskStatus{State: toProtoState(status.State), Update: msg}
+	if status.Timestamp != nil {
+		result.Timestamp = timestamppb.New(*status.Timestamp)
+	}
+	return result, nil
+}
+
+func fromProtoStatus(status *a2apb.TaskStatus) (a2a.TaskStatus, error) {
+	result := a2a.TaskStatus{State: fromProtoState(status.GetState())}
+	if status.GetUpdate() != nil {
+		msg, err := FromProtoMessage(status.GetUpdate())
+		if err != nil {
+			return a2a.TaskStatus{}, err
+		}
+		result.Message = msg
+	}
+	if status.GetTimestamp() != nil {
+		ts := status.GetTimestamp().AsTime()
+		result.Timestamp = &ts
+	}
+	return result, nil
+}
+
+var stateToProto = map[a2a.TaskState]a2apb.TaskState{
+	a2a.TaskStateSubmitted:     a2apb.TaskState_TASK_STATE_SUBMITTED,
+	a2a.TaskStateWorking:       a2apb.TaskState_TASK_STATE_WORKING,
+	a2a.TaskStateCompleted:     a2apb.TaskState_TASK_STATE_COMPLETED,
+	a2a.TaskStateFailed:        a2apb.TaskState_TASK_STATE_FAILED,
+	a2a.TaskStateCanceled:      a2apb.TaskState_TASK_STATE_CANCELLED,
+	a2a.TaskStateInputRequired: a2apb.TaskState_TASK_STATE_INPUT_REQUIRED,
+	a2a.TaskStateRejected:      a2apb.TaskState_TASK_STATE_REJECTED,
+	a2a.TaskStateAuthRequired:  a2apb.TaskState_TASK_STATE_AUTH_REQUIRED,
+}
+
+func toProtoState(state a2a.TaskState) a2apb.TaskState {
+	return stateToProto[state]
+}
+
+func fromProtoState(state a2apb.TaskState) a2a.TaskState {
+	for k, v := range stateToProto {
+		if v == state {
+			return k
+		}
+	}
+	return a2a.TaskStateUnknown
+}
+
+func toProtoRole(role a2a.MessageRole) a2apb.Role {
+	switch role {
+	case a2a.MessageRoleUser:
+		return a2apb.Role_ROLE_USER
+	case a2a.MessageRoleAgent:
+		return a2apb.Role_ROLE_AGENT
+	default:
+		return a2apb.Role_ROLE_UNSPECIFIED
+	}
+}
+
+func fromProtoRole(role a2apb.Role) a2a.MessageRole {
+	switch role {
+	case a2apb.Role_ROLE_USER:
+		return a2a.MessageRoleUser
+	case a2apb.Role_ROLE_AGENT:
+		return a2a.MessageRoleAgent
+	default:
+		return ""
+	}
+}
+
+func toProtoParts(parts a2a.ContentParts) ([]*a2apb.Part, error) {
+	result := make([]*a2apb.Part, 0, len(parts))
+	for _, part := range parts {
+		pb, err := toProtoPart(part)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pb)
+	}
+	return result, nil
+}
+
+func toProtoPart(part a2a.Part) (*a2apb.Part, error) {
+	switch p := part.(type) {
+	case a2a.TextPart:
+		return &a2apb.Part{Part: &a2apb.Part_Text{Text: p.Text}}, nil
+	case a2a.DataPart:
+		data, err := toProtoStruct(p.Data)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			data = &structpb.Struct{}
+		}
+		return &a2apb.Part{Part: &a2apb.Part_Data{Data: &a2apb.DataPart{Data: data}}}, nil
+	case a2a.FilePart:
+		file, err := toProtoFile(p.File)
+		if err != nil {
+			return nil, err
+		}
+		return &a2apb.Part{Part: &a2apb.Part_File{File: file}}, nil
+	default:
+		return nil, fmt.Errorf("unknown part type %T", part)
+	}
+}
+
+func toProtoFile(content a2a.FilePartContent) (*a2apb.FilePart, error) {
+	switch f := content.(type) {
+	case a2a.FileURI:
+		return &a2apb.FilePart{MimeType: f.MimeType, File: &a2apb.FilePart_FileWithUri{FileWithUri: f.URI}}, nil
+	case a2a.FileBytes:
+		data, err := base64.StdEncoding.DecodeString(f.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file bytes: %w", err)
+		}
+		return &a2apb.FilePart{MimeType: f.MimeType, File: &a2apb.FilePart_FileWithBytes{FileWithBytes: data}}, nil
+	default:
+		return nil, fmt.Errorf("unknown file content type %T", content)
+	}
+}
+
+func fromProtoParts(parts []*a2apb.Part) (a2a.ContentParts, error) {
+	result := make(a2a.ContentParts, 0, len(parts))
+	for _, part := range parts {
+		converted, err := fromProtoPart(part)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+func fromProtoPart(part *a2apb.Part) (a2a.Part, error) {
+	switch p := part.GetPart().(type) {
+	case *a2apb.Part_Text:
+		return a2a.TextPart{Text: p.Text}, nil
+	case *a2apb.Part_Data:
+		data := fromProtoStruct(p.Data.GetData())
+		if data == nil {
+			data = map[string]any{}
+		}
+		return a2a.DataPart{Data: data}, nil
+	case *a2apb.Part_File:
+		meta := a2a.FileMeta{MimeType: p.File.GetMimeType()}
+		switch f := p.File.GetFile().(type) {
+		case *a2apb.FilePart_FileWithUri:
+			return a2a.FilePart{File: a2a.FileURI{FileMeta: meta, URI: f.FileWithUri}}, nil
+		case *a2apb.FilePart_FileWithBytes:
+			return a2a.FilePart{File: a2a.FileBytes{FileMeta: meta, Bytes: base64.StdEncoding.EncodeToString(f.FileWithBytes)}}, nil
+		default:
+			return nil, fmt.Errorf("unknown file content type %T", f)
+		}
+	default:
+		return nil, fmt.Errorf("unknown part type %T", p)
+	}
+}
+
+// toProtoStruct converts metadata to a Struct through JSON so that any JSON-serializable value
+// is supported, not only the ones structpb.NewValue accepts.
+func toProtoStruct(m map[string]any) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	result := &structpb.Struct{}
+	if err := result.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("failed to convert metadata: %w", err)
+	}
+	return result, nil
+}
+
+func fromProtoStruct(s *structpb.Struct) map[string]any {
+	if s == nil {
+		return nil
+	}
+	return s.AsMap()
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbconv
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2apb"
+)
+
+func TestTask_RoundTrip(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	task := &a2a.Task{
+		ID:        "task-1",
+		ContextID: "ctx-1",
+		Status: a2a.TaskStatus{
+			State:     a2a.TaskStateCanceled,
+			Timestamp: &ts,
+			Message:   &a2a.Message{ID: "m-0", Role: a2a.MessageRoleAgent, Parts: a2a.ContentParts{a2a.TextPart{Text: "bye"}}},
+		},
+		History: []*a2a.Message{{
+			ID:         "m-1",
+			ContextID:  "ctx-1",
+			TaskID:     "task-1",
+			Role:       a2a.MessageRoleUser,
+			Extensions: []string{"ext"},
+			Metadata:   map[string]any{"nested": map[string]any{"n": 1.0}},
+			Parts: a2a.ContentParts{
+				a2a.TextPart{Text: "hi"},
+				a2a.DataPart{Data: map[string]any{"list": []any{"a", true}}},
+				a2a.FilePart{File: a2a.FileBytes{FileMeta: a2a.FileMeta{MimeType: "text/plain"}, Bytes: "aGVsbG8="}},
+				a2a.FilePart{File: a2a.FileURI{FileMeta: a2a.FileMeta{MimeType: "image/png"}, URI: "https://example.com/a.png"}},
+			},
+		}},
+		Artifacts: []*a2a.Artifact{{
+			ID:          "a-1",
+			Name:        "result",
+			Description: "the result",
+			Parts:       a2a.ContentParts{a2a.TextPart{Text: "42"}},
+			Metadata:    map[string]any{"k": "v"},
+		}},
+		Metadata: map[string]any{"typed": []string{"converted", "through json"}},
+	}
+
+	pb, err := ToProtoTask(task)
+	if err != nil {
+		t.Fatalf("ToProtoTask() error = %v", err)
+	}
+	if pb.Status.State != a2apb.TaskState_TASK_STATE_CANCELLED {
+		t.Errorf("ToProtoTask() state = %v, want CANCELLED", pb.Status.State)
+	}
+	if got := pb.History[0].Content[2].GetFile().GetFileWithBytes(); string(got) != "hello" {
+		t.Errorf("ToProtoTask() file bytes = %q, want decoded content", got)
+	}
+
+	got, err := FromProtoTask(pb)
+	if err != nil {
+		t.Fatalf("FromProtoTask() error = %v", err)
+	}
+	want := *task
+	want.Metadata = map[string]any{"typed": []any{"converted", "through json"}}
+	if !reflect.DeepEqual(got, &want) {
+		t.Errorf("FromProtoTask(ToProtoTask()) = %+v, want %+v", got, &want)
+	}
+}
+
+func TestStreamResponse_RoundTrip(t *testing.T) {
+	events := []a2a.Event{
+		&a2a.Message{ID: "m-1", Role: a2a.MessageRoleAgent, Parts: a2a.ContentParts{}},
+		&a2a.TaskStatusUpdateEvent{TaskID: "t", ContextID: "c", Final: true, Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}},
+		&a2a.TaskArtifactUpdateEvent{
+			TaskID:    "t",
+			ContextID: "c",
+			Append:    true,
+			LastChunk: true,
+			Artifact:  &a2a.Artifact{ID: "a", Parts: a2a.ContentParts{a2a.TextPart{Text: "chunk"}}},
+		},
+	}
+	for _, event := range events {
+		pb, err := ToProtoStreamResponse(event)
+		if err != nil {
+			t.Fatalf("ToProtoStreamResponse(%T) error = %v", event, err)
+		}
+		got, err := FromProtoStreamResponse(pb)
+		if err != nil {
+			t.Fatalf("FromProtoStreamResponse(%T) error = %v", event, err)
+		}
+		if !reflect.DeepEqual(got, event) {
+			t.Errorf("FromProtoStreamResponse(ToProtoStreamResponse()) = %+v, want %+v", got, event)
+		}
+	}
+}
+
+func TestTaskPushConfig_RoundTrip(t *testing.T) {
+	config := a2a.TaskPushConfig{
+		TaskID: "task-1",
+		Config: a2a.PushConfig{
+			ID:    "cfg-1",
+			URL:   "https://example.com/push",
+			Token: "token",
+			Auth:  &a2a.PushAuthInfo{Schemes: []string{"Bearer"}, Credentials: "secret"},
+		},
+	}
+	pb := ToProtoTaskPushConfig(config)
+	if pb.Name != "tasks/task-1/pushNotificationConfigs/cfg-1" {
+		t.Errorf("ToProtoTaskPushConfig() name = %q", pb.Name)
+	}
+	got, err := FromProtoTaskPushConfig(pb)
+	if err != nil {
+		t.Fatalf("FromProtoTaskPushConfig() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, config) {
+		t.Errorf("FromProtoTaskPushConfig(ToProtoTaskPushConfig()) = %+v, want %+v", got, config)
+	}
+
+	pb.PushNotificationConfig.Id = ""
+	if got, err := FromProtoTaskPushConfig(pb); err != nil || got.Config.ID != "cfg-1" {
+		t.Errorf("FromProtoTaskPushConfig() = %+v, %v, want ID taken from the name", got, err)
+	}
+	if _, err := FromProtoTaskPushConfig(&a2apb.TaskPushNotificationConfig{Name: "configs/1"}); err == nil {
+		t.Error("FromProtoTaskPushConfig() error = nil for invalid name")
+	}
+}
+
+func TestAgentCard_RoundTrip(t *testing.T) {
+	card := &a2a.AgentCard{
+		ProtocolVersion:      "0.3.0",
+		Name:                 "agent",
+		Description:          "an agent",
+		URL:                  "https://example.com/a2a",
+		PreferredTransport:   a2a.TransportProtocolGRPC,
+		AdditionalInterfaces: []a2a.AgentInterface{{URL: "https://example.com/jsonrpc", Transport: "JSONRPC"}},
+		Provider:             &a2a.AgentProvider{URL: "https://example.com", Org: "Example"},
+		Version:              "1.0.0",
+		DocumentationURL:     "https://example.com/docs",
+		Capabilities: a2a.AgentCapabilities{
+			Streaming:         true,
+			PushNotifications: true,
+			Extensions:        []a2a.AgentExtension{{URI: "urn:ext", Required: true, Params: map[string]any{"p": "v"}}},
+		},
+		SecuritySchemes: a2a.NamedSecuritySchemes{
+			"key":    a2a.APIKeySecurityScheme{In: a2a.APIKeySecuritySchemeInHeader, Name: "X-Key"},
+			"bearer": a2a.HTTPAuthSecurityScheme{Scheme: "Bearer", BearerFormat: "JWT"},
+			"oidc":   a2a.OpenIDConnectSecurityScheme{OpenIDConnectURL: "https://example.com/.well-known/openid-configuration"},
+			"oauth": a2a.OAuth2SecurityScheme{Flows: a2a.OAuthFlows{ClientCredentials: &a2a.ClientCredentialsOAuthFlow{
+				TokenURL: "https://example.com/token",
+				Scopes:   map[string]string{"read": "read access"},
+			}}},
+		},
+		Security:                          []a2a.SecurityRequirements{{"oauth": {"read"}}},
+		DefaultInputModes:                 []string{"text/plain"},
+		DefaultOutputModes:                []string{"application/json"},
+		Skills:                            []a2a.AgentSkill{{ID: "s", Name: "skill", Tags: []string{"t"}, InputModes: []string{"text/plain"}}},
+		SupportsAuthenticatedExtendedCard: true,
+	}
+
+	pb, err := ToProtoAgentCard(card)
+	if err != nil {
+		t.Fatalf("ToProtoAgentCard() error = %v", err)
+	}
+	got, err := FromProtoAgentCard(pb)
+	if err != nil {
+		t.Fatalf("FromProtoAgentCard() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, card) {
+		t.Errorf("FromProtoAgentCard(ToProtoAgentCard()) = %+v, want %+v", got, card)
+	}
+
+	card.SecuritySchemes["mtls"] = a2a.MutualTLSSecurityScheme{}
+	if _, err := ToProtoAgentCard(card); err == nil {
+		t.Error("ToProtoAgentCard() error = nil for a mutual TLS scheme")
+	}
+}
+
+func TestParseTaskName(t *testing.T) {
+	testCases := []struct {
+		name    string
+		want    a2a.TaskID
+		wantErr bool
+	}{
+		{name: "tasks/abc", want: "abc"},
+		{name: "tasks/abc/pushNotificationConfigs/cfg", want: "abc"},
+		{name: "tasks/", wantErr: true},
+		{name: "abc", wantErr: true},
+	}
+	for _, tc := range testCases {
+		got, err := ParseTaskName(tc.name)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("ParseTaskName(%q) = %q, %v, want %q, error = %v", tc.name, got, err, tc.want, tc.wantErr)
+		}
+	}
+}
@@ -14,4 +14,163 @@
 
 package push
 
-type HTTPPushSender struct{}
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultTokenHeader is the header used for passing PushConfig.Token to the webhook.
+	DefaultTokenHeader = "X-A2A-Notification-Token"
+	// NonceHeader and TimestampHeader carry a unique notification nonce and the sending time in seconds
+	// since Unix epoch, which receivers use for rejecting replayed notifications.
+	NonceHeader     = "X-A2A-Notification-Nonce"
+	TimestampHeader = "X-A2A-Notification-Timestamp"
+
+	defaultTimeout    = 10 * time.Second
+	defaultMaxRetries = 3
+	defaultBackoff    = 500 * time.Millisecond
+	maxBackoff        = 30 * time.Second
+)
+
+// ErrUnsupportedAuthScheme is returned if none of the authentication schemes requested by a PushConfig
+// are supported by HTTPPushSender.
+var ErrUnsupportedAuthScheme = errors.New("unsupported push authentication scheme")
+
+// HTTPPushSender delivers Task snapshots to webhooks with JSON POST requests. Requests which failed
+// because of network errors, 5xx or 429 responses are retried with exponential backoff.
+// The zero value is ready to use.
+type HTTPPushSender struct {
+	// Client is used for making requests. http.DefaultClient is used if not set.
+	Client *http.Client
+	// Timeout limits the duration of a single delivery attempt. Defaults to 10 seconds.
+	Timeout time.Duration
+	// MaxRetries is the number of repeated attempts after the first one failed. Defaults to 3,
+	// a negative value disables retries.
+	MaxRetries int
+	// Backoff is the delay before the first retry, doubled after every attempt. Defaults to 500ms.
+	Backoff time.Duration
+	// TokenHeader is the header PushConfig.Token is passed in. Defaults to DefaultTokenHeader.
+	TokenHeader string
+}
+
+// Send delivers the Task to the webhook described by the config. All attempts of a delivery carry the same
+// nonce and the time of the attempt. Neither is signed, so they only let a receiver reject replays of requests
+// which are authenticated with the token or the Authorization header. A receiver which rejects seen nonces must
+// treat a retry with the same nonce as a redelivery: if it failed to handle the first attempt, it has to forget
+// the nonce, as a2aclient.PushReceiver does, or the retry is rejected and the notification is lost.
+func (s *HTTPPushSender) Send(ctx context.Context, config a2a.PushConfig, task a2a.Task) error {
+	body, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to serialize task: %w", err)
+	}
+
+	maxRetries := s.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = defaultMaxRetries
+	}
+	backoff := s.Backoff
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+
+	nonce := uuid.NewString()
+	for attempt := 0; ; attempt++ {
+		retryable, err := s.attempt(ctx, config, nonce, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= maxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w, last error: %w", ctx.Err(), err)
+		case <-timer.C:
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+// attempt makes a single delivery request. The returned bool reports whether a failure can be retried.
+func (s *HTTPPushSender) attempt(ctx context.Context, config a2a.PushConfig, nonce string, body []byte) (bool, error) {
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(NonceHeader, nonce)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(time.Now().Unix(), 10))
+	if config.Token != "" {
+		header := s.TokenHeader
+		if header == "" {
+			header = DefaultTokenHeader
+		}
+		req.Header.Set(header, config.Token)
+	}
+	if err := authorize(req, config.Auth); err != nil {
+		return false, err
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("push request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retryable, fmt.Errorf("push request failed with status %s", resp.Status)
+}
+
+// authorize sets the Authorization header using the first scheme from the list which is supported.
+func authorize(req *http.Request, auth *a2a.PushAuthInfo) error {
+	if auth == nil || len(auth.Schemes) == 0 {
+		return nil
+	}
+	for _, scheme := range auth.Schemes {
+		switch strings.ToLower(scheme) {
+		case "bearer":
+			req.Header.Set("Authorization", "Bearer "+auth.Credentials)
+			return nil
+		case "basic":
+			credentials := auth.Credentials
+			// Credentials can be provided either encoded or as a plain "user:password" pair.
+			if strings.Contains(credentials, ":") {
+				credentials = base64.StdEncoding.EncodeToString([]byte(credentials))
+			}
+			req.Header.Set("Authorization", "Basic "+credentials)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedAuthScheme, strings.Join(auth.Schemes, ", "))
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/a2aproject/a2a-go/a2acrypto"
+)
+
+func TestHTTPPushSender_Send(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name       string
+		config     a2a.PushConfig
+		wantHeader string
+		wantValue  string
+	}{
+		{
+			name:       "token",
+			config:     a2a.PushConfig{Token: "secret"},
+			wantHeader: DefaultTokenHeader,
+			wantValue:  "secret",
+		},
+		{
+			name:       "bearer",
+			config:     a2a.PushConfig{Auth: &a2a.PushAuthInfo{Schemes: []string{"Bearer"}, Credentials: "token"}},
+			wantHeader: "Authorization",
+			wantValue:  "Bearer token",
+		},
+		{
+			name:       "basic with plain credentials",
+			config:     a2a.PushConfig{Auth: &a2a.PushAuthInfo{Schemes: []string{"Digest", "basic"}, Credentials: "user:pass"}},
+			wantHeader: "Authorization",
+			wantValue:  "Basic dXNlcjpwYXNz",
+		},
+		{
+			name:       "basic with encoded credentials",
+			config:     a2a.PushConfig{Auth: &a2a.PushAuthInfo{Schemes: []string{"Basic"}, Credentials: "dXNlcjpwYXNz"}},
+			wantHeader: "Authorization",
+			wantValue:  "Basic dXNlcjpwYXNz",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var gotHeader string
+			var gotTask a2a.Task
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotHeader = r.Header.Get(tc.wantHeader)
+				if err := json.NewDecoder(r.Body).Decode(&gotTask); err != nil {
+					t.Errorf("failed to decode task: %v", err)
+				}
+			}))
+			defer server.Close()
+
+			config := tc.config
+			config.URL = server.URL
+			task := a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+			sender := &HTTPPushSender{}
+			if err := sender.Send(t.Context(), config, task); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			if gotHeader != tc.wantValue {
+				t.Fatalf("got %s = %q, want %q", tc.wantHeader, gotHeader, tc.wantValue)
+			}
+			if gotTask.ID != task.ID || gotTask.Status.State != task.Status.State {
+				t.Fatalf("webhook received %+v, want %+v", gotTask, task)
+			}
+		})
+	}
+}
+
+func TestHTTPPushSender_Retries(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name         string
+		statuses     []int
+		maxRetries   int
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{name: "success after server errors", statuses: []int{500, 429, 200}, wantAttempts: 3},
+		{name: "retries exhausted", statuses: []int{503, 503, 503}, maxRetries: 2, wantAttempts: 3, wantErr: true},
+		{name: "retries disabled", statuses: []int{503, 200}, maxRetries: -1, wantAttempts: 1, wantErr: true},
+		{name: "client error not retried", statuses: []int{400, 200}, wantAttempts: 1, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				w.WriteHeader(tc.statuses[min(int(n), len(tc.statuses))-1])
+			}))
+			defer server.Close()
+
+			sender := &HTTPPushSender{MaxRetries: tc.maxRetries, Backoff: time.Millisecond}
+			err := sender.Send(t.Context(), a2a.PushConfig{URL: server.URL}, a2a.Task{ID: "task-1"})
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("Send() error = %v, want error %v", err, tc.wantErr)
+			}
+			if got := attempts.Load(); got != tc.wantAttempts {
+				t.Fatalf("got %d attempts, want %d", got, tc.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestHTTPPushSender_UnsupportedAuthScheme(t *testing.T) {
+	t.Parallel()
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+	}))
+	defer server.Close()
+
+	config := a2a.PushConfig{URL: server.URL, Auth: &a2a.PushAuthInfo{Schemes: []string{"Digest"}}}
+	err := (&HTTPPushSender{}).Send(t.Context(), config, a2a.Task{ID: "task-1"})
+	if !errors.Is(err, ErrUnsupportedAuthScheme) {
+		t.Fatalf("Send() error = %v, want ErrUnsupportedAuthScheme", err)
+	}
+	if attempts.Load() != 0 {
+		t.Fatal("request was made with unsupported auth scheme")
+	}
+}
+
+func TestHTTPPushSender_Timeout(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	sender := &HTTPPushSender{Timeout: 10 * time.Millisecond, MaxRetries: -1}
+	if err := sender.Send(t.Context(), a2a.PushConfig{URL: server.URL}, a2a.Task{ID: "task-1"}); err == nil {
+		t.Fatal("Send() succeeded, want timeout error")
+	}
+}
+
+func TestHTTPPushSender_NonceAndTimestamp(t *testing.T) {
+	t.Parallel()
+	var nonces []string
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonces = append(nonces, r.Header.Get(NonceHeader))
+		seconds, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+		if err != nil || time.Since(time.Unix(seconds, 0)) > time.Minute {
+			t.Errorf("got %s = %q, want the current time", TimestampHeader, r.Header.Get(TimestampHeader))
+		}
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	sender := &HTTPPushSender{Backoff: time.Millisecond}
+	for range 2 {
+		if err := sender.Send(t.Context(), a2a.PushConfig{URL: server.URL}, a2a.Task{ID: "task-1"}); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	if len(nonces) != 3 || nonces[0] == "" {
+		t.Fatalf("got nonces %v, want 3 non-empty nonces", nonces)
+	}
+	if nonces[0] != nonces[1] {
+		t.Fatalf("retry nonce %q differs from the first attempt nonce %q", nonces[1], nonces[0])
+	}
+	if nonces[1] == nonces[2] {
+		t.Fatalf("different notifications share nonce %q", nonces[1])
+	}
+}
+
+func TestHTTPPushSender_PushReceiver(t *testing.T) {
+	t.Parallel()
+	var received []a2a.TaskState
+	receiver := &a2aclient.PushReceiver{
+		Token:  "secret",
+		Replay: &a2acrypto.ReplayGuard{Cache: a2acrypto.NewMemNonceCache()},
+		Handler: func(ctx context.Context, task *a2a.Task) error {
+			received = append(received, task.Status.State)
+			return nil
+		},
+	}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	sender := &HTTPPushSender{MaxRetries: -1}
+	config := a2a.PushConfig{URL: server.URL, Token: "secret"}
+	for _, state := range []a2a.TaskState{a2a.TaskStateWorking, a2a.TaskStateCompleted} {
+		now := time.Now()
+		task := a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: state, Timestamp: &now}}
+		if err := sender.Send(t.Context(), config, task); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	want := []a2a.TaskState{a2a.TaskStateWorking, a2a.TaskStateCompleted}
+	if !slices.Equal(received, want) {
+		t.Fatalf("receiver handled %v, want %v", received, want)
+	}
+}
@@ -14,4 +14,84 @@
 
 package push
 
-type InMemoryPushConfigStore struct{}
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// InMemoryPushConfigStore keeps copies of push configs in memory. A Task can have multiple configs
+// which are identified by PushConfig.ID and returned in the order they were first saved.
+type InMemoryPushConfigStore struct {
+	mu      sync.RWMutex
+	configs map[a2a.TaskID][]a2a.PushConfig
+}
+
+// NewInMemoryPushConfigStore creates an empty InMemoryPushConfigStore.
+func NewInMemoryPushConfigStore() *InMemoryPushConfigStore {
+	return &InMemoryPushConfigStore{configs: make(map[a2a.TaskID][]a2a.PushConfig)}
+}
+
+// Save adds a config or replaces the config with the same ID. A config without an ID is stored
+// under the Task ID, which makes it the default config of the Task.
+func (s *InMemoryPushConfigStore) Save(ctx context.Context, taskId a2a.TaskID, config a2a.PushConfig) error {
+	config = copyConfig(config)
+	if config.ID == "" {
+		config.ID = string(taskId)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	configs := s.configs[taskId]
+	if i := slices.IndexFunc(configs, func(c a2a.PushConfig) bool { return c.ID == config.ID }); i >= 0 {
+		configs[i] = config
+		return nil
+	}
+	s.configs[taskId] = append(configs, config)
+	return nil
+}
+
+// Get returns copies of the configs registered for the Task. An empty list is returned for unknown Tasks.
+func (s *InMemoryPushConfigStore) Get(ctx context.Context, taskId a2a.TaskID) ([]a2a.PushConfig, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	configs := s.configs[taskId]
+	result := make([]a2a.PushConfig, len(configs))
+	for i, config := range configs {
+		result[i] = copyConfig(config)
+	}
+	return result, nil
+}
+
+// Delete removes the config with the given ID. Deleting a config which doesn't exist is not an error.
+func (s *InMemoryPushConfigStore) Delete(ctx context.Context, taskId a2a.TaskID, configID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	configs := slices.DeleteFunc(s.configs[taskId], func(c a2a.PushConfig) bool { return c.ID == configID })
+	if len(configs) == 0 {
+		delete(s.configs, taskId)
+	} else {
+		s.configs[taskId] = configs
+	}
+	return nil
+}
+
+// DeleteAll removes all configs of the Task.
+func (s *InMemoryPushConfigStore) DeleteAll(ctx context.Context, taskId a2a.TaskID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.configs, taskId)
+	return nil
+}
+
+// copyConfig makes a copy which doesn't share the authentication details with the original.
+func copyConfig(config a2a.PushConfig) a2a.PushConfig {
+	if config.Auth != nil {
+		auth := *config.Auth
+		auth.Schemes = slices.Clone(auth.Schemes)
+		config.Auth = &auth
+	}
+	return config
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func mustGet(t *testing.T, store *InMemoryPushConfigStore, taskID a2a.TaskID) []a2a.PushConfig {
+	t.Helper()
+	configs, err := store.Get(t.Context(), taskID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	return configs
+}
+
+func configIDs(configs []a2a.PushConfig) []string {
+	ids := make([]string, len(configs))
+	for i, config := range configs {
+		ids[i] = config.ID
+	}
+	return ids
+}
+
+func TestInMemoryPushConfigStore_MultipleConfigs(t *testing.T) {
+	ctx := t.Context()
+	store := NewInMemoryPushConfigStore()
+	for _, config := range []a2a.PushConfig{
+		{ID: "first", URL: "https://example.com/1"},
+		{URL: "https://example.com/default"},
+		{ID: "second", URL: "https://example.com/2"},
+		{ID: "first", URL: "https://example.com/updated"},
+	} {
+		if err := store.Save(ctx, "task-1", config); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	if err := store.Save(ctx, "task-2", a2a.PushConfig{ID: "other"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	configs := mustGet(t, store, "task-1")
+	if got, want := configIDs(configs), []string{"first", "task-1", "second"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Get() returned configs %v, want %v", got, want)
+	}
+	if configs[0].URL != "https://example.com/updated" {
+		t.Fatalf("Save() didn't replace the config with the same ID, got URL %s", configs[0].URL)
+	}
+	if got := mustGet(t, store, "unknown"); len(got) != 0 {
+		t.Fatalf("Get() = %v for an unknown task, want empty", got)
+	}
+}
+
+func TestInMemoryPushConfigStore_Delete(t *testing.T) {
+	ctx := t.Context()
+	store := NewInMemoryPushConfigStore()
+	for _, id := range []string{"a", "b", "c"} {
+		if err := store.Save(ctx, "task-1", a2a.PushConfig{ID: id}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	if err := store.Save(ctx, "task-2", a2a.PushConfig{ID: "a"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if err := store.Delete(ctx, "task-1", "b"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "task-1", "missing"); err != nil {
+		t.Fatalf("Delete() error = %v for a missing config", err)
+	}
+	if got, want := configIDs(mustGet(t, store, "task-1")), []string{"a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Get() after Delete() = %v, want %v", got, want)
+	}
+
+	if err := store.DeleteAll(ctx, "task-1"); err != nil {
+		t.Fatalf("DeleteAll() error = %v", err)
+	}
+	if got := mustGet(t, store, "task-1"); len(got) != 0 {
+		t.Fatalf("Get() after DeleteAll() = %v, want empty", got)
+	}
+	if got := mustGet(t, store, "task-2"); len(got) != 1 {
+		t.Fatalf("DeleteAll() removed configs of another task, got %v", got)
+	}
+}
+
+func TestInMemoryPushConfigStore_StoredImmutability(t *testing.T) {
+	ctx := t.Context()
+	store := NewInMemoryPushConfigStore()
+	config := a2a.PushConfig{ID: "cfg", Auth: &a2a.PushAuthInfo{Schemes: []string{"Bearer"}, Credentials: "secret"}}
+	if err := store.Save(ctx, "task-1", config); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	config.Auth.Schemes[0] = "Basic"
+	config.Auth.Credentials = "changed"
+
+	got := mustGet(t, store, "task-1")
+	got[0].Auth.Schemes[0] = "Digest"
+
+	stored := mustGet(t, store, "task-1")[0]
+	if stored.Auth.Schemes[0] != "Bearer" || stored.Auth.Credentials != "secret" {
+		t.Fatalf("stored config was modified through a shared reference: %+v", stored.Auth)
+	}
+}
@@ -21,12 +21,14 @@ import (
 	"sync"
 
 	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
 )
 
 // Mem stores deep-copied Tasks in memory.
 type Mem struct {
-	mu    sync.RWMutex
-	tasks map[a2a.TaskID]*a2a.Task
+	mu       sync.RWMutex
+	tasks    map[a2a.TaskID]*a2a.Task
+	versions map[a2a.TaskID]taskupdate.TaskVersion
 }
 
 func init() {
@@ -37,7 +39,8 @@ func init() {
 // NewMem creates an empty Mem store.
 func NewMem() *Mem {
 	return &Mem{
-		tasks: make(map[a2a.TaskID]*a2a.Task),
+		tasks:    make(map[a2a.TaskID]*a2a.Task),
+		versions: make(map[a2a.TaskID]taskupdate.TaskVersion),
 	}
 }
 
@@ -46,18 +49,58 @@ func (s *Mem) Save(ctx context.Context, task *a2a.Task) error {
 		return err
 	}
 
-	copy, err := deepCopy(task)
+	copy, err := DeepCopy(task)
 	if err != nil {
 		return err
 	}
 
 	s.mu.Lock()
 	s.tasks[task.ID] = copy
+	s.versions[task.ID]++
 	s.mu.Unlock()
 
 	return nil
 }
 
+// SaveVersioned implements taskupdate.VersionedSaver.
+func (s *Mem) SaveVersioned(ctx context.Context, task *a2a.Task, prev taskupdate.TaskVersion) (taskupdate.TaskVersion, error) {
+	if err := validateTask(task); err != nil {
+		return 0, err
+	}
+
+	copy, err := DeepCopy(task)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.versions[task.ID] != prev {
+		return 0, taskupdate.ErrConcurrentModification
+	}
+	s.tasks[task.ID] = copy
+	s.versions[task.ID]++
+	return s.versions[task.ID], nil
+}
+
+// GetVersioned implements taskupdate.VersionedSaver.
+func (s *Mem) GetVersioned(ctx context.Context, taskId a2a.TaskID) (*a2a.Task, taskupdate.TaskVersion, error) {
+	s.mu.RLock()
+	task, ok := s.tasks[taskId]
+	version := s.versions[taskId]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, 0, a2a.ErrTaskNotFound
+	}
+
+	copy, err := DeepCopy(task)
+	if err != nil {
+		return nil, 0, err
+	}
+	return copy, version, nil
+}
+
 func (s *Mem) Get(ctx context.Context, taskId a2a.TaskID) (*a2a.Task, error) {
 	s.mu.RLock()
 	task, ok := s.tasks[taskId]
@@ -67,11 +110,75 @@ func (s *Mem) Get(ctx context.Context, taskId a2a.TaskID) (*a2a.Task, error) {
 		return nil, a2a.ErrTaskNotFound
 	}
 
-	return deepCopy(task)
+	return DeepCopy(task)
+}
+
+// SaveAll stores all the tasks at once. No task is stored if any of them is invalid.
+func (s *Mem) SaveAll(ctx context.Context, tasks []*a2a.Task) error {
+	copies := make([]*a2a.Task, len(tasks))
+	for i, task := range tasks {
+		if err := validateTask(task); err != nil {
+			return err
+		}
+		copy, err := DeepCopy(task)
+		if err != nil {
+			return err
+		}
+		copies[i] = copy
+	}
+
+	s.mu.Lock()
+	for _, copy := range copies {
+		s.tasks[copy.ID] = copy
+		s.versions[copy.ID]++
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// GetAll retrieves the tasks with the provided IDs. Missing tasks are omitted from the result.
+func (s *Mem) GetAll(ctx context.Context, taskIds []a2a.TaskID) (map[a2a.TaskID]*a2a.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[a2a.TaskID]*a2a.Task, len(taskIds))
+	for _, id := range taskIds {
+		task, ok := s.tasks[id]
+		if !ok {
+			continue
+		}
+		copy, err := DeepCopy(task)
+		if err != nil {
+			return nil, err
+		}
+		result[id] = copy
+	}
+	return result, nil
+}
+
+// MemStats is a snapshot of the Mem store state reported for debugging.
+type MemStats struct {
+	// Tasks is the total number of stored Tasks.
+	Tasks int `json:"tasks"`
+	// ByState is the number of stored Tasks in every state.
+	ByState map[a2a.TaskState]int `json:"byState"`
+}
+
+// Stats returns MemStats of the store.
+func (s *Mem) Stats(ctx context.Context) (any, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stats := MemStats{Tasks: len(s.tasks), ByState: make(map[a2a.TaskState]int)}
+	for _, task := range s.tasks {
+		stats.ByState[task.Status.State]++
+	}
+	return stats, nil
 }
 
-// Copy to keep a saved Task unchanged until an explicit Save.
-func deepCopy(task *a2a.Task) (*a2a.Task, error) {
+// DeepCopy returns a copy of the task which doesn't share any mutable state with the original.
+// Stores use it to keep a saved Task unchanged until an explicit Save.
+func DeepCopy(task *a2a.Task) (*a2a.Task, error) {
 	var buf bytes.Buffer
 	enc := gob.NewEncoder(&buf)
 	dec := gob.NewDecoder(&buf)
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/internal/taskupdate"
 )
 
 func mustSave(t *testing.T, store *Mem, task *a2a.Task) {
@@ -106,3 +107,103 @@ func TestInMemoryTaskStore_TaskNotFound(t *testing.T) {
 		t.Fatalf("Unexpected error: got: %v, wanted ErrTaskNotFound", err)
 	}
 }
+
+func TestInMemoryTaskStore_Stats(t *testing.T) {
+	store := NewMem()
+	mustSave(t, store, &a2a.Task{ID: a2a.NewTaskID(), ContextID: "id", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}})
+	mustSave(t, store, &a2a.Task{ID: a2a.NewTaskID(), ContextID: "id", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}})
+	mustSave(t, store, &a2a.Task{ID: a2a.NewTaskID(), ContextID: "id", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}})
+
+	got, err := store.Stats(t.Context())
+	if err != nil {
+		t.Fatalf("Stats() error: %v", err)
+	}
+	want := MemStats{Tasks: 3, ByState: map[a2a.TaskState]int{a2a.TaskStateWorking: 2, a2a.TaskStateCompleted: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Stats() = %v, want %v", got, want)
+	}
+}
+
+func TestInMemoryTaskStore_Batch(t *testing.T) {
+	store := NewMem()
+	tasks := []*a2a.Task{
+		{ID: a2a.NewTaskID(), ContextID: "id"},
+		{ID: a2a.NewTaskID(), ContextID: "id"},
+	}
+	if err := store.SaveAll(t.Context(), tasks); err != nil {
+		t.Fatalf("SaveAll() error: %v", err)
+	}
+
+	got, err := store.GetAll(t.Context(), []a2a.TaskID{tasks[0].ID, "missing", tasks[1].ID})
+	if err != nil {
+		t.Fatalf("GetAll() error: %v", err)
+	}
+	if len(got) != 2 || got[tasks[0].ID].ID != tasks[0].ID || got[tasks[1].ID].ID != tasks[1].ID {
+		t.Fatalf("GetAll() = %v, want both saved tasks", got)
+	}
+}
+
+func TestInMemoryTaskStore_SaveAllInvalid(t *testing.T) {
+	store := NewMem()
+	valid := &a2a.Task{ID: a2a.NewTaskID(), ContextID: "id"}
+	invalid := &a2a.Task{ID: a2a.NewTaskID(), Metadata: map[string]any{"hello": forbiddenType{}}}
+	if err := store.SaveAll(t.Context(), []*a2a.Task{valid, invalid}); err == nil {
+		t.Fatal("SaveAll() error = nil, want error for an invalid task")
+	}
+	if _, err := store.Get(t.Context(), valid.ID); !errors.Is(err, a2a.ErrTaskNotFound) {
+		t.Fatalf("Get() error = %v, want %v because no task is saved from an invalid batch", err, a2a.ErrTaskNotFound)
+	}
+}
+
+func TestInMemoryTaskStore_SaveVersioned(t *testing.T) {
+	store := NewMem()
+	task := &a2a.Task{ID: a2a.NewTaskID(), ContextID: "id"}
+
+	v1, err := store.SaveVersioned(t.Context(), task, 0)
+	if err != nil {
+		t.Fatalf("SaveVersioned() of a new task error: %v", err)
+	}
+	if _, err := store.SaveVersioned(t.Context(), task, 0); !errors.Is(err, taskupdate.ErrConcurrentModification) {
+		t.Fatalf("SaveVersioned() with a stale version error = %v, want %v", err, taskupdate.ErrConcurrentModification)
+	}
+	v2, err := store.SaveVersioned(t.Context(), task, v1)
+	if err != nil {
+		t.Fatalf("SaveVersioned() error: %v", err)
+	}
+
+	mustSave(t, store, task)
+	_, got, err := store.GetVersioned(t.Context(), task.ID)
+	if err != nil {
+		t.Fatalf("GetVersioned() error: %v", err)
+	}
+	if got <= v2 {
+		t.Fatalf("GetVersioned() version = %d, want unversioned Save to advance it past %d", got, v2)
+	}
+}
+
+type locationPart struct {
+	a2a.CustomPartBase
+	City string
+}
+
+func (locationPart) PartKind() string     { return "x-location" }
+func (locationPart) Meta() map[string]any { return nil }
+
+func TestInMemoryTaskStore_CustomPart(t *testing.T) {
+	err := a2a.RegisterPartKind(locationPart{}, a2a.PartCodec{
+		Decode: func(data []byte) (a2a.CustomPart, error) { return locationPart{}, nil },
+	})
+	if err != nil {
+		t.Fatalf("RegisterPartKind() error = %v", err)
+	}
+	store := NewMem()
+
+	artifact := &a2a.Artifact{ID: "artifact", Parts: a2a.ContentParts{locationPart{City: "Lisbon"}}}
+	task := &a2a.Task{ID: a2a.NewTaskID(), Artifacts: []*a2a.Artifact{artifact}}
+	mustSave(t, store, task)
+
+	got := mustGet(t, store, task.ID)
+	if !reflect.DeepEqual(got.Artifacts, task.Artifacts) {
+		t.Fatalf("Artifacts mismatch: got = %v, want = %v", got.Artifacts, task.Artifacts)
+	}
+}
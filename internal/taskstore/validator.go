@@ -74,6 +74,9 @@ func validateParts(parts a2a.ContentParts) error {
 		return nil
 	}
 	for _, p := range parts {
+		if err := a2a.ValidatePart(p); err != nil {
+			return err
+		}
 		if err := validateMeta(p.Meta()); err != nil {
 			return err
 		}
@@ -16,21 +16,58 @@ package taskupdate
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/a2aproject/a2a-go/a2a"
 )
 
+// ErrConcurrentModification is returned by VersionedSaver when the stored Task was updated after
+// the version the caller based its changes on. The operation can be retried after reloading the Task.
+var ErrConcurrentModification = errors.New("task was modified concurrently")
+
+// ErrTaskTerminal is returned by Manager for events which would update a Task in a terminal state.
+var ErrTaskTerminal = errors.New("task is in a terminal state")
+
+// TaskVersion identifies a stored Task state. Zero means the Task was not stored yet.
+type TaskVersion int64
+
+// maxConflictRetries limits the number of times an event is reapplied to a reloaded Task.
+const maxConflictRetries = 3
+
 // Saver is used for saving the Task after updating its state.
 type Saver interface {
 	Save(ctx context.Context, task *a2a.Task) error
 }
 
+// VersionedSaver is an optional interface of Saver implementations supporting optimistic concurrency
+// control. Manager uses it to detect concurrent updates from other replicas instead of overwriting them.
+type VersionedSaver interface {
+	Saver
+
+	// SaveVersioned stores the task only if the stored version is equal to prev and returns the new version.
+	// Returns ErrConcurrentModification otherwise.
+	SaveVersioned(ctx context.Context, task *a2a.Task, prev TaskVersion) (TaskVersion, error)
+
+	// GetVersioned returns the stored Task together with its version.
+	GetVersioned(ctx context.Context, taskId a2a.TaskID) (*a2a.Task, TaskVersion, error)
+}
+
 // Manager is used for processing a2a.Event related to a Task. It updates
 // the Task accordingly and uses Saver to store the new state.
 type Manager struct {
-	Task  *a2a.Task
-	saver Saver
+	Task *a2a.Task
+	// Version is the stored version of Task. Used and updated if Saver implements VersionedSaver.
+	Version TaskVersion
+	saver   Saver
+	tx      *transaction
+}
+
+// transaction collects events applied to the Task which are not saved yet.
+type transaction struct {
+	events []a2a.Event
 }
 
 // NewManager creates an initialized update Manager for the provided task.
@@ -39,20 +76,109 @@ func NewManager(saver Saver, task *a2a.Task) *Manager {
 }
 
 // Process validates that the event is associated with the managed Task and updates the Task accordingly.
+// If the Task was concurrently modified, the latest version is reloaded and the event is reapplied to it.
+// Inside WithTransaction the updated Task is saved when the transaction is committed.
 func (mgr *Manager) Process(ctx context.Context, event a2a.Event) error {
+	if mgr.tx != nil {
+		if err := mgr.process(ctx, event); err != nil {
+			return err
+		}
+		mgr.tx.events = append(mgr.tx.events, event)
+		return nil
+	}
+	return mgr.retryOnConflict(ctx, func() error {
+		return mgr.process(ctx, event)
+	})
+}
+
+// WithTransaction groups all events processed by fn into a single Save, eg. an artifact chunk together
+// with a status change. This reduces the number of writes and doesn't expose partially updated Tasks
+// to readers of persistent stores. If fn returns an error nothing is saved and the Task is restored
+// to the state before the transaction. On concurrent modification all the events are reapplied
+// to the reloaded Task.
+func (mgr *Manager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mgr.tx != nil {
+		return fmt.Errorf("nested transactions are not supported")
+	}
+	if mgr.Task == nil {
+		return fmt.Errorf("event processor Task not set")
+	}
+
+	snapshot := *mgr.Task
+	snapshot.Metadata = maps.Clone(mgr.Task.Metadata)
+	tx := &transaction{}
+	mgr.tx = tx
+	err := fn(ctx)
+	mgr.tx = nil
+	if err != nil {
+		mgr.Task = &snapshot
+		return err
+	}
+	if len(tx.events) == 0 {
+		return nil
+	}
+
+	reloaded := false
+	return mgr.retryOnConflict(ctx, func() error {
+		if reloaded {
+			if err := mgr.replay(ctx, tx.events); err != nil {
+				return err
+			}
+		}
+		reloaded = true
+		return mgr.save(ctx, mgr.Task)
+	})
+}
+
+// replay applies events to the Task without saving it.
+func (mgr *Manager) replay(ctx context.Context, events []a2a.Event) error {
+	mgr.tx = &transaction{}
+	defer func() { mgr.tx = nil }()
+	for _, event := range events {
+		if err := mgr.process(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retryOnConflict reloads the Task and calls apply again if it failed with ErrConcurrentModification.
+func (mgr *Manager) retryOnConflict(ctx context.Context, apply func() error) error {
+	versioned, ok := mgr.saver.(VersionedSaver)
+	if !ok {
+		return apply()
+	}
+	for attempt := 0; ; attempt++ {
+		err := apply()
+		if !errors.Is(err, ErrConcurrentModification) || attempt >= maxConflictRetries {
+			return err
+		}
+		task, version, err := versioned.GetVersioned(ctx, mgr.Task.ID)
+		if err != nil {
+			return fmt.Errorf("failed to reload concurrently modified task: %w", err)
+		}
+		mgr.Task, mgr.Version = task, version
+	}
+}
+
+func (mgr *Manager) process(ctx context.Context, event a2a.Event) error {
 	if mgr.Task == nil {
 		return fmt.Errorf("event processor Task not set")
 	}
 
 	switch v := event.(type) {
-	case *a2a.Message:
+	case *a2a.Message, a2a.CustomEvent:
 		return nil
 
 	case *a2a.Task:
 		if err := mgr.validate(v.ID, v.ContextID); err != nil {
 			return err
 		}
-		if err := mgr.saver.Save(ctx, v); err != nil {
+		// a snapshot can amend a terminal Task, eg. with the final message, but not change its state
+		if mgr.Task.Status.State.Terminal() && v.Status.State != mgr.Task.Status.State {
+			return fmt.Errorf("%w: task is %s", ErrTaskTerminal, mgr.Task.Status.State)
+		}
+		if err := mgr.save(ctx, v); err != nil {
 			return err
 		}
 		mgr.Task = v
@@ -62,12 +188,18 @@ func (mgr *Manager) Process(ctx context.Context, event a2a.Event) error {
 		if err := mgr.validate(v.TaskID, v.ContextID); err != nil {
 			return err
 		}
+		if err := mgr.checkNotTerminal(); err != nil {
+			return err
+		}
 		return mgr.updateArtifact(ctx, v)
 
 	case *a2a.TaskStatusUpdateEvent:
 		if err := mgr.validate(v.TaskID, v.ContextID); err != nil {
 			return err
 		}
+		if err := mgr.checkNotTerminal(); err != nil {
+			return err
+		}
 		return mgr.updateStatus(ctx, v)
 
 	default:
@@ -75,8 +207,39 @@ func (mgr *Manager) Process(ctx context.Context, event a2a.Event) error {
 	}
 }
 
-func (mgr *Manager) updateArtifact(_ context.Context, _ *a2a.TaskArtifactUpdateEvent) error {
-	return fmt.Errorf("not implemented")
+func (mgr *Manager) updateArtifact(ctx context.Context, event *a2a.TaskArtifactUpdateEvent) error {
+	update := event.Artifact
+	if update == nil {
+		return fmt.Errorf("artifact update event is missing an artifact")
+	}
+
+	task := mgr.Task
+	for i, artifact := range task.Artifacts {
+		if artifact.ID != update.ID {
+			continue
+		}
+		if !event.Append {
+			task.Artifacts[i] = update
+			return mgr.save(ctx, task)
+		}
+		merged := *artifact
+		merged.Parts = append(slices.Clone(artifact.Parts), update.Parts...)
+		if update.Metadata != nil {
+			merged.Metadata = maps.Clone(artifact.Metadata)
+			if merged.Metadata == nil {
+				merged.Metadata = make(map[string]any)
+			}
+			maps.Copy(merged.Metadata, update.Metadata)
+		}
+		task.Artifacts[i] = &merged
+		return mgr.save(ctx, task)
+	}
+
+	if event.Append {
+		return fmt.Errorf("no artifact %s to append to", update.ID)
+	}
+	task.Artifacts = append(task.Artifacts, update)
+	return mgr.save(ctx, task)
 }
 
 func (mgr *Manager) updateStatus(ctx context.Context, event *a2a.TaskStatusUpdateEvent) error {
@@ -97,7 +260,24 @@ func (mgr *Manager) updateStatus(ctx context.Context, event *a2a.TaskStatusUpdat
 
 	task.Status = event.Status
 
-	return mgr.saver.Save(ctx, task)
+	return mgr.save(ctx, task)
+}
+
+func (mgr *Manager) save(ctx context.Context, task *a2a.Task) error {
+	if mgr.tx != nil {
+		// saved on commit
+		return nil
+	}
+	versioned, ok := mgr.saver.(VersionedSaver)
+	if !ok {
+		return mgr.saver.Save(ctx, task)
+	}
+	version, err := versioned.SaveVersioned(ctx, task, mgr.Version)
+	if err != nil {
+		return err
+	}
+	mgr.Version = version
+	return nil
 }
 
 func (mgr *Manager) validate(taskID a2a.TaskID, contextID string) error {
@@ -111,3 +291,10 @@ func (mgr *Manager) validate(taskID a2a.TaskID, contextID string) error {
 
 	return nil
 }
+
+func (mgr *Manager) checkNotTerminal() error {
+	if state := mgr.Task.Status.State; state.Terminal() {
+		return fmt.Errorf("%w: task is %s", ErrTaskTerminal, state)
+	}
+	return nil
+}
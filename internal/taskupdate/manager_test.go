@@ -17,6 +17,7 @@ package taskupdate
 import (
 	"context"
 	"errors"
+	"maps"
 	"testing"
 
 	"github.com/a2aproject/a2a-go/a2a"
@@ -192,3 +193,273 @@ func TestManager_IDValidationFailure(t *testing.T) {
 		}
 	}
 }
+
+// versionedSaver simulates a store modified by another replica before the first save.
+type versionedSaver struct {
+	stored   *a2a.Task
+	version  TaskVersion
+	conflict func(*versionedSaver)
+	attempts int
+}
+
+func (s *versionedSaver) Save(ctx context.Context, task *a2a.Task) error {
+	s.stored = task
+	s.version++
+	return nil
+}
+
+func (s *versionedSaver) SaveVersioned(ctx context.Context, task *a2a.Task, prev TaskVersion) (TaskVersion, error) {
+	s.attempts++
+	if conflict := s.conflict; conflict != nil {
+		s.conflict = nil
+		conflict(s)
+	}
+	if prev != s.version {
+		return 0, ErrConcurrentModification
+	}
+	copy := *task
+	s.stored = &copy
+	s.version++
+	return s.version, nil
+}
+
+func (s *versionedSaver) GetVersioned(ctx context.Context, taskId a2a.TaskID) (*a2a.Task, TaskVersion, error) {
+	copy := *s.stored
+	copy.Metadata = maps.Clone(s.stored.Metadata)
+	return &copy, s.version, nil
+}
+
+func TestManager_ConcurrentModificationRetried(t *testing.T) {
+	task := newTestTask()
+	saver := &versionedSaver{stored: task, version: 1}
+	saver.conflict = func(s *versionedSaver) {
+		// another replica stored metadata which must not be lost
+		updated := *s.stored
+		updated.Metadata = map[string]any{"other": "replica"}
+		s.stored = &updated
+		s.version++
+	}
+	m := NewManager(saver, &a2a.Task{ID: task.ID, ContextID: task.ContextID})
+	m.Version = 1
+
+	event := newStatusUpdate(task)
+	event.Status.State = a2a.TaskStateWorking
+	if err := m.Process(t.Context(), event); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if saver.attempts != 2 || m.Version != 3 {
+		t.Fatalf("got %d save attempts and version %d, want 2 and 3", saver.attempts, m.Version)
+	}
+	if saver.stored.Status.State != a2a.TaskStateWorking || saver.stored.Metadata["other"] != "replica" {
+		t.Fatalf("stored task = %+v, want the event applied on top of the concurrent update", saver.stored)
+	}
+}
+
+func TestManager_ConcurrentModificationRetriesExhausted(t *testing.T) {
+	task := newTestTask()
+	saver := &versionedSaver{stored: task, version: 1}
+	var conflict func(*versionedSaver)
+	conflict = func(s *versionedSaver) {
+		s.version++
+		s.conflict = conflict
+	}
+	saver.conflict = conflict
+	m := NewManager(saver, &a2a.Task{ID: task.ID, ContextID: task.ContextID})
+
+	if err := m.Process(t.Context(), newStatusUpdate(task)); !errors.Is(err, ErrConcurrentModification) {
+		t.Fatalf("Process() error = %v, want %v", err, ErrConcurrentModification)
+	}
+	if saver.attempts != maxConflictRetries+1 {
+		t.Fatalf("got %d save attempts, want %d", saver.attempts, maxConflictRetries+1)
+	}
+}
+
+// countingSaver counts Save calls.
+func TestManager_TerminalTaskNotUpdated(t *testing.T) {
+	task := newTestTask()
+	task.Status.State = a2a.TaskStateCanceled
+	saver := &testSaver{}
+	m := NewManager(saver, task)
+
+	status := newStatusUpdate(task)
+	status.Status.State = a2a.TaskStateWorking
+	artifact := &a2a.TaskArtifactUpdateEvent{TaskID: task.ID, ContextID: task.ContextID, Artifact: &a2a.Artifact{ID: "a"}}
+	snapshot := &a2a.Task{ID: task.ID, ContextID: task.ContextID, Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+	for _, event := range []a2a.Event{status, artifact, snapshot} {
+		if err := m.Process(t.Context(), event); !errors.Is(err, ErrTaskTerminal) {
+			t.Fatalf("Process(%T) error = %v, want %v", event, err, ErrTaskTerminal)
+		}
+	}
+	if saver.saved != nil || m.Task.Status.State != a2a.TaskStateCanceled {
+		t.Fatalf("terminal task updated: saved %+v, managed %+v", saver.saved, m.Task)
+	}
+
+	amended := &a2a.Task{ID: task.ID, ContextID: task.ContextID, Status: task.Status, History: []*a2a.Message{a2a.NewMessage(a2a.MessageRoleAgent)}}
+	if err := m.Process(t.Context(), amended); err != nil {
+		t.Fatalf("Process() of a snapshot in the same state error = %v", err)
+	}
+}
+
+func TestManager_ConcurrentlyCanceledTaskNotUpdated(t *testing.T) {
+	task := newTestTask()
+	saver := &versionedSaver{stored: task, version: 1}
+	saver.conflict = func(s *versionedSaver) {
+		canceled := *s.stored
+		canceled.Status.State = a2a.TaskStateCanceled
+		s.stored = &canceled
+		s.version++
+	}
+	m := NewManager(saver, &a2a.Task{ID: task.ID, ContextID: task.ContextID})
+	m.Version = 1
+
+	event := newStatusUpdate(task)
+	event.Status.State = a2a.TaskStateWorking
+	if err := m.Process(t.Context(), event); !errors.Is(err, ErrTaskTerminal) {
+		t.Fatalf("Process() error = %v, want %v", err, ErrTaskTerminal)
+	}
+	if saver.stored.Status.State != a2a.TaskStateCanceled {
+		t.Fatalf("stored task state = %s, want %s", saver.stored.Status.State, a2a.TaskStateCanceled)
+	}
+}
+
+type countingSaver struct {
+	testSaver
+	saves int
+}
+
+func (s *countingSaver) Save(ctx context.Context, task *a2a.Task) error {
+	s.saves++
+	return s.testSaver.Save(ctx, task)
+}
+
+func TestManager_WithTransaction(t *testing.T) {
+	saver := &countingSaver{}
+	task := newTestTask()
+	m := NewManager(saver, task)
+
+	err := m.WithTransaction(t.Context(), func(ctx context.Context) error {
+		for _, state := range []a2a.TaskState{a2a.TaskStateWorking, a2a.TaskStateCompleted} {
+			event := newStatusUpdate(task)
+			event.Status.State = state
+			if err := m.Process(ctx, event); err != nil {
+				return err
+			}
+		}
+		if saver.saves != 0 {
+			t.Fatalf("Save() called %d times before commit, want 0", saver.saves)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction() error = %v", err)
+	}
+	if saver.saves != 1 || saver.saved.Status.State != a2a.TaskStateCompleted {
+		t.Fatalf("got %d saves of %+v, want a single save of the completed task", saver.saves, saver.saved)
+	}
+}
+
+func TestManager_WithTransactionRollback(t *testing.T) {
+	saver := &countingSaver{}
+	task := newTestTask()
+	task.Metadata = map[string]any{"k": "original"}
+	m := NewManager(saver, task)
+
+	fnErr := errors.New("executor failed")
+	err := m.WithTransaction(t.Context(), func(ctx context.Context) error {
+		event := newStatusUpdate(task)
+		event.Status.State = a2a.TaskStateWorking
+		event.Metadata = map[string]any{"k": "updated"}
+		if err := m.Process(ctx, event); err != nil {
+			return err
+		}
+		return fnErr
+	})
+	if !errors.Is(err, fnErr) {
+		t.Fatalf("WithTransaction() error = %v, want %v", err, fnErr)
+	}
+	if saver.saves != 0 {
+		t.Fatalf("Save() called %d times, want 0", saver.saves)
+	}
+	if m.Task.Status.State != "" || m.Task.Metadata["k"] != "original" {
+		t.Fatalf("Task = %+v, want the state before the transaction", m.Task)
+	}
+}
+
+func TestManager_WithTransactionConflict(t *testing.T) {
+	task := newTestTask()
+	saver := &versionedSaver{stored: task, version: 1}
+	saver.conflict = func(s *versionedSaver) {
+		updated := *s.stored
+		updated.Metadata = map[string]any{"other": "replica"}
+		s.stored = &updated
+		s.version++
+	}
+	m := NewManager(saver, &a2a.Task{ID: task.ID, ContextID: task.ContextID})
+	m.Version = 1
+
+	err := m.WithTransaction(t.Context(), func(ctx context.Context) error {
+		working := newStatusUpdate(task)
+		working.Status.State = a2a.TaskStateWorking
+		working.Metadata = map[string]any{"step": 1}
+		if err := m.Process(ctx, working); err != nil {
+			return err
+		}
+		completed := newStatusUpdate(task)
+		completed.Status.State = a2a.TaskStateCompleted
+		return m.Process(ctx, completed)
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction() error = %v", err)
+	}
+
+	stored := saver.stored
+	if saver.attempts != 2 || stored.Status.State != a2a.TaskStateCompleted || stored.Metadata["other"] != "replica" || stored.Metadata["step"] != 1 {
+		t.Fatalf("got %d save attempts, stored %+v, want all events reapplied on top of the concurrent update", saver.attempts, stored)
+	}
+}
+
+func TestManager_NestedTransaction(t *testing.T) {
+	m := NewManager(&testSaver{}, newTestTask())
+	err := m.WithTransaction(t.Context(), func(ctx context.Context) error {
+		return m.WithTransaction(ctx, func(ctx context.Context) error { return nil })
+	})
+	if err == nil {
+		t.Fatal("WithTransaction() error = nil, want error for a nested transaction")
+	}
+}
+
+func TestManager_ArtifactUpdates(t *testing.T) {
+	saver := &testSaver{}
+	task := newTestTask()
+	m := NewManager(saver, task)
+
+	chunk := func(id a2a.ArtifactID, text string, append bool) *a2a.TaskArtifactUpdateEvent {
+		return &a2a.TaskArtifactUpdateEvent{
+			TaskID:    task.ID,
+			ContextID: task.ContextID,
+			Append:    append,
+			Artifact:  &a2a.Artifact{ID: id, Parts: a2a.ContentParts{a2a.TextPart{Text: text}}},
+		}
+	}
+	events := []a2a.Event{
+		chunk("a1", "Hello", false),
+		chunk("a1", ", world", true),
+		chunk("a2", "draft", false),
+		chunk("a2", "final", false),
+	}
+	for _, event := range events {
+		if err := m.Process(t.Context(), event); err != nil {
+			t.Fatalf("Process() error = %v", err)
+		}
+	}
+
+	artifacts := saver.saved.Artifacts
+	if len(artifacts) != 2 || len(artifacts[0].Parts) != 2 || len(artifacts[1].Parts) != 1 || artifacts[1].Parts[0].(a2a.TextPart).Text != "final" {
+		t.Fatalf("saved artifacts = %v, want a1 with two chunks and replaced a2", artifacts)
+	}
+
+	if err := m.Process(t.Context(), chunk("missing", "x", true)); err == nil {
+		t.Fatal("Process() error = nil, want error for appending to a missing artifact")
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wirelog formats raw protocol frames for debugging with secrets redacted.
+package wirelog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Direction of a frame.
+type Direction string
+
+const (
+	Out Direction = "-->"
+	In  Direction = "<--"
+)
+
+// Redacted replaces secret values in logged frames.
+const Redacted = "[REDACTED]"
+
+// maxBodySize limits the number of body bytes logged for a single frame.
+const maxBodySize = 64 << 10
+
+// secretHeaders are header and metadata names which values are always redacted.
+var secretHeaders = []string{"authorization", "proxy-authorization", "cookie", "set-cookie", "x-api-key", "api-key"}
+
+// secretWords make a header or a JSON field secret if its name contains one of them.
+var secretWords = []string{"token", "secret", "password", "apikey", "credential", "authorization"}
+
+// Logger writes frames to an io.Writer. It is safe for concurrent use, frames are never interleaved.
+type Logger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// New creates a Logger writing to w.
+func New(w io.Writer) *Logger {
+	return &Logger{w: w}
+}
+
+// Frame writes a frame consisting of a title line, headers sorted by name and a body.
+// Secret header values and JSON body fields are redacted. Write errors are ignored.
+func (l *Logger) Frame(direction Direction, title string, headers map[string][]string, body []byte) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s\n", direction, title)
+	for _, name := range slices.Sorted(maps.Keys(headers)) {
+		for _, value := range headers[name] {
+			if IsSecretHeader(name) {
+				value = Redacted
+			}
+			fmt.Fprintf(&buf, "%s: %s\n", name, value)
+		}
+	}
+	if len(body) > 0 {
+		if len(headers) > 0 {
+			buf.WriteByte('\n')
+		}
+		writeBody(&buf, RedactJSON(body))
+	}
+	buf.WriteByte('\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.w.Write(buf.Bytes())
+}
+
+func writeBody(buf *bytes.Buffer, body []byte) {
+	truncated := 0
+	if len(body) > maxBodySize {
+		truncated = len(body) - maxBodySize
+		body = body[:maxBodySize]
+	}
+	buf.Write(body)
+	if len(body) > 0 && body[len(body)-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+	if truncated > 0 {
+		fmt.Fprintf(buf, "... (%d bytes truncated)\n", truncated)
+	}
+}
+
+// IsSecretHeader reports whether values of the HTTP header or gRPC metadata entry must not be logged.
+func IsSecretHeader(name string) bool {
+	name = strings.ToLower(name)
+	return slices.Contains(secretHeaders, name) || isSecretName(name)
+}
+
+func isSecretName(name string) bool {
+	normalized := strings.NewReplacer("-", "", "_", "", ".", "").Replace(strings.ToLower(name))
+	for _, word := range secretWords {
+		if strings.Contains(normalized, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactJSON replaces values of secret fields in a JSON document. The document is returned
+// unchanged if it is not valid JSON or doesn't contain secrets.
+func RedactJSON(data []byte) []byte {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil || dec.More() {
+		return data
+	}
+	if !redact(doc) {
+		return data
+	}
+	redacted, err := json.Marshal(doc)
+	if err != nil {
+		return data
+	}
+	return redacted
+}
+
+func redact(value any) bool {
+	changed := false
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if isSecretName(key) {
+				if _, isString := field.(string); isString || field == nil {
+					v[key] = Redacted
+					changed = true
+					continue
+				}
+			}
+			changed = redact(field) || changed
+		}
+	case []any:
+		for _, item := range v {
+			changed = redact(item) || changed
+		}
+	}
+	return changed
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The A2A Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wirelog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "no secrets", in: `{"b": 1, "a": "x"}`, want: `{"b": 1, "a": "x"}`},
+		{name: "not json", in: `data: token`, want: `data: token`},
+		{name: "nested secrets", in: `{"params":{"authentication":{"credentials":"abc"},"items":[{"api_key":"k"}]}}`,
+			want: `{"params":{"authentication":{"credentials":"[REDACTED]"},"items":[{"api_key":"[REDACTED]"}]}}`},
+		{name: "non-string values kept", in: `{"maxTokens":100}`, want: `{"maxTokens":100}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(RedactJSON([]byte(tt.in))); got != tt.want {
+				t.Fatalf("RedactJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogger_Frame(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf).Frame(Out, "POST /", map[string][]string{
+		"X-Request-Id":  {"1"},
+		"Authorization": {"Bearer secret"},
+		"X-Auth-Token":  {"secret"},
+	}, bytes.Repeat([]byte("a"), maxBodySize+10))
+
+	got := buf.String()
+	wantPrefix := "--> POST /\nAuthorization: [REDACTED]\nX-Auth-Token: [REDACTED]\nX-Request-Id: 1\n\n"
+	if !strings.HasPrefix(got, wantPrefix) {
+		t.Fatalf("Frame() = %q, want prefix %q", got[:len(wantPrefix)], wantPrefix)
+	}
+	if !strings.HasSuffix(got, "... (10 bytes truncated)\n\n") {
+		t.Fatalf("Frame() = ...%q, want the body truncated", got[len(got)-40:])
+	}
+}
@@ -0,0 +1,57 @@
+#!/bin/bash
+# Runs the in-memory queue benchmarks on a base git ref and on the working tree,
+# prints a benchstat comparison and fails if any statistically significant regression
+# exceeds the threshold.
+#
+# Ensure $GOBIN is in path and benchstat is installed:
+# > go install golang.org/x/perf/cmd/benchstat@latest
+#
+# Then run:
+# > ./tools/benchcheck.sh origin/main
+#
+# The comparison is also appended to $GITHUB_STEP_SUMMARY when running in GitHub Actions.
+
+set -euo pipefail
+
+BASE_REF="${1:?usage: $0 <base-ref> [threshold-percent]}"
+THRESHOLD="${2:-15}"
+BENCH="${BENCH:-BenchmarkInMemoryQueue}"
+PKG="./a2asrv/eventqueue"
+COUNT="${COUNT:-10}"
+
+WORK_DIR="$(mktemp -d)"
+trap 'git worktree remove --force "$WORK_DIR/base" >/dev/null 2>&1 || true; rm -rf "$WORK_DIR"' EXIT
+
+run_bench() {
+  go test -run '^$' -bench "$BENCH" -benchmem -count "$COUNT" "$PKG"
+}
+
+echo "Running benchmarks on $BASE_REF..."
+git worktree add --detach "$WORK_DIR/base" "$BASE_REF" >/dev/null
+(cd "$WORK_DIR/base" && run_bench) > "$WORK_DIR/base.txt"
+
+echo "Running benchmarks on the working tree..."
+run_bench > "$WORK_DIR/head.txt"
+
+(cd "$WORK_DIR" && benchstat base.txt head.txt) | tee "$WORK_DIR/benchstat.txt"
+
+if [[ -n "${GITHUB_STEP_SUMMARY:-}" ]]; then
+  {
+    echo "### In-memory queue benchmarks"
+    echo '```'
+    cat "$WORK_DIR/benchstat.txt"
+    echo '```'
+  } >> "$GITHUB_STEP_SUMMARY"
+fi
+
+# Significant changes are reported as "+12.34% (p=0.000 n=10)", insignificant ones as "~".
+awk -v threshold="$THRESHOLD" '
+  match($0, /\+[0-9.]+% \(p=/) {
+    delta = substr($0, RSTART + 1, RLENGTH - 6) + 0
+    if (delta > threshold) {
+      print "regression above " threshold "%: " $0
+      failed = 1
+    }
+  }
+  END { exit failed }
+' "$WORK_DIR/benchstat.txt"